@@ -17,9 +17,12 @@ package work
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/avast/retry-go/v4"
+	"github.com/google/uuid"
 	"go.uber.org/multierr"
 )
 
@@ -30,23 +33,27 @@ var (
 )
 
 type bestEffortUnit struct {
-	unit
+	*unit
 
 	successfulInserts     map[TypeName][]interface{}
 	successfulUpdates     map[TypeName][]interface{}
 	successfulDeletes     map[TypeName][]interface{}
+	successfulUpserts     map[TypeName][]interface{}
+	successfulPatches     map[TypeName][]UnitPatch
 	successfulInsertCount int
 	successfulUpdateCount int
 	successfulDeleteCount int
+	successfulUpsertCount int
+	successfulPatchCount  int
 }
 
-func (u *bestEffortUnit) rollbackInserts(ctx context.Context, mCtx UnitMapperContext) (err error) {
+func (u *bestEffortUnit) rollbackInserts(ctx context.Context, mCtx UnitMapperContext, done unitCompensations) (err error) {
 	//delete successfully inserted entities.
 	u.logger.Debug("attempting to rollback inserted entities", "count", u.successfulInsertCount)
 	for typeName, i := range u.successfulInserts {
-		if f, ok := u.deleteFunc(typeName); ok {
-			if err = f(ctx, mCtx, i...); err != nil {
-				u.logger.Error(err.Error(), "typeName", typeName.String())
+		if f, ok := u.deleteFunc(typeName); ok && !u.writesInBulk(typeName) {
+			step := unitCompensation{typeName: typeName, operation: UnitOperationInsert, by: UnitOperationDelete}
+			if err = u.compensate(ctx, mCtx, done, step, f, i, len(i)); err != nil {
 				return
 			}
 		}
@@ -54,13 +61,45 @@ func (u *bestEffortUnit) rollbackInserts(ctx context.Context, mCtx UnitMapperCon
 	return nil
 }
 
-func (u *bestEffortUnit) rollbackUpdates(ctx context.Context, mCtx UnitMapperContext) (err error) {
-	//reapply previously registered state for the entities.
+func (u *bestEffortUnit) rollbackUpserts(ctx context.Context, mCtx UnitMapperContext, done unitCompensations) (err error) {
+	//delete the upserted entities reported as inserted, and reapply the
+	//previous state of those reported as replaced. The previously registered
+	//state of registered entities is reapplied when rolling back updates.
+	u.logger.Debug("attempting to rollback upserted entities", "count", u.successfulUpsertCount)
+	for typeName, up := range u.successfulUpserts {
+		if u.writesInBulk(typeName) {
+			continue
+		}
+		inserted, previous, uncompensated := mCtx.upserted.compensations(up, u.registered)
+		if len(uncompensated) > 0 {
+			// deleting them would lose rows that existed prior to the save.
+			u.logger.Warn("upserted entities without a reported outcome cannot be rolled back",
+				"typeName", typeName.String(), "count", len(uncompensated))
+		}
+		if f, ok := u.deleteFunc(typeName); ok && len(inserted) > 0 {
+			step := unitCompensation{typeName: typeName, operation: UnitOperationUpsert, by: UnitOperationDelete}
+			if err = u.compensate(ctx, mCtx, done, step, f, inserted, len(inserted)); err != nil {
+				return
+			}
+		}
+		if f, ok := u.updateFunc(typeName); ok && len(previous) > 0 {
+			step := unitCompensation{typeName: typeName, operation: UnitOperationUpsert, by: UnitOperationUpdate}
+			if err = u.compensate(ctx, mCtx, done, step, f, previous, len(previous)); err != nil {
+				return
+			}
+		}
+	}
+	return
+}
+
+func (u *bestEffortUnit) rollbackUpdates(ctx context.Context, mCtx UnitMapperContext, done unitCompensations) (err error) {
+	//reapply previously registered state for the entities, which also
+	//compensates for any patches applied to registered entities.
 	u.logger.Debug("attempting to rollback updated entities", "count", u.successfulUpdateCount)
 	for typeName, r := range u.registered {
-		if f, ok := u.updateFunc(typeName); ok {
-			if err = f(ctx, mCtx, r...); err != nil {
-				u.logger.Error(err.Error(), "typeName", typeName.String())
+		if f, ok := u.updateFunc(typeName); ok && !u.writesInBulk(typeName) {
+			step := unitCompensation{typeName: typeName, operation: UnitOperationUpdate, by: UnitOperationUpdate}
+			if err = u.compensate(ctx, mCtx, done, step, f, r, u.reverted(typeName, r)); err != nil {
 				return
 			}
 		}
@@ -68,13 +107,13 @@ func (u *bestEffortUnit) rollbackUpdates(ctx context.Context, mCtx UnitMapperCon
 	return
 }
 
-func (u *bestEffortUnit) rollbackDeletes(ctx context.Context, mCtx UnitMapperContext) (err error) {
+func (u *bestEffortUnit) rollbackDeletes(ctx context.Context, mCtx UnitMapperContext, done unitCompensations) (err error) {
 	//reinsert successfully deleted entities.
 	u.logger.Debug("attempting to rollback deleted entities", "count", u.successfulDeleteCount)
 	for typeName, d := range u.successfulDeletes {
-		if f, ok := u.insertFunc(typeName); ok {
-			if err = f(ctx, mCtx, d...); err != nil {
-				u.logger.Error(err.Error(), "typeName", typeName.String())
+		if f, ok := u.insertFunc(typeName); ok && !u.writesInBulk(typeName) {
+			step := unitCompensation{typeName: typeName, operation: UnitOperationDelete, by: UnitOperationInsert}
+			if err = u.compensate(ctx, mCtx, done, step, f, d, len(d)); err != nil {
 				return
 			}
 		}
@@ -82,49 +121,159 @@ func (u *bestEffortUnit) rollbackDeletes(ctx context.Context, mCtx UnitMapperCon
 	return
 }
 
+// reverted provides the number of the provided registered entities of the
+// provided type that were successfully updated, patched, or upserted, whose
+// changes are reverted by reapplying their registered state.
+func (u *bestEffortUnit) reverted(t TypeName, registered []interface{}) (count int) {
+	for _, entity := range registered {
+		if indexOf(u.successfulUpdates[t], entity) >= 0 ||
+			indexOf(u.successfulUpserts[t], entity) >= 0 ||
+			u.patched(t, entity) {
+			count++
+		}
+	}
+	return
+}
+
+// patched determines if the provided entity of the provided type was
+// successfully patched.
+func (u *bestEffortUnit) patched(t TypeName, entity interface{}) bool {
+	entityID, ok := id(entity)
+	if !ok {
+		return false
+	}
+	for _, p := range u.successfulPatches[t] {
+		if cacheKey(t, p.ID) == cacheKey(t, entityID) {
+			return true
+		}
+	}
+	return false
+}
+
+// unitCompensation identifies a step performed when rolling back a work unit,
+// being the data mapper operation compensating for the changes of an
+// operation applied to entities of a type.
+type unitCompensation struct {
+	typeName  TypeName
+	operation UnitOperation
+	by        UnitOperation
+}
+
+// unitCompensations tracks the steps completed when rolling back a work unit,
+// such that retrying the rollback only performs the steps that failed.
+type unitCompensations map[unitCompensation]bool
+
+// compensate performs the provided step with the provided data mapper
+// function, unless the step was completed by a prior attempt to roll back,
+// counting the provided number of entities as compensated for.
+func (u *bestEffortUnit) compensate(
+	ctx context.Context,
+	mCtx UnitMapperContext,
+	done unitCompensations,
+	step unitCompensation,
+	f UnitDataMapperFunc,
+	entities []interface{},
+	count int,
+) (err error) {
+	if done[step] {
+		return
+	}
+	err = f(ctx, mCtx, entities...)
+	u.compensated(step.typeName, step.operation, count, err)
+	if err != nil {
+		u.logger.Error(err.Error(), "typeName", step.typeName.String())
+		return
+	}
+	done[step] = true
+	return
+}
+
+// compensated counts the provided number of entities of the provided type
+// whose changes for the provided operation were compensated for, or could
+// not be when the provided error is not nil.
+func (u *bestEffortUnit) compensated(t TypeName, operation UnitOperation, count int, err error) {
+	name := u.metrics.CompensationSuccess
+	if err != nil {
+		name = u.metrics.CompensationFailure
+	}
+	tags := map[string]string{"type_name": t.String(), "operation": string(operation)}
+	u.scope.tagged(tags).counter(name, int64(count))
+}
+
 func (u *bestEffortUnit) rollback(ctx context.Context, mCtx UnitMapperContext) (err error) {
-	//setup timer.
-	stop := u.scope.Timer(rollback).Start().Stop
+	mCtx.compensate = true
+
+	//setup timer and span.
+	stop := u.scope.start(u.metrics.Rollback)
+	ctx, end := u.startSpan(ctx, UnitSpanRollback)
 
 	//log and capture metrics if there is a panic.
 	defer func() {
 		stop()
+		end(err)
 		if r := recover(); r != nil {
 			msg := "panic: unable to rollback work unit"
 			u.logger.Error(msg, "panic", fmt.Sprintf("%v", r))
-			u.scope.Counter(rollbackFailure).Inc(1)
+			u.scope.counter(u.metrics.RollbackFailure, 1)
 			panic(r)
 		}
 
 		if err != nil {
-			u.scope.Counter(rollbackFailure).Inc(1)
+			u.scope.counter(u.metrics.RollbackFailure, 1)
 		} else {
-			u.scope.Counter(rollbackSuccess).Inc(1)
+			u.scope.counter(u.metrics.RollbackSuccess, 1)
 		}
 	}()
 
-	if err = u.rollbackDeletes(ctx, mCtx); err != nil {
-		return
-	}
-
-	if err = u.rollbackUpdates(ctx, mCtx); err != nil {
-		return
-	}
+	done := make(unitCompensations)
+	options := append([]retry.Option{retry.Context(ctx)}, u.rollbackRetryOptions...)
+	err = retry.Do(func() error {
+		if u.appendOnly {
+			// entities inserted by append-only work units are retained.
+			return nil
+		}
+		if u.bulkWriter != nil && !done[bulkCompensation] {
+			if err := u.rollbackBulk(ctx, mCtx); err != nil {
+				return err
+			}
+			done[bulkCompensation] = true
+		}
+		if err := u.rollbackDeletes(ctx, mCtx, done); err != nil {
+			return err
+		}
+		if err := u.rollbackUpdates(ctx, mCtx, done); err != nil {
+			return err
+		}
+		if err := u.rollbackUpserts(ctx, mCtx, done); err != nil {
+			return err
+		}
+		return u.rollbackInserts(ctx, mCtx, done)
+	}, options...)
+	return
+}
 
-	if err = u.rollbackInserts(ctx, mCtx); err != nil {
-		return
+// abort rolls back the work unit due to the provided error.
+func (u *bestEffortUnit) abort(ctx context.Context, mCtx UnitMapperContext, err error) error {
+	u.executeActions(ctx, UnitActionTypeBeforeRollback)
+	errRollback := u.rollback(ctx, mCtx)
+	if errRollback == nil {
+		u.executeRollbackActions(ctx, err)
 	}
-	return
+	err = multierr.Combine(err, errRollback)
+	u.logger.Error(err.Error())
+	return err
 }
 
 func (u *bestEffortUnit) applyInserts(ctx context.Context, mCtx UnitMapperContext) (err error) {
 	for typeName, additions := range u.additions {
-		if f, ok := u.insertFunc(typeName); ok {
-			if err = f(ctx, mCtx, additions...); err != nil {
-				u.executeActions(UnitActionTypeBeforeRollback)
+		if f, ok := u.insertFunc(typeName); ok && !u.writesInBulk(typeName) {
+			var applied []interface{}
+			applied, err = u.apply(ctx, mCtx, typeName, f, isolated, additions)
+			if err = operationErr(typeName, UnitOperationInsert, err); err != nil {
+				u.executeActions(ctx, UnitActionTypeBeforeRollback)
 				errRollback := u.rollback(ctx, mCtx)
 				if errRollback == nil {
-					u.executeActions(UnitActionTypeAfterRollback)
+					u.executeRollbackActions(ctx, err)
 				}
 				err = multierr.Combine(err, errRollback)
 				u.logger.Error(err.Error(), "typeName", typeName.String())
@@ -134,8 +283,34 @@ func (u *bestEffortUnit) applyInserts(ctx context.Context, mCtx UnitMapperContex
 				u.successfulInserts[typeName] = []interface{}{}
 			}
 			u.successfulInserts[typeName] =
-				append(u.successfulInserts[typeName], additions...)
-			u.successfulInsertCount = u.successfulInsertCount + len(additions)
+				append(u.successfulInserts[typeName], applied...)
+			u.successfulInsertCount = u.successfulInsertCount + len(applied)
+		}
+	}
+	return
+}
+
+func (u *bestEffortUnit) applyUpserts(ctx context.Context, mCtx UnitMapperContext) (err error) {
+	for typeName, upserts := range u.upserts {
+		if f, ok := u.upsertFunc(typeName); ok && !u.writesInBulk(typeName) {
+			var applied []interface{}
+			applied, err = u.apply(ctx, mCtx, typeName, f, isolated, upserts)
+			if err = operationErr(typeName, UnitOperationUpsert, err); err != nil {
+				u.executeActions(ctx, UnitActionTypeBeforeRollback)
+				errRollback := u.rollback(ctx, mCtx)
+				if errRollback == nil {
+					u.executeRollbackActions(ctx, err)
+				}
+				err = multierr.Combine(err, errRollback)
+				u.logger.Error(err.Error(), "typeName", typeName.String())
+				return
+			}
+			if _, ok := u.successfulUpserts[typeName]; !ok {
+				u.successfulUpserts[typeName] = []interface{}{}
+			}
+			u.successfulUpserts[typeName] =
+				append(u.successfulUpserts[typeName], applied...)
+			u.successfulUpsertCount = u.successfulUpsertCount + len(applied)
 		}
 	}
 	return
@@ -143,12 +318,14 @@ func (u *bestEffortUnit) applyInserts(ctx context.Context, mCtx UnitMapperContex
 
 func (u *bestEffortUnit) applyUpdates(ctx context.Context, mCtx UnitMapperContext) (err error) {
 	for typeName, alterations := range u.alterations {
-		if f, ok := u.updateFunc(typeName); ok {
-			if err = f(ctx, mCtx, alterations...); err != nil {
-				u.executeActions(UnitActionTypeBeforeRollback)
+		if f, ok := u.updateFunc(typeName); ok && !u.writesInBulk(typeName) {
+			var applied []interface{}
+			applied, err = u.apply(ctx, mCtx, typeName, f, isolated, alterations)
+			if err = operationErr(typeName, UnitOperationUpdate, err); err != nil {
+				u.executeActions(ctx, UnitActionTypeBeforeRollback)
 				errRollback := u.rollback(ctx, mCtx)
 				if errRollback == nil {
-					u.executeActions(UnitActionTypeAfterRollback)
+					u.executeRollbackActions(ctx, err)
 				}
 				err = multierr.Combine(err, errRollback)
 				u.logger.Error(err.Error(), "typeName", typeName.String())
@@ -158,8 +335,27 @@ func (u *bestEffortUnit) applyUpdates(ctx context.Context, mCtx UnitMapperContex
 				u.successfulUpdates[typeName] = []interface{}{}
 			}
 			u.successfulUpdates[typeName] =
-				append(u.successfulUpdates[typeName], alterations...)
-			u.successfulUpdateCount = u.successfulUpdateCount + len(alterations)
+				append(u.successfulUpdates[typeName], applied...)
+			u.successfulUpdateCount = u.successfulUpdateCount + len(applied)
+		}
+	}
+	return
+}
+
+func (u *bestEffortUnit) applyPatches(ctx context.Context, mCtx UnitMapperContext) (err error) {
+	for typeName, patches := range u.patches {
+		if f, ok := u.patchFunc(typeName); ok && !u.writesInBulk(typeName) {
+			if err = operationErr(typeName, UnitOperationPatch, f(ctx, mCtx, patches...)); err != nil {
+				u.executeActions(ctx, UnitActionTypeBeforeRollback)
+				errRollback := u.rollback(ctx, mCtx)
+				if errRollback == nil {
+					u.executeRollbackActions(ctx, err)
+				}
+				err = multierr.Combine(err, errRollback)
+				u.logger.Error(err.Error(), "typeName", typeName.String())
+				return
+			}
+			u.successfulPatches[typeName] = append(u.successfulPatches[typeName], patches...)
 		}
 	}
 	return
@@ -167,12 +363,14 @@ func (u *bestEffortUnit) applyUpdates(ctx context.Context, mCtx UnitMapperContex
 
 func (u *bestEffortUnit) applyDeletes(ctx context.Context, mCtx UnitMapperContext) (err error) {
 	for typeName, removals := range u.removals {
-		if f, ok := u.deleteFunc(typeName); ok {
-			if err = f(ctx, mCtx, removals...); err != nil {
-				u.executeActions(UnitActionTypeBeforeRollback)
+		if f, ok := u.deleteFunc(typeName); ok && !u.writesInBulk(typeName) {
+			var applied []interface{}
+			applied, err = u.apply(ctx, mCtx, typeName, f, isolated, removals)
+			if err = operationErr(typeName, UnitOperationDelete, err); err != nil {
+				u.executeActions(ctx, UnitActionTypeBeforeRollback)
 				errRollback := u.rollback(ctx, mCtx)
 				if errRollback == nil {
-					u.executeActions(UnitActionTypeAfterRollback)
+					u.executeRollbackActions(ctx, err)
 				}
 				err = multierr.Combine(err, errRollback)
 				u.logger.Error(err.Error(), "typeName", typeName.String())
@@ -182,8 +380,30 @@ func (u *bestEffortUnit) applyDeletes(ctx context.Context, mCtx UnitMapperContex
 				u.successfulDeletes[typeName] = []interface{}{}
 			}
 			u.successfulDeletes[typeName] =
-				append(u.successfulDeletes[typeName], removals...)
-			u.successfulDeleteCount = u.successfulDeleteCount + len(removals)
+				append(u.successfulDeletes[typeName], applied...)
+			u.successfulDeleteCount = u.successfulDeleteCount + len(applied)
+		}
+	}
+	return
+}
+
+func (u *bestEffortUnit) applyDeletesWhere(ctx context.Context, mCtx UnitMapperContext) (err error) {
+	for typeName, criteria := range u.removalCriteria {
+		if f, ok := u.deleteWhereFunc(typeName); ok {
+			if err = operationErr(typeName, UnitOperationDeleteWhere, f(ctx, mCtx, criteria...)); err != nil {
+				u.executeActions(ctx, UnitActionTypeBeforeRollback)
+				errRollback := u.rollback(ctx, mCtx)
+				if errRollback == nil {
+					u.executeRollbackActions(ctx, err)
+				}
+				err = multierr.Combine(err, errRollback)
+				u.logger.Error(err.Error(), "typeName", typeName.String())
+				return
+			}
+			// the entities removed are unknown, so they cannot be reinserted
+			// should a rollback occur.
+			u.logger.Warn("entities removed by criteria cannot be rolled back",
+				"typeName", typeName.String(), "count", len(criteria))
 		}
 	}
 	return
@@ -193,76 +413,197 @@ func (u *bestEffortUnit) resetSuccesses() {
 	u.successfulInserts = make(map[TypeName][]interface{})
 	u.successfulUpdates = make(map[TypeName][]interface{})
 	u.successfulDeletes = make(map[TypeName][]interface{})
+	u.successfulUpserts = make(map[TypeName][]interface{})
+	u.successfulPatches = make(map[TypeName][]UnitPatch)
 }
 
 func (u *bestEffortUnit) resetSuccessCounts() {
 	u.successfulInsertCount = 0
 	u.successfulUpdateCount = 0
 	u.successfulDeleteCount = 0
+	u.successfulUpsertCount = 0
+	u.successfulPatchCount = 0
 }
 
-func (u *bestEffortUnit) save(ctx context.Context) (err error) {
+func (u *bestEffortUnit) save(ctx context.Context, mCtx UnitMapperContext) (err error) {
+	u.timings.attempt()
+
+	//verify uniqueness before any writes occur.
+	if err = u.checkUniqueness(ctx, mCtx, u.additions, u.upserts, u.alterations); err != nil {
+		return retry.Unrecoverable(u.abort(ctx, mCtx, err))
+	}
+
+	//write the entities written in bulk.
+	if u.bulkWriter != nil {
+		if err = u.timed(UnitSavePhaseBulk, func() error { return u.applyBulk(ctx, mCtx) }); err != nil {
+			return
+		}
+	}
+
 	//insert newly added entities.
-	u.executeActions(UnitActionTypeBeforeInserts)
-	if err = u.applyInserts(ctx, UnitMapperContext{}); err != nil {
+	if err = u.executeActions(ctx, UnitActionTypeBeforeInserts); err != nil {
+		return retry.Unrecoverable(u.abort(ctx, mCtx, err))
+	}
+	if err = u.timed(UnitSavePhaseInserts, func() error { return u.applyInserts(ctx, mCtx) }); err != nil {
 		return
 	}
-	u.executeActions(UnitActionTypeAfterInserts)
+	u.executeActions(ctx, UnitActionTypeAfterInserts)
+
+	//upsert upserted entities.
+	if err = u.executeActions(ctx, UnitActionTypeBeforeUpserts); err != nil {
+		return retry.Unrecoverable(u.abort(ctx, mCtx, err))
+	}
+	if err = u.timed(UnitSavePhaseUpserts, func() error { return u.applyUpserts(ctx, mCtx) }); err != nil {
+		return
+	}
+	u.executeActions(ctx, UnitActionTypeAfterUpserts)
 
 	//update altered entities.
-	u.executeActions(UnitActionTypeBeforeUpdates)
-	if err = u.applyUpdates(ctx, UnitMapperContext{}); err != nil {
+	if err = u.executeActions(ctx, UnitActionTypeBeforeUpdates); err != nil {
+		return retry.Unrecoverable(u.abort(ctx, mCtx, err))
+	}
+	err = u.timed(UnitSavePhaseUpdates, func() error {
+		if err := u.applyUpdates(ctx, mCtx); err != nil {
+			return err
+		}
+		return u.applyPatches(ctx, mCtx)
+	})
+	if err != nil {
 		return
 	}
-	u.executeActions(UnitActionTypeAfterUpdates)
+	u.executeActions(ctx, UnitActionTypeAfterUpdates)
 
 	//delete removed entities.
-	u.executeActions(UnitActionTypeBeforeDeletes)
-	if err = u.applyDeletes(ctx, UnitMapperContext{}); err != nil {
+	if err = u.executeActions(ctx, UnitActionTypeBeforeDeletes); err != nil {
+		return retry.Unrecoverable(u.abort(ctx, mCtx, err))
+	}
+	err = u.timed(UnitSavePhaseDeletes, func() error {
+		if err := u.applyDeletes(ctx, mCtx); err != nil {
+			return err
+		}
+		return u.applyDeletesWhere(ctx, mCtx)
+	})
+	if err != nil {
 		return
 	}
-	u.executeActions(UnitActionTypeAfterDeletes)
+	u.executeActions(ctx, UnitActionTypeAfterDeletes)
 	return
 }
 
 // Save commits the new additions, modifications, and removals
 // within the work unit to a persistent store.
-func (u *bestEffortUnit) Save(ctx context.Context) (err error) {
-	u.executeActions(UnitActionTypeBeforeSave)
+func (u *bestEffortUnit) Save(ctx context.Context, opts ...UnitSaveOption) (err error) {
+	o := newUnitSaveOptions(opts)
+	ctx, end := u.traceSave(ctx)
+	defer func() { end(err) }()
+	return u.withSaveMiddleware(func(ctx context.Context) error {
+		return u.saveAll(ctx, o)
+	})(ctx)
+}
+
+// saveAll performs the save pipeline of the work unit.
+func (u *bestEffortUnit) saveAll(ctx context.Context, opts unitSaveOptions) (err error) {
+	u.checkGoroutine("Save")
+	defer u.beginSaving()()
+	if err = u.failed(); err != nil {
+		u.logger.Error(err.Error())
+		return
+	}
+	if err = u.terminalErr(); err != nil {
+		return
+	}
+	defer func() {
+		if err != nil && !errors.Is(err, ErrConfirmationRequired) {
+			u.transition(UnitStatusFailed)
+		}
+	}()
+	if err = u.executeActions(ctx, UnitActionTypeBeforeSave); err != nil {
+		return
+	}
+	if err = u.authorize(ctx); err != nil {
+		return
+	}
+	if err = u.checkBoundaries(); err != nil {
+		return
+	}
+	if err = u.verifyLockTokens(ctx); err != nil {
+		return
+	}
+	if err = u.guardMutations(opts.confirmation); err != nil {
+		return
+	}
 
 	//setup timer.
-	stop := u.scope.Timer(save).Start().Stop
+	stop := u.scope.start(u.metrics.Save)
+	start := time.Now()
+	u.timings.reset()
+	u.history.reset()
+	mCtx := UnitMapperContext{SaveID: uuid.NewString()}
+	u.resetQuarantined(0)
 
 	//rollback if there is a panic.
 	defer func() {
 		stop()
+		defer func() {
+			u.detectSlowSave(mCtx.SaveID, time.Since(start), err)
+			u.executeSaveActions(ctx, time.Since(start), err)
+		}()
 		if r := recover(); r != nil {
-			u.executeActions(UnitActionTypeBeforeRollback)
-			if err = u.rollback(ctx, UnitMapperContext{}); err == nil {
-				u.executeActions(UnitActionTypeAfterRollback)
+			u.executeActions(ctx, UnitActionTypeBeforeRollback)
+			cause := fmt.Errorf("panic: unable to save work unit\n%v", r)
+			if err = u.rollback(ctx, mCtx); err == nil {
+				u.executeRollbackActions(ctx, cause)
 			}
-			err = multierr.Combine(
-				fmt.Errorf("panic: unable to save work unit\n%v", r), err)
+			err = multierr.Combine(cause, err)
 			u.logger.Error("panic: unable to save work unit", "panic", fmt.Sprintf("%v", r))
 			panic(r)
 		}
 		if err == nil {
-			u.scope.Counter(saveSuccess).Inc(1)
-			u.scope.Counter(insert).Inc(int64(u.additionCount))
-			u.scope.Counter(update).Inc(int64(u.alterationCount))
-			u.scope.Counter(delete).Inc(int64(u.removalCount))
-			u.executeActions(UnitActionTypeAfterSave)
+			u.transition(UnitStatusCompleted)
+			u.scope.counter(u.metrics.SaveSuccess, 1)
+			u.scope.counter(u.metrics.Insert, int64(u.additionCount))
+			u.scope.counter(u.metrics.Upsert, int64(u.upsertCount))
+			u.scope.counter(u.metrics.Update, int64(u.alterationCount))
+			u.scope.counter(u.metrics.Delete, int64(u.removalCount))
+			u.scope.counter(u.metrics.Patch, int64(u.patchCount))
+			u.scope.counter(u.metrics.DeleteWhere, int64(u.criteriaCount))
+			u.applyGeneratedIDs(ctx, mCtx.generated)
+			u.refresh(ctx, mCtx)
+			u.advanceLockTokens(ctx)
+			u.emitChangeRecords(ctx, mCtx.SaveID)
+			u.persistQuarantined(ctx)
+			u.executeActions(ctx, UnitActionTypeAfterSave)
+		} else {
+			u.resetQuarantined(0)
 		}
 	}()
 
-	onRetry :=
-		retry.OnRetry(func(attempt uint, err error) {
-			u.resetSuccesses()
-			u.resetSuccessCounts()
-			u.logger.Warn("attempted retry", "attempt", int(attempt+1), "error", err.Error())
-			u.scope.Counter(retryAttempt).Inc(1)
-		})
+	onRetry := u.onRetry(ctx, mCtx.SaveID, func() {
+		u.resetSuccesses()
+		u.resetSuccessCounts()
+		u.resetQuarantined(0)
+	})
 	u.retryOptions = append(u.retryOptions, retry.Context(ctx), onRetry)
-	err = retry.Do(func() error { return u.save(ctx) }, u.retryOptions...)
+	next := u.attempts(mCtx.SaveID)
+	err = retry.Do(func() error {
+		mCtx = next()
+		mCtx.generated = &unitGeneratedIDs{}
+		mCtx.upserted = &unitUpsertOutcomes{}
+		return u.history.record(mCtx, u.save(ctx, mCtx))
+	}, u.retryOptions...)
+	err = u.history.wrap(err)
 	return
 }
+
+func (u *bestEffortUnit) SaveBackground() error {
+	return u.Save(context.Background())
+}
+
+// SaveAsync commits the new additions, modifications, and removals within
+// the work unit in a new goroutine, providing the future for the outcome of
+// the save.
+func (u *bestEffortUnit) SaveAsync(ctx context.Context) *UnitSaveFuture {
+	return NewUnitSaveFuture(ctx, func(ctx context.Context) error {
+		return u.Save(ctx)
+	})
+}
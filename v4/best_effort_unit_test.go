@@ -19,6 +19,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/freerware/work/v4"
@@ -38,6 +39,7 @@ type BestEffortUnitTestSuite struct {
 
 	// mocks.
 	mappers map[work.TypeName]*mock.UnitDataMapper
+	opts    []work.UnitOption
 	scope   tally.TestScope
 	mc      *gomock.Controller
 
@@ -56,6 +58,14 @@ type BestEffortUnitTestSuite struct {
 	retryAttemptScopeName            string
 	retryAttemptScopeNameWithTags    string
 	insertScopeName                  string
+	patchScopeName                   string
+	patchScopeNameWithTags           string
+	deleteWhereScopeName             string
+	rollbackRetryScopeNameWithTags   string
+	quarantineScopeNameWithTags      string
+	deleteWhereScopeNameWithTags     string
+	upsertScopeName                  string
+	upsertScopeNameWithTags          string
 	insertScopeNameWithTags          string
 	updateScopeName                  string
 	updateScopeNameWithTags          string
@@ -97,8 +107,16 @@ func (s *BestEffortUnitTestSuite) Setup() {
 	s.rollbackFailureScopeNameWithTags = fmt.Sprintf("%s%s%s", s.rollbackFailureScopeName, sep, s.tags)
 	s.retryAttemptScopeName = fmt.Sprintf("%s.%s", s.scopePrefix, "unit.retry.attempt")
 	s.retryAttemptScopeNameWithTags = fmt.Sprintf("%s%s%s", s.retryAttemptScopeName, sep, s.tags)
+	s.rollbackRetryScopeNameWithTags = fmt.Sprintf("%s.retry%s%s", s.rollbackScopeName, sep, s.tags)
+	s.quarantineScopeNameWithTags = fmt.Sprintf("%s.%s%s%s", s.scopePrefix, "unit.quarantine", sep, s.tags)
 	s.insertScopeName = fmt.Sprintf("%s.%s", s.scopePrefix, "unit.insert")
 	s.insertScopeNameWithTags = fmt.Sprintf("%s%s%s", s.insertScopeName, sep, s.tags)
+	s.upsertScopeName = fmt.Sprintf("%s.%s", s.scopePrefix, "unit.upsert")
+	s.upsertScopeNameWithTags = fmt.Sprintf("%s%s%s", s.upsertScopeName, sep, s.tags)
+	s.patchScopeName = fmt.Sprintf("%s.%s", s.scopePrefix, "unit.patch")
+	s.patchScopeNameWithTags = fmt.Sprintf("%s%s%s", s.patchScopeName, sep, s.tags)
+	s.deleteWhereScopeName = fmt.Sprintf("%s.%s", s.scopePrefix, "unit.delete.where")
+	s.deleteWhereScopeNameWithTags = fmt.Sprintf("%s%s%s", s.deleteWhereScopeName, sep, s.tags)
 	s.updateScopeName = fmt.Sprintf("%s.%s", s.scopePrefix, "unit.update")
 	s.updateScopeNameWithTags = fmt.Sprintf("%s%s%s", s.updateScopeName, sep, s.tags)
 	s.deleteScopeName = fmt.Sprintf("%s.%s", s.scopePrefix, "unit.delete")
@@ -133,13 +151,13 @@ func (s *BestEffortUnitTestSuite) Setup() {
 	s.retryCount = 2
 	s.scope = ts
 	var err error
-	opts := []work.UnitOption{
+	s.opts = []work.UnitOption{
 		work.UnitDataMappers(dm),
 		work.UnitWithZapLogger(l),
 		work.UnitTallyMetricScope(ts),
 		work.UnitRetryAttempts(s.retryCount),
 	}
-	s.sut, err = work.NewUnit(opts...)
+	s.sut, err = work.NewUnit(s.opts...)
 	s.Require().NoError(err)
 }
 
@@ -193,12 +211,12 @@ func (s *BestEffortUnitTestSuite) subtests() []TableDrivenTest {
 			ctx: context.Background(),
 			err: errors.New("whoa"),
 			assertions: func() {
-				s.Len(s.scope.Snapshot().Counters(), 4)
+				s.Len(s.unitCounters(), 4)
 				s.Contains(s.scope.Snapshot().Counters(), s.rollbackSuccessScopeNameWithTags)
 				s.Contains(s.scope.Snapshot().Counters(), s.retryAttemptScopeNameWithTags)
 				s.Contains(s.scope.Snapshot().Counters(), s.cacheInsertScopeNameWithTags)
 				s.Contains(s.scope.Snapshot().Counters(), s.cacheDeleteScopeNameWithTags)
-				s.Len(s.scope.Snapshot().Timers(), 2)
+				s.Len(s.unitTimers(), 2)
 				s.Contains(s.scope.Snapshot().Timers(), s.saveScopeNameWithTags)
 				s.Contains(s.scope.Snapshot().Timers(), s.rollbackScopeNameWithTags)
 			},
@@ -242,12 +260,12 @@ func (s *BestEffortUnitTestSuite) subtests() []TableDrivenTest {
 			ctx: context.Background(),
 			err: errors.New("ouch; whoa"),
 			assertions: func() {
-				s.Len(s.scope.Snapshot().Counters(), 4)
+				s.Len(s.unitCounters(), 4)
 				s.Contains(s.scope.Snapshot().Counters(), s.rollbackFailureScopeNameWithTags)
 				s.Contains(s.scope.Snapshot().Counters(), s.retryAttemptScopeNameWithTags)
 				s.Contains(s.scope.Snapshot().Counters(), s.cacheInsertScopeNameWithTags)
 				s.Contains(s.scope.Snapshot().Counters(), s.cacheDeleteScopeNameWithTags)
-				s.Len(s.scope.Snapshot().Timers(), 2)
+				s.Len(s.unitTimers(), 2)
 				s.Contains(s.scope.Snapshot().Timers(), s.saveScopeNameWithTags)
 				s.Contains(s.scope.Snapshot().Timers(), s.rollbackScopeNameWithTags)
 			},
@@ -309,12 +327,12 @@ func (s *BestEffortUnitTestSuite) subtests() []TableDrivenTest {
 			ctx: context.Background(),
 			err: errors.New("whoa"),
 			assertions: func() {
-				s.Len(s.scope.Snapshot().Counters(), 4)
+				s.Len(s.unitCounters(), 4)
 				s.Contains(s.scope.Snapshot().Counters(), s.rollbackSuccessScopeNameWithTags)
 				s.Contains(s.scope.Snapshot().Counters(), s.retryAttemptScopeNameWithTags)
 				s.Contains(s.scope.Snapshot().Counters(), s.cacheInsertScopeNameWithTags)
 				s.Contains(s.scope.Snapshot().Counters(), s.cacheDeleteScopeNameWithTags)
-				s.Len(s.scope.Snapshot().Timers(), 2)
+				s.Len(s.unitTimers(), 2)
 				s.Contains(s.scope.Snapshot().Timers(), s.saveScopeNameWithTags)
 				s.Contains(s.scope.Snapshot().Timers(), s.rollbackScopeNameWithTags)
 			},
@@ -372,12 +390,12 @@ func (s *BestEffortUnitTestSuite) subtests() []TableDrivenTest {
 			ctx: context.Background(),
 			err: errors.New("ouch; whoa"),
 			assertions: func() {
-				s.Len(s.scope.Snapshot().Counters(), 4)
+				s.Len(s.unitCounters(), 4)
 				s.Contains(s.scope.Snapshot().Counters(), s.rollbackFailureScopeNameWithTags)
 				s.Contains(s.scope.Snapshot().Counters(), s.retryAttemptScopeNameWithTags)
 				s.Contains(s.scope.Snapshot().Counters(), s.cacheInsertScopeNameWithTags)
 				s.Contains(s.scope.Snapshot().Counters(), s.cacheDeleteScopeNameWithTags)
-				s.Len(s.scope.Snapshot().Timers(), 2)
+				s.Len(s.unitTimers(), 2)
 				s.Contains(s.scope.Snapshot().Timers(), s.saveScopeNameWithTags)
 				s.Contains(s.scope.Snapshot().Timers(), s.rollbackScopeNameWithTags)
 			},
@@ -447,12 +465,12 @@ func (s *BestEffortUnitTestSuite) subtests() []TableDrivenTest {
 			ctx: context.Background(),
 			err: errors.New("whoa"),
 			assertions: func() {
-				s.Len(s.scope.Snapshot().Counters(), 4)
+				s.Len(s.unitCounters(), 4)
 				s.Contains(s.scope.Snapshot().Counters(), s.rollbackSuccessScopeNameWithTags)
 				s.Contains(s.scope.Snapshot().Counters(), s.retryAttemptScopeNameWithTags)
 				s.Contains(s.scope.Snapshot().Counters(), s.cacheInsertScopeNameWithTags)
 				s.Contains(s.scope.Snapshot().Counters(), s.cacheDeleteScopeNameWithTags)
-				s.Len(s.scope.Snapshot().Timers(), 2)
+				s.Len(s.unitTimers(), 2)
 				s.Contains(s.scope.Snapshot().Timers(), s.saveScopeNameWithTags)
 				s.Contains(s.scope.Snapshot().Timers(), s.rollbackScopeNameWithTags)
 			},
@@ -564,12 +582,12 @@ func (s *BestEffortUnitTestSuite) subtests() []TableDrivenTest {
 			ctx: context.Background(),
 			err: errors.New("whoa; ouch"),
 			assertions: func() {
-				s.Len(s.scope.Snapshot().Counters(), 4)
+				s.Len(s.unitCounters(), 4)
 				s.Contains(s.scope.Snapshot().Counters(), s.rollbackFailureScopeNameWithTags)
 				s.Contains(s.scope.Snapshot().Counters(), s.retryAttemptScopeNameWithTags)
 				s.Contains(s.scope.Snapshot().Counters(), s.cacheInsertScopeNameWithTags)
 				s.Contains(s.scope.Snapshot().Counters(), s.cacheDeleteScopeNameWithTags)
-				s.Len(s.scope.Snapshot().Timers(), 2)
+				s.Len(s.unitTimers(), 2)
 				s.Contains(s.scope.Snapshot().Timers(), s.saveScopeNameWithTags)
 				s.Contains(s.scope.Snapshot().Timers(), s.rollbackScopeNameWithTags)
 			},
@@ -650,11 +668,11 @@ func (s *BestEffortUnitTestSuite) subtests() []TableDrivenTest {
 			},
 			ctx: context.Background(),
 			assertions: func() {
-				s.Len(s.scope.Snapshot().Counters(), 3)
+				s.Len(s.unitCounters(), 3)
 				s.Contains(s.scope.Snapshot().Counters(), s.rollbackSuccessScopeNameWithTags)
 				s.Contains(s.scope.Snapshot().Counters(), s.cacheInsertScopeNameWithTags)
 				s.Contains(s.scope.Snapshot().Counters(), s.cacheDeleteScopeNameWithTags)
-				s.Len(s.scope.Snapshot().Timers(), 2)
+				s.Len(s.unitTimers(), 2)
 				s.Contains(s.scope.Snapshot().Timers(), s.saveScopeNameWithTags)
 				s.Contains(s.scope.Snapshot().Timers(), s.rollbackScopeNameWithTags)
 			},
@@ -724,11 +742,11 @@ func (s *BestEffortUnitTestSuite) subtests() []TableDrivenTest {
 			ctx: context.Background(),
 			err: errors.New("whoa"),
 			assertions: func() {
-				s.Len(s.scope.Snapshot().Counters(), 3)
+				s.Len(s.unitCounters(), 3)
 				s.Contains(s.scope.Snapshot().Counters(), s.rollbackFailureScopeNameWithTags)
 				s.Contains(s.scope.Snapshot().Counters(), s.cacheInsertScopeNameWithTags)
 				s.Contains(s.scope.Snapshot().Counters(), s.cacheDeleteScopeNameWithTags)
-				s.Len(s.scope.Snapshot().Timers(), 2)
+				s.Len(s.unitTimers(), 2)
 				s.Contains(s.scope.Snapshot().Timers(), s.saveScopeNameWithTags)
 				s.Contains(s.scope.Snapshot().Timers(), s.rollbackScopeNameWithTags)
 			},
@@ -804,11 +822,11 @@ func (s *BestEffortUnitTestSuite) subtests() []TableDrivenTest {
 			ctx: context.Background(),
 			err: errors.New("whoa"),
 			assertions: func() {
-				s.Len(s.scope.Snapshot().Counters(), 3)
+				s.Len(s.unitCounters(), 3)
 				s.Contains(s.scope.Snapshot().Counters(), s.rollbackFailureScopeNameWithTags)
 				s.Contains(s.scope.Snapshot().Counters(), s.cacheInsertScopeNameWithTags)
 				s.Contains(s.scope.Snapshot().Counters(), s.cacheDeleteScopeNameWithTags)
-				s.Len(s.scope.Snapshot().Timers(), 2)
+				s.Len(s.unitTimers(), 2)
 				s.Contains(s.scope.Snapshot().Timers(), s.saveScopeNameWithTags)
 				s.Contains(s.scope.Snapshot().Timers(), s.rollbackScopeNameWithTags)
 			},
@@ -855,14 +873,17 @@ func (s *BestEffortUnitTestSuite) subtests() []TableDrivenTest {
 			},
 			ctx: context.Background(),
 			assertions: func() {
-				s.Len(s.scope.Snapshot().Counters(), 6)
+				s.Len(s.unitCounters(), 9)
 				s.Contains(s.scope.Snapshot().Counters(), s.saveSuccessScopeNameWithTags)
 				s.Contains(s.scope.Snapshot().Counters(), s.insertScopeNameWithTags)
+				s.Contains(s.scope.Snapshot().Counters(), s.upsertScopeNameWithTags)
+				s.Contains(s.scope.Snapshot().Counters(), s.patchScopeNameWithTags)
+				s.Contains(s.scope.Snapshot().Counters(), s.deleteWhereScopeNameWithTags)
 				s.Contains(s.scope.Snapshot().Counters(), s.updateScopeNameWithTags)
 				s.Contains(s.scope.Snapshot().Counters(), s.deleteScopeNameWithTags)
 				s.Contains(s.scope.Snapshot().Counters(), s.cacheInsertScopeNameWithTags)
 				s.Contains(s.scope.Snapshot().Counters(), s.cacheDeleteScopeNameWithTags)
-				s.Len(s.scope.Snapshot().Timers(), 1)
+				s.Len(s.unitTimers(), 1)
 				s.Contains(s.scope.Snapshot().Timers(), s.saveScopeNameWithTags)
 			},
 		},
@@ -959,15 +980,18 @@ func (s *BestEffortUnitTestSuite) subtests() []TableDrivenTest {
 			},
 			ctx: context.Background(),
 			assertions: func() {
-				s.Len(s.scope.Snapshot().Counters(), 8)
+				s.Len(s.unitCounters(), 11)
 				s.Contains(s.scope.Snapshot().Counters(), s.saveSuccessScopeNameWithTags)
 				s.Contains(s.scope.Snapshot().Counters(), s.retryAttemptScopeNameWithTags)
 				s.Contains(s.scope.Snapshot().Counters(), s.insertScopeNameWithTags)
+				s.Contains(s.scope.Snapshot().Counters(), s.upsertScopeNameWithTags)
+				s.Contains(s.scope.Snapshot().Counters(), s.patchScopeNameWithTags)
+				s.Contains(s.scope.Snapshot().Counters(), s.deleteWhereScopeNameWithTags)
 				s.Contains(s.scope.Snapshot().Counters(), s.updateScopeNameWithTags)
 				s.Contains(s.scope.Snapshot().Counters(), s.deleteScopeNameWithTags)
 				s.Contains(s.scope.Snapshot().Counters(), s.cacheInsertScopeNameWithTags)
 				s.Contains(s.scope.Snapshot().Counters(), s.cacheDeleteScopeNameWithTags)
-				s.Len(s.scope.Snapshot().Timers(), 2)
+				s.Len(s.unitTimers(), 2)
 				s.Contains(s.scope.Snapshot().Timers(), s.saveScopeNameWithTags)
 			},
 		},
@@ -1009,6 +1033,574 @@ func (s *BestEffortUnitTestSuite) TestBestEffortUnit_Save() {
 	}
 }
 
+func (s *BestEffortUnitTestSuite) TestBestEffortUnit_Save_IdempotencyTokens() {
+	// arrange.
+	ctx := context.Background()
+	foo := test.Foo{ID: 28}
+	fooType := work.TypeNameOf(foo)
+	var mCtxs []work.UnitMapperContext
+	capture := func(ctx context.Context, mCtx work.UnitMapperContext, e ...interface{}) {
+		mCtxs = append(mCtxs, mCtx)
+	}
+	s.Require().NoError(s.sut.Add(ctx, foo))
+	insertFailure := s.mappers[fooType].EXPECT().
+		Insert(ctx, gomock.Any(), foo).Do(capture).Return(errors.New("whoa"))
+	s.mappers[fooType].EXPECT().
+		Insert(ctx, gomock.Any(), foo).Do(capture).Return(nil).After(insertFailure)
+
+	// action.
+	err := s.sut.Save(ctx)
+
+	// assert.
+	s.Require().NoError(err)
+	s.Require().Len(mCtxs, 2)
+	s.NotEmpty(mCtxs[0].SaveID)
+	s.Equal(mCtxs[0].SaveID, mCtxs[1].SaveID)
+	s.NotEmpty(mCtxs[0].AttemptID)
+	s.NotEmpty(mCtxs[1].AttemptID)
+	s.NotEqual(mCtxs[0].AttemptID, mCtxs[1].AttemptID)
+}
+
+func (s *BestEffortUnitTestSuite) TestBestEffortUnit_Save_UpsertRollback() {
+	// arrange.
+	ctx := context.Background()
+	registered, unregistered := test.Foo{ID: 28}, test.Foo{ID: 1992}
+	bar := test.Bar{ID: "28"}
+	fooType, barType := work.TypeNameOf(registered), work.TypeNameOf(bar)
+	upsertFunc := func(ctx context.Context, mCtx work.UnitMapperContext, e ...interface{}) error {
+		mCtx.SetUpsertInserted(unregistered)
+		return nil
+	}
+	opts := append(s.opts,
+		work.UnitUpsertFunc(fooType, upsertFunc), work.UnitRetryAttempts(1))
+	sut, err := work.NewUnit(opts...)
+	s.Require().NoError(err)
+	s.Require().NoError(sut.Register(ctx, registered))
+	s.Require().NoError(sut.Upsert(ctx, registered, unregistered))
+	s.Require().NoError(sut.Alter(ctx, bar))
+	s.mappers[barType].EXPECT().Update(ctx, gomock.Any(), bar).Return(errors.New("whoa"))
+	s.mappers[fooType].EXPECT().Update(ctx, gomock.Any(), registered).Return(nil)
+	s.mappers[fooType].EXPECT().Delete(ctx, gomock.Any(), unregistered).Return(nil)
+
+	// action.
+	err = sut.Save(ctx)
+
+	// assert.
+	s.EqualError(err, "whoa")
+}
+
+func (s *BestEffortUnitTestSuite) TestBestEffortUnit_Save_UpsertRollback_ExistingRow() {
+	// arrange.
+	ctx := context.Background()
+	existing, previous := test.Foo{ID: 28}, test.Foo{ID: 28}
+	unreported := test.Foo{ID: 1992}
+	bar := test.Bar{ID: "28"}
+	fooType, barType := work.TypeNameOf(existing), work.TypeNameOf(bar)
+	upsertFunc := func(ctx context.Context, mCtx work.UnitMapperContext, e ...interface{}) error {
+		mCtx.SetUpsertReplaced(existing, previous)
+		return nil
+	}
+	opts := append(s.opts,
+		work.UnitUpsertFunc(fooType, upsertFunc), work.UnitRetryAttempts(1))
+	sut, err := work.NewUnit(opts...)
+	s.Require().NoError(err)
+	s.Require().NoError(sut.Upsert(ctx, existing, unreported))
+	s.Require().NoError(sut.Alter(ctx, bar))
+	s.mappers[barType].EXPECT().Update(ctx, gomock.Any(), bar).Return(errors.New("whoa"))
+	// the previous state of the existing row is reapplied rather than the
+	// row being deleted, and rows without a reported outcome are retained.
+	s.mappers[fooType].EXPECT().Update(ctx, gomock.Any(), previous).Return(nil)
+	s.mappers[fooType].EXPECT().Delete(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+	// action.
+	err = sut.Save(ctx)
+
+	// assert.
+	s.EqualError(err, "whoa")
+}
+
+func (s *BestEffortUnitTestSuite) TestBestEffortUnit_Save_RollbackRetry() {
+	// arrange.
+	ctx := context.Background()
+	foo, bar := test.Foo{ID: 28}, test.Bar{ID: "28"}
+	fooType, barType := work.TypeNameOf(foo), work.TypeNameOf(bar)
+	opts := append(s.opts,
+		work.UnitRetryAttempts(1),
+		work.UnitRollbackRetryAttempts(3),
+		work.UnitRollbackRetryDelay(0))
+	sut, err := work.NewUnit(opts...)
+	s.Require().NoError(err)
+	s.Require().NoError(sut.Add(ctx, foo))
+	s.Require().NoError(sut.Alter(ctx, bar))
+	s.mappers[fooType].EXPECT().Insert(ctx, gomock.Any(), foo).Return(nil)
+	s.mappers[barType].EXPECT().Update(ctx, gomock.Any(), bar).Return(errors.New("whoa"))
+	gomock.InOrder(
+		s.mappers[fooType].EXPECT().Delete(ctx, gomock.Any(), foo).Return(errors.New("ouch")),
+		s.mappers[fooType].EXPECT().Delete(ctx, gomock.Any(), foo).Return(nil),
+	)
+
+	// action.
+	err = sut.Save(ctx)
+
+	// assert.
+	s.EqualError(err, "whoa")
+	s.Contains(s.scope.Snapshot().Counters(), s.rollbackSuccessScopeNameWithTags)
+	s.NotContains(s.scope.Snapshot().Counters(), s.rollbackFailureScopeNameWithTags)
+	s.Contains(s.scope.Snapshot().Counters(), s.rollbackRetryScopeNameWithTags)
+}
+
+func (s *BestEffortUnitTestSuite) TestBestEffortUnit_Save_RollbackRetryCompletedSteps() {
+	// arrange.
+	ctx := context.Background()
+	foo, bar := test.Foo{ID: 28}, test.Bar{ID: "28"}
+	fooType, barType := work.TypeNameOf(foo), work.TypeNameOf(bar)
+	opts := append(s.opts,
+		work.UnitRetryAttempts(1),
+		work.UnitRollbackRetryAttempts(3),
+		work.UnitRollbackRetryDelay(0))
+	sut, err := work.NewUnit(opts...)
+	s.Require().NoError(err)
+	s.Require().NoError(sut.Register(ctx, foo))
+	s.Require().NoError(sut.Add(ctx, bar))
+	s.Require().NoError(sut.Alter(ctx, foo))
+	s.mappers[barType].EXPECT().Insert(ctx, gomock.Any(), bar).Return(nil)
+	// the registered state is reapplied once, as only the failed deletion
+	// is retried.
+	gomock.InOrder(
+		s.mappers[fooType].EXPECT().Update(ctx, gomock.Any(), foo).Return(errors.New("whoa")),
+		s.mappers[fooType].EXPECT().Update(ctx, gomock.Any(), foo).Return(nil),
+	)
+	gomock.InOrder(
+		s.mappers[barType].EXPECT().Delete(ctx, gomock.Any(), bar).Return(errors.New("ouch")),
+		s.mappers[barType].EXPECT().Delete(ctx, gomock.Any(), bar).Return(nil),
+	)
+
+	// action.
+	err = sut.Save(ctx)
+
+	// assert.
+	s.EqualError(err, "whoa")
+	s.Contains(s.scope.Snapshot().Counters(), s.rollbackSuccessScopeNameWithTags)
+	s.Contains(s.scope.Snapshot().Counters(), s.rollbackRetryScopeNameWithTags)
+}
+
+func (s *BestEffortUnitTestSuite) TestBestEffortUnit_Save_Quarantine() {
+	// arrange.
+	ctx := context.Background()
+	valid, malformed := test.Foo{ID: 1}, test.Foo{ID: 2}
+	fooType := work.TypeNameOf(valid)
+	sut, err := work.NewUnit(append(s.opts, work.UnitQuarantineAfter(3))...)
+	s.Require().NoError(err)
+	s.Require().NoError(sut.Add(ctx, valid, malformed))
+	s.mappers[fooType].EXPECT().Insert(ctx, gomock.Any(), valid).Return(nil)
+	s.mappers[fooType].EXPECT().Insert(ctx, gomock.Any(), malformed).Return(errors.New("whoa")).Times(3)
+
+	// action.
+	err = sut.Save(ctx)
+
+	// assert.
+	s.Require().NoError(err)
+	quarantined := sut.Quarantined()
+	s.Require().Len(quarantined, 1)
+	s.Equal(malformed, quarantined[0].Entity)
+	s.Equal(3, quarantined[0].Failures)
+	s.Contains(s.scope.Snapshot().Counters(), s.quarantineScopeNameWithTags)
+}
+
+func (s *BestEffortUnitTestSuite) TestBestEffortUnit_Save_GeneratedIDs() {
+	// arrange.
+	ctx := context.Background()
+	quux := &test.Quux{}
+	quuxType := work.TypeNameOf(quux)
+	insert := func(ctx context.Context, mCtx work.UnitMapperContext, e ...interface{}) error {
+		mCtx.SetGeneratedID(e[0], "28")
+		return nil
+	}
+	noop := func(ctx context.Context, mCtx work.UnitMapperContext, e ...interface{}) error {
+		return nil
+	}
+	opts := append(s.opts, work.UnitInsertFunc(quuxType, insert), work.UnitDeleteFunc(quuxType, noop))
+	sut, err := work.NewUnit(opts...)
+	s.Require().NoError(err)
+	s.Require().NoError(sut.Add(ctx, quux))
+
+	// action.
+	err = sut.Save(ctx)
+
+	// assert.
+	s.Require().NoError(err)
+	s.Equal("28", quux.ID())
+}
+
+func (s *BestEffortUnitTestSuite) TestBestEffortUnit_Save_RefreshAfterSave() {
+	// arrange.
+	ctx := context.Background()
+	foo, quux := test.Foo{ID: 28}, &test.Quux{}
+	fooType, quuxType := work.TypeNameOf(foo), work.TypeNameOf(quux)
+	noop := func(ctx context.Context, mCtx work.UnitMapperContext, e ...interface{}) error {
+		return nil
+	}
+	refresh := func(ctx context.Context, mCtx work.UnitMapperContext, e ...interface{}) error {
+		e[0].(*test.Quux).SetID("28")
+		return nil
+	}
+	opts := append(s.opts,
+		work.UnitUpdateFunc(quuxType, noop), work.UnitDeleteFunc(quuxType, noop),
+		work.UnitRefreshFunc(quuxType, refresh), work.UnitRefreshAfterSave())
+	sut, err := work.NewUnit(opts...)
+	s.Require().NoError(err)
+	s.Require().NoError(sut.Alter(ctx, quux, foo))
+	s.mappers[fooType].EXPECT().Update(ctx, gomock.Any(), foo).Return(nil)
+
+	// action.
+	err = sut.Save(ctx)
+
+	// assert.
+	s.Require().NoError(err)
+	s.Equal("28", quux.ID())
+}
+
+func (s *BestEffortUnitTestSuite) TestBestEffortUnit_Save_SlowSave() {
+	// arrange.
+	ctx := context.Background()
+	foo := test.Foo{ID: 28}
+	fooType := work.TypeNameOf(foo)
+	var diagnostics []work.UnitSaveDiagnostics
+	handler := func(d work.UnitSaveDiagnostics) { diagnostics = append(diagnostics, d) }
+	sut, err := work.NewUnit(append(s.opts, work.UnitSlowSaveThreshold(0, handler))...)
+	s.Require().NoError(err)
+	s.Require().NoError(sut.Add(ctx, foo))
+	s.mappers[fooType].EXPECT().Insert(ctx, gomock.Any(), foo).Return(errors.New("whoa")).Times(s.retryCount)
+
+	// action.
+	err = sut.Save(ctx)
+
+	// assert.
+	s.Require().Error(err)
+	s.Require().Len(diagnostics, 1)
+	s.EqualError(diagnostics[0].Err, "whoa")
+	s.Equal(s.retryCount, diagnostics[0].Attempts)
+	s.Empty(diagnostics[0].Stacks)
+}
+
+func (s *BestEffortUnitTestSuite) TestBestEffortUnit_Save_DetectReentrancy() {
+	// arrange.
+	ctx := context.Background()
+	foo, bar := test.Foo{ID: 28}, test.Bar{ID: "28"}
+	fooType, barType := work.TypeNameOf(foo), work.TypeNameOf(bar)
+	sut, err := work.NewUnit(append(s.opts, work.UnitDetectReentrancy())...)
+	s.Require().NoError(err)
+	s.Require().NoError(sut.Add(ctx, foo))
+	s.mappers[fooType].EXPECT().Insert(ctx, gomock.Any(), foo).
+		DoAndReturn(func(ctx context.Context, mCtx work.UnitMapperContext, e ...interface{}) error {
+			return sut.Add(ctx, bar)
+		})
+	s.mappers[fooType].EXPECT().Delete(ctx, gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	s.mappers[barType].EXPECT().Delete(ctx, gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	// action + assert.
+	s.PanicsWithValue(
+		"work: Add called while the work unit is being saved; data mappers and "+
+			"actions must not stage entities into the work unit they are saving, "+
+			"and should stage them into a new work unit instead",
+		func() { sut.Save(ctx) })
+	s.NoError(sut.Add(ctx, bar))
+}
+
+func (s *BestEffortUnitTestSuite) TestBestEffortUnit_Save_UniqueCheck() {
+	// arrange.
+	ctx := context.Background()
+	foo := test.Foo{ID: 28}
+	fooType := work.TypeNameOf(foo)
+	passed := func(ctx context.Context, mCtx work.UnitMapperContext, e ...interface{}) error {
+		return nil
+	}
+	taken := errors.New("foo already exists")
+	failed := func(ctx context.Context, mCtx work.UnitMapperContext, e ...interface{}) error {
+		return taken
+	}
+	opts := append(s.opts, work.UnitUniqueCheck(fooType, passed), work.UnitUniqueCheck(fooType, failed))
+	sut, err := work.NewUnit(opts...)
+	s.Require().NoError(err)
+	s.Require().NoError(sut.Add(ctx, foo))
+
+	// action.
+	err = sut.Save(ctx)
+
+	// assert.
+	var uniqueness *work.UnitUniquenessError
+	s.Require().ErrorAs(err, &uniqueness)
+	s.Equal(fooType, uniqueness.TypeName)
+	s.ErrorIs(err, taken)
+}
+
+func (s *BestEffortUnitTestSuite) TestBestEffortUnit_Save_BulkWriter() {
+	// arrange.
+	ctx := context.Background()
+	foo, bar := test.Foo{ID: 28}, test.Bar{ID: "28"}
+	fooType, barType := work.TypeNameOf(foo), work.TypeNameOf(bar)
+	var writes [][]work.UnitBulkOperation
+	bulkWriter := func(ctx context.Context, mCtx work.UnitMapperContext, ops []work.UnitBulkOperation) ([]error, error) {
+		writes = append(writes, ops)
+		return make([]error, len(ops)), nil
+	}
+	opts := append(s.opts, work.UnitBulkWriter(bulkWriter, fooType), work.UnitRetryAttempts(1))
+	sut, err := work.NewUnit(opts...)
+	s.Require().NoError(err)
+	s.Require().NoError(sut.Add(ctx, foo))
+	s.Require().NoError(sut.Alter(ctx, bar))
+	s.mappers[barType].EXPECT().Update(ctx, gomock.Any(), bar).Return(errors.New("whoa"))
+
+	// action.
+	err = sut.Save(ctx)
+
+	// assert.
+	s.EqualError(err, "whoa")
+	s.Require().Len(writes, 2)
+	s.Equal([]work.UnitBulkOperation{
+		{Operation: work.UnitOperationInsert, TypeName: fooType, Entity: foo},
+	}, writes[0])
+	s.Equal([]work.UnitBulkOperation{
+		{Operation: work.UnitOperationDelete, TypeName: fooType, Entity: foo},
+	}, writes[1])
+	s.Contains(s.scope.Snapshot().Timers(), s.saveScopeName+".bulk+unit_type=best_effort")
+}
+
+func (s *BestEffortUnitTestSuite) TestBestEffortUnit_Save_BulkWriterItemError() {
+	// arrange.
+	ctx := context.Background()
+	foo := test.Foo{ID: 28}
+	fooType := work.TypeNameOf(foo)
+	bulkWriter := func(ctx context.Context, mCtx work.UnitMapperContext, ops []work.UnitBulkOperation) ([]error, error) {
+		return []error{errors.New("whoa")}, nil
+	}
+	opts := append(s.opts, work.UnitBulkWriter(bulkWriter, fooType), work.UnitRetryAttempts(1))
+	sut, err := work.NewUnit(opts...)
+	s.Require().NoError(err)
+	s.Require().NoError(sut.Add(ctx, foo))
+
+	// action.
+	err = sut.Save(ctx)
+
+	// assert.
+	var opErr *work.UnitOperationError
+	s.Require().ErrorAs(err, &opErr)
+	s.Equal(fooType, opErr.TypeName)
+	s.Equal(work.UnitOperationInsert, opErr.Operation)
+	s.EqualError(err, "whoa")
+}
+
+func (s *BestEffortUnitTestSuite) TestBestEffortUnit_Save_Compensating() {
+	// arrange.
+	ctx := context.Background()
+	foo, bar := test.Foo{ID: 28}, test.Bar{ID: "28"}
+	fooType, barType := work.TypeNameOf(foo), work.TypeNameOf(bar)
+	sut, err := work.NewUnit(append(s.opts, work.UnitRetryAttempts(1))...)
+	s.Require().NoError(err)
+	s.Require().NoError(sut.Add(ctx, foo))
+	s.Require().NoError(sut.Alter(ctx, bar))
+	var compensating []bool
+	track := func(ctx context.Context, mCtx work.UnitMapperContext, e ...interface{}) error {
+		compensating = append(compensating, mCtx.Compensating())
+		return nil
+	}
+	s.mappers[fooType].EXPECT().Insert(ctx, gomock.Any(), foo).DoAndReturn(track)
+	s.mappers[barType].EXPECT().Update(ctx, gomock.Any(), bar).Return(errors.New("whoa"))
+	s.mappers[fooType].EXPECT().Delete(ctx, gomock.Any(), foo).DoAndReturn(track)
+
+	// action.
+	err = sut.Save(ctx)
+
+	// assert.
+	s.EqualError(err, "whoa")
+	s.Equal([]bool{false, true}, compensating)
+}
+
+func (s *BestEffortUnitTestSuite) TestBestEffortUnit_Save_CompensationMetrics() {
+	// arrange.
+	ctx := context.Background()
+	foos := []interface{}{test.Foo{ID: 28}, test.Foo{ID: 1992}}
+	bar := test.Bar{ID: "28"}
+	fooType, barType := work.TypeNameOf(test.Foo{}), work.TypeNameOf(bar)
+	name := func(outcome string) string {
+		return fmt.Sprintf("%s.unit.compensation.%s+operation=insert,type_name=%s,%s",
+			s.scopePrefix, outcome, fooType, s.tags)
+	}
+	tests := []struct {
+		name      string
+		deleteErr error
+		outcome   string
+	}{
+		{name: "Success", outcome: "success"},
+		{name: "Failure", deleteErr: errors.New("ouch"), outcome: "failure"},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			scope := tally.NewTestScope(s.scopePrefix, map[string]string{})
+			opts := append(s.opts, work.UnitTallyMetricScope(scope), work.UnitRetryAttempts(1))
+			sut, err := work.NewUnit(opts...)
+			s.Require().NoError(err)
+			s.Require().NoError(sut.Add(ctx, foos...))
+			s.Require().NoError(sut.Alter(ctx, bar))
+			s.mappers[fooType].EXPECT().Insert(ctx, gomock.Any(), foos...).Return(nil)
+			s.mappers[barType].EXPECT().Update(ctx, gomock.Any(), bar).Return(errors.New("whoa"))
+			s.mappers[fooType].EXPECT().Delete(ctx, gomock.Any(), foos...).Return(tt.deleteErr)
+
+			// action.
+			err = sut.Save(ctx)
+
+			// assert.
+			s.Error(err)
+			counters := scope.Snapshot().Counters()
+			s.Require().Contains(counters, name(tt.outcome))
+			s.Equal(int64(len(foos)), counters[name(tt.outcome)].Value())
+		})
+	}
+}
+
+func (s *BestEffortUnitTestSuite) TestBestEffortUnit_Save_CompensationMetrics_Updates() {
+	// arrange.
+	ctx := context.Background()
+	foo := test.Foo{ID: 28}
+	bars := []interface{}{test.Bar{ID: "28"}, test.Bar{ID: "1992"}}
+	fooType, barType := work.TypeNameOf(foo), work.TypeNameOf(test.Bar{})
+	name := fmt.Sprintf("%s.unit.compensation.success+operation=update,type_name=%s,%s",
+		s.scopePrefix, barType, s.tags)
+	scope := tally.NewTestScope(s.scopePrefix, map[string]string{})
+	opts := append(s.opts, work.UnitTallyMetricScope(scope), work.UnitRetryAttempts(1))
+	sut, err := work.NewUnit(opts...)
+	s.Require().NoError(err)
+	s.Require().NoError(sut.Register(ctx, bars...))
+	s.Require().NoError(sut.Alter(ctx, bars[0]))
+	s.Require().NoError(sut.Remove(ctx, foo))
+	s.mappers[barType].EXPECT().Update(ctx, gomock.Any(), bars[0]).Return(nil)
+	s.mappers[fooType].EXPECT().Delete(ctx, gomock.Any(), foo).Return(errors.New("whoa"))
+	s.mappers[barType].EXPECT().Update(ctx, gomock.Any(), bars...).Return(nil)
+
+	// action.
+	err = sut.Save(ctx)
+
+	// assert.
+	s.Error(err)
+	counters := scope.Snapshot().Counters()
+	s.Require().Contains(counters, name)
+	s.Equal(int64(1), counters[name].Value())
+}
+
+func (s *BestEffortUnitTestSuite) TestBestEffortUnit_Save_AppendOnly() {
+	// arrange.
+	ctx := context.Background()
+	foos := []interface{}{test.Foo{ID: 28}, test.Foo{ID: 29}}
+	fooType := work.TypeNameOf(test.Foo{})
+	var inserted []interface{}
+	insertErrs := []error{errors.New("whoa"), nil}
+	insert := func(ctx context.Context, mCtx work.UnitMapperContext, e ...interface{}) (err error) {
+		inserted = append(inserted, e...)
+		err, insertErrs = insertErrs[0], insertErrs[1:]
+		return
+	}
+	sut, err := work.NewUnit(
+		work.UnitInsertFunc(fooType, insert),
+		work.UnitAppendOnly(),
+		work.UnitRetryAttempts(2),
+	)
+	s.Require().NoError(err)
+	s.Require().NoError(sut.Add(ctx, foos[0]))
+	s.Require().NoError(sut.Add(ctx, foos[1]))
+
+	// action.
+	err = sut.Save(ctx)
+
+	// assert.
+	s.Require().NoError(err)
+	s.Len(inserted, 4)
+	s.ElementsMatch(append(append([]interface{}{}, foos...), foos...), inserted)
+}
+
+func (s *BestEffortUnitTestSuite) TestBestEffortUnit_Add_MissingDeleteFunc() {
+	// arrange.
+	ctx := context.Background()
+	fooType := work.TypeNameOf(test.Foo{})
+	noop := func(context.Context, work.UnitMapperContext, ...interface{}) error { return nil }
+	sut, err := work.NewUnit(work.UnitInsertFunc(fooType, noop))
+	s.Require().NoError(err)
+
+	// action.
+	err = sut.Add(ctx, test.Foo{ID: 28})
+
+	// assert.
+	s.ErrorIs(err, work.ErrMissingDataMapper)
+}
+
+func (s *BestEffortUnitTestSuite) TestBestEffortUnit_Save_RetryHistory() {
+	// arrange.
+	ctx := context.Background()
+	fooType := work.TypeNameOf(test.Foo{})
+	noop := func(context.Context, work.UnitMapperContext, ...interface{}) error { return nil }
+	tests := []struct {
+		name     string
+		limit    int
+		attempts []string
+		omitted  int
+	}{
+		{name: "Uncapped", attempts: []string{"timeout", "deadlock", "timeout"}},
+		{name: "Capped", limit: 2, attempts: []string{"deadlock", "timeout"}, omitted: 1},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			errs := []error{errors.New("timeout"), errors.New("deadlock"), errors.New("timeout")}
+			insert := func(context.Context, work.UnitMapperContext, ...interface{}) (err error) {
+				err, errs = errs[0], errs[1:]
+				return
+			}
+			sut, err := work.NewUnit(
+				work.UnitInsertFunc(fooType, insert),
+				work.UnitDeleteFunc(fooType, noop),
+				work.UnitRetryAttempts(3),
+				work.UnitRetryHistory(tt.limit),
+			)
+			s.Require().NoError(err)
+			s.Require().NoError(sut.Add(ctx, test.Foo{ID: 28}))
+
+			// action.
+			err = sut.Save(ctx)
+
+			// assert.
+			var retryErr *work.UnitRetryError
+			s.Require().ErrorAs(err, &retryErr)
+			s.Equal(tt.attempts, retryErr.Errors())
+			s.Equal(tt.omitted, retryErr.Omitted)
+			s.Equal(3, retryErr.Attempts[len(retryErr.Attempts)-1].Attempt)
+			s.EqualError(retryErr.Err, "timeout")
+			s.Equal(tt.attempts, work.NewErrorReport(err).Attempts)
+		})
+	}
+}
+
+// unitCounters provides the counters emitted, excluding those for each type
+// compensated for when rolling back.
+func (s *BestEffortUnitTestSuite) unitCounters() map[string]tally.CounterSnapshot {
+	counters := make(map[string]tally.CounterSnapshot)
+	for name, counter := range s.scope.Snapshot().Counters() {
+		if !strings.HasPrefix(name, s.scopePrefix+".unit.compensation.") {
+			counters[name] = counter
+		}
+	}
+	return counters
+}
+
+// unitTimers provides the timers emitted, excluding those for each phase of
+// a save and for each action.
+func (s *BestEffortUnitTestSuite) unitTimers() map[string]tally.TimerSnapshot {
+	timers := make(map[string]tally.TimerSnapshot)
+	for name, timer := range s.scope.Snapshot().Timers() {
+		if !strings.HasPrefix(name, s.saveScopeName+".") && !strings.HasPrefix(name, s.scopePrefix+".unit.action+") {
+			timers[name] = timer
+		}
+	}
+	return timers
+}
+
 func (s *BestEffortUnitTestSuite) TearDown() {
 	defer func() { s.isSetup, s.isTornDown = false, true }()
 
@@ -0,0 +1,71 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package compat
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/freerware/work/v4"
+)
+
+// DataMapper represents a creator, modifier, and deleter
+// of entities.
+type DataMapper interface {
+	Insert(...interface{}) error
+	Update(...interface{}) error
+	Delete(...interface{}) error
+}
+
+// SQLDataMapper represents a creator, modifier, and deleter
+// of entities persisted in SQL data stores.
+type SQLDataMapper interface {
+	Insert(*sql.Tx, ...interface{}) error
+	Update(*sql.Tx, ...interface{}) error
+	Delete(*sql.Tx, ...interface{}) error
+}
+
+type dataMapper struct {
+	mapper DataMapper
+}
+
+func (dm dataMapper) Insert(_ context.Context, _ work.UnitMapperContext, entities ...interface{}) error {
+	return dm.mapper.Insert(entities...)
+}
+
+func (dm dataMapper) Update(_ context.Context, _ work.UnitMapperContext, entities ...interface{}) error {
+	return dm.mapper.Update(entities...)
+}
+
+func (dm dataMapper) Delete(_ context.Context, _ work.UnitMapperContext, entities ...interface{}) error {
+	return dm.mapper.Delete(entities...)
+}
+
+type sqlDataMapper struct {
+	mapper SQLDataMapper
+}
+
+func (dm sqlDataMapper) Insert(_ context.Context, mCtx work.UnitMapperContext, entities ...interface{}) error {
+	return dm.mapper.Insert(mCtx.Tx, entities...)
+}
+
+func (dm sqlDataMapper) Update(_ context.Context, mCtx work.UnitMapperContext, entities ...interface{}) error {
+	return dm.mapper.Update(mCtx.Tx, entities...)
+}
+
+func (dm sqlDataMapper) Delete(_ context.Context, mCtx work.UnitMapperContext, entities ...interface{}) error {
+	return dm.mapper.Delete(mCtx.Tx, entities...)
+}
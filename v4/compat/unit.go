@@ -0,0 +1,216 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package compat provides the work unit constructors and signatures found in
+// version 3 of this module, implemented on top of version 4.
+//
+// It is intended to allow codebases to migrate incrementally: existing data
+// mappers and call sites continue to compile, while the work units they
+// construct immediately benefit from the retries, caching, and metrics
+// offered by version 4. Once call sites are migrated, Unwrap provides access
+// to the underlying version 4 work unit.
+package compat
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/freerware/work/v4"
+	"github.com/uber-go/tally/v4"
+	"go.uber.org/zap"
+)
+
+// ErrNoDB represents the error when a SQL work unit is constructed
+// without a database.
+var ErrNoDB = errors.New("must provide a database for a SQL work unit")
+
+// TypeName represents an entity's type.
+type TypeName = work.TypeName
+
+// TypeNameOf provides the type name for the provided entity.
+func TypeNameOf(entity interface{}) TypeName {
+	return work.TypeNameOf(entity)
+}
+
+// Option applies an option to the provided configuration.
+type Option = work.UnitOption
+
+var (
+	// UnitLogger specifies the option to provide a logger for the work unit.
+	UnitLogger = func(l *zap.Logger) Option {
+		return work.UnitWithZapLogger(l)
+	}
+
+	// UnitScope specifies the option to provide a metric scope for the work unit.
+	UnitScope = func(s tally.Scope) Option {
+		return work.UnitTallyMetricScope(s)
+	}
+
+	// DisableDefaultLoggingActions disables the default logging actions.
+	DisableDefaultLoggingActions = func() Option {
+		return work.DisableDefaultLoggingActions()
+	}
+)
+
+// Unit represents an atomic set of entity changes.
+type Unit interface {
+
+	// Register tracks the provided entities as clean.
+	Register(...interface{}) error
+
+	// Add marks the provided entities as new additions.
+	Add(...interface{}) error
+
+	// Alter marks the provided entities as modifications.
+	Alter(...interface{}) error
+
+	// Remove marks the provided entities as removals.
+	Remove(...interface{}) error
+
+	// Save commits the new additions, modifications, and removals
+	// within the work unit to a persistent store.
+	Save() error
+
+	// Unwrap provides the underlying work unit.
+	Unwrap() work.Unit
+}
+
+type unit struct {
+	u work.Unit
+}
+
+// NewSQLUnit constructs a work unit for SQL data stores.
+func NewSQLUnit(
+	mappers map[TypeName]SQLDataMapper,
+	db *sql.DB,
+	options ...Option,
+) (Unit, error) {
+	// as with version 3, missing data mappers are reported first.
+	if len(mappers) == 0 {
+		return nil, work.ErrNoDataMapper
+	}
+	if db == nil {
+		return nil, ErrNoDB
+	}
+	dm := make(map[work.TypeName]work.UnitDataMapper, len(mappers))
+	for t, m := range mappers {
+		dm[t] = sqlDataMapper{mapper: m}
+	}
+	return newUnit(dm, append(options, work.UnitDB(db))...)
+}
+
+// NewBestEffortUnit constructs a work unit that when faced
+// with adversity, attempts rollback.
+func NewBestEffortUnit(
+	mappers map[TypeName]DataMapper, options ...Option) (Unit, error) {
+	dm := make(map[work.TypeName]work.UnitDataMapper, len(mappers))
+	for t, m := range mappers {
+		dm[t] = dataMapper{mapper: m}
+	}
+	return newUnit(dm, options...)
+}
+
+// Uniter represents a factory for work units.
+type Uniter interface {
+
+	// Unit constructs a new work unit.
+	Unit() (Unit, error)
+}
+
+type sqlUniter struct {
+	mappers map[TypeName]SQLDataMapper
+	db      *sql.DB
+	options []Option
+}
+
+// NewSQLUniter constructs a new SQL work unit factory.
+func NewSQLUniter(
+	mappers map[TypeName]SQLDataMapper,
+	db *sql.DB,
+	options ...Option,
+) Uniter {
+	return &sqlUniter{
+		mappers: mappers,
+		db:      db,
+		options: options,
+	}
+}
+
+// Unit constructs a new SQL work unit.
+func (u *sqlUniter) Unit() (Unit, error) {
+	return NewSQLUnit(u.mappers, u.db, u.options...)
+}
+
+type bestEffortUniter struct {
+	mappers map[TypeName]DataMapper
+	options []Option
+}
+
+// NewBestEffortUniter constructs a new best effort work unit factory.
+func NewBestEffortUniter(
+	mappers map[TypeName]DataMapper, options ...Option) Uniter {
+	return &bestEffortUniter{
+		mappers: mappers,
+		options: options,
+	}
+}
+
+// Unit constructs a new best effort work unit.
+func (u *bestEffortUniter) Unit() (Unit, error) {
+	return NewBestEffortUnit(u.mappers, u.options...)
+}
+
+func newUnit(mappers map[work.TypeName]work.UnitDataMapper, options ...Option) (Unit, error) {
+	// data mappers are provided first so that the provided options
+	// can augment them.
+	opts := append([]Option{work.UnitDataMappers(mappers)}, options...)
+	u, err := work.NewUnit(opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &unit{u: u}, nil
+}
+
+// Register tracks the provided entities as clean.
+func (u *unit) Register(entities ...interface{}) error {
+	return u.u.Register(context.Background(), entities...)
+}
+
+// Add marks the provided entities as new additions.
+func (u *unit) Add(entities ...interface{}) error {
+	return u.u.Add(context.Background(), entities...)
+}
+
+// Alter marks the provided entities as modifications.
+func (u *unit) Alter(entities ...interface{}) error {
+	return u.u.Alter(context.Background(), entities...)
+}
+
+// Remove marks the provided entities as removals.
+func (u *unit) Remove(entities ...interface{}) error {
+	return u.u.Remove(context.Background(), entities...)
+}
+
+// Save commits the new additions, modifications, and removals
+// within the work unit to a persistent store.
+func (u *unit) Save() error {
+	return u.u.Save(context.Background())
+}
+
+// Unwrap provides the underlying work unit.
+func (u *unit) Unwrap() work.Unit {
+	return u.u
+}
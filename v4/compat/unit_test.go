@@ -0,0 +1,257 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package compat_test
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/freerware/work/v4"
+	"github.com/freerware/work/v4/compat"
+	"github.com/freerware/work/v4/internal/test"
+	"github.com/stretchr/testify/suite"
+	"github.com/uber-go/tally/v4"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+type dataMapper struct {
+	inserted, updated, deleted []interface{}
+	err                        error
+}
+
+func (dm *dataMapper) Insert(entities ...interface{}) error {
+	dm.inserted = append(dm.inserted, entities...)
+	return dm.err
+}
+
+func (dm *dataMapper) Update(entities ...interface{}) error {
+	dm.updated = append(dm.updated, entities...)
+	return dm.err
+}
+
+func (dm *dataMapper) Delete(entities ...interface{}) error {
+	dm.deleted = append(dm.deleted, entities...)
+	return dm.err
+}
+
+type sqlDataMapper struct {
+	dataMapper
+	txs []*sql.Tx
+}
+
+func (dm *sqlDataMapper) Insert(tx *sql.Tx, entities ...interface{}) error {
+	dm.txs = append(dm.txs, tx)
+	return dm.dataMapper.Insert(entities...)
+}
+
+func (dm *sqlDataMapper) Update(tx *sql.Tx, entities ...interface{}) error {
+	dm.txs = append(dm.txs, tx)
+	return dm.dataMapper.Update(entities...)
+}
+
+func (dm *sqlDataMapper) Delete(tx *sql.Tx, entities ...interface{}) error {
+	dm.txs = append(dm.txs, tx)
+	return dm.dataMapper.Delete(entities...)
+}
+
+type UnitTestSuite struct {
+	suite.Suite
+}
+
+func TestUnitTestSuite(t *testing.T) {
+	suite.Run(t, new(UnitTestSuite))
+}
+
+func (s *UnitTestSuite) TestNewSQLUnit_NoDataMappers() {
+	// action.
+	_, err := compat.NewSQLUnit(map[compat.TypeName]compat.SQLDataMapper{}, nil)
+
+	// assert.
+	s.ErrorIs(err, work.ErrNoDataMapper)
+}
+
+func (s *UnitTestSuite) TestNewBestEffortUnit_NoDataMappers() {
+	// action.
+	_, err := compat.NewBestEffortUnit(map[compat.TypeName]compat.DataMapper{})
+
+	// assert.
+	s.ErrorIs(err, work.ErrNoDataMapper)
+}
+
+func (s *UnitTestSuite) TestSQLUnit_Save() {
+	// arrange.
+	db, _db, err := sqlmock.New()
+	s.Require().NoError(err)
+	defer db.Close()
+	foo, bar, baz := test.Foo{ID: 1}, test.Foo{ID: 2}, test.Foo{ID: 3}
+	m := &sqlDataMapper{}
+	mappers := map[compat.TypeName]compat.SQLDataMapper{compat.TypeNameOf(foo): m}
+	sut, err := compat.NewSQLUnit(mappers, db)
+	s.Require().NoError(err)
+	s.Require().NoError(sut.Add(foo))
+	s.Require().NoError(sut.Alter(bar))
+	s.Require().NoError(sut.Remove(baz))
+	_db.ExpectBegin()
+	_db.ExpectCommit()
+
+	// action.
+	err = sut.Save()
+
+	// assert.
+	s.Require().NoError(err)
+	s.Equal([]interface{}{foo}, m.inserted)
+	s.Equal([]interface{}{bar}, m.updated)
+	s.Equal([]interface{}{baz}, m.deleted)
+	s.Len(m.txs, 3)
+	for _, tx := range m.txs {
+		s.NotNil(tx)
+	}
+	s.NotNil(sut.Unwrap())
+	s.NoError(_db.ExpectationsWereMet())
+}
+
+func (s *UnitTestSuite) TestSQLUnit_Save_Retries() {
+	// arrange.
+	db, _db, err := sqlmock.New()
+	s.Require().NoError(err)
+	defer db.Close()
+	foo := test.Foo{ID: 1}
+	m := &sqlDataMapper{dataMapper: dataMapper{err: errors.New("whoa")}}
+	mappers := map[compat.TypeName]compat.SQLDataMapper{compat.TypeNameOf(foo): m}
+	sut, err := compat.NewSQLUnit(mappers, db, work.UnitRetryAttempts(2))
+	s.Require().NoError(err)
+	s.Require().NoError(sut.Add(foo))
+	for i := 0; i < 2; i++ {
+		_db.ExpectBegin()
+		_db.ExpectRollback()
+	}
+
+	// action.
+	err = sut.Save()
+
+	// assert.
+	s.EqualError(err, "whoa")
+	s.Len(m.inserted, 2)
+	s.NoError(_db.ExpectationsWereMet())
+}
+
+func (s *UnitTestSuite) TestBestEffortUnit_Save() {
+	// arrange.
+	foo, bar := test.Foo{ID: 1}, test.Foo{ID: 2}
+	m := &dataMapper{}
+	mappers := map[compat.TypeName]compat.DataMapper{compat.TypeNameOf(foo): m}
+	sut, err := compat.NewBestEffortUnit(mappers)
+	s.Require().NoError(err)
+	s.Require().NoError(sut.Register(bar))
+	s.Require().NoError(sut.Add(foo))
+	s.Require().NoError(sut.Alter(bar))
+
+	// action.
+	err = sut.Save()
+
+	// assert.
+	s.Require().NoError(err)
+	s.Equal([]interface{}{foo}, m.inserted)
+	s.Equal([]interface{}{bar}, m.updated)
+	s.Empty(m.deleted)
+}
+
+func (s *UnitTestSuite) TestNewSQLUnit_NoDB() {
+	// arrange.
+	mappers := map[compat.TypeName]compat.SQLDataMapper{
+		compat.TypeNameOf(test.Foo{}): &sqlDataMapper{},
+	}
+
+	// action.
+	_, err := compat.NewSQLUnit(mappers, nil)
+
+	// assert.
+	s.ErrorIs(err, compat.ErrNoDB)
+}
+
+func (s *UnitTestSuite) TestSQLUniter_Unit() {
+	// arrange.
+	db, _db, err := sqlmock.New()
+	s.Require().NoError(err)
+	defer db.Close()
+	foo := test.Foo{ID: 1}
+	m := &sqlDataMapper{}
+	core, logs := observer.New(zap.DebugLevel)
+	scope := tally.NewTestScope("test", map[string]string{})
+	uniter := compat.NewSQLUniter(
+		map[compat.TypeName]compat.SQLDataMapper{compat.TypeNameOf(foo): m},
+		db,
+		compat.UnitLogger(zap.New(core)),
+		compat.UnitScope(scope),
+	)
+	_db.ExpectBegin()
+	_db.ExpectCommit()
+
+	// action.
+	sut, err := uniter.Unit()
+	s.Require().NoError(err)
+	s.Require().NoError(sut.Add(foo))
+	err = sut.Save()
+
+	// assert.
+	s.Require().NoError(err)
+	s.Equal([]interface{}{foo}, m.inserted)
+	s.NotZero(logs.FilterMessage("attempting to save unit").Len())
+	s.NotEmpty(scope.Snapshot().Counters())
+	s.NoError(_db.ExpectationsWereMet())
+}
+
+func (s *UnitTestSuite) TestSQLUniter_Unit_NoDB() {
+	// arrange.
+	uniter := compat.NewSQLUniter(
+		map[compat.TypeName]compat.SQLDataMapper{
+			compat.TypeNameOf(test.Foo{}): &sqlDataMapper{},
+		},
+		nil,
+	)
+
+	// action.
+	_, err := uniter.Unit()
+
+	// assert.
+	s.ErrorIs(err, compat.ErrNoDB)
+}
+
+func (s *UnitTestSuite) TestBestEffortUniter_Unit() {
+	// arrange.
+	foo := test.Foo{ID: 1}
+	m := &dataMapper{}
+	core, logs := observer.New(zap.DebugLevel)
+	uniter := compat.NewBestEffortUniter(
+		map[compat.TypeName]compat.DataMapper{compat.TypeNameOf(foo): m},
+		compat.UnitLogger(zap.New(core)),
+		compat.DisableDefaultLoggingActions(),
+	)
+
+	// action.
+	sut, err := uniter.Unit()
+	s.Require().NoError(err)
+	s.Require().NoError(sut.Remove(foo))
+	err = sut.Save()
+
+	// assert.
+	s.Require().NoError(err)
+	s.Equal([]interface{}{foo}, m.deleted)
+	s.Zero(logs.FilterMessage("attempting to save unit").Len())
+}
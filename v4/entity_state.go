@@ -0,0 +1,109 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package work
+
+import "reflect"
+
+// EntityState represents the state of an entity within a work unit.
+type EntityState int
+
+// The various states an entity can be in within a work unit.
+const (
+	// EntityStateRegistered indicates an entity that has been registered as clean.
+	EntityStateRegistered EntityState = iota
+	// EntityStateAdded indicates an entity that has been marked as a new addition.
+	EntityStateAdded
+	// EntityStateAltered indicates an entity that has been marked as a modification.
+	EntityStateAltered
+	// EntityStateRemoved indicates an entity that has been marked as a removal.
+	EntityStateRemoved
+	// EntityStateUpserted indicates an entity that has been marked as an upsert.
+	EntityStateUpserted
+)
+
+// String provides the string representation of the entity state.
+func (s EntityState) String() string {
+	switch s {
+	case EntityStateRegistered:
+		return "registered"
+	case EntityStateAdded:
+		return "added"
+	case EntityStateAltered:
+		return "altered"
+	case EntityStateRemoved:
+		return "removed"
+	case EntityStateUpserted:
+		return "upserted"
+	default:
+		return "unknown"
+	}
+}
+
+// sameIdentity determines if the provided entities share the same identity.
+// Entities that do not expose an identity are compared by value.
+func sameIdentity(a, b interface{}) bool {
+	t := TypeNameOf(a)
+	if t != TypeNameOf(b) {
+		return false
+	}
+	aID, aOK := id(a)
+	bID, bOK := id(b)
+	if aOK && bOK {
+		return cacheKey(t, aID) == cacheKey(t, bID)
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// contains determines if the provided entities contain an entity sharing the
+// same identity as the entity provided.
+func contains(entities map[TypeName][]interface{}, entity interface{}) bool {
+	for _, e := range entities[TypeNameOf(entity)] {
+		if sameIdentity(e, entity) {
+			return true
+		}
+	}
+	return false
+}
+
+// StateOf provides the state of the entity with the same identity as the
+// entity provided. When an entity has been staged multiple times, removals
+// take precedence over alterations, alterations over upserts, upserts over
+// additions, and additions over registrations.
+func (u *unit) StateOf(entity interface{}) (EntityState, bool) {
+	// invalid entities cannot have been staged.
+	if validateEntity(entity) != nil {
+		return EntityStateRegistered, false
+	}
+	u.mutex.RLock()
+	defer u.mutex.RUnlock()
+	return u.stateOf(entity)
+}
+
+func (u *unit) stateOf(entity interface{}) (EntityState, bool) {
+	switch {
+	case contains(u.removals, entity):
+		return EntityStateRemoved, true
+	case contains(u.alterations, entity):
+		return EntityStateAltered, true
+	case contains(u.upserts, entity):
+		return EntityStateUpserted, true
+	case contains(u.additions, entity):
+		return EntityStateAdded, true
+	case contains(u.registered, entity):
+		return EntityStateRegistered, true
+	}
+	return EntityStateRegistered, false
+}
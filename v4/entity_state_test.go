@@ -0,0 +1,150 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package work_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/freerware/work/v4"
+	"github.com/freerware/work/v4/internal/mock"
+	"github.com/freerware/work/v4/internal/test"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+)
+
+type EntityStateTestSuite struct {
+	suite.Suite
+
+	// system under test.
+	sut work.Unit
+
+	// mocks.
+	mc *gomock.Controller
+}
+
+func TestEntityStateTestSuite(t *testing.T) {
+	suite.Run(t, new(EntityStateTestSuite))
+}
+
+func (s *EntityStateTestSuite) SetupTest() {
+	// initialize mocks.
+	s.mc = gomock.NewController(s.T())
+
+	// construct SUT.
+	dm := map[work.TypeName]work.UnitDataMapper{
+		work.TypeNameOf(test.Foo{}): mock.NewUnitDataMapper(s.mc),
+		work.TypeNameOf(test.Biz{}): mock.NewUnitDataMapper(s.mc),
+	}
+	var err error
+	s.sut, err = work.NewUnit(work.UnitDataMappers(dm))
+	s.Require().NoError(err)
+}
+
+func (s *EntityStateTestSuite) TestEntityState_String() {
+	s.Equal("registered", work.EntityStateRegistered.String())
+	s.Equal("added", work.EntityStateAdded.String())
+	s.Equal("altered", work.EntityStateAltered.String())
+	s.Equal("removed", work.EntityStateRemoved.String())
+	s.Equal("unknown", work.EntityState(-1).String())
+}
+
+func (s *EntityStateTestSuite) TestUnit_StateOf() {
+	// arrange.
+	ctx := context.Background()
+	tests := []struct {
+		name    string
+		arrange func()
+		entity  interface{}
+		state   work.EntityState
+		ok      bool
+	}{
+		{
+			name:    "Untracked",
+			arrange: func() {},
+			entity:  test.Foo{ID: 28},
+			ok:      false,
+		},
+		{
+			name:    "Registered",
+			arrange: func() { s.Require().NoError(s.sut.Register(ctx, test.Foo{ID: 28})) },
+			entity:  test.Foo{ID: 28},
+			state:   work.EntityStateRegistered,
+			ok:      true,
+		},
+		{
+			name:    "Added",
+			arrange: func() { s.Require().NoError(s.sut.Add(ctx, test.Foo{ID: 28})) },
+			entity:  test.Foo{ID: 28},
+			state:   work.EntityStateAdded,
+			ok:      true,
+		},
+		{
+			name: "Altered",
+			arrange: func() {
+				s.Require().NoError(s.sut.Register(ctx, test.Foo{ID: 28}))
+				s.Require().NoError(s.sut.Alter(ctx, test.Foo{ID: 28}))
+			},
+			entity: test.Foo{ID: 28},
+			state:  work.EntityStateAltered,
+			ok:     true,
+		},
+		{
+			name: "Removed",
+			arrange: func() {
+				s.Require().NoError(s.sut.Alter(ctx, test.Foo{ID: 28}))
+				s.Require().NoError(s.sut.Remove(ctx, test.Foo{ID: 28}))
+			},
+			entity: test.Foo{ID: 28},
+			state:  work.EntityStateRemoved,
+			ok:     true,
+		},
+		{
+			name:    "DifferentIdentity",
+			arrange: func() { s.Require().NoError(s.sut.Add(ctx, test.Foo{ID: 28})) },
+			entity:  test.Foo{ID: 1992},
+			ok:      false,
+		},
+		{
+			name:    "WithoutIdentity",
+			arrange: func() { s.Require().NoError(s.sut.Add(ctx, test.Biz{Identifier: "28"})) },
+			entity:  test.Biz{Identifier: "28"},
+			state:   work.EntityStateAdded,
+			ok:      true,
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			// setup.
+			s.SetupTest()
+			tt.arrange()
+
+			// action.
+			state, ok := s.sut.StateOf(tt.entity)
+
+			// assert.
+			s.Equal(tt.ok, ok)
+			if tt.ok {
+				s.Equal(tt.state, state)
+			}
+		})
+	}
+}
+
+func (s *EntityStateTestSuite) TearDownTest() {
+	s.sut = nil
+	s.mc.Finish()
+}
@@ -0,0 +1,65 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package adapters
+
+import (
+	"fmt"
+	"strings"
+)
+
+// badKey is the key utilized for values lacking a key, consistent with the
+// 'log/slog' standard library package.
+const badKey = "!BADKEY"
+
+// pairs normalizes the provided arguments into alternating string keys and
+// values, such that values lacking a key, including those following
+// non-string keys, are keyed by badKey.
+func pairs(args []any) []any {
+	normalized := make([]any, 0, len(args)+1)
+	for len(args) > 0 {
+		key, ok := args[0].(string)
+		if !ok || len(args) == 1 {
+			normalized = append(normalized, badKey, args[0])
+			args = args[1:]
+			continue
+		}
+		normalized = append(normalized, key, args[1])
+		args = args[2:]
+	}
+	return normalized
+}
+
+// format renders the provided message with arguments as text, with each
+// argument rendered as key=value.
+func format(msg string, args []any) string {
+	var b strings.Builder
+	b.WriteString(msg)
+	normalized := pairs(args)
+	for i := 0; i < len(normalized); i = i + 2 {
+		fmt.Fprintf(&b, " %s=%v", normalized[i], normalized[i+1])
+	}
+	return b.String()
+}
+
+// fields provides the provided arguments as a map of keys to values.
+func fields(args []any) map[string]any {
+	normalized := pairs(args)
+	f := make(map[string]any, len(normalized)/2)
+	for i := 0; i < len(normalized); i = i + 2 {
+		f[normalized[i].(string)] = normalized[i+1]
+	}
+	return f
+}
@@ -31,20 +31,20 @@ func NewStandardLogger(logger *log.Logger) *StandardLogger {
 
 // Debug logs the provided arguments as a 'debug' level message.
 func (adapter *StandardLogger) Debug(msg string, args ...any) {
-	adapter.l.Println(append([]any{msg}, args...))
+	adapter.l.Println("DEBUG", format(msg, args))
 }
 
 // Info logs the provided arguments as a 'info' level message.
 func (adapter *StandardLogger) Info(msg string, args ...any) {
-	adapter.l.Println(append([]any{msg}, args...))
+	adapter.l.Println("INFO", format(msg, args))
 }
 
 // Warn logs the provided arguments as a 'warn' level message.
 func (adapter *StandardLogger) Warn(msg string, args ...any) {
-	adapter.l.Println(append([]any{msg}, args...))
+	adapter.l.Println("WARN", format(msg, args))
 }
 
 // Error logs the provided arguments as an 'error' level message.
 func (adapter *StandardLogger) Error(msg string, args ...any) {
-	adapter.l.Println(append([]any{msg}, args...))
+	adapter.l.Println("ERROR", format(msg, args))
 }
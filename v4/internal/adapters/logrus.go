@@ -31,20 +31,20 @@ func NewLogrusLogger(logger *logrus.Logger) *LogrusLogger {
 
 // Debug logs the provided message with arguments as a 'debug' level message.
 func (adapter *LogrusLogger) Debug(msg string, args ...any) {
-	adapter.l.Debug(append([]any{msg}, args...))
+	adapter.l.WithFields(fields(args)).Debug(msg)
 }
 
 // Info logs the provided message with arguments as a 'info' level message.
 func (adapter *LogrusLogger) Info(msg string, args ...any) {
-	adapter.l.Info(append([]any{msg}, args...))
+	adapter.l.WithFields(fields(args)).Info(msg)
 }
 
 // Warn logs the provided message with arguments as a 'warn' level message.
 func (adapter *LogrusLogger) Warn(msg string, args ...any) {
-	adapter.l.Warn(append([]any{msg}, args...))
+	adapter.l.WithFields(fields(args)).Warn(msg)
 }
 
 // Error logs the provided message with arguments as an 'error' level message.
 func (adapter *LogrusLogger) Error(msg string, args ...any) {
-	adapter.l.Error(append([]any{msg}, args...))
+	adapter.l.WithFields(fields(args)).Error(msg)
 }
@@ -15,6 +15,8 @@
 
 package adapters
 
+import "time"
+
 // NopLogger represents an adapter for a no-op logger.
 type NopLogger struct {
 }
@@ -35,3 +37,19 @@ func (adapter *NopLogger) Warn(msg string, args ...any) {}
 
 // Error does nothing.
 func (adapter *NopLogger) Error(msg string, args ...any) {}
+
+// NopCollector represents an adapter for a no-op metrics collector.
+type NopCollector struct {
+}
+
+// NewNopCollector creates a no-op metrics collector adapter that does
+// nothing.
+func NewNopCollector() *NopCollector {
+	return &NopCollector{}
+}
+
+// Counter does nothing.
+func (adapter *NopCollector) Counter(name string, tags map[string]string, value int64) {}
+
+// Timer does nothing.
+func (adapter *NopCollector) Timer(name string, tags map[string]string, d time.Duration) {}
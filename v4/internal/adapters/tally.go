@@ -0,0 +1,53 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package adapters
+
+import (
+	"time"
+
+	"github.com/uber-go/tally/v4"
+)
+
+// TallyCollector represents an adapter for the tally metrics scope.
+type TallyCollector struct {
+	s tally.Scope
+}
+
+// NewTallyCollector creates a tally metrics collector adapter for the
+// provided scope.
+func NewTallyCollector(scope tally.Scope) *TallyCollector {
+	return &TallyCollector{s: scope}
+}
+
+// scope provides the scope tagged with the provided tags.
+func (adapter *TallyCollector) scope(tags map[string]string) tally.Scope {
+	if len(tags) == 0 {
+		return adapter.s
+	}
+	return adapter.s.Tagged(tags)
+}
+
+// Counter increments the counter with the provided name and tags by the
+// provided value.
+func (adapter *TallyCollector) Counter(name string, tags map[string]string, value int64) {
+	adapter.scope(tags).Counter(name).Inc(value)
+}
+
+// Timer records the provided duration for the timer with the provided name
+// and tags.
+func (adapter *TallyCollector) Timer(name string, tags map[string]string, d time.Duration) {
+	adapter.scope(tags).Timer(name).Record(d)
+}
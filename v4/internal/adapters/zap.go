@@ -31,20 +31,20 @@ func NewZapLogger(logger *zap.Logger) *ZapLogger {
 
 // Debug logs the provided message with arguments as a 'debug' level message.
 func (adapter *ZapLogger) Debug(msg string, args ...any) {
-	adapter.l.Sugar().Debugw(msg, args...)
+	adapter.l.Sugar().Debugw(msg, pairs(args)...)
 }
 
 // Info logs the provided message with arguments as a 'info' level message.
 func (adapter *ZapLogger) Info(msg string, args ...any) {
-	adapter.l.Sugar().Infow(msg, args...)
+	adapter.l.Sugar().Infow(msg, pairs(args)...)
 }
 
 // Warn logs the provided message with arguments as a 'warn' level message.
 func (adapter *ZapLogger) Warn(msg string, args ...any) {
-	adapter.l.Sugar().Warnw(msg, args...)
+	adapter.l.Sugar().Warnw(msg, pairs(args)...)
 }
 
 // Error logs the provided message with arguments as an 'error' level message.
 func (adapter *ZapLogger) Error(msg string, args ...any) {
-	adapter.l.Sugar().Errorw(msg, args...)
+	adapter.l.Sugar().Errorw(msg, pairs(args)...)
 }
@@ -54,6 +54,29 @@ func BenchmarkRegister(b *testing.B) {
 	}
 }
 
+// BenchmarkRegisterBatch benchmarks the RegisterBatch method for work units.
+func BenchmarkRegisterBatch(b *testing.B) {
+	ctx := context.Background()
+	entities := setupEntities()
+	t := unit.TypeNameOf(test.Foo{})
+	mappers := map[unit.TypeName]unit.DataMapper{
+		t: NoOpDataMapper{},
+	}
+	b.StopTimer()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		unit, err := unit.New(unit.DataMappers(mappers))
+		if err != nil {
+			b.FailNow()
+		}
+		b.StartTimer()
+		if err = unit.RegisterBatch(ctx, t, entities); err != nil {
+			b.FailNow()
+		}
+		b.StopTimer()
+	}
+}
+
 // BenchmarkAdd benchmarks the Add method for work units.
 func BenchmarkAdd(b *testing.B) {
 	ctx := context.Background()
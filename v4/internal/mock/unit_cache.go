@@ -76,3 +76,97 @@ func (mr *UnitCacheClientMockRecorder) Set(arg0, arg1, arg2 interface{}) *gomock
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*UnitCacheClient)(nil).Set), arg0, arg1, arg2)
 }
+
+// UnitCacheMultiClient is a mock of UnitCacheMultiClient interface.
+type UnitCacheMultiClient struct {
+	ctrl     *gomock.Controller
+	recorder *UnitCacheMultiClientMockRecorder
+}
+
+// UnitCacheMultiClientMockRecorder is the mock recorder for UnitCacheMultiClient.
+type UnitCacheMultiClientMockRecorder struct {
+	mock *UnitCacheMultiClient
+}
+
+// NewUnitCacheMultiClient creates a new mock instance.
+func NewUnitCacheMultiClient(ctrl *gomock.Controller) *UnitCacheMultiClient {
+	mock := &UnitCacheMultiClient{ctrl: ctrl}
+	mock.recorder = &UnitCacheMultiClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *UnitCacheMultiClient) EXPECT() *UnitCacheMultiClientMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *UnitCacheMultiClient) Delete(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *UnitCacheMultiClientMockRecorder) Delete(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*UnitCacheMultiClient)(nil).Delete), arg0, arg1)
+}
+
+// DeleteMulti mocks base method.
+func (m *UnitCacheMultiClient) DeleteMulti(arg0 context.Context, arg1 []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMulti", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteMulti indicates an expected call of DeleteMulti.
+func (mr *UnitCacheMultiClientMockRecorder) DeleteMulti(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMulti", reflect.TypeOf((*UnitCacheMultiClient)(nil).DeleteMulti), arg0, arg1)
+}
+
+// Get mocks base method.
+func (m *UnitCacheMultiClient) Get(arg0 context.Context, arg1 string) (interface{}, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0, arg1)
+	ret0, _ := ret[0].(interface{})
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *UnitCacheMultiClientMockRecorder) Get(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*UnitCacheMultiClient)(nil).Get), arg0, arg1)
+}
+
+// Set mocks base method.
+func (m *UnitCacheMultiClient) Set(arg0 context.Context, arg1 string, arg2 interface{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Set", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Set indicates an expected call of Set.
+func (mr *UnitCacheMultiClientMockRecorder) Set(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*UnitCacheMultiClient)(nil).Set), arg0, arg1, arg2)
+}
+
+// SetMulti mocks base method.
+func (m *UnitCacheMultiClient) SetMulti(arg0 context.Context, arg1 map[string]interface{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetMulti", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetMulti indicates an expected call of SetMulti.
+func (mr *UnitCacheMultiClientMockRecorder) SetMulti(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMulti", reflect.TypeOf((*UnitCacheMultiClient)(nil).SetMulti), arg0, arg1)
+}
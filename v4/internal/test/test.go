@@ -36,3 +36,16 @@ func (b Baz) ID() interface{} { return b.Identifier }
 type Biz struct {
 	Identifier string
 }
+
+type Qux struct {
+	Key  string `work:"id"`
+	Name string
+}
+
+type Quux struct {
+	id interface{}
+}
+
+func (q *Quux) ID() interface{} { return q.id }
+
+func (q *Quux) SetID(id interface{}) { q.id = id }
@@ -18,9 +18,17 @@ package work
 import (
 	"context"
 	"database/sql"
+	"database/sql/driver"
+	"errors"
 	"fmt"
+	"io"
+	"net"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/avast/retry-go/v4"
+	"github.com/google/uuid"
 	"go.uber.org/multierr"
 )
 
@@ -28,38 +36,147 @@ var (
 	sqlUnitTag = map[string]string{
 		"unit_type": "sql",
 	}
+
+	// ErrCommitAmbiguous represents the error that is returned when the
+	// outcome of committing the transaction for a work unit is unknown, such
+	// as when the connection to the database is lost during the commit. In
+	// this scenario the changes may or may not have been applied, and the
+	// save is not retried since doing so could duplicate data.
+	ErrCommitAmbiguous = errors.New("unable to determine if transaction was committed")
+
+	// ErrPreflightPing represents the error that is returned when a healthy
+	// connection to the SQL store cannot be verified before saving. In this
+	// scenario no data mappers have been invoked and nothing was applied.
+	ErrPreflightPing = errors.New("unable to verify connection before saving")
 )
 
+// isCommitAmbiguous determines if the provided error returned when committing
+// a transaction leaves the outcome of the commit unknown.
+func isCommitAmbiguous(err error) bool {
+	// the transaction is rolled back when the context is done prior to
+	// the commit being issued.
+	if errors.Is(err, sql.ErrTxDone) ||
+		errors.Is(err, context.Canceled) ||
+		errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	// errors reported by the database itself indicate the commit was
+	// rejected, whereas connection level errors leave the outcome unknown.
+	var netErr net.Error
+	return errors.Is(err, driver.ErrBadConn) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.As(err, &netErr)
+}
+
 type sqlUnit struct {
-	unit
+	*unit
 }
 
-func (u *sqlUnit) rollback(tx *sql.Tx) (err error) {
+func (u *sqlUnit) rollback(ctx context.Context, tx *sql.Tx) (err error) {
 
-	//setup timer.
-	stop := u.scope.Timer(rollback).Start().Stop
+	//setup timer and span.
+	stop := u.scope.start(u.metrics.Rollback)
+	_, end := u.startSpan(ctx, UnitSpanRollback)
 
 	//log and capture metrics.
 	defer func() {
 		stop()
+		end(err)
 		if err != nil {
-			u.scope.Counter(rollbackFailure).Inc(1)
+			u.scope.counter(u.metrics.RollbackFailure, 1)
 		} else {
-			u.scope.Counter(rollbackSuccess).Inc(1)
+			u.scope.counter(u.metrics.RollbackSuccess, 1)
 		}
 	}()
 	err = tx.Rollback()
 	return
 }
 
-func (u *sqlUnit) applyInserts(ctx context.Context, mCtx UnitMapperContext) (err error) {
-	for typeName, additions := range u.additions {
+// abort rolls back the provided transaction due to the provided error.
+func (u *sqlUnit) abort(ctx context.Context, tx *sql.Tx, err error) error {
+	u.executeActions(ctx, UnitActionTypeBeforeRollback)
+	errRollback := u.rollback(ctx, tx)
+	if errRollback == nil {
+		u.executeRollbackActions(ctx, err)
+	}
+	err = multierr.Combine(err, errRollback)
+	u.logger.Error(err.Error())
+	return err
+}
+
+// applySessionSettings applies the session settings of the work unit to the
+// provided transaction using set_config, the parameterized equivalent of
+// SET LOCAL, so that the settings are discarded once the transaction ends.
+func (u *sqlUnit) applySessionSettings(ctx context.Context, tx *sql.Tx) (err error) {
+	names := make([]string, 0, len(u.sessionSettings))
+	for name := range u.sessionSettings {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		_, err = tx.ExecContext(ctx, "SELECT set_config($1, $2, true)", name, u.sessionSettings[name])
+		if err != nil {
+			return
+		}
+	}
+	return
+}
+
+// quoteIdentifier quotes each part of the provided, possibly schema-qualified,
+// identifier, such that it cannot be interpreted as anything other than an
+// identifier.
+func quoteIdentifier(name string) string {
+	parts := strings.Split(name, ".")
+	for i, part := range parts {
+		parts[i] = `"` + strings.ReplaceAll(part, `"`, `""`) + `"`
+	}
+	return strings.Join(parts, ".")
+}
+
+func (u *sqlUnit) setConstraintsDeferred(ctx context.Context, tx *sql.Tx) (err error) {
+	if !u.deferConstraints {
+		return
+	}
+	constraints := "ALL"
+	if len(u.deferredConstraints) > 0 {
+		names := make([]string, 0, len(u.deferredConstraints))
+		for _, name := range u.deferredConstraints {
+			names = append(names, quoteIdentifier(name))
+		}
+		constraints = strings.Join(names, ", ")
+	}
+	_, err = tx.ExecContext(ctx, fmt.Sprintf("SET CONSTRAINTS %s DEFERRED", constraints))
+	return
+}
+
+func (u *sqlUnit) applyInserts(ctx context.Context, mCtx UnitMapperContext, c sqlChunk) (err error) {
+	for typeName, additions := range c.additions {
 		if f, ok := u.insertFunc(typeName); ok {
-			if err = f(ctx, mCtx, additions...); err != nil {
-				u.executeActions(UnitActionTypeBeforeRollback)
-				errRollback := u.rollback(mCtx.Tx)
+			if err = operationErr(typeName, UnitOperationInsert, u.applyEntities(ctx, mCtx, typeName, f, additions)); err != nil {
+				u.executeActions(ctx, UnitActionTypeBeforeRollback)
+				errRollback := u.rollback(ctx, mCtx.Tx)
+				if errRollback == nil {
+					u.executeRollbackActions(ctx, err)
+				}
+				err = multierr.Combine(err, errRollback)
+				u.logger.Error(err.Error(), "typeName", typeName.String())
+				return
+			}
+		}
+	}
+	return
+}
+
+func (u *sqlUnit) applyUpserts(ctx context.Context, mCtx UnitMapperContext, c sqlChunk) (err error) {
+	for typeName, upserts := range c.upserts {
+		if f, ok := u.upsertFunc(typeName); ok {
+			if err = operationErr(typeName, UnitOperationUpsert, u.applyEntities(ctx, mCtx, typeName, f, upserts)); err != nil {
+				u.executeActions(ctx, UnitActionTypeBeforeRollback)
+				errRollback := u.rollback(ctx, mCtx.Tx)
 				if errRollback == nil {
-					u.executeActions(UnitActionTypeAfterRollback)
+					u.executeRollbackActions(ctx, err)
 				}
 				err = multierr.Combine(err, errRollback)
 				u.logger.Error(err.Error(), "typeName", typeName.String())
@@ -70,13 +187,32 @@ func (u *sqlUnit) applyInserts(ctx context.Context, mCtx UnitMapperContext) (err
 	return
 }
 
-func (u *sqlUnit) applyUpdates(ctx context.Context, mCtx UnitMapperContext) (err error) {
-	for typeName, alterations := range u.alterations {
+func (u *sqlUnit) applyUpdates(ctx context.Context, mCtx UnitMapperContext, c sqlChunk) (err error) {
+	for typeName, alterations := range c.alterations {
 		if f, ok := u.updateFunc(typeName); ok {
-			if err = f(ctx, mCtx, alterations...); err != nil {
-				errRollback := u.rollback(mCtx.Tx)
+			if err = operationErr(typeName, UnitOperationUpdate, u.applyEntities(ctx, mCtx, typeName, f, alterations)); err != nil {
+				errRollback := u.rollback(ctx, mCtx.Tx)
+				if errRollback == nil {
+					u.executeRollbackActions(ctx, err)
+				}
+				err = multierr.Combine(err, errRollback)
+				u.logger.Error(err.Error(), "typeName", typeName.String())
+				return
+			}
+		}
+	}
+	return
+}
+
+func (u *sqlUnit) applyPatches(ctx context.Context, mCtx UnitMapperContext, c sqlChunk) (err error) {
+	for typeName, patches := range c.patches {
+		if f, ok := u.patchFunc(typeName); ok {
+			mCtx.typeName = typeName
+			if err = operationErr(typeName, UnitOperationPatch, f(ctx, mCtx, patches...)); err != nil {
+				u.executeActions(ctx, UnitActionTypeBeforeRollback)
+				errRollback := u.rollback(ctx, mCtx.Tx)
 				if errRollback == nil {
-					u.executeActions(UnitActionTypeAfterRollback)
+					u.executeRollbackActions(ctx, err)
 				}
 				err = multierr.Combine(err, errRollback)
 				u.logger.Error(err.Error(), "typeName", typeName.String())
@@ -87,14 +223,33 @@ func (u *sqlUnit) applyUpdates(ctx context.Context, mCtx UnitMapperContext) (err
 	return
 }
 
-func (u *sqlUnit) applyDeletes(ctx context.Context, mCtx UnitMapperContext) (err error) {
-	for typeName, removals := range u.removals {
+func (u *sqlUnit) applyDeletes(ctx context.Context, mCtx UnitMapperContext, c sqlChunk) (err error) {
+	for typeName, removals := range c.removals {
 		if f, ok := u.deleteFunc(typeName); ok {
-			if err = f(ctx, mCtx, removals...); err != nil {
-				u.executeActions(UnitActionTypeBeforeRollback)
-				errRollback := u.rollback(mCtx.Tx)
+			if err = operationErr(typeName, UnitOperationDelete, u.applyEntities(ctx, mCtx, typeName, f, removals)); err != nil {
+				u.executeActions(ctx, UnitActionTypeBeforeRollback)
+				errRollback := u.rollback(ctx, mCtx.Tx)
+				if errRollback == nil {
+					u.executeRollbackActions(ctx, err)
+				}
+				err = multierr.Combine(err, errRollback)
+				u.logger.Error(err.Error(), "typeName", typeName.String())
+				return
+			}
+		}
+	}
+	return
+}
+
+func (u *sqlUnit) applyDeletesWhere(ctx context.Context, mCtx UnitMapperContext, c sqlChunk) (err error) {
+	for typeName, criteria := range c.removalCriteria {
+		if f, ok := u.deleteWhereFunc(typeName); ok {
+			mCtx.typeName = typeName
+			if err = operationErr(typeName, UnitOperationDeleteWhere, f(ctx, mCtx, criteria...)); err != nil {
+				u.executeActions(ctx, UnitActionTypeBeforeRollback)
+				errRollback := u.rollback(ctx, mCtx.Tx)
 				if errRollback == nil {
-					u.executeActions(UnitActionTypeAfterRollback)
+					u.executeRollbackActions(ctx, err)
 				}
 				err = multierr.Combine(err, errRollback)
 				u.logger.Error(err.Error(), "typeName", typeName.String())
@@ -105,87 +260,316 @@ func (u *sqlUnit) applyDeletes(ctx context.Context, mCtx UnitMapperContext) (err
 	return
 }
 
-func (u *sqlUnit) save(ctx context.Context) (err error) {
-	//start transaction.
-	tx, err := u.db.BeginTx(ctx, nil)
-	mCtx := UnitMapperContext{Tx: tx}
+func (u *sqlUnit) save(ctx context.Context, mCtx UnitMapperContext, c sqlChunk) (err error) {
+	u.timings.attempt()
+
+	//snapshot the staged entities, so that those staged while saving can be
+	//applied in deferred passes, and discarded should the transaction fail.
+	var base sqlChunk
+	if u.deferredStagingPasses > 0 {
+		base = u.snapshot()
+		defer func() {
+			if err != nil {
+				u.restore(base)
+			}
+		}()
+	}
+
+	//await prior writes.
+	if err = u.AwaitToken(ctx); err != nil {
+		return
+	}
+
+	//start transaction on a dedicated connection, so that data mappers can
+	//access the driver connection the transaction is open on.
+	var conn *sql.Conn
+	var tx *sql.Tx
+	err = u.timed(UnitSavePhaseBegin, func() (err error) {
+		if conn, err = u.db.Conn(ctx); err != nil {
+			return
+		}
+		if tx, err = conn.BeginTx(ctx, nil); err != nil {
+			conn.Close()
+		}
+		return
+	})
+	mCtx.Tx = tx
+	mCtx.conn = conn
+	mCtx.guard = u.txGuard()
+	defer mCtx.guard.close()
+	mCtx.generated = &unitGeneratedIDs{}
+	mCtx.commentTags = u.sqlCommentTags
+	mCtx.driverName = u.driverName
 	if err != nil {
 		// consider a failure to begin transaction as successful rollback,
 		// since none of the desired changes are applied.
-		u.scope.Counter(rollbackSuccess).Inc(1)
+		u.scope.counter(u.metrics.RollbackSuccess, 1)
 		u.logger.Error(err.Error())
 		return
 	}
 
+	//release the connection once the transaction has completed, rolling back
+	//should it remain open.
+	defer func() {
+		tx.Rollback()
+		conn.Close()
+	}()
+
+	//record how long the transaction is held open, excluding the time spent
+	//between attempts.
+	held := time.Now()
+	defer func() { u.scope.timer(u.metrics.TxHold, time.Since(held)) }()
+
+	//discard entities quarantined when the transaction is not committed.
+	mark := u.quarantineMark()
+	defer func() {
+		if err != nil {
+			u.resetQuarantined(mark)
+		}
+	}()
+
 	//rollback if there is a panic.
 	defer func() {
 		if r := recover(); r != nil {
-			u.executeActions(UnitActionTypeBeforeRollback)
-			if err = u.rollback(tx); err == nil {
-				u.executeActions(UnitActionTypeAfterRollback)
-			}
 			msg := "panic: unable to save work unit"
+			u.executeActions(ctx, UnitActionTypeBeforeRollback)
+			if err = u.rollback(ctx, tx); err == nil {
+				u.executeRollbackActions(ctx, fmt.Errorf("%s\n%v", msg, r))
+			}
 			err = multierr.Combine(fmt.Errorf("%s\n%v", msg, r), err)
 			u.logger.Error(msg, "panic", fmt.Sprintf("%v", r))
 			panic(r)
 		}
 	}()
 
+	//defer constraint checking until commit.
+	if err = u.setConstraintsDeferred(ctx, tx); err != nil {
+		u.executeActions(ctx, UnitActionTypeBeforeRollback)
+		errRollback := u.rollback(ctx, tx)
+		if errRollback == nil {
+			u.executeRollbackActions(ctx, err)
+		}
+		err = multierr.Combine(err, errRollback)
+		u.logger.Error(err.Error())
+		return
+	}
+
+	//apply session settings for the transaction.
+	if err = u.applySessionSettings(ctx, tx); err != nil {
+		u.executeActions(ctx, UnitActionTypeBeforeRollback)
+		errRollback := u.rollback(ctx, tx)
+		if errRollback == nil {
+			u.executeRollbackActions(ctx, err)
+		}
+		err = multierr.Combine(err, errRollback)
+		u.logger.Error(err.Error())
+		return
+	}
+
+	//verify uniqueness before any writes occur.
+	if err = u.checkUniqueness(ctx, mCtx, c.additions, c.upserts, c.alterations); err != nil {
+		return retry.Unrecoverable(u.abort(ctx, tx, err))
+	}
+
 	//insert newly added entities.
-	u.executeActions(UnitActionTypeBeforeInserts)
-	if err = u.applyInserts(ctx, mCtx); err != nil {
+	if err = u.executeActions(ctx, UnitActionTypeBeforeInserts); err != nil {
+		return retry.Unrecoverable(u.abort(ctx, tx, err))
+	}
+	if err = u.timed(UnitSavePhaseInserts, func() error { return u.applyInserts(ctx, mCtx, c) }); err != nil {
 		return
 	}
-	u.executeActions(UnitActionTypeAfterInserts)
+	u.executeActions(ctx, UnitActionTypeAfterInserts)
+
+	//upsert upserted entities.
+	if err = u.executeActions(ctx, UnitActionTypeBeforeUpserts); err != nil {
+		return retry.Unrecoverable(u.abort(ctx, tx, err))
+	}
+	if err = u.timed(UnitSavePhaseUpserts, func() error { return u.applyUpserts(ctx, mCtx, c) }); err != nil {
+		return
+	}
+	u.executeActions(ctx, UnitActionTypeAfterUpserts)
 
 	//update altered entities.
-	u.executeActions(UnitActionTypeBeforeUpdates)
-	if err = u.applyUpdates(ctx, mCtx); err != nil {
+	if err = u.executeActions(ctx, UnitActionTypeBeforeUpdates); err != nil {
+		return retry.Unrecoverable(u.abort(ctx, tx, err))
+	}
+	err = u.timed(UnitSavePhaseUpdates, func() error {
+		if err := u.applyUpdates(ctx, mCtx, c); err != nil {
+			return err
+		}
+		return u.applyPatches(ctx, mCtx, c)
+	})
+	if err != nil {
 		return
 	}
-	u.executeActions(UnitActionTypeAfterUpdates)
+	u.executeActions(ctx, UnitActionTypeAfterUpdates)
 
 	//delete removed entities.
-	u.executeActions(UnitActionTypeBeforeDeletes)
-	if err = u.applyDeletes(ctx, mCtx); err != nil {
+	if err = u.executeActions(ctx, UnitActionTypeBeforeDeletes); err != nil {
+		return retry.Unrecoverable(u.abort(ctx, tx, err))
+	}
+	err = u.timed(UnitSavePhaseDeletes, func() error {
+		if err := u.applyDeletes(ctx, mCtx, c); err != nil {
+			return err
+		}
+		return u.applyDeletesWhere(ctx, mCtx, c)
+	})
+	if err != nil {
 		return
 	}
-	u.executeActions(UnitActionTypeAfterDeletes)
+	u.executeActions(ctx, UnitActionTypeAfterDeletes)
+
+	//apply entities staged while saving.
+	if u.deferredStagingPasses > 0 {
+		if err = u.applyDeferred(ctx, mCtx, base); err != nil {
+			return
+		}
+	}
+
+	if err = u.timed(UnitSavePhaseCommit, tx.Commit); err != nil {
+		if isCommitAmbiguous(err) {
+			// neither a rollback nor a retry can be performed safely, since
+			// the transaction may have been committed.
+			u.scope.counter(u.metrics.CommitAmbiguous, 1)
+			err = multierr.Combine(ErrCommitAmbiguous, err)
+			u.logger.Error(err.Error())
+			err = retry.Unrecoverable(err)
+			return
+		}
 
-	if err = tx.Commit(); err != nil {
 		// consider error during transaction commit as successful rollback,
 		// since the rollback is implicitly done.
 		// please see https://golang.org/src/database/sql/sql.go#L1991 for reference.
-		u.executeActions(UnitActionTypeAfterRollback)
-		u.scope.Counter(rollbackSuccess).Inc(1)
+		u.executeRollbackActions(ctx, err)
+		u.scope.counter(u.metrics.RollbackSuccess, 1)
 		u.logger.Error(err.Error())
 		return
 	}
+	u.applyGeneratedIDs(ctx, mCtx.generated)
 	return
 }
 
+// preflight verifies that a healthy connection to the SQL store is available
+// when configured to do so.
+func (u *sqlUnit) preflight(ctx context.Context) error {
+	if !u.preflightPing {
+		return nil
+	}
+	if err := u.db.PingContext(ctx); err != nil {
+		u.scope.counter(u.metrics.PreflightFailure, 1)
+		err = multierr.Combine(ErrPreflightPing, err)
+		u.logger.Error(err.Error())
+		return u.classify(err)
+	}
+	return nil
+}
+
 // Save commits the new additions, modifications, and removals
 // within the work unit to an SQL store.
-func (u *sqlUnit) Save(ctx context.Context) (err error) {
-	u.executeActions(UnitActionTypeBeforeSave)
+func (u *sqlUnit) Save(ctx context.Context, opts ...UnitSaveOption) (err error) {
+	o := newUnitSaveOptions(opts)
+	ctx, end := u.traceSave(ctx)
+	defer func() { end(err) }()
+	return u.withSaveMiddleware(func(ctx context.Context) error {
+		return u.saveAll(ctx, o)
+	})(ctx)
+}
+
+// saveAll performs the save pipeline of the work unit.
+func (u *sqlUnit) saveAll(ctx context.Context, opts unitSaveOptions) (err error) {
+	u.checkGoroutine("Save")
+	defer u.beginSaving()()
+	if err = u.failed(); err != nil {
+		u.logger.Error(err.Error())
+		return
+	}
+	if err = u.terminalErr(); err != nil {
+		return
+	}
+	defer func() {
+		if err != nil && !errors.Is(err, ErrConfirmationRequired) {
+			u.transition(UnitStatusFailed)
+		}
+	}()
+	if err = u.preflight(ctx); err != nil {
+		return
+	}
+	if err = u.executeActions(ctx, UnitActionTypeBeforeSave); err != nil {
+		return
+	}
+	if err = u.authorize(ctx); err != nil {
+		return
+	}
+	if err = u.checkBoundaries(); err != nil {
+		return
+	}
+	if err = u.verifyLockTokens(ctx); err != nil {
+		return
+	}
+	if err = u.guardMutations(opts.confirmation); err != nil {
+		return
+	}
 
 	//setup timer.
-	stop := u.scope.Timer(save).Start().Stop
+	stop := u.scope.start(u.metrics.Save)
+	start := time.Now()
+	saveID := uuid.NewString()
+	u.timings.reset()
+	u.history.reset()
+	u.resetQuarantined(0)
 	defer func() {
 		stop()
+		defer func() {
+			u.detectSlowSave(saveID, time.Since(start), err)
+			u.executeSaveActions(ctx, time.Since(start), err)
+		}()
 		if r := recover(); r != nil {
 			panic(r)
 		}
 		if err == nil {
-			u.scope.Counter(saveSuccess).Inc(1)
-			u.scope.Counter(insert).Inc(int64(u.additionCount))
-			u.scope.Counter(update).Inc(int64(u.alterationCount))
-			u.scope.Counter(delete).Inc(int64(u.removalCount))
-			u.executeActions(UnitActionTypeAfterSave)
+			u.transition(UnitStatusCompleted)
+			u.scope.counter(u.metrics.SaveSuccess, 1)
+			u.scope.counter(u.metrics.Insert, int64(u.additionCount))
+			u.scope.counter(u.metrics.Upsert, int64(u.upsertCount))
+			u.scope.counter(u.metrics.Update, int64(u.alterationCount))
+			u.scope.counter(u.metrics.Delete, int64(u.removalCount))
+			u.scope.counter(u.metrics.Patch, int64(u.patchCount))
+			u.scope.counter(u.metrics.DeleteWhere, int64(u.criteriaCount))
+			u.captureCommitToken(ctx)
+			u.refresh(ctx, UnitMapperContext{SaveID: saveID})
+			u.advanceLockTokens(ctx)
+			u.emitChangeRecords(ctx, saveID)
+			u.persistQuarantined(ctx)
+			u.executeActions(ctx, UnitActionTypeAfterSave)
+		} else {
+			err = u.classify(err)
 		}
 	}()
 
-	u.retryOptions = append(u.retryOptions, retry.Context(ctx))
-	err = retry.Do(func() error { return u.save(ctx) }, u.retryOptions...)
+	u.retryOptions = append(u.retryOptions, retry.Context(ctx), u.onRetry(ctx, saveID, nil))
+	chunks := u.chunks()
+	if len(chunks) > 1 {
+		err = u.saveChunks(ctx, saveID, chunks)
+		return
+	}
+	next := u.attempts(saveID)
+	err = retry.Do(func() error {
+		mCtx := next()
+		return u.history.record(mCtx, u.save(ctx, mCtx, chunks[0]))
+	}, u.retryOptions...)
+	err = u.history.wrap(err)
 	return
 }
+
+func (u *sqlUnit) SaveBackground() error {
+	return u.Save(context.Background())
+}
+
+// SaveAsync commits the new additions, modifications, and removals within
+// the work unit in a new goroutine, providing the future for the outcome of
+// the save.
+func (u *sqlUnit) SaveAsync(ctx context.Context) *UnitSaveFuture {
+	return NewUnitSaveFuture(ctx, func(ctx context.Context) error {
+		return u.Save(ctx)
+	})
+}
@@ -0,0 +1,254 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package work
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/avast/retry-go/v4"
+)
+
+// UnitTransactionChunk represents the outcome of a single transaction
+// performed when the operations of a work unit are split across multiple
+// transactions.
+type UnitTransactionChunk struct {
+	// Index is the position of the chunk, starting at zero, in the order the
+	// chunks are committed.
+	Index int
+	// Operations is the number of operations within the chunk.
+	Operations int
+	// Committed indicates whether the chunk was committed.
+	Committed bool
+	// Err is the error encountered when saving the chunk, if any.
+	Err error
+}
+
+// UnitPartialSaveError represents the error that is returned when the
+// operations of a work unit are split across multiple transactions and one
+// of them fails. The chunks preceding the failed chunk remain committed,
+// while the failed chunk and those following it are not applied and remain
+// staged within the work unit.
+type UnitPartialSaveError struct {
+	// Chunks are the outcomes of each chunk of the work unit.
+	Chunks []UnitTransactionChunk
+	// Err is the error encountered by the failed chunk.
+	Err error
+}
+
+// Error provides the error message.
+func (e *UnitPartialSaveError) Error() string {
+	var committed []string
+	for _, c := range e.Chunks {
+		if c.Committed {
+			committed = append(committed, fmt.Sprint(c.Index))
+		}
+	}
+	return fmt.Sprintf("partial save: committed %d of %d chunks [%s]: %v",
+		len(committed), len(e.Chunks), strings.Join(committed, ", "), e.Err)
+}
+
+// Unwrap provides the error encountered by the failed chunk.
+func (e *UnitPartialSaveError) Unwrap() error {
+	return e.Err
+}
+
+// sqlChunk represents the operations applied within a single transaction.
+type sqlChunk struct {
+	additions       map[TypeName][]interface{}
+	upserts         map[TypeName][]interface{}
+	alterations     map[TypeName][]interface{}
+	patches         map[TypeName][]UnitPatch
+	removals        map[TypeName][]interface{}
+	removalCriteria map[TypeName][]interface{}
+	operations      int
+}
+
+func newSQLChunk() sqlChunk {
+	return sqlChunk{
+		additions:       make(map[TypeName][]interface{}),
+		upserts:         make(map[TypeName][]interface{}),
+		alterations:     make(map[TypeName][]interface{}),
+		patches:         make(map[TypeName][]UnitPatch),
+		removals:        make(map[TypeName][]interface{}),
+		removalCriteria: make(map[TypeName][]interface{}),
+	}
+}
+
+func typeNames(m map[TypeName][]interface{}) []TypeName {
+	names := make([]TypeName, 0, len(m))
+	for t := range m {
+		names = append(names, t)
+	}
+	sort.Slice(names, func(i, j int) bool { return names[i] < names[j] })
+	return names
+}
+
+// chunks splits the operations of the work unit into chunks containing at
+// most the configured maximum number of operations, preserving the order in
+// which the operations are applied. When no maximum is configured, or the
+// operations fit within it, a single chunk containing all operations is
+// provided.
+func (u *sqlUnit) chunks() []sqlChunk {
+	total := u.additionCount + u.upsertCount + u.alterationCount +
+		u.patchCount + u.removalCount + u.criteriaCount
+	if u.deferredStagingPasses > 0 && (u.maxOperationsPerTransaction <= 0 || total <= u.maxOperationsPerTransaction) {
+		// entities staged while saving must not be visible to the chunk.
+		return []sqlChunk{u.snapshot()}
+	}
+	if u.maxOperationsPerTransaction <= 0 || total <= u.maxOperationsPerTransaction {
+		return []sqlChunk{{
+			additions:       u.additions,
+			upserts:         u.upserts,
+			alterations:     u.alterations,
+			patches:         u.patches,
+			removals:        u.removals,
+			removalCriteria: u.removalCriteria,
+			operations:      total,
+		}}
+	}
+
+	max := u.maxOperationsPerTransaction
+	chunks := []sqlChunk{newSQLChunk()}
+	// reserve provides the chunk to place up to n operations in, along with
+	// the number of operations it can accommodate.
+	reserve := func(n int) (*sqlChunk, int) {
+		c := &chunks[len(chunks)-1]
+		if c.operations == max {
+			chunks = append(chunks, newSQLChunk())
+			c = &chunks[len(chunks)-1]
+		}
+		if available := max - c.operations; n > available {
+			n = available
+		}
+		c.operations = c.operations + n
+		return c, n
+	}
+	split := func(m map[TypeName][]interface{}, target func(*sqlChunk) map[TypeName][]interface{}) {
+		for _, t := range typeNames(m) {
+			for entities := m[t]; len(entities) > 0; {
+				c, n := reserve(len(entities))
+				target(c)[t] = append(target(c)[t], entities[:n]...)
+				entities = entities[n:]
+			}
+		}
+	}
+	split(u.additions, func(c *sqlChunk) map[TypeName][]interface{} { return c.additions })
+	split(u.upserts, func(c *sqlChunk) map[TypeName][]interface{} { return c.upserts })
+	split(u.alterations, func(c *sqlChunk) map[TypeName][]interface{} { return c.alterations })
+	patchTypes := make([]TypeName, 0, len(u.patches))
+	for t := range u.patches {
+		patchTypes = append(patchTypes, t)
+	}
+	sort.Slice(patchTypes, func(i, j int) bool { return patchTypes[i] < patchTypes[j] })
+	for _, t := range patchTypes {
+		for patches := u.patches[t]; len(patches) > 0; {
+			c, n := reserve(len(patches))
+			c.patches[t] = append(c.patches[t], patches[:n]...)
+			patches = patches[n:]
+		}
+	}
+	split(u.removals, func(c *sqlChunk) map[TypeName][]interface{} { return c.removals })
+	split(u.removalCriteria, func(c *sqlChunk) map[TypeName][]interface{} { return c.removalCriteria })
+	return chunks
+}
+
+// saveChunks saves each of the provided chunks in its own transaction, in
+// order, stopping at the first chunk that fails after exhausting its retries.
+func (u *sqlUnit) saveChunks(ctx context.Context, saveID string, chunks []sqlChunk) error {
+	results := make([]UnitTransactionChunk, len(chunks))
+	for i, c := range chunks {
+		results[i] = UnitTransactionChunk{Index: i, Operations: c.operations}
+	}
+	for i, c := range chunks {
+		next := u.attempts(saveID)
+		u.history.reset()
+		err := retry.Do(func() error {
+			mCtx := next()
+			return u.history.record(mCtx, u.save(ctx, mCtx, c))
+		}, u.retryOptions...)
+		err = u.history.wrap(err)
+		if err != nil {
+			results[i].Err = err
+			u.logger.Error("unable to save chunk", "chunk", i, "chunks", len(chunks), "error", err.Error())
+			u.settle(ctx, saveID, chunks[:i], chunks[i:])
+			return &UnitPartialSaveError{Chunks: results, Err: err}
+		}
+		results[i].Committed = true
+	}
+	return nil
+}
+
+// settle completes the chunks committed before a chunk failed, leaving only
+// the chunks that were not applied staged within the work unit so that saving
+// it once more does not apply the committed chunks again.
+func (u *sqlUnit) settle(ctx context.Context, saveID string, committed, pending []sqlChunk) {
+	if len(committed) > 0 {
+		u.stage(merge(committed))
+		u.captureCommitToken(ctx)
+		u.refresh(ctx, UnitMapperContext{SaveID: saveID})
+		u.advanceLockTokens(ctx)
+		u.emitChangeRecords(ctx, saveID)
+	}
+	u.stage(merge(pending))
+}
+
+// merge combines the provided chunks into a single chunk, preserving the
+// order of their operations.
+func merge(chunks []sqlChunk) sqlChunk {
+	merged := newSQLChunk()
+	mergeInto := func(dst, src map[TypeName][]interface{}) {
+		for t, entities := range src {
+			dst[t] = append(dst[t], entities...)
+		}
+	}
+	for _, c := range chunks {
+		mergeInto(merged.additions, c.additions)
+		mergeInto(merged.upserts, c.upserts)
+		mergeInto(merged.alterations, c.alterations)
+		mergeInto(merged.removals, c.removals)
+		mergeInto(merged.removalCriteria, c.removalCriteria)
+		for t, patches := range c.patches {
+			merged.patches[t] = append(merged.patches[t], patches...)
+		}
+		merged.operations = merged.operations + c.operations
+	}
+	return merged
+}
+
+// stage replaces the entities staged within the work unit with those of the
+// provided chunk.
+func (u *unit) stage(c sqlChunk) {
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+	count := func(m map[TypeName][]interface{}) (n int) {
+		for _, entities := range m {
+			n = n + len(entities)
+		}
+		return
+	}
+	u.additions, u.additionCount = c.additions, count(c.additions)
+	u.upserts, u.upsertCount = c.upserts, count(c.upserts)
+	u.alterations, u.alterationCount = c.alterations, count(c.alterations)
+	u.removals, u.removalCount = c.removals, count(c.removals)
+	u.removalCriteria, u.criteriaCount = c.removalCriteria, count(c.removalCriteria)
+	u.patches, u.patchCount = c.patches, 0
+	for _, patches := range c.patches {
+		u.patchCount = u.patchCount + len(patches)
+	}
+}
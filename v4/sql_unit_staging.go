@@ -0,0 +1,149 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package work
+
+import (
+	"context"
+	"errors"
+
+	"github.com/avast/retry-go/v4"
+)
+
+// ErrUnitStagingPassesExceeded represents the error that is returned when
+// entities staged while saving the work unit continue to be staged after the
+// maximum number of deferred staging passes.
+var ErrUnitStagingPassesExceeded = errors.New("maximum deferred staging passes exceeded")
+
+// snapshot provides the entities currently staged within the work unit. The
+// slices are shared with the work unit, which only ever appends to them, so
+// entities staged afterwards are excluded from the snapshot.
+func (u *unit) snapshot() sqlChunk {
+	u.mutex.RLock()
+	defer u.mutex.RUnlock()
+	c := newSQLChunk()
+	copyInto := func(dst, src map[TypeName][]interface{}) {
+		for t, entities := range src {
+			dst[t] = entities
+			c.operations = c.operations + len(entities)
+		}
+	}
+	copyInto(c.additions, u.additions)
+	copyInto(c.upserts, u.upserts)
+	copyInto(c.alterations, u.alterations)
+	copyInto(c.removals, u.removals)
+	copyInto(c.removalCriteria, u.removalCriteria)
+	for t, patches := range u.patches {
+		c.patches[t] = patches
+		c.operations = c.operations + len(patches)
+	}
+	return c
+}
+
+// since provides the entities within the chunk that are absent from the
+// provided base chunk, where both chunks are snapshots of the work unit.
+func (c sqlChunk) since(base sqlChunk) sqlChunk {
+	pending := newSQLChunk()
+	diff := func(dst, now, then map[TypeName][]interface{}) {
+		for t, entities := range now {
+			if n := len(then[t]); len(entities) > n {
+				dst[t] = entities[n:]
+				pending.operations = pending.operations + len(entities) - n
+			}
+		}
+	}
+	diff(pending.additions, c.additions, base.additions)
+	diff(pending.upserts, c.upserts, base.upserts)
+	diff(pending.alterations, c.alterations, base.alterations)
+	diff(pending.removals, c.removals, base.removals)
+	diff(pending.removalCriteria, c.removalCriteria, base.removalCriteria)
+	for t, patches := range c.patches {
+		if n := len(base.patches[t]); len(patches) > n {
+			pending.patches[t] = patches[n:]
+			pending.operations = pending.operations + len(patches) - n
+		}
+	}
+	return pending
+}
+
+// restore discards the entities staged within the work unit after the
+// provided snapshot was taken, such as those staged during a transaction
+// that was rolled back, which are staged once more when it is retried.
+func (u *unit) restore(base sqlChunk) {
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+	truncate := func(m, then map[TypeName][]interface{}, count *int) map[TypeName][]interface{} {
+		restored := make(map[TypeName][]interface{}, len(then))
+		for t, entities := range m {
+			n := len(then[t])
+			*count = *count - (len(entities) - n)
+			if n > 0 {
+				restored[t] = entities[:n]
+			}
+		}
+		return restored
+	}
+	u.additions = truncate(u.additions, base.additions, &u.additionCount)
+	u.upserts = truncate(u.upserts, base.upserts, &u.upsertCount)
+	u.alterations = truncate(u.alterations, base.alterations, &u.alterationCount)
+	u.removals = truncate(u.removals, base.removals, &u.removalCount)
+	u.removalCriteria = truncate(u.removalCriteria, base.removalCriteria, &u.criteriaCount)
+	patches := make(map[TypeName][]UnitPatch, len(base.patches))
+	for t, p := range u.patches {
+		n := len(base.patches[t])
+		u.patchCount = u.patchCount - (len(p) - n)
+		if n > 0 {
+			patches[t] = p[:n]
+		}
+	}
+	u.patches = patches
+}
+
+// applyDeferred applies the entities staged while the transaction was being
+// performed, such as by data mappers deriving child records, in subsequent
+// passes within the same transaction. Phase actions are not executed for
+// these passes.
+func (u *sqlUnit) applyDeferred(ctx context.Context, mCtx UnitMapperContext, base sqlChunk) (err error) {
+	for pass := 1; ; pass++ {
+		now := u.snapshot()
+		pending := now.since(base)
+		if pending.operations == 0 {
+			return
+		}
+		if pass > u.deferredStagingPasses {
+			return retry.Unrecoverable(u.abort(ctx, mCtx.Tx, ErrUnitStagingPassesExceeded))
+		}
+		u.logger.Debug("applying deferred staging pass", "pass", pass, "count", pending.operations)
+		if err = u.applyInserts(ctx, mCtx, pending); err != nil {
+			return
+		}
+		if err = u.applyUpserts(ctx, mCtx, pending); err != nil {
+			return
+		}
+		if err = u.applyUpdates(ctx, mCtx, pending); err != nil {
+			return
+		}
+		if err = u.applyPatches(ctx, mCtx, pending); err != nil {
+			return
+		}
+		if err = u.applyDeletes(ctx, mCtx, pending); err != nil {
+			return
+		}
+		if err = u.applyDeletesWhere(ctx, mCtx, pending); err != nil {
+			return
+		}
+		base = now
+	}
+}
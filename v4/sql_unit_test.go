@@ -18,9 +18,14 @@ package work_test
 import (
 	"context"
 	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"regexp"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/freerware/work/v4"
@@ -44,28 +49,38 @@ type SQLUnitTestSuite struct {
 	scope   tally.TestScope
 	mc      *gomock.Controller
 	mappers map[work.TypeName]*mock.UnitDataMapper
+	opts    []work.UnitOption
 
 	// metrics scope names and tags.
-	scopePrefix                      string
-	saveScopeName                    string
-	saveSuccessScopeName             string
-	saveScopeNameWithTags            string
-	saveSuccessScopeNameWithTags     string
-	rollbackScopeNameWithTags        string
-	rollbackSuccessScopeNameWithTags string
-	rollbackFailureScopeNameWithTags string
-	rollbackScopeName                string
-	rollbackFailureScopeName         string
-	rollbackSuccessScopeName         string
-	retryAttemptScopeName            string
-	retryAttemptScopeNameWithTags    string
-	insertScopeName                  string
-	insertScopeNameWithTags          string
-	updateScopeName                  string
-	updateScopeNameWithTags          string
-	deleteScopeName                  string
-	deleteScopeNameWithTags          string
-	tags                             string
+	scopePrefix                          string
+	saveScopeName                        string
+	saveSuccessScopeName                 string
+	saveScopeNameWithTags                string
+	saveSuccessScopeNameWithTags         string
+	rollbackScopeNameWithTags            string
+	rollbackSuccessScopeNameWithTags     string
+	rollbackFailureScopeNameWithTags     string
+	rollbackScopeName                    string
+	rollbackFailureScopeName             string
+	rollbackSuccessScopeName             string
+	retryAttemptScopeName                string
+	retryAttemptScopeNameWithTags        string
+	insertScopeName                      string
+	patchScopeName                       string
+	patchScopeNameWithTags               string
+	deleteWhereScopeName                 string
+	deleteWhereScopeNameWithTags         string
+	changeRecordFailureScopeNameWithTags string
+	upsertScopeName                      string
+	upsertScopeNameWithTags              string
+	insertScopeNameWithTags              string
+	updateScopeName                      string
+	updateScopeNameWithTags              string
+	deleteScopeName                      string
+	deleteScopeNameWithTags              string
+	commitAmbiguousScopeName             string
+	commitAmbiguousScopeNameWithTags     string
+	tags                                 string
 
 	// suite state.
 	isSetup    bool
@@ -99,10 +114,20 @@ func (s *SQLUnitTestSuite) Setup() {
 	s.retryAttemptScopeNameWithTags = fmt.Sprintf("%s%s%s", s.retryAttemptScopeName, sep, s.tags)
 	s.insertScopeName = fmt.Sprintf("%s.%s", s.scopePrefix, "unit.insert")
 	s.insertScopeNameWithTags = fmt.Sprintf("%s%s%s", s.insertScopeName, sep, s.tags)
+	s.upsertScopeName = fmt.Sprintf("%s.%s", s.scopePrefix, "unit.upsert")
+	s.upsertScopeNameWithTags = fmt.Sprintf("%s%s%s", s.upsertScopeName, sep, s.tags)
+	s.patchScopeName = fmt.Sprintf("%s.%s", s.scopePrefix, "unit.patch")
+	s.patchScopeNameWithTags = fmt.Sprintf("%s%s%s", s.patchScopeName, sep, s.tags)
+	s.deleteWhereScopeName = fmt.Sprintf("%s.%s", s.scopePrefix, "unit.delete.where")
+	s.deleteWhereScopeNameWithTags = fmt.Sprintf("%s%s%s", s.deleteWhereScopeName, sep, s.tags)
+	s.changeRecordFailureScopeNameWithTags = fmt.Sprintf(
+		"%s.%s%s%s", s.scopePrefix, "unit.cdc.failure", sep, s.tags)
 	s.updateScopeName = fmt.Sprintf("%s.%s", s.scopePrefix, "unit.update")
 	s.updateScopeNameWithTags = fmt.Sprintf("%s%s%s", s.updateScopeName, sep, s.tags)
 	s.deleteScopeName = fmt.Sprintf("%s.%s", s.scopePrefix, "unit.delete")
 	s.deleteScopeNameWithTags = fmt.Sprintf("%s%s%s", s.deleteScopeName, sep, s.tags)
+	s.commitAmbiguousScopeName = fmt.Sprintf("%s.%s", s.scopePrefix, "unit.commit.ambiguous")
+	s.commitAmbiguousScopeNameWithTags = fmt.Sprintf("%s%s%s", s.commitAmbiguousScopeName, sep, s.tags)
 
 	// test entities.
 	foo := test.Foo{ID: 28}
@@ -132,14 +157,14 @@ func (s *SQLUnitTestSuite) Setup() {
 	ts := tally.NewTestScope(s.scopePrefix, map[string]string{})
 	s.retryCount = 2
 	s.scope = ts
-	opts := []work.UnitOption{
+	s.opts = []work.UnitOption{
 		work.UnitDataMappers(dm),
 		work.UnitWithZapLogger(l),
 		work.UnitTallyMetricScope(ts),
 		work.UnitDB(s.db),
 		work.UnitRetryAttempts(s.retryCount),
 	}
-	s.sut, err = work.NewUnit(opts...)
+	s.sut, err = work.NewUnit(s.opts...)
 	s.Require().NoError(err)
 }
 
@@ -181,10 +206,10 @@ func (s *SQLUnitTestSuite) subtests() []TableDrivenTest {
 			ctx: context.Background(),
 			err: errors.New("whoa"),
 			assertions: func() {
-				s.Len(s.scope.Snapshot().Counters(), 3)
+				s.Len(s.scope.Snapshot().Counters(), 4)
 				s.Contains(s.scope.Snapshot().Counters(), s.rollbackSuccessScopeNameWithTags)
 				s.Contains(s.scope.Snapshot().Counters(), s.retryAttemptScopeNameWithTags)
-				s.Len(s.scope.Snapshot().Timers(), 1)
+				s.Len(s.unitTimers(), 1)
 				s.Contains(s.scope.Snapshot().Timers(), s.saveScopeNameWithTags)
 			},
 		},
@@ -219,10 +244,10 @@ func (s *SQLUnitTestSuite) subtests() []TableDrivenTest {
 			ctx: context.Background(),
 			err: errors.New("whoa"),
 			assertions: func() {
-				s.Len(s.scope.Snapshot().Counters(), 3)
+				s.Len(s.scope.Snapshot().Counters(), 4)
 				s.Contains(s.scope.Snapshot().Counters(), s.rollbackSuccessScopeNameWithTags)
 				s.Contains(s.scope.Snapshot().Counters(), s.retryAttemptScopeNameWithTags)
-				s.Len(s.scope.Snapshot().Timers(), 2)
+				s.Len(s.unitTimers(), 2)
 				s.Contains(s.scope.Snapshot().Timers(), s.saveScopeNameWithTags)
 				s.Contains(s.scope.Snapshot().Timers(), s.rollbackScopeNameWithTags)
 			},
@@ -258,10 +283,10 @@ func (s *SQLUnitTestSuite) subtests() []TableDrivenTest {
 			ctx: context.Background(),
 			err: errors.New("ouch; whoa"),
 			assertions: func() {
-				s.Len(s.scope.Snapshot().Counters(), 3)
+				s.Len(s.scope.Snapshot().Counters(), 4)
 				s.Contains(s.scope.Snapshot().Counters(), s.rollbackFailureScopeNameWithTags)
 				s.Contains(s.scope.Snapshot().Counters(), s.retryAttemptScopeNameWithTags)
-				s.Len(s.scope.Snapshot().Timers(), 2)
+				s.Len(s.unitTimers(), 2)
 				s.Contains(s.scope.Snapshot().Timers(), s.saveScopeNameWithTags)
 				s.Contains(s.scope.Snapshot().Timers(), s.rollbackScopeNameWithTags)
 			},
@@ -301,10 +326,10 @@ func (s *SQLUnitTestSuite) subtests() []TableDrivenTest {
 			ctx: context.Background(),
 			err: errors.New("whoa"),
 			assertions: func() {
-				s.Len(s.scope.Snapshot().Counters(), 3)
+				s.Len(s.scope.Snapshot().Counters(), 4)
 				s.Contains(s.scope.Snapshot().Counters(), s.rollbackSuccessScopeNameWithTags)
 				s.Contains(s.scope.Snapshot().Counters(), s.retryAttemptScopeNameWithTags)
-				s.Len(s.scope.Snapshot().Timers(), 2)
+				s.Len(s.unitTimers(), 2)
 				s.Contains(s.scope.Snapshot().Timers(), s.saveScopeNameWithTags)
 				s.Contains(s.scope.Snapshot().Timers(), s.rollbackScopeNameWithTags)
 			},
@@ -344,10 +369,10 @@ func (s *SQLUnitTestSuite) subtests() []TableDrivenTest {
 			ctx: context.Background(),
 			err: errors.New("ouch; whoa"),
 			assertions: func() {
-				s.Len(s.scope.Snapshot().Counters(), 3)
+				s.Len(s.scope.Snapshot().Counters(), 4)
 				s.Contains(s.scope.Snapshot().Counters(), s.rollbackFailureScopeNameWithTags)
 				s.Contains(s.scope.Snapshot().Counters(), s.retryAttemptScopeNameWithTags)
-				s.Len(s.scope.Snapshot().Timers(), 2)
+				s.Len(s.unitTimers(), 2)
 				s.Contains(s.scope.Snapshot().Timers(), s.saveScopeNameWithTags)
 				s.Contains(s.scope.Snapshot().Timers(), s.rollbackScopeNameWithTags)
 			},
@@ -391,10 +416,10 @@ func (s *SQLUnitTestSuite) subtests() []TableDrivenTest {
 			ctx: context.Background(),
 			err: errors.New("whoa"),
 			assertions: func() {
-				s.Len(s.scope.Snapshot().Counters(), 3)
+				s.Len(s.scope.Snapshot().Counters(), 4)
 				s.Contains(s.scope.Snapshot().Counters(), s.rollbackSuccessScopeNameWithTags)
 				s.Contains(s.scope.Snapshot().Counters(), s.retryAttemptScopeNameWithTags)
-				s.Len(s.scope.Snapshot().Timers(), 2)
+				s.Len(s.unitTimers(), 2)
 				s.Contains(s.scope.Snapshot().Timers(), s.saveScopeNameWithTags)
 				s.Contains(s.scope.Snapshot().Timers(), s.rollbackScopeNameWithTags)
 			},
@@ -438,10 +463,10 @@ func (s *SQLUnitTestSuite) subtests() []TableDrivenTest {
 			ctx: context.Background(),
 			err: errors.New("ouch; whoa"),
 			assertions: func() {
-				s.Len(s.scope.Snapshot().Counters(), 3)
+				s.Len(s.scope.Snapshot().Counters(), 4)
 				s.Contains(s.scope.Snapshot().Counters(), s.rollbackFailureScopeNameWithTags)
 				s.Contains(s.scope.Snapshot().Counters(), s.retryAttemptScopeNameWithTags)
-				s.Len(s.scope.Snapshot().Timers(), 2)
+				s.Len(s.unitTimers(), 2)
 				s.Contains(s.scope.Snapshot().Timers(), s.saveScopeNameWithTags)
 				s.Contains(s.scope.Snapshot().Timers(), s.rollbackScopeNameWithTags)
 			},
@@ -486,7 +511,7 @@ func (s *SQLUnitTestSuite) subtests() []TableDrivenTest {
 			assertions: func() {
 				s.Len(s.scope.Snapshot().Counters(), 2)
 				s.Contains(s.scope.Snapshot().Counters(), s.rollbackSuccessScopeNameWithTags)
-				s.Len(s.scope.Snapshot().Timers(), 2)
+				s.Len(s.unitTimers(), 2)
 				s.Contains(s.scope.Snapshot().Timers(), s.saveScopeNameWithTags)
 				s.Contains(s.scope.Snapshot().Timers(), s.rollbackScopeNameWithTags)
 			},
@@ -532,7 +557,7 @@ func (s *SQLUnitTestSuite) subtests() []TableDrivenTest {
 			assertions: func() {
 				s.Len(s.scope.Snapshot().Counters(), 2)
 				s.Contains(s.scope.Snapshot().Counters(), s.rollbackFailureScopeNameWithTags)
-				s.Len(s.scope.Snapshot().Timers(), 2)
+				s.Len(s.unitTimers(), 2)
 				s.Contains(s.scope.Snapshot().Timers(), s.saveScopeNameWithTags)
 				s.Contains(s.scope.Snapshot().Timers(), s.rollbackScopeNameWithTags)
 			},
@@ -577,10 +602,51 @@ func (s *SQLUnitTestSuite) subtests() []TableDrivenTest {
 			ctx: context.Background(),
 			err: errors.New("whoa"),
 			assertions: func() {
-				s.Len(s.scope.Snapshot().Counters(), 3)
+				s.Len(s.scope.Snapshot().Counters(), 4)
 				s.Contains(s.scope.Snapshot().Counters(), s.rollbackSuccessScopeNameWithTags)
 				s.Contains(s.scope.Snapshot().Counters(), s.retryAttemptScopeNameWithTags)
-				s.Len(s.scope.Snapshot().Timers(), 1)
+				s.Len(s.unitTimers(), 1)
+				s.Contains(s.scope.Snapshot().Timers(), s.saveScopeNameWithTags)
+			},
+		},
+		{
+			name:      "CommitAmbiguousError",
+			additions: []interface{}{foos[0], bars[0]},
+			alters:    []interface{}{foos[1], bars[1]},
+			removals:  []interface{}{foos[2]},
+			expectations: func(ctx context.Context, registers, additions, alters, removals []interface{}) {
+				s._db.ExpectBegin()
+				s._db.ExpectCommit().WillReturnError(driver.ErrBadConn)
+				s.mappers[fooType].EXPECT().Insert(ctx, gomock.Any(), additions[0]).Return(nil)
+				s.mappers[barType].EXPECT().Insert(ctx, gomock.Any(), additions[1]).Return(nil)
+				s.mappers[fooType].EXPECT().Update(ctx, gomock.Any(), alters[0]).Return(nil)
+				s.mappers[barType].EXPECT().Update(ctx, gomock.Any(), alters[1]).Return(nil)
+				s.mappers[fooType].EXPECT().Delete(ctx, gomock.Any(), removals[0]).Return(nil)
+			},
+			ctx:        context.Background(),
+			err:        fmt.Errorf("%s; %s", work.ErrCommitAmbiguous, driver.ErrBadConn),
+			assertions: func() {},
+		},
+		{
+			name:      "CommitAmbiguousError_MetricsEmitted",
+			additions: []interface{}{foos[0], bars[0]},
+			alters:    []interface{}{foos[1], bars[1]},
+			removals:  []interface{}{foos[2]},
+			expectations: func(ctx context.Context, registers, additions, alters, removals []interface{}) {
+				s._db.ExpectBegin()
+				s._db.ExpectCommit().WillReturnError(driver.ErrBadConn)
+				s.mappers[fooType].EXPECT().Insert(ctx, gomock.Any(), additions[0]).Return(nil)
+				s.mappers[barType].EXPECT().Insert(ctx, gomock.Any(), additions[1]).Return(nil)
+				s.mappers[fooType].EXPECT().Update(ctx, gomock.Any(), alters[0]).Return(nil)
+				s.mappers[barType].EXPECT().Update(ctx, gomock.Any(), alters[1]).Return(nil)
+				s.mappers[fooType].EXPECT().Delete(ctx, gomock.Any(), removals[0]).Return(nil)
+			},
+			ctx: context.Background(),
+			err: fmt.Errorf("%s; %s", work.ErrCommitAmbiguous, driver.ErrBadConn),
+			assertions: func() {
+				s.Len(s.scope.Snapshot().Counters(), 3)
+				s.Contains(s.scope.Snapshot().Counters(), s.commitAmbiguousScopeNameWithTags)
+				s.Len(s.unitTimers(), 1)
 				s.Contains(s.scope.Snapshot().Timers(), s.saveScopeNameWithTags)
 			},
 		},
@@ -617,12 +683,15 @@ func (s *SQLUnitTestSuite) subtests() []TableDrivenTest {
 			},
 			ctx: context.Background(),
 			assertions: func() {
-				s.Len(s.scope.Snapshot().Counters(), 5)
+				s.Len(s.scope.Snapshot().Counters(), 8)
 				s.Contains(s.scope.Snapshot().Counters(), s.saveSuccessScopeNameWithTags)
 				s.Contains(s.scope.Snapshot().Counters(), s.insertScopeNameWithTags)
+				s.Contains(s.scope.Snapshot().Counters(), s.upsertScopeNameWithTags)
+				s.Contains(s.scope.Snapshot().Counters(), s.patchScopeNameWithTags)
+				s.Contains(s.scope.Snapshot().Counters(), s.deleteWhereScopeNameWithTags)
 				s.Contains(s.scope.Snapshot().Counters(), s.updateScopeNameWithTags)
 				s.Contains(s.scope.Snapshot().Counters(), s.deleteScopeNameWithTags)
-				s.Len(s.scope.Snapshot().Timers(), 1)
+				s.Len(s.unitTimers(), 1)
 				s.Contains(s.scope.Snapshot().Timers(), s.saveScopeNameWithTags)
 			},
 		},
@@ -677,13 +746,16 @@ func (s *SQLUnitTestSuite) subtests() []TableDrivenTest {
 			},
 			ctx: context.Background(),
 			assertions: func() {
-				s.Len(s.scope.Snapshot().Counters(), 7)
+				s.Len(s.scope.Snapshot().Counters(), 10)
 				s.Contains(s.scope.Snapshot().Counters(), s.saveSuccessScopeNameWithTags)
 				s.Contains(s.scope.Snapshot().Counters(), s.retryAttemptScopeNameWithTags)
 				s.Contains(s.scope.Snapshot().Counters(), s.insertScopeNameWithTags)
+				s.Contains(s.scope.Snapshot().Counters(), s.upsertScopeNameWithTags)
+				s.Contains(s.scope.Snapshot().Counters(), s.patchScopeNameWithTags)
+				s.Contains(s.scope.Snapshot().Counters(), s.deleteWhereScopeNameWithTags)
 				s.Contains(s.scope.Snapshot().Counters(), s.updateScopeNameWithTags)
 				s.Contains(s.scope.Snapshot().Counters(), s.deleteScopeNameWithTags)
-				s.Len(s.scope.Snapshot().Timers(), 2)
+				s.Len(s.unitTimers(), 2)
 				s.Contains(s.scope.Snapshot().Timers(), s.saveScopeNameWithTags)
 			},
 		},
@@ -725,6 +797,1934 @@ func (s *SQLUnitTestSuite) TestSQLUnit_Save() {
 	}
 }
 
+func (s *SQLUnitTestSuite) TestSQLUnit_Save_IdempotencyTokens() {
+	// arrange.
+	ctx := context.Background()
+	foo := test.Foo{ID: 28}
+	fooType := work.TypeNameOf(foo)
+	var mCtxs []work.UnitMapperContext
+	capture := func(ctx context.Context, mCtx work.UnitMapperContext, e ...interface{}) {
+		mCtxs = append(mCtxs, mCtx)
+	}
+	s.Require().NoError(s.sut.Add(ctx, foo))
+	s._db.ExpectBegin()
+	s._db.ExpectRollback()
+	s._db.ExpectBegin()
+	s._db.ExpectCommit()
+	insertFailure := s.mappers[fooType].EXPECT().
+		Insert(ctx, gomock.Any(), foo).Do(capture).Return(errors.New("whoa"))
+	s.mappers[fooType].EXPECT().
+		Insert(ctx, gomock.Any(), foo).Do(capture).Return(nil).After(insertFailure)
+
+	// action.
+	err := s.sut.Save(ctx)
+
+	// assert.
+	s.Require().NoError(err)
+	s.Require().NoError(s._db.ExpectationsWereMet())
+	s.Require().Len(mCtxs, 2)
+	s.NotEmpty(mCtxs[0].SaveID)
+	s.Equal(mCtxs[0].SaveID, mCtxs[1].SaveID)
+	s.NotEmpty(mCtxs[0].AttemptID)
+	s.NotEmpty(mCtxs[1].AttemptID)
+	s.NotEqual(mCtxs[0].AttemptID, mCtxs[1].AttemptID)
+}
+
+func (s *SQLUnitTestSuite) TestSQLUnit_Save_DeferConstraints() {
+	tests := []struct {
+		name        string
+		constraints []string
+		statement   string
+	}{
+		{name: "All", statement: "SET CONSTRAINTS ALL DEFERRED"},
+		{
+			name:        "Named",
+			constraints: []string{"fk_foo_bar", "public.fk_bar_foo"},
+			statement:   `SET CONSTRAINTS "fk_foo_bar", "public"."fk_bar_foo" DEFERRED`,
+		},
+		{
+			name:        "Quoted",
+			constraints: []string{`fk" DEFERRED; DROP TABLE foo; --`},
+			statement:   `SET CONSTRAINTS "fk"" DEFERRED; DROP TABLE foo; --" DEFERRED`,
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			// setup.
+			s.Setup()
+
+			// arrange.
+			ctx := context.Background()
+			foo := test.Foo{ID: 28}
+			opts := append(s.opts, work.UnitDeferConstraints(tt.constraints...))
+			sut, err := work.NewUnit(opts...)
+			s.Require().NoError(err)
+			s.Require().NoError(sut.Add(ctx, foo))
+			s._db.ExpectBegin()
+			s._db.ExpectExec(regexp.QuoteMeta(tt.statement)).
+				WillReturnResult(sqlmock.NewResult(0, 0))
+			s._db.ExpectCommit()
+			s.mappers[work.TypeNameOf(foo)].EXPECT().
+				Insert(ctx, gomock.Any(), foo).Return(nil)
+
+			// action.
+			err = sut.Save(ctx)
+
+			// assert.
+			s.Require().NoError(err)
+			s.Require().NoError(s._db.ExpectationsWereMet())
+
+			// tear down.
+			s.TearDown()
+		})
+	}
+}
+
+func (s *SQLUnitTestSuite) TestSQLUnit_Save_DeferConstraintsError() {
+	// arrange.
+	ctx := context.Background()
+	foo := test.Foo{ID: 28}
+	opts := append(s.opts, work.UnitDeferConstraints())
+	sut, err := work.NewUnit(opts...)
+	s.Require().NoError(err)
+	s.Require().NoError(sut.Add(ctx, foo))
+	for i := 0; i < s.retryCount; i++ {
+		s._db.ExpectBegin()
+		s._db.ExpectExec(regexp.QuoteMeta("SET CONSTRAINTS ALL DEFERRED")).
+			WillReturnError(errors.New("whoa"))
+		s._db.ExpectRollback()
+	}
+
+	// action.
+	err = sut.Save(ctx)
+
+	// assert.
+	s.EqualError(err, "whoa")
+	s.Require().NoError(s._db.ExpectationsWereMet())
+}
+
+func (s *SQLUnitTestSuite) TestSQLUnit_Save_Upsert() {
+	tests := []struct {
+		name         string
+		upsertErr    error
+		expectations func()
+		err          error
+	}{
+		{
+			name: "Success",
+			expectations: func() {
+				s._db.ExpectBegin()
+				s._db.ExpectCommit()
+			},
+		},
+		{
+			name:      "UpsertError",
+			upsertErr: errors.New("whoa"),
+			expectations: func() {
+				for i := 0; i < s.retryCount; i++ {
+					s._db.ExpectBegin()
+					s._db.ExpectRollback()
+				}
+			},
+			err: errors.New("whoa"),
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			// setup.
+			s.Setup()
+
+			// arrange.
+			ctx := context.Background()
+			foo := test.Foo{ID: 28}
+			var upserted []interface{}
+			upsertFunc := func(ctx context.Context, mCtx work.UnitMapperContext, e ...interface{}) error {
+				s.NotNil(mCtx.Tx)
+				upserted = append(upserted, e...)
+				return tt.upsertErr
+			}
+			opts := append(s.opts, work.UnitUpsertFunc(work.TypeNameOf(foo), upsertFunc))
+			sut, err := work.NewUnit(opts...)
+			s.Require().NoError(err)
+			s.Require().NoError(sut.Upsert(ctx, foo))
+			tt.expectations()
+
+			// action.
+			err = sut.Save(ctx)
+
+			// assert.
+			if tt.err != nil {
+				s.EqualError(err, tt.err.Error())
+			} else {
+				s.Require().NoError(err)
+				s.Equal([]interface{}{foo}, upserted)
+				s.Contains(s.scope.Snapshot().Counters(), s.upsertScopeNameWithTags)
+			}
+			s.Require().NoError(s._db.ExpectationsWereMet())
+
+			// tear down.
+			s.TearDown()
+		})
+	}
+}
+
+func (s *SQLUnitTestSuite) TestSQLUnit_Save_Patch() {
+	// arrange.
+	ctx := context.Background()
+	t := work.TypeNameOf(test.Foo{})
+	fields := map[string]interface{}{"name": "foo"}
+	var patched []work.UnitPatch
+	patchFunc := func(ctx context.Context, mCtx work.UnitMapperContext, p ...work.UnitPatch) error {
+		s.NotNil(mCtx.Tx)
+		patched = append(patched, p...)
+		return nil
+	}
+	opts := append(s.opts, work.UnitPatchFunc(t, patchFunc))
+	sut, err := work.NewUnit(opts...)
+	s.Require().NoError(err)
+	s.Require().NoError(sut.Patch(ctx, t, 28, fields))
+	s._db.ExpectBegin()
+	s._db.ExpectCommit()
+
+	// action.
+	err = sut.Save(ctx)
+
+	// assert.
+	s.Require().NoError(err)
+	s.Equal([]work.UnitPatch{{TypeName: t, ID: 28, Fields: fields}}, patched)
+	s.Require().NoError(s._db.ExpectationsWereMet())
+}
+
+func (s *SQLUnitTestSuite) TestSQLUnit_Save_RemoveWhere() {
+	// arrange.
+	ctx := context.Background()
+	t := work.TypeNameOf(test.Foo{})
+	criteria := map[string]interface{}{"name": "foo"}
+	var removed []interface{}
+	deleteWhereFunc := func(ctx context.Context, mCtx work.UnitMapperContext, c ...interface{}) error {
+		s.NotNil(mCtx.Tx)
+		removed = append(removed, c...)
+		return nil
+	}
+	opts := append(s.opts, work.UnitDeleteWhereFunc(t, deleteWhereFunc))
+	sut, err := work.NewUnit(opts...)
+	s.Require().NoError(err)
+	s.Require().NoError(sut.RemoveWhere(ctx, t, criteria))
+	s._db.ExpectBegin()
+	s._db.ExpectCommit()
+
+	// action.
+	err = sut.Save(ctx)
+
+	// assert.
+	s.Require().NoError(err)
+	s.Equal([]interface{}{criteria}, removed)
+	s.Require().NoError(s._db.ExpectationsWereMet())
+}
+
+func (s *SQLUnitTestSuite) TestSQLUnit_Save_RemoveWhereError() {
+	// arrange.
+	ctx := context.Background()
+	t := work.TypeNameOf(test.Foo{})
+	deleteWhereFunc := func(ctx context.Context, mCtx work.UnitMapperContext, c ...interface{}) error {
+		return errors.New("whoa")
+	}
+	opts := append(s.opts, work.UnitDeleteWhereFunc(t, deleteWhereFunc))
+	sut, err := work.NewUnit(opts...)
+	s.Require().NoError(err)
+	s.Require().NoError(sut.RemoveWhere(ctx, t, "name = 'foo'"))
+	for i := 0; i < s.retryCount; i++ {
+		s._db.ExpectBegin()
+		s._db.ExpectRollback()
+	}
+
+	// action.
+	err = sut.Save(ctx)
+
+	// assert.
+	s.EqualError(err, "whoa")
+	s.Require().NoError(s._db.ExpectationsWereMet())
+}
+
+func (s *SQLUnitTestSuite) TestSQLUnit_SaveBackground() {
+	// arrange.
+	foo := test.Foo{ID: 28}
+	fooType := work.TypeNameOf(foo)
+	s.Require().NoError(s.sut.AddBackground(foo))
+	s._db.ExpectBegin()
+	s.mappers[fooType].EXPECT().
+		Insert(context.Background(), gomock.Any(), foo).Return(nil)
+	s._db.ExpectCommit()
+
+	// action.
+	err := s.sut.SaveBackground()
+
+	// assert.
+	s.NoError(err)
+	s.Require().NoError(s._db.ExpectationsWereMet())
+}
+
+func (s *SQLUnitTestSuite) TestSQLUnit_SaveAsync() {
+	// arrange.
+	ctx := context.Background()
+	foo := test.Foo{ID: 28}
+	fooType := work.TypeNameOf(foo)
+	s.Require().NoError(s.sut.Add(ctx, foo))
+	s._db.ExpectBegin()
+	s.mappers[fooType].EXPECT().Insert(ctx, gomock.Any(), foo).Return(nil)
+	s._db.ExpectCommit()
+
+	// action.
+	f := s.sut.SaveAsync(ctx)
+
+	// assert.
+	s.NoError(f.Wait(ctx))
+	s.Require().NotNil(f.Done())
+	<-f.Done()
+	s.NoError(f.Err())
+	s.Require().NoError(s._db.ExpectationsWereMet())
+}
+
+func (s *SQLUnitTestSuite) TestSQLUnit_SaveAsync_Error() {
+	// arrange.
+	ctx := context.Background()
+	foo := test.Foo{ID: 28}
+	fooType := work.TypeNameOf(foo)
+	s.Require().NoError(s.sut.Add(ctx, foo))
+	for i := 0; i < s.retryCount; i++ {
+		s._db.ExpectBegin()
+		s._db.ExpectRollback()
+	}
+	s.mappers[fooType].EXPECT().Insert(ctx, gomock.Any(), foo).
+		Return(errors.New("whoa")).Times(s.retryCount)
+
+	// action.
+	f := s.sut.SaveAsync(ctx)
+
+	// assert.
+	s.EqualError(f.Wait(ctx), "whoa")
+	s.EqualError(f.Err(), "whoa")
+	s.Require().NoError(s._db.ExpectationsWereMet())
+}
+
+func (s *SQLUnitTestSuite) TestSQLUnit_Save_TypedActions() {
+	// arrange.
+	ctx := context.Background()
+	foo := test.Foo{ID: 28}
+	fooType := work.TypeNameOf(foo)
+	var causes, errs []error
+	var durations []time.Duration
+	opts := append(s.opts,
+		work.UnitRollbackActions(func(ctx work.UnitRollbackActionContext) {
+			causes = append(causes, ctx.Err)
+		}),
+		work.UnitSaveActions(func(ctx work.UnitSaveActionContext) {
+			durations = append(durations, ctx.Duration)
+			errs = append(errs, ctx.Err)
+		}),
+	)
+	sut, err := work.NewUnit(opts...)
+	s.Require().NoError(err)
+	s.Require().NoError(sut.Add(ctx, foo))
+	for i := 0; i < s.retryCount; i++ {
+		s._db.ExpectBegin()
+		s._db.ExpectRollback()
+	}
+	s.mappers[fooType].EXPECT().
+		Insert(ctx, gomock.Any(), foo).Return(errors.New("whoa")).Times(s.retryCount)
+
+	// action.
+	err = sut.Save(ctx)
+
+	// assert.
+	s.EqualError(err, "whoa")
+	s.Len(causes, s.retryCount)
+	for _, cause := range causes {
+		s.EqualError(cause, "whoa")
+	}
+	s.Len(durations, 1)
+	s.Positive(durations[0])
+	s.Len(errs, 1)
+	s.EqualError(errs[0], "whoa")
+	s.Require().NoError(s._db.ExpectationsWereMet())
+}
+
+func (s *SQLUnitTestSuite) TestSQLUnit_Save_HaltActionsOnFailure() {
+	// arrange.
+	ctx := context.Background()
+	foo := test.Foo{ID: 28}
+	var causes []error
+	opts := append(s.opts,
+		work.UnitHaltActionsOnFailure(),
+		work.UnitActionsWithPriority(work.UnitActionTypeBeforeInserts, 10,
+			func(work.UnitActionContext) { panic("unauthorized") }),
+		work.UnitRollbackActions(func(ctx work.UnitRollbackActionContext) {
+			causes = append(causes, ctx.Err)
+		}),
+	)
+	sut, err := work.NewUnit(opts...)
+	s.Require().NoError(err)
+	s.Require().NoError(sut.Add(ctx, foo))
+	s._db.ExpectBegin()
+	s._db.ExpectRollback()
+
+	// action.
+	err = sut.Save(ctx)
+
+	// assert.
+	s.ErrorIs(err, work.ErrUnitActionFailed)
+	s.Len(causes, 1)
+	s.ErrorIs(causes[0], work.ErrUnitActionFailed)
+	s.Require().NoError(s._db.ExpectationsWereMet())
+}
+
+type changeRecordSink struct {
+	records []work.UnitChangeRecord
+	err     error
+}
+
+func (sink *changeRecordSink) Emit(ctx context.Context, records []work.UnitChangeRecord) error {
+	sink.records = append(sink.records, records...)
+	return sink.err
+}
+
+func (s *SQLUnitTestSuite) TestSQLUnit_Save_ChangeRecords() {
+	// arrange.
+	ctx := context.Background()
+	added, registered, altered, removed := test.Foo{ID: 1}, test.Foo{ID: 2}, test.Foo{ID: 2}, test.Foo{ID: 3}
+	fooType := work.TypeNameOf(added)
+	sink := &changeRecordSink{}
+	sut, err := work.NewUnit(append(s.opts, work.UnitChangeRecords(sink))...)
+	s.Require().NoError(err)
+	s.Require().NoError(sut.Register(ctx, registered))
+	s.Require().NoError(sut.Add(ctx, added))
+	s.Require().NoError(sut.Alter(ctx, altered))
+	s.Require().NoError(sut.Remove(ctx, removed))
+	var saveID string
+	s._db.ExpectBegin()
+	s.mappers[fooType].EXPECT().Insert(ctx, gomock.Any(), added).
+		DoAndReturn(func(ctx context.Context, mCtx work.UnitMapperContext, e ...interface{}) error {
+			saveID = mCtx.SaveID
+			return nil
+		})
+	s.mappers[fooType].EXPECT().Update(ctx, gomock.Any(), altered).Return(nil)
+	s.mappers[fooType].EXPECT().Delete(ctx, gomock.Any(), removed).Return(nil)
+	s._db.ExpectCommit()
+
+	// action.
+	err = sut.Save(ctx)
+
+	// assert.
+	s.Require().NoError(err)
+	s.Require().Len(sink.records, 3)
+	for _, r := range sink.records {
+		s.Equal(fooType, r.TypeName)
+		s.Equal(saveID, r.TxID)
+		s.False(r.Timestamp.IsZero())
+	}
+	s.Equal(work.UnitChangeOperationCreate, sink.records[0].Op)
+	s.Nil(sink.records[0].Before)
+	s.Equal(added, sink.records[0].After)
+	s.Equal(work.UnitChangeOperationUpdate, sink.records[1].Op)
+	s.Equal(registered, sink.records[1].Before)
+	s.Equal(altered, sink.records[1].After)
+	s.Equal(work.UnitChangeOperationDelete, sink.records[2].Op)
+	s.Equal(removed, sink.records[2].Before)
+	s.Nil(sink.records[2].After)
+	s.Require().NoError(s._db.ExpectationsWereMet())
+}
+
+func (s *SQLUnitTestSuite) TestSQLUnit_Save_ChangeRecordsError() {
+	// arrange.
+	ctx := context.Background()
+	foo := test.Foo{ID: 1}
+	fooType := work.TypeNameOf(foo)
+	sink := &changeRecordSink{err: errors.New("whoa")}
+	sut, err := work.NewUnit(append(s.opts, work.UnitChangeRecords(sink))...)
+	s.Require().NoError(err)
+	s.Require().NoError(sut.Add(ctx, foo))
+	s._db.ExpectBegin()
+	s.mappers[fooType].EXPECT().Insert(ctx, gomock.Any(), foo).Return(nil)
+	s._db.ExpectCommit()
+
+	// action.
+	err = sut.Save(ctx)
+
+	// assert.
+	s.NoError(err)
+	s.Len(sink.records, 1)
+	s.Contains(s.scope.Snapshot().Counters(), s.changeRecordFailureScopeNameWithTags)
+	s.Require().NoError(s._db.ExpectationsWereMet())
+}
+
+func (s *SQLUnitTestSuite) TestSQLUnit_Save_MaxOperationsPerTransaction() {
+	// arrange.
+	ctx := context.Background()
+	foos := []interface{}{test.Foo{ID: 1}, test.Foo{ID: 2}, test.Foo{ID: 3}}
+	removed := test.Foo{ID: 4}
+	fooType := work.TypeNameOf(removed)
+	sut, err := work.NewUnit(append(s.opts, work.UnitMaxOperationsPerTransaction(2))...)
+	s.Require().NoError(err)
+	s.Require().NoError(sut.Add(ctx, foos...))
+	s.Require().NoError(sut.Remove(ctx, removed))
+	s._db.ExpectBegin()
+	s.mappers[fooType].EXPECT().Insert(ctx, gomock.Any(), foos[0], foos[1]).Return(nil)
+	s._db.ExpectCommit()
+	s._db.ExpectBegin()
+	s.mappers[fooType].EXPECT().Insert(ctx, gomock.Any(), foos[2]).Return(nil)
+	s.mappers[fooType].EXPECT().Delete(ctx, gomock.Any(), removed).Return(nil)
+	s._db.ExpectCommit()
+
+	// action.
+	err = sut.Save(ctx)
+
+	// assert.
+	s.NoError(err)
+	s.Require().NoError(s._db.ExpectationsWereMet())
+}
+
+func (s *SQLUnitTestSuite) TestSQLUnit_Save_MaxOperationsPerTransactionPartialFailure() {
+	// arrange.
+	ctx := context.Background()
+	foos := []interface{}{test.Foo{ID: 1}, test.Foo{ID: 2}, test.Foo{ID: 3}}
+	fooType := work.TypeNameOf(foos[0])
+	sut, err := work.NewUnit(append(s.opts, work.UnitMaxOperationsPerTransaction(2))...)
+	s.Require().NoError(err)
+	s.Require().NoError(sut.Add(ctx, foos...))
+	s._db.ExpectBegin()
+	s.mappers[fooType].EXPECT().Insert(ctx, gomock.Any(), foos[0], foos[1]).Return(nil)
+	s._db.ExpectCommit()
+	for i := 0; i < s.retryCount; i++ {
+		s._db.ExpectBegin()
+		s._db.ExpectRollback()
+	}
+	s.mappers[fooType].EXPECT().
+		Insert(ctx, gomock.Any(), foos[2]).Return(errors.New("whoa")).Times(s.retryCount)
+
+	// action.
+	err = sut.Save(ctx)
+
+	// assert.
+	s.Require().Error(err)
+	var partial *work.UnitPartialSaveError
+	s.Require().ErrorAs(err, &partial)
+	s.Require().Len(partial.Chunks, 2)
+	s.True(partial.Chunks[0].Committed)
+	s.Equal(2, partial.Chunks[0].Operations)
+	s.NoError(partial.Chunks[0].Err)
+	s.False(partial.Chunks[1].Committed)
+	s.Equal(1, partial.Chunks[1].Operations)
+	s.Error(partial.Chunks[1].Err)
+	s.Require().NoError(s._db.ExpectationsWereMet())
+}
+
+func (s *SQLUnitTestSuite) TestSQLUnit_Save_MaxOperationsPerTransactionPartialFailureResumes() {
+	// arrange.
+	ctx := context.Background()
+	foos := []interface{}{test.Foo{ID: 1}, test.Foo{ID: 2}, test.Foo{ID: 3}}
+	fooType := work.TypeNameOf(foos[0])
+	sink := &changeRecordSink{}
+	sut, err := work.NewUnit(append(
+		s.opts,
+		work.UnitMaxOperationsPerTransaction(2),
+		work.UnitChangeRecords(sink),
+	)...)
+	s.Require().NoError(err)
+	s.Require().NoError(sut.Add(ctx, foos...))
+	s._db.ExpectBegin()
+	s.mappers[fooType].EXPECT().Insert(ctx, gomock.Any(), foos[0], foos[1]).Return(nil)
+	s._db.ExpectCommit()
+	for i := 0; i < s.retryCount; i++ {
+		s._db.ExpectBegin()
+		s._db.ExpectRollback()
+	}
+	s.mappers[fooType].EXPECT().
+		Insert(ctx, gomock.Any(), foos[2]).Return(errors.New("whoa")).Times(s.retryCount)
+	s.Require().Error(sut.Save(ctx))
+	s.Require().Len(sink.records, 2)
+	s._db.ExpectBegin()
+	s.mappers[fooType].EXPECT().Insert(ctx, gomock.Any(), foos[2]).Return(nil)
+	s._db.ExpectCommit()
+
+	// action.
+	err = sut.Save(ctx)
+
+	// assert.
+	s.NoError(err)
+	s.Len(sink.records, 3)
+	s.Require().NoError(s._db.ExpectationsWereMet())
+}
+
+type quarantineSink struct {
+	quarantined []work.UnitQuarantinedEntity
+}
+
+func (sink *quarantineSink) Quarantine(ctx context.Context, q []work.UnitQuarantinedEntity) error {
+	sink.quarantined = append(sink.quarantined, q...)
+	return nil
+}
+
+func (s *SQLUnitTestSuite) TestSQLUnit_Save_Quarantine() {
+	// arrange.
+	ctx := context.Background()
+	valid, malformed := test.Foo{ID: 1}, test.Foo{ID: 2}
+	fooType := work.TypeNameOf(valid)
+	sink := &quarantineSink{}
+	opts := append(s.opts, work.UnitQuarantineAfter(2), work.UnitPersistQuarantined(sink))
+	sut, err := work.NewUnit(opts...)
+	s.Require().NoError(err)
+	s.Require().NoError(sut.Add(ctx, valid, malformed))
+	savepoint := regexp.QuoteMeta("SAVEPOINT work_quarantine")
+	release := regexp.QuoteMeta("RELEASE SAVEPOINT work_quarantine")
+	rollbackTo := regexp.QuoteMeta("ROLLBACK TO SAVEPOINT work_quarantine")
+	s._db.ExpectBegin()
+	s._db.ExpectExec(savepoint).WillReturnResult(sqlmock.NewResult(0, 0))
+	s._db.ExpectExec(release).WillReturnResult(sqlmock.NewResult(0, 0))
+	for i := 0; i < 2; i++ {
+		s._db.ExpectExec(savepoint).WillReturnResult(sqlmock.NewResult(0, 0))
+		s._db.ExpectExec(rollbackTo).WillReturnResult(sqlmock.NewResult(0, 0))
+	}
+	s._db.ExpectCommit()
+	s.mappers[fooType].EXPECT().Insert(ctx, gomock.Any(), valid).Return(nil)
+	s.mappers[fooType].EXPECT().Insert(ctx, gomock.Any(), malformed).Return(errors.New("whoa")).Times(2)
+
+	// action.
+	err = sut.Save(ctx)
+
+	// assert.
+	s.Require().NoError(err)
+	quarantined := sut.Quarantined()
+	s.Require().Len(quarantined, 1)
+	s.Equal(fooType, quarantined[0].TypeName)
+	s.Equal(malformed, quarantined[0].Entity)
+	s.Equal(2, quarantined[0].Failures)
+	s.EqualError(quarantined[0].Err, "whoa")
+	s.Equal(quarantined, sink.quarantined)
+	s.Require().NoError(s._db.ExpectationsWereMet())
+}
+
+func (s *SQLUnitTestSuite) TestSQLUnit_Save_QuarantineSavepointError() {
+	// arrange.
+	ctx := context.Background()
+	foo := test.Foo{ID: 1}
+	sut, err := work.NewUnit(append(s.opts, work.UnitQuarantineAfter(2))...)
+	s.Require().NoError(err)
+	s.Require().NoError(sut.Add(ctx, foo))
+	for i := 0; i < s.retryCount; i++ {
+		s._db.ExpectBegin()
+		s._db.ExpectExec(regexp.QuoteMeta("SAVEPOINT work_quarantine")).
+			WillReturnError(errors.New("whoa"))
+		s._db.ExpectRollback()
+	}
+
+	// action.
+	err = sut.Save(ctx)
+
+	// assert.
+	s.EqualError(err, "whoa")
+	s.Empty(sut.Quarantined())
+	s.Require().NoError(s._db.ExpectationsWereMet())
+}
+
+func (s *SQLUnitTestSuite) TestSQLUnit_Save_GeneratedIDs() {
+	// arrange.
+	ctx := context.Background()
+	quux, qux := &test.Quux{}, &test.Qux{Name: "qux"}
+	quuxType, quxType := work.TypeNameOf(quux), work.TypeNameOf(qux)
+	insert := func(ctx context.Context, mCtx work.UnitMapperContext, e ...interface{}) error {
+		mCtx.SetGeneratedID(e[0], int64(28))
+		return nil
+	}
+	noop := func(ctx context.Context, mCtx work.UnitMapperContext, e ...interface{}) error {
+		return nil
+	}
+	opts := append(s.opts,
+		work.UnitInsertFunc(quuxType, insert), work.UnitDeleteFunc(quuxType, noop),
+		work.UnitInsertFunc(quxType, insert), work.UnitDeleteFunc(quxType, noop))
+	sut, err := work.NewUnit(opts...)
+	s.Require().NoError(err)
+	s.Require().NoError(sut.Add(ctx, quux, qux))
+	s._db.ExpectBegin()
+	s._db.ExpectCommit()
+
+	// action.
+	err = sut.Save(ctx)
+
+	// assert.
+	s.Require().NoError(err)
+	s.Equal(int64(28), quux.ID())
+	s.Equal("28", qux.Key)
+	cached, err := sut.Cached().Load(ctx, quuxType, int64(28))
+	s.Require().NoError(err)
+	s.Equal(quux, cached)
+	s.Require().NoError(s._db.ExpectationsWereMet())
+}
+
+func (s *SQLUnitTestSuite) TestSQLUnit_Save_GeneratedIDsRolledBack() {
+	// arrange.
+	ctx := context.Background()
+	quux := &test.Quux{}
+	quuxType := work.TypeNameOf(quux)
+	insert := func(ctx context.Context, mCtx work.UnitMapperContext, e ...interface{}) error {
+		mCtx.SetGeneratedID(e[0], int64(28))
+		return errors.New("whoa")
+	}
+	noop := func(ctx context.Context, mCtx work.UnitMapperContext, e ...interface{}) error {
+		return nil
+	}
+	opts := append(s.opts, work.UnitInsertFunc(quuxType, insert), work.UnitDeleteFunc(quuxType, noop))
+	sut, err := work.NewUnit(opts...)
+	s.Require().NoError(err)
+	s.Require().NoError(sut.Add(ctx, quux))
+	for i := 0; i < s.retryCount; i++ {
+		s._db.ExpectBegin()
+		s._db.ExpectRollback()
+	}
+
+	// action.
+	err = sut.Save(ctx)
+
+	// assert.
+	s.Require().Error(err)
+	s.Nil(quux.ID())
+	s.Require().NoError(s._db.ExpectationsWereMet())
+}
+
+func (s *SQLUnitTestSuite) TestSQLUnit_Save_RefreshAfterSave() {
+	// arrange.
+	ctx := context.Background()
+	quux := &test.Quux{}
+	quuxType := work.TypeNameOf(quux)
+	noop := func(ctx context.Context, mCtx work.UnitMapperContext, e ...interface{}) error {
+		return nil
+	}
+	var refreshTx *sql.Tx
+	refresh := func(ctx context.Context, mCtx work.UnitMapperContext, e ...interface{}) error {
+		refreshTx = mCtx.Tx
+		e[0].(*test.Quux).SetID(int64(28))
+		return nil
+	}
+	opts := append(s.opts,
+		work.UnitInsertFunc(quuxType, noop), work.UnitDeleteFunc(quuxType, noop),
+		work.UnitRefreshFunc(quuxType, refresh), work.UnitRefreshAfterSave())
+	sut, err := work.NewUnit(opts...)
+	s.Require().NoError(err)
+	s.Require().NoError(sut.Add(ctx, quux))
+	s._db.ExpectBegin()
+	s._db.ExpectCommit()
+	s._db.ExpectBegin()
+	s._db.ExpectCommit()
+
+	// action.
+	err = sut.Save(ctx)
+
+	// assert.
+	s.Require().NoError(err)
+	s.NotNil(refreshTx)
+	s.Equal(int64(28), quux.ID())
+	cached, err := sut.Cached().Load(ctx, quuxType, int64(28))
+	s.Require().NoError(err)
+	s.Equal(quux, cached)
+	s.Require().NoError(s._db.ExpectationsWereMet())
+}
+
+func (s *SQLUnitTestSuite) TestSQLUnit_Save_RefreshAfterSaveError() {
+	// arrange.
+	ctx := context.Background()
+	quux := &test.Quux{}
+	quuxType := work.TypeNameOf(quux)
+	noop := func(ctx context.Context, mCtx work.UnitMapperContext, e ...interface{}) error {
+		return nil
+	}
+	refresh := func(ctx context.Context, mCtx work.UnitMapperContext, e ...interface{}) error {
+		return errors.New("whoa")
+	}
+	opts := append(s.opts,
+		work.UnitInsertFunc(quuxType, noop), work.UnitDeleteFunc(quuxType, noop),
+		work.UnitRefreshFunc(quuxType, refresh), work.UnitRefreshAfterSave())
+	sut, err := work.NewUnit(opts...)
+	s.Require().NoError(err)
+	s.Require().NoError(sut.Add(ctx, quux))
+	s._db.ExpectBegin()
+	s._db.ExpectCommit()
+	s._db.ExpectBegin()
+	s._db.ExpectRollback()
+
+	// action.
+	err = sut.Save(ctx)
+
+	// assert.
+	s.NoError(err)
+	s.Require().NoError(s._db.ExpectationsWereMet())
+}
+
+func (s *SQLUnitTestSuite) TestSQLUnit_Save_CommitToken() {
+	// arrange.
+	ctx := context.Background()
+	foo := test.Foo{ID: 28}
+	fooType := work.TypeNameOf(foo)
+	tokenizer := work.UnitPostgresCommitTokenizer{}
+	sut, err := work.NewUnit(append(s.opts, work.UnitCommitTokens(tokenizer))...)
+	s.Require().NoError(err)
+	s.Require().NoError(sut.Add(ctx, foo))
+	s._db.ExpectBegin()
+	s.mappers[fooType].EXPECT().Insert(ctx, gomock.Any(), foo).Return(nil)
+	s._db.ExpectCommit()
+	s._db.ExpectQuery(regexp.QuoteMeta("SELECT pg_current_wal_lsn()::text")).
+		WillReturnRows(sqlmock.NewRows([]string{"lsn"}).AddRow("0/16B3748"))
+
+	// action.
+	err = sut.Save(ctx)
+
+	// assert.
+	s.Require().NoError(err)
+	s.Equal("0/16B3748", sut.CommitToken())
+	s.Require().NoError(s._db.ExpectationsWereMet())
+}
+
+func (s *SQLUnitTestSuite) TestSQLUnit_Save_RetryHistory() {
+	// arrange.
+	ctx := context.Background()
+	sut, err := work.NewUnit(append(s.opts, work.UnitRetryAttempts(2), work.UnitRetryHistory(0))...)
+	s.Require().NoError(err)
+	s.Require().NoError(sut.Add(ctx, test.Foo{ID: 28}))
+	s._db.ExpectBegin().WillReturnError(errors.New("whoa"))
+	s._db.ExpectBegin().WillReturnError(errors.New("ugh"))
+
+	// action.
+	err = sut.Save(ctx)
+
+	// assert.
+	var retryErr *work.UnitRetryError
+	s.Require().ErrorAs(err, &retryErr)
+	s.Require().Len(retryErr.Attempts, 2)
+	s.ErrorContains(retryErr.Attempts[0].Err, "whoa")
+	s.ErrorContains(retryErr.Attempts[1].Err, "ugh")
+	s.Equal([]int{1, 2}, []int{retryErr.Attempts[0].Attempt, retryErr.Attempts[1].Attempt})
+	s.ErrorContains(err, "ugh")
+	s.Require().NoError(s._db.ExpectationsWereMet())
+}
+
+func (s *SQLUnitTestSuite) TestSQLUnit_AwaitToken() {
+	// arrange.
+	ctx := context.Background()
+	tokenizer := work.UnitPostgresCommitTokenizer{PollInterval: time.Millisecond}
+	opts := append(s.opts, work.UnitCommitTokens(tokenizer), work.UnitWaitForToken("0/16B3748"))
+	sut, err := work.NewUnit(opts...)
+	s.Require().NoError(err)
+	query := regexp.QuoteMeta("SELECT COALESCE(pg_last_wal_replay_lsn() >= $1::pg_lsn, true)")
+	s._db.ExpectQuery(query).WithArgs("0/16B3748").
+		WillReturnRows(sqlmock.NewRows([]string{"caught_up"}).AddRow(false))
+	s._db.ExpectQuery(query).WithArgs("0/16B3748").
+		WillReturnRows(sqlmock.NewRows([]string{"caught_up"}).AddRow(true))
+
+	// action.
+	err = sut.AwaitToken(ctx)
+
+	// assert.
+	s.Require().NoError(err)
+	s.NoError(sut.AwaitToken(ctx))
+	s.Require().NoError(s._db.ExpectationsWereMet())
+}
+
+func (s *SQLUnitTestSuite) TestSQLUnit_AwaitToken_ContextDone() {
+	// arrange.
+	ctx, cancel := context.WithCancel(context.Background())
+	tokenizer := work.UnitPostgresCommitTokenizer{PollInterval: time.Hour}
+	opts := append(s.opts, work.UnitCommitTokens(tokenizer), work.UnitWaitForToken("0/16B3748"))
+	sut, err := work.NewUnit(opts...)
+	s.Require().NoError(err)
+	query := regexp.QuoteMeta("SELECT COALESCE(pg_last_wal_replay_lsn() >= $1::pg_lsn, true)")
+	s._db.ExpectQuery(query).WithArgs("0/16B3748").
+		WillReturnRows(sqlmock.NewRows([]string{"caught_up"}).AddRow(false))
+	time.AfterFunc(10*time.Millisecond, cancel)
+
+	// action.
+	err = sut.AwaitToken(ctx)
+
+	// assert.
+	s.ErrorIs(err, context.Canceled)
+	s.Require().NoError(s._db.ExpectationsWereMet())
+}
+
+func (s *SQLUnitTestSuite) TestSQLUnit_Save_SessionSettings() {
+	// arrange.
+	ctx := context.Background()
+	foo := test.Foo{ID: 28}
+	settings := map[string]string{"app.tenant_id": "28", "app.user_id": "1992"}
+	opts := append(s.opts, work.UnitWithSessionSettings(settings))
+	sut, err := work.NewUnit(opts...)
+	s.Require().NoError(err)
+	s.Require().NoError(sut.Add(ctx, foo))
+	statement := regexp.QuoteMeta("SELECT set_config($1, $2, true)")
+	s._db.ExpectBegin()
+	s._db.ExpectExec(statement).WithArgs("app.tenant_id", "28").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	s._db.ExpectExec(statement).WithArgs("app.user_id", "1992").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	s._db.ExpectCommit()
+	s.mappers[work.TypeNameOf(foo)].EXPECT().Insert(ctx, gomock.Any(), foo).Return(nil)
+
+	// action.
+	err = sut.Save(ctx)
+
+	// assert.
+	s.Require().NoError(err)
+	s.Require().NoError(s._db.ExpectationsWereMet())
+}
+
+func (s *SQLUnitTestSuite) TestSQLUnit_Save_SessionSettingsError() {
+	// arrange.
+	ctx := context.Background()
+	foo := test.Foo{ID: 28}
+	opts := append(s.opts, work.UnitWithSessionSettings(map[string]string{"app.tenant_id": "28"}))
+	sut, err := work.NewUnit(opts...)
+	s.Require().NoError(err)
+	s.Require().NoError(sut.Add(ctx, foo))
+	for i := 0; i < s.retryCount; i++ {
+		s._db.ExpectBegin()
+		s._db.ExpectExec(regexp.QuoteMeta("SELECT set_config($1, $2, true)")).
+			WillReturnError(errors.New("whoa"))
+		s._db.ExpectRollback()
+	}
+
+	// action.
+	err = sut.Save(ctx)
+
+	// assert.
+	s.EqualError(err, "whoa")
+	s.Require().NoError(s._db.ExpectationsWereMet())
+}
+
+func (s *SQLUnitTestSuite) TestSQLUnit_Save_SQLComments() {
+	// arrange.
+	ctx := context.Background()
+	foo := test.Foo{ID: 28}
+	fooType := work.TypeNameOf(foo)
+	tags := map[string]string{"service": "orders", "route": "/orders/{id}"}
+	sut, err := work.NewUnit(append(s.opts, work.UnitSQLComments(tags))...)
+	s.Require().NoError(err)
+	s.Require().NoError(sut.Add(ctx, foo))
+	s._db.ExpectBegin()
+	s._db.ExpectExec(`^INSERT INTO foo \(id\) VALUES \(\$1\) ` +
+		`/\*attempt_id='[0-9a-f-]+',route='%2Forders%2F%7Bid%7D',save_id='[0-9a-f-]+',service='orders'\*/;$`).
+		WithArgs(28).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	s._db.ExpectCommit()
+	s.mappers[fooType].EXPECT().Insert(ctx, gomock.Any(), foo).
+		DoAndReturn(func(ctx context.Context, mCtx work.UnitMapperContext, e ...interface{}) error {
+			_, err := mCtx.ExecContext(ctx, "INSERT INTO foo (id) VALUES ($1);", e[0].(test.Foo).ID)
+			return err
+		})
+
+	// action.
+	err = sut.Save(ctx)
+
+	// assert.
+	s.Require().NoError(err)
+	s.Require().NoError(s._db.ExpectationsWereMet())
+}
+
+func (s *SQLUnitTestSuite) TestSQLUnit_Save_SQLCommentsExistingComment() {
+	// arrange.
+	ctx := context.Background()
+	foo := test.Foo{ID: 28}
+	fooType := work.TypeNameOf(foo)
+	sut, err := work.NewUnit(append(s.opts, work.UnitSQLComments(map[string]string{"service": "orders"}))...)
+	s.Require().NoError(err)
+	s.Require().NoError(sut.Add(ctx, foo))
+	statement := "INSERT INTO foo (id) VALUES ($1) /*hint*/"
+	s._db.ExpectBegin()
+	s._db.ExpectExec("^" + regexp.QuoteMeta(statement) + "$").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	s._db.ExpectCommit()
+	s.mappers[fooType].EXPECT().Insert(ctx, gomock.Any(), foo).
+		DoAndReturn(func(ctx context.Context, mCtx work.UnitMapperContext, e ...interface{}) error {
+			_, err := mCtx.ExecContext(ctx, statement, 28)
+			return err
+		})
+
+	// action.
+	err = sut.Save(ctx)
+
+	// assert.
+	s.Require().NoError(err)
+	s.Require().NoError(s._db.ExpectationsWereMet())
+}
+
+func (s *SQLUnitTestSuite) TestSQLUnit_Save_SlowSave() {
+	// arrange.
+	ctx := context.Background()
+	foo, bar := test.Foo{ID: 28}, test.Bar{ID: "28"}
+	fooType, barType := work.TypeNameOf(foo), work.TypeNameOf(bar)
+	var diagnostics []work.UnitSaveDiagnostics
+	handler := func(d work.UnitSaveDiagnostics) { diagnostics = append(diagnostics, d) }
+	opts := append(s.opts, work.UnitSlowSaveThreshold(0, handler), work.UnitSlowSaveStacks())
+	sut, err := work.NewUnit(opts...)
+	s.Require().NoError(err)
+	s.Require().NoError(sut.Add(ctx, foo))
+	s.Require().NoError(sut.Remove(ctx, bar))
+	s._db.ExpectBegin()
+	s.mappers[fooType].EXPECT().Insert(ctx, gomock.Any(), foo).Return(nil)
+	s.mappers[barType].EXPECT().Delete(ctx, gomock.Any(), bar).Return(nil)
+	s._db.ExpectCommit()
+
+	// action.
+	err = sut.Save(ctx)
+
+	// assert.
+	s.Require().NoError(err)
+	s.Require().Len(diagnostics, 1)
+	d := diagnostics[0]
+	s.NotEmpty(d.SaveID)
+	s.NoError(d.Err)
+	s.Equal(1, d.Attempts)
+	s.Equal(map[work.TypeName]work.UnitTypeCounts{
+		fooType: {Additions: 1},
+		barType: {Removals: 1},
+	}, d.Counts)
+	for _, phase := range []work.UnitSavePhase{
+		work.UnitSavePhaseBegin,
+		work.UnitSavePhaseInserts,
+		work.UnitSavePhaseUpserts,
+		work.UnitSavePhaseUpdates,
+		work.UnitSavePhaseDeletes,
+		work.UnitSavePhaseCommit,
+	} {
+		s.Contains(d.Phases, phase)
+	}
+	s.Contains(string(d.Stacks), "goroutine")
+	s.Require().NoError(s._db.ExpectationsWereMet())
+}
+
+func (s *SQLUnitTestSuite) TestSQLUnit_Save_SlowSaveBelowThreshold() {
+	// arrange.
+	ctx := context.Background()
+	foo := test.Foo{ID: 28}
+	invoked := false
+	handler := func(d work.UnitSaveDiagnostics) { invoked = true }
+	sut, err := work.NewUnit(append(s.opts, work.UnitSlowSaveThreshold(time.Hour, handler))...)
+	s.Require().NoError(err)
+	s.Require().NoError(sut.Add(ctx, foo))
+	s._db.ExpectBegin()
+	s.mappers[work.TypeNameOf(foo)].EXPECT().Insert(ctx, gomock.Any(), foo).Return(nil)
+	s._db.ExpectCommit()
+
+	// action.
+	err = sut.Save(ctx)
+
+	// assert.
+	s.Require().NoError(err)
+	s.False(invoked)
+	s.Require().NoError(s._db.ExpectationsWereMet())
+}
+
+func (s *SQLUnitTestSuite) TestSQLUnit_Save_PhaseTimers() {
+	// arrange.
+	ctx := context.Background()
+	foo := test.Foo{ID: 28}
+	s.Require().NoError(s.sut.Add(ctx, foo))
+	s._db.ExpectBegin()
+	s.mappers[work.TypeNameOf(foo)].EXPECT().Insert(ctx, gomock.Any(), foo).Return(nil)
+	s._db.ExpectCommit()
+
+	// action.
+	err := s.sut.Save(ctx)
+
+	// assert.
+	s.Require().NoError(err)
+	timers := s.scope.Snapshot().Timers()
+	for _, phase := range []string{"begin", "inserts", "upserts", "updates", "deletes", "commit"} {
+		name := fmt.Sprintf("%s.%s%s%s", s.saveScopeName, phase, "+", s.tags)
+		s.Contains(timers, name)
+		s.Len(timers[name].Values(), 1)
+	}
+	s.Require().NoError(s._db.ExpectationsWereMet())
+}
+
+func (s *SQLUnitTestSuite) TestSQLUnit_Save_DeferredStaging() {
+	// arrange.
+	ctx := context.Background()
+	foo, bar := test.Foo{ID: 28}, test.Bar{ID: "28"}
+	fooType, barType := work.TypeNameOf(foo), work.TypeNameOf(bar)
+	opts := append(s.opts, work.UnitDetectReentrancy(), work.UnitDeferredStaging(1))
+	sut, err := work.NewUnit(opts...)
+	s.Require().NoError(err)
+	s.Require().NoError(sut.Add(ctx, foo))
+	s._db.ExpectBegin()
+	s.mappers[fooType].EXPECT().Insert(ctx, gomock.Any(), foo).
+		DoAndReturn(func(ctx context.Context, mCtx work.UnitMapperContext, e ...interface{}) error {
+			return sut.Add(ctx, bar)
+		})
+	s.mappers[barType].EXPECT().Insert(ctx, gomock.Any(), bar).Return(nil)
+	s._db.ExpectCommit()
+
+	// action.
+	err = sut.Save(ctx)
+
+	// assert.
+	s.Require().NoError(err)
+	s.Require().NoError(s._db.ExpectationsWereMet())
+}
+
+func (s *SQLUnitTestSuite) TestSQLUnit_Save_DeferredStagingRetried() {
+	// arrange.
+	ctx := context.Background()
+	foo, bar := test.Foo{ID: 28}, test.Bar{ID: "28"}
+	fooType, barType := work.TypeNameOf(foo), work.TypeNameOf(bar)
+	sut, err := work.NewUnit(append(s.opts, work.UnitDeferredStaging(1))...)
+	s.Require().NoError(err)
+	s.Require().NoError(sut.Add(ctx, foo))
+	stage := func(ctx context.Context, mCtx work.UnitMapperContext, e ...interface{}) error {
+		return sut.Add(ctx, bar)
+	}
+	s._db.ExpectBegin()
+	s._db.ExpectRollback()
+	s._db.ExpectBegin()
+	s._db.ExpectCommit()
+	gomock.InOrder(
+		s.mappers[fooType].EXPECT().Insert(ctx, gomock.Any(), foo).DoAndReturn(stage),
+		s.mappers[barType].EXPECT().Insert(ctx, gomock.Any(), bar).Return(errors.New("whoa")),
+		s.mappers[fooType].EXPECT().Insert(ctx, gomock.Any(), foo).DoAndReturn(stage),
+		s.mappers[barType].EXPECT().Insert(ctx, gomock.Any(), bar).Return(nil),
+	)
+
+	// action.
+	err = sut.Save(ctx)
+
+	// assert.
+	s.Require().NoError(err)
+	s.Require().NoError(s._db.ExpectationsWereMet())
+}
+
+func (s *SQLUnitTestSuite) TestSQLUnit_Save_DeferredStagingPassesExceeded() {
+	// arrange.
+	ctx := context.Background()
+	foo, bar := test.Foo{ID: 28}, test.Bar{ID: "28"}
+	fooType, barType := work.TypeNameOf(foo), work.TypeNameOf(bar)
+	sut, err := work.NewUnit(append(s.opts, work.UnitDeferredStaging(1))...)
+	s.Require().NoError(err)
+	s.Require().NoError(sut.Add(ctx, foo))
+	s._db.ExpectBegin()
+	s.mappers[fooType].EXPECT().Insert(ctx, gomock.Any(), foo).
+		DoAndReturn(func(ctx context.Context, mCtx work.UnitMapperContext, e ...interface{}) error {
+			return sut.Add(ctx, bar)
+		})
+	s.mappers[barType].EXPECT().Insert(ctx, gomock.Any(), bar).
+		DoAndReturn(func(ctx context.Context, mCtx work.UnitMapperContext, e ...interface{}) error {
+			return sut.Add(ctx, test.Bar{ID: "1992"})
+		})
+	s._db.ExpectRollback()
+
+	// action.
+	err = sut.Save(ctx)
+
+	// assert.
+	s.ErrorIs(err, work.ErrUnitStagingPassesExceeded)
+	s.Require().NoError(s._db.ExpectationsWereMet())
+}
+
+func (s *SQLUnitTestSuite) TestSQLUnit_Save_Projection() {
+	// arrange.
+	ctx := context.Background()
+	foos := []interface{}{test.Foo{ID: 28}, test.Foo{ID: 1992}}
+	bars := []interface{}{test.Bar{ID: "28"}, test.Bar{ID: "1992"}}
+	fooType, barType := work.TypeNameOf(test.Foo{}), work.TypeNameOf(test.Bar{})
+	project := func(entity interface{}) []interface{} {
+		return []interface{}{test.Bar{ID: fmt.Sprint(entity.(test.Foo).ID)}}
+	}
+	sut, err := work.NewUnit(append(s.opts, work.UnitWithProjection(fooType, project))...)
+	s.Require().NoError(err)
+	s.Require().NoError(sut.Add(ctx, foos[0]))
+	s.Require().NoError(sut.Remove(ctx, foos[1]))
+	s._db.ExpectBegin()
+	s.mappers[fooType].EXPECT().Insert(ctx, gomock.Any(), foos[0]).Return(nil)
+	s.mappers[barType].EXPECT().Insert(ctx, gomock.Any(), bars[0]).Return(nil)
+	s.mappers[fooType].EXPECT().Delete(ctx, gomock.Any(), foos[1]).Return(nil)
+	s.mappers[barType].EXPECT().Delete(ctx, gomock.Any(), bars[1]).Return(nil)
+	s._db.ExpectCommit()
+
+	// action.
+	err = sut.Save(ctx)
+
+	// assert.
+	s.Require().NoError(err)
+	s.Require().NoError(s._db.ExpectationsWereMet())
+}
+
+func (s *SQLUnitTestSuite) TestSQLUnit_Save_UniqueCheck() {
+	// arrange.
+	ctx := context.Background()
+	foos := []interface{}{test.Foo{ID: 28}, test.Foo{ID: 1992}}
+	fooType := work.TypeNameOf(test.Foo{})
+	taken := errors.New("foo already exists")
+	var checked []interface{}
+	check := func(ctx context.Context, mCtx work.UnitMapperContext, e ...interface{}) error {
+		s.NotNil(mCtx.Tx)
+		checked = e
+		return taken
+	}
+	sut, err := work.NewUnit(append(s.opts, work.UnitUniqueCheck(fooType, check))...)
+	s.Require().NoError(err)
+	s.Require().NoError(sut.Add(ctx, foos[0]))
+	s.Require().NoError(sut.Alter(ctx, foos[1]))
+	s._db.ExpectBegin()
+	s._db.ExpectRollback()
+
+	// action.
+	err = sut.Save(ctx)
+
+	// assert.
+	var uniqueness *work.UnitUniquenessError
+	s.Require().ErrorAs(err, &uniqueness)
+	s.Equal(fooType, uniqueness.TypeName)
+	s.ErrorIs(err, taken)
+	s.Equal(foos, checked)
+	s.Require().NoError(s._db.ExpectationsWereMet())
+}
+
+func (s *SQLUnitTestSuite) TestSQLUnit_Save_OnRetry() {
+	// arrange.
+	type key struct{}
+	ctx := context.WithValue(context.Background(), key{}, "value")
+	foo := test.Foo{ID: 28}
+	fooType := work.TypeNameOf(foo)
+	var attempts []uint
+	var rCtxs []work.UnitRetryContext
+	onRetry := func(attempt uint, err error, rCtx work.UnitRetryContext) {
+		s.EqualError(err, "whoa")
+		attempts = append(attempts, attempt)
+		rCtxs = append(rCtxs, rCtx)
+	}
+	sut, err := work.NewUnit(append(s.opts, work.UnitOnRetry(onRetry))...)
+	s.Require().NoError(err)
+	s.Require().NoError(sut.Add(ctx, foo))
+	for i := 0; i < s.retryCount; i++ {
+		s._db.ExpectBegin()
+		s._db.ExpectRollback()
+	}
+	s.mappers[fooType].EXPECT().Insert(ctx, gomock.Any(), foo).Return(errors.New("whoa")).Times(s.retryCount)
+
+	// action.
+	err = sut.Save(ctx)
+
+	// assert.
+	s.EqualError(err, "whoa")
+	s.Equal([]uint{0}, attempts)
+	s.Require().Len(rCtxs, 1)
+	s.Equal("value", rCtxs[0].Context.Value(key{}))
+	s.NotEmpty(rCtxs[0].SaveID)
+	s.Equal(s.retryCount, rCtxs[0].MaxAttempts)
+	s.Require().NoError(s._db.ExpectationsWereMet())
+}
+
+func (s *SQLUnitTestSuite) TestSQLUnit_Save_Attempt() {
+	// arrange.
+	ctx := context.Background()
+	foo := test.Foo{ID: 28}
+	fooType := work.TypeNameOf(foo)
+	var attempts []int
+	var finals []bool
+	s._db.ExpectBegin()
+	s._db.ExpectRollback()
+	s._db.ExpectBegin()
+	s._db.ExpectCommit()
+	s.mappers[fooType].EXPECT().Insert(ctx, gomock.Any(), foo).
+		DoAndReturn(func(ctx context.Context, mCtx work.UnitMapperContext, e ...interface{}) error {
+			attempts = append(attempts, mCtx.Attempt())
+			finals = append(finals, mCtx.IsFinalAttempt())
+			if !mCtx.IsFinalAttempt() {
+				return errors.New("whoa")
+			}
+			return nil
+		}).Times(s.retryCount)
+	sut, err := work.NewUnit(s.opts...)
+	s.Require().NoError(err)
+	s.Require().NoError(sut.Add(ctx, foo))
+
+	// action.
+	err = sut.Save(ctx)
+
+	// assert.
+	s.Require().NoError(err)
+	s.Equal([]int{1, 2}, attempts)
+	s.Equal([]bool{false, true}, finals)
+	s.Require().NoError(s._db.ExpectationsWereMet())
+}
+
+func (s *SQLUnitTestSuite) TestSQLUnit_Save_MetricNames() {
+	// arrange.
+	ctx := context.Background()
+	foo := test.Foo{ID: 28}
+	fooType := work.TypeNameOf(foo)
+	s._db.ExpectBegin()
+	s._db.ExpectCommit()
+	s.mappers[fooType].EXPECT().Insert(ctx, gomock.Any(), foo).Return(nil)
+	opts := append(s.opts,
+		work.UnitMetricScope("uow"),
+		work.UnitWithMetricNames(work.UnitMetricNames{Save: "commit", Insert: "created"}),
+	)
+	sut, err := work.NewUnit(opts...)
+	s.Require().NoError(err)
+	s.Require().NoError(sut.Add(ctx, foo))
+
+	// action.
+	err = sut.Save(ctx)
+
+	// assert.
+	s.Require().NoError(err)
+	counters := s.scope.Snapshot().Counters()
+	timers := s.scope.Snapshot().Timers()
+	s.Contains(counters, "test.uow.created+"+s.tags)
+	s.Contains(counters, "test.uow.save.success+"+s.tags)
+	s.Contains(timers, "test.uow.commit+"+s.tags)
+	s.Contains(timers, "test.uow.commit.commit+"+s.tags)
+	s.NotContains(counters, s.insertScopeNameWithTags)
+	s.NotContains(timers, s.saveScopeNameWithTags)
+	s.Require().NoError(s._db.ExpectationsWereMet())
+}
+
+func (s *SQLUnitTestSuite) TestSQLUnit_Save_TxLeaked() {
+	// arrange.
+	ctx := context.Background()
+	foo := test.Foo{ID: 28}
+	fooType := work.TypeNameOf(foo)
+	var leaked work.UnitMapperContext
+	s._db.ExpectBegin()
+	s._db.ExpectExec("INSERT INTO foo").WillReturnResult(sqlmock.NewResult(28, 1))
+	s._db.ExpectCommit()
+	s.mappers[fooType].EXPECT().Insert(ctx, gomock.Any(), foo).
+		DoAndReturn(func(ctx context.Context, mCtx work.UnitMapperContext, e ...interface{}) error {
+			leaked = mCtx
+			_, err := mCtx.ExecContext(ctx, "INSERT INTO foo (id) VALUES (28)")
+			return err
+		})
+	sut, err := work.NewUnit(s.opts...)
+	s.Require().NoError(err)
+	s.Require().NoError(sut.Add(ctx, foo))
+	s.Require().NoError(sut.Save(ctx))
+
+	// action.
+	_, err = leaked.ExecContext(ctx, "DELETE FROM foo")
+
+	// assert.
+	s.Require().ErrorIs(err, work.ErrTxLeaked)
+	var leakErr *work.UnitTxLeakError
+	s.Require().ErrorAs(err, &leakErr)
+	s.Equal(fooType, leakErr.TypeName)
+	s.Equal(leaked.SaveID, leakErr.SaveID)
+	s.Contains(s.scope.Snapshot().Counters(), fmt.Sprintf("%s.unit.tx.leak+%s", s.scopePrefix, s.tags))
+	s.Require().NoError(s._db.ExpectationsWereMet())
+}
+
+func (s *SQLUnitTestSuite) TestSQLUnit_Save_MergeAlterations() {
+	// arrange.
+	ctx := context.Background()
+	foo := test.Foo{ID: 28}
+	fooType := work.TypeNameOf(foo)
+	s._db.ExpectBegin()
+	s._db.ExpectCommit()
+	s.mappers[fooType].EXPECT().Update(ctx, gomock.Any(), foo).Return(nil)
+	sut, err := work.NewUnit(append(s.opts, work.UnitMergeAlterations())...)
+	s.Require().NoError(err)
+	s.Require().NoError(sut.Alter(ctx, foo))
+	s.Require().NoError(sut.Alter(ctx, foo))
+	s.Require().NoError(sut.Alter(ctx, foo))
+
+	// action.
+	err = sut.Save(ctx)
+
+	// assert.
+	s.Require().NoError(err)
+	merged := fmt.Sprintf("%s.unit.alter.merged+%s", s.scopePrefix, s.tags)
+	s.Require().Contains(s.scope.Snapshot().Counters(), merged)
+	s.EqualValues(2, s.scope.Snapshot().Counters()[merged].Value())
+	s.Require().NoError(s._db.ExpectationsWereMet())
+}
+
+func (s *SQLUnitTestSuite) TestSQLUnit_Save_StaleEntity() {
+	tests := []struct {
+		name       string
+		resolution work.UnitResolution
+		expected   test.Foo
+		forced     bool
+	}{
+		{"Ours", work.UnitResolution{Choice: work.UnitResolutionOurs}, test.Foo{ID: 28}, true},
+		{"Merge", work.UnitResolution{Choice: work.UnitResolutionMerge, Merged: test.Foo{ID: 28}}, test.Foo{ID: 28}, false},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			// arrange.
+			s.Setup()
+			ctx := context.Background()
+			foo := test.Foo{ID: 28}
+			fooType := work.TypeNameOf(foo)
+			var conflicts []work.UnitConflict
+			resolver := func(c work.UnitConflict) work.UnitResolution {
+				conflicts = append(conflicts, c)
+				return tt.resolution
+			}
+			var forced []bool
+			s._db.ExpectBegin()
+			s._db.ExpectCommit()
+			s.mappers[fooType].EXPECT().Update(ctx, gomock.Any(), gomock.Any()).
+				DoAndReturn(func(ctx context.Context, mCtx work.UnitMapperContext, e ...interface{}) error {
+					forced = append(forced, mCtx.Forced())
+					if len(forced) == 1 {
+						return &work.UnitStaleEntityError{Entity: e[0], Current: test.Foo{ID: 28}}
+					}
+					s.Equal([]interface{}{tt.expected}, e)
+					return nil
+				}).Times(2)
+			sut, err := work.NewUnit(append(s.opts, work.UnitConflictResolver(resolver))...)
+			s.Require().NoError(err)
+			s.Require().NoError(sut.Alter(ctx, foo))
+
+			// action.
+			err = sut.Save(ctx)
+
+			// assert.
+			s.Require().NoError(err)
+			s.Equal([]bool{false, tt.forced}, forced)
+			s.Require().Len(conflicts, 1)
+			s.True(conflicts[0].Stale)
+			s.Equal(work.EntityStateAltered, conflicts[0].OursState)
+			s.Require().NoError(s._db.ExpectationsWereMet())
+		})
+	}
+}
+
+func (s *SQLUnitTestSuite) TestSQLUnit_Save_StaleEntity_ForcesResolvedOnly() {
+	// arrange.
+	ctx := context.Background()
+	foos := []interface{}{test.Foo{ID: 28}, test.Foo{ID: 1992}}
+	fooType := work.TypeNameOf(test.Foo{})
+	resolver := func(c work.UnitConflict) work.UnitResolution {
+		return work.UnitResolution{Choice: work.UnitResolutionOurs}
+	}
+	forced := make(map[bool][]interface{})
+	s._db.ExpectBegin()
+	s._db.ExpectCommit()
+	s.mappers[fooType].EXPECT().Update(ctx, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, mCtx work.UnitMapperContext, e ...interface{}) error {
+			if len(e) == len(foos) {
+				return &work.UnitStaleEntityError{Entity: foos[0], Current: foos[0]}
+			}
+			forced[mCtx.Forced()] = append(forced[mCtx.Forced()], e...)
+			return nil
+		}).Times(3)
+	sut, err := work.NewUnit(append(s.opts, work.UnitConflictResolver(resolver))...)
+	s.Require().NoError(err)
+	s.Require().NoError(sut.Alter(ctx, foos...))
+
+	// action.
+	err = sut.Save(ctx)
+
+	// assert.
+	s.Require().NoError(err)
+	s.Equal([]interface{}{foos[0]}, forced[true])
+	s.Equal([]interface{}{foos[1]}, forced[false])
+	s.Require().NoError(s._db.ExpectationsWereMet())
+}
+
+func (s *SQLUnitTestSuite) TestSQLUnit_Save_StaleEntity_Unresolved() {
+	// arrange.
+	ctx := context.Background()
+	foo := test.Foo{ID: 28}
+	fooType := work.TypeNameOf(foo)
+	stale := &work.UnitStaleEntityError{Entity: foo}
+	for i := 0; i < s.retryCount; i++ {
+		s._db.ExpectBegin()
+		s._db.ExpectRollback()
+	}
+	s.mappers[fooType].EXPECT().Update(ctx, gomock.Any(), foo).Return(stale).Times(s.retryCount)
+	s.Require().NoError(s.sut.Alter(ctx, foo))
+
+	// action.
+	err := s.sut.Save(ctx)
+
+	// assert.
+	s.ErrorIs(err, work.ErrStaleEntity)
+	s.Require().NoError(s._db.ExpectationsWereMet())
+}
+
+func (s *SQLUnitTestSuite) TestSQLUnit_Save_TxHold() {
+	// arrange.
+	ctx := context.Background()
+	foo := test.Foo{ID: 28}
+	fooType := work.TypeNameOf(foo)
+	s._db.ExpectBegin()
+	s._db.ExpectRollback()
+	s._db.ExpectBegin()
+	s._db.ExpectCommit()
+	gomock.InOrder(
+		s.mappers[fooType].EXPECT().Insert(ctx, gomock.Any(), foo).Return(errors.New("whoa")),
+		s.mappers[fooType].EXPECT().Insert(ctx, gomock.Any(), foo).Return(nil),
+	)
+	s.Require().NoError(s.sut.Add(ctx, foo))
+
+	// action.
+	err := s.sut.Save(ctx)
+
+	// assert.
+	s.Require().NoError(err)
+	name := fmt.Sprintf("%s.unit.tx.hold+%s", s.scopePrefix, s.tags)
+	s.Require().Contains(s.scope.Snapshot().Timers(), name)
+	s.Len(s.scope.Snapshot().Timers()[name].Values(), 2)
+	s.Require().NoError(s._db.ExpectationsWereMet())
+}
+
+func (s *SQLUnitTestSuite) TestSQLUnit_Save_PreflightPing() {
+	// arrange.
+	ctx := context.Background()
+	foo := test.Foo{ID: 28}
+	fooType := work.TypeNameOf(foo)
+	db, _db, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+	s.Require().NoError(err)
+	_db.ExpectPing()
+	_db.ExpectBegin()
+	_db.ExpectCommit()
+	s.mappers[fooType].EXPECT().Insert(ctx, gomock.Any(), foo).Return(nil)
+	opts := append(s.opts, work.UnitDB(db), work.UnitPreflightPing())
+	sut, err := work.NewUnit(opts...)
+	s.Require().NoError(err)
+	s.Require().NoError(sut.Add(ctx, foo))
+
+	// action.
+	err = sut.Save(ctx)
+
+	// assert.
+	s.Require().NoError(err)
+	s.Require().NoError(_db.ExpectationsWereMet())
+}
+
+func (s *SQLUnitTestSuite) TestSQLUnit_Save_PreflightPingFailure() {
+	// arrange.
+	ctx := context.Background()
+	foo := test.Foo{ID: 28}
+	db, _db, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+	s.Require().NoError(err)
+	_db.ExpectPing().WillReturnError(driver.ErrBadConn)
+	opts := append(s.opts, work.UnitDB(db), work.UnitPreflightPing())
+	sut, err := work.NewUnit(opts...)
+	s.Require().NoError(err)
+	s.Require().NoError(sut.Add(ctx, foo))
+
+	// action.
+	err = sut.Save(ctx)
+
+	// assert.
+	s.ErrorIs(err, work.ErrPreflightPing)
+	s.ErrorIs(err, driver.ErrBadConn)
+	name := fmt.Sprintf("%s.unit.preflight.failure+%s", s.scopePrefix, s.tags)
+	s.Require().Contains(s.scope.Snapshot().Counters(), name)
+	s.Equal(int64(1), s.scope.Snapshot().Counters()[name].Value())
+	s.Require().NoError(_db.ExpectationsWereMet())
+}
+
+func (s *SQLUnitTestSuite) TestSQLUnit_Save_ClassifiedError() {
+	// arrange.
+	ctx := context.Background()
+	foo := test.Foo{ID: 28}
+	fooType := work.TypeNameOf(foo)
+	errDuplicate := errors.New("duplicate")
+	work.RegisterErrorClassifier("sqlmock", func(err error) work.ErrorClass {
+		if errors.Is(err, errDuplicate) {
+			return work.ErrorClassConstraint
+		}
+		return work.ErrorClassUnknown
+	})
+	s._db.ExpectBegin()
+	s._db.ExpectRollback()
+	s.mappers[fooType].EXPECT().Insert(ctx, gomock.Any(), foo).Return(errDuplicate)
+	sut, err := work.NewUnit(append(s.opts, work.UnitDriverName("sqlmock"))...)
+	s.Require().NoError(err)
+	s.Require().NoError(sut.Add(ctx, foo))
+
+	// action.
+	err = sut.Save(ctx)
+
+	// assert.
+	var classified *work.ClassifiedError
+	s.Require().ErrorAs(err, &classified)
+	s.Equal(work.ErrorClassConstraint, classified.Class)
+	s.ErrorIs(err, errDuplicate)
+	name := fmt.Sprintf("%s.unit.save.failure+error_class=constraint,%s", s.scopePrefix, s.tags)
+	s.Contains(s.scope.Snapshot().Counters(), name)
+	s.Require().NoError(s._db.ExpectationsWereMet())
+}
+
+func (s *SQLUnitTestSuite) TestSQLUnit_Save_ErrorReport() {
+	// arrange.
+	ctx := context.Background()
+	foo, bar := test.Foo{ID: 28}, test.Bar{ID: "28"}
+	fooType, barType := work.TypeNameOf(foo), work.TypeNameOf(bar)
+	for i := 0; i < s.retryCount; i++ {
+		s._db.ExpectBegin()
+		s._db.ExpectRollback().WillReturnError(errors.New("whoa"))
+	}
+	s.mappers[fooType].EXPECT().Insert(ctx, gomock.Any(), foo).Return(nil).Times(s.retryCount)
+	s.mappers[barType].EXPECT().Update(ctx, gomock.Any(), bar).Return(errors.New("ouch")).Times(s.retryCount)
+	s.Require().NoError(s.sut.Add(ctx, foo))
+	s.Require().NoError(s.sut.Alter(ctx, bar))
+
+	// action.
+	err := s.sut.Save(ctx)
+	report := work.NewErrorReport(err)
+
+	// assert.
+	s.Require().Error(err)
+	s.Require().NotNil(report)
+	s.Equal("ouch; whoa", report.Message)
+	s.Equal([]work.ErrorReportFailure{
+		{TypeName: barType, Operation: "update", Errors: []string{"ouch"}},
+		{Errors: []string{"whoa"}},
+	}, report.Failures)
+	b, err := json.Marshal(report)
+	s.Require().NoError(err)
+	s.JSONEq(`{
+		"message": "ouch; whoa",
+		"failures": [
+			{"typeName": "test.Bar", "operation": "update", "errors": ["ouch"]},
+			{"errors": ["whoa"]}
+		]
+	}`, string(b))
+	s.Nil(work.NewErrorReport(nil))
+	s.Require().NoError(s._db.ExpectationsWereMet())
+}
+
+func (s *SQLUnitTestSuite) TestSQLUnit_Save_ActionMetrics() {
+	// arrange.
+	ctx := context.Background()
+	foo := test.Foo{ID: 28}
+	fooType := work.TypeNameOf(foo)
+	var causes []error
+	opts := append(s.opts,
+		work.UnitNamedAction("audit", work.UnitActionTypeAfterSave,
+			func(work.UnitActionContext) { panic("whoa") }),
+		work.UnitSaveActions(func(ctx work.UnitSaveActionContext) {
+			causes = append(causes, ctx.Err)
+		}),
+	)
+	sut, err := work.NewUnit(opts...)
+	s.Require().NoError(err)
+	s.Require().NoError(sut.Add(ctx, foo))
+	s._db.ExpectBegin()
+	s._db.ExpectCommit()
+	s.mappers[fooType].EXPECT().Insert(ctx, gomock.Any(), foo).Return(nil)
+
+	// action.
+	err = sut.Save(ctx)
+
+	// assert.
+	s.Require().NoError(err)
+	s.Equal([]error{nil}, causes)
+	tags := fmt.Sprintf("action_name=audit,action_type=after_save,%s", s.tags)
+	s.Contains(s.scope.Snapshot().Timers(), fmt.Sprintf("%s.unit.action+%s", s.scopePrefix, tags))
+	s.Contains(s.scope.Snapshot().Counters(), fmt.Sprintf("%s.unit.action.panic+%s", s.scopePrefix, tags))
+	s.Contains(s.scope.Snapshot().Timers(), fmt.Sprintf("%s.unit.action+action_type=save,%s", s.scopePrefix, s.tags))
+	s.Require().NoError(s._db.ExpectationsWereMet())
+}
+
+func (s *SQLUnitTestSuite) TestSQLUnit_Save_ActionPanicError() {
+	// arrange.
+	ctx := context.Background()
+	foo := test.Foo{ID: 28}
+	opts := append(s.opts,
+		work.UnitHaltActionsOnFailure(),
+		work.UnitNamedAction("authorize", work.UnitActionTypeBeforeInserts,
+			func(work.UnitActionContext) { panic("unauthorized") }),
+	)
+	sut, err := work.NewUnit(opts...)
+	s.Require().NoError(err)
+	s.Require().NoError(sut.Add(ctx, foo))
+	s._db.ExpectBegin()
+	s._db.ExpectRollback()
+
+	// action.
+	err = sut.Save(ctx)
+
+	// assert.
+	var panicErr *work.UnitActionPanicError
+	s.Require().ErrorAs(err, &panicErr)
+	s.Equal("before_inserts", panicErr.ActionType)
+	s.Equal("authorize", panicErr.Name)
+	s.Equal("unauthorized", panicErr.Value)
+	s.NotEmpty(panicErr.Stack)
+	s.ErrorIs(err, work.ErrUnitActionFailed)
+	s.Require().NoError(s._db.ExpectationsWereMet())
+}
+
+func (s *SQLUnitTestSuite) TestSQLUnit_Save_Authorizer() {
+	// arrange.
+	type role struct{}
+	ctx := context.WithValue(context.Background(), role{}, "clerk")
+	foo, bar := test.Foo{ID: 28}, test.Bar{ID: "28"}
+	errClerk := errors.New("clerks cannot remove bars")
+	var authorized []work.UnitOperation
+	authorizer := func(ctx context.Context, op work.UnitOperation, entity interface{}) error {
+		authorized = append(authorized, op)
+		if op == work.UnitOperationDelete && ctx.Value(role{}) == "clerk" {
+			return errClerk
+		}
+		return nil
+	}
+	sut, err := work.NewUnit(append(s.opts, work.UnitWithAuthorizer(authorizer))...)
+	s.Require().NoError(err)
+	s.Require().NoError(sut.Add(ctx, foo))
+	s.Require().NoError(sut.Remove(ctx, bar))
+
+	// action.
+	err = sut.Save(ctx)
+
+	// assert.
+	s.ErrorIs(err, work.ErrForbidden)
+	s.ErrorIs(err, errClerk)
+	var forbidden *work.UnitForbiddenError
+	s.Require().ErrorAs(err, &forbidden)
+	s.Equal(work.UnitOperationDelete, forbidden.Operation)
+	s.Equal(work.TypeNameOf(bar), forbidden.TypeName)
+	s.Equal(bar, forbidden.Entity)
+	s.Equal([]work.UnitOperation{work.UnitOperationInsert, work.UnitOperationDelete}, authorized)
+	s.Require().NoError(s._db.ExpectationsWereMet())
+}
+
+func (s *SQLUnitTestSuite) TestSQLUnit_Save_DriverName() {
+	// arrange.
+	ctx := context.Background()
+	foo := test.Foo{ID: 28}
+	fooType := work.TypeNameOf(foo)
+	sut, err := work.NewUnit(append(s.opts, work.UnitDriverName("postgres"))...)
+	s.Require().NoError(err)
+	s.Require().NoError(sut.Add(ctx, foo))
+	s._db.ExpectBegin()
+	s._db.ExpectCommit()
+	var driverName string
+	s.mappers[fooType].EXPECT().Insert(ctx, gomock.Any(), foo).
+		DoAndReturn(func(ctx context.Context, mCtx work.UnitMapperContext, e ...interface{}) error {
+			driverName = mCtx.DriverName()
+			return nil
+		})
+
+	// action.
+	err = sut.Save(ctx)
+
+	// assert.
+	s.Require().NoError(err)
+	s.Equal("postgres", driverName)
+}
+
+func (s *SQLUnitTestSuite) TestSQLUnit_Save_WrapTx() {
+	// arrange.
+	ctx := context.Background()
+	foo := test.Foo{ID: 28}
+	fooType := work.TypeNameOf(foo)
+	var leaked work.UnitMapperContext
+	s._db.ExpectBegin()
+	s._db.ExpectExec("INSERT INTO foo").WillReturnResult(sqlmock.NewResult(28, 1))
+	s._db.ExpectCommit()
+	s.mappers[fooType].EXPECT().Insert(ctx, gomock.Any(), foo).
+		DoAndReturn(func(ctx context.Context, mCtx work.UnitMapperContext, e ...interface{}) error {
+			leaked = mCtx
+			tx, err := work.WrapTx(mCtx, func(tx *sql.Tx) *sql.Tx { return tx })
+			if err != nil {
+				return err
+			}
+			_, err = tx.ExecContext(ctx, "INSERT INTO foo (id) VALUES (28)")
+			return err
+		})
+	sut, err := work.NewUnit(s.opts...)
+	s.Require().NoError(err)
+	s.Require().NoError(sut.Add(ctx, foo))
+
+	// action.
+	err = sut.Save(ctx)
+
+	// assert.
+	s.Require().NoError(err)
+	s.Require().NoError(s._db.ExpectationsWereMet())
+	_, err = work.WrapTx(leaked, func(tx *sql.Tx) *sql.Tx { return tx })
+	s.ErrorIs(err, work.ErrTxLeaked)
+	_, err = work.WrapTx(work.UnitMapperContext{}, func(tx *sql.Tx) *sql.Tx { return tx })
+	s.ErrorIs(err, work.ErrMissingTx)
+}
+
+func (s *SQLUnitTestSuite) TestSQLUnit_Save_Raw() {
+	// arrange.
+	ctx := context.Background()
+	foo := test.Foo{ID: 28}
+	fooType := work.TypeNameOf(foo)
+	var leaked work.UnitMapperContext
+	var driverConn interface{}
+	s._db.ExpectBegin()
+	s._db.ExpectCommit()
+	s.mappers[fooType].EXPECT().Insert(ctx, gomock.Any(), foo).
+		DoAndReturn(func(ctx context.Context, mCtx work.UnitMapperContext, e ...interface{}) error {
+			leaked = mCtx
+			return mCtx.Raw(func(c interface{}) error {
+				driverConn = c
+				return nil
+			})
+		})
+	sut, err := work.NewUnit(s.opts...)
+	s.Require().NoError(err)
+	s.Require().NoError(sut.Add(ctx, foo))
+
+	// action.
+	err = sut.Save(ctx)
+
+	// assert.
+	s.Require().NoError(err)
+	s.Require().NoError(s._db.ExpectationsWereMet())
+	s.Implements((*driver.Conn)(nil), driverConn)
+	s.ErrorIs(leaked.Raw(func(interface{}) error { return nil }), work.ErrTxLeaked)
+	s.ErrorIs(work.UnitMapperContext{}.Raw(func(interface{}) error { return nil }), work.ErrMissingTx)
+}
+
+func (s *SQLUnitTestSuite) TestSQLUnit_MapperContext_MissingTx() {
+	// arrange.
+	ctx := context.Background()
+	mCtx := work.UnitMapperContext{}
+
+	// action.
+	_, execErr := mCtx.ExecContext(ctx, "DELETE FROM foo")
+	_, queryErr := mCtx.QueryContext(ctx, "SELECT id FROM foo")
+	var id int
+	rowErr := mCtx.QueryRowContext(ctx, "SELECT id FROM foo").Scan(&id)
+	_, prepareErr := mCtx.PrepareContext(ctx, "SELECT id FROM foo")
+
+	// assert.
+	s.ErrorIs(execErr, work.ErrMissingTx)
+	s.ErrorIs(queryErr, work.ErrMissingTx)
+	s.ErrorIs(rowErr, work.ErrMissingTx)
+	s.ErrorIs(prepareErr, work.ErrMissingTx)
+}
+
+func (s *SQLUnitTestSuite) TestSQLUnit_ReadOnly() {
+
+	// arrange.
+	ctx := context.Background()
+	foo := test.Foo{ID: 28}
+	query := regexp.QuoteMeta("SELECT id FROM foo")
+
+	s.Run("ConsistentReads", func() {
+		s._db.ExpectBegin()
+		s._db.ExpectQuery(query).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(28))
+		s._db.ExpectQuery(query).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(28))
+		s._db.ExpectCommit()
+		sut, err := work.NewReadOnlyUnit(s.opts...)
+		s.Require().NoError(err)
+		read := func(ctx context.Context, mCtx work.UnitMapperContext) error {
+			s.NotNil(mCtx.Tx)
+			var id int
+			if err := mCtx.QueryRowContext(ctx, "SELECT id FROM foo").Scan(&id); err != nil {
+				return err
+			}
+			return sut.Register(ctx, test.Foo{ID: id})
+		}
+
+		// action.
+		s.Require().NoError(sut.Read(ctx, read))
+		s.Require().NoError(sut.Read(ctx, read))
+		err = sut.Save(ctx)
+
+		// assert.
+		s.Require().NoError(err)
+		s.Require().NoError(s._db.ExpectationsWereMet())
+		s.Equal(work.UnitStatusCompleted, sut.Status())
+		state, ok := sut.StateOf(foo)
+		s.True(ok)
+		s.Equal(work.EntityStateRegistered, state)
+		s.Contains(s.scope.Snapshot().Counters(), s.saveSuccessScopeNameWithTags)
+	})
+
+	s.Run("StagingForbidden", func() {
+		sut, err := work.NewReadOnlyUnit(s.opts...)
+		s.Require().NoError(err)
+
+		// action + assert.
+		s.ErrorIs(sut.Add(ctx, foo), work.ErrUnitReadOnly)
+		s.ErrorIs(sut.Alter(ctx, foo), work.ErrUnitReadOnly)
+		s.ErrorIs(sut.Remove(ctx, foo), work.ErrUnitReadOnly)
+		s.ErrorIs(sut.Upsert(ctx, foo), work.ErrUnitReadOnly)
+	})
+
+	s.Run("ReadError", func() {
+		s._db.ExpectBegin()
+		s._db.ExpectRollback()
+		sut, err := work.NewReadOnlyUnit(s.opts...)
+		s.Require().NoError(err)
+
+		// action.
+		err = sut.Read(ctx, func(context.Context, work.UnitMapperContext) error {
+			return errors.New("whoa")
+		})
+
+		// assert.
+		s.EqualError(err, "whoa")
+		s.Require().NoError(s._db.ExpectationsWereMet())
+	})
+
+	s.Run("Discard", func() {
+		s._db.ExpectBegin()
+		s._db.ExpectQuery(query).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(28))
+		s._db.ExpectRollback()
+		sut, err := work.NewReadOnlyUnit(s.opts...)
+		s.Require().NoError(err)
+		s.Require().NoError(sut.Read(ctx, func(ctx context.Context, mCtx work.UnitMapperContext) error {
+			var id int
+			return mCtx.QueryRowContext(ctx, "SELECT id FROM foo").Scan(&id)
+		}))
+
+		// action.
+		err = sut.Discard(ctx)
+
+		// assert.
+		s.Require().NoError(err)
+		s.Require().NoError(s._db.ExpectationsWereMet())
+		s.Equal(work.UnitStatusDiscarded, sut.Status())
+		s.ErrorIs(sut.Read(ctx, func(context.Context, work.UnitMapperContext) error {
+			return nil
+		}), work.ErrReadOnlyUnitDiscarded)
+		s.ErrorIs(sut.Save(ctx), work.ErrReadOnlyUnitDiscarded)
+		s.NoError(sut.Discard(ctx))
+	})
+
+	s.Run("OptionsAppliedOnce", func() {
+		applied := 0
+		opts := append(s.opts, func(*work.UnitOptions) { applied++ })
+
+		// action.
+		_, err := work.NewReadOnlyUnit(opts...)
+
+		// assert.
+		s.Require().NoError(err)
+		s.Equal(1, applied)
+	})
+
+	s.Run("MissingDB", func() {
+		// action.
+		_, err := work.NewReadOnlyUnit()
+
+		// assert.
+		s.ErrorIs(err, work.ErrReadOnlyUnitDB)
+	})
+}
+
+// unitTimers provides the timers emitted, excluding those for each phase of
+// a save, for how long transactions are held, and for each action.
+func (s *SQLUnitTestSuite) unitTimers() map[string]tally.TimerSnapshot {
+	timers := make(map[string]tally.TimerSnapshot)
+	for name, timer := range s.scope.Snapshot().Timers() {
+		if !strings.HasPrefix(name, s.saveScopeName+".") &&
+			!strings.HasPrefix(name, s.scopePrefix+".unit.tx.hold") &&
+			!strings.HasPrefix(name, s.scopePrefix+".unit.action+") {
+			timers[name] = timer
+		}
+	}
+	return timers
+}
+
 func (s *SQLUnitTestSuite) TearDown() {
 	defer func() { s.isSetup, s.isTornDown = false, true }()
 
@@ -19,27 +19,52 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"runtime"
+	"runtime/debug"
 	"sync"
 	"time"
 
 	"github.com/avast/retry-go/v4"
 	"github.com/freerware/work/v4/internal/adapters"
 	"github.com/uber-go/tally/v4"
+	"go.uber.org/multierr"
 )
 
 // Metric scope name definitions.
 const (
-	rollbackSuccess = "rollback.success"
-	rollbackFailure = "rollback.failure"
-	saveSuccess     = "save.success"
-	save            = "save"
-	rollback        = "rollback"
-	retryAttempt    = "retry.attempt"
-	insert          = "insert"
-	update          = "update"
-	delete          = "delete"
-	cacheInsert     = "cache.insert"
-	cacheDelete     = "cache.delete"
+	rollbackSuccess     = "rollback.success"
+	rollbackFailure     = "rollback.failure"
+	saveSuccess         = "save.success"
+	save                = "save"
+	rollback            = "rollback"
+	retryAttempt        = "retry.attempt"
+	rollbackRetry       = "rollback.retry"
+	insert              = "insert"
+	update              = "update"
+	delete              = "delete"
+	cacheInsert         = "cache.insert"
+	cacheDelete         = "cache.delete"
+	upsert              = "upsert"
+	patch               = "patch"
+	deleteWhere         = "delete.where"
+	changeRecordFailure = "cdc.failure"
+	commitAmbiguous     = "commit.ambiguous"
+	quarantine          = "quarantine"
+	alterMerged         = "alter.merged"
+	stale               = "stale"
+	txHold              = "tx.hold"
+	staleEvicted        = "stale.evicted"
+	txLeak              = "tx.leak"
+	saveFailure         = "save.failure"
+	action              = "action"
+	actionPanic         = "action.panic"
+	preflightFailure    = "preflight.failure"
+	cacheError          = "cache.error"
+	invalidationRetry   = "cache.invalidation.retry"
+	invalidationFailure = "cache.invalidation.failure"
+	mutationGuard       = "mutation.guard"
+	compensationSuccess = "compensation.success"
+	compensationFailure = "compensation.failure"
 )
 
 var (
@@ -60,6 +85,12 @@ type Unit interface {
 	// Register tracks the provided entities as clean.
 	Register(context.Context, ...interface{}) error
 
+	// RegisterBatch tracks the provided entities, all of which must have the
+	// provided type name, as clean. It is optimized for registering many
+	// entities at once, acquiring the work unit's lock a single time and
+	// caching the entities in bulk.
+	RegisterBatch(context.Context, TypeName, []interface{}) error
+
 	// Cached provides the entities that have been previously registered
 	// and have not been acted on via Add, Alter, or Remove.
 	Cached() *UnitCache
@@ -70,46 +101,194 @@ type Unit interface {
 	// Alter marks the provided entities as modifications.
 	Alter(context.Context, ...interface{}) error
 
+	// AddBackground marks the provided entities as new additions using
+	// context.Background.
+	AddBackground(...interface{}) error
+
 	// Remove marks the provided entities as removals.
 	Remove(context.Context, ...interface{}) error
 
+	// Upsert marks the provided entities as upserts, which are inserted
+	// if they do not exist and updated otherwise.
+	Upsert(context.Context, ...interface{}) error
+
+	// Patch marks the entity with the provided type name and ID for a
+	// partial update, where only the provided fields are modified.
+	Patch(context.Context, TypeName, interface{}, map[string]interface{}) error
+
+	// RemoveWhere marks the entities with the provided type name that match
+	// the provided criteria as removals.
+	RemoveWhere(context.Context, TypeName, interface{}) error
+
 	// Save commits the new additions, modifications, and removals
-	// within the work unit to a persistent store.
-	Save(context.Context) error
+	// within the work unit to a persistent store, applying the provided
+	// save options.
+	Save(context.Context, ...UnitSaveOption) error
+
+	// SaveBackground commits the new additions, modifications, and removals
+	// within the work unit to a persistent store using context.Background.
+	SaveBackground() error
+
+	// SaveAsync commits the new additions, modifications, and removals
+	// within the work unit to a persistent store in a new goroutine,
+	// providing the future for the outcome of the save.
+	SaveAsync(context.Context) *UnitSaveFuture
+
+	// Group creates a new group whose goroutines stage entities into the
+	// work unit concurrently, along with a context derived from the one
+	// provided. If any of the group's goroutines fail, the work unit fails
+	// upon save.
+	Group(context.Context) (*UnitGroup, context.Context)
+
+	// StateOf provides the state of the entity within the work unit that
+	// shares the same identity as the entity provided, if any.
+	StateOf(interface{}) (EntityState, bool)
+
+	// ClearActions removes all of the actions of the provided action type.
+	ClearActions(UnitActionType)
+
+	// RemoveAction removes the actions identified by the provided name,
+	// indicating whether any were removed.
+	RemoveAction(string) bool
+
+	// Quarantined provides the entities that were quarantined during the
+	// most recent save.
+	Quarantined() []UnitQuarantinedEntity
+
+	// CommitToken provides the token for the most recent successful save of
+	// the work unit.
+	CommitToken() string
+
+	// AwaitToken blocks until the data store has applied the position
+	// identified by the token the work unit was created with, such as before
+	// reading from a replica.
+	AwaitToken(context.Context) error
+
+	// Status provides the status of the work unit within its lifecycle.
+	Status() UnitStatus
+
+	// LockToken provides the lock token captured for the provided entity
+	// when it was registered, or when it was last saved, indicating whether
+	// one was captured.
+	LockToken(interface{}) (string, bool)
 }
 
 type unit struct {
-	additions       map[TypeName][]interface{}
-	alterations     map[TypeName][]interface{}
-	removals        map[TypeName][]interface{}
-	registered      map[TypeName][]interface{}
-	cached          *UnitCache
-	additionCount   int
-	alterationCount int
-	removalCount    int
-	registerCount   int
-	logger          UnitLogger
-	scope           tally.Scope
-	actions         map[UnitActionType][]UnitAction
-	mutex           sync.RWMutex
-	db              *sql.DB
-	retryOptions    []retry.Option
-	insertFuncs     *sync.Map
-	updateFuncs     *sync.Map
-	deleteFuncs     *sync.Map
+	additions                   map[TypeName][]interface{}
+	alterations                 map[TypeName][]interface{}
+	removals                    map[TypeName][]interface{}
+	registered                  map[TypeName][]interface{}
+	upserts                     map[TypeName][]interface{}
+	patches                     map[TypeName][]UnitPatch
+	removalCriteria             map[TypeName][]interface{}
+	cached                      *UnitCache
+	additionCount               int
+	alterationCount             int
+	removalCount                int
+	registerCount               int
+	upsertCount                 int
+	patchCount                  int
+	criteriaCount               int
+	logger                      UnitLogger
+	tracer                      UnitTracer
+	scope                       unitScope
+	tallyScope                  tally.Scope
+	metrics                     UnitMetricNames
+	actions                     map[UnitActionType][]unitAction
+	haltActions                 bool
+	changeRecordSink            UnitChangeRecordSink
+	maxOperationsPerTransaction int
+	quarantineAfter             int
+	idGenerator                 UnitIDGenerator
+	quarantineSink              UnitQuarantineSink
+	quarantined                 []UnitQuarantinedEntity
+	actionsMutex                sync.RWMutex
+	rollbackActions             []UnitRollbackAction
+	saveActions                 []UnitSaveAction
+	mutex                       sync.RWMutex
+	db                          *sql.DB
+	retryOptions                []retry.Option
+	rollbackRetryOptions        []retry.Option
+	insertFuncs                 *sync.Map
+	updateFuncs                 *sync.Map
+	deleteFuncs                 *sync.Map
+	upsertFuncs                 *sync.Map
+	patchFuncs                  *sync.Map
+	deleteWhereFuncs            *sync.Map
+	refreshFuncs                *sync.Map
+	refreshAfterSave            bool
+	tokens                      unitCommitTokens
+	timings                     unitSaveTimings
+	slowSaveThreshold           time.Duration
+	slowSaveHandler             UnitSlowSaveHandler
+	slowSaveStacks              bool
+	detectReentrancy            bool
+	deferredStagingPasses       int
+	projections                 map[TypeName][]UnitProjectionBuilder
+	rejectNilEntities           bool
+	strictTracking              bool
+	uniqueChecks                map[TypeName][]UnitUniqueCheckFunc
+	retryAttempts               int
+	retryFuncs                  []UnitRetryFunc
+	goroutineAudit              UnitGoroutineAuditMode
+	mergeAlterations            bool
+	conflictResolver            UnitConflictResolverFunc
+	staleAfter                  time.Duration
+	evictStale                  bool
+	preflightPing               bool
+	driverName                  string
+	allowedTypes                map[TypeName]struct{}
+	deniedTypes                 map[TypeName]struct{}
+	authorizer                  UnitAuthorizerFunc
+	cacheFailureMode            UnitCacheFailureMode
+	invalidationRetryAttempts   int
+	invalidationRetryDelay      time.Duration
+	invalidationQueueSize       int
+	bulkWriter                  UnitBulkWriteFunc
+	bulkTypes                   map[TypeName]struct{}
+	created                     time.Time
+	trackActive                 bool
+	activeID                    string
+	creationStack               []byte
+	appendOnly                  bool
+	readOnly                    bool
+	events                      *unitEventSourcing
+	aggregateRoots              map[TypeName][]TypeName
+	locks                       unitLockTokens
+	history                     unitRetryHistory
+	mutationLimits              map[TypeName]int
+	codecs                      map[TypeName]UnitCodec
+	defaultCodec                UnitCodec
+	confirmation                *unitConfirmation
+	saveMiddleware              []UnitSaveMiddlewareFunc
+	staleness                   unitStaleness
+	invalidation                unitInvalidation
+	status                      int32
+	goroutine                   uint64
+	saving                      int32
+
+	sessionSettings     map[string]string
+	sqlCommentTags      map[string]string
+	deferConstraints    bool
+	deferredConstraints []string
+	failure             error
 }
 
 func options(options []UnitOption) UnitOptions {
 	// set defaults.
 	o := UnitOptions{
-		logger:             adapters.NewNopLogger(),
-		scope:              tally.NoopScope,
-		actions:            make(map[UnitActionType][]UnitAction),
-		retryAttempts:      3,
-		retryType:          UnitRetryDelayTypeFixed,
-		retryDelay:         50 * time.Millisecond,
-		retryMaximumJitter: 50 * time.Millisecond,
-		cacheClient:        &memoryCacheClient{},
+		logger:                adapters.NewNopLogger(),
+		actions:               make(map[UnitActionType][]unitAction),
+		retryAttempts:         3,
+		retryType:             UnitRetryDelayTypeFixed,
+		retryDelay:            50 * time.Millisecond,
+		retryMaximumJitter:    50 * time.Millisecond,
+		rollbackRetryAttempts: 1,
+		rollbackRetryDelay:    50 * time.Millisecond,
+		cacheClient:           &memoryCacheClient{},
+		metricNames:           defaultUnitMetricNames(),
+		metricScope:           "unit",
+		invalidationQueueSize: 1024,
 	}
 	// apply options.
 	for _, opt := range options {
@@ -118,55 +297,190 @@ func options(options []UnitOption) UnitOptions {
 	if !o.disableDefaultLoggingActions {
 		UnitDefaultLoggingActions()(&o)
 	}
+	if o.db != nil && o.driverName == "" {
+		o.driverName = driverNameOf(o.db.Driver())
+	}
 	// prepare metrics scope.
-	o.scope = o.scope.SubScope("unit")
+	tags := bestEffortUnitTag
 	if o.db != nil {
-		o.scope = o.scope.Tagged(sqlUnitTag)
-	} else {
-		o.scope = o.scope.Tagged(bestEffortUnitTag)
+		tags = sqlUnitTag
+	}
+	if o.scope != nil {
+		if o.metricScope != "" {
+			o.scope = o.scope.SubScope(o.metricScope)
+		}
+		o.scope = o.scope.Tagged(tags)
+	}
+	switch {
+	case o.metricsCollector != nil:
+		o.metricsScope = unitScope{collector: o.metricsCollector, prefix: o.metricScope, tags: tags}
+	case o.scope != nil:
+		// the tally scope is already qualified and tagged.
+		o.metricsScope = unitScope{collector: adapters.NewTallyCollector(o.scope)}
+	default:
+		o.metricsScope = unitScope{collector: adapters.NewNopCollector()}
+	}
+	if o.scope == nil {
+		o.scope = tally.NoopScope
 	}
 	return o
 }
 
 func NewUnit(opts ...UnitOption) (Unit, error) {
-	options := options(opts)
+	return newUnit(options(opts))
+}
+
+// newUnit creates a new work unit with the provided prepared options.
+func newUnit(options UnitOptions) (Unit, error) {
 	retryOptions := []retry.Option{
 		retry.Attempts(uint(options.retryAttempts)),
 		retry.Delay(options.retryDelay),
 		retry.DelayType(options.retryType.convert()),
 		retry.LastErrorOnly(true),
+	}
+	if options.db != nil {
+		// failures that cannot succeed when retried are not retried.
+		retryOptions = append(retryOptions, retry.RetryIf(func(err error) bool {
+			return retry.IsRecoverable(err) && ClassifyError(options.driverName, err).retryable()
+		}))
+	}
+	rollbackRetryOptions := []retry.Option{
+		retry.Attempts(uint(options.rollbackRetryAttempts)),
+		retry.Delay(options.rollbackRetryDelay),
+		retry.DelayType(options.retryType.convert()),
+		retry.LastErrorOnly(true),
 		retry.OnRetry(func(attempt uint, err error) {
-			options.logger.Warn("attempted retry", "attempt", int(attempt+1), "error", err.Error())
-			options.scope.Counter(retryAttempt).Inc(1)
+			// retries are only performed when attempts remain.
+			if last := options.rollbackRetryAttempts; last != 0 && int(attempt+1) >= last {
+				return
+			}
+			options.logger.Warn("attempted rollback retry", "attempt", int(attempt+1), "error", err.Error())
+			options.metricsScope.counter(options.metricNames.RollbackRetry, 1)
 		}),
 	}
+	cacheClient := options.cacheClient
+	if options.cacheKeys != nil {
+		cacheClient = encryptCache(cacheClient, options.cacheKeys)
+	}
 	u := unit{
-		additions:    make(map[TypeName][]interface{}),
-		alterations:  make(map[TypeName][]interface{}),
-		removals:     make(map[TypeName][]interface{}),
-		registered:   make(map[TypeName][]interface{}),
-		cached:       &UnitCache{cc: options.cacheClient, scope: options.scope},
-		logger:       options.logger,
-		scope:        options.scope,
-		actions:      options.actions,
-		db:           options.db,
-		insertFuncs:  options.iFuncs(),
-		updateFuncs:  options.uFuncs(),
-		deleteFuncs:  options.dFuncs(),
-		retryOptions: retryOptions,
-	}
-	if !options.hasDataMapperFuncs() {
+		additions:                   make(map[TypeName][]interface{}),
+		alterations:                 make(map[TypeName][]interface{}),
+		removals:                    make(map[TypeName][]interface{}),
+		registered:                  make(map[TypeName][]interface{}),
+		upserts:                     make(map[TypeName][]interface{}),
+		patches:                     make(map[TypeName][]UnitPatch),
+		removalCriteria:             make(map[TypeName][]interface{}),
+		cached:                      &UnitCache{cc: cacheClient, scope: options.metricsScope, metrics: options.metricNames},
+		logger:                      options.logger,
+		tracer:                      options.tracer,
+		scope:                       options.metricsScope,
+		tallyScope:                  options.scope,
+		metrics:                     options.metricNames,
+		actions:                     options.actions,
+		haltActions:                 options.haltActionsOnFailure,
+		changeRecordSink:            options.changeRecordSink,
+		maxOperationsPerTransaction: options.maxOperationsPerTransaction,
+		quarantineAfter:             options.quarantineAfter,
+		idGenerator:                 options.idGenerator,
+		quarantineSink:              options.quarantineSink,
+		rollbackActions:             options.rollbackActions,
+		saveActions:                 options.saveActions,
+		db:                          options.db,
+		insertFuncs:                 options.iFuncs(),
+		updateFuncs:                 options.uFuncs(),
+		deleteFuncs:                 options.dFuncs(),
+		upsertFuncs:                 options.upFuncs(),
+		patchFuncs:                  options.pFuncs(),
+		deleteWhereFuncs:            options.dwFuncs(),
+		refreshFuncs:                options.rFuncs(),
+		refreshAfterSave:            options.refreshAfterSave,
+		slowSaveThreshold:           options.slowSaveThreshold,
+		slowSaveHandler:             options.slowSaveHandler,
+		slowSaveStacks:              options.slowSaveStacks,
+		detectReentrancy:            options.detectReentrancy,
+		deferredStagingPasses:       options.deferredStagingPasses,
+		projections:                 options.projections,
+		rejectNilEntities:           options.rejectNilEntities,
+		strictTracking:              options.strictTracking,
+		uniqueChecks:                options.uniqueChecks,
+		retryAttempts:               options.retryAttempts,
+		retryFuncs:                  options.retryFuncs,
+		goroutineAudit:              options.goroutineAudit,
+		mergeAlterations:            options.mergeAlterations,
+		conflictResolver:            options.conflictResolver,
+		staleAfter:                  options.staleAfter,
+		evictStale:                  options.evictStale,
+		preflightPing:               options.preflightPing,
+		driverName:                  options.driverName,
+		allowedTypes:                options.allowedTypes,
+		deniedTypes:                 options.deniedTypes,
+		authorizer:                  options.authorizer,
+		cacheFailureMode:            options.cacheFailureMode,
+		invalidationRetryAttempts:   options.invalidationRetryAttempts,
+		invalidationRetryDelay:      options.invalidationRetryDelay,
+		invalidationQueueSize:       options.invalidationQueueSize,
+		bulkWriter:                  options.bulkWriter,
+		bulkTypes:                   options.bulkTypes,
+		trackActive:                 options.trackActive,
+		saveMiddleware:              options.saveMiddleware,
+		appendOnly:                  options.appendOnly,
+		readOnly:                    options.readOnly,
+		events:                      options.events,
+		aggregateRoots:              options.aggregateRoots,
+		locks:                       unitLockTokens{tokenFunc: options.lockTokenFunc},
+		history:                     unitRetryHistory{enabled: options.retryHistory, limit: options.retryHistoryLimit},
+		mutationLimits:              options.mutationLimits,
+		codecs:                      options.codecs,
+		defaultCodec:                options.defaultCodec,
+		confirmation:                options.mutationConfirmation(),
+		created:                     time.Now(),
+		tokens:                      unitCommitTokens{tokenizer: options.commitTokenizer, await: options.awaitToken},
+		retryOptions:                retryOptions,
+		rollbackRetryOptions:        rollbackRetryOptions,
+
+		sessionSettings:     options.sessionSettings,
+		sqlCommentTags:      options.sqlCommentTags,
+		deferConstraints:    options.deferConstraints,
+		deferredConstraints: options.deferredConstraints,
+	}
+	if u.goroutineAudit != 0 {
+		u.goroutine = goroutineID()
+	}
+	if options.detectLeaks {
+		u.creationStack = debug.Stack()
+	}
+	if !options.hasDataMapperFuncs() && !options.readOnly {
 		return nil, ErrNoDataMapper
 	}
 	if u.db != nil {
-		return &sqlUnit{unit: u}, nil
+		// changes are always applied within the transaction.
+		u.bulkWriter, u.bulkTypes = nil, nil
+		su := &sqlUnit{unit: &u}
+		su.track()
+		if options.detectLeaks {
+			runtime.SetFinalizer(su, func(su *sqlUnit) { su.collect() })
+		}
+		return su, nil
 	}
-	return &bestEffortUnit{
-		unit:              u,
+	bu := &bestEffortUnit{
+		unit:              &u,
 		successfulInserts: make(map[TypeName][]interface{}),
 		successfulUpdates: make(map[TypeName][]interface{}),
 		successfulDeletes: make(map[TypeName][]interface{}),
-	}, nil
+		successfulUpserts: make(map[TypeName][]interface{}),
+		successfulPatches: make(map[TypeName][]UnitPatch),
+	}
+	bu.track()
+	if options.detectLeaks {
+		runtime.SetFinalizer(bu, func(bu *bestEffortUnit) { bu.collect() })
+	}
+	return bu, nil
+}
+
+// IDOf provides the identity of the provided entity, indicating whether the
+// entity has an identity.
+func IDOf(entity interface{}) (interface{}, bool) {
+	return id(entity)
 }
 
 func id(entity interface{}) (interface{}, bool) {
@@ -181,10 +495,33 @@ func id(entity interface{}) (interface{}, bool) {
 }
 
 func (u *unit) Register(ctx context.Context, entities ...interface{}) (err error) {
-	u.executeActions(UnitActionTypeBeforeRegister)
+	u.checkReentrancy("Register")
+	u.checkGoroutine("Register")
+	if err = u.terminalErr(); err != nil {
+		return
+	}
+	if err = u.executeActions(ctx, UnitActionTypeBeforeRegister); err != nil {
+		return
+	}
+	if err = u.validate(entities); err != nil {
+		return
+	}
+	registered := make([]interface{}, 0, len(entities))
+	defer func() {
+		cacheErr := u.cached.storeAll(ctx, registered)
+		if cacheErr = u.cacheFailure(cacheOperationSet, cacheErr, UnitCacheFailureModeWarn); cacheErr != nil {
+			err = multierr.Append(err, cacheErr)
+		}
+		if lockErr := u.captureLockTokens(ctx, registered); lockErr != nil {
+			err = multierr.Append(err, lockErr)
+		}
+		if err == nil {
+			u.executeActions(ctx, UnitActionTypeAfterRegister)
+		}
+	}()
 	for _, entity := range entities {
 		t := TypeNameOf(entity)
-		if !u.hasDeleteFunc(t) && !u.hasInsertFunc(t) && !u.hasUpdateFunc(t) {
+		if !u.canRegister(t) {
 			u.logger.Error(ErrMissingDataMapper.Error(), "typeName", t.String())
 			return ErrMissingDataMapper
 		}
@@ -194,13 +531,50 @@ func (u *unit) Register(ctx context.Context, entities ...interface{}) (err error
 			u.registered[t] = []interface{}{}
 		}
 		u.registered[t] = append(u.registered[t], entity)
-		if cacheErr := u.cached.store(ctx, entity); cacheErr != nil {
-			u.logger.Warn(cacheErr.Error())
-		}
 		u.registerCount = u.registerCount + 1
 		u.mutex.Unlock()
+		registered = append(registered, entity)
+	}
+	return
+}
+
+func (u *unit) RegisterBatch(ctx context.Context, t TypeName, entities []interface{}) (err error) {
+	u.checkReentrancy("RegisterBatch")
+	u.checkGoroutine("RegisterBatch")
+	if err = u.terminalErr(); err != nil {
+		return
+	}
+	if err = u.executeActions(ctx, UnitActionTypeBeforeRegister); err != nil {
+		return
+	}
+	if len(entities) == 0 {
+		u.executeActions(ctx, UnitActionTypeAfterRegister)
+		return
 	}
-	u.executeActions(UnitActionTypeAfterRegister)
+	if err = u.validate(entities); err != nil {
+		return
+	}
+	if err = u.validateType(t, entities); err != nil {
+		return
+	}
+	if !u.canRegister(t) {
+		u.logger.Error(ErrMissingDataMapper.Error(), "typeName", t.String())
+		return ErrMissingDataMapper
+	}
+
+	u.mutex.Lock()
+	u.registered[t] = append(u.registered[t], entities...)
+	u.registerCount = u.registerCount + len(entities)
+	u.mutex.Unlock()
+
+	cacheErr := u.cached.storeAll(ctx, entities)
+	if err = u.cacheFailure(cacheOperationSet, cacheErr, UnitCacheFailureModeWarn); err != nil {
+		return
+	}
+	if err = u.captureLockTokens(ctx, entities); err != nil {
+		return
+	}
+	u.executeActions(ctx, UnitActionTypeAfterRegister)
 	return
 }
 
@@ -209,29 +583,91 @@ func (u *unit) Cached() *UnitCache {
 }
 
 func (u *unit) Add(ctx context.Context, entities ...interface{}) (err error) {
-	u.executeActions(UnitActionTypeBeforeAdd)
-	for _, entity := range entities {
+	u.checkReentrancy("Add")
+	u.checkGoroutine("Add")
+	if err = u.terminalErr(); err != nil {
+		return
+	}
+	if err = u.readOnlyErr("Add"); err != nil {
+		return
+	}
+	u.heartbeat()
+	if err = u.executeActions(ctx, UnitActionTypeBeforeAdd); err != nil {
+		return
+	}
+	if err = u.validate(entities); err != nil {
+		return
+	}
+	if err = u.generateIDs(entities); err != nil {
+		return
+	}
+	projected := u.project(entities)
+	derived := projected[len(entities):]
+	if err = u.validate(derived); err != nil {
+		return
+	}
+	if err = u.generateIDs(derived); err != nil {
+		return
+	}
+	for _, entity := range projected {
 		t := TypeNameOf(entity)
-		if !u.hasDeleteFunc(t) {
+		if !u.canAdd(t) {
 			u.logger.Error(ErrMissingDataMapper.Error(), "typeName", t.String())
 			return ErrMissingDataMapper
 		}
 
 		u.mutex.Lock()
-		if _, ok := u.additions[t]; !ok {
-			u.additions[t] = []interface{}{}
+		entity, ok, resolveErr := u.resolveStaged(EntityStateAdded, entity)
+		if ok {
+			u.additions[t] = append(u.additions[t], entity)
+			u.additionCount = u.additionCount + 1
 		}
-		u.additions[t] = append(u.additions[t], entity)
-		u.additionCount = u.additionCount + 1
 		u.mutex.Unlock()
+		if resolveErr != nil {
+			return resolveErr
+		}
 	}
-	u.executeActions(UnitActionTypeAfterAdd)
+	u.executeActions(ctx, UnitActionTypeAfterAdd)
 	return
 }
 
+func (u *unit) AddBackground(entities ...interface{}) error {
+	return u.Add(context.Background(), entities...)
+}
+
 func (u *unit) Alter(ctx context.Context, entities ...interface{}) (err error) {
-	u.executeActions(UnitActionTypeBeforeAlter)
-	for _, entity := range entities {
+	u.checkReentrancy("Alter")
+	u.checkGoroutine("Alter")
+	if err = u.terminalErr(); err != nil {
+		return
+	}
+	if err = u.readOnlyErr("Alter"); err != nil {
+		return
+	}
+	if err = u.appendOnlyErr("Alter"); err != nil {
+		return
+	}
+	u.heartbeat()
+	if err = u.executeActions(ctx, UnitActionTypeBeforeAlter); err != nil {
+		return
+	}
+	var projected []interface{}
+	if projected, err = u.projected(entities); err != nil {
+		return
+	}
+	if err = u.checkTracked(entities); err != nil {
+		return
+	}
+	staged := make([]interface{}, 0, len(entities))
+	defer func() {
+		if cacheErr := u.invalidate(ctx, entityCacheKeys(staged)); cacheErr != nil {
+			err = multierr.Append(err, cacheErr)
+		}
+		if err == nil {
+			u.executeActions(ctx, UnitActionTypeAfterAlter)
+		}
+	}()
+	for _, entity := range projected {
 		t := TypeNameOf(entity)
 		if !u.hasUpdateFunc(t) {
 			u.logger.Error(ErrMissingDataMapper.Error(), "typeName", t.String())
@@ -239,24 +675,54 @@ func (u *unit) Alter(ctx context.Context, entities ...interface{}) (err error) {
 		}
 
 		u.mutex.Lock()
-		if _, ok := u.alterations[t]; !ok {
-			u.alterations[t] = []interface{}{}
-		}
-		u.alterations[t] = append(u.alterations[t], entity)
-		u.alterationCount = u.alterationCount + 1
-		if err = u.cached.delete(ctx, entity); err != nil {
-			u.mutex.Unlock()
-			return
+		entity, ok, resolveErr := u.resolveStaged(EntityStateAltered, entity)
+		if ok {
+			u.stageAlteration(t, entity)
 		}
 		u.mutex.Unlock()
+		if resolveErr != nil {
+			return resolveErr
+		}
+		if ok {
+			staged = append(staged, entity)
+		}
 	}
-	u.executeActions(UnitActionTypeAfterAlter)
 	return
 }
 
 func (u *unit) Remove(ctx context.Context, entities ...interface{}) (err error) {
-	u.executeActions(UnitActionTypeBeforeRemove)
-	for _, entity := range entities {
+	u.checkReentrancy("Remove")
+	u.checkGoroutine("Remove")
+	if err = u.terminalErr(); err != nil {
+		return
+	}
+	if err = u.readOnlyErr("Remove"); err != nil {
+		return
+	}
+	if err = u.appendOnlyErr("Remove"); err != nil {
+		return
+	}
+	u.heartbeat()
+	if err = u.executeActions(ctx, UnitActionTypeBeforeRemove); err != nil {
+		return
+	}
+	var projected []interface{}
+	if projected, err = u.projected(entities); err != nil {
+		return
+	}
+	if err = u.checkTracked(entities); err != nil {
+		return
+	}
+	staged := make([]interface{}, 0, len(entities))
+	defer func() {
+		if cacheErr := u.invalidate(ctx, entityCacheKeys(staged)); cacheErr != nil {
+			err = multierr.Append(err, cacheErr)
+		}
+		if err == nil {
+			u.executeActions(ctx, UnitActionTypeAfterRemove)
+		}
+	}()
+	for _, entity := range projected {
 		t := TypeNameOf(entity)
 		if !u.hasDeleteFunc(t) {
 			u.logger.Error(ErrMissingDataMapper.Error(), "typeName", t.String())
@@ -264,24 +730,156 @@ func (u *unit) Remove(ctx context.Context, entities ...interface{}) (err error)
 		}
 
 		u.mutex.Lock()
-		if _, ok := u.removals[t]; !ok {
-			u.removals[t] = []interface{}{}
+		entity, ok, resolveErr := u.resolveStaged(EntityStateRemoved, entity)
+		if ok {
+			u.removals[t] = append(u.removals[t], entity)
+			u.removalCount = u.removalCount + 1
 		}
-		u.removals[t] = append(u.removals[t], entity)
-		u.removalCount = u.removalCount + 1
-		if err = u.cached.delete(ctx, entity); err != nil {
-			u.mutex.Unlock()
-			return
+		u.mutex.Unlock()
+		if resolveErr != nil {
+			return resolveErr
 		}
+		if ok {
+			staged = append(staged, entity)
+		}
+	}
+	return
+}
+
+func (u *unit) Upsert(ctx context.Context, entities ...interface{}) (err error) {
+	u.checkReentrancy("Upsert")
+	u.checkGoroutine("Upsert")
+	if err = u.terminalErr(); err != nil {
+		return
+	}
+	if err = u.readOnlyErr("Upsert"); err != nil {
+		return
+	}
+	if err = u.appendOnlyErr("Upsert"); err != nil {
+		return
+	}
+	u.heartbeat()
+	if err = u.executeActions(ctx, UnitActionTypeBeforeUpsert); err != nil {
+		return
+	}
+	var projected []interface{}
+	if projected, err = u.projected(entities); err != nil {
+		return
+	}
+	staged := make([]interface{}, 0, len(entities))
+	defer func() {
+		if cacheErr := u.invalidate(ctx, entityCacheKeys(staged)); cacheErr != nil {
+			err = multierr.Append(err, cacheErr)
+		}
+		if err == nil {
+			u.executeActions(ctx, UnitActionTypeAfterUpsert)
+		}
+	}()
+	for _, entity := range projected {
+		t := TypeNameOf(entity)
+		if !u.hasUpsertFunc(t) {
+			u.logger.Error(ErrMissingDataMapper.Error(), "typeName", t.String())
+			return ErrMissingDataMapper
+		}
+
+		u.mutex.Lock()
+		entity, ok, resolveErr := u.resolveStaged(EntityStateUpserted, entity)
+		if ok {
+			u.upserts[t] = append(u.upserts[t], entity)
+			u.upsertCount = u.upsertCount + 1
+		}
+		u.mutex.Unlock()
+		if resolveErr != nil {
+			return resolveErr
+		}
+		if ok {
+			staged = append(staged, entity)
+		}
+	}
+	return
+}
+
+func (u *unit) Patch(ctx context.Context, t TypeName, id interface{}, fields map[string]interface{}) (err error) {
+	u.checkReentrancy("Patch")
+	u.checkGoroutine("Patch")
+	if err = u.terminalErr(); err != nil {
+		return
+	}
+	if err = u.readOnlyErr("Patch"); err != nil {
+		return
+	}
+	if err = u.appendOnlyErr("Patch"); err != nil {
+		return
+	}
+	u.heartbeat()
+	if err = u.executeActions(ctx, UnitActionTypeBeforePatch); err != nil {
+		return
+	}
+	if err = u.allowed(t); err != nil {
+		return
+	}
+	if !u.hasPatchFunc(t) {
+		u.logger.Error(ErrMissingDataMapper.Error(), "typeName", t.String())
+		return ErrMissingDataMapper
+	}
+
+	u.mutex.Lock()
+	u.patches[t] = append(u.patches[t], UnitPatch{TypeName: t, ID: id, Fields: fields})
+	u.patchCount = u.patchCount + 1
+	if err = u.invalidate(ctx, []string{cacheKey(t, id)}); err != nil {
 		u.mutex.Unlock()
+		return
+	}
+	u.mutex.Unlock()
+	u.executeActions(ctx, UnitActionTypeAfterPatch)
+	return
+}
+
+func (u *unit) RemoveWhere(ctx context.Context, t TypeName, criteria interface{}) (err error) {
+	u.checkReentrancy("RemoveWhere")
+	u.checkGoroutine("RemoveWhere")
+	if err = u.terminalErr(); err != nil {
+		return
+	}
+	if err = u.readOnlyErr("RemoveWhere"); err != nil {
+		return
+	}
+	if err = u.appendOnlyErr("RemoveWhere"); err != nil {
+		return
+	}
+	u.heartbeat()
+	if err = u.executeActions(ctx, UnitActionTypeBeforeRemoveWhere); err != nil {
+		return
+	}
+	if err = u.allowed(t); err != nil {
+		return
+	}
+	if !u.hasDeleteWhereFunc(t) {
+		u.logger.Error(ErrMissingDataMapper.Error(), "typeName", t.String())
+		return ErrMissingDataMapper
 	}
-	u.executeActions(UnitActionTypeAfterRemove)
+
+	u.mutex.Lock()
+	u.removalCriteria[t] = append(u.removalCriteria[t], criteria)
+	u.criteriaCount = u.criteriaCount + 1
+	// the entities matching the criteria are unknown, so all registered
+	// entities of the same type are conservatively removed from the cache.
+	registered := append([]interface{}(nil), u.registered[t]...)
+	u.mutex.Unlock()
+	if err = u.invalidate(ctx, entityCacheKeys(registered)); err != nil {
+		return
+	}
+	u.executeActions(ctx, UnitActionTypeAfterRemoveWhere)
 	return
 }
 
 func (u *unit) insertFunc(t TypeName) (f UnitDataMapperFunc, ok bool) {
+	if u.insertFuncs == nil {
+		return
+	}
 	if val, exists := u.insertFuncs.Load(t); exists {
 		if f, ok = val.(UnitDataMapperFunc); ok {
+			f = u.traced(UnitOperationInsert, t, f)
 			return
 		}
 	}
@@ -290,12 +888,16 @@ func (u *unit) insertFunc(t TypeName) (f UnitDataMapperFunc, ok bool) {
 
 func (u *unit) hasInsertFunc(t TypeName) (ok bool) {
 	_, ok = u.insertFunc(t)
-	return
+	return ok || u.writesInBulk(t)
 }
 
 func (u *unit) updateFunc(t TypeName) (f UnitDataMapperFunc, ok bool) {
+	if u.updateFuncs == nil {
+		return
+	}
 	if val, exists := u.updateFuncs.Load(t); exists {
 		if f, ok = val.(UnitDataMapperFunc); ok {
+			f = u.traced(UnitOperationUpdate, t, f)
 			return
 		}
 	}
@@ -304,12 +906,16 @@ func (u *unit) updateFunc(t TypeName) (f UnitDataMapperFunc, ok bool) {
 
 func (u *unit) hasUpdateFunc(t TypeName) (ok bool) {
 	_, ok = u.updateFunc(t)
-	return
+	return ok || u.writesInBulk(t)
 }
 
 func (u *unit) deleteFunc(t TypeName) (f UnitDataMapperFunc, ok bool) {
+	if u.deleteFuncs == nil {
+		return
+	}
 	if val, exists := u.deleteFuncs.Load(t); exists {
 		if f, ok = val.(UnitDataMapperFunc); ok {
+			f = u.traced(UnitOperationDelete, t, f)
 			return
 		}
 	}
@@ -318,18 +924,180 @@ func (u *unit) deleteFunc(t TypeName) (f UnitDataMapperFunc, ok bool) {
 
 func (u *unit) hasDeleteFunc(t TypeName) (ok bool) {
 	_, ok = u.deleteFunc(t)
+	return ok || u.writesInBulk(t)
+}
+
+func (u *unit) upsertFunc(t TypeName) (f UnitDataMapperFunc, ok bool) {
+	if u.upsertFuncs == nil {
+		return
+	}
+	if val, exists := u.upsertFuncs.Load(t); exists {
+		if f, ok = val.(UnitDataMapperFunc); ok {
+			f = u.traced(UnitOperationUpsert, t, f)
+			return
+		}
+	}
 	return
 }
 
-func (u *unit) executeActions(actionType UnitActionType) {
-	for _, action := range u.actions[actionType] {
-		action(UnitActionContext{
-			Logger:          u.logger,
-			Scope:           u.scope,
-			AdditionCount:   u.additionCount,
-			AlterationCount: u.alterationCount,
-			RemovalCount:    u.removalCount,
-			RegisterCount:   u.registerCount,
+func (u *unit) hasUpsertFunc(t TypeName) (ok bool) {
+	_, ok = u.upsertFunc(t)
+	return ok || u.writesInBulk(t)
+}
+
+func (u *unit) patchFunc(t TypeName) (f UnitPatchDataMapperFunc, ok bool) {
+	if u.patchFuncs == nil {
+		return
+	}
+	if val, exists := u.patchFuncs.Load(t); exists {
+		if f, ok = val.(UnitPatchDataMapperFunc); ok {
+			f = u.tracedPatch(t, f)
+			return
+		}
+	}
+	return
+}
+
+func (u *unit) hasPatchFunc(t TypeName) (ok bool) {
+	_, ok = u.patchFunc(t)
+	return ok || u.writesInBulk(t)
+}
+
+func (u *unit) deleteWhereFunc(t TypeName) (f UnitDataMapperFunc, ok bool) {
+	if u.deleteWhereFuncs == nil {
+		return
+	}
+	if val, exists := u.deleteWhereFuncs.Load(t); exists {
+		if f, ok = val.(UnitDataMapperFunc); ok {
+			f = u.traced(UnitOperationDeleteWhere, t, f)
+			return
+		}
+	}
+	return
+}
+
+func (u *unit) hasDeleteWhereFunc(t TypeName) (ok bool) {
+	_, ok = u.deleteWhereFunc(t)
+	return
+}
+
+func (u *unit) refreshFunc(t TypeName) (f UnitDataMapperFunc, ok bool) {
+	if u.refreshFuncs == nil {
+		return
+	}
+	if val, exists := u.refreshFuncs.Load(t); exists {
+		if f, ok = val.(UnitDataMapperFunc); ok {
+			return
+		}
+	}
+	return
+}
+
+func (u *unit) actionContext(ctx context.Context) UnitActionContext {
+	// entities may be staged concurrently, such as within a group.
+	u.mutex.RLock()
+	defer u.mutex.RUnlock()
+	return UnitActionContext{
+		Context:              ctx,
+		Logger:               u.logger,
+		Scope:                u.tallyScope,
+		Metrics:              u.scope,
+		AdditionCount:        u.additionCount,
+		AlterationCount:      u.alterationCount,
+		RemovalCount:         u.removalCount,
+		RegisterCount:        u.registerCount,
+		UpsertCount:          u.upsertCount,
+		PatchCount:           u.patchCount,
+		RemovalCriteriaCount: u.criteriaCount,
+		modified:             u.modified,
+		changes:              u.changes,
+		codec:                u.codec,
+	}
+}
+
+func (u *unit) modified(t TypeName) bool {
+	u.mutex.RLock()
+	defer u.mutex.RUnlock()
+	return len(u.additions[t]) > 0 ||
+		len(u.alterations[t]) > 0 ||
+		len(u.removals[t]) > 0 ||
+		len(u.upserts[t]) > 0 ||
+		len(u.patches[t]) > 0 ||
+		len(u.removalCriteria[t]) > 0
+}
+
+func (u *unit) ClearActions(actionType UnitActionType) {
+	u.actionsMutex.Lock()
+	defer u.actionsMutex.Unlock()
+	u.actions[actionType] = nil
+}
+
+func (u *unit) RemoveAction(name string) (removed bool) {
+	u.actionsMutex.Lock()
+	defer u.actionsMutex.Unlock()
+	for t, actions := range u.actions {
+		remaining := make([]unitAction, 0, len(actions))
+		for _, a := range actions {
+			if a.name == name {
+				removed = true
+				continue
+			}
+			remaining = append(remaining, a)
+		}
+		u.actions[t] = remaining
+	}
+	return
+}
+
+func (u *unit) executeActions(ctx context.Context, actionType UnitActionType) (err error) {
+	u.actionsMutex.RLock()
+	actions := u.actions[actionType]
+	u.actionsMutex.RUnlock()
+	for _, a := range actions {
+		if err = u.executeAction(ctx, actionType, a); err != nil {
+			return
+		}
+	}
+	return
+}
+
+func (u *unit) executeAction(ctx context.Context, actionType UnitActionType, a unitAction) error {
+	aCtx := u.actionContext(ctx)
+	err := u.runAction(actionType.String(), a.name, func() bool {
+		if a.when != nil && !a.when(aCtx) {
+			return false
+		}
+		a.action(aCtx)
+		return true
+	})
+	if !u.haltActions {
+		return nil
+	}
+	return err
+}
+
+func (u *unit) executeRollbackActions(ctx context.Context, cause error) {
+	u.executeActions(ctx, UnitActionTypeAfterRollback)
+	for _, action := range u.rollbackActions {
+		u.runAction(unitActionTypeRollback, "", func() bool {
+			action(UnitRollbackActionContext{
+				UnitActionContext: u.actionContext(ctx),
+				Err:               cause,
+			})
+			return true
+		})
+	}
+}
+
+func (u *unit) executeSaveActions(ctx context.Context, duration time.Duration, err error) {
+	for _, action := range u.saveActions {
+		u.runAction(unitActionTypeSave, "", func() bool {
+			action(UnitSaveActionContext{
+				UnitActionContext: u.actionContext(ctx),
+				Duration:          duration,
+				Err:               err,
+			})
+			return true
 		})
 	}
 }
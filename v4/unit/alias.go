@@ -16,6 +16,8 @@
 package unit
 
 import (
+	"database/sql"
+
 	"github.com/freerware/work/v4"
 )
 
@@ -30,6 +32,40 @@ var (
 	// ErrNoDataMapper represents the error that occurs when attempting
 	// to create a work unit without any data mappers.
 	ErrNoDataMapper = work.ErrNoDataMapper
+
+	// ErrCommitAmbiguous represents the error that is returned when the
+	// outcome of committing the transaction for a work unit is unknown.
+	ErrCommitAmbiguous = work.ErrCommitAmbiguous
+
+	// ErrPreflightPing represents the error that is returned when a healthy
+	// connection cannot be verified before saving a work unit.
+	ErrPreflightPing = work.ErrPreflightPing
+
+	// ErrGroupFailed represents the error that is returned when attempting
+	// to save a work unit after one of the goroutines within a group
+	// associated with the work unit has failed.
+	ErrGroupFailed = work.ErrUnitGroupFailed
+
+	// ErrActionFailed represents the error that is returned when an action
+	// fails while the work unit is configured to halt actions upon failure.
+	ErrActionFailed = work.ErrUnitActionFailed
+
+	// ErrAppendOnly represents the error that is returned when attempting
+	// to stage anything other than additions or registrations within an
+	// append-only work unit.
+	ErrAppendOnly = work.ErrUnitAppendOnly
+
+	// ErrReadOnly represents the error that is returned when attempting to
+	// stage changes within a read-only work unit.
+	ErrReadOnly = work.ErrUnitReadOnly
+
+	// ErrReadOnlyDB represents the error that is returned when creating a
+	// read-only work unit without a database.
+	ErrReadOnlyDB = work.ErrReadOnlyUnitDB
+
+	// ErrReadOnlyDiscarded represents the error that is returned when reading
+	// from, or saving, a read-only work unit that was discarded.
+	ErrReadOnlyDiscarded = work.ErrReadOnlyUnitDiscarded
 )
 
 /* Units + Uniters. */
@@ -37,21 +73,58 @@ var (
 // Unit represents an atomic set of entity changes.
 type Unit = work.Unit
 
+// ReadOnly represents a work unit whose reads are performed within a single
+// read-only transaction.
+type ReadOnly = work.ReadOnlyUnit
+
+// ReadFunc represents a function performing reads within the transaction of
+// a read-only work unit.
+type ReadFunc = work.UnitReadFunc
+
 // Uniter represents a factory for work units.
 type Uniter = work.Uniter
 
+// VerifiableUniter represents a factory for work units capable of verifying
+// the configuration of the work units it constructs.
+type VerifiableUniter = work.VerifiableUniter
+
+// Group represents a collection of goroutines that stage entities into
+// a work unit concurrently.
+type Group = work.UnitGroup
+
 // TypeName represents an entity's type.
 type TypeName = work.TypeName
 
+// EntityState represents the state of an entity within a work unit.
+type EntityState = work.EntityState
+
 var (
 	// TypeNameOf provides the type name for the provided entity.
 	TypeNameOf = work.TypeNameOf
+	// IDOf provides the identity of the provided entity, indicating whether
+	// the entity has an identity.
+	IDOf = work.IDOf
 	// New creates a new work unit.
 	New = work.NewUnit
+	// NewReadOnly creates a new read-only work unit.
+	NewReadOnly = work.NewReadOnlyUnit
 	// NewUniter creates a new uniter with the provided unit options.
 	NewUniter = work.NewUniter
 )
 
+var (
+	// EntityStateRegistered indicates an entity that has been registered as clean.
+	EntityStateRegistered = work.EntityStateRegistered
+	// EntityStateAdded indicates an entity that has been marked as a new addition.
+	EntityStateAdded = work.EntityStateAdded
+	// EntityStateAltered indicates an entity that has been marked as a modification.
+	EntityStateAltered = work.EntityStateAltered
+	// EntityStateRemoved indicates an entity that has been marked as a removal.
+	EntityStateRemoved = work.EntityStateRemoved
+	// EntityStateUpserted indicates an entity that has been marked as an upsert.
+	EntityStateUpserted = work.EntityStateUpserted
+)
+
 /* Options. */
 
 // Option applies an option to the provided configuration.
@@ -81,8 +154,15 @@ var (
 	// WithStructuredLogger specifies the option to provide a structured logger as defined
 	// in the 'log/slog' standard library package for the work unit.
 	WithStructuredLogger = work.UnitWithStructuredLogger
+	// WithTracer specifies the option to provide a tracer for the work unit.
+	WithTracer = work.UnitWithTracer
 	// TallyMetricScope specifies the option to provide a tally metric scope for the work unit.
+	//
+	// Deprecated: Use worktally.WithScope instead.
 	TallyMetricScope = work.UnitTallyMetricScope
+	// WithMetricsCollector specifies the option to provide a metrics
+	// collector for the work unit, taking precedence over TallyMetricScope.
+	WithMetricsCollector = work.UnitWithMetricsCollector
 	// AfterRegisterActions specifies the option to provide actions to execute
 	// after entities are registered with the work unit.
 	AfterRegisterActions = work.UnitAfterRegisterActions
@@ -149,6 +229,230 @@ var (
 	DeleteFunc = work.UnitDeleteFunc
 	// WithCacheClient defines the cache client to be used.
 	WithCacheClient = work.UnitWithCacheClient
+	// UpsertFunc defines the function to be used for upserting entities
+	// in the underlying data store.
+	UpsertFunc = work.UnitUpsertFunc
+	// PatchFunc defines the function to be used for applying partial
+	// updates to existing entities in the underlying data store.
+	PatchFunc = work.UnitPatchFunc
+	// AfterPatchActions specifies the option to provide actions to execute
+	// after entities are patched with the work unit.
+	AfterPatchActions = work.UnitAfterPatchActions
+	// DeleteWhereFunc defines the function to be used for deleting the
+	// entities matching the provided criteria in the underlying data store.
+	DeleteWhereFunc = work.UnitDeleteWhereFunc
+	// RefreshFunc defines the function to be used for reloading the entities
+	// of the provided type from the data store once the work unit is saved.
+	RefreshFunc = work.UnitRefreshFunc
+	// RefreshAfterSave specifies the option to reload the saved entities from
+	// the data store once the work unit is saved.
+	RefreshAfterSave = work.UnitRefreshAfterSave
+	// AfterRemoveWhereActions specifies the option to provide actions to
+	// execute after entities matching criteria are removed with the work unit.
+	AfterRemoveWhereActions = work.UnitAfterRemoveWhereActions
+	// AfterUpsertActions specifies the option to provide actions to execute
+	// after entities are upserted with the work unit.
+	AfterUpsertActions = work.UnitAfterUpsertActions
+	// AfterUpsertsActions specifies the option to provide actions to execute
+	// after upserted entities are upserted in the data store.
+	AfterUpsertsActions = work.UnitAfterUpsertsActions
+	// BeforeUpsertsActions specifies the option to provide actions to execute
+	// before upserted entities are upserted in the data store.
+	BeforeUpsertsActions = work.UnitBeforeUpsertsActions
+	// DeferConstraints specifies the option to defer the checking of
+	// constraints until the transaction is committed.
+	DeferConstraints = work.UnitDeferConstraints
+	// RollbackRetryAttempts defines the number of attempts to perform when
+	// rolling back a best effort work unit, independent of save attempts.
+	RollbackRetryAttempts = work.UnitRollbackRetryAttempts
+	// RollbackRetryDelay defines the delay to utilize during rollback
+	// retries.
+	RollbackRetryDelay = work.UnitRollbackRetryDelay
+	// WithSessionSettings specifies the option to apply the provided settings
+	// at the start of every transaction, equivalent to SET LOCAL.
+	WithSessionSettings = work.UnitWithSessionSettings
+	// SQLComments specifies the option to annotate the statements executed
+	// through the mapper context with a comment identifying the work unit.
+	SQLComments = work.UnitSQLComments
+	// SlowSaveThreshold specifies the option to invoke the provided handler
+	// with a diagnostic snapshot of each save exceeding the provided duration.
+	SlowSaveThreshold = work.UnitSlowSaveThreshold
+	// SlowSaveStacks specifies the option to include the stack traces of all
+	// goroutines in the diagnostic snapshot of slow saves.
+	SlowSaveStacks = work.UnitSlowSaveStacks
+	// DetectReentrancy specifies the option to panic with a diagnostic
+	// message when entities are staged into the work unit while it is saved.
+	DetectReentrancy = work.UnitDetectReentrancy
+	// DeferredStaging specifies the option to apply entities staged while the
+	// work unit is saved in subsequent passes within the same transaction.
+	DeferredStaging = work.UnitDeferredStaging
+	// WithProjection specifies the option to stage the read model rows
+	// derived from entities of the provided type whenever they are staged.
+	WithProjection = work.UnitWithProjection
+	// RejectNilEntities specifies the option to reject zero-value entities
+	// when they are staged.
+	RejectNilEntities = work.UnitRejectNilEntities
+	// StrictTracking specifies the option to require entities to be
+	// registered or added before they are altered or removed.
+	StrictTracking = work.UnitStrictTracking
+	// UniqueCheck specifies the option to verify that the entities of the
+	// provided type do not violate a uniqueness constraint before any writes
+	// occur.
+	UniqueCheck = work.UnitUniqueCheck
+	// OnRetry specifies the option to invoke the provided function before
+	// each save of the work unit is retried.
+	OnRetry = work.UnitOnRetry
+	// RetryHistory specifies the option to collect the error of every failed
+	// attempt to save the work unit, optionally capped to the most recent
+	// attempts.
+	RetryHistory = work.UnitRetryHistory
+	// DescribeOptions provides a summary of the effective configuration of
+	// work units created with the provided options, with secrets redacted.
+	DescribeOptions = work.DescribeUnitOptions
+	// MetricScope specifies the option to name the scope that qualifies the
+	// names of the metrics provided to metrics collectors.
+	MetricScope = work.UnitMetricScope
+	// WithMetricNames specifies the option to override the names of the
+	// metrics emitted by the work unit.
+	WithMetricNames = work.UnitWithMetricNames
+	// GoroutineAudit specifies the option to audit the goroutines that use
+	// the work unit, reacting with the provided mode when the work unit is
+	// used by a goroutine other than the one that created it.
+	GoroutineAudit = work.UnitGoroutineAudit
+	// MergeAlterations specifies the option to merge repeated alterations of
+	// entities sharing the same identity into the latest of them.
+	MergeAlterations = work.UnitMergeAlterations
+	// ConflictResolver specifies the option to resolve conflicts with the
+	// provided resolver rather than failing.
+	ConflictResolver = work.UnitConflictResolver
+	// StaleAfter specifies the option to report, or evict, work units that
+	// have been open and dirty without being saved for too long.
+	StaleAfter = work.UnitStaleAfter
+	// TrackActive specifies the option to track the work unit within the
+	// active unit registry until it is saved successfully or discarded.
+	TrackActive = work.UnitTrackActive
+	// DetectLeaks specifies the option to log an error when the work unit is
+	// garbage collected while it has staged changes that were not saved.
+	DetectLeaks = work.UnitDetectLeaks
+	// SaveMiddleware specifies the option to wrap the save pipeline of the
+	// work unit with the provided middleware.
+	SaveMiddleware = work.UnitSaveMiddleware
+	// AppendOnly specifies the option to only permit entities to be added to,
+	// or registered with, the work unit.
+	AppendOnly = work.UnitAppendOnly
+	// ReadIsolation specifies the option to provide the isolation level of
+	// the transaction read-only work units perform their reads within.
+	ReadIsolation = work.UnitReadIsolation
+	// EventStore specifies the option to persist the aggregates of the
+	// provided types as the events raised against them.
+	EventStore = work.UnitEventStore
+	// SnapshotFunc defines the function to be used for writing snapshots of
+	// the event-sourced aggregates of the provided type.
+	SnapshotFunc = work.UnitSnapshotFunc
+	// SnapshotEvery specifies the option to only write snapshots of the
+	// event-sourced aggregates of the provided type every time the provided
+	// number of events are appended to their streams.
+	SnapshotEvery = work.UnitSnapshotEvery
+	// WithAggregateBoundaries specifies the option to enforce the consistency
+	// boundaries of aggregates, provided as the types of the entities
+	// belonging to each aggregate keyed by the type of its root.
+	WithAggregateBoundaries = work.UnitWithAggregateBoundaries
+	// LockTokens specifies the option to detect concurrent modifications of
+	// entities across work units sharing the same cache using lock tokens.
+	LockTokens = work.UnitLockTokens
+	// MutationGuard specifies the option to reject saving the work unit when
+	// more changes are staged for a type than the provided limit for that
+	// type.
+	MutationGuard = work.UnitMutationGuard
+	// ConfirmMutations specifies the option to require confirmation, rather
+	// than rejecting the save, when more changes are staged for a type than
+	// its mutation guard permits.
+	ConfirmMutations = work.UnitConfirmMutations
+	// PreflightPing specifies the option to verify that a healthy connection
+	// to the SQL store is available before saving.
+	PreflightPing = work.UnitPreflightPing
+	// DriverName specifies the option to classify failures with the error
+	// classifier registered for the driver with the provided name.
+	DriverName = work.UnitDriverName
+	// AllowTypes specifies the option to only allow entities of the provided
+	// types to be staged within the work unit.
+	AllowTypes = work.UnitAllowTypes
+	// DenyTypes specifies the option to reject entities of the provided
+	// types from being staged within the work unit.
+	DenyTypes = work.UnitDenyTypes
+	// WithAuthorizer specifies the option to authorize each operation staged
+	// within the work unit with the provided authorizer when saving.
+	WithAuthorizer = work.UnitWithAuthorizer
+	// CacheEncryption specifies the option to encrypt the entities written
+	// to the cache with the keys provided by the key source.
+	CacheEncryption = work.UnitCacheEncryption
+	// CacheFailurePolicy specifies the option to handle failures of the
+	// cache client with the provided mode.
+	CacheFailurePolicy = work.UnitCacheFailurePolicy
+	// CacheInvalidationRetry specifies the option to retry failed cache
+	// invalidations in the background.
+	CacheInvalidationRetry = work.UnitCacheInvalidationRetry
+	// CacheInvalidationQueueSize specifies the option to bound the number of
+	// failed cache invalidations awaiting retry in the background.
+	CacheInvalidationQueueSize = work.UnitCacheInvalidationQueueSize
+	// BulkWriter specifies the option to apply the changes to entities of
+	// the provided types with a single request to the bulk write function.
+	BulkWriter = work.UnitBulkWriter
+	// CommitTokens specifies the option to capture and await commit tokens
+	// with the provided tokenizer.
+	CommitTokens = work.UnitCommitTokens
+	// WaitForToken specifies the option to await the position identified by
+	// the provided commit token before each transaction is started.
+	WaitForToken = work.UnitWaitForToken
+	// WithIDGenerator specifies the option to assign identifiers generated by
+	// the provided generator to added entities lacking one.
+	WithIDGenerator = work.UnitWithIDGenerator
+	// QuarantineAfter specifies the option to apply entities individually,
+	// quarantining those that fail the provided number of times rather than
+	// failing the save.
+	QuarantineAfter = work.UnitQuarantineAfter
+	// PersistQuarantined specifies the option to provide the entities
+	// quarantined during a successful save to the provided sink.
+	PersistQuarantined = work.UnitPersistQuarantined
+	// MaxOperationsPerTransaction specifies the option to split the
+	// operations of a work unit across multiple sequential transactions,
+	// each containing at most the provided number of operations.
+	MaxOperationsPerTransaction = work.UnitMaxOperationsPerTransaction
+	// ChangeRecords specifies the option to emit change records for each
+	// mutation committed by the work unit to the provided sink.
+	ChangeRecords = work.UnitChangeRecords
+	// Codecs specifies the option to serialize entities of each type with
+	// the provided codec for that type when requested by actions.
+	Codecs = work.UnitCodecs
+	// DefaultCodec specifies the option to serialize entities of types
+	// without a codec with the provided codec.
+	DefaultCodec = work.UnitDefaultCodec
+	// RollbackActions specifies the option to provide actions to execute
+	// after the work unit is rolled back, which are provided the error that
+	// triggered the rollback.
+	RollbackActions = work.UnitRollbackActions
+	// SaveActions specifies the option to provide actions to execute after
+	// the work unit is saved, which are provided the duration of the save as
+	// well as the error encountered, if any.
+	SaveActions = work.UnitSaveActions
+	// ActionsWithPriority specifies the option to provide actions to execute
+	// for the provided action type with the provided priority. Actions with a
+	// higher priority are executed first.
+	ActionsWithPriority = work.UnitActionsWithPriority
+	// BeforeSaveActionsWithPriority specifies the option to provide actions to
+	// execute before the work unit is saved with the provided priority.
+	BeforeSaveActionsWithPriority = work.UnitBeforeSaveActionsWithPriority
+	// HaltActionsOnFailure specifies the option to stop executing the
+	// subsequent actions of the same type when an action fails.
+	HaltActionsOnFailure = work.UnitHaltActionsOnFailure
+	// NamedAction specifies the option to provide an action to execute for
+	// the provided action type that is identified by the provided name,
+	// allowing it to be removed from the work unit by name.
+	NamedAction = work.UnitNamedAction
+	// ConditionalAction specifies the option to provide an action to execute
+	// for the provided action type only when the provided predicate is
+	// satisfied.
+	ConditionalAction = work.UnitConditionalAction
 )
 
 /* Actions. */
@@ -163,6 +467,25 @@ type Action = work.UnitAction
 // ActionType represents the type of work unit action.
 type ActionType = work.UnitActionType
 
+// Changes represents a snapshot of the entity changes staged within a work
+// unit, organized by type name.
+type Changes = work.UnitChanges
+
+// RollbackActionContext represents the executional context for an action
+// performed after a work unit is rolled back.
+type RollbackActionContext = work.UnitRollbackActionContext
+
+// RollbackAction represents an operation performed after a work unit is
+// rolled back.
+type RollbackAction = work.UnitRollbackAction
+
+// SaveActionContext represents the executional context for an action
+// performed after a work unit is saved, whether successful or not.
+type SaveActionContext = work.UnitSaveActionContext
+
+// SaveAction represents an operation performed after a work unit is saved.
+type SaveAction = work.UnitSaveAction
+
 var (
 	// ActionTypeAfterRegister indicates an action type that occurs after
 	// an entity is registered.
@@ -216,6 +539,30 @@ var (
 	ActionTypeBeforeRollback = work.UnitActionTypeBeforeRollback
 	// ActionTypeBeforeSave indicates an action type that occurs before save.
 	ActionTypeBeforeSave = work.UnitActionTypeBeforeSave
+	// ActionTypeAfterPatch indicates an action type that occurs after an
+	// entity is patched.
+	ActionTypeAfterPatch = work.UnitActionTypeAfterPatch
+	// ActionTypeBeforePatch indicates an action type that occurs before an
+	// entity is patched.
+	ActionTypeBeforePatch = work.UnitActionTypeBeforePatch
+	// ActionTypeAfterRemoveWhere indicates an action type that occurs after
+	// entities matching criteria are removed.
+	ActionTypeAfterRemoveWhere = work.UnitActionTypeAfterRemoveWhere
+	// ActionTypeBeforeRemoveWhere indicates an action type that occurs before
+	// entities matching criteria are removed.
+	ActionTypeBeforeRemoveWhere = work.UnitActionTypeBeforeRemoveWhere
+	// ActionTypeAfterUpsert indicates an action type that occurs after an
+	// entity is upserted.
+	ActionTypeAfterUpsert = work.UnitActionTypeAfterUpsert
+	// ActionTypeAfterUpserts indicates an action type that occurs after
+	// entities are upserted in the data store.
+	ActionTypeAfterUpserts = work.UnitActionTypeAfterUpserts
+	// ActionTypeBeforeUpsert indicates an action type that occurs before an
+	// entity is upserted.
+	ActionTypeBeforeUpsert = work.UnitActionTypeBeforeUpsert
+	// ActionTypeBeforeUpserts indicates an action type that occurs before
+	// entities are upserted in the data store.
+	ActionTypeBeforeUpserts = work.UnitActionTypeBeforeUpserts
 )
 
 /* Data Mappers. */
@@ -231,7 +578,696 @@ type DataMapper = work.UnitDataMapper
 // operation, such as insert, update, or delete.
 type DataMapperFunc = work.UnitDataMapperFunc
 
+// Patch represents a partial update to an existing entity.
+type Patch = work.UnitPatch
+
+// PatchDataMapperFunc represents a data mapper function that applies
+// partial updates to existing entities.
+type PatchDataMapperFunc = work.UnitPatchDataMapperFunc
+
+// BulkOperation represents a single operation within a bulk write.
+type BulkOperation = work.UnitBulkOperation
+
+// BulkWriteFunc applies operations with a single request to the underlying
+// data store.
+type BulkWriteFunc = work.UnitBulkWriteFunc
+
 /* Logging. */
 
 // Logger represents a logger.
 type Logger = work.UnitLogger
+
+// MetricsCollector represents a collector of the metrics emitted by work
+// units.
+type MetricsCollector = work.UnitMetricsCollector
+
+/* Tracing. */
+
+// Tracer represents a tracer of the operations performed by work units.
+type Tracer = work.UnitTracer
+
+// Span represents a span tracing an operation performed by a work unit.
+type Span = work.UnitSpan
+
+/* Serialization. */
+
+// Codec represents a type responsible for serializing entities into payloads
+// for external systems.
+type Codec = work.UnitCodec
+
+// Payload represents a serialized entity.
+type Payload = work.UnitPayload
+
+// Payloads represents the serialized entities staged within a work unit.
+type Payloads = work.UnitPayloads
+
+var (
+	// JSONCodec is the codec serializing entities as JSON.
+	JSONCodec = work.UnitJSONCodec
+	// ErrMissingCodec represents the error that is returned when serializing
+	// an entity whose type has no codec.
+	ErrMissingCodec = work.ErrMissingCodec
+)
+
+/* Caching. */
+
+// Cache represents the cache that the work unit manipulates as a result of
+// entity registration.
+type Cache = work.UnitCache
+
+// CacheClient represents a client for a cache provider.
+type CacheClient = work.UnitCacheClient
+
+// CacheMultiClient represents a client for a cache provider that is capable
+// of setting and deleting many entries in a single round trip.
+type CacheMultiClient = work.UnitCacheMultiClient
+
+/* Change Data Capture. */
+
+// ChangeOperation represents the type of change captured by a change record.
+type ChangeOperation = work.UnitChangeOperation
+
+// ChangeRecord represents a single mutation committed by a work unit.
+type ChangeRecord = work.UnitChangeRecord
+
+// ChangeRecordSink represents a destination for change records.
+type ChangeRecordSink = work.UnitChangeRecordSink
+
+const (
+	// ChangeOperationCreate indicates an entity that was created.
+	ChangeOperationCreate = work.UnitChangeOperationCreate
+	// ChangeOperationUpdate indicates an entity that was updated.
+	ChangeOperationUpdate = work.UnitChangeOperationUpdate
+	// ChangeOperationDelete indicates an entity that was deleted.
+	ChangeOperationDelete = work.UnitChangeOperationDelete
+)
+
+/* Transaction Splitting. */
+
+// TransactionChunk represents the outcome of a single transaction performed
+// when the operations of a work unit are split across multiple transactions.
+type TransactionChunk = work.UnitTransactionChunk
+
+// PartialSaveError represents the error that is returned when one of the
+// transactions of a split work unit fails.
+type PartialSaveError = work.UnitPartialSaveError
+
+/* Quarantine. */
+
+// QuarantinedEntity represents an entity that was excluded from a save after
+// its data mapper repeatedly failed to apply it.
+type QuarantinedEntity = work.UnitQuarantinedEntity
+
+// QuarantineSink represents a destination for quarantined entities.
+type QuarantineSink = work.UnitQuarantineSink
+
+/* Identifiers. */
+
+// IDGenerator generates identifiers for added entities lacking one.
+type IDGenerator = work.UnitIDGenerator
+
+var (
+	// ErrIDNotAssignable represents the error that is returned when an
+	// identifier cannot be assigned to an entity.
+	ErrIDNotAssignable = work.ErrUnitIDNotAssignable
+	// UUIDv7 generates time-ordered UUIDs.
+	UUIDv7 = work.UnitUUIDv7
+	// ULID generates lexicographically sortable identifiers.
+	ULID = work.UnitULID
+)
+
+/* Read Your Writes. */
+
+// CommitTokenizer captures and awaits tokens identifying the position of
+// committed saves within the data store.
+type CommitTokenizer = work.UnitCommitTokenizer
+
+// PostgresCommitTokenizer captures and awaits Postgres write-ahead log
+// positions.
+type PostgresCommitTokenizer = work.UnitPostgresCommitTokenizer
+
+/* Diagnostics. */
+
+// SavePhase represents a phase of saving a work unit.
+type SavePhase = work.UnitSavePhase
+
+// TypeCounts represents the number of entities of a single type staged
+// within a work unit.
+type TypeCounts = work.UnitTypeCounts
+
+// SaveDiagnostics represents a snapshot of a save that exceeded the slow save
+// threshold.
+type SaveDiagnostics = work.UnitSaveDiagnostics
+
+// SlowSaveHandler handles the diagnostics of a slow save.
+type SlowSaveHandler = work.UnitSlowSaveHandler
+
+const (
+	// SavePhaseBegin indicates the phase that starts the transaction.
+	SavePhaseBegin = work.UnitSavePhaseBegin
+	// SavePhaseInserts indicates the phase that inserts added entities.
+	SavePhaseInserts = work.UnitSavePhaseInserts
+	// SavePhaseUpserts indicates the phase that upserts entities.
+	SavePhaseUpserts = work.UnitSavePhaseUpserts
+	// SavePhaseUpdates indicates the phase that updates altered and patched
+	// entities.
+	SavePhaseUpdates = work.UnitSavePhaseUpdates
+	// SavePhaseDeletes indicates the phase that deletes removed entities.
+	SavePhaseDeletes = work.UnitSavePhaseDeletes
+	// SavePhaseCommit indicates the phase that commits the transaction.
+	SavePhaseCommit = work.UnitSavePhaseCommit
+	// SavePhaseBulk indicates the phase that applies the changes with a
+	// single bulk write.
+	SavePhaseBulk = work.UnitSavePhaseBulk
+)
+
+/* Deferred Staging. */
+
+// ErrStagingPassesExceeded represents the error that is returned when
+// entities continue to be staged after the maximum number of deferred
+// staging passes.
+var ErrStagingPassesExceeded = work.ErrUnitStagingPassesExceeded
+
+/* Projections. */
+
+// ProjectionBuilder builds the read model rows derived from a source entity.
+type ProjectionBuilder = work.UnitProjectionBuilder
+
+/* Registries. */
+
+// Registry represents a collection of named uniters.
+type Registry = work.Registry
+
+// NamedUniter represents a uniter along with the name it is registered with.
+type NamedUniter = work.NamedUniter
+
+var (
+	// ErrUniterNotRegistered represents the error that is returned when no
+	// uniter is registered with the requested name.
+	ErrUniterNotRegistered = work.ErrUniterNotRegistered
+	// ErrUniterAlreadyRegistered represents the error that is returned when a
+	// uniter is already registered with the provided name.
+	ErrUniterAlreadyRegistered = work.ErrUniterAlreadyRegistered
+	// NewRegistry creates a new registry without any uniters.
+	NewRegistry = work.NewRegistry
+	// NewRegistryOf creates a new registry containing the provided uniters.
+	NewRegistryOf = work.NewRegistryOf
+	// Register registers a uniter with the provided name within the default
+	// registry.
+	Register = work.Register
+	// Get retrieves the uniter registered with the provided name within the
+	// default registry.
+	Get = work.Get
+	// UniterProvider provides a constructor retrieving the uniter registered
+	// with the provided name from a registry.
+	UniterProvider = work.UniterProvider
+)
+
+/* Verification. */
+
+// Readiness represents the outcome of verifying the configuration of the work
+// units constructed by a uniter.
+type Readiness = work.UniterReadiness
+
+// Check represents the outcome of a single check performed when verifying a
+// uniter.
+type Check = work.UniterCheck
+
+// MissingMappersError represents the error that is returned when verifying a
+// uniter whose work units lack the data mapper functions required for a
+// type.
+type MissingMappersError = work.UnitMissingMappersError
+
+var (
+	// VerifyOptions verifies the configuration of work units created with
+	// the provided options.
+	VerifyOptions = work.VerifyUnitOptions
+	// VerifyStatements specifies the option to provide statements that are
+	// prepared against the database when verifying the uniter.
+	VerifyStatements = work.UnitVerifyStatements
+)
+
+/* Dependencies. */
+
+// Deps represents the dependencies of a work unit.
+type Deps = work.UnitDeps
+
+var (
+	// NewFromDeps creates a new work unit with the provided dependencies.
+	NewFromDeps = work.NewUnitFromDeps
+	// NewUniterFromDeps creates a new uniter with the provided dependencies.
+	NewUniterFromDeps = work.NewUniterFromDeps
+)
+
+/* Validation. */
+
+// InvalidEntityError represents the error that is returned when an entity
+// cannot be staged, describing why.
+type InvalidEntityError = work.UnitInvalidEntityError
+
+var (
+	// ErrInvalidEntity represents the error that is returned when an entity
+	// cannot be staged, such as nil entities.
+	ErrInvalidEntity = work.ErrInvalidEntity
+	// ErrUntrackedEntity represents the error that is returned when an
+	// untracked entity is altered or removed while strict tracking is enabled.
+	ErrUntrackedEntity = work.ErrUntrackedEntity
+)
+
+/* Uniqueness. */
+
+// UniqueCheckFunc verifies that entities do not violate a uniqueness
+// constraint.
+type UniqueCheckFunc = work.UnitUniqueCheckFunc
+
+// UniquenessError represents the error that is returned when a uniqueness
+// check fails.
+type UniquenessError = work.UnitUniquenessError
+
+/* Retries. */
+
+// RetryContext describes the save of a work unit that is being retried.
+type RetryContext = work.UnitRetryContext
+
+// RetryFunc is invoked before the save of a work unit is retried.
+type RetryFunc = work.UnitRetryFunc
+
+// RetryAttempt describes a failed attempt to save a work unit.
+type RetryAttempt = work.UnitRetryAttempt
+
+// RetryError represents the error that is returned when saving a work unit
+// fails while retry history is enabled, providing the error of each failed
+// attempt.
+type RetryError = work.UnitRetryError
+
+/* Description. */
+
+// Description represents a summary of the effective configuration of work
+// units, suitable for logging at startup.
+type Description = work.UnitDescription
+
+/* Metrics. */
+
+// MetricNames represents the names of the metrics emitted by work units.
+type MetricNames = work.UnitMetricNames
+
+/* Transaction Leaks. */
+
+// TxLeakError represents the error that is returned when the transaction
+// provided to a data mapper is used after its save attempt has completed.
+type TxLeakError = work.UnitTxLeakError
+
+var (
+	// ErrTxLeaked represents the error that is returned when the transaction
+	// provided to data mappers is used after its save attempt has completed.
+	ErrTxLeaked = work.ErrTxLeaked
+	// ErrMissingTx represents the error that is returned when accessing the
+	// transaction of a mapper context that has none.
+	ErrMissingTx = work.ErrMissingTx
+)
+
+// WrapTx provides the value produced by the provided function from the
+// transaction of the provided mapper context.
+func WrapTx[T any](mCtx MapperContext, wrap func(*sql.Tx) T) (T, error) {
+	return work.WrapTx(mCtx, wrap)
+}
+
+/* Goroutine Auditing. */
+
+// GoroutineAuditMode represents the manner in which the work unit reacts to
+// being used by a goroutine other than the one that created it.
+type GoroutineAuditMode = work.UnitGoroutineAuditMode
+
+const (
+	// GoroutineAuditLog logs usages of the work unit by goroutines other than
+	// the one that created it.
+	GoroutineAuditLog = work.UnitGoroutineAuditLog
+	// GoroutineAuditPanic logs and panics upon usages of the work unit by
+	// goroutines other than the one that created it.
+	GoroutineAuditPanic = work.UnitGoroutineAuditPanic
+)
+
+/* Transfers. */
+
+var (
+	// Transfer atomically moves the provided entities, along with their
+	// states and cached registered state, from one work unit to another.
+	Transfer = work.Transfer
+	// ErrTransferUnsupported represents the error that is returned when
+	// transferring entities between work units not created by this package.
+	ErrTransferUnsupported = work.ErrTransferUnsupported
+	// ErrTransferWhileSaving represents the error that is returned when
+	// transferring entities to or from a work unit being saved.
+	ErrTransferWhileSaving = work.ErrTransferWhileSaving
+	// ErrEntityNotStaged represents the error that is returned when
+	// transferring an entity that is not staged within the source work unit.
+	ErrEntityNotStaged = work.ErrEntityNotStaged
+)
+
+/* Batching. */
+
+// BatchingUniter represents a uniter that coalesces the changes of many
+// short-lived work units into consolidated work units saved in bulk.
+type BatchingUniter = work.BatchingUniter
+
+var (
+	// NewBatchingUniter creates a new batching uniter with the provided unit
+	// options.
+	NewBatchingUniter = work.NewBatchingUniter
+	// ErrBatchingUniterClosed represents the error that is returned when work
+	// units are submitted to a batching uniter that has been closed.
+	ErrBatchingUniterClosed = work.ErrBatchingUniterClosed
+	// ErrInvalidFlushInterval represents the error that is returned when
+	// creating a batching uniter with a flush interval that is not positive.
+	ErrInvalidFlushInterval = work.ErrInvalidFlushInterval
+)
+
+/* Asynchronous Saves. */
+
+// SaveFuture represents the eventual outcome of saving a work unit.
+type SaveFuture = work.UnitSaveFuture
+
+// SavePanicError represents the error that completes the future of a save
+// that panicked.
+type SavePanicError = work.UnitSavePanicError
+
+var (
+	// NewSaveFuture performs the provided save in a new goroutine, providing
+	// the future for its outcome.
+	NewSaveFuture = work.NewUnitSaveFuture
+)
+
+/* Conflicts. */
+
+// Conflict represents a conflict between entities sharing the same identity.
+type Conflict = work.UnitConflict
+
+// Resolution represents the resolution of a conflict.
+type Resolution = work.UnitResolution
+
+// ResolutionChoice represents the manner in which a conflict is resolved.
+type ResolutionChoice = work.UnitResolutionChoice
+
+// ConflictResolverFunc resolves the provided conflict.
+type ConflictResolverFunc = work.UnitConflictResolverFunc
+
+// ConflictError represents the error that is returned when a conflict is not
+// resolved.
+type ConflictError = work.UnitConflictError
+
+// StaleEntityError represents the error that data mappers return when an
+// entity cannot be written because its persisted version has changed.
+type StaleEntityError = work.UnitStaleEntityError
+
+const (
+	// ResolutionFail fails upon the conflict.
+	ResolutionFail = work.UnitResolutionFail
+	// ResolutionOurs resolves the conflict in favor of the entity being
+	// staged or written.
+	ResolutionOurs = work.UnitResolutionOurs
+	// ResolutionTheirs resolves the conflict in favor of the entity that is
+	// already staged or persisted.
+	ResolutionTheirs = work.UnitResolutionTheirs
+	// ResolutionMerge resolves the conflict in favor of a merged entity.
+	ResolutionMerge = work.UnitResolutionMerge
+)
+
+var (
+	// ErrConflict represents the error that is returned when a conflict is
+	// not resolved.
+	ErrConflict = work.ErrConflict
+	// ErrStaleEntity represents the error that data mappers return when an
+	// entity cannot be written because its persisted version has changed.
+	ErrStaleEntity = work.ErrStaleEntity
+)
+
+/* Staleness. */
+
+var (
+	// ErrEvicted represents the error that is returned when saving a work
+	// unit whose staged changes were discarded for being stale.
+	ErrEvicted = work.ErrUnitEvicted
+)
+
+/* Error classification. */
+
+// ErrorClass represents the category of a failure encountered when saving a
+// work unit.
+type ErrorClass = work.ErrorClass
+
+// ErrorClassifier categorizes the provided error returned by a driver.
+type ErrorClassifier = work.ErrorClassifier
+
+// ClassifiedError represents a failure encountered when saving a work unit
+// that was categorized by the error classifier of the driver.
+type ClassifiedError = work.ClassifiedError
+
+const (
+	// ErrorClassUnknown represents failures that could not be categorized.
+	ErrorClassUnknown = work.ErrorClassUnknown
+	// ErrorClassTransient represents failures that may succeed when retried.
+	ErrorClassTransient = work.ErrorClassTransient
+	// ErrorClassConstraint represents failures due to violating a constraint.
+	ErrorClassConstraint = work.ErrorClassConstraint
+	// ErrorClassPermission represents failures due to lacking privileges.
+	ErrorClassPermission = work.ErrorClassPermission
+	// ErrorClassTimeout represents failures due to exceeding a deadline.
+	ErrorClassTimeout = work.ErrorClassTimeout
+)
+
+var (
+	// RegisterErrorClassifier registers the provided classifier for the
+	// driver registered with the provided name.
+	RegisterErrorClassifier = work.RegisterErrorClassifier
+	// ClassifyError categorizes the provided error with the classifier of
+	// the driver registered with the provided name.
+	ClassifyError = work.ClassifyError
+)
+
+/* Error reports. */
+
+// OperationError represents the error that is returned when a data mapper
+// fails to apply an operation for entities of a particular type.
+type OperationError = work.UnitOperationError
+
+// ErrorReport represents the error returned when saving a work unit broken
+// down by type and operation.
+type ErrorReport = work.ErrorReport
+
+// ErrorReportFailure represents the failures of a particular operation for
+// entities of a particular type.
+type ErrorReportFailure = work.ErrorReportFailure
+
+var (
+	// NewErrorReport creates a report of the provided error, as returned
+	// when saving a work unit.
+	NewErrorReport = work.NewErrorReport
+)
+
+/* Status. */
+
+// Status represents the status of a work unit within its lifecycle.
+type Status = work.UnitStatus
+
+const (
+	// StatusPending indicates that the work unit has not been saved.
+	StatusPending = work.UnitStatusPending
+	// StatusSaving indicates that the work unit is being saved.
+	StatusSaving = work.UnitStatusSaving
+	// StatusCompleted indicates that the work unit was saved successfully.
+	StatusCompleted = work.UnitStatusCompleted
+	// StatusFailed indicates that the most recent save of the work unit
+	// failed.
+	StatusFailed = work.UnitStatusFailed
+	// StatusDiscarded indicates that the staged changes of the work unit
+	// were discarded.
+	StatusDiscarded = work.UnitStatusDiscarded
+)
+
+var (
+	// ErrCompleted represents the error that is returned when staging
+	// entities into, or saving, a work unit that has already been saved.
+	ErrCompleted = work.ErrUnitCompleted
+)
+
+/* Action failures. */
+
+// ActionPanicError represents the error that is captured when an action
+// panics.
+type ActionPanicError = work.UnitActionPanicError
+
+/* Type filtering. */
+
+// TypeNotAllowedError represents the error that is returned when staging an
+// entity whose type is not allowed within the work unit.
+type TypeNotAllowedError = work.UnitTypeNotAllowedError
+
+var (
+	// ErrTypeNotAllowed represents the error that is returned when staging
+	// an entity whose type is not allowed within the work unit.
+	ErrTypeNotAllowed = work.ErrTypeNotAllowed
+)
+
+/* Aggregate boundaries. */
+
+// AggregateBoundaryError represents the error that is returned when saving a
+// work unit with entities of a type belonging to an aggregate staged without
+// their aggregate root.
+type AggregateBoundaryError = work.UnitAggregateBoundaryError
+
+var (
+	// ErrAggregateBoundary represents the error that is returned when saving
+	// a work unit with entities of a type belonging to an aggregate staged
+	// without their aggregate root.
+	ErrAggregateBoundary = work.ErrAggregateBoundary
+)
+
+/* Authorization. */
+
+// Operation represents an operation staged within a work unit.
+type Operation = work.UnitOperation
+
+// AuthorizerFunc authorizes the provided operation for the provided entity.
+type AuthorizerFunc = work.UnitAuthorizerFunc
+
+// ForbiddenError represents the error that is returned when saving a work
+// unit containing an operation that the authorizer forbids.
+type ForbiddenError = work.UnitForbiddenError
+
+const (
+	// OperationInsert represents inserting an added entity.
+	OperationInsert = work.UnitOperationInsert
+	// OperationUpsert represents upserting an entity.
+	OperationUpsert = work.UnitOperationUpsert
+	// OperationUpdate represents updating an altered entity.
+	OperationUpdate = work.UnitOperationUpdate
+	// OperationPatch represents partially updating an entity.
+	OperationPatch = work.UnitOperationPatch
+	// OperationDelete represents deleting a removed entity.
+	OperationDelete = work.UnitOperationDelete
+	// OperationDeleteWhere represents deleting the entities matching
+	// criteria.
+	OperationDeleteWhere = work.UnitOperationDeleteWhere
+)
+
+var (
+	// ErrForbidden represents the error that is returned when saving a work
+	// unit containing an operation that the authorizer forbids.
+	ErrForbidden = work.ErrForbidden
+)
+
+/* Cache encryption. */
+
+// CacheKeySource provides the key used to encrypt and decrypt cached
+// entities.
+type CacheKeySource = work.UnitCacheKeySource
+
+var (
+	// StaticCacheKey provides a key source that always provides the provided
+	// key.
+	StaticCacheKey = work.UnitStaticCacheKey
+	// ErrCacheDecryption represents the error that is returned when a cached
+	// entity cannot be decrypted.
+	ErrCacheDecryption = work.ErrCacheDecryption
+)
+
+/* Cache failures. */
+
+// CacheFailureMode represents how work units behave when the cache client
+// fails to set or delete cached entities.
+type CacheFailureMode = work.UnitCacheFailureMode
+
+const (
+	// CacheFailureModeIgnore represents discarding cache failures.
+	CacheFailureModeIgnore = work.UnitCacheFailureModeIgnore
+	// CacheFailureModeWarn represents logging cache failures as warnings.
+	CacheFailureModeWarn = work.UnitCacheFailureModeWarn
+	// CacheFailureModeFail represents returning cache failures.
+	CacheFailureModeFail = work.UnitCacheFailureModeFail
+)
+
+/* Active units. */
+
+// ActiveUnit describes a work unit that is currently open, as tracked by the
+// active unit registry.
+type ActiveUnit = work.ActiveUnit
+
+var (
+	// ActiveUnits provides the tracked work units that are currently open.
+	ActiveUnits = work.ActiveUnits
+	// ActiveUnitsHandler provides the HTTP handler responding with the
+	// tracked work units that are currently open as JSON.
+	ActiveUnitsHandler = work.ActiveUnitsHandler
+)
+
+/* Save middleware. */
+
+// SaveFunc represents the save pipeline of a work unit, or a portion of it
+// wrapped by middleware.
+type SaveFunc = work.UnitSaveFunc
+
+// SaveMiddlewareFunc wraps the provided save pipeline of a work unit.
+type SaveMiddlewareFunc = work.UnitSaveMiddlewareFunc
+
+/* Event sourcing. */
+
+// EventSourced represents an aggregate that is persisted as the events
+// raised against it, rather than as its state.
+type EventSourced = work.UnitEventSourced
+
+// EventStream represents the uncommitted events of an aggregate to append to
+// its stream.
+type EventStream = work.UnitEventStream
+
+// EventAppendFunc appends the events of the provided streams to the
+// underlying event store.
+type EventAppendFunc = work.UnitEventAppendFunc
+
+// ErrNotEventSourced represents the error that is returned when saving an
+// entity of an event-sourced type that does not implement EventSourced.
+var ErrNotEventSourced = work.ErrUnitNotEventSourced
+
+/* Lock tokens. */
+
+// LockTokenFunc provides the lock token for the provided entity.
+type LockTokenFunc = work.UnitLockTokenFunc
+
+// LockTokenCarrier represents an entity that carries the lock token of the
+// state it was read in.
+type LockTokenCarrier = work.UnitLockTokenCarrier
+
+// HashLockToken provides the hex encoded SHA-256 hash of the JSON encoding of
+// the provided entity as its lock token.
+var HashLockToken = work.UnitHashLockToken
+
+/* Mutation guards. */
+
+// MutationGuardError represents the error that is returned when saving a work
+// unit staging more changes for a type than its mutation guard permits.
+type MutationGuardError = work.UnitMutationGuardError
+
+// ErrMutationLimitExceeded represents the error that is returned when saving
+// a work unit staging more changes for a type than its mutation guard
+// permits.
+var ErrMutationLimitExceeded = work.ErrMutationLimitExceeded
+
+// MutationSummary describes the changes staged within a work unit for a type
+// exceeding its mutation limit.
+type MutationSummary = work.UnitMutationSummary
+
+// ConfirmationError represents the error that is returned when saving a work
+// unit requires confirmation, providing the token to confirm the save with.
+type ConfirmationError = work.UnitConfirmationError
+
+// ErrConfirmationRequired represents the error that is returned when saving
+// a work unit staging more changes for a type than its mutation guard
+// permits, without confirming the save.
+var ErrConfirmationRequired = work.ErrConfirmationRequired
+
+// SaveOption represents an option for a single save of a work unit.
+type SaveOption = work.UnitSaveOption
+
+// Confirm provides the save option to confirm saving a work unit with the
+// token from the ConfirmationError returned by the previous attempt to save
+// it.
+var Confirm = work.Confirm
@@ -15,9 +15,22 @@
 
 package work
 
+import "errors"
+
+// ErrUnitActionFailed represents the error that is returned when an action
+// fails while the work unit is configured to halt actions upon failure.
+var ErrUnitActionFailed = errors.New("work unit action failed")
+
 // Action represents an operation performed during a paticular lifecycle event of a work unit.
 type UnitAction func(UnitActionContext)
 
+// UnitRollbackAction represents an operation performed after a work unit is
+// rolled back.
+type UnitRollbackAction func(UnitRollbackActionContext)
+
+// UnitSaveAction represents an operation performed after a work unit is saved.
+type UnitSaveAction func(UnitSaveActionContext)
+
 // UnitActionType represents the type of work unit action.
 type UnitActionType int
 
@@ -59,4 +72,27 @@ const (
 	UnitActionTypeBeforeRollback
 	// UnitActionTypeBeforeSave indicates an action type that occurs before save.
 	UnitActionTypeBeforeSave
+	// UnitActionTypeAfterUpsert indicates an action type that occurs after an entity is upserted.
+	UnitActionTypeAfterUpsert
+	// UnitActionTypeAfterUpserts indicates an action type that occurs after entities are upserted in the data store.
+	UnitActionTypeAfterUpserts
+	// UnitActionTypeBeforeUpsert indicates an action type that occurs before an entity is upserted.
+	UnitActionTypeBeforeUpsert
+	// UnitActionTypeBeforeUpserts indicates an action type that occurs before entities are upserted in the data store.
+	UnitActionTypeBeforeUpserts
+	// UnitActionTypeAfterPatch indicates an action type that occurs after an entity is patched.
+	UnitActionTypeAfterPatch
+	// UnitActionTypeBeforePatch indicates an action type that occurs before an entity is patched.
+	UnitActionTypeBeforePatch
+	// UnitActionTypeAfterRemoveWhere indicates an action type that occurs after entities matching criteria are removed.
+	UnitActionTypeAfterRemoveWhere
+	// UnitActionTypeBeforeRemoveWhere indicates an action type that occurs before entities matching criteria are removed.
+	UnitActionTypeBeforeRemoveWhere
 )
+
+type unitAction struct {
+	action   UnitAction
+	priority int
+	name     string
+	when     func(UnitActionContext) bool
+}
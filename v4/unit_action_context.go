@@ -16,15 +16,30 @@
 package work
 
 import (
+	"context"
+	"time"
+
 	"github.com/uber-go/tally/v4"
 )
 
 // UnitActionContext represents the executional context for an action.
 type UnitActionContext struct {
+	// Context is the context provided when performing the operation that
+	// triggered the action, such as Save or Register, carrying its deadline,
+	// trace spans, and other request-scoped values.
+	Context context.Context
 	// Logger is the work units configured logger.
 	Logger UnitLogger
-	// Scope is the work units configured metrics scope.
+	// Scope is the work units configured tally metrics scope, which is a
+	// no-op scope unless one is provided with the work.UnitTallyMetricScope
+	// option.
+	//
+	// Deprecated: Use Metrics instead.
 	Scope tally.Scope
+	// Metrics is the work units configured metrics collector, which
+	// qualifies the names of metrics and tags them in the same manner as the
+	// metrics emitted by the work unit.
+	Metrics UnitMetricsCollector
 	// AdditionCount represents the number of entities indicated as new.
 	AdditionCount int
 	// AlterationCount represents the number of entities indicated as modified.
@@ -33,4 +48,83 @@ type UnitActionContext struct {
 	RemovalCount int
 	// RegisterCount represents the number of entities indicated as registered.
 	RegisterCount int
+	// UpsertCount represents the number of entities indicated as upserted.
+	UpsertCount int
+	// PatchCount represents the number of partial updates indicated.
+	PatchCount int
+	// RemovalCriteriaCount represents the number of criteria indicated for
+	// removing the entities matching them.
+	RemovalCriteriaCount int
+
+	modified func(TypeName) bool
+	changes  func() UnitChanges
+	codec    func(TypeName) (UnitCodec, error)
+}
+
+// Changes provides a snapshot of the entity changes staged within the work
+// unit at the time it is called.
+func (ctx UnitActionContext) Changes() UnitChanges {
+	if ctx.changes == nil {
+		return UnitChanges{}
+	}
+	return ctx.changes()
+}
+
+// Encode serializes the provided entity with the codec for its type, as
+// provided with the work.UnitCodecs or work.UnitDefaultCodec options.
+func (ctx UnitActionContext) Encode(entity interface{}) (UnitPayload, error) {
+	return encode(ctx.codec, entity)
+}
+
+// Payloads serializes the entity changes staged within the work unit at the
+// time it is called with the codecs for their types, such that hooks for
+// external systems need not reflect on the entities themselves.
+func (ctx UnitActionContext) Payloads() (payloads UnitPayloads, err error) {
+	changes := ctx.Changes()
+	if payloads.Additions, err = encodeAll(ctx.codec, changes.Additions); err != nil {
+		return UnitPayloads{}, err
+	}
+	if payloads.Alterations, err = encodeAll(ctx.codec, changes.Alterations); err != nil {
+		return UnitPayloads{}, err
+	}
+	if payloads.Removals, err = encodeAll(ctx.codec, changes.Removals); err != nil {
+		return UnitPayloads{}, err
+	}
+	if payloads.Upserts, err = encodeAll(ctx.codec, changes.Upserts); err != nil {
+		return UnitPayloads{}, err
+	}
+	return
+}
+
+// Modified indicates whether any entities with one of the provided type names
+// have been staged for modification within the work unit, whether by
+// addition, alteration, removal, upsert, patch, or removal by criteria.
+func (ctx UnitActionContext) Modified(types ...TypeName) bool {
+	if ctx.modified == nil {
+		return false
+	}
+	for _, t := range types {
+		if ctx.modified(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// UnitRollbackActionContext represents the executional context for an
+// action performed after a work unit is rolled back.
+type UnitRollbackActionContext struct {
+	UnitActionContext
+	// Err is the error that triggered the rollback.
+	Err error
+}
+
+// UnitSaveActionContext represents the executional context for an action
+// performed after a work unit is saved, whether successful or not.
+type UnitSaveActionContext struct {
+	UnitActionContext
+	// Duration is the time taken to save the work unit, including retries.
+	Duration time.Duration
+	// Err is the error encountered when saving the work unit, if any.
+	Err error
 }
@@ -0,0 +1,127 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package work
+
+import (
+	"fmt"
+	"runtime/debug"
+	"time"
+)
+
+var unitActionTypeNames = map[UnitActionType]string{
+	UnitActionTypeAfterRegister:     "after_register",
+	UnitActionTypeAfterAdd:          "after_add",
+	UnitActionTypeAfterAlter:        "after_alter",
+	UnitActionTypeAfterRemove:       "after_remove",
+	UnitActionTypeAfterInserts:      "after_inserts",
+	UnitActionTypeAfterUpdates:      "after_updates",
+	UnitActionTypeAfterDeletes:      "after_deletes",
+	UnitActionTypeAfterRollback:     "after_rollback",
+	UnitActionTypeAfterSave:         "after_save",
+	UnitActionTypeBeforeRegister:    "before_register",
+	UnitActionTypeBeforeAdd:         "before_add",
+	UnitActionTypeBeforeAlter:       "before_alter",
+	UnitActionTypeBeforeRemove:      "before_remove",
+	UnitActionTypeBeforeInserts:     "before_inserts",
+	UnitActionTypeBeforeUpdates:     "before_updates",
+	UnitActionTypeBeforeDeletes:     "before_deletes",
+	UnitActionTypeBeforeRollback:    "before_rollback",
+	UnitActionTypeBeforeSave:        "before_save",
+	UnitActionTypeAfterUpsert:       "after_upsert",
+	UnitActionTypeAfterUpserts:      "after_upserts",
+	UnitActionTypeBeforeUpsert:      "before_upsert",
+	UnitActionTypeBeforeUpserts:     "before_upserts",
+	UnitActionTypeAfterPatch:        "after_patch",
+	UnitActionTypeBeforePatch:       "before_patch",
+	UnitActionTypeAfterRemoveWhere:  "after_remove_where",
+	UnitActionTypeBeforeRemoveWhere: "before_remove_where",
+}
+
+// String provides the name of the action type.
+func (t UnitActionType) String() string {
+	if name, ok := unitActionTypeNames[t]; ok {
+		return name
+	}
+	return fmt.Sprintf("UnitActionType(%d)", int(t))
+}
+
+const (
+	// unitActionTypeRollback is the name of the type of the actions
+	// performed with the cause of a rollback.
+	unitActionTypeRollback = "rollback"
+	// unitActionTypeSave is the name of the type of the actions performed
+	// with the outcome of a save.
+	unitActionTypeSave = "save"
+)
+
+// UnitActionPanicError represents the error that is captured when an action
+// panics. Panics within actions never crash the operation that triggered
+// them; they are reported, and surfaced from the operation when halting
+// actions on failure.
+type UnitActionPanicError struct {
+	// ActionType is the name of the type of the action, such as before_save.
+	ActionType string
+	// Name is the name of the action, if it was named.
+	Name string
+	// Value is the value the action panicked with.
+	Value interface{}
+	// Stack is the stack trace of the goroutine when the action panicked.
+	Stack []byte
+}
+
+// Error provides the error message.
+func (e *UnitActionPanicError) Error() string {
+	return fmt.Sprintf("%s; %v", ErrUnitActionFailed, e.Value)
+}
+
+// Is indicates whether the error is ErrUnitActionFailed.
+func (e *UnitActionPanicError) Is(target error) bool {
+	return target == ErrUnitActionFailed
+}
+
+// Unwrap provides the value the action panicked with when it is an error.
+func (e *UnitActionPanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
+// runAction invokes the provided function performing the action of the
+// provided type and name, which indicates whether the action was performed.
+// Performed actions are timed, and panics are captured as a
+// UnitActionPanicError.
+func (u *unit) runAction(actionType, name string, f func() bool) (err error) {
+	tags := map[string]string{"action_type": actionType}
+	if name != "" {
+		tags["action_name"] = name
+	}
+	start := time.Now()
+	performed := false
+	defer func() {
+		r := recover()
+		if !performed && r == nil {
+			return
+		}
+		scope := u.scope.tagged(tags)
+		scope.timer(u.metrics.Action, time.Since(start))
+		if r != nil {
+			err = &UnitActionPanicError{ActionType: actionType, Name: name, Value: r, Stack: debug.Stack()}
+			scope.counter(u.metrics.ActionPanic, 1)
+			u.logger.Error(err.Error(), "actionType", actionType, "actionName", name)
+		}
+	}()
+	performed = f()
+	return
+}
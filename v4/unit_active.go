@@ -0,0 +1,151 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package work
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ActiveUnit describes a work unit that is currently open, as tracked by
+// the active unit registry.
+type ActiveUnit struct {
+	// ID uniquely identifies the work unit within the registry.
+	ID string `json:"id"`
+	// Created is when the work unit was created.
+	Created time.Time `json:"created"`
+	// Age is how long the work unit has been open.
+	Age time.Duration `json:"age"`
+	// Status is the status of the work unit within its lifecycle.
+	Status string `json:"status"`
+	// Caller is the function, file, and line that created the work unit.
+	Caller string `json:"caller"`
+	// Additions is the number of entities staged as additions.
+	Additions int `json:"additions"`
+	// Alterations is the number of entities staged as alterations.
+	Alterations int `json:"alterations"`
+	// Removals is the number of entities staged as removals.
+	Removals int `json:"removals"`
+	// Registers is the number of entities registered.
+	Registers int `json:"registers"`
+	// Upserts is the number of entities staged as upserts.
+	Upserts int `json:"upserts"`
+	// Patches is the number of patches staged.
+	Patches int `json:"patches"`
+	// RemovalCriteria is the number of criteria staged for removal.
+	RemovalCriteria int `json:"removalCriteria"`
+}
+
+// activeUnitEntry represents a work unit tracked by the active unit
+// registry.
+type activeUnitEntry struct {
+	unit   *unit
+	caller string
+}
+
+// activeUnits is the active unit registry, holding the entries of the
+// tracked work units keyed by their identifier.
+var activeUnits sync.Map
+
+// callerOf provides the function, file, and line of the first caller
+// outside of this package.
+func callerOf() string {
+	pcs := make([]uintptr, 16)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs)])
+	for {
+		frame, more := frames.Next()
+		if !strings.HasPrefix(frame.Function, "github.com/freerware/work/v4.") {
+			return fmt.Sprintf("%s (%s:%d)", frame.Function, frame.File, frame.Line)
+		}
+		if !more {
+			return "unknown"
+		}
+	}
+}
+
+// track adds the work unit to the active unit registry when configured to,
+// where it remains until it is saved successfully or discarded.
+func (u *unit) track() {
+	if !u.trackActive {
+		return
+	}
+	u.activeID = uuid.NewString()
+	activeUnits.Store(u.activeID, activeUnitEntry{unit: u, caller: callerOf()})
+}
+
+// untrack removes the work unit from the active unit registry.
+func (u *unit) untrack() {
+	if u.activeID == "" {
+		return
+	}
+	activeUnits.Delete(u.activeID)
+}
+
+// ActiveUnits provides the work units created with UnitTrackActive that
+// are currently open, meaning they have been neither saved successfully nor
+// discarded, ordered from oldest to newest. Work units that remain open for
+// long in long-running services are likely leaked.
+func ActiveUnits() []ActiveUnit {
+	now := time.Now()
+	units := []ActiveUnit{}
+	activeUnits.Range(func(_, value interface{}) bool {
+		entry := value.(activeUnitEntry)
+		u := entry.unit
+		u.mutex.RLock()
+		units = append(units, ActiveUnit{
+			ID:              u.activeID,
+			Created:         u.created,
+			Age:             now.Sub(u.created),
+			Status:          u.Status().String(),
+			Caller:          entry.caller,
+			Additions:       u.additionCount,
+			Alterations:     u.alterationCount,
+			Removals:        u.removalCount,
+			Registers:       u.registerCount,
+			Upserts:         u.upsertCount,
+			Patches:         u.patchCount,
+			RemovalCriteria: u.criteriaCount,
+		})
+		u.mutex.RUnlock()
+		return true
+	})
+	sort.Slice(units, func(i, j int) bool {
+		return units[i].Created.Before(units[j].Created)
+	})
+	return units
+}
+
+// ActiveUnitsHandler provides the HTTP handler responding with the work
+// units that are currently open as JSON, intended to be mounted on a debug
+// server alongside handlers such as those of net/http/pprof:
+//
+//	mux.Handle("/debug/work/units", work.ActiveUnitsHandler())
+func ActiveUnitsHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(ActiveUnits()); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+}
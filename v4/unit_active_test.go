@@ -0,0 +1,147 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package work_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/freerware/work/v4"
+	"github.com/freerware/work/v4/internal/test"
+	"github.com/stretchr/testify/suite"
+)
+
+type ActiveUnitsTestSuite struct {
+	suite.Suite
+
+	insertErr error
+}
+
+func TestActiveUnitsTestSuite(t *testing.T) {
+	suite.Run(t, new(ActiveUnitsTestSuite))
+}
+
+func (s *ActiveUnitsTestSuite) SetupTest() {
+	s.insertErr = nil
+}
+
+func (s *ActiveUnitsTestSuite) unit(opts ...work.UnitOption) work.Unit {
+	fooType := work.TypeNameOf(test.Foo{})
+	insert := func(context.Context, work.UnitMapperContext, ...interface{}) error { return s.insertErr }
+	noop := func(context.Context, work.UnitMapperContext, ...interface{}) error { return nil }
+	opts = append([]work.UnitOption{
+		work.UnitInsertFunc(fooType, insert),
+		work.UnitUpdateFunc(fooType, noop),
+		work.UnitDeleteFunc(fooType, noop),
+		work.UnitRetryAttempts(1),
+	}, opts...)
+	u, err := work.NewUnit(opts...)
+	s.Require().NoError(err)
+	return u
+}
+
+func (s *ActiveUnitsTestSuite) TestActiveUnits() {
+	// arrange.
+	ctx := context.Background()
+	before := len(work.ActiveUnits())
+	u := s.unit(work.UnitTrackActive())
+	s.Require().NoError(u.Add(ctx, test.Foo{ID: 1}, test.Foo{ID: 2}))
+	s.Require().NoError(u.Register(ctx, test.Foo{ID: 3}))
+
+	// action.
+	units := work.ActiveUnits()
+
+	// assert.
+	s.Require().Len(units, before+1)
+	active := units[len(units)-1]
+	s.NotEmpty(active.ID)
+	s.False(active.Created.IsZero())
+	s.Equal(work.UnitStatusPending.String(), active.Status)
+	s.Contains(active.Caller, "ActiveUnitsTestSuite")
+	s.Contains(active.Caller, "unit_active_test.go")
+	s.Equal(2, active.Additions)
+	s.Equal(1, active.Registers)
+}
+
+func (s *ActiveUnitsTestSuite) TestActiveUnits_Untracked() {
+	// arrange.
+	before := len(work.ActiveUnits())
+	s.unit()
+
+	// action.
+	units := work.ActiveUnits()
+
+	// assert.
+	s.Len(units, before)
+}
+
+func (s *ActiveUnitsTestSuite) TestActiveUnits_Saved() {
+	// arrange.
+	ctx := context.Background()
+	before := len(work.ActiveUnits())
+	u := s.unit(work.UnitTrackActive())
+	s.Require().NoError(u.Add(ctx, test.Foo{ID: 1}))
+	s.Require().Len(work.ActiveUnits(), before+1)
+
+	// action.
+	err := u.Save(ctx)
+
+	// assert.
+	s.Require().NoError(err)
+	s.Len(work.ActiveUnits(), before)
+}
+
+func (s *ActiveUnitsTestSuite) TestActiveUnits_Failed() {
+	// arrange.
+	ctx := context.Background()
+	s.insertErr = errors.New("whoa")
+	before := len(work.ActiveUnits())
+	u := s.unit(work.UnitTrackActive())
+	s.Require().NoError(u.Add(ctx, test.Foo{ID: 1}))
+
+	// action.
+	err := u.Save(ctx)
+
+	// assert.
+	s.Require().Error(err)
+	units := work.ActiveUnits()
+	s.Require().Len(units, before+1)
+	s.Equal(work.UnitStatusFailed.String(), units[len(units)-1].Status)
+}
+
+func (s *ActiveUnitsTestSuite) TestActiveUnitsHandler() {
+	// arrange.
+	ctx := context.Background()
+	u := s.unit(work.UnitTrackActive())
+	s.Require().NoError(u.Add(ctx, test.Foo{ID: 1}))
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/debug/work/units", nil)
+
+	// action.
+	work.ActiveUnitsHandler().ServeHTTP(rec, req)
+
+	// assert.
+	s.Equal(http.StatusOK, rec.Code)
+	s.Equal("application/json", rec.Header().Get("Content-Type"))
+	var units []work.ActiveUnit
+	s.Require().NoError(json.Unmarshal(rec.Body.Bytes(), &units))
+	s.Require().NotEmpty(units)
+	s.Equal(1, units[len(units)-1].Additions)
+}
@@ -0,0 +1,104 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package work
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// ErrAggregateBoundary represents the error that is returned when saving a
+// work unit with entities of a type belonging to an aggregate staged without
+// their aggregate root.
+var ErrAggregateBoundary = errors.New("entity staged outside of its aggregate")
+
+// UnitAggregateBoundaryError represents the error that is returned when
+// saving a work unit with entities of a type belonging to an aggregate
+// staged without their aggregate root, describing the types.
+type UnitAggregateBoundaryError struct {
+	// TypeName is the type of the entities staged without their aggregate
+	// root.
+	TypeName TypeName
+	// Roots are the types of the aggregate roots the type belongs to, any of
+	// which must be staged or registered alongside it.
+	Roots []TypeName
+}
+
+// Error provides the error message.
+func (e *UnitAggregateBoundaryError) Error() string {
+	return fmt.Sprintf("%s: %s staged without any of %v", ErrAggregateBoundary, e.TypeName, e.Roots)
+}
+
+// Unwrap provides ErrAggregateBoundary, so that the error can be identified
+// with errors.Is.
+func (e *UnitAggregateBoundaryError) Unwrap() error {
+	return ErrAggregateBoundary
+}
+
+// aggregateRoots inverts the provided aggregate boundaries, providing the
+// types of the aggregate roots for each type belonging to an aggregate,
+// merged with the provided roots, if any.
+func aggregateRoots(
+	roots map[TypeName][]TypeName, boundaries map[TypeName][]TypeName) map[TypeName][]TypeName {
+	if roots == nil {
+		roots = make(map[TypeName][]TypeName)
+	}
+	for root, children := range boundaries {
+		for _, child := range children {
+			roots[child] = append(roots[child], root)
+		}
+	}
+	for child := range roots {
+		sort.Slice(roots[child], func(i, j int) bool { return roots[child][i] < roots[child][j] })
+	}
+	return roots
+}
+
+// checkBoundaries ensures that the entities of each type belonging to an
+// aggregate are staged alongside an aggregate root of that aggregate, which
+// is itself either staged or registered, providing a
+// UnitAggregateBoundaryError for the first type that is not.
+func (u *unit) checkBoundaries() error {
+	if len(u.aggregateRoots) == 0 {
+		return nil
+	}
+	u.mutex.RLock()
+	defer u.mutex.RUnlock()
+	staged := func(t TypeName) bool {
+		return len(u.additions[t])+len(u.alterations[t])+len(u.removals[t])+
+			len(u.upserts[t])+len(u.patches[t])+len(u.removalCriteria[t]) > 0
+	}
+	children := make([]TypeName, 0, len(u.aggregateRoots))
+	for t := range u.aggregateRoots {
+		children = append(children, t)
+	}
+	sort.Slice(children, func(i, j int) bool { return children[i] < children[j] })
+	for _, t := range children {
+		if !staged(t) {
+			continue
+		}
+		bounded := false
+		for _, root := range u.aggregateRoots[t] {
+			bounded = bounded || staged(root) || len(u.registered[root]) > 0
+		}
+		if !bounded {
+			err := &UnitAggregateBoundaryError{TypeName: t, Roots: u.aggregateRoots[t]}
+			u.logger.Error(err.Error(), "typeName", t.String())
+			return err
+		}
+	}
+	return nil
+}
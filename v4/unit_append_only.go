@@ -0,0 +1,47 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package work
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrUnitAppendOnly represents the error that is returned when staging
+// alterations, removals, upserts, or patches into an append-only work unit.
+var ErrUnitAppendOnly = errors.New("work unit is append-only")
+
+// appendOnlyErr provides ErrUnitAppendOnly when the provided staging
+// operation is performed on an append-only work unit.
+func (u *unit) appendOnlyErr(operation string) error {
+	if !u.appendOnly {
+		return nil
+	}
+	err := fmt.Errorf("%w: %s is not permitted", ErrUnitAppendOnly, operation)
+	u.logger.Error(err.Error())
+	return err
+}
+
+// canAdd determines if entities of the provided type can be added, which
+// requires an insert function. Work units without a transaction also
+// require a delete function to compensate for the inserts, unless they are
+// append-only or the type is event-sourced.
+func (u *unit) canAdd(t TypeName) bool {
+	if !u.hasInsertFunc(t) {
+		return false
+	}
+	return u.db != nil || u.appendOnly || u.sourcesEvents(t) || u.hasDeleteFunc(t)
+}
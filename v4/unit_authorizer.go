@@ -0,0 +1,133 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package work
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// UnitOperation represents an operation staged within a work unit.
+type UnitOperation string
+
+const (
+	// UnitOperationInsert represents inserting an added entity.
+	UnitOperationInsert UnitOperation = "insert"
+	// UnitOperationUpsert represents upserting an entity.
+	UnitOperationUpsert UnitOperation = "upsert"
+	// UnitOperationUpdate represents updating an altered entity.
+	UnitOperationUpdate UnitOperation = "update"
+	// UnitOperationPatch represents partially updating an entity, where the
+	// entity is the UnitPatch.
+	UnitOperationPatch UnitOperation = "patch"
+	// UnitOperationDelete represents deleting a removed entity.
+	UnitOperationDelete UnitOperation = "delete"
+	// UnitOperationDeleteWhere represents deleting the entities matching
+	// criteria, where the entity is the criteria.
+	UnitOperationDeleteWhere UnitOperation = "deleteWhere"
+)
+
+// ErrForbidden represents the error that is returned when saving a work unit
+// containing an operation that the authorizer of the work unit forbids.
+var ErrForbidden = errors.New("operation is forbidden")
+
+// UnitAuthorizerFunc authorizes the provided operation for the provided
+// entity using the context provided when saving the work unit, returning an
+// error when the operation is forbidden.
+type UnitAuthorizerFunc func(ctx context.Context, op UnitOperation, entity interface{}) error
+
+// UnitForbiddenError represents the error that is returned when saving a
+// work unit containing an operation that the authorizer of the work unit
+// forbids.
+type UnitForbiddenError struct {
+	// Operation is the operation that is forbidden.
+	Operation UnitOperation
+	// TypeName is the type of the entity the operation is forbidden for.
+	TypeName TypeName
+	// Entity is the entity the operation is forbidden for.
+	Entity interface{}
+	// Err is the error returned by the authorizer.
+	Err error
+}
+
+// Error provides the error message.
+func (e *UnitForbiddenError) Error() string {
+	return fmt.Sprintf("%s: %s of %s: %v", ErrForbidden, e.Operation, e.TypeName, e.Err)
+}
+
+// Is indicates whether the error is ErrForbidden.
+func (e *UnitForbiddenError) Is(target error) bool {
+	return target == ErrForbidden
+}
+
+// Unwrap provides the error returned by the authorizer.
+func (e *UnitForbiddenError) Unwrap() error {
+	return e.Err
+}
+
+// authorize invokes the authorizer of the work unit, if any, for each of the
+// staged operations, providing a UnitForbiddenError for the first operation
+// that is forbidden.
+func (u *unit) authorize(ctx context.Context) error {
+	if u.authorizer == nil {
+		return nil
+	}
+	changes := u.changes()
+	u.mutex.RLock()
+	criteria := copyEntities(u.removalCriteria)
+	u.mutex.RUnlock()
+	check := func(op UnitOperation, t TypeName, entity interface{}) error {
+		if err := u.authorizer(ctx, op, entity); err != nil {
+			err = &UnitForbiddenError{Operation: op, TypeName: t, Entity: entity, Err: err}
+			u.logger.Error(err.Error(), "typeName", t.String(), "operation", string(op))
+			return err
+		}
+		return nil
+	}
+	for _, staged := range []struct {
+		op       UnitOperation
+		entities map[TypeName][]interface{}
+	}{
+		{UnitOperationInsert, changes.Additions},
+		{UnitOperationUpsert, changes.Upserts},
+		{UnitOperationUpdate, changes.Alterations},
+		{UnitOperationDelete, changes.Removals},
+		{UnitOperationDeleteWhere, criteria},
+	} {
+		for _, t := range typeNames(staged.entities) {
+			for _, entity := range staged.entities[t] {
+				if err := check(staged.op, t, entity); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	patchTypes := make([]TypeName, 0, len(changes.Patches))
+	for t := range changes.Patches {
+		patchTypes = append(patchTypes, t)
+	}
+	sort.Slice(patchTypes, func(i, j int) bool { return patchTypes[i] < patchTypes[j] })
+	for _, t := range patchTypes {
+		for _, p := range changes.Patches[t] {
+			if err := check(UnitOperationPatch, t, p); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,199 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package work
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/multierr"
+)
+
+// UnitBulkOperation represents a single operation within a bulk write.
+type UnitBulkOperation struct {
+	// Operation is the operation to apply, which is one of
+	// UnitOperationInsert, UnitOperationUpsert, UnitOperationUpdate,
+	// UnitOperationPatch, or UnitOperationDelete.
+	Operation UnitOperation
+	// TypeName is the type of the entity the operation is applied for.
+	TypeName TypeName
+	// Entity is the entity the operation is applied for, which is nil for
+	// patches.
+	Entity interface{}
+	// Patch is the patch to apply, which is only provided for patches.
+	Patch UnitPatch
+}
+
+// UnitBulkWriteFunc applies the provided operations with a single request to
+// the underlying data store, such as for data stores that lack transactions
+// but support batching writes. The outcome of each operation is provided in
+// the order the operations were provided, where nil indicates the operation
+// was applied. An error is returned instead when the request itself fails,
+// as the outcome of each operation is unknown.
+type UnitBulkWriteFunc func(context.Context, UnitMapperContext, []UnitBulkOperation) ([]error, error)
+
+// writesInBulk determines if the entities of the provided type are written
+// with the bulk write function.
+func (u *unit) writesInBulk(t TypeName) bool {
+	_, ok := u.bulkTypes[t]
+	return ok
+}
+
+// inBulk provides the entities of the types written in bulk within the
+// provided entities.
+func (u *unit) inBulk(entities map[TypeName][]interface{}) map[TypeName][]interface{} {
+	written := make(map[TypeName][]interface{})
+	for typeName, e := range entities {
+		if u.writesInBulk(typeName) {
+			written[typeName] = e
+		}
+	}
+	return written
+}
+
+func appendBulkOperations(
+	operations []UnitBulkOperation, op UnitOperation, entities map[TypeName][]interface{}) []UnitBulkOperation {
+	for typeName, e := range entities {
+		for _, entity := range e {
+			operations = append(operations,
+				UnitBulkOperation{Operation: op, TypeName: typeName, Entity: entity})
+		}
+	}
+	return operations
+}
+
+// bulkOperations provides the operations staged within the work unit for
+// the types written in bulk.
+func (u *bestEffortUnit) bulkOperations() []UnitBulkOperation {
+	var operations []UnitBulkOperation
+	operations = appendBulkOperations(operations, UnitOperationInsert, u.inBulk(u.additions))
+	operations = appendBulkOperations(operations, UnitOperationUpsert, u.inBulk(u.upserts))
+	operations = appendBulkOperations(operations, UnitOperationUpdate, u.inBulk(u.alterations))
+	for typeName, patches := range u.patches {
+		if !u.writesInBulk(typeName) {
+			continue
+		}
+		for _, p := range patches {
+			operations = append(operations,
+				UnitBulkOperation{Operation: UnitOperationPatch, TypeName: typeName, Patch: p})
+		}
+	}
+	return appendBulkOperations(operations, UnitOperationDelete, u.inBulk(u.removals))
+}
+
+// writeBulk applies the provided operations with the bulk write function,
+// providing the errors of the operations that failed as
+// UnitOperationErrors.
+func (u *bestEffortUnit) writeBulk(
+	ctx context.Context, mCtx UnitMapperContext, operations []UnitBulkOperation) ([]error, error) {
+	if len(operations) == 0 {
+		return nil, nil
+	}
+	results, err := u.bulkWriter(ctx, mCtx, operations)
+	if err != nil {
+		return nil, err
+	}
+	if len(results) != len(operations) {
+		return nil, fmt.Errorf("bulk write provided %d results for %d operations", len(results), len(operations))
+	}
+	for i, op := range operations {
+		results[i] = operationErr(op.TypeName, op.Operation, results[i])
+	}
+	return results, nil
+}
+
+// applyBulk applies the changes to the entities written in bulk with a
+// single bulk write, tracking the operations that succeed so that they can
+// be compensated for should any of the changes within the work unit fail.
+func (u *bestEffortUnit) applyBulk(ctx context.Context, mCtx UnitMapperContext) (err error) {
+	operations := u.bulkOperations()
+	results, err := u.writeBulk(ctx, mCtx, operations)
+	if err == nil {
+		for i, op := range operations {
+			if results[i] != nil {
+				err = multierr.Append(err, results[i])
+				continue
+			}
+			u.succeeded(op)
+		}
+	}
+	if err != nil {
+		return u.abort(ctx, mCtx, err)
+	}
+	return
+}
+
+// succeeded tracks the provided operation as applied.
+func (u *bestEffortUnit) succeeded(op UnitBulkOperation) {
+	switch op.Operation {
+	case UnitOperationInsert:
+		u.successfulInserts[op.TypeName] = append(u.successfulInserts[op.TypeName], op.Entity)
+		u.successfulInsertCount = u.successfulInsertCount + 1
+	case UnitOperationUpsert:
+		u.successfulUpserts[op.TypeName] = append(u.successfulUpserts[op.TypeName], op.Entity)
+		u.successfulUpsertCount = u.successfulUpsertCount + 1
+	case UnitOperationUpdate:
+		u.successfulUpdates[op.TypeName] = append(u.successfulUpdates[op.TypeName], op.Entity)
+		u.successfulUpdateCount = u.successfulUpdateCount + 1
+	case UnitOperationPatch:
+		u.successfulPatchCount = u.successfulPatchCount + 1
+	case UnitOperationDelete:
+		u.successfulDeletes[op.TypeName] = append(u.successfulDeletes[op.TypeName], op.Entity)
+		u.successfulDeleteCount = u.successfulDeleteCount + 1
+	}
+}
+
+// bulkCompensation identifies the step compensating for the operations
+// applied with a bulk writer when rolling back a work unit.
+var bulkCompensation = unitCompensation{operation: UnitOperation("bulk")}
+
+// rollbackBulk compensates for the operations that were applied with a
+// single bulk write, on a best-effort basis. Deleted entities are
+// upserted, registered entities are reapplied when any entities were
+// updated or patched, and inserted entities, along with upserted entities
+// that were not previously registered, are deleted.
+func (u *bestEffortUnit) rollbackBulk(ctx context.Context, mCtx UnitMapperContext) error {
+	u.logger.Debug("attempting to compensate for bulk write",
+		"inserts", u.successfulInsertCount,
+		"upserts", u.successfulUpsertCount,
+		"updates", u.successfulUpdateCount,
+		"patches", u.successfulPatchCount,
+		"deletes", u.successfulDeleteCount,
+	)
+	var operations []UnitBulkOperation
+	operations = appendBulkOperations(operations, UnitOperationUpsert, u.inBulk(u.successfulDeletes))
+	if u.successfulUpdateCount+u.successfulPatchCount+u.successfulUpsertCount > 0 {
+		operations = appendBulkOperations(operations, UnitOperationUpsert, u.inBulk(u.registered))
+	}
+	for typeName, up := range u.inBulk(u.successfulUpserts) {
+		inserted, previous, uncompensated := mCtx.upserted.compensations(up, u.registered)
+		if len(uncompensated) > 0 {
+			u.logger.Warn("upserted entities without a reported outcome cannot be rolled back",
+				"typeName", typeName.String(), "count", len(uncompensated))
+		}
+		operations = appendBulkOperations(operations, UnitOperationDelete, map[TypeName][]interface{}{typeName: inserted})
+		operations = appendBulkOperations(operations, UnitOperationUpsert, map[TypeName][]interface{}{typeName: previous})
+	}
+	operations = appendBulkOperations(operations, UnitOperationDelete, u.inBulk(u.successfulInserts))
+	results, err := u.writeBulk(ctx, mCtx, operations)
+	for _, result := range results {
+		err = multierr.Append(err, result)
+	}
+	if err != nil {
+		u.logger.Error(err.Error())
+	}
+	return err
+}
@@ -21,7 +21,7 @@ import (
 	"fmt"
 	"sync"
 
-	"github.com/uber-go/tally/v4"
+	"go.uber.org/multierr"
 )
 
 type memoryCacheClient struct {
@@ -43,6 +43,20 @@ func (mcc *memoryCacheClient) Set(ctx context.Context, key string, entry interfa
 	return
 }
 
+func (mcc *memoryCacheClient) SetMulti(ctx context.Context, entries map[string]interface{}) (err error) {
+	for key, entry := range entries {
+		mcc.m.Store(key, entry)
+	}
+	return
+}
+
+func (mcc *memoryCacheClient) DeleteMulti(ctx context.Context, keys []string) (err error) {
+	for _, key := range keys {
+		mcc.m.Delete(key)
+	}
+	return
+}
+
 // UnitCacheClient represents a client for a cache provider.
 type UnitCacheClient interface {
 	Get(context.Context, string) (interface{}, error)
@@ -50,12 +64,23 @@ type UnitCacheClient interface {
 	Delete(context.Context, string) error
 }
 
+// UnitCacheMultiClient represents a client for a cache provider that is
+// capable of setting and deleting many entries in a single round trip. Cache
+// clients that implement it are detected automatically, and are used by work
+// units in place of individual calls to Set and Delete.
+type UnitCacheMultiClient interface {
+	UnitCacheClient
+	SetMulti(context.Context, map[string]interface{}) error
+	DeleteMulti(context.Context, []string) error
+}
+
 // UnitCache represents the cache that the work unit manipulates as a result
 // of entity registration.
 type UnitCache struct {
 	cc UnitCacheClient
 
-	scope tally.Scope
+	scope   unitScope
+	metrics UnitMetricNames
 }
 
 var (
@@ -70,11 +95,17 @@ func cacheKey(t TypeName, id interface{}) string {
 
 // Delete removes an entity from the work unit cache.
 func (uc *UnitCache) delete(ctx context.Context, entity interface{}) (err error) {
-	t := TypeNameOf(entity)
 	if id, ok := id(entity); ok {
-		if err = uc.cc.Delete(ctx, cacheKey(t, id)); err == nil {
-			uc.scope.Counter(cacheDelete).Inc(1)
-		}
+		err = uc.deleteByID(ctx, TypeNameOf(entity), id)
+	}
+	return
+}
+
+// deleteByID removes the entity with the provided type name and ID from the
+// work unit cache.
+func (uc *UnitCache) deleteByID(ctx context.Context, t TypeName, id interface{}) (err error) {
+	if err = uc.cc.Delete(ctx, cacheKey(t, id)); err == nil {
+		uc.scope.counter(uc.metrics.CacheDelete, 1)
 	}
 	return
 }
@@ -87,7 +118,90 @@ func (uc *UnitCache) store(ctx context.Context, entity interface{}) (err error)
 	}
 	t := TypeNameOf(entity)
 	if err = uc.cc.Set(ctx, cacheKey(t, id), entity); err == nil {
-		uc.scope.Counter(cacheInsert).Inc(1)
+		uc.scope.counter(uc.metrics.CacheInsert, 1)
+	}
+	return
+}
+
+// storeAll places the provided entities in the work unit cache, using a
+// single round trip when supported by the cache client. Entities with an
+// unresolvable ID are not cached.
+func (uc *UnitCache) storeAll(ctx context.Context, entities []interface{}) (err error) {
+	entries := make(map[string]interface{}, len(entities))
+	for _, entity := range entities {
+		id, ok := id(entity)
+		if !ok {
+			err = ErrUncachableEntity
+			continue
+		}
+		entries[cacheKey(TypeNameOf(entity), id)] = entity
+	}
+	return multierr.Append(err, uc.setEntries(ctx, entries))
+}
+
+// setEntries places the provided entries in the work unit cache, using a
+// single round trip when supported by the cache client.
+func (uc *UnitCache) setEntries(ctx context.Context, entries map[string]interface{}) (err error) {
+	if len(entries) == 0 {
+		return
+	}
+	if mc, ok := uc.cc.(UnitCacheMultiClient); ok {
+		if err = mc.SetMulti(ctx, entries); err != nil {
+			return
+		}
+		uc.scope.counter(uc.metrics.CacheInsert, int64(len(entries)))
+		return
+	}
+	for key, entry := range entries {
+		if setErr := uc.cc.Set(ctx, key, entry); setErr != nil {
+			err = multierr.Append(err, setErr)
+			continue
+		}
+		uc.scope.counter(uc.metrics.CacheInsert, 1)
+	}
+	return
+}
+
+// entityCacheKeys provides the cache keys of the provided entities. Entities
+// with an unresolvable ID are skipped.
+func entityCacheKeys(entities []interface{}) []string {
+	keys := make([]string, 0, len(entities))
+	for _, entity := range entities {
+		if id, ok := id(entity); ok {
+			keys = append(keys, cacheKey(TypeNameOf(entity), id))
+		}
+	}
+	return keys
+}
+
+// deleteAll removes the provided entities from the work unit cache, using a
+// single round trip when supported by the cache client.
+func (uc *UnitCache) deleteAll(ctx context.Context, entities []interface{}) (err error) {
+	_, err = uc.deleteKeys(ctx, entityCacheKeys(entities))
+	return
+}
+
+// deleteKeys removes the entries with the provided keys from the work unit
+// cache, using a single round trip when supported by the cache client. The
+// keys of the entries that could not be removed are provided.
+func (uc *UnitCache) deleteKeys(ctx context.Context, keys []string) (failed []string, err error) {
+	if len(keys) == 0 {
+		return
+	}
+	if mc, ok := uc.cc.(UnitCacheMultiClient); ok {
+		if err = mc.DeleteMulti(ctx, keys); err != nil {
+			return keys, err
+		}
+		uc.scope.counter(uc.metrics.CacheDelete, int64(len(keys)))
+		return
+	}
+	for _, key := range keys {
+		if deleteErr := uc.cc.Delete(ctx, key); deleteErr != nil {
+			failed = append(failed, key)
+			err = multierr.Append(err, deleteErr)
+			continue
+		}
+		uc.scope.counter(uc.metrics.CacheDelete, 1)
 	}
 	return
 }
@@ -0,0 +1,197 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package work
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"io"
+	"reflect"
+	"sync"
+)
+
+// ErrCacheDecryption represents the error that is returned when a cached
+// entity cannot be decrypted, such as when it was encrypted with a different
+// key.
+var ErrCacheDecryption = errors.New("unable to decrypt cached entity")
+
+// UnitCacheKeySource provides the AES key, being 16, 24, or 32 bytes, used
+// to encrypt and decrypt cached entities. It is invoked for each cache
+// operation, allowing keys to be retrieved from a secret store.
+type UnitCacheKeySource func(context.Context) ([]byte, error)
+
+// UnitStaticCacheKey provides the key source for the provided key.
+func UnitStaticCacheKey(key []byte) UnitCacheKeySource {
+	return func(context.Context) ([]byte, error) {
+		return key, nil
+	}
+}
+
+// encryptedCacheEntry represents the plaintext of an encrypted cache entry.
+type encryptedCacheEntry struct {
+	TypeName TypeName        `json:"type"`
+	Entity   json.RawMessage `json:"entity"`
+}
+
+// encryptedCacheClient represents a cache client that encrypts entities with
+// AES-GCM before handing them to the underlying cache client, and decrypts
+// them when they are retrieved. Entities are encoded as JSON, and are
+// decoded into the type they were cached with, when it is known within the
+// process, or provided as json.RawMessage otherwise.
+type encryptedCacheClient struct {
+	cc    UnitCacheClient
+	keys  UnitCacheKeySource
+	types sync.Map
+}
+
+// encryptedCacheMultiClient represents an encrypted cache client whose
+// underlying cache client supports setting and deleting many entries in a
+// single round trip.
+type encryptedCacheMultiClient struct {
+	*encryptedCacheClient
+	mc UnitCacheMultiClient
+}
+
+// encryptCache provides a cache client that encrypts the entities cached
+// with the provided cache client using the keys from the provided source.
+func encryptCache(cc UnitCacheClient, keys UnitCacheKeySource) UnitCacheClient {
+	ecc := &encryptedCacheClient{cc: cc, keys: keys}
+	if mc, ok := cc.(UnitCacheMultiClient); ok {
+		return &encryptedCacheMultiClient{encryptedCacheClient: ecc, mc: mc}
+	}
+	return ecc
+}
+
+func (ecc *encryptedCacheClient) aead(ctx context.Context) (cipher.AEAD, error) {
+	key, err := ecc.keys(ctx)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// encrypt encodes and encrypts the provided entity cached under the provided
+// key, with the nonce preceding the ciphertext. The key is authenticated as
+// additional data, and since cache keys are derived from the type name and
+// identity of entities, entries cannot be swapped between keys undetected.
+func (ecc *encryptedCacheClient) encrypt(ctx context.Context, key string, entity interface{}) ([]byte, error) {
+	t := TypeNameOf(entity)
+	ecc.types.Store(t, reflect.TypeOf(entity))
+	payload, err := json.Marshal(entity)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := json.Marshal(encryptedCacheEntry{TypeName: t, Entity: payload})
+	if err != nil {
+		return nil, err
+	}
+	aead, err := ecc.aead(ctx)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err = io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, plaintext, []byte(key)), nil
+}
+
+// decrypt decrypts and decodes the provided cache entry cached under the
+// provided key.
+func (ecc *encryptedCacheClient) decrypt(ctx context.Context, key string, entry interface{}) (interface{}, error) {
+	ciphertext, ok := entry.([]byte)
+	if !ok {
+		if s, isString := entry.(string); isString {
+			ciphertext, ok = []byte(s), true
+		}
+	}
+	if !ok {
+		return nil, ErrCacheDecryption
+	}
+	aead, err := ecc.aead(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(ciphertext) < aead.NonceSize() {
+		return nil, ErrCacheDecryption
+	}
+	nonce, sealed := ciphertext[:aead.NonceSize()], ciphertext[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, sealed, []byte(key))
+	if err != nil {
+		return nil, ErrCacheDecryption
+	}
+	var e encryptedCacheEntry
+	if err = json.Unmarshal(plaintext, &e); err != nil {
+		return nil, err
+	}
+	val, known := ecc.types.Load(e.TypeName)
+	if !known {
+		return e.Entity, nil
+	}
+	t := val.(reflect.Type)
+	if t.Kind() == reflect.Ptr {
+		entity := reflect.New(t.Elem())
+		err = json.Unmarshal(e.Entity, entity.Interface())
+		return entity.Interface(), err
+	}
+	entity := reflect.New(t)
+	err = json.Unmarshal(e.Entity, entity.Interface())
+	return entity.Elem().Interface(), err
+}
+
+func (ecc *encryptedCacheClient) Get(ctx context.Context, key string) (interface{}, error) {
+	entry, err := ecc.cc.Get(ctx, key)
+	if err != nil || entry == nil {
+		return entry, err
+	}
+	return ecc.decrypt(ctx, key, entry)
+}
+
+func (ecc *encryptedCacheClient) Set(ctx context.Context, key string, entity interface{}) error {
+	entry, err := ecc.encrypt(ctx, key, entity)
+	if err != nil {
+		return err
+	}
+	return ecc.cc.Set(ctx, key, entry)
+}
+
+func (ecc *encryptedCacheClient) Delete(ctx context.Context, key string) error {
+	return ecc.cc.Delete(ctx, key)
+}
+
+func (ecc *encryptedCacheMultiClient) SetMulti(ctx context.Context, entities map[string]interface{}) error {
+	entries := make(map[string]interface{}, len(entities))
+	for key, entity := range entities {
+		entry, err := ecc.encrypt(ctx, key, entity)
+		if err != nil {
+			return err
+		}
+		entries[key] = entry
+	}
+	return ecc.mc.SetMulti(ctx, entries)
+}
+
+func (ecc *encryptedCacheMultiClient) DeleteMulti(ctx context.Context, keys []string) error {
+	return ecc.mc.DeleteMulti(ctx, keys)
+}
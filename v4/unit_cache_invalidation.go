@@ -0,0 +1,162 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package work
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/avast/retry-go/v4"
+)
+
+// ErrCacheInvalidationQueueFull represents the error that is returned when a
+// failed cache invalidation cannot be retried in the background, as the
+// invalidations of the work unit awaiting retry are at capacity.
+var ErrCacheInvalidationQueueFull = errors.New("cache invalidation retry queue is full")
+
+// unitInvalidation tracks the cache invalidations of a work unit that await
+// retry. Keys are deduplicated and bounded, and are retried by a single
+// worker that only runs while keys are pending.
+type unitInvalidation struct {
+	mutex   sync.Mutex
+	pending map[string]struct{}
+	keys    []string
+	running bool
+	stopped bool
+	ctx     context.Context
+	cancel  context.CancelFunc
+}
+
+// invalidate removes the entries with the provided keys from the cache,
+// handling failures according to the cache failure mode of the work unit.
+// Invalidations that fail without failing the caller are retried in the
+// background when configured, unless too many of them await retry.
+func (u *unit) invalidate(ctx context.Context, keys []string) error {
+	failed, err := u.cached.deleteKeys(ctx, keys)
+	if err = u.cacheFailure(cacheOperationDelete, err, UnitCacheFailureModeFail); err != nil {
+		return err
+	}
+	if len(failed) > 0 && u.invalidationRetryAttempts > 0 {
+		if rejected := u.enqueueInvalidation(failed); rejected > 0 {
+			u.scope.counter(u.metrics.CacheInvalidationFailure, int64(rejected))
+			u.logger.Error(ErrCacheInvalidationQueueFull.Error(), "keys", rejected)
+			return ErrCacheInvalidationQueueFull
+		}
+	}
+	return nil
+}
+
+// enqueueInvalidation queues the provided keys for retry, starting the
+// worker retrying them when it is not running, and provides the number of
+// keys that could not be queued.
+func (u *unit) enqueueInvalidation(keys []string) (rejected int) {
+	q := &u.invalidation
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	if q.stopped {
+		return len(keys)
+	}
+	if q.pending == nil {
+		q.pending = make(map[string]struct{})
+		q.ctx, q.cancel = context.WithCancel(context.Background())
+	}
+	for _, key := range keys {
+		if _, ok := q.pending[key]; ok {
+			continue
+		}
+		if len(q.pending) >= u.invalidationQueueSize {
+			rejected = rejected + 1
+			continue
+		}
+		q.pending[key] = struct{}{}
+		q.keys = append(q.keys, key)
+	}
+	if !q.running && len(q.keys) > 0 {
+		q.running = true
+		go u.retryInvalidations(q.ctx)
+	}
+	return
+}
+
+// stopInvalidation stops retrying the cache invalidations of the work unit,
+// abandoning those awaiting retry.
+func (u *unit) stopInvalidation() {
+	q := &u.invalidation
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	if q.stopped {
+		return
+	}
+	q.stopped = true
+	if q.cancel != nil {
+		q.cancel()
+	}
+	if abandoned := len(q.keys); abandoned > 0 {
+		q.pending, q.keys = nil, nil
+		u.scope.counter(u.metrics.CacheInvalidationFailure, int64(abandoned))
+		u.logger.Warn("abandoned cache invalidation", "keys", abandoned)
+	}
+}
+
+// retryInvalidations retries the queued cache invalidations of the work unit
+// until none remain or the provided context is done. Retries are detached
+// from the context of the staging calls that queued them, as they have
+// likely completed.
+func (u *unit) retryInvalidations(ctx context.Context) {
+	q := &u.invalidation
+	for {
+		// the delay precedes each round of attempts.
+		t := time.NewTimer(u.invalidationRetryDelay)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+		case <-t.C:
+		}
+		q.mutex.Lock()
+		keys := q.keys
+		q.pending, q.keys = nil, nil
+		if len(keys) == 0 || ctx.Err() != nil {
+			q.running = false
+			q.mutex.Unlock()
+			return
+		}
+		q.pending = make(map[string]struct{})
+		q.mutex.Unlock()
+		u.retryInvalidation(ctx, keys)
+	}
+}
+
+// retryInvalidation retries removing the entries with the provided keys from
+// the cache, backing off between attempts.
+func (u *unit) retryInvalidation(ctx context.Context, keys []string) {
+	err := retry.Do(
+		func() (err error) {
+			u.scope.counter(u.metrics.CacheInvalidationRetry, 1)
+			keys, err = u.cached.deleteKeys(ctx, keys)
+			return
+		},
+		retry.Context(ctx),
+		retry.Attempts(uint(u.invalidationRetryAttempts)),
+		retry.Delay(u.invalidationRetryDelay),
+		retry.DelayType(retry.BackOffDelay),
+		retry.LastErrorOnly(true),
+	)
+	if err != nil {
+		u.scope.counter(u.metrics.CacheInvalidationFailure, int64(len(keys)))
+		u.logger.Warn("abandoned cache invalidation", "keys", len(keys), "error", err.Error())
+	}
+}
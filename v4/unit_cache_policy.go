@@ -0,0 +1,76 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package work
+
+// UnitCacheFailureMode represents how work units behave when the cache client
+// fails to set or delete cached entities.
+type UnitCacheFailureMode int
+
+const (
+	// UnitCacheFailureModeIgnore represents discarding cache failures,
+	// counting them without logging.
+	UnitCacheFailureModeIgnore UnitCacheFailureMode = iota + 1
+	// UnitCacheFailureModeWarn represents counting cache failures and logging
+	// them as warnings.
+	UnitCacheFailureModeWarn
+	// UnitCacheFailureModeFail represents counting cache failures and
+	// returning them to the caller.
+	UnitCacheFailureModeFail
+)
+
+// String provides the name of the cache failure mode.
+func (m UnitCacheFailureMode) String() string {
+	switch m {
+	case UnitCacheFailureModeIgnore:
+		return "ignore"
+	case UnitCacheFailureModeWarn:
+		return "warn"
+	case UnitCacheFailureModeFail:
+		return "fail"
+	default:
+		return "unknown"
+	}
+}
+
+// Cache operation tag values.
+const (
+	cacheOperationSet    = "set"
+	cacheOperationDelete = "delete"
+	cacheOperationGet    = "get"
+)
+
+// cacheFailure handles the provided failure of the provided cache operation
+// according to the cache failure mode of the work unit, falling back to the
+// provided mode when none has been specified. The error is returned only when
+// the mode dictates that it fails the caller.
+func (u *unit) cacheFailure(operation string, err error, fallback UnitCacheFailureMode) error {
+	if err == nil {
+		return nil
+	}
+	u.scope.tagged(map[string]string{"operation": operation}).counter(u.metrics.CacheError, 1)
+	mode := u.cacheFailureMode
+	if mode == 0 {
+		mode = fallback
+	}
+	switch mode {
+	case UnitCacheFailureModeIgnore:
+		return nil
+	case UnitCacheFailureModeWarn:
+		u.logger.Warn("cache operation failed", "operation", operation, "error", err.Error())
+		return nil
+	default:
+		return err
+	}
+}
@@ -21,7 +21,6 @@ import (
 
 	"github.com/freerware/work/v4/internal/test"
 	"github.com/stretchr/testify/suite"
-	"github.com/uber-go/tally/v4"
 )
 
 type UnitCacheTestSuite struct {
@@ -36,7 +35,7 @@ func TestUnitCacheTestSuite(t *testing.T) {
 }
 
 func (s *UnitCacheTestSuite) SetupTest() {
-	s.sut = UnitCache{cc: &memoryCacheClient{}, scope: tally.NoopScope}
+	s.sut = UnitCache{cc: &memoryCacheClient{}}
 }
 
 func (s *UnitCacheTestSuite) TestUnitCache_Delete() {
@@ -55,6 +54,43 @@ func (s *UnitCacheTestSuite) TestUnitCache_Delete() {
 	s.Nil(cached)
 }
 
+func (s *UnitCacheTestSuite) TestUnitCache_StoreAll() {
+	// arrange.
+	ctx := context.Background()
+	baz, bar, biz := test.Baz{Identifier: "1"}, test.Bar{ID: "2"}, test.Biz{}
+
+	// action.
+	err := s.sut.storeAll(ctx, []interface{}{baz, bar, biz})
+
+	// assert.
+	s.ErrorIs(err, ErrUncachableEntity)
+	cachedBaz, err := s.sut.Load(ctx, TypeNameOf(baz), baz.ID())
+	s.NoError(err)
+	s.Equal(baz, cachedBaz)
+	cachedBar, err := s.sut.Load(ctx, TypeNameOf(bar), bar.ID)
+	s.NoError(err)
+	s.Equal(bar, cachedBar)
+}
+
+func (s *UnitCacheTestSuite) TestUnitCache_DeleteAll() {
+	// arrange.
+	ctx := context.Background()
+	baz, bar := test.Baz{Identifier: "1"}, test.Bar{ID: "2"}
+	s.Require().NoError(s.sut.storeAll(ctx, []interface{}{baz, bar}))
+
+	// action.
+	err := s.sut.deleteAll(ctx, []interface{}{baz, bar})
+
+	// assert.
+	s.NoError(err)
+	cachedBaz, err := s.sut.Load(ctx, TypeNameOf(baz), baz.ID())
+	s.NoError(err)
+	s.Nil(cachedBaz)
+	cachedBar, err := s.sut.Load(ctx, TypeNameOf(bar), bar.ID)
+	s.NoError(err)
+	s.Nil(cachedBar)
+}
+
 func (s *UnitCacheTestSuite) TestUnitCache_Load_Exists() {
 	// arrange.
 	ctx := context.Background()
@@ -155,3 +191,38 @@ func (s *UnitCacheTestSuite) TestUnitCache_Store_UncachableEntityError() {
 	s.Error(err)
 	s.ErrorIs(err, ErrUncachableEntity)
 }
+
+func (s *UnitCacheTestSuite) TestUnitCache_Encryption() {
+	// arrange.
+	ctx := context.Background()
+	key := []byte("0123456789abcdef0123456789abcdef")
+	mcc := &memoryCacheClient{}
+	s.sut = UnitCache{cc: encryptCache(mcc, UnitStaticCacheKey(key))}
+	bar, baz := test.Bar{ID: "2"}, &test.Baz{Identifier: "1"}
+
+	// action.
+	err := s.sut.storeAll(ctx, []interface{}{bar, baz})
+
+	// assert.
+	s.Require().NoError(err)
+	raw, _ := mcc.Get(ctx, cacheKey(TypeNameOf(bar), bar.ID))
+	s.IsType([]byte{}, raw)
+	s.NotContains(string(raw.([]byte)), `"2"`)
+	cachedBar, err := s.sut.Load(ctx, TypeNameOf(bar), bar.ID)
+	s.NoError(err)
+	s.Equal(bar, cachedBar)
+	cachedBaz, err := s.sut.Load(ctx, TypeNameOf(baz), baz.ID())
+	s.NoError(err)
+	s.Equal(baz, cachedBaz)
+
+	// entities encrypted with other keys cannot be decrypted.
+	other := encryptCache(mcc, UnitStaticCacheKey([]byte("fedcba9876543210fedcba9876543210")))
+	_, err = other.Get(ctx, cacheKey(TypeNameOf(bar), bar.ID))
+	s.ErrorIs(err, ErrCacheDecryption)
+
+	// entries swapped between keys cannot be decrypted.
+	swapped := cacheKey(TypeNameOf(bar), "3")
+	s.Require().NoError(mcc.Set(ctx, swapped, raw))
+	_, err = s.sut.Load(ctx, TypeNameOf(bar), "3")
+	s.ErrorIs(err, ErrCacheDecryption)
+}
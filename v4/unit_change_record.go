@@ -0,0 +1,165 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package work
+
+import (
+	"context"
+	"time"
+)
+
+// UnitChangeOperation represents the type of change captured by a change
+// record, following the conventions established by Debezium.
+type UnitChangeOperation string
+
+// The various types of changes captured by change records.
+const (
+	// UnitChangeOperationCreate indicates an entity that was created.
+	UnitChangeOperationCreate UnitChangeOperation = "c"
+	// UnitChangeOperationUpdate indicates an entity that was updated.
+	UnitChangeOperationUpdate UnitChangeOperation = "u"
+	// UnitChangeOperationDelete indicates an entity that was deleted.
+	UnitChangeOperationDelete UnitChangeOperation = "d"
+)
+
+// UnitChangeRecord represents a single mutation committed by a work unit.
+type UnitChangeRecord struct {
+	// Op is the type of change.
+	Op UnitChangeOperation
+	// TypeName is the type name of the entity that was changed.
+	TypeName TypeName
+	// Before is the state of the entity prior to the change, which is the
+	// registered entity sharing the same identity, if any.
+	Before interface{}
+	// After is the state of the entity after the change, which is nil for
+	// deletions. For patches, After is the UnitPatch applied.
+	After interface{}
+	// TxID identifies the save of the work unit that committed the change,
+	// which is shared by all changes committed together.
+	TxID string
+	// Timestamp is the time at which the change was committed.
+	Timestamp time.Time
+}
+
+// UnitChangeRecordSink represents a destination for change records, such as
+// a message broker.
+type UnitChangeRecordSink interface {
+	// Emit publishes the provided change records.
+	Emit(context.Context, []UnitChangeRecord) error
+}
+
+// registeredAs provides the registered entity sharing the same identity as
+// the entity provided, if any.
+func (u *unit) registeredAs(entity interface{}) interface{} {
+	for _, e := range u.registered[TypeNameOf(entity)] {
+		if sameIdentity(e, entity) {
+			return e
+		}
+	}
+	return nil
+}
+
+// registeredWithID provides the registered entity with the provided type
+// name and identity, if any.
+func (u *unit) registeredWithID(t TypeName, entityID interface{}) interface{} {
+	for _, e := range u.registered[t] {
+		if eID, ok := id(e); ok && cacheKey(t, eID) == cacheKey(t, entityID) {
+			return e
+		}
+	}
+	return nil
+}
+
+func (u *unit) changeRecords(txID string) []UnitChangeRecord {
+	u.mutex.RLock()
+	defer u.mutex.RUnlock()
+
+	now := time.Now()
+	var records []UnitChangeRecord
+	record := func(op UnitChangeOperation, t TypeName, before, after interface{}) {
+		records = append(records, UnitChangeRecord{
+			Op:        op,
+			TypeName:  t,
+			Before:    before,
+			After:     after,
+			TxID:      txID,
+			Timestamp: now,
+		})
+	}
+	for t, entities := range u.additions {
+		for _, entity := range entities {
+			if u.isQuarantined(t, entity) {
+				continue
+			}
+			record(UnitChangeOperationCreate, t, nil, entity)
+		}
+	}
+	for t, entities := range u.upserts {
+		for _, entity := range entities {
+			if u.isQuarantined(t, entity) {
+				continue
+			}
+			before := u.registeredAs(entity)
+			op := UnitChangeOperationUpdate
+			if before == nil {
+				op = UnitChangeOperationCreate
+			}
+			record(op, t, before, entity)
+		}
+	}
+	for t, entities := range u.alterations {
+		for _, entity := range entities {
+			if u.isQuarantined(t, entity) {
+				continue
+			}
+			record(UnitChangeOperationUpdate, t, u.registeredAs(entity), entity)
+		}
+	}
+	for t, patches := range u.patches {
+		for _, p := range patches {
+			record(UnitChangeOperationUpdate, t, u.registeredWithID(t, p.ID), p)
+		}
+	}
+	for t, entities := range u.removals {
+		for _, entity := range entities {
+			if u.isQuarantined(t, entity) {
+				continue
+			}
+			before := u.registeredAs(entity)
+			if before == nil {
+				before = entity
+			}
+			record(UnitChangeOperationDelete, t, before, nil)
+		}
+	}
+	return records
+}
+
+// emitChangeRecords publishes the change records for the mutations committed
+// by the work unit. Since the mutations have already been committed, failures
+// are logged rather than returned.
+func (u *unit) emitChangeRecords(ctx context.Context, txID string) {
+	if u.changeRecordSink == nil {
+		return
+	}
+	records := u.changeRecords(txID)
+	if len(records) == 0 {
+		return
+	}
+	if err := u.changeRecordSink.Emit(ctx, records); err != nil {
+		u.scope.counter(u.metrics.ChangeRecordFailure, 1)
+		u.logger.Error("unable to emit change records", "error", err.Error(), "txID", txID)
+	}
+}
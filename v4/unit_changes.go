@@ -0,0 +1,61 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package work
+
+// UnitChanges represents a snapshot of the entity changes staged within a
+// work unit, organized by type name.
+type UnitChanges struct {
+	// Additions are the entities indicated as new.
+	Additions map[TypeName][]interface{}
+	// Alterations are the entities indicated as modified.
+	Alterations map[TypeName][]interface{}
+	// Removals are the entities indicated as removed.
+	Removals map[TypeName][]interface{}
+	// Upserts are the entities indicated as upserted.
+	Upserts map[TypeName][]interface{}
+	// Patches are the partial updates indicated.
+	Patches map[TypeName][]UnitPatch
+}
+
+func copyEntities(m map[TypeName][]interface{}) map[TypeName][]interface{} {
+	c := make(map[TypeName][]interface{}, len(m))
+	for t, entities := range m {
+		if len(entities) == 0 {
+			continue
+		}
+		c[t] = append([]interface{}(nil), entities...)
+	}
+	return c
+}
+
+func (u *unit) changes() UnitChanges {
+	u.mutex.RLock()
+	defer u.mutex.RUnlock()
+	patches := make(map[TypeName][]UnitPatch, len(u.patches))
+	for t, p := range u.patches {
+		if len(p) == 0 {
+			continue
+		}
+		patches[t] = append([]UnitPatch(nil), p...)
+	}
+	return UnitChanges{
+		Additions:   copyEntities(u.additions),
+		Alterations: copyEntities(u.alterations),
+		Removals:    copyEntities(u.removals),
+		Upserts:     copyEntities(u.upserts),
+		Patches:     patches,
+	}
+}
@@ -0,0 +1,119 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package work
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// ErrMissingCodec represents the error that is returned when serializing an
+// entity whose type has no codec, and no default codec is provided.
+var ErrMissingCodec = errors.New("missing codec for entity type")
+
+// UnitCodec represents a type responsible for serializing entities into
+// payloads for external systems, such as webhooks, audit logs, or event
+// buses.
+type UnitCodec interface {
+	// ContentType provides the media type of the payloads, such as
+	// "application/json".
+	ContentType() string
+	// Encode serializes the provided entity.
+	Encode(entity interface{}) ([]byte, error)
+}
+
+type jsonCodec struct{}
+
+func (jsonCodec) ContentType() string {
+	return "application/json"
+}
+
+func (jsonCodec) Encode(entity interface{}) ([]byte, error) {
+	return json.Marshal(entity)
+}
+
+// UnitJSONCodec is the codec serializing entities as JSON.
+var UnitJSONCodec UnitCodec = jsonCodec{}
+
+// UnitPayload represents a serialized entity.
+type UnitPayload struct {
+	// TypeName is the type name of the entity serialized.
+	TypeName TypeName
+	// ContentType is the media type of the data, as provided by the codec.
+	ContentType string
+	// Data is the serialized entity.
+	Data []byte
+}
+
+// UnitPayloads represents the serialized entities staged within a work unit.
+// Patches are not serialized, since their fields are already provided as
+// plain values by UnitActionContext.Changes.
+type UnitPayloads struct {
+	// Additions are the entities indicated as new.
+	Additions map[TypeName][]UnitPayload
+	// Alterations are the entities indicated as modified.
+	Alterations map[TypeName][]UnitPayload
+	// Removals are the entities indicated as removed.
+	Removals map[TypeName][]UnitPayload
+	// Upserts are the entities indicated as upserted.
+	Upserts map[TypeName][]UnitPayload
+}
+
+// codec provides the codec for entities of the provided type, falling back
+// to the default codec.
+func (u *unit) codec(t TypeName) (UnitCodec, error) {
+	if c, ok := u.codecs[t]; ok {
+		return c, nil
+	}
+	if u.defaultCodec != nil {
+		return u.defaultCodec, nil
+	}
+	return nil, fmt.Errorf("%w: %s", ErrMissingCodec, t)
+}
+
+// encode serializes the provided entity with the codec for its type.
+func encode(codec func(TypeName) (UnitCodec, error), entity interface{}) (UnitPayload, error) {
+	t := TypeNameOf(entity)
+	if codec == nil {
+		return UnitPayload{}, fmt.Errorf("%w: %s", ErrMissingCodec, t)
+	}
+	c, err := codec(t)
+	if err != nil {
+		return UnitPayload{}, err
+	}
+	data, err := c.Encode(entity)
+	if err != nil {
+		return UnitPayload{}, fmt.Errorf("unable to encode entity of type %s: %w", t, err)
+	}
+	return UnitPayload{TypeName: t, ContentType: c.ContentType(), Data: data}, nil
+}
+
+// encodeAll serializes the provided entities by type with the codecs for
+// their types.
+func encodeAll(codec func(TypeName) (UnitCodec, error), m map[TypeName][]interface{}) (map[TypeName][]UnitPayload, error) {
+	payloads := make(map[TypeName][]UnitPayload, len(m))
+	for t, entities := range m {
+		for _, entity := range entities {
+			p, err := encode(codec, entity)
+			if err != nil {
+				return nil, err
+			}
+			payloads[t] = append(payloads[t], p)
+		}
+	}
+	return payloads, nil
+}
@@ -0,0 +1,124 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package work
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"time"
+)
+
+// UnitCommitTokenizer captures tokens identifying the position of committed
+// saves within the data store, and awaits those positions on replicas,
+// allowing work units spanning read replicas to read their own writes.
+type UnitCommitTokenizer interface {
+	// Token captures the token for the most recently committed save.
+	Token(context.Context, *sql.DB) (string, error)
+	// Await blocks until the data store has applied the position identified
+	// by the provided token, or the context is done.
+	Await(context.Context, *sql.DB, string) error
+}
+
+// UnitPostgresCommitTokenizer captures and awaits Postgres write-ahead log
+// positions.
+type UnitPostgresCommitTokenizer struct {
+	// PollInterval is the delay between checks of the replica's replay
+	// position, which defaults to 10 milliseconds.
+	PollInterval time.Duration
+}
+
+// Token captures the current write-ahead log position of the primary.
+func (t UnitPostgresCommitTokenizer) Token(ctx context.Context, db *sql.DB) (token string, err error) {
+	err = db.QueryRowContext(ctx, "SELECT pg_current_wal_lsn()::text").Scan(&token)
+	return
+}
+
+// Await blocks until the replica has replayed the write-ahead log up to the
+// position identified by the provided token. Primaries, which do not replay
+// the write-ahead log, are considered caught up.
+func (t UnitPostgresCommitTokenizer) Await(ctx context.Context, db *sql.DB, token string) error {
+	interval := t.PollInterval
+	if interval <= 0 {
+		interval = 10 * time.Millisecond
+	}
+	query := "SELECT COALESCE(pg_last_wal_replay_lsn() >= $1::pg_lsn, true)"
+	for {
+		var caughtUp bool
+		if err := db.QueryRowContext(ctx, query, token).Scan(&caughtUp); err != nil {
+			return err
+		}
+		if caughtUp {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
+// unitCommitTokens tracks the commit tokens of a work unit.
+type unitCommitTokens struct {
+	mutex     sync.Mutex
+	tokenizer UnitCommitTokenizer
+	committed string
+	awaited   string
+	await     string
+}
+
+// CommitToken provides the token for the most recent successful save of the
+// work unit, which is empty unless the work.UnitCommitTokens option is used.
+func (u *unit) CommitToken() string {
+	u.tokens.mutex.Lock()
+	defer u.tokens.mutex.Unlock()
+	return u.tokens.committed
+}
+
+// AwaitToken blocks until the data store has applied the position identified
+// by the token provided with the work.UnitWaitForToken option, if any.
+func (u *unit) AwaitToken(ctx context.Context) error {
+	u.tokens.mutex.Lock()
+	defer u.tokens.mutex.Unlock()
+	t := &u.tokens
+	if t.tokenizer == nil || u.db == nil || t.await == "" || t.awaited == t.await {
+		return nil
+	}
+	if err := t.tokenizer.Await(ctx, u.db, t.await); err != nil {
+		u.logger.Error(err.Error(), "token", t.await)
+		return err
+	}
+	t.awaited = t.await
+	return nil
+}
+
+// captureCommitToken captures the token for the save that was just
+// committed. Since the save has already been committed, failures are logged
+// rather than returned.
+func (u *unit) captureCommitToken(ctx context.Context) {
+	u.tokens.mutex.Lock()
+	defer u.tokens.mutex.Unlock()
+	if u.tokens.tokenizer == nil || u.db == nil {
+		return
+	}
+	token, err := u.tokens.tokenizer.Token(ctx, u.db)
+	if err != nil {
+		u.logger.Warn("unable to capture commit token", "error", err.Error())
+		return
+	}
+	u.tokens.committed = token
+}
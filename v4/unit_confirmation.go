@@ -0,0 +1,141 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package work
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// ErrConfirmationRequired represents the error that is returned when saving
+// a work unit staging more changes for a type than its mutation guard
+// permits, without confirming the save.
+var ErrConfirmationRequired = errors.New("save requires confirmation")
+
+// UnitMutationSummary describes the changes staged within a work unit for a
+// type exceeding its mutation limit.
+type UnitMutationSummary struct {
+	// TypeName is the type whose changes exceed the limit.
+	TypeName TypeName
+	// Count is the number of changes staged for the type.
+	Count int
+	// Limit is the maximum number of changes permitted for the type.
+	Limit int
+}
+
+// UnitConfirmationError represents the error that is returned when saving a
+// work unit requires confirmation, providing the token to confirm the save
+// with and a summary of the changes that require it.
+type UnitConfirmationError struct {
+	// Token is the token to provide to Confirm when saving the work unit
+	// again.
+	Token string
+	// Summary describes the types exceeding their mutation limits, sorted by
+	// type name.
+	Summary []UnitMutationSummary
+}
+
+// Error provides the error message.
+func (e *UnitConfirmationError) Error() string {
+	changes := make([]string, 0, len(e.Summary))
+	for _, s := range e.Summary {
+		changes = append(changes, fmt.Sprintf("%d changes staged for %s exceeds the limit of %d",
+			s.Count, s.TypeName, s.Limit))
+	}
+	return fmt.Sprintf("%s: %s", ErrConfirmationRequired, strings.Join(changes, ", "))
+}
+
+// Unwrap provides ErrConfirmationRequired, so that the error can be
+// identified with errors.Is.
+func (e *UnitConfirmationError) Unwrap() error {
+	return ErrConfirmationRequired
+}
+
+// UnitSaveOption represents an option for a single save of a work unit.
+type UnitSaveOption func(*unitSaveOptions)
+
+// unitSaveOptions represents the options for a single save of a work unit.
+type unitSaveOptions struct {
+	confirmation string
+}
+
+// newUnitSaveOptions applies the provided save options.
+func newUnitSaveOptions(opts []UnitSaveOption) unitSaveOptions {
+	var o unitSaveOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// Confirm provides the save option to confirm saving a work unit with the
+// token from the UnitConfirmationError returned by the previous attempt to
+// save it.
+func Confirm(token string) UnitSaveOption {
+	return func(o *unitSaveOptions) {
+		o.confirmation = token
+	}
+}
+
+// unitConfirmation tracks the confirmation pending for a work unit whose
+// saves exceeding mutation limits require confirmation.
+type unitConfirmation struct {
+	mutex   sync.Mutex
+	token   string
+	summary []UnitMutationSummary
+}
+
+// mutationConfirmation provides the confirmation tracking for work units
+// requiring confirmation of saves exceeding mutation limits, or nil when
+// they are rejected instead.
+func (uo *UnitOptions) mutationConfirmation() *unitConfirmation {
+	if !uo.confirmMutations {
+		return nil
+	}
+	return &unitConfirmation{}
+}
+
+// confirm determines if the provided token confirms saving the changes
+// described by the provided summary, providing a UnitConfirmationError with
+// a new token when it does not. A token only confirms the save it was issued
+// for, and is invalidated should the number of changes staged for the types
+// exceeding their limits differ.
+func (c *unitConfirmation) confirm(token string, summary []UnitMutationSummary) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if token != "" && token == c.token && sameMutationSummary(c.summary, summary) {
+		c.token, c.summary = "", nil
+		return nil
+	}
+	c.token, c.summary = uuid.NewString(), summary
+	return &UnitConfirmationError{Token: c.token, Summary: summary}
+}
+
+// sameMutationSummary determines if the provided summaries are identical.
+func sameMutationSummary(a, b []UnitMutationSummary) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,304 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package work
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrConflict represents the error that is returned when a conflict is
+	// not resolved.
+	ErrConflict = errors.New("unresolved conflict")
+
+	// ErrStaleEntity represents the error that data mappers return, by way of
+	// UnitStaleEntityError, when an entity cannot be written because its
+	// persisted version has changed since it was read, such as when
+	// leveraging optimistic locking.
+	ErrStaleEntity = errors.New("stale entity")
+)
+
+// UnitResolutionChoice represents the manner in which a conflict is resolved.
+type UnitResolutionChoice int
+
+const (
+	// UnitResolutionFail fails upon the conflict.
+	UnitResolutionFail UnitResolutionChoice = iota
+	// UnitResolutionOurs resolves the conflict in favor of the entity being
+	// staged or written.
+	UnitResolutionOurs
+	// UnitResolutionTheirs resolves the conflict in favor of the entity that
+	// is already staged or persisted.
+	UnitResolutionTheirs
+	// UnitResolutionMerge resolves the conflict in favor of a merged entity.
+	UnitResolutionMerge
+)
+
+// UnitResolution represents the resolution of a conflict.
+type UnitResolution struct {
+	// Choice is the manner in which the conflict is resolved.
+	Choice UnitResolutionChoice
+	// Merged is the merged entity, when the conflict is resolved by merging.
+	Merged interface{}
+}
+
+// UnitConflict represents a conflict between entities sharing the same
+// identity, either between operations staged within a work unit, or between
+// an entity being written and its persisted state.
+type UnitConflict struct {
+	// TypeName is the type of the conflicting entities.
+	TypeName TypeName
+	// Ours is the entity being staged or written.
+	Ours interface{}
+	// OursState is the state the entity is being staged in.
+	OursState EntityState
+	// Theirs is the entity already staged, or the persisted state of the
+	// entity when stale.
+	Theirs interface{}
+	// TheirsState is the state the entity is already staged in, or
+	// EntityStateRegistered when stale.
+	TheirsState EntityState
+	// Stale indicates whether the conflict is due to the persisted version of
+	// the entity having changed since it was read.
+	Stale bool
+}
+
+// UnitConflictResolverFunc resolves the provided conflict.
+type UnitConflictResolverFunc func(UnitConflict) UnitResolution
+
+// UnitConflictError represents the error that is returned when a conflict is
+// not resolved.
+type UnitConflictError struct {
+	// Conflict is the unresolved conflict.
+	Conflict UnitConflict
+}
+
+// Error provides the error message.
+func (e *UnitConflictError) Error() string {
+	if e.Conflict.Stale {
+		return fmt.Sprintf("%v: stale %s", ErrConflict, e.Conflict.TypeName)
+	}
+	return fmt.Sprintf("%v: %s staged as %s is being staged as %s", ErrConflict,
+		e.Conflict.TypeName, e.Conflict.TheirsState, e.Conflict.OursState)
+}
+
+// Unwrap provides ErrConflict.
+func (e *UnitConflictError) Unwrap() error {
+	return ErrConflict
+}
+
+// UnitStaleEntityError represents the error that data mappers return when an
+// entity cannot be written because its persisted version has changed since
+// it was read. Data mappers return it without having applied any of the
+// entities they were provided, so that the entities can be applied again
+// once the conflict is resolved.
+type UnitStaleEntityError struct {
+	// Entity is the stale entity.
+	Entity interface{}
+	// Current is the persisted state of the entity, if known.
+	Current interface{}
+}
+
+// Error provides the error message.
+func (e *UnitStaleEntityError) Error() string {
+	return fmt.Sprintf("%v: %s", ErrStaleEntity, TypeNameOf(e.Entity))
+}
+
+// Unwrap provides ErrStaleEntity.
+func (e *UnitStaleEntityError) Unwrap() error {
+	return ErrStaleEntity
+}
+
+// conflicting determines if staging an entity in the provided state conflicts
+// with the same identity already being staged in the provided state.
+func conflicting(staged, staging EntityState) bool {
+	if staged == staging || staged == EntityStateRegistered {
+		return false
+	}
+	// altering an entity that is added or upserted refines it.
+	return staging != EntityStateAltered ||
+		(staged != EntityStateAdded && staged != EntityStateUpserted)
+}
+
+// staged provides the entities staged in the provided state, along with the
+// count of them. The lock of the work unit must be held.
+func (u *unit) staged(state EntityState) (map[TypeName][]interface{}, *int) {
+	switch state {
+	case EntityStateAdded:
+		return u.additions, &u.additionCount
+	case EntityStateAltered:
+		return u.alterations, &u.alterationCount
+	case EntityStateUpserted:
+		return u.upserts, &u.upsertCount
+	default:
+		return u.removals, &u.removalCount
+	}
+}
+
+// resolveStaged resolves the conflicts between staging the provided entity
+// in the provided state and the operations already staged for its identity,
+// providing the entity to stage and whether to stage it. The lock of the
+// work unit must be held, and is released while the conflict resolver is
+// invoked, such that the resolver can use the work unit.
+func (u *unit) resolveStaged(state EntityState, entity interface{}) (interface{}, bool, error) {
+	if u.conflictResolver == nil {
+		return entity, true, nil
+	}
+	ours := entity
+	var conflicts []UnitConflict
+	for _, stagedState := range []EntityState{
+		EntityStateAdded, EntityStateAltered, EntityStateUpserted, EntityStateRemoved,
+	} {
+		entities, _ := u.staged(stagedState)
+		if !conflicting(stagedState, state) || !contains(entities, ours) {
+			continue
+		}
+		var theirs interface{}
+		for _, e := range entities[TypeNameOf(ours)] {
+			if sameIdentity(e, ours) {
+				theirs = e
+			}
+		}
+		conflicts = append(conflicts, UnitConflict{
+			TypeName:    TypeNameOf(ours),
+			Ours:        ours,
+			OursState:   state,
+			Theirs:      theirs,
+			TheirsState: stagedState,
+		})
+	}
+	if len(conflicts) == 0 {
+		return entity, true, nil
+	}
+	u.mutex.Unlock()
+	resolutions := make([]UnitResolution, 0, len(conflicts))
+	for _, conflict := range conflicts {
+		r := u.conflictResolver(conflict)
+		resolutions = append(resolutions, r)
+		if r.Choice != UnitResolutionOurs && r.Choice != UnitResolutionMerge {
+			break
+		}
+	}
+	u.mutex.Lock()
+	for i, r := range resolutions {
+		switch r.Choice {
+		case UnitResolutionOurs:
+		case UnitResolutionMerge:
+			entity = r.Merged
+		case UnitResolutionTheirs:
+			return nil, false, nil
+		default:
+			err := &UnitConflictError{Conflict: conflicts[i]}
+			u.logger.Error(err.Error(), "typeName", conflicts[i].TypeName.String())
+			return nil, false, err
+		}
+		entities, count := u.staged(conflicts[i].TheirsState)
+		*count = *count - len(take(entities, ours))
+	}
+	return entity, true, nil
+}
+
+// resolving provides the data mapper function that resolves the conflicts
+// reported by the provided data mapper function for stale entities, applying
+// the entities again once resolved. Entities resolved in favor of ours are
+// applied again separately, with a mapper context indicating that they are
+// forced.
+func (u *unit) resolving(t TypeName, f UnitDataMapperFunc) UnitDataMapperFunc {
+	if u.conflictResolver == nil {
+		return f
+	}
+	return func(ctx context.Context, mCtx UnitMapperContext, entities ...interface{}) error {
+		remaining := entities
+		var forced []interface{}
+		for i := 0; ; i++ {
+			err := u.applyForced(ctx, mCtx, f, &remaining, forced)
+			var stale *UnitStaleEntityError
+			if !errors.As(err, &stale) || i == len(entities) {
+				return err
+			}
+			conflict := UnitConflict{
+				TypeName:    t,
+				Ours:        stale.Entity,
+				Theirs:      stale.Current,
+				TheirsState: EntityStateRegistered,
+				Stale:       true,
+			}
+			if state, ok := u.StateOf(stale.Entity); ok {
+				conflict.OursState = state
+			}
+			r := u.conflictResolver(conflict)
+			resolved := make([]interface{}, 0, len(remaining))
+			for _, e := range remaining {
+				if !sameIdentity(e, stale.Entity) {
+					resolved = append(resolved, e)
+					continue
+				}
+				switch r.Choice {
+				case UnitResolutionOurs:
+					forced = append(forced, e)
+					resolved = append(resolved, e)
+				case UnitResolutionMerge:
+					resolved = append(resolved, r.Merged)
+				case UnitResolutionTheirs:
+				default:
+					u.logger.Error(ErrConflict.Error(), "typeName", t.String())
+					return &UnitConflictError{Conflict: conflict}
+				}
+			}
+			if remaining = resolved; len(remaining) == 0 {
+				return nil
+			}
+		}
+	}
+}
+
+// applyForced applies the provided remaining entities with the provided data
+// mapper function, applying those among the provided forced entities
+// separately with a mapper context indicating that they are forced. Entities
+// that are applied are removed from those remaining.
+func (u *unit) applyForced(
+	ctx context.Context,
+	mCtx UnitMapperContext,
+	f UnitDataMapperFunc,
+	remaining *[]interface{},
+	forced []interface{},
+) error {
+	var unforced, force []interface{}
+	for _, e := range *remaining {
+		if indexOf(forced, e) >= 0 {
+			force = append(force, e)
+		} else {
+			unforced = append(unforced, e)
+		}
+	}
+	if len(unforced) > 0 || len(force) == 0 {
+		if err := f(ctx, mCtx, unforced...); err != nil {
+			return err
+		}
+		*remaining = force
+	}
+	if len(force) > 0 {
+		fCtx := mCtx
+		fCtx.forced = true
+		if err := f(ctx, fCtx, force...); err != nil {
+			return err
+		}
+		*remaining = nil
+	}
+	return nil
+}
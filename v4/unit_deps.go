@@ -0,0 +1,69 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package work
+
+import "database/sql"
+
+// UnitDeps represents the dependencies of a work unit, as an alternative to
+// the variadic unit options for dependency injection tools such as
+// github.com/google/wire, which struggle to express option slices. Zero
+// values retain the defaults of the work unit.
+type UnitDeps struct {
+	// DB is the database for the work unit.
+	DB *sql.DB
+	// Logger is the logger for the work unit.
+	Logger UnitLogger
+	// Metrics is the metrics collector for the work unit.
+	Metrics UnitMetricsCollector
+	// Mappers are the data mappers for the work unit.
+	Mappers map[TypeName]UnitDataMapper
+	// Cache is the cache client for the work unit.
+	Cache UnitCacheClient
+	// Options are the additional unit options for the work unit, applied
+	// after the dependencies.
+	Options []UnitOption
+}
+
+// options provides the unit options describing the dependencies.
+func (d UnitDeps) options() []UnitOption {
+	var opts []UnitOption
+	if d.DB != nil {
+		opts = append(opts, UnitDB(d.DB))
+	}
+	if d.Logger != nil {
+		opts = append(opts, UnitWithLogger(d.Logger))
+	}
+	if d.Metrics != nil {
+		opts = append(opts, UnitWithMetricsCollector(d.Metrics))
+	}
+	if len(d.Mappers) > 0 {
+		opts = append(opts, UnitDataMappers(d.Mappers))
+	}
+	if d.Cache != nil {
+		opts = append(opts, UnitWithCacheClient(d.Cache))
+	}
+	return append(opts, d.Options...)
+}
+
+// NewUnitFromDeps creates a new work unit with the provided dependencies.
+func NewUnitFromDeps(deps UnitDeps) (Unit, error) {
+	return NewUnit(deps.options()...)
+}
+
+// NewUniterFromDeps creates a new uniter with the provided dependencies.
+func NewUniterFromDeps(deps UnitDeps) Uniter {
+	return NewUniter(deps.options()...)
+}
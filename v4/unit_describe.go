@@ -0,0 +1,286 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package work
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// redacted replaces the values of settings that appear to hold secrets.
+const redacted = "[REDACTED]"
+
+// secretNames are the fragments of names identifying settings whose values
+// appear to hold secrets.
+var secretNames = []string{"password", "passwd", "secret", "token", "key", "credential", "auth"}
+
+// retryTypeNames are the names of the retry delay types.
+var retryTypeNames = map[UnitRetryDelayType]string{
+	UnitRetryDelayTypeFixed:   "fixed",
+	UnitRetryDelayTypeBackOff: "backoff",
+	UnitRetryDelayTypeRandom:  "random",
+}
+
+// UnitDescription represents a summary of the effective configuration of
+// work units, suitable for logging at startup so that operators can verify
+// deployed settings. Values of settings that appear to hold secrets are
+// redacted.
+type UnitDescription struct {
+	// Type is the type of work unit, either "sql" or "best_effort".
+	Type string `json:"type"`
+	// Driver is the type of the database driver, if any.
+	Driver string `json:"driver,omitempty"`
+	// Logger is the type of the logger.
+	Logger string `json:"logger"`
+	// MetricsCollector is the type of the metrics collector.
+	MetricsCollector string `json:"metricsCollector"`
+	// MetricScope is the name of the sub-scope metrics are emitted within.
+	MetricScope string `json:"metricScope"`
+	// CacheClient is the type of the cache client.
+	CacheClient string `json:"cacheClient"`
+	// DataMappers are the operations with data mapper functions by type.
+	DataMappers map[TypeName][]string `json:"dataMappers"`
+	// Actions is the number of actions.
+	Actions int `json:"actions"`
+	// HaltActionsOnFailure indicates if actions halt upon failure.
+	HaltActionsOnFailure bool `json:"haltActionsOnFailure"`
+	// RetryAttempts is the maximum number of attempts to save.
+	RetryAttempts int `json:"retryAttempts"`
+	// RetryDelay is the delay between attempts to save.
+	RetryDelay time.Duration `json:"retryDelay"`
+	// RetryMaximumJitter is the maximum jitter added to the retry delay.
+	RetryMaximumJitter time.Duration `json:"retryMaximumJitter"`
+	// RetryType is the manner in which the retry delay changes.
+	RetryType string `json:"retryType"`
+	// RollbackRetryAttempts is the maximum number of attempts to roll back.
+	RollbackRetryAttempts int `json:"rollbackRetryAttempts"`
+	// RollbackRetryDelay is the delay between attempts to roll back.
+	RollbackRetryDelay time.Duration `json:"rollbackRetryDelay"`
+	// MaxOperationsPerTransaction is the maximum number of operations per
+	// transaction, where zero indicates no maximum.
+	MaxOperationsPerTransaction int `json:"maxOperationsPerTransaction"`
+	// QuarantineAfter is the number of failures after which entities are
+	// quarantined, where zero indicates that entities are not quarantined.
+	QuarantineAfter int `json:"quarantineAfter"`
+	// DeferConstraints indicates if constraint checking is deferred.
+	DeferConstraints bool `json:"deferConstraints"`
+	// SessionSettings are the transaction-scoped session settings.
+	SessionSettings map[string]string `json:"sessionSettings,omitempty"`
+	// SQLCommentTags are the tags of the comments annotating statements.
+	SQLCommentTags map[string]string `json:"sqlCommentTags,omitempty"`
+	// SlowSaveThreshold is the duration of saves considered slow, where
+	// zero indicates that slow saves are not detected.
+	SlowSaveThreshold time.Duration `json:"slowSaveThreshold"`
+	// StaleAfter is the duration after which open and dirty work units are
+	// reported, where zero indicates that they are not.
+	StaleAfter time.Duration `json:"staleAfter"`
+	// EvictStale indicates if the staged changes of stale work units are
+	// discarded.
+	EvictStale bool `json:"evictStale"`
+	// Options are the names of the remaining options that are enabled.
+	Options []string `json:"options"`
+}
+
+// isSecret determines if the setting with the provided name appears to hold
+// a secret.
+func isSecret(name string) bool {
+	name = strings.ToLower(name)
+	for _, s := range secretNames {
+		if strings.Contains(name, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// redact provides a copy of the provided settings with the values of those
+// that appear to hold secrets redacted.
+func redact(settings map[string]string) map[string]string {
+	if len(settings) == 0 {
+		return nil
+	}
+	r := make(map[string]string, len(settings))
+	for name, value := range settings {
+		if isSecret(name) {
+			value = redacted
+		}
+		r[name] = value
+	}
+	return r
+}
+
+// typeOf provides the name of the type of the provided value, or "none" when
+// it is nil.
+func typeOf(v interface{}) string {
+	if v == nil {
+		return "none"
+	}
+	return fmt.Sprintf("%T", v)
+}
+
+// mapperTypes provides the types with the provided data mapper functions.
+func mapperTypes(funcs map[TypeName]UnitDataMapperFunc) []TypeName {
+	types := make([]TypeName, 0, len(funcs))
+	for t := range funcs {
+		types = append(types, t)
+	}
+	return types
+}
+
+// patchMapperTypes provides the types with the provided patch data mapper
+// functions.
+func patchMapperTypes(funcs map[TypeName]UnitPatchDataMapperFunc) []TypeName {
+	types := make([]TypeName, 0, len(funcs))
+	for t := range funcs {
+		types = append(types, t)
+	}
+	return types
+}
+
+// Describe provides a summary of the effective configuration described by
+// the options.
+func (uo *UnitOptions) Describe() UnitDescription {
+	d := UnitDescription{
+		Type:                        "best_effort",
+		Logger:                      typeOf(uo.logger),
+		MetricsCollector:            typeOf(uo.metricsScope.collector),
+		MetricScope:                 uo.metricScope,
+		CacheClient:                 typeOf(uo.cacheClient),
+		DataMappers:                 make(map[TypeName][]string),
+		HaltActionsOnFailure:        uo.haltActionsOnFailure,
+		RetryAttempts:               uo.retryAttempts,
+		RetryDelay:                  uo.retryDelay,
+		RetryMaximumJitter:          uo.retryMaximumJitter,
+		RetryType:                   retryTypeNames[uo.retryType],
+		RollbackRetryAttempts:       uo.rollbackRetryAttempts,
+		RollbackRetryDelay:          uo.rollbackRetryDelay,
+		MaxOperationsPerTransaction: uo.maxOperationsPerTransaction,
+		QuarantineAfter:             uo.quarantineAfter,
+		DeferConstraints:            uo.deferConstraints,
+		SessionSettings:             redact(uo.sessionSettings),
+		SQLCommentTags:              redact(uo.sqlCommentTags),
+		SlowSaveThreshold:           uo.slowSaveThreshold,
+		StaleAfter:                  uo.staleAfter,
+		EvictStale:                  uo.evictStale,
+		Options:                     []string{},
+	}
+	if uo.db != nil {
+		d.Type = "sql"
+		d.Driver = typeOf(uo.db.Driver())
+	}
+	for _, m := range []struct {
+		operation string
+		types     []TypeName
+	}{
+		{"insert", mapperTypes(uo.insertFuncs)},
+		{"update", mapperTypes(uo.updateFuncs)},
+		{"delete", mapperTypes(uo.deleteFuncs)},
+		{"upsert", mapperTypes(uo.upsertFuncs)},
+		{"patch", patchMapperTypes(uo.patchFuncs)},
+		{"deleteWhere", mapperTypes(uo.deleteWhereFuncs)},
+		{"refresh", mapperTypes(uo.refreshFuncs)},
+	} {
+		for _, t := range m.types {
+			d.DataMappers[t] = append(d.DataMappers[t], m.operation)
+		}
+	}
+	for _, actions := range uo.actions {
+		d.Actions = d.Actions + len(actions)
+	}
+	d.Actions = d.Actions + len(uo.rollbackActions) + len(uo.saveActions)
+	for name, enabled := range map[string]bool{
+		"refreshAfterSave":   uo.refreshAfterSave,
+		"commitTokens":       uo.commitTokenizer != nil,
+		"slowSaveStacks":     uo.slowSaveStacks,
+		"detectReentrancy":   uo.detectReentrancy,
+		"goroutineAudit":     uo.goroutineAudit != 0,
+		"mergeAlterations":   uo.mergeAlterations,
+		"conflictResolver":   uo.conflictResolver != nil,
+		"preflightPing":      uo.preflightPing,
+		"allowTypes":         uo.allowedTypes != nil,
+		"denyTypes":          uo.deniedTypes != nil,
+		"authorizer":         uo.authorizer != nil,
+		"cacheEncryption":    uo.cacheKeys != nil,
+		"deferredStaging":    uo.deferredStagingPasses > 0,
+		"projections":        len(uo.projections) > 0,
+		"rejectNilEntities":  uo.rejectNilEntities,
+		"strictTracking":     uo.strictTracking,
+		"uniqueChecks":       len(uo.uniqueChecks) > 0,
+		"onRetry":            len(uo.retryFuncs) > 0,
+		"changeRecords":      uo.changeRecordSink != nil,
+		"idGenerator":        uo.idGenerator != nil,
+		"persistQuarantined": uo.quarantineSink != nil,
+		"trackActive":        uo.trackActive,
+		"detectLeaks":        uo.detectLeaks,
+		"saveMiddleware":     len(uo.saveMiddleware) > 0,
+		"appendOnly":         uo.appendOnly,
+		"readOnly":           uo.readOnly,
+		"tracing":            uo.tracer != nil,
+		"eventSourcing":      uo.events != nil,
+		"aggregateBounds":    len(uo.aggregateRoots) > 0,
+		"lockTokens":         uo.lockTokenFunc != nil,
+		"retryHistory":       uo.retryHistory,
+		"mutationGuard":      len(uo.mutationLimits) > 0,
+		"confirmMutations":   uo.confirmMutations,
+		"codecs":             len(uo.codecs) > 0 || uo.defaultCodec != nil,
+	} {
+		if enabled {
+			d.Options = append(d.Options, name)
+		}
+	}
+	sort.Strings(d.Options)
+	return d
+}
+
+// DescribeUnitOptions provides a summary of the effective configuration of
+// work units created with the provided options, including defaults.
+func DescribeUnitOptions(opts ...UnitOption) UnitDescription {
+	o := options(opts)
+	return o.Describe()
+}
+
+// KeyValues provides the description as alternating keys and values, suitable
+// for providing as the arguments of a UnitLogger.
+func (d UnitDescription) KeyValues() []interface{} {
+	return []interface{}{
+		"type", d.Type,
+		"driver", d.Driver,
+		"logger", d.Logger,
+		"metricsCollector", d.MetricsCollector,
+		"metricScope", d.MetricScope,
+		"cacheClient", d.CacheClient,
+		"dataMappers", fmt.Sprint(d.DataMappers),
+		"actions", d.Actions,
+		"haltActionsOnFailure", d.HaltActionsOnFailure,
+		"retryAttempts", d.RetryAttempts,
+		"retryDelay", d.RetryDelay.String(),
+		"retryMaximumJitter", d.RetryMaximumJitter.String(),
+		"retryType", d.RetryType,
+		"rollbackRetryAttempts", d.RollbackRetryAttempts,
+		"rollbackRetryDelay", d.RollbackRetryDelay.String(),
+		"maxOperationsPerTransaction", d.MaxOperationsPerTransaction,
+		"quarantineAfter", d.QuarantineAfter,
+		"deferConstraints", d.DeferConstraints,
+		"sessionSettings", fmt.Sprint(d.SessionSettings),
+		"sqlCommentTags", fmt.Sprint(d.SQLCommentTags),
+		"slowSaveThreshold", d.SlowSaveThreshold.String(),
+		"staleAfter", d.StaleAfter.String(),
+		"evictStale", d.EvictStale,
+		"options", strings.Join(d.Options, ","),
+	}
+}
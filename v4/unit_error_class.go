@@ -0,0 +1,297 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package work
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"net"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// ErrorClass represents the category of a failure encountered when saving a
+// work unit.
+type ErrorClass int
+
+const (
+	// ErrorClassUnknown represents failures that could not be categorized.
+	ErrorClassUnknown ErrorClass = iota
+	// ErrorClassTransient represents failures that may succeed when retried,
+	// such as lost connections, deadlocks, and serialization failures.
+	ErrorClassTransient
+	// ErrorClassConstraint represents failures due to violating a constraint
+	// of the data store, such as unique or foreign key constraints.
+	ErrorClassConstraint
+	// ErrorClassPermission represents failures due to lacking the privileges
+	// necessary to perform an operation.
+	ErrorClassPermission
+	// ErrorClassTimeout represents failures due to exceeding a deadline, such
+	// as statement or lock timeouts.
+	ErrorClassTimeout
+)
+
+var errorClassNames = map[ErrorClass]string{
+	ErrorClassUnknown:    "unknown",
+	ErrorClassTransient:  "transient",
+	ErrorClassConstraint: "constraint",
+	ErrorClassPermission: "permission",
+	ErrorClassTimeout:    "timeout",
+}
+
+// String provides the name of the error class.
+func (c ErrorClass) String() string {
+	return errorClassNames[c]
+}
+
+// retryable determines if failures of the error class may succeed when
+// retried.
+func (c ErrorClass) retryable() bool {
+	return c != ErrorClassConstraint && c != ErrorClassPermission
+}
+
+// ErrorClassifier categorizes the provided error returned by a driver,
+// providing ErrorClassUnknown for those it does not recognize.
+type ErrorClassifier func(error) ErrorClass
+
+// ClassifiedError represents a failure encountered when saving a work unit
+// that was categorized by the error classifier of the driver.
+type ClassifiedError struct {
+	// Class is the category of the failure.
+	Class ErrorClass
+	// Err is the failure.
+	Err error
+}
+
+// Error provides the error message.
+func (e *ClassifiedError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap provides the failure.
+func (e *ClassifiedError) Unwrap() error {
+	return e.Err
+}
+
+var (
+	classifiersMutex sync.RWMutex
+	classifiers      = map[string]ErrorClassifier{
+		"postgres": classifySQLState,
+		"pgx":      classifySQLState,
+		"mysql":    classifyMySQL,
+		"sqlite3":  classifySQLite,
+	}
+
+	// driverNames are the names drivers are conventionally registered with,
+	// by the package path of their driver type.
+	driverNames = map[string]string{
+		"github.com/lib/pq":              "postgres",
+		"github.com/jackc/pgx/v4/stdlib": "pgx",
+		"github.com/jackc/pgx/v5/stdlib": "pgx",
+		"github.com/go-sql-driver/mysql": "mysql",
+		"github.com/mattn/go-sqlite3":    "sqlite3",
+	}
+)
+
+// RegisterErrorClassifier registers the provided classifier for the driver
+// registered with the provided name, replacing any classifier previously
+// registered for it. Classifiers are shipped for lib/pq ("postgres"), pgx,
+// go-sql-driver/mysql ("mysql"), and go-sqlite3 ("sqlite3").
+func RegisterErrorClassifier(driverName string, classifier ErrorClassifier) {
+	classifiersMutex.Lock()
+	defer classifiersMutex.Unlock()
+	classifiers[driverName] = classifier
+}
+
+// ClassifyError categorizes the provided error with the classifier of the
+// driver registered with the provided name, falling back to categorizing
+// failures common to all drivers, such as lost connections and exceeded
+// deadlines.
+func ClassifyError(driverName string, err error) ErrorClass {
+	if err == nil {
+		return ErrorClassUnknown
+	}
+	var classified *ClassifiedError
+	if errors.As(err, &classified) {
+		return classified.Class
+	}
+	classifiersMutex.RLock()
+	classifier, ok := classifiers[driverName]
+	classifiersMutex.RUnlock()
+	if ok {
+		if c := classifier(err); c != ErrorClassUnknown {
+			return c
+		}
+	}
+	var netErr net.Error
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return ErrorClassTimeout
+	case errors.As(err, &netErr) && netErr.Timeout():
+		return ErrorClassTimeout
+	case errors.Is(err, driver.ErrBadConn) || netErr != nil:
+		return ErrorClassTransient
+	}
+	return ErrorClassUnknown
+}
+
+// driverNameOf provides the name the provided driver is conventionally
+// registered with, if known.
+func driverNameOf(d driver.Driver) string {
+	t := reflect.TypeOf(d)
+	if t == nil {
+		return ""
+	}
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return driverNames[t.PkgPath()]
+}
+
+// classify categorizes the provided error with the classifier of the driver
+// of the work unit, counting the failure by its class and providing the
+// error as a ClassifiedError when it is categorized.
+func (u *unit) classify(err error) error {
+	c := ClassifyError(u.driverName, err)
+	u.scope.tagged(map[string]string{"error_class": c.String()}).
+		counter(u.metrics.SaveFailure, 1)
+	if c == ErrorClassUnknown {
+		return err
+	}
+	var classified *ClassifiedError
+	if errors.As(err, &classified) {
+		return err
+	}
+	return &ClassifiedError{Class: c, Err: err}
+}
+
+// walkErrors invokes the provided function for each error within the tree
+// of the provided error until it returns true.
+func walkErrors(err error, f func(error) bool) bool {
+	if err == nil {
+		return false
+	}
+	if f(err) {
+		return true
+	}
+	switch e := err.(type) {
+	case interface{ Unwrap() error }:
+		return walkErrors(e.Unwrap(), f)
+	case interface{ Unwrap() []error }:
+		for _, err := range e.Unwrap() {
+			if walkErrors(err, f) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// driverErrorField provides the value of the field with the provided name of
+// the first error within the tree of the provided error whose type has the
+// provided name, which allows classifying driver errors without depending on
+// the driver.
+func driverErrorField(err error, typeName, field string) (v reflect.Value, ok bool) {
+	walkErrors(err, func(err error) bool {
+		rv := reflect.ValueOf(err)
+		if rv.Kind() == reflect.Ptr {
+			rv = rv.Elem()
+		}
+		if rv.Kind() != reflect.Struct || rv.Type().Name() != typeName {
+			return false
+		}
+		v = rv.FieldByName(field)
+		ok = v.IsValid()
+		return ok
+	})
+	return
+}
+
+// classifySQLState categorizes errors reporting a SQLSTATE code, such as
+// those of lib/pq and pgx.
+func classifySQLState(err error) ErrorClass {
+	var state interface{ SQLState() string }
+	if !errors.As(err, &state) {
+		return ErrorClassUnknown
+	}
+	code := state.SQLState()
+	switch {
+	case code == "57014" || code == "55P03":
+		// query_canceled, lock_not_available.
+		return ErrorClassTimeout
+	case code == "40001" || code == "40P01" || code == "57P01":
+		// serialization_failure, deadlock_detected, admin_shutdown.
+		return ErrorClassTransient
+	case strings.HasPrefix(code, "08") || strings.HasPrefix(code, "53"):
+		// connection_exception, insufficient_resources.
+		return ErrorClassTransient
+	case strings.HasPrefix(code, "23"):
+		// integrity_constraint_violation.
+		return ErrorClassConstraint
+	case code == "42501" || strings.HasPrefix(code, "28"):
+		// insufficient_privilege, invalid_authorization_specification.
+		return ErrorClassPermission
+	}
+	return ErrorClassUnknown
+}
+
+// classifyMySQL categorizes the errors of go-sql-driver/mysql.
+func classifyMySQL(err error) ErrorClass {
+	v, ok := driverErrorField(err, "MySQLError", "Number")
+	if !ok || v.Kind() != reflect.Uint16 {
+		return ErrorClassUnknown
+	}
+	switch v.Uint() {
+	case 1205, 3024:
+		// lock wait timeout, maximum statement execution time exceeded.
+		return ErrorClassTimeout
+	case 1040, 1213, 2006, 2013:
+		// too many connections, deadlock, server gone away, lost connection.
+		return ErrorClassTransient
+	case 1048, 1062, 1216, 1217, 1451, 1452, 1557, 3819:
+		// not null, duplicate entry, foreign key, and check constraints.
+		return ErrorClassConstraint
+	case 1044, 1045, 1142, 1143, 1227:
+		// access denied.
+		return ErrorClassPermission
+	}
+	return ErrorClassUnknown
+}
+
+// classifySQLite categorizes the errors of go-sqlite3.
+func classifySQLite(err error) ErrorClass {
+	v, ok := driverErrorField(err, "Error", "Code")
+	if !ok || v.Kind() != reflect.Int {
+		return ErrorClassUnknown
+	}
+	switch v.Int() {
+	case 9:
+		// SQLITE_INTERRUPT.
+		return ErrorClassTimeout
+	case 5, 6:
+		// SQLITE_BUSY, SQLITE_LOCKED.
+		return ErrorClassTransient
+	case 19:
+		// SQLITE_CONSTRAINT.
+		return ErrorClassConstraint
+	case 3, 8, 23:
+		// SQLITE_PERM, SQLITE_READONLY, SQLITE_AUTH.
+		return ErrorClassPermission
+	}
+	return ErrorClassUnknown
+}
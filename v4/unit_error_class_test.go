@@ -0,0 +1,99 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package work_test
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/freerware/work/v4"
+	"github.com/stretchr/testify/suite"
+)
+
+// sqlStateError represents an error reporting a SQLSTATE code, such as those
+// of lib/pq and pgx.
+type sqlStateError string
+
+func (e sqlStateError) Error() string    { return "sqlstate " + string(e) }
+func (e sqlStateError) SQLState() string { return string(e) }
+
+// MySQLError mirrors the error type of go-sql-driver/mysql.
+type MySQLError struct {
+	Number  uint16
+	Message string
+}
+
+func (e *MySQLError) Error() string { return e.Message }
+
+type ErrorClassTestSuite struct {
+	suite.Suite
+}
+
+func TestErrorClassTestSuite(t *testing.T) {
+	suite.Run(t, new(ErrorClassTestSuite))
+}
+
+func (s *ErrorClassTestSuite) TestClassifyError() {
+	tests := []struct {
+		name       string
+		driverName string
+		err        error
+		expected   work.ErrorClass
+	}{
+		{"Nil", "postgres", nil, work.ErrorClassUnknown},
+		{"Postgres_UniqueViolation", "postgres", sqlStateError("23505"), work.ErrorClassConstraint},
+		{"Postgres_Deadlock", "postgres", sqlStateError("40P01"), work.ErrorClassTransient},
+		{"Pgx_InsufficientPrivilege", "pgx", sqlStateError("42501"), work.ErrorClassPermission},
+		{"Pgx_QueryCanceled", "pgx", fmt.Errorf("wrapped: %w", sqlStateError("57014")), work.ErrorClassTimeout},
+		{"Pgx_SyntaxError", "pgx", sqlStateError("42601"), work.ErrorClassUnknown},
+		{"MySQL_DuplicateEntry", "mysql", &MySQLError{Number: 1062}, work.ErrorClassConstraint},
+		{"MySQL_Deadlock", "mysql", &MySQLError{Number: 1213}, work.ErrorClassTransient},
+		{"MySQL_AccessDenied", "mysql", &MySQLError{Number: 1142}, work.ErrorClassPermission},
+		{"MySQL_LockWaitTimeout", "mysql", &MySQLError{Number: 1205}, work.ErrorClassTimeout},
+		{"UnregisteredDriver", "unknown", sqlStateError("23505"), work.ErrorClassUnknown},
+		{"BadConnection", "unknown", driver.ErrBadConn, work.ErrorClassTransient},
+		{"DeadlineExceeded", "unknown", context.DeadlineExceeded, work.ErrorClassTimeout},
+		{"Classified", "unknown", &work.ClassifiedError{Class: work.ErrorClassPermission, Err: errors.New("whoa")}, work.ErrorClassPermission},
+	}
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			// action.
+			class := work.ClassifyError(test.driverName, test.err)
+
+			// assert.
+			s.Equal(test.expected, class)
+		})
+	}
+}
+
+func (s *ErrorClassTestSuite) TestRegisterErrorClassifier() {
+	// arrange.
+	errInsufficientFunds := errors.New("insufficient funds")
+	work.RegisterErrorClassifier("ledger", func(err error) work.ErrorClass {
+		if errors.Is(err, errInsufficientFunds) {
+			return work.ErrorClassConstraint
+		}
+		return work.ErrorClassUnknown
+	})
+
+	// action + assert.
+	s.Equal(work.ErrorClassConstraint, work.ClassifyError("ledger", errInsufficientFunds))
+	s.Equal(work.ErrorClassUnknown, work.ClassifyError("ledger", errors.New("whoa")))
+	s.Equal("constraint", work.ErrorClassConstraint.String())
+}
@@ -0,0 +1,146 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package work
+
+import (
+	"errors"
+)
+
+// UnitOperationError represents the error that is returned when a data
+// mapper fails to apply an operation for entities of a particular type.
+type UnitOperationError struct {
+	// TypeName is the type of the entities the operation was applied for.
+	TypeName TypeName
+	// Operation is the operation that failed.
+	Operation UnitOperation
+	// Err is the error returned by the data mapper.
+	Err error
+}
+
+// Error provides the error message.
+func (e *UnitOperationError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap provides the error returned by the data mapper.
+func (e *UnitOperationError) Unwrap() error {
+	return e.Err
+}
+
+// operationErr provides the provided error encountered when applying the
+// provided operation for entities of the provided type as a
+// UnitOperationError, if any.
+func operationErr(t TypeName, operation UnitOperation, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &UnitOperationError{TypeName: t, Operation: operation, Err: err}
+}
+
+// ErrorReportFailure represents the failures of a particular operation for
+// entities of a particular type.
+type ErrorReportFailure struct {
+	// TypeName is the type of the entities the operation was applied for,
+	// which is empty for failures not specific to a type, such as those
+	// encountered when beginning or rolling back transactions.
+	TypeName TypeName `json:"typeName,omitempty"`
+	// Operation is the operation that failed, which is empty for failures not
+	// specific to an operation.
+	Operation UnitOperation `json:"operation,omitempty"`
+	// Errors are the messages of each failure.
+	Errors []string `json:"errors"`
+}
+
+// ErrorReport represents the error returned when saving a work unit broken
+// down by type and operation, such as for rendering in API responses.
+type ErrorReport struct {
+	// Message is the message of the error.
+	Message string `json:"message"`
+	// Class is the class of the error, if it was categorized.
+	Class string `json:"class,omitempty"`
+	// Failures are the failures comprising the error, grouped by type and
+	// operation in the order they were encountered.
+	Failures []ErrorReportFailure `json:"failures"`
+	// Attempts are the messages of the errors of each failed attempt, in the
+	// order they were attempted, when retry history is enabled.
+	Attempts []string `json:"attempts,omitempty"`
+}
+
+// NewErrorReport creates a report of the provided error, as returned when
+// saving a work unit. Nil is provided when the error is nil.
+func NewErrorReport(err error) *ErrorReport {
+	if err == nil {
+		return nil
+	}
+	r := ErrorReport{Message: err.Error(), Failures: []ErrorReportFailure{}}
+	var classified *ClassifiedError
+	if errors.As(err, &classified) {
+		r.Class = classified.Class.String()
+	}
+	var retryErr *UnitRetryError
+	if errors.As(err, &retryErr) {
+		r.Attempts = retryErr.Errors()
+	}
+	type key struct {
+		typeName  TypeName
+		operation UnitOperation
+	}
+	index := make(map[key]int)
+	for _, leaf := range errorLeaves(err) {
+		var k key
+		var opErr *UnitOperationError
+		if errors.As(leaf, &opErr) {
+			k = key{typeName: opErr.TypeName, operation: opErr.Operation}
+		}
+		i, ok := index[k]
+		if !ok {
+			i = len(r.Failures)
+			index[k] = i
+			r.Failures = append(r.Failures, ErrorReportFailure{
+				TypeName:  k.typeName,
+				Operation: k.operation,
+				Errors:    []string{},
+			})
+		}
+		r.Failures[i].Errors = append(r.Failures[i].Errors, leaf.Error())
+	}
+	return &r
+}
+
+// errorLeaves provides the individual failures comprising the provided
+// error, splitting combined errors apart while keeping wrapped errors whole
+// unless they wrap failures of operations or combined errors.
+func errorLeaves(err error) []error {
+	switch e := err.(type) {
+	case *UnitOperationError:
+		return []error{err}
+	case interface{ Unwrap() []error }:
+		var leaves []error
+		for _, err := range e.Unwrap() {
+			leaves = append(leaves, errorLeaves(err)...)
+		}
+		return leaves
+	case interface{ Unwrap() error }:
+		if inner := e.Unwrap(); inner != nil {
+			leaves := errorLeaves(inner)
+			var opErr *UnitOperationError
+			if len(leaves) > 1 || errors.As(inner, &opErr) {
+				return leaves
+			}
+		}
+	}
+	return []error{err}
+}
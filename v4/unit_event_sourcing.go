@@ -0,0 +1,190 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package work
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrUnitNotEventSourced represents the error that is returned when saving
+// an entity of an event-sourced type that does not implement
+// UnitEventSourced.
+var ErrUnitNotEventSourced = errors.New("entity is not event-sourced")
+
+// UnitEventSourced represents an aggregate that is persisted as the events
+// raised against it, rather than as its state.
+type UnitEventSourced interface {
+	// UncommittedEvents provides the events raised against the aggregate
+	// that have yet to be saved, in the order they were raised.
+	UncommittedEvents() []interface{}
+	// Version provides the version of the aggregate as of when it was loaded
+	// or last saved, which is the number of events within its stream.
+	Version() int
+	// MarkEventsCommitted marks the uncommitted events as saved, advancing
+	// the version of the aggregate.
+	MarkEventsCommitted()
+}
+
+// UnitEventStream represents the uncommitted events of an aggregate to
+// append to its stream.
+type UnitEventStream struct {
+	// TypeName is the type of the aggregate.
+	TypeName TypeName
+	// ID is the identity of the aggregate, as provided by IDOf.
+	ID interface{}
+	// ExpectedVersion is the version the stream is expected to be at, which
+	// is the version of the aggregate as of when it was loaded or last saved.
+	ExpectedVersion int
+	// Aggregate is the aggregate the events were raised against.
+	Aggregate interface{}
+	// Events are the events to append, in the order they were raised.
+	Events []interface{}
+}
+
+// UnitEventAppendFunc appends the events of the provided streams to the
+// underlying event store. Should the version of any stream differ from the
+// version it is expected to be at, no events are appended and
+// UnitStaleEntityError is returned for the aggregate of the stream.
+type UnitEventAppendFunc func(context.Context, UnitMapperContext, []UnitEventStream) error
+
+// unitEventSourcing represents the configuration of the event-sourced types
+// of a work unit.
+type unitEventSourcing struct {
+	appendFunc    UnitEventAppendFunc
+	types         map[TypeName]struct{}
+	snapshots     map[TypeName]UnitDataMapperFunc
+	snapshotEvery map[TypeName]int
+}
+
+// eventSourcing provides the configuration of the event-sourced types,
+// which is shared by the data mapper functions of those types.
+func (uo *UnitOptions) eventSourcing() *unitEventSourcing {
+	if uo.events == nil {
+		uo.events = &unitEventSourcing{
+			types:         make(map[TypeName]struct{}),
+			snapshots:     make(map[TypeName]UnitDataMapperFunc),
+			snapshotEvery: make(map[TypeName]int),
+		}
+		uo.saveActions = append(uo.saveActions, uo.events.markCommitted)
+	}
+	return uo.events
+}
+
+// sourcesEvents determines if the entities of the provided type are
+// persisted as events.
+func (u *unit) sourcesEvents(t TypeName) bool {
+	if u.events == nil {
+		return false
+	}
+	_, ok := u.events.types[t]
+	return ok
+}
+
+// streams provides the streams of uncommitted events for the provided
+// aggregates, omitting aggregates without any.
+func streams(t TypeName, aggregates []interface{}) ([]UnitEventStream, error) {
+	var s []UnitEventStream
+	for _, aggregate := range aggregates {
+		es, ok := aggregate.(UnitEventSourced)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrUnitNotEventSourced, t)
+		}
+		events := es.UncommittedEvents()
+		if len(events) == 0 {
+			continue
+		}
+		id, ok := IDOf(aggregate)
+		if !ok {
+			return nil, fmt.Errorf("unable to determine stream identity for %s", t)
+		}
+		s = append(s, UnitEventStream{
+			TypeName:        t,
+			ID:              id,
+			ExpectedVersion: es.Version(),
+			Aggregate:       aggregate,
+			Events:          events,
+		})
+	}
+	return s, nil
+}
+
+// mapperFunc provides the data mapper function that appends the
+// uncommitted events of the aggregates of the provided type, and writes
+// their snapshots with the snapshot function of the type, if any.
+// Compensating writes are ignored, as appended events are never removed.
+func (es *unitEventSourcing) mapperFunc(t TypeName) UnitDataMapperFunc {
+	return func(ctx context.Context, mCtx UnitMapperContext, aggregates ...interface{}) error {
+		if mCtx.Compensating() {
+			return nil
+		}
+		s, err := streams(t, aggregates)
+		if err != nil || len(s) == 0 {
+			return err
+		}
+		if err = es.appendFunc(ctx, mCtx, s); err != nil {
+			return err
+		}
+		snapshot, ok := es.snapshots[t]
+		if !ok {
+			return nil
+		}
+		var snapshotted []interface{}
+		for _, stream := range s {
+			if es.snapshotDue(stream) {
+				snapshotted = append(snapshotted, stream.Aggregate)
+			}
+		}
+		if len(snapshotted) == 0 {
+			return nil
+		}
+		return snapshot(ctx, mCtx, snapshotted...)
+	}
+}
+
+// snapshotDue determines if a snapshot of the aggregate of the provided
+// stream is due, which is when appending its events crosses a multiple of
+// the snapshot frequency of its type. Snapshots are always due for types
+// without a snapshot frequency.
+func (es *unitEventSourcing) snapshotDue(stream UnitEventStream) bool {
+	every, ok := es.snapshotEvery[stream.TypeName]
+	if !ok {
+		return true
+	}
+	version := stream.ExpectedVersion + len(stream.Events)
+	return version/every > stream.ExpectedVersion/every
+}
+
+// markCommitted marks the events of the aggregates within a work unit as
+// committed once it is saved successfully.
+func (es *unitEventSourcing) markCommitted(actionCtx UnitSaveActionContext) {
+	if actionCtx.Err != nil {
+		return
+	}
+	changes := actionCtx.Changes()
+	for _, staged := range []map[TypeName][]interface{}{changes.Additions, changes.Alterations} {
+		for t, entities := range staged {
+			if _, ok := es.types[t]; !ok {
+				continue
+			}
+			for _, entity := range entities {
+				if aggregate, ok := entity.(UnitEventSourced); ok {
+					aggregate.MarkEventsCommitted()
+				}
+			}
+		}
+	}
+}
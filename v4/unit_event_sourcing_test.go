@@ -0,0 +1,266 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package work_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/freerware/work/v4"
+	"github.com/freerware/work/v4/internal/test"
+	"github.com/stretchr/testify/suite"
+)
+
+type account struct {
+	id      int
+	version int
+	events  []interface{}
+}
+
+func (a *account) Identifier() interface{} { return a.id }
+
+func (a *account) UncommittedEvents() []interface{} { return a.events }
+
+func (a *account) Version() int { return a.version }
+
+func (a *account) MarkEventsCommitted() {
+	a.version = a.version + len(a.events)
+	a.events = nil
+}
+
+type deposited struct {
+	amount int
+}
+
+type EventSourcingTestSuite struct {
+	suite.Suite
+
+	appended    []work.UnitEventStream
+	snapshotted []interface{}
+	appendErr   error
+}
+
+func TestEventSourcingTestSuite(t *testing.T) {
+	suite.Run(t, new(EventSourcingTestSuite))
+}
+
+func (s *EventSourcingTestSuite) SetupTest() {
+	s.appended, s.snapshotted, s.appendErr = nil, nil, nil
+}
+
+func (s *EventSourcingTestSuite) unit(opts ...work.UnitOption) work.Unit {
+	accountType := work.TypeNameOf(&account{})
+	appendFunc := func(ctx context.Context, mCtx work.UnitMapperContext, streams []work.UnitEventStream) error {
+		if s.appendErr != nil {
+			return s.appendErr
+		}
+		s.appended = append(s.appended, streams...)
+		return nil
+	}
+	snapshot := func(ctx context.Context, mCtx work.UnitMapperContext, aggregates ...interface{}) error {
+		s.snapshotted = append(s.snapshotted, aggregates...)
+		return nil
+	}
+	opts = append([]work.UnitOption{
+		work.UnitEventStore(appendFunc, accountType),
+		work.UnitSnapshotFunc(accountType, snapshot),
+		work.UnitRetryAttempts(1),
+	}, opts...)
+	u, err := work.NewUnit(opts...)
+	s.Require().NoError(err)
+	return u
+}
+
+func (s *EventSourcingTestSuite) TestSave() {
+	// arrange.
+	ctx := context.Background()
+	opened := &account{id: 1, events: []interface{}{deposited{amount: 10}}}
+	existing := &account{id: 2, version: 3, events: []interface{}{deposited{amount: 5}, deposited{amount: 7}}}
+	unchanged := &account{id: 3, version: 1}
+	u := s.unit()
+	s.Require().NoError(u.Add(ctx, opened))
+	s.Require().NoError(u.Alter(ctx, existing, unchanged))
+
+	// action.
+	err := u.Save(ctx)
+
+	// assert.
+	s.Require().NoError(err)
+	s.Require().Len(s.appended, 2)
+	s.ElementsMatch([]work.UnitEventStream{
+		{
+			TypeName:        work.TypeNameOf(&account{}),
+			ID:              1,
+			ExpectedVersion: 0,
+			Aggregate:       opened,
+			Events:          []interface{}{deposited{amount: 10}},
+		},
+		{
+			TypeName:        work.TypeNameOf(&account{}),
+			ID:              2,
+			ExpectedVersion: 3,
+			Aggregate:       existing,
+			Events:          []interface{}{deposited{amount: 5}, deposited{amount: 7}},
+		},
+	}, s.appended)
+	s.ElementsMatch([]interface{}{opened, existing}, s.snapshotted)
+	s.Equal(1, opened.version)
+	s.Empty(opened.events)
+	s.Equal(5, existing.version)
+	s.Empty(existing.events)
+	s.Equal(1, unchanged.version)
+}
+
+func (s *EventSourcingTestSuite) TestSave_Stale() {
+	// arrange.
+	ctx := context.Background()
+	existing := &account{id: 2, version: 3, events: []interface{}{deposited{amount: 5}}}
+	s.appendErr = &work.UnitStaleEntityError{Entity: existing}
+	u := s.unit()
+	s.Require().NoError(u.Alter(ctx, existing))
+
+	// action.
+	err := u.Save(ctx)
+
+	// assert.
+	s.ErrorIs(err, work.ErrStaleEntity)
+	s.Empty(s.snapshotted)
+	s.Equal(3, existing.version)
+	s.Len(existing.events, 1)
+}
+
+func (s *EventSourcingTestSuite) TestSave_NotEventSourced() {
+	// arrange.
+	ctx := context.Background()
+	fooType := work.TypeNameOf(test.Foo{})
+	appendFunc := func(context.Context, work.UnitMapperContext, []work.UnitEventStream) error { return nil }
+	u, err := work.NewUnit(work.UnitEventStore(appendFunc, fooType), work.UnitRetryAttempts(1))
+	s.Require().NoError(err)
+	s.Require().NoError(u.Add(ctx, test.Foo{ID: 1}))
+
+	// action.
+	err = u.Save(ctx)
+
+	// assert.
+	s.ErrorIs(err, work.ErrUnitNotEventSourced)
+}
+
+func (s *EventSourcingTestSuite) TestRemove() {
+	// arrange.
+	ctx := context.Background()
+	u := s.unit()
+
+	// action.
+	err := u.Remove(ctx, &account{id: 1, version: 1})
+
+	// assert.
+	s.ErrorIs(err, work.ErrMissingDataMapper)
+}
+
+func (s *EventSourcingTestSuite) TestSave_RollbackRetainsEvents() {
+	// arrange.
+	ctx := context.Background()
+	fooType := work.TypeNameOf(test.Foo{})
+	updateErr := errors.New("whoa")
+	update := func(context.Context, work.UnitMapperContext, ...interface{}) error { return updateErr }
+	u := s.unit(work.UnitUpdateFunc(fooType, update))
+	opened := &account{id: 1, events: []interface{}{deposited{amount: 10}}}
+	s.Require().NoError(u.Add(ctx, opened))
+	s.Require().NoError(u.Alter(ctx, test.Foo{ID: 1}))
+
+	// action.
+	err := u.Save(ctx)
+
+	// assert.
+	s.ErrorIs(err, updateErr)
+	s.Len(s.appended, 1)
+	s.Equal(0, opened.version)
+	s.Len(opened.events, 1)
+}
+
+func (s *EventSourcingTestSuite) TestSave_SnapshotEvery() {
+	// arrange.
+	ctx := context.Background()
+	accountType := work.TypeNameOf(&account{})
+	below := &account{id: 1, version: 3, events: []interface{}{deposited{amount: 1}}}
+	crossing := &account{id: 2, version: 4, events: []interface{}{deposited{amount: 1}, deposited{amount: 2}}}
+	reaching := &account{id: 3, version: 9, events: []interface{}{deposited{amount: 1}}}
+	u := s.unit(work.UnitSnapshotEvery(accountType, 5))
+	s.Require().NoError(u.Alter(ctx, below, crossing, reaching))
+
+	// action.
+	err := u.Save(ctx)
+
+	// assert.
+	s.Require().NoError(err)
+	s.Len(s.appended, 3)
+	s.ElementsMatch([]interface{}{crossing, reaching}, s.snapshotted)
+}
+
+func (s *EventSourcingTestSuite) TestSave_SnapshotWithinTransaction() {
+	// arrange.
+	ctx := context.Background()
+	accountType := work.TypeNameOf(&account{})
+	db, mock, err := sqlmock.New()
+	s.Require().NoError(err)
+	defer db.Close()
+	appendFunc := func(ctx context.Context, mCtx work.UnitMapperContext, streams []work.UnitEventStream) error {
+		for _, stream := range streams {
+			for _, event := range stream.Events {
+				_, err := mCtx.Tx.ExecContext(ctx, "INSERT INTO events (stream, amount) VALUES (?, ?)",
+					stream.ID, event.(deposited).amount)
+				if err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+	snapshot := func(ctx context.Context, mCtx work.UnitMapperContext, aggregates ...interface{}) error {
+		for _, aggregate := range aggregates {
+			a := aggregate.(*account)
+			_, err := mCtx.Tx.ExecContext(ctx, "UPSERT INTO snapshots (stream, version) VALUES (?, ?)",
+				a.id, a.version+len(a.events))
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	u, err := work.NewUnit(
+		work.UnitDB(db),
+		work.UnitEventStore(appendFunc, accountType),
+		work.UnitSnapshotFunc(accountType, snapshot),
+		work.UnitSnapshotEvery(accountType, 2),
+		work.UnitRetryAttempts(1),
+	)
+	s.Require().NoError(err)
+	existing := &account{id: 1, version: 1, events: []interface{}{deposited{amount: 5}}}
+	s.Require().NoError(u.Alter(ctx, existing))
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO events").WithArgs(1, 5).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("UPSERT INTO snapshots").WithArgs(1, 2).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	// action.
+	err = u.Save(ctx)
+
+	// assert.
+	s.Require().NoError(err)
+	s.NoError(mock.ExpectationsWereMet())
+	s.Equal(2, existing.version)
+}
@@ -0,0 +1,143 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package work_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/freerware/work/v4"
+	"github.com/freerware/work/v4/internal/test"
+)
+
+// cyclic represents an entity that references itself.
+type cyclic struct {
+	Key  int
+	Next *cyclic
+}
+
+func (c *cyclic) ID() interface{} {
+	return c.Key
+}
+
+// panicky represents an entity whose identity panics when it lacks one.
+type panicky struct {
+	key *int
+}
+
+func (p panicky) ID() interface{} {
+	return *p.key
+}
+
+// unhashable represents an entity whose identity cannot be compared.
+type unhashable struct {
+	keys []int
+}
+
+func (u unhashable) ID() interface{} {
+	return u.keys
+}
+
+// recursive represents an entity whose identity contains itself.
+type recursive struct{}
+
+func (recursive) ID() interface{} {
+	id := []interface{}{nil}
+	id[0] = id
+	return id
+}
+
+// mapped represents an entity that is a map, which cannot be hashed.
+type mapped map[string]interface{}
+
+// adversarial provides the adversarial entity identified by the provided
+// byte.
+func adversarial(b byte) interface{} {
+	one := 1
+	c := &cyclic{Key: int(b)}
+	c.Next = c
+	m := mapped{}
+	m["self"] = m
+	entities := []interface{}{
+		nil,
+		(*test.Foo)(nil),
+		(*cyclic)(nil),
+		c,
+		panicky{},
+		panicky{key: &one},
+		unhashable{keys: []int{int(b)}},
+		recursive{},
+		m,
+		mapped(nil),
+		test.Foo{ID: int(b)},
+		func() {},
+	}
+	return entities[int(b)%len(entities)]
+}
+
+// stage stages the provided entity within the work unit in the manner
+// identified by the provided byte.
+func stage(ctx context.Context, u work.Unit, b byte, entity interface{}) error {
+	switch b % 5 {
+	case 0:
+		return u.Add(ctx, entity)
+	case 1:
+		return u.Alter(ctx, entity)
+	case 2:
+		return u.Remove(ctx, entity)
+	case 3:
+		return u.Upsert(ctx, entity)
+	default:
+		return u.Register(ctx, entity)
+	}
+}
+
+func FuzzUnit_Stage(f *testing.F) {
+	for _, seed := range [][]byte{{}, {0, 1, 2, 3, 4}, {5, 6, 7, 8, 9}, {10, 11, 3, 12, 40}, {36, 19, 7, 8}} {
+		f.Add(seed)
+	}
+	noop := func(ctx context.Context, mCtx work.UnitMapperContext, e ...interface{}) error {
+		return nil
+	}
+	var opts []work.UnitOption
+	for _, entity := range []interface{}{
+		&test.Foo{}, &cyclic{}, panicky{}, unhashable{}, recursive{}, mapped{}, test.Foo{},
+	} {
+		t := work.TypeNameOf(entity)
+		opts = append(opts,
+			work.UnitInsertFunc(t, noop), work.UnitUpdateFunc(t, noop),
+			work.UnitDeleteFunc(t, noop), work.UnitUpsertFunc(t, noop))
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		ctx := context.Background()
+		u, err := work.NewUnit(opts...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := 0; i+1 < len(data); i = i + 2 {
+			entity := adversarial(data[i])
+			err := stage(ctx, u, data[i+1], entity)
+			if err != nil && !errors.Is(err, work.ErrInvalidEntity) && !errors.Is(err, work.ErrMissingDataMapper) {
+				t.Fatalf("unexpected error staging %T: %v", entity, err)
+			}
+			u.StateOf(entity)
+		}
+		if err := u.Save(ctx); err != nil {
+			t.Fatalf("unexpected error saving: %v", err)
+		}
+	})
+}
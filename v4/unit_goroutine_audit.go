@@ -0,0 +1,87 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package work
+
+import (
+	"fmt"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// UnitGoroutineAuditMode represents the manner in which the work unit reacts
+// to being used by a goroutine other than the one that created it.
+type UnitGoroutineAuditMode int
+
+const (
+	// UnitGoroutineAuditLog logs usages of the work unit by goroutines other
+	// than the one that created it.
+	UnitGoroutineAuditLog UnitGoroutineAuditMode = iota + 1
+	// UnitGoroutineAuditPanic logs and panics upon usages of the work unit by
+	// goroutines other than the one that created it.
+	UnitGoroutineAuditPanic
+)
+
+// sanctionedGoroutines holds the identifiers of the goroutines sanctioned to
+// use work units created by other goroutines, such as those of unit groups
+// and asynchronous saves.
+var sanctionedGoroutines sync.Map
+
+// sanctionGoroutine sanctions the calling goroutine to use work units created
+// by other goroutines, providing the function that withdraws the sanction.
+func sanctionGoroutine() func() {
+	id := goroutineID()
+	sanctionedGoroutines.Store(id, struct{}{})
+	return func() { sanctionedGoroutines.Delete(id) }
+}
+
+// goroutineID provides the identifier of the calling goroutine, as reported
+// in stack traces.
+func goroutineID() uint64 {
+	var buf [64]byte
+	stack := strings.TrimPrefix(string(buf[:runtime.Stack(buf[:], false)]), "goroutine ")
+	if i := strings.IndexByte(stack, ' '); i > 0 {
+		stack = stack[:i]
+	}
+	id, _ := strconv.ParseUint(stack, 10, 64)
+	return id
+}
+
+// checkGoroutine reports the provided operation when it is performed by a
+// goroutine other than the one that created the work unit. Work units are
+// intended to be confined to a single request, so sharing them across
+// goroutines, such as across requests, is almost always a mistake. The
+// goroutines of unit groups and asynchronous saves are exempt.
+func (u *unit) checkGoroutine(operation string) {
+	if u.goroutineAudit == 0 {
+		return
+	}
+	id := goroutineID()
+	if id == u.goroutine {
+		return
+	}
+	if _, ok := sanctionedGoroutines.Load(id); ok {
+		return
+	}
+	msg := fmt.Sprintf("work: %s called by goroutine %d, while the work unit "+
+		"was created by goroutine %d; work units must not be shared across "+
+		"goroutines", operation, id, u.goroutine)
+	u.logger.Error(msg, "operation", operation, "goroutine", id, "creator", u.goroutine)
+	if u.goroutineAudit == UnitGoroutineAuditPanic {
+		panic(msg)
+	}
+}
@@ -0,0 +1,99 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package work
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"go.uber.org/multierr"
+)
+
+var (
+	// ErrUnitGroupFailed represents the error that is returned when
+	// attempting to save a work unit after one of the goroutines within
+	// a group associated with the work unit has failed.
+	ErrUnitGroupFailed = errors.New("unable to save work unit - group failed")
+)
+
+// UnitGroup represents a collection of goroutines that stage entities into
+// a work unit concurrently. It mirrors the behavior of errgroup.Group, with
+// the addition that a failure within any of its goroutines causes the
+// work unit to fail upon save.
+type UnitGroup struct {
+	u      *unit
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+	once   sync.Once
+	err    error
+}
+
+// Go calls the provided function in a new goroutine. The first call to return
+// a non-nil error cancels the group's context and marks the work unit as
+// failed; its error will be returned by Wait.
+func (g *UnitGroup) Go(f func() error) {
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		if g.u.goroutineAudit != 0 {
+			defer sanctionGoroutine()()
+		}
+		if err := f(); err != nil {
+			g.once.Do(func() {
+				g.err = err
+				g.u.fail(err)
+				g.cancel()
+			})
+		}
+	}()
+}
+
+// Wait blocks until all function calls from the Go method have returned,
+// then returns the first non-nil error (if any) from them.
+func (g *UnitGroup) Wait() error {
+	g.wg.Wait()
+	g.cancel()
+	return g.err
+}
+
+// Group creates a new group whose goroutines stage entities into the work
+// unit, along with a derived context that is canceled the first time a
+// function passed to Go returns a non-nil error or the first time Wait
+// returns, whichever occurs first.
+func (u *unit) Group(ctx context.Context) (*UnitGroup, context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	return &UnitGroup{u: u, cancel: cancel}, ctx
+}
+
+// fail marks the work unit as failed due to the provided error.
+func (u *unit) fail(err error) {
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+	if u.failure == nil {
+		u.failure = err
+	}
+}
+
+// failed provides the error that caused the work unit to fail, if any.
+func (u *unit) failed() (err error) {
+	u.mutex.RLock()
+	defer u.mutex.RUnlock()
+	if u.failure != nil {
+		err = multierr.Combine(ErrUnitGroupFailed, u.failure)
+	}
+	return
+}
@@ -0,0 +1,136 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package work_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/freerware/work/v4"
+	"github.com/freerware/work/v4/internal/mock"
+	"github.com/freerware/work/v4/internal/test"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+)
+
+type UnitGroupTestSuite struct {
+	suite.Suite
+
+	// system under test.
+	sut work.Unit
+
+	// mocks.
+	mappers map[work.TypeName]*mock.UnitDataMapper
+	mc      *gomock.Controller
+}
+
+func TestUnitGroupTestSuite(t *testing.T) {
+	suite.Run(t, new(UnitGroupTestSuite))
+}
+
+func (s *UnitGroupTestSuite) SetupTest() {
+	// test entities.
+	fooTypeName := work.TypeNameOf(test.Foo{})
+
+	// initialize mocks.
+	s.mc = gomock.NewController(s.T())
+	s.mappers = make(map[work.TypeName]*mock.UnitDataMapper)
+	s.mappers[fooTypeName] = mock.NewUnitDataMapper(s.mc)
+
+	// construct SUT.
+	dm := map[work.TypeName]work.UnitDataMapper{fooTypeName: s.mappers[fooTypeName]}
+	var err error
+	s.sut, err = work.NewUnit(work.UnitDataMappers(dm), work.UnitRetryAttempts(1))
+	s.Require().NoError(err)
+}
+
+func (s *UnitGroupTestSuite) TestUnitGroup_Success() {
+	// arrange.
+	ctx := context.Background()
+	foos := []interface{}{test.Foo{ID: 1}, test.Foo{ID: 2}, test.Foo{ID: 3}}
+	g, gCtx := s.sut.Group(ctx)
+	s.mappers[work.TypeNameOf(test.Foo{})].EXPECT().
+		Insert(ctx, gomock.Any(), gomock.Any()).
+		Do(func(ctx context.Context, mCtx work.UnitMapperContext, e ...interface{}) {
+			s.ElementsMatch(foos, e)
+		}).Return(nil)
+
+	// action.
+	for _, foo := range foos {
+		foo := foo
+		g.Go(func() error { return s.sut.Add(gCtx, foo) })
+	}
+	err := g.Wait()
+
+	// assert.
+	s.Require().NoError(err)
+	s.Error(gCtx.Err())
+	s.NoError(s.sut.Save(ctx))
+}
+
+func (s *UnitGroupTestSuite) TestUnitGroup_Actions() {
+	// arrange.
+	ctx := context.Background()
+	fooType := work.TypeNameOf(test.Foo{})
+	dm := map[work.TypeName]work.UnitDataMapper{fooType: s.mappers[fooType]}
+	var mutex sync.Mutex
+	var counts []int
+	action := func(actionCtx work.UnitActionContext) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		counts = append(counts, actionCtx.AdditionCount)
+	}
+	sut, err := work.NewUnit(work.UnitDataMappers(dm), work.UnitAfterAddActions(action))
+	s.Require().NoError(err)
+	g, gCtx := sut.Group(ctx)
+
+	// action.
+	for i := 0; i < 20; i++ {
+		foo := test.Foo{ID: i}
+		g.Go(func() error { return sut.Add(gCtx, foo) })
+	}
+	err = g.Wait()
+
+	// assert.
+	s.Require().NoError(err)
+	s.Len(counts, 20)
+}
+
+func (s *UnitGroupTestSuite) TestUnitGroup_Failure() {
+	// arrange.
+	ctx := context.Background()
+	g, gCtx := s.sut.Group(ctx)
+
+	// action.
+	g.Go(func() error { return s.sut.Add(gCtx, test.Foo{ID: 1}) })
+	g.Go(func() error { return errors.New("whoa") })
+	err := g.Wait()
+
+	// assert.
+	s.EqualError(err, "whoa")
+	s.ErrorIs(gCtx.Err(), context.Canceled)
+	err = s.sut.Save(ctx)
+	s.ErrorIs(err, work.ErrUnitGroupFailed)
+	s.EqualError(err, work.ErrUnitGroupFailed.Error()+"; whoa")
+}
+
+func (s *UnitGroupTestSuite) TearDownTest() {
+	s.sut = nil
+	s.mappers = nil
+	s.mc.Finish()
+}
@@ -0,0 +1,183 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package work
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrUnitIDNotAssignable represents the error that is returned when an
+// identifier cannot be assigned to an entity, such as when the entity is not
+// provided as a pointer.
+var ErrUnitIDNotAssignable = errors.New("unable to assign identifier to entity")
+
+// unitIDTag is the struct tag value that marks the field holding the identity
+// of an entity, such as `work:"id"`.
+const unitIDTag = "id"
+
+// UnitIDGenerator generates identifiers for added entities lacking one.
+type UnitIDGenerator func() (interface{}, error)
+
+// idSetter represents an object whose identity can be assigned.
+type idSetter interface {
+	// SetID assigns the identity for the object.
+	SetID(interface{})
+}
+
+// UnitUUIDv7 generates time-ordered UUIDs as defined by RFC 9562.
+func UnitUUIDv7() (interface{}, error) {
+	return uuid.NewV7()
+}
+
+// crockford is the alphabet utilized when encoding ULIDs.
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// UnitULID generates lexicographically sortable identifiers as defined by the
+// ULID specification, encoded as strings.
+func UnitULID() (interface{}, error) {
+	var b [16]byte
+	ms := uint64(time.Now().UnixMilli())
+	binary.BigEndian.PutUint16(b[0:2], uint16(ms>>32))
+	binary.BigEndian.PutUint32(b[2:6], uint32(ms))
+	if _, err := rand.Read(b[6:]); err != nil {
+		return nil, err
+	}
+
+	// encode the 128 bits as 26 characters of 5 bits each, where the first
+	// character only holds the 3 most significant bits.
+	var s [26]byte
+	hi, lo := binary.BigEndian.Uint64(b[0:8]), binary.BigEndian.Uint64(b[8:16])
+	for i := 25; i >= 0; i-- {
+		s[i] = crockford[lo&0x1F]
+		lo = lo>>5 | hi<<59
+		hi = hi >> 5
+	}
+	return string(s[:]), nil
+}
+
+// hasID determines if the provided entity has been assigned an identity.
+func hasID(entity interface{}) bool {
+	if i, ok := id(entity); ok {
+		return i != nil && !reflect.ValueOf(i).IsZero()
+	}
+	if f, ok := idField(entity); ok {
+		return !f.IsZero()
+	}
+	// entities that can only be assigned an identity are always assigned one.
+	_, ok := entity.(idSetter)
+	return !ok
+}
+
+// idField provides the field of the entity marked with the identity struct
+// tag, if any.
+func idField(entity interface{}) (reflect.Value, bool) {
+	v := reflect.ValueOf(entity)
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return reflect.Value{}, false
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return reflect.Value{}, false
+	}
+	for i := 0; i < v.NumField(); i++ {
+		if v.Type().Field(i).Tag.Get("work") == unitIDTag {
+			return v.Field(i), true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+// assignID assigns the provided identity to the entity, either through its
+// SetID method or the field marked with the identity struct tag.
+func assignID(entity interface{}, entityID interface{}) error {
+	if s, ok := entity.(idSetter); ok {
+		s.SetID(entityID)
+		return nil
+	}
+	f, ok := idField(entity)
+	if !ok {
+		return nil
+	}
+	if !f.CanSet() {
+		return ErrUnitIDNotAssignable
+	}
+	if entityID == nil {
+		return ErrUnitIDNotAssignable
+	}
+	v := reflect.ValueOf(entityID)
+	switch {
+	case v.Type().AssignableTo(f.Type()):
+		f.Set(v)
+	case f.Kind() == reflect.String:
+		f.SetString(fmt.Sprint(entityID))
+	case v.CanConvert(f.Type()):
+		f.Set(v.Convert(f.Type()))
+	default:
+		return ErrUnitIDNotAssignable
+	}
+	return nil
+}
+
+// generateIDs assigns identifiers to the provided entities lacking one.
+func (u *unit) generateIDs(entities []interface{}) error {
+	if u.idGenerator == nil {
+		return nil
+	}
+	for _, entity := range entities {
+		if hasID(entity) {
+			continue
+		}
+		entityID, err := u.idGenerator()
+		if err != nil {
+			return err
+		}
+		if err = assignID(entity, entityID); err != nil {
+			u.logger.Error(err.Error(), "typeName", TypeNameOf(entity).String())
+			return err
+		}
+	}
+	return nil
+}
+
+// applyGeneratedIDs assigns the identifiers generated by the data store to
+// their entities, and caches the entities. Since the entities have already
+// been saved, failures are logged rather than returned.
+func (u *unit) applyGeneratedIDs(ctx context.Context, generated *unitGeneratedIDs) {
+	if generated == nil || len(generated.entities) == 0 {
+		return
+	}
+	assigned := make([]interface{}, 0, len(generated.entities))
+	for i, entity := range generated.entities {
+		if err := assignID(entity, generated.ids[i]); err != nil {
+			u.logger.Warn(err.Error(), "typeName", TypeNameOf(entity).String())
+			continue
+		}
+		assigned = append(assigned, entity)
+	}
+	if err := u.cached.storeAll(ctx, assigned); err != nil {
+		u.logger.Warn(err.Error())
+	}
+}
@@ -0,0 +1,137 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package work_test
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/freerware/work/v4"
+	"github.com/freerware/work/v4/internal/mock"
+	"github.com/freerware/work/v4/internal/test"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+)
+
+type IDGeneratorTestSuite struct {
+	suite.Suite
+
+	// mocks.
+	mc *gomock.Controller
+	dm map[work.TypeName]work.UnitDataMapper
+}
+
+func TestIDGeneratorTestSuite(t *testing.T) {
+	suite.Run(t, new(IDGeneratorTestSuite))
+}
+
+func (s *IDGeneratorTestSuite) SetupTest() {
+	s.mc = gomock.NewController(s.T())
+	s.dm = map[work.TypeName]work.UnitDataMapper{
+		work.TypeNameOf(&test.Qux{}):  mock.NewUnitDataMapper(s.mc),
+		work.TypeNameOf(test.Qux{}):   mock.NewUnitDataMapper(s.mc),
+		work.TypeNameOf(&test.Quux{}): mock.NewUnitDataMapper(s.mc),
+	}
+}
+
+func (s *IDGeneratorTestSuite) unit(gen work.UnitIDGenerator) work.Unit {
+	u, err := work.NewUnit(work.UnitDataMappers(s.dm), work.UnitWithIDGenerator(gen))
+	s.Require().NoError(err)
+	return u
+}
+
+func (s *IDGeneratorTestSuite) TestUnitUUIDv7() {
+	// action.
+	id, err := work.UnitUUIDv7()
+
+	// assert.
+	s.Require().NoError(err)
+	s.Require().IsType(uuid.UUID{}, id)
+	s.Equal(uuid.Version(7), id.(uuid.UUID).Version())
+}
+
+func (s *IDGeneratorTestSuite) TestUnitULID() {
+	// action.
+	first, err := work.UnitULID()
+	s.Require().NoError(err)
+	second, err := work.UnitULID()
+	s.Require().NoError(err)
+
+	// assert.
+	pattern := regexp.MustCompile("^[0-7][0-9A-HJKMNP-TV-Z]{25}$")
+	s.Regexp(pattern, first)
+	s.Regexp(pattern, second)
+	s.NotEqual(first, second)
+	// the timestamp prefix is shared by identifiers generated within the
+	// same millisecond, and otherwise increases.
+	s.LessOrEqual(first.(string)[:10], second.(string)[:10])
+}
+
+func (s *IDGeneratorTestSuite) TestUnit_Add_StructTag() {
+	// arrange.
+	ctx := context.Background()
+	sut := s.unit(work.UnitULID)
+	missing, present := &test.Qux{Name: "missing"}, &test.Qux{Key: "28", Name: "present"}
+
+	// action.
+	err := sut.Add(ctx, missing, present)
+
+	// assert.
+	s.Require().NoError(err)
+	s.Len(missing.Key, 26)
+	s.Equal("28", present.Key)
+}
+
+func (s *IDGeneratorTestSuite) TestUnit_Add_Setter() {
+	// arrange.
+	ctx := context.Background()
+	sut := s.unit(work.UnitUUIDv7)
+	quux := &test.Quux{}
+
+	// action.
+	err := sut.Add(ctx, quux)
+
+	// assert.
+	s.Require().NoError(err)
+	s.IsType(uuid.UUID{}, quux.ID())
+}
+
+func (s *IDGeneratorTestSuite) TestUnit_Add_NotAssignable() {
+	// arrange.
+	ctx := context.Background()
+	sut := s.unit(work.UnitULID)
+
+	// action.
+	err := sut.Add(ctx, test.Qux{Name: "value"})
+
+	// assert.
+	s.ErrorIs(err, work.ErrUnitIDNotAssignable)
+}
+
+func (s *IDGeneratorTestSuite) TestUnit_Add_GeneratorError() {
+	// arrange.
+	ctx := context.Background()
+	sut := s.unit(func() (interface{}, error) { return nil, errors.New("whoa") })
+
+	// action.
+	err := sut.Add(ctx, &test.Qux{})
+
+	// assert.
+	s.EqualError(err, "whoa")
+}
@@ -0,0 +1,56 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package work
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// dirty determines if the work unit has staged changes. The lock of the work
+// unit must be held.
+func (u *unit) dirty() bool {
+	return u.additionCount+u.alterationCount+u.removalCount+u.upsertCount+
+		u.patchCount+u.criteriaCount > 0
+}
+
+// reportLeak reports the work unit, with the stack it was created with, when
+// it is garbage collected while its staged changes were neither saved nor
+// discarded.
+func (u *unit) reportLeak() {
+	if UnitStatus(atomic.LoadInt32(&u.status)).terminal() {
+		return
+	}
+	u.mutex.RLock()
+	dirty := u.dirty()
+	u.mutex.RUnlock()
+	if !dirty {
+		return
+	}
+	u.logger.Error("work unit was garbage collected without saving its staged changes",
+		"age", time.Since(u.created).String(), "stack", string(u.creationStack))
+}
+
+// collect reports the work unit when it leaked, once the work unit handed to
+// the caller is garbage collected. The active unit registry, the staleness
+// timer, and the cache invalidation retries only hold the state shared with
+// the handle, so they no longer hold it once it has been released.
+func (u *unit) collect() {
+	u.reportLeak()
+	u.untrack()
+	u.stopStale()
+	u.stopInvalidation()
+}
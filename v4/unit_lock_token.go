@@ -0,0 +1,208 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package work
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/google/uuid"
+	"go.uber.org/multierr"
+)
+
+// UnitLockTokenFunc provides the lock token for the provided entity, which
+// changes whenever the persisted state of the entity changes, such as its
+// version or a hash of its state.
+type UnitLockTokenFunc func(entity interface{}) (string, error)
+
+// UnitLockTokenCarrier represents an entity that carries the lock token of
+// the state it was read in, such as across the requests of a web flow, where
+// the token is rendered within an edit form and provided back upon submission.
+type UnitLockTokenCarrier interface {
+	// LockToken provides the lock token of the state the entity was read in.
+	LockToken() string
+}
+
+// UnitHashLockToken provides the hex encoded SHA-256 hash of the JSON
+// encoding of the provided entity as its lock token.
+func UnitHashLockToken(entity interface{}) (string, error) {
+	b, err := json.Marshal(entity)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+func lockTokenKey(t TypeName, id interface{}) string {
+	return "lock-" + cacheKey(t, id)
+}
+
+// unitLockTokens tracks the lock tokens of a work unit.
+type unitLockTokens struct {
+	tokenFunc UnitLockTokenFunc
+	captured  sync.Map
+}
+
+// LockToken provides the lock token captured for the provided entity when it
+// was registered, or when it was last saved, indicating whether one was
+// captured. Tokens are only captured when the work.UnitLockTokens option is
+// used.
+func (u *unit) LockToken(entity interface{}) (string, bool) {
+	id, ok := id(entity)
+	if !ok {
+		return "", false
+	}
+	token, ok := u.locks.captured.Load(lockTokenKey(TypeNameOf(entity), id))
+	if !ok {
+		return "", false
+	}
+	return token.(string), true
+}
+
+// lockTokenEntries provides the cache entries of the lock tokens for the
+// provided entities. Entities with an unresolvable ID are skipped.
+func (u *unit) lockTokenEntries(entities []interface{}) (map[string]interface{}, error) {
+	entries := make(map[string]interface{}, len(entities))
+	for _, entity := range entities {
+		id, ok := id(entity)
+		if !ok {
+			continue
+		}
+		token, err := u.locks.tokenFunc(entity)
+		if err != nil {
+			return nil, err
+		}
+		entries[lockTokenKey(TypeNameOf(entity), id)] = token
+	}
+	return entries, nil
+}
+
+// storeLockTokens captures the provided lock tokens, placing them within the
+// cache so that they can be verified by other work units sharing it.
+func (u *unit) storeLockTokens(ctx context.Context, entries map[string]interface{}) error {
+	for key, token := range entries {
+		u.locks.captured.Store(key, token)
+	}
+	return u.cached.setEntries(ctx, entries)
+}
+
+// captureLockTokens captures the lock tokens of the provided registered
+// entities.
+func (u *unit) captureLockTokens(ctx context.Context, entities []interface{}) error {
+	if u.locks.tokenFunc == nil {
+		return nil
+	}
+	entries, err := u.lockTokenEntries(entities)
+	if err != nil {
+		u.logger.Error(err.Error())
+		return err
+	}
+	return u.cacheFailure(cacheOperationSet, u.storeLockTokens(ctx, entries), UnitCacheFailureModeWarn)
+}
+
+// verifyLockTokens ensures that the lock tokens of the altered and removed
+// entities match the lock tokens within the cache, providing a
+// UnitStaleEntityError for the first entity whose state has since been
+// modified. The expected token is the token carried by the entity, when it
+// is a UnitLockTokenCarrier, and otherwise the token captured when the
+// entity was registered. Entities without an expected token, or without a
+// token within the cache, are not verified.
+func (u *unit) verifyLockTokens(ctx context.Context) error {
+	if u.locks.tokenFunc == nil {
+		return nil
+	}
+	u.mutex.RLock()
+	var entities []interface{}
+	for _, staged := range []map[TypeName][]interface{}{u.alterations, u.removals} {
+		for _, t := range typeNames(staged) {
+			entities = append(entities, staged[t]...)
+		}
+	}
+	u.mutex.RUnlock()
+	for _, entity := range entities {
+		expected, ok := u.LockToken(entity)
+		if carrier, isCarrier := entity.(UnitLockTokenCarrier); isCarrier && carrier.LockToken() != "" {
+			expected, ok = carrier.LockToken(), true
+		}
+		if !ok {
+			continue
+		}
+		id, _ := id(entity)
+		current, err := u.cached.cc.Get(ctx, lockTokenKey(TypeNameOf(entity), id))
+		if err != nil {
+			if err = u.cacheFailure(cacheOperationGet, err, UnitCacheFailureModeFail); err != nil {
+				return err
+			}
+			continue
+		}
+		if current != nil && fmt.Sprint(current) != expected {
+			err := &UnitStaleEntityError{Entity: entity}
+			u.logger.Error(err.Error(), "typeName", TypeNameOf(entity).String())
+			return err
+		}
+	}
+	return nil
+}
+
+// advanceLockTokens captures the lock tokens of the entities that were just
+// saved, so that entities read before the save are detected as stale. The
+// identities of patched entities are assigned new random tokens, as their
+// state is not known, while the tokens of removed entities are discarded.
+// Since the save has already been committed, failures are logged rather than
+// returned.
+func (u *unit) advanceLockTokens(ctx context.Context) {
+	if u.locks.tokenFunc == nil {
+		return
+	}
+	changes := u.changes()
+	var saved []interface{}
+	for _, staged := range []map[TypeName][]interface{}{changes.Additions, changes.Alterations, changes.Upserts} {
+		for _, entities := range staged {
+			saved = append(saved, entities...)
+		}
+	}
+	entries, err := u.lockTokenEntries(saved)
+	if err != nil {
+		u.logger.Warn("unable to capture lock tokens", "error", err.Error())
+		return
+	}
+	for t, patches := range changes.Patches {
+		for _, p := range patches {
+			entries[lockTokenKey(t, p.ID)] = uuid.NewString()
+		}
+	}
+	var removed []string
+	for t, entities := range changes.Removals {
+		for _, entity := range entities {
+			if id, ok := id(entity); ok {
+				removed = append(removed, lockTokenKey(t, id))
+			}
+		}
+	}
+	err = u.storeLockTokens(ctx, entries)
+	if len(removed) > 0 {
+		for _, key := range removed {
+			u.locks.captured.Delete(key)
+		}
+		_, deleteErr := u.cached.deleteKeys(ctx, removed)
+		err = multierr.Append(err, deleteErr)
+	}
+	u.cacheFailure(cacheOperationSet, err, UnitCacheFailureModeWarn)
+}
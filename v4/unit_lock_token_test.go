@@ -0,0 +1,182 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package work_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/freerware/work/v4"
+	"github.com/freerware/work/v4/internal/test"
+	"github.com/stretchr/testify/suite"
+)
+
+type document struct {
+	ID    int
+	Title string
+	Token string `json:"-"`
+}
+
+func (d document) Identifier() interface{} { return d.ID }
+
+func (d document) LockToken() string { return d.Token }
+
+type sharedCache struct {
+	m sync.Map
+}
+
+func (c *sharedCache) Get(ctx context.Context, key string) (interface{}, error) {
+	entry, _ := c.m.Load(key)
+	return entry, nil
+}
+
+func (c *sharedCache) Set(ctx context.Context, key string, entry interface{}) error {
+	c.m.Store(key, entry)
+	return nil
+}
+
+func (c *sharedCache) Delete(ctx context.Context, key string) error {
+	c.m.Delete(key)
+	return nil
+}
+
+type LockTokensTestSuite struct {
+	suite.Suite
+
+	cache   *sharedCache
+	updated []interface{}
+}
+
+func TestLockTokensTestSuite(t *testing.T) {
+	suite.Run(t, new(LockTokensTestSuite))
+}
+
+func (s *LockTokensTestSuite) SetupTest() {
+	s.cache = &sharedCache{}
+	s.updated = nil
+}
+
+func (s *LockTokensTestSuite) unit() work.Unit {
+	update := func(ctx context.Context, mCtx work.UnitMapperContext, e ...interface{}) error {
+		s.updated = append(s.updated, e...)
+		return nil
+	}
+	noop := func(context.Context, work.UnitMapperContext, ...interface{}) error { return nil }
+	var opts []work.UnitOption
+	for _, t := range []work.TypeName{work.TypeNameOf(document{}), work.TypeNameOf(test.Foo{})} {
+		opts = append(opts,
+			work.UnitInsertFunc(t, noop),
+			work.UnitUpdateFunc(t, update),
+			work.UnitDeleteFunc(t, noop),
+		)
+	}
+	patch := func(context.Context, work.UnitMapperContext, ...work.UnitPatch) error { return nil }
+	opts = append(opts,
+		work.UnitPatchFunc(work.TypeNameOf(test.Foo{}), patch),
+		work.UnitWithCacheClient(s.cache),
+		work.UnitLockTokens(nil),
+		work.UnitRetryAttempts(1),
+	)
+	u, err := work.NewUnit(opts...)
+	s.Require().NoError(err)
+	return u
+}
+
+func (s *LockTokensTestSuite) TestSave() {
+	// arrange.
+	ctx := context.Background()
+	read := s.unit()
+	s.Require().NoError(read.Register(ctx, document{ID: 1, Title: "draft"}))
+	token, ok := read.LockToken(document{ID: 1})
+	s.Require().True(ok)
+	draft, err := work.UnitHashLockToken(document{ID: 1, Title: "draft"})
+	s.Require().NoError(err)
+	s.Equal(draft, token)
+	submitted := document{ID: 1, Title: "final", Token: token}
+	sut := s.unit()
+	s.Require().NoError(sut.Alter(ctx, submitted))
+
+	// action.
+	err = sut.Save(ctx)
+
+	// assert.
+	s.Require().NoError(err)
+	s.Equal([]interface{}{submitted}, s.updated)
+	advanced, ok := sut.LockToken(submitted)
+	s.Require().True(ok)
+	expected, _ := work.UnitHashLockToken(document{ID: 1, Title: "final"})
+	s.Equal(expected, advanced)
+}
+
+func (s *LockTokensTestSuite) TestSave_CarriedTokenStale() {
+	// arrange.
+	ctx := context.Background()
+	read := s.unit()
+	s.Require().NoError(read.Register(ctx, document{ID: 1, Title: "draft"}))
+	token, _ := read.LockToken(document{ID: 1})
+	concurrent := s.unit()
+	s.Require().NoError(concurrent.Alter(ctx, document{ID: 1, Title: "theirs"}))
+	s.Require().NoError(concurrent.Save(ctx))
+	s.updated = nil
+	sut := s.unit()
+	s.Require().NoError(sut.Alter(ctx, document{ID: 1, Title: "ours", Token: token}))
+
+	// action.
+	err := sut.Save(ctx)
+
+	// assert.
+	var stale *work.UnitStaleEntityError
+	s.Require().ErrorAs(err, &stale)
+	s.ErrorIs(err, work.ErrStaleEntity)
+	s.Equal(document{ID: 1, Title: "ours", Token: token}, stale.Entity)
+	s.Empty(s.updated)
+}
+
+func (s *LockTokensTestSuite) TestSave_RegisteredTokenStale() {
+	// arrange.
+	ctx := context.Background()
+	sut := s.unit()
+	s.Require().NoError(sut.Register(ctx, test.Foo{ID: 1}))
+	concurrent := s.unit()
+	s.Require().NoError(concurrent.Register(ctx, test.Foo{ID: 1}))
+	s.Require().NoError(concurrent.Patch(ctx, work.TypeNameOf(test.Foo{}), 1, map[string]interface{}{"ID": 1}))
+	s.Require().NoError(concurrent.Save(ctx))
+	s.Require().NoError(sut.Remove(ctx, test.Foo{ID: 1}))
+
+	// action.
+	err := sut.Save(ctx)
+
+	// assert.
+	s.ErrorIs(err, work.ErrStaleEntity)
+}
+
+func (s *LockTokensTestSuite) TestSave_Removal() {
+	// arrange.
+	ctx := context.Background()
+	sut := s.unit()
+	s.Require().NoError(sut.Register(ctx, test.Foo{ID: 1}))
+	s.Require().NoError(sut.Remove(ctx, test.Foo{ID: 1}))
+
+	// action.
+	err := sut.Save(ctx)
+
+	// assert.
+	s.Require().NoError(err)
+	_, ok := sut.LockToken(test.Foo{ID: 1})
+	s.False(ok)
+	entry, _ := s.cache.Get(ctx, "lock-"+string(work.TypeNameOf(test.Foo{}))+"-1")
+	s.Nil(entry)
+}
@@ -15,13 +15,195 @@
 
 package work
 
-import "database/sql"
+import (
+	"database/sql"
+	"sync"
+
+	"github.com/google/uuid"
+)
 
 // UnitMapperContext represents the additional context provided to data mappers
 // and data mapper functions to help facilitate the mapping process.
 type UnitMapperContext struct {
 	// Tx is the open transaction leveraged for SQL-related data mapping
 	// operations. This transaction will be nil unless the work.UnitDB option
-	// is used.
+	// is used. Prefer the ExecContext, QueryContext, QueryRowContext, and
+	// PrepareContext methods, which detect usages of the transaction after
+	// the save attempt has completed.
 	Tx *sql.Tx
+
+	// SaveID uniquely identifies the save operation being performed, and
+	// remains the same across each retry attempt of that save. Data mappers
+	// can leverage it as an idempotency token to prevent duplicate writes
+	// when a save is retried after a commit succeeded but its acknowledgement
+	// was lost.
+	SaveID string
+
+	// AttemptID uniquely identifies the current attempt of the save operation
+	// being performed, and changes with each retry attempt.
+	AttemptID string
+
+	generated   *unitGeneratedIDs
+	upserted    *unitUpsertOutcomes
+	commentTags map[string]string
+	attempt     int
+	maxAttempts int
+	typeName    TypeName
+	guard       *unitTxGuard
+	forced      bool
+	compensate  bool
+	driverName  string
+	conn        *sql.Conn
+}
+
+// Attempt provides the number of the current attempt of the save operation
+// being performed, starting at one.
+func (mCtx UnitMapperContext) Attempt() int {
+	return mCtx.attempt
+}
+
+// IsFinalAttempt indicates if the current attempt of the save operation
+// being performed is the last one, such that it is not retried should it
+// fail. Data mappers can leverage it to fall back to a degraded write path
+// or to emit richer diagnostics.
+func (mCtx UnitMapperContext) IsFinalAttempt() bool {
+	return mCtx.maxAttempts != 0 && mCtx.attempt >= mCtx.maxAttempts
+}
+
+// Forced indicates if the entities are being applied again after a stale
+// conflict was resolved in favor of them, in which case data mappers should
+// write them regardless of the persisted version.
+func (mCtx UnitMapperContext) Forced() bool {
+	return mCtx.forced
+}
+
+// Compensating indicates if the entities are being applied to compensate for
+// the changes applied earlier within a save that is being rolled back, as
+// performed by work units without a database, allowing data mappers to
+// issue dedicated compensating writes.
+func (mCtx UnitMapperContext) Compensating() bool {
+	return mCtx.compensate
+}
+
+// DriverName provides the name of the driver of the SQL store the work unit
+// saves to, such as "postgres", "pgx", "mysql", or "sqlite3", allowing data
+// mappers to build statements in the appropriate dialect. It is empty unless
+// the work.UnitDB option is used and the driver is either recognized or
+// named with the work.UnitDriverName option.
+func (mCtx UnitMapperContext) DriverName() string {
+	return mCtx.driverName
+}
+
+// attempts provides a function creating the mapper context for each attempt
+// of the save identified by the provided save ID.
+func (u *unit) attempts(saveID string) func() UnitMapperContext {
+	attempt := 0
+	return func() UnitMapperContext {
+		attempt = attempt + 1
+		return UnitMapperContext{
+			SaveID:      saveID,
+			AttemptID:   uuid.NewString(),
+			attempt:     attempt,
+			maxAttempts: u.retryAttempts,
+		}
+	}
+}
+
+// unitGeneratedIDs records the identifiers generated by the data store for
+// inserted entities.
+type unitGeneratedIDs struct {
+	mutex    sync.Mutex
+	entities []interface{}
+	ids      []interface{}
+}
+
+// SetGeneratedID reports the identifier generated by the data store for the
+// provided entity, such as one captured with a RETURNING clause or
+// sql.Result.LastInsertId. Once the work unit is saved, the identifier is
+// assigned to the entity and the entity is cached.
+func (mCtx UnitMapperContext) SetGeneratedID(entity, id interface{}) {
+	if mCtx.generated == nil {
+		return
+	}
+	mCtx.generated.mutex.Lock()
+	defer mCtx.generated.mutex.Unlock()
+	mCtx.generated.entities = append(mCtx.generated.entities, entity)
+	mCtx.generated.ids = append(mCtx.generated.ids, id)
+}
+
+// unitUpsertOutcomes records the outcomes of upserts reported by data mappers,
+// which determine how upserts are compensated for when rolling back.
+type unitUpsertOutcomes struct {
+	mutex    sync.Mutex
+	inserted []interface{}
+	replaced []interface{}
+	previous []interface{}
+}
+
+// SetUpsertInserted reports that upserting the provided entity inserted it,
+// such that work units without a database delete it should the save be
+// rolled back.
+func (mCtx UnitMapperContext) SetUpsertInserted(entity interface{}) {
+	if mCtx.upserted == nil {
+		return
+	}
+	mCtx.upserted.mutex.Lock()
+	defer mCtx.upserted.mutex.Unlock()
+	mCtx.upserted.inserted = append(mCtx.upserted.inserted, entity)
+}
+
+// SetUpsertReplaced reports that upserting the provided entity replaced the
+// provided previous state of it, such that work units without a database
+// reapply the previous state should the save be rolled back.
+func (mCtx UnitMapperContext) SetUpsertReplaced(entity, previous interface{}) {
+	if mCtx.upserted == nil {
+		return
+	}
+	mCtx.upserted.mutex.Lock()
+	defer mCtx.upserted.mutex.Unlock()
+	mCtx.upserted.replaced = append(mCtx.upserted.replaced, entity)
+	mCtx.upserted.previous = append(mCtx.upserted.previous, previous)
+}
+
+// compensations provides how the provided upserted entities are compensated
+// for: those reported as inserted are deleted, and the previous states of
+// those reported as replaced are reapplied, unless they are registered, as
+// the registered state is reapplied instead. The remaining entities cannot be
+// compensated for, since deleting them would lose rows that existed prior to
+// the save.
+func (o *unitUpsertOutcomes) compensations(
+	upserted []interface{}, registered map[TypeName][]interface{},
+) (inserted, previous, uncompensated []interface{}) {
+	if o != nil {
+		o.mutex.Lock()
+		defer o.mutex.Unlock()
+	}
+	for _, entity := range upserted {
+		if o != nil && indexOf(o.inserted, entity) >= 0 {
+			inserted = append(inserted, entity)
+			continue
+		}
+		if contains(registered, entity) {
+			continue
+		}
+		if o != nil {
+			if i := indexOf(o.replaced, entity); i >= 0 {
+				previous = append(previous, o.previous[i])
+				continue
+			}
+		}
+		uncompensated = append(uncompensated, entity)
+	}
+	return
+}
+
+// indexOf provides the index of the entity sharing the same identity as the
+// entity provided, or -1 when there is none.
+func indexOf(entities []interface{}, entity interface{}) int {
+	for i, e := range entities {
+		if sameIdentity(e, entity) {
+			return i
+		}
+	}
+	return -1
 }
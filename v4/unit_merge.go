@@ -0,0 +1,34 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package work
+
+// stageAlteration stages the provided entity as an alteration. When merging
+// alterations is enabled, an alteration already staged for the same identity
+// is replaced by the provided entity, so that only a single update is issued
+// for it. The lock of the work unit must be held.
+func (u *unit) stageAlteration(t TypeName, entity interface{}) {
+	if u.mergeAlterations {
+		for i, e := range u.alterations[t] {
+			if sameIdentity(e, entity) {
+				u.alterations[t][i] = entity
+				u.scope.counter(u.metrics.AlterMerged, 1)
+				return
+			}
+		}
+	}
+	u.alterations[t] = append(u.alterations[t], entity)
+	u.alterationCount = u.alterationCount + 1
+}
@@ -0,0 +1,197 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package work
+
+// UnitMetricNames represents the names of the metrics emitted by work units,
+// allowing them to adhere to established naming conventions. Names that are
+// left empty retain their defaults. The timers for each save phase are named
+// after the Save timer, suffixed with the name of the phase.
+type UnitMetricNames struct {
+	// Save is the name of the timer for saves.
+	Save string
+	// SaveSuccess is the name of the counter for successful saves.
+	SaveSuccess string
+	// TxHold is the name of the timer for how long each transaction is held
+	// open, from when it begins until it is committed or rolled back.
+	TxHold string
+	// Rollback is the name of the timer for rollbacks.
+	Rollback string
+	// RollbackSuccess is the name of the counter for successful rollbacks.
+	RollbackSuccess string
+	// RollbackFailure is the name of the counter for failed rollbacks.
+	RollbackFailure string
+	// RollbackRetry is the name of the counter for rollback retries.
+	RollbackRetry string
+	// RetryAttempt is the name of the counter for save retries.
+	RetryAttempt string
+	// Insert is the name of the counter for inserted entities.
+	Insert string
+	// Update is the name of the counter for updated entities.
+	Update string
+	// Delete is the name of the counter for deleted entities.
+	Delete string
+	// Upsert is the name of the counter for upserted entities.
+	Upsert string
+	// Patch is the name of the counter for patched entities.
+	Patch string
+	// DeleteWhere is the name of the counter for deletes by criteria.
+	DeleteWhere string
+	// CacheInsert is the name of the counter for entities inserted into the
+	// cache.
+	CacheInsert string
+	// CacheDelete is the name of the counter for entities deleted from the
+	// cache.
+	CacheDelete string
+	// ChangeRecordFailure is the name of the counter for change records that
+	// could not be emitted.
+	ChangeRecordFailure string
+	// CommitAmbiguous is the name of the counter for commits with an unknown
+	// outcome.
+	CommitAmbiguous string
+	// Quarantine is the name of the counter for quarantined entities.
+	Quarantine string
+	// AlterMerged is the name of the counter for alterations merged into an
+	// alteration already staged for the same identity.
+	AlterMerged string
+	// Stale is the name of the counter for work units that have been open
+	// and dirty past the staleness threshold.
+	Stale string
+	// StaleEvicted is the name of the counter for stale work units whose
+	// staged changes were discarded.
+	StaleEvicted string
+	// Action is the name of the timer for each action performed, tagged with
+	// the type and name of the action.
+	Action string
+	// ActionPanic is the name of the counter for actions that panicked,
+	// tagged with the type and name of the action.
+	ActionPanic string
+	// SaveFailure is the name of the counter for failed saves, tagged with
+	// the class of the failure.
+	SaveFailure string
+	// TxLeak is the name of the counter for usages of the transaction after
+	// the save attempt it belongs to has completed.
+	TxLeak string
+	// PreflightFailure is the name of the counter for saves that failed to
+	// verify the connection to the SQL store before beginning.
+	PreflightFailure string
+	// CacheError is the name of the counter for failed cache operations,
+	// tagged with the operation.
+	CacheError string
+	// CacheInvalidationRetry is the name of the counter for background
+	// attempts to retry failed cache invalidations.
+	CacheInvalidationRetry string
+	// CacheInvalidationFailure is the name of the counter for failed cache
+	// invalidations that were abandoned after exhausting their retries.
+	CacheInvalidationFailure string
+	// MutationGuard is the name of the counter for saves rejected for
+	// staging more changes for a type than its mutation limit.
+	MutationGuard string
+	// CompensationSuccess is the name of the counter for entities whose
+	// changes were compensated for when rolling back work units without a
+	// database, tagged with the type name and the compensated operation.
+	CompensationSuccess string
+	// CompensationFailure is the name of the counter for entities whose
+	// changes could not be compensated for when rolling back work units
+	// without a database, tagged with the type name and the compensated
+	// operation.
+	CompensationFailure string
+}
+
+// defaultUnitMetricNames provides the default names of the metrics emitted by
+// work units.
+func defaultUnitMetricNames() UnitMetricNames {
+	return UnitMetricNames{
+		Save:                     save,
+		SaveSuccess:              saveSuccess,
+		TxHold:                   txHold,
+		Rollback:                 rollback,
+		RollbackSuccess:          rollbackSuccess,
+		RollbackFailure:          rollbackFailure,
+		RollbackRetry:            rollbackRetry,
+		RetryAttempt:             retryAttempt,
+		Insert:                   insert,
+		Update:                   update,
+		Delete:                   delete,
+		Upsert:                   upsert,
+		Patch:                    patch,
+		DeleteWhere:              deleteWhere,
+		CacheInsert:              cacheInsert,
+		CacheDelete:              cacheDelete,
+		ChangeRecordFailure:      changeRecordFailure,
+		CommitAmbiguous:          commitAmbiguous,
+		Quarantine:               quarantine,
+		AlterMerged:              alterMerged,
+		Stale:                    stale,
+		StaleEvicted:             staleEvicted,
+		TxLeak:                   txLeak,
+		SaveFailure:              saveFailure,
+		Action:                   action,
+		ActionPanic:              actionPanic,
+		PreflightFailure:         preflightFailure,
+		CacheError:               cacheError,
+		CacheInvalidationRetry:   invalidationRetry,
+		CacheInvalidationFailure: invalidationFailure,
+		MutationGuard:            mutationGuard,
+		CompensationSuccess:      compensationSuccess,
+		CompensationFailure:      compensationFailure,
+	}
+}
+
+// override provides the names with those that are not empty within the
+// provided overrides replacing their counterparts.
+func (n UnitMetricNames) override(overrides UnitMetricNames) UnitMetricNames {
+	or := func(name, override string) string {
+		if override != "" {
+			return override
+		}
+		return name
+	}
+	return UnitMetricNames{
+		Save:                     or(n.Save, overrides.Save),
+		SaveSuccess:              or(n.SaveSuccess, overrides.SaveSuccess),
+		TxHold:                   or(n.TxHold, overrides.TxHold),
+		Rollback:                 or(n.Rollback, overrides.Rollback),
+		RollbackSuccess:          or(n.RollbackSuccess, overrides.RollbackSuccess),
+		RollbackFailure:          or(n.RollbackFailure, overrides.RollbackFailure),
+		RollbackRetry:            or(n.RollbackRetry, overrides.RollbackRetry),
+		RetryAttempt:             or(n.RetryAttempt, overrides.RetryAttempt),
+		Insert:                   or(n.Insert, overrides.Insert),
+		Update:                   or(n.Update, overrides.Update),
+		Delete:                   or(n.Delete, overrides.Delete),
+		Upsert:                   or(n.Upsert, overrides.Upsert),
+		Patch:                    or(n.Patch, overrides.Patch),
+		DeleteWhere:              or(n.DeleteWhere, overrides.DeleteWhere),
+		CacheInsert:              or(n.CacheInsert, overrides.CacheInsert),
+		CacheDelete:              or(n.CacheDelete, overrides.CacheDelete),
+		ChangeRecordFailure:      or(n.ChangeRecordFailure, overrides.ChangeRecordFailure),
+		CommitAmbiguous:          or(n.CommitAmbiguous, overrides.CommitAmbiguous),
+		Quarantine:               or(n.Quarantine, overrides.Quarantine),
+		AlterMerged:              or(n.AlterMerged, overrides.AlterMerged),
+		Stale:                    or(n.Stale, overrides.Stale),
+		StaleEvicted:             or(n.StaleEvicted, overrides.StaleEvicted),
+		TxLeak:                   or(n.TxLeak, overrides.TxLeak),
+		SaveFailure:              or(n.SaveFailure, overrides.SaveFailure),
+		Action:                   or(n.Action, overrides.Action),
+		ActionPanic:              or(n.ActionPanic, overrides.ActionPanic),
+		PreflightFailure:         or(n.PreflightFailure, overrides.PreflightFailure),
+		CacheError:               or(n.CacheError, overrides.CacheError),
+		CacheInvalidationRetry:   or(n.CacheInvalidationRetry, overrides.CacheInvalidationRetry),
+		CacheInvalidationFailure: or(n.CacheInvalidationFailure, overrides.CacheInvalidationFailure),
+		MutationGuard:            or(n.MutationGuard, overrides.MutationGuard),
+		CompensationSuccess:      or(n.CompensationSuccess, overrides.CompensationSuccess),
+		CompensationFailure:      or(n.CompensationFailure, overrides.CompensationFailure),
+	}
+}
@@ -0,0 +1,107 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package work
+
+import "time"
+
+// UnitMetricsCollector represents a type responsible for collecting the
+// metrics emitted by work units, such that they can be reported to any
+// metrics system. Names are qualified by the metric scope name, separated
+// by a period, such as "unit.save.success", and tagged with the type of the
+// work unit, either "sql" or "best_effort", as "unit_type".
+type UnitMetricsCollector interface {
+	// Counter increments the counter with the provided name and tags by the
+	// provided value.
+	Counter(name string, tags map[string]string, value int64)
+
+	// Timer records the provided duration for the timer with the provided
+	// name and tags.
+	Timer(name string, tags map[string]string, d time.Duration)
+}
+
+// unitScope represents the collector of the metrics emitted by a work unit,
+// qualifying their names with the provided prefix and tagging them with the
+// provided tags. The zero value collects nothing.
+type unitScope struct {
+	collector UnitMetricsCollector
+	prefix    string
+	tags      map[string]string
+}
+
+// merge provides the tags of the scope along with the provided tags, which
+// take precedence.
+func (s unitScope) merge(tags map[string]string) map[string]string {
+	if len(tags) == 0 {
+		return s.tags
+	}
+	if len(s.tags) == 0 {
+		return tags
+	}
+	merged := make(map[string]string, len(s.tags)+len(tags))
+	for k, v := range s.tags {
+		merged[k] = v
+	}
+	for k, v := range tags {
+		merged[k] = v
+	}
+	return merged
+}
+
+// name provides the provided name qualified with the prefix of the scope.
+func (s unitScope) name(name string) string {
+	if s.prefix == "" {
+		return name
+	}
+	return s.prefix + "." + name
+}
+
+func (s unitScope) Counter(name string, tags map[string]string, value int64) {
+	if s.collector == nil {
+		return
+	}
+	s.collector.Counter(s.name(name), s.merge(tags), value)
+}
+
+func (s unitScope) Timer(name string, tags map[string]string, d time.Duration) {
+	if s.collector == nil {
+		return
+	}
+	s.collector.Timer(s.name(name), s.merge(tags), d)
+}
+
+// tagged provides the scope additionally tagging metrics with the provided
+// tags.
+func (s unitScope) tagged(tags map[string]string) unitScope {
+	return unitScope{collector: s.collector, prefix: s.prefix, tags: s.merge(tags)}
+}
+
+// counter increments the counter with the provided name by the provided
+// value.
+func (s unitScope) counter(name string, value int64) {
+	s.Counter(name, nil, value)
+}
+
+// timer records the provided duration for the timer with the provided name.
+func (s unitScope) timer(name string, d time.Duration) {
+	s.Timer(name, nil, d)
+}
+
+// start starts the timer with the provided name, providing the function
+// stopping it.
+func (s unitScope) start(name string) func() {
+	start := time.Now()
+	return func() { s.timer(name, time.Since(start)) }
+}
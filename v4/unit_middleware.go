@@ -0,0 +1,37 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package work
+
+import "context"
+
+// UnitSaveFunc represents the save pipeline of a work unit, or a portion of
+// it wrapped by middleware.
+type UnitSaveFunc func(context.Context) error
+
+// UnitSaveMiddlewareFunc wraps the provided save pipeline of a work unit,
+// such as to trace saves, inject faults, or enforce fault budgets. Middleware
+// can act both before and after invoking the next function, and can skip
+// invoking it altogether by returning an error.
+type UnitSaveMiddlewareFunc func(next UnitSaveFunc) UnitSaveFunc
+
+// withSaveMiddleware provides the provided save pipeline wrapped by the
+// middleware of the work unit, with the first middleware outermost.
+func (u *unit) withSaveMiddleware(save UnitSaveFunc) UnitSaveFunc {
+	for i := len(u.saveMiddleware) - 1; i >= 0; i-- {
+		save = u.saveMiddleware[i](save)
+	}
+	return save
+}
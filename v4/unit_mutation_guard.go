@@ -0,0 +1,92 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package work
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// ErrMutationLimitExceeded represents the error that is returned when saving
+// a work unit staging more changes for a type than its mutation guard
+// permits.
+var ErrMutationLimitExceeded = errors.New("mutation limit exceeded")
+
+// UnitMutationGuardError represents the error that is returned when saving a
+// work unit staging more changes for a type than its mutation guard
+// permits, describing the type.
+type UnitMutationGuardError struct {
+	// TypeName is the type whose changes exceed the limit.
+	TypeName TypeName
+	// Count is the number of changes staged for the type.
+	Count int
+	// Limit is the maximum number of changes permitted for the type.
+	Limit int
+}
+
+// Error provides the error message.
+func (e *UnitMutationGuardError) Error() string {
+	return fmt.Sprintf("%s: %d changes staged for %s exceeds the limit of %d",
+		ErrMutationLimitExceeded, e.Count, e.TypeName, e.Limit)
+}
+
+// Unwrap provides ErrMutationLimitExceeded, so that the error can be
+// identified with errors.Is.
+func (e *UnitMutationGuardError) Unwrap() error {
+	return ErrMutationLimitExceeded
+}
+
+// guardMutations ensures that the number of changes staged for each type
+// with a mutation limit does not exceed it, providing a
+// UnitMutationGuardError for the first type that does. Additions,
+// alterations, removals, upserts, patches, and removal criteria each count
+// as a single change. For work units requiring confirmation, the provided
+// confirmation token must instead confirm the changes exceeding the limits.
+func (u *unit) guardMutations(confirmation string) error {
+	if len(u.mutationLimits) == 0 {
+		return nil
+	}
+	u.mutex.RLock()
+	defer u.mutex.RUnlock()
+	limited := make([]TypeName, 0, len(u.mutationLimits))
+	for t := range u.mutationLimits {
+		limited = append(limited, t)
+	}
+	sort.Slice(limited, func(i, j int) bool { return limited[i] < limited[j] })
+	var exceeded []UnitMutationSummary
+	for _, t := range limited {
+		count := len(u.additions[t]) + len(u.alterations[t]) + len(u.removals[t]) +
+			len(u.upserts[t]) + len(u.patches[t]) + len(u.removalCriteria[t])
+		if limit := u.mutationLimits[t]; count > limit {
+			exceeded = append(exceeded, UnitMutationSummary{TypeName: t, Count: count, Limit: limit})
+		}
+	}
+	if len(exceeded) == 0 {
+		return nil
+	}
+	if u.confirmation != nil {
+		if err := u.confirmation.confirm(confirmation, exceeded); err != nil {
+			u.logger.Info(err.Error())
+			return err
+		}
+		return nil
+	}
+	first := exceeded[0]
+	err := &UnitMutationGuardError{TypeName: first.TypeName, Count: first.Count, Limit: first.Limit}
+	u.logger.Error(err.Error(), "typeName", first.TypeName.String())
+	u.scope.counter(u.metrics.MutationGuard, 1)
+	return err
+}
@@ -20,6 +20,7 @@ import (
 	"database/sql"
 	"log"
 	"log/slog"
+	"sort"
 	"sync"
 	"time"
 
@@ -33,29 +34,102 @@ import (
 // UnitOptions represents the configuration options for the work unit.
 type UnitOptions struct {
 	logger                       UnitLogger
+	tracer                       UnitTracer
 	scope                        tally.Scope
-	actions                      map[UnitActionType][]UnitAction
+	metricsCollector             UnitMetricsCollector
+	metricsScope                 unitScope
+	metricScope                  string
+	metricNames                  UnitMetricNames
+	actions                      map[UnitActionType][]unitAction
+	rollbackActions              []UnitRollbackAction
+	saveActions                  []UnitSaveAction
 	disableDefaultLoggingActions bool
+	haltActionsOnFailure         bool
 	db                           *sql.DB
 	retryAttempts                int
 	retryDelay                   time.Duration
 	retryMaximumJitter           time.Duration
 	retryType                    UnitRetryDelayType
+	rollbackRetryAttempts        int
+	rollbackRetryDelay           time.Duration
 	insertFuncs                  map[TypeName]UnitDataMapperFunc
 	insertFuncsLen               int
 	updateFuncs                  map[TypeName]UnitDataMapperFunc
 	updateFuncsLen               int
 	deleteFuncs                  map[TypeName]UnitDataMapperFunc
 	deleteFuncsLen               int
+	upsertFuncs                  map[TypeName]UnitDataMapperFunc
+	upsertFuncsLen               int
+	patchFuncs                   map[TypeName]UnitPatchDataMapperFunc
+	patchFuncsLen                int
+	deleteWhereFuncs             map[TypeName]UnitDataMapperFunc
+	deleteWhereFuncsLen          int
+	refreshFuncs                 map[TypeName]UnitDataMapperFunc
+	refreshAfterSave             bool
+	commitTokenizer              UnitCommitTokenizer
+	sessionSettings              map[string]string
+	sqlCommentTags               map[string]string
+	slowSaveThreshold            time.Duration
+	slowSaveHandler              UnitSlowSaveHandler
+	slowSaveStacks               bool
+	detectReentrancy             bool
+	deferredStagingPasses        int
+	projections                  map[TypeName][]UnitProjectionBuilder
+	rejectNilEntities            bool
+	strictTracking               bool
+	uniqueChecks                 map[TypeName][]UnitUniqueCheckFunc
+	retryFuncs                   []UnitRetryFunc
+	goroutineAudit               UnitGoroutineAuditMode
+	mergeAlterations             bool
+	conflictResolver             UnitConflictResolverFunc
+	staleAfter                   time.Duration
+	evictStale                   bool
+	preflightPing                bool
+	driverName                   string
+	allowedTypes                 map[TypeName]struct{}
+	deniedTypes                  map[TypeName]struct{}
+	authorizer                   UnitAuthorizerFunc
+	cacheKeys                    UnitCacheKeySource
+	cacheFailureMode             UnitCacheFailureMode
+	invalidationRetryAttempts    int
+	invalidationRetryDelay       time.Duration
+	invalidationQueueSize        int
+	bulkWriter                   UnitBulkWriteFunc
+	bulkTypes                    map[TypeName]struct{}
+	trackActive                  bool
+	detectLeaks                  bool
+	saveMiddleware               []UnitSaveMiddlewareFunc
+	appendOnly                   bool
+	readOnly                     bool
+	readIsolation                sql.IsolationLevel
+	verifyStatements             []string
+	events                       *unitEventSourcing
+	aggregateRoots               map[TypeName][]TypeName
+	lockTokenFunc                UnitLockTokenFunc
+	retryHistory                 bool
+	retryHistoryLimit            int
+	mutationLimits               map[TypeName]int
+	codecs                       map[TypeName]UnitCodec
+	defaultCodec                 UnitCodec
+	confirmMutations             bool
+	awaitToken                   string
 	cacheClient                  UnitCacheClient
+	deferConstraints             bool
+	deferredConstraints          []string
+	changeRecordSink             UnitChangeRecordSink
+	maxOperationsPerTransaction  int
+	quarantineAfter              int
+	idGenerator                  UnitIDGenerator
+	quarantineSink               UnitQuarantineSink
 }
 
 func (uo *UnitOptions) totalDataMapperFuncs() int {
-	return uo.insertFuncsLen + uo.updateFuncsLen + uo.deleteFuncsLen
+	return uo.insertFuncsLen + uo.updateFuncsLen + uo.deleteFuncsLen +
+		uo.upsertFuncsLen + uo.patchFuncsLen + uo.deleteWhereFuncsLen
 }
 
 func (uo *UnitOptions) hasDataMapperFuncs() bool {
-	return uo.totalDataMapperFuncs() != 0
+	return uo.totalDataMapperFuncs() != 0 || len(uo.bulkTypes) != 0
 }
 
 func (uo *UnitOptions) iFuncs() (funcs *sync.Map) {
@@ -94,6 +168,70 @@ func (uo *UnitOptions) dFuncs() (funcs *sync.Map) {
 	return
 }
 
+func (uo *UnitOptions) upFuncs() (funcs *sync.Map) {
+	if uo.upsertFuncs == nil {
+		return
+	}
+
+	funcs = &sync.Map{}
+	for t, f := range uo.upsertFuncs {
+		funcs.Store(t, f)
+	}
+	return
+}
+
+func (uo *UnitOptions) pFuncs() (funcs *sync.Map) {
+	if uo.patchFuncs == nil {
+		return
+	}
+
+	funcs = &sync.Map{}
+	for t, f := range uo.patchFuncs {
+		funcs.Store(t, f)
+	}
+	return
+}
+
+func (uo *UnitOptions) dwFuncs() (funcs *sync.Map) {
+	if uo.deleteWhereFuncs == nil {
+		return
+	}
+
+	funcs = &sync.Map{}
+	for t, f := range uo.deleteWhereFuncs {
+		funcs.Store(t, f)
+	}
+	return
+}
+
+func (uo *UnitOptions) rFuncs() (funcs *sync.Map) {
+	if uo.refreshFuncs == nil {
+		return
+	}
+
+	funcs = &sync.Map{}
+	for t, f := range uo.refreshFuncs {
+		funcs.Store(t, f)
+	}
+	return
+}
+
+// addAction inserts the provided action after the actions of the provided
+// type with the same or higher priority.
+func (uo *UnitOptions) addAction(t UnitActionType, a unitAction) {
+	if uo.actions == nil {
+		uo.actions = make(map[UnitActionType][]unitAction)
+	}
+	actions := uo.actions[t]
+	i := sort.Search(len(actions), func(i int) bool {
+		return actions[i].priority < a.priority
+	})
+	actions = append(actions, unitAction{})
+	copy(actions[i+1:], actions[i:])
+	actions[i] = a
+	uo.actions[t] = actions
+}
+
 // UnitOption applies an option to the provided configuration.
 type UnitOption func(*UnitOptions)
 
@@ -125,6 +263,10 @@ const (
 // operation, such as insert, update, or delete.
 type UnitDataMapperFunc func(context.Context, UnitMapperContext, ...interface{}) error
 
+// UnitPatchDataMapperFunc represents a data mapper function that applies
+// partial updates to existing entities.
+type UnitPatchDataMapperFunc func(context.Context, UnitMapperContext, ...UnitPatch) error
+
 var (
 	// UnitDB specifies the option to provide the database for the work unit.
 	UnitDB = func(db *sql.DB) UnitOption {
@@ -190,21 +332,120 @@ var (
 		}
 	}
 
+	// UnitWithTracer specifies the option to provide a tracer for the work
+	// unit, which traces each save and rollback, along with each invocation
+	// of a data mapper function, as spans annotated with the type of work
+	// unit, the type of the entities, the number of entities, and the save
+	// attempt. Adapters for tracing systems, such as OpenTelemetry, are
+	// provided by dedicated modules.
+	UnitWithTracer = func(t UnitTracer) UnitOption {
+		return func(o *UnitOptions) {
+			o.tracer = t
+		}
+	}
+
+	// UnitWithMetricsCollector specifies the option to provide a metrics
+	// collector for the work unit, which collects each of the counters and
+	// timers emitted by the work unit, and takes precedence over the
+	// work.UnitTallyMetricScope option. Adapters for metrics systems, such as
+	// tally, Prometheus, and OpenTelemetry, are provided by dedicated
+	// modules.
+	UnitWithMetricsCollector = func(c UnitMetricsCollector) UnitOption {
+		return func(o *UnitOptions) {
+			o.metricsCollector = c
+		}
+	}
+
 	// UnitTallyMetricScope specifies the option to provide a tally metric
-	// scope for the work unit.
+	// scope for the work unit, whose metrics are collected within it unless
+	// a metrics collector is provided with the work.UnitWithMetricsCollector
+	// option.
+	//
+	// Deprecated: Use worktally.WithScope, or work.UnitWithMetricsCollector
+	// with a worktally.Collector, instead.
 	UnitTallyMetricScope = func(s tally.Scope) UnitOption {
 		return func(o *UnitOptions) {
 			o.scope = s
 		}
 	}
 
+	// UnitMetricScope specifies the option to name the scope that qualifies
+	// the names of the metrics provided to metrics collectors, which defaults
+	// to "unit", such as "unit.save.success". When empty, names are not
+	// qualified. The tally metric scope provided with the
+	// work.UnitTallyMetricScope option is likewise sub-scoped with it.
+	UnitMetricScope = func(name string) UnitOption {
+		return func(o *UnitOptions) {
+			o.metricScope = name
+		}
+	}
+
+	// UnitWithMetricNames specifies the option to override the names of the
+	// metrics emitted by the work unit, such as to adhere to established
+	// naming conventions. Names that are left empty retain their defaults.
+	UnitWithMetricNames = func(names UnitMetricNames) UnitOption {
+		return func(o *UnitOptions) {
+			o.metricNames = o.metricNames.override(names)
+		}
+	}
+
 	// setActions appends the provided actions as the provided action type.
 	setActions = func(t UnitActionType, a ...UnitAction) UnitOption {
+		return UnitActionsWithPriority(t, 0, a...)
+	}
+
+	// UnitActionsWithPriority specifies the option to provide actions to
+	// execute for the provided action type with the provided priority.
+	// Actions with a higher priority are executed before those with a lower
+	// priority, and actions sharing the same priority are executed in the
+	// order they are provided. Actions provided without a priority have a
+	// priority of zero.
+	UnitActionsWithPriority = func(t UnitActionType, priority int, a ...UnitAction) UnitOption {
 		return func(o *UnitOptions) {
-			if o.actions == nil {
-				o.actions = make(map[UnitActionType][]UnitAction)
+			for _, action := range a {
+				o.addAction(t, unitAction{action: action, priority: priority})
 			}
-			o.actions[t] = append(o.actions[t], a...)
+		}
+	}
+
+	// UnitNamedAction specifies the option to provide an action to execute
+	// for the provided action type that is identified by the provided name,
+	// allowing it to be removed from the work unit by name.
+	UnitNamedAction = func(name string, t UnitActionType, a UnitAction) UnitOption {
+		return func(o *UnitOptions) {
+			o.addAction(t, unitAction{action: a, name: name})
+		}
+	}
+
+	// UnitConditionalAction specifies the option to provide an action to
+	// execute for the provided action type only when the provided predicate
+	// is satisfied, such as when specific entity types were modified.
+	UnitConditionalAction = func(
+		t UnitActionType,
+		predicate func(UnitActionContext) bool,
+		a UnitAction,
+	) UnitOption {
+		return func(o *UnitOptions) {
+			o.addAction(t, unitAction{action: a, when: predicate})
+		}
+	}
+
+	// UnitBeforeSaveActionsWithPriority specifies the option to provide
+	// actions to execute before the work unit is saved with the provided
+	// priority.
+	UnitBeforeSaveActionsWithPriority = func(priority int, a ...UnitAction) UnitOption {
+		return UnitActionsWithPriority(UnitActionTypeBeforeSave, priority, a...)
+	}
+
+	// UnitHaltActionsOnFailure specifies the option to stop executing the
+	// subsequent actions of the same type when an action fails by panicking.
+	// The failure is surfaced as a UnitActionPanicError, which is
+	// ErrUnitActionFailed, from the operation that triggered the actions
+	// whenever the action type precedes it, such as before save or before an
+	// entity is added. Without this option, the failure is only reported.
+	UnitHaltActionsOnFailure = func() UnitOption {
+		return func(o *UnitOptions) {
+			o.haltActionsOnFailure = true
 		}
 	}
 
@@ -232,12 +473,54 @@ var (
 		return setActions(UnitActionTypeAfterRemove, a...)
 	}
 
+	// UnitAfterUpsertActions specifies the option to provide actions to execute
+	// after entities are upserted with the work unit.
+	UnitAfterUpsertActions = func(a ...UnitAction) UnitOption {
+		return setActions(UnitActionTypeAfterUpsert, a...)
+	}
+
+	// UnitAfterPatchActions specifies the option to provide actions to execute
+	// after entities are patched with the work unit.
+	UnitAfterPatchActions = func(a ...UnitAction) UnitOption {
+		return setActions(UnitActionTypeAfterPatch, a...)
+	}
+
+	// UnitAfterRemoveWhereActions specifies the option to provide actions to
+	// execute after entities matching criteria are removed with the work unit.
+	UnitAfterRemoveWhereActions = func(a ...UnitAction) UnitOption {
+		return setActions(UnitActionTypeAfterRemoveWhere, a...)
+	}
+
+	// UnitRollbackActions specifies the option to provide actions to execute
+	// after the work unit is rolled back, which are provided the error that
+	// triggered the rollback.
+	UnitRollbackActions = func(a ...UnitRollbackAction) UnitOption {
+		return func(o *UnitOptions) {
+			o.rollbackActions = append(o.rollbackActions, a...)
+		}
+	}
+
+	// UnitSaveActions specifies the option to provide actions to execute
+	// after the work unit is saved, which are provided the duration of the
+	// save as well as the error encountered, if any.
+	UnitSaveActions = func(a ...UnitSaveAction) UnitOption {
+		return func(o *UnitOptions) {
+			o.saveActions = append(o.saveActions, a...)
+		}
+	}
+
 	// UnitAfterInsertsActions specifies the option to provide actions to execute
 	// after new entities are inserted in the data store.
 	UnitAfterInsertsActions = func(a ...UnitAction) UnitOption {
 		return setActions(UnitActionTypeAfterInserts, a...)
 	}
 
+	// UnitAfterUpsertsActions specifies the option to provide actions to execute
+	// after upserted entities are upserted in the data store.
+	UnitAfterUpsertsActions = func(a ...UnitAction) UnitOption {
+		return setActions(UnitActionTypeAfterUpserts, a...)
+	}
+
 	// UnitAfterUpdatesActions specifies the option to provide actions to execute
 	// after altered entities are updated in the data store.
 	UnitAfterUpdatesActions = func(a ...UnitAction) UnitOption {
@@ -268,6 +551,12 @@ var (
 		return setActions(UnitActionTypeBeforeInserts, a...)
 	}
 
+	// UnitBeforeUpsertsActions specifies the option to provide actions to execute
+	// before upserted entities are upserted in the data store.
+	UnitBeforeUpsertsActions = func(a ...UnitAction) UnitOption {
+		return setActions(UnitActionTypeBeforeUpserts, a...)
+	}
+
 	// UnitBeforeUpdatesActions specifies the option to provide actions to execute
 	// before altered entities are updated in the data store.
 	UnitBeforeUpdatesActions = func(a ...UnitAction) UnitOption {
@@ -300,27 +589,41 @@ var (
 		afterInsertLogAction := func(ctx UnitActionContext) {
 			ctx.Logger.Debug("successfully inserted entities", "count", ctx.AdditionCount)
 		}
+		beforeUpsertLogAction := func(ctx UnitActionContext) {
+			ctx.Logger.Debug("attempting to upsert entities", "count", ctx.UpsertCount)
+		}
+		afterUpsertLogAction := func(ctx UnitActionContext) {
+			ctx.Logger.Debug("successfully upserted entities", "count", ctx.UpsertCount)
+		}
 		beforeUpdateLogAction := func(ctx UnitActionContext) {
-			ctx.Logger.Debug("attempting to update entities", "count", ctx.AlterationCount)
+			ctx.Logger.Debug("attempting to update entities",
+				"count", ctx.AlterationCount, "patchCount", ctx.PatchCount)
 		}
 		afterUpdateLogAction := func(ctx UnitActionContext) {
-			ctx.Logger.Debug("successfully updated entities", "count", ctx.AlterationCount)
+			ctx.Logger.Debug("successfully updated entities",
+				"count", ctx.AlterationCount, "patchCount", ctx.PatchCount)
 		}
 		beforeDeleteLogAction := func(ctx UnitActionContext) {
-			ctx.Logger.Debug("attempting to delete entities", "count", ctx.RemovalCount)
+			ctx.Logger.Debug("attempting to delete entities",
+				"count", ctx.RemovalCount, "criteriaCount", ctx.RemovalCriteriaCount)
 		}
 		afterDeleteLogAction := func(ctx UnitActionContext) {
-			ctx.Logger.Debug("successfully deleted entities", "count", ctx.RemovalCount)
+			ctx.Logger.Debug("successfully deleted entities",
+				"count", ctx.RemovalCount, "criteriaCount", ctx.RemovalCriteriaCount)
 		}
 		beforeSaveLogAction := func(ctx UnitActionContext) {
 			ctx.Logger.Debug("attempting to save unit")
 		}
 		afterSaveLogAction := func(ctx UnitActionContext) {
-			totalCount := ctx.AdditionCount + ctx.AlterationCount + ctx.RemovalCount
+			totalCount := ctx.AdditionCount + ctx.AlterationCount +
+				ctx.RemovalCount + ctx.UpsertCount + ctx.PatchCount
 			ctx.Logger.Info("successfully saved unit",
 				"insertCount", ctx.AdditionCount,
+				"upsertCount", ctx.UpsertCount,
 				"updateCount", ctx.AlterationCount,
 				"deleteCount", ctx.RemovalCount,
+				"patchCount", ctx.PatchCount,
+				"deleteCriteriaCount", ctx.RemovalCriteriaCount,
 				"registerCount", ctx.RegisterCount,
 				"totalUpdateCount", totalCount)
 		}
@@ -334,6 +637,8 @@ var (
 			subOpts := []UnitOption{
 				setActions(UnitActionTypeBeforeInserts, beforeInsertLogAction),
 				setActions(UnitActionTypeAfterInserts, afterInsertLogAction),
+				setActions(UnitActionTypeBeforeUpserts, beforeUpsertLogAction),
+				setActions(UnitActionTypeAfterUpserts, afterUpsertLogAction),
 				setActions(UnitActionTypeBeforeUpdates, beforeUpdateLogAction),
 				setActions(UnitActionTypeAfterUpdates, afterUpdateLogAction),
 				setActions(UnitActionTypeBeforeDeletes, beforeDeleteLogAction),
@@ -388,6 +693,29 @@ var (
 		}
 	}
 
+	// UnitRollbackRetryAttempts defines the number of attempts to perform
+	// when rolling back a best effort work unit, independent of the attempts
+	// performed when saving. Zero indicates attempts are performed until the
+	// rollback succeeds. Combined with UnitRetryAttempts(1), the work unit is
+	// saved once while its compensating operations are retried, which
+	// requires the data mappers to tolerate reapplying them.
+	UnitRollbackRetryAttempts = func(attempts int) UnitOption {
+		if attempts < 0 {
+			attempts = 0
+		}
+		return func(o *UnitOptions) {
+			o.rollbackRetryAttempts = attempts
+		}
+	}
+
+	// UnitRollbackRetryDelay defines the delay to utilize during rollback
+	// retries.
+	UnitRollbackRetryDelay = func(delay time.Duration) UnitOption {
+		return func(o *UnitOptions) {
+			o.rollbackRetryDelay = delay
+		}
+	}
+
 	// UnitInsertFunc defines the function to be used for inserting new
 	// entities in the underlying data store.
 	UnitInsertFunc = func(t TypeName, insertFunc UnitDataMapperFunc) UnitOption {
@@ -424,6 +752,672 @@ var (
 		}
 	}
 
+	// UnitDeferConstraints specifies the option to defer the checking of
+	// constraints until the transaction is committed, allowing entities with
+	// cyclic foreign key relationships to be saved without manual ordering.
+	// When constraint names are provided, only those constraints are deferred;
+	// otherwise, all deferrable constraints are deferred. Constraint names are
+	// quoted, and are therefore case-sensitive, optionally qualified by their
+	// schema. This option only applies to work units that leverage the
+	// work.UnitDB option.
+	UnitDeferConstraints = func(constraints ...string) UnitOption {
+		return func(o *UnitOptions) {
+			o.deferConstraints = true
+			o.deferredConstraints = append(o.deferredConstraints, constraints...)
+		}
+	}
+
+	// UnitWithSessionSettings specifies the option to apply the provided
+	// settings at the start of every transaction, equivalent to SET LOCAL,
+	// such as to provide the tenant to row-level security policies via
+	// app.tenant_id. This option only applies to work units that leverage
+	// the work.UnitDB option.
+	UnitWithSessionSettings = func(settings map[string]string) UnitOption {
+		return func(o *UnitOptions) {
+			if o.sessionSettings == nil {
+				o.sessionSettings = make(map[string]string)
+			}
+			for name, value := range settings {
+				o.sessionSettings[name] = value
+			}
+		}
+	}
+
+	// UnitSQLComments specifies the option to annotate the statements
+	// executed through work.UnitMapperContext with a comment containing the
+	// provided tags, such as the service and endpoint, along with the save
+	// and attempt identifiers, following the sqlcommenter conventions. This
+	// allows slow queries to be attributed to the work units issuing them.
+	// This option only applies to work units that leverage the work.UnitDB
+	// option.
+	UnitSQLComments = func(tags map[string]string) UnitOption {
+		return func(o *UnitOptions) {
+			if o.sqlCommentTags == nil {
+				o.sqlCommentTags = make(map[string]string)
+			}
+			for k, v := range tags {
+				o.sqlCommentTags[k] = v
+			}
+		}
+	}
+
+	// UnitSlowSaveThreshold specifies the option to invoke the provided
+	// handler with a diagnostic snapshot of each save that takes at least
+	// the provided duration, including the number of entities per type, the
+	// time taken by each phase, and the number of attempts performed.
+	UnitSlowSaveThreshold = func(d time.Duration, handler UnitSlowSaveHandler) UnitOption {
+		return func(o *UnitOptions) {
+			o.slowSaveThreshold = d
+			o.slowSaveHandler = handler
+		}
+	}
+
+	// UnitSlowSaveStacks specifies the option to include the stack traces of
+	// all goroutines in the diagnostic snapshot of slow saves. Capturing the
+	// stack traces briefly stops the world, so it is disabled by default.
+	UnitSlowSaveStacks = func() UnitOption {
+		return func(o *UnitOptions) {
+			o.slowSaveStacks = true
+		}
+	}
+
+	// UnitDetectReentrancy specifies the option to panic with a diagnostic
+	// message when entities are staged into the work unit while it is being
+	// saved, such as by a data mapper calling Add, Alter, or Remove on the
+	// work unit it is invoked by. This is intended for use during
+	// development and testing.
+	UnitDetectReentrancy = func() UnitOption {
+		return func(o *UnitOptions) {
+			o.detectReentrancy = true
+		}
+	}
+
+	// UnitDetectLeaks specifies the option to log an error, along with the
+	// stack the work unit was created with, when the work unit is garbage
+	// collected while it has staged changes that were neither saved nor
+	// discarded, such as when a call to Save is forgotten. Capturing the stack
+	// of each work unit is costly, so this is intended for use in development
+	// and staging environments. Work units tracked with UnitTrackActive are
+	// removed from the active unit registry once reported.
+	UnitDetectLeaks = func() UnitOption {
+		return func(o *UnitOptions) {
+			o.detectLeaks = true
+		}
+	}
+
+	// UnitDeferredStaging specifies the option to allow data mappers and
+	// actions to stage entities into the work unit while it is being saved,
+	// such as to derive audit rows or denormalized projections. The entities
+	// staged are applied in subsequent passes within the same transaction,
+	// up to the provided number of passes, after which the save fails with
+	// ErrUnitStagingPassesExceeded. Phase actions are not executed for these
+	// passes. This option only applies to work units that leverage the
+	// work.UnitDB option.
+	UnitDeferredStaging = func(maxPasses int) UnitOption {
+		return func(o *UnitOptions) {
+			o.deferredStagingPasses = maxPasses
+		}
+	}
+
+	// UnitWithProjection specifies the option to stage the read model rows
+	// derived by the provided builder whenever entities of the provided type
+	// are added, altered, upserted, or removed, such that the rows are staged
+	// in the same manner as their source entities, and are therefore saved
+	// within the same transaction. Data mapper functions must be provided for
+	// the types of the derived rows.
+	UnitWithProjection = func(from TypeName, builder UnitProjectionBuilder) UnitOption {
+		return func(o *UnitOptions) {
+			if o.projections == nil {
+				o.projections = make(map[TypeName][]UnitProjectionBuilder)
+			}
+			o.projections[from] = append(o.projections[from], builder)
+		}
+	}
+
+	// UnitRejectNilEntities specifies the option to reject zero-value
+	// entities, whose fields are all unset, when they are staged, returning
+	// ErrInvalidEntity immediately rather than failing obscurely within a
+	// data mapper. Nil entities are always rejected.
+	UnitRejectNilEntities = func() UnitOption {
+		return func(o *UnitOptions) {
+			o.rejectNilEntities = true
+		}
+	}
+
+	// UnitStrictTracking specifies the option to require entities to be
+	// registered or added to the work unit before they are altered or
+	// removed, returning ErrUntrackedEntity otherwise. This catches entities
+	// that are modified without being loaded first.
+	UnitStrictTracking = func() UnitOption {
+		return func(o *UnitOptions) {
+			o.strictTracking = true
+		}
+	}
+
+	// UnitUniqueCheck specifies the option to verify that the entities of
+	// the provided type being inserted, upserted, or updated do not violate
+	// a uniqueness constraint at the start of each save, before any writes
+	// occur. For work units that leverage the work.UnitDB option, the check
+	// is executed within the transaction. Failed checks are returned as a
+	// *UnitUniquenessError and are not retried.
+	UnitUniqueCheck = func(t TypeName, check UnitUniqueCheckFunc) UnitOption {
+		return func(o *UnitOptions) {
+			if o.uniqueChecks == nil {
+				o.uniqueChecks = make(map[TypeName][]UnitUniqueCheckFunc)
+			}
+			o.uniqueChecks[t] = append(o.uniqueChecks[t], check)
+		}
+	}
+
+	// UnitOnRetry specifies the option to invoke the provided function before
+	// each save of the work unit is retried, in addition to the built-in
+	// logging and metrics, such as to annotate a trace span.
+	UnitOnRetry = func(f UnitRetryFunc) UnitOption {
+		return func(o *UnitOptions) {
+			o.retryFuncs = append(o.retryFuncs, f)
+		}
+	}
+
+	// UnitGoroutineAudit specifies the option to audit the goroutines that
+	// use the work unit, reacting with the provided mode when the work unit
+	// is used by a goroutine other than the one that created it. Intended
+	// for debugging, as identifying the calling goroutine is costly.
+	UnitGoroutineAudit = func(mode UnitGoroutineAuditMode) UnitOption {
+		return func(o *UnitOptions) {
+			o.goroutineAudit = mode
+		}
+	}
+
+	// UnitMergeAlterations specifies the option to merge repeated alterations
+	// of entities sharing the same identity into the latest of them, so that
+	// only a single update is issued for each identity when the work unit is
+	// saved.
+	UnitMergeAlterations = func() UnitOption {
+		return func(o *UnitOptions) {
+			o.mergeAlterations = true
+		}
+	}
+
+	// UnitConflictResolver specifies the option to resolve conflicts with the
+	// provided resolver rather than failing, both between operations staged
+	// for the same identity, such as removing an added entity, and between
+	// entities being written and their persisted versions, as reported by
+	// data mappers with UnitStaleEntityError.
+	UnitConflictResolver = func(resolver UnitConflictResolverFunc) UnitOption {
+		return func(o *UnitOptions) {
+			o.conflictResolver = resolver
+		}
+	}
+
+	// UnitStaleAfter specifies the option to report work units that have been
+	// open and dirty without being saved for longer than the provided
+	// duration, such as those held by pooled or batching uniters, repeating
+	// the report every duration thereafter. When evict is true, the staged
+	// changes of such work units are instead discarded, and staging entities
+	// into or saving them results in ErrUnitEvicted.
+	UnitStaleAfter = func(d time.Duration, evict bool) UnitOption {
+		return func(o *UnitOptions) {
+			o.staleAfter = d
+			o.evictStale = evict
+		}
+	}
+
+	// UnitPreflightPing specifies the option to verify that a healthy
+	// connection to the SQL store is available before saving, so that
+	// connection pool failures result in ErrPreflightPing before any data
+	// mappers are invoked.
+	UnitPreflightPing = func() UnitOption {
+		return func(o *UnitOptions) {
+			o.preflightPing = true
+		}
+	}
+
+	// UnitDriverName specifies the option to classify the failures of the
+	// work unit with the error classifier registered for the driver with the
+	// provided name, rather than the one detected from the driver type. The
+	// name is also provided to data mappers by UnitMapperContext.DriverName.
+	UnitDriverName = func(name string) UnitOption {
+		return func(o *UnitOptions) {
+			o.driverName = name
+		}
+	}
+
+	// UnitAllowTypes specifies the option to only allow entities of the
+	// provided types to be staged within the work unit, such as for work
+	// units constructed for a specific bounded context, with entities of any
+	// other type being rejected with UnitTypeNotAllowedError.
+	UnitAllowTypes = func(types ...TypeName) UnitOption {
+		return func(o *UnitOptions) {
+			o.allowedTypes = typeSet(o.allowedTypes, types)
+		}
+	}
+
+	// UnitDenyTypes specifies the option to reject entities of the provided
+	// types from being staged within the work unit with
+	// UnitTypeNotAllowedError.
+	UnitDenyTypes = func(types ...TypeName) UnitOption {
+		return func(o *UnitOptions) {
+			o.deniedTypes = typeSet(o.deniedTypes, types)
+		}
+	}
+
+	// UnitWithAuthorizer specifies the option to authorize each operation
+	// staged within the work unit with the provided authorizer when saving,
+	// using the context provided to Save. Saves containing a forbidden
+	// operation fail with UnitForbiddenError before any data mappers are
+	// invoked.
+	UnitWithAuthorizer = func(authorizer UnitAuthorizerFunc) UnitOption {
+		return func(o *UnitOptions) {
+			o.authorizer = authorizer
+		}
+	}
+
+	// UnitCacheEncryption specifies the option to encrypt the entities
+	// cached by the work unit with AES-GCM using the keys from the provided
+	// source, such as when using external cache clients shared with other
+	// processes, since registered entities often contain sensitive data.
+	// Entities are encoded as JSON before being encrypted.
+	UnitCacheEncryption = func(keys UnitCacheKeySource) UnitOption {
+		return func(o *UnitOptions) {
+			o.cacheKeys = keys
+		}
+	}
+
+	// UnitCacheFailurePolicy specifies the option to handle failures of the
+	// cache client when setting or deleting cached entities with the provided
+	// mode, so that an unavailable cache does not necessarily fail the work
+	// unit. Failures are always counted. By default, failures to cache
+	// registered entities are logged as warnings, while failures to
+	// invalidate cached entities are returned.
+	UnitCacheFailurePolicy = func(mode UnitCacheFailureMode) UnitOption {
+		return func(o *UnitOptions) {
+			o.cacheFailureMode = mode
+		}
+	}
+
+	// UnitCacheInvalidationRetry specifies the option to retry the cache
+	// invalidations that fail without failing the caller, as dictated by the
+	// cache failure policy, in the background up to the provided number of
+	// attempts. The provided delay precedes the first attempt and backs off
+	// exponentially between subsequent attempts, allowing external caches to
+	// converge rather than holding stale entities indefinitely.
+	UnitCacheInvalidationRetry = func(attempts int, delay time.Duration) UnitOption {
+		if attempts < 0 {
+			attempts = 0
+		}
+		return func(o *UnitOptions) {
+			o.invalidationRetryAttempts = attempts
+			o.invalidationRetryDelay = delay
+		}
+	}
+
+	// UnitCacheInvalidationQueueSize specifies the option to bound the number
+	// of failed cache invalidations awaiting retry in the background, as
+	// enabled by the work.UnitCacheInvalidationRetry option. Invalidations
+	// that fail while the retries are at capacity fail the caller with
+	// ErrCacheInvalidationQueueFull. Defaults to 1024.
+	UnitCacheInvalidationQueueSize = func(size int) UnitOption {
+		if size < 1 {
+			size = 1
+		}
+		return func(o *UnitOptions) {
+			o.invalidationQueueSize = size
+		}
+	}
+
+	// UnitBulkWriter specifies the option to apply the changes to entities of
+	// the provided types with a single request to the provided bulk write
+	// function when saving, rather than with their data mapper functions,
+	// such as for data stores without transactions that support batching
+	// writes. The bulk write precedes the data mapper functions of other
+	// types. Operations that fail are provided as UnitOperationErrors, and
+	// the operations that were applied are compensated for with another bulk
+	// write on a best-effort basis should the save fail. Entities removed by
+	// criteria continue to be removed with their data mapper functions. The
+	// option is ignored by work units with a database.
+	UnitBulkWriter = func(f UnitBulkWriteFunc, types ...TypeName) UnitOption {
+		return func(o *UnitOptions) {
+			o.bulkWriter = f
+			o.bulkTypes = typeSet(o.bulkTypes, types)
+		}
+	}
+
+	// UnitTrackActive specifies the option to track the work unit within the
+	// active unit registry until it is saved successfully or discarded, so
+	// that work units leaked by long-running services can be diagnosed with
+	// ActiveUnits and ActiveUnitsHandler.
+	UnitTrackActive = func() UnitOption {
+		return func(o *UnitOptions) {
+			o.trackActive = true
+		}
+	}
+
+	// UnitSaveMiddleware specifies the option to wrap the save pipeline of
+	// the work unit with the provided middleware, so that cross-cutting
+	// concerns such as tracing, fault injection, and fault budgets can be
+	// composed around saves. Middleware is applied in the order provided,
+	// with the first being outermost, and is appended to any middleware
+	// specified previously.
+	UnitSaveMiddleware = func(middleware ...UnitSaveMiddlewareFunc) UnitOption {
+		return func(o *UnitOptions) {
+			o.saveMiddleware = append(o.saveMiddleware, middleware...)
+		}
+	}
+
+	// UnitAppendOnly specifies the option to only permit entities to be added
+	// to, or registered with, the work unit, such as for event stores and
+	// other append-only tables. Only an insert function is required for each
+	// type, and staging alterations, removals, upserts, patches, or removals
+	// by criteria results in ErrUnitAppendOnly. Entities inserted by work
+	// units without a transaction are not deleted should the save fail, and
+	// are inserted again should the save be retried.
+	UnitAppendOnly = func() UnitOption {
+		return func(o *UnitOptions) {
+			o.appendOnly = true
+		}
+	}
+
+	// UnitVerifyStatements specifies the option to provide statements that
+	// are prepared against the database when verifying the uniter, such as
+	// probe queries ensuring that the tables and columns the data mappers
+	// rely on exist. Statements are appended to those specified previously.
+	UnitVerifyStatements = func(statements ...string) UnitOption {
+		return func(o *UnitOptions) {
+			o.verifyStatements = append(o.verifyStatements, statements...)
+		}
+	}
+
+	// UnitReadIsolation specifies the option to provide the isolation level
+	// of the transaction read-only work units perform their reads within,
+	// which defaults to sql.LevelRepeatableRead so that each read observes
+	// the same snapshot of the data store.
+	UnitReadIsolation = func(level sql.IsolationLevel) UnitOption {
+		return func(o *UnitOptions) {
+			o.readIsolation = level
+		}
+	}
+
+	// UnitEventStore specifies the option to persist the aggregates of the
+	// provided types as the events raised against them, which are appended
+	// with the provided function when the aggregates are added or altered.
+	// Aggregates must implement UnitEventSourced, and have their events
+	// marked as committed once the work unit is saved successfully. Removing
+	// the aggregates is not supported, and the events appended by work units
+	// without a transaction are retained should the save fail.
+	UnitEventStore = func(f UnitEventAppendFunc, types ...TypeName) UnitOption {
+		return func(o *UnitOptions) {
+			es := o.eventSourcing()
+			es.appendFunc = f
+			for _, t := range types {
+				es.types[t] = struct{}{}
+				UnitInsertFunc(t, es.mapperFunc(t))(o)
+				UnitUpdateFunc(t, es.mapperFunc(t))(o)
+			}
+		}
+	}
+
+	// UnitSnapshotFunc defines the function to be used for writing snapshots
+	// of the event-sourced aggregates of the provided type, which is invoked
+	// with the aggregates once their events are appended, before they are
+	// marked as committed.
+	UnitSnapshotFunc = func(t TypeName, snapshotFunc UnitDataMapperFunc) UnitOption {
+		return func(o *UnitOptions) {
+			o.eventSourcing().snapshots[t] = snapshotFunc
+		}
+	}
+
+	// UnitSnapshotEvery specifies the option to only write snapshots of the
+	// event-sourced aggregates of the provided type every time the provided
+	// number of events are appended to their streams, rather than every time
+	// they are saved. Snapshots are written with the snapshot function of the
+	// type when the events appended for an aggregate cross a multiple of the
+	// provided number, within the same transaction as the events when the
+	// work unit has one.
+	UnitSnapshotEvery = func(t TypeName, events int) UnitOption {
+		if events < 1 {
+			events = 1
+		}
+		return func(o *UnitOptions) {
+			o.eventSourcing().snapshotEvery[t] = events
+		}
+	}
+
+	// UnitWithAggregateBoundaries specifies the option to enforce the
+	// consistency boundaries of aggregates, provided as the types of the
+	// entities belonging to each aggregate keyed by the type of its root.
+	// Saving a work unit with entities of a type belonging to an aggregate
+	// staged without an entity of its aggregate root type also being staged
+	// or registered results in UnitAggregateBoundaryError.
+	UnitWithAggregateBoundaries = func(boundaries map[TypeName][]TypeName) UnitOption {
+		return func(o *UnitOptions) {
+			o.aggregateRoots = aggregateRoots(o.aggregateRoots, boundaries)
+		}
+	}
+
+	// UnitLockTokens specifies the option to detect concurrent modifications
+	// of entities across work units sharing the same cache, such as work
+	// units spanning the requests of a web flow, using the lock tokens
+	// provided by the provided function, which defaults to
+	// UnitHashLockToken. Tokens are captured when entities are registered
+	// and saved, and placed within the cache. Saving altered or removed
+	// entities whose tokens differ from those within the cache results in
+	// UnitStaleEntityError, where the token carried by entities implementing
+	// UnitLockTokenCarrier takes precedence over the token captured when they
+	// were registered. Since the tokens are verified before the changes are
+	// applied, data mappers should still enforce optimistic locking where
+	// concurrent saves must be detected without fail.
+	UnitLockTokens = func(f UnitLockTokenFunc) UnitOption {
+		if f == nil {
+			f = UnitHashLockToken
+		}
+		return func(o *UnitOptions) {
+			o.lockTokenFunc = f
+		}
+	}
+
+	// UnitRetryHistory specifies the option to collect the error of every
+	// failed attempt to save the work unit, rather than only the last,
+	// providing them with UnitRetryError when the save fails. When the
+	// provided limit is positive, only the errors of the most recent
+	// attempts up to the limit are collected.
+	UnitRetryHistory = func(limit int) UnitOption {
+		return func(o *UnitOptions) {
+			o.retryHistory = true
+			o.retryHistoryLimit = limit
+		}
+	}
+
+	// UnitMutationGuard specifies the option to reject saving the work unit,
+	// before any data mappers are invoked, when more changes are staged for
+	// a type than the provided limit for that type, as a safety net against
+	// bugs that would otherwise mass modify a table. Saves exceeding a limit
+	// result in UnitMutationGuardError. Limits are merged with those
+	// specified previously.
+	UnitMutationGuard = func(maxPerType map[TypeName]int) UnitOption {
+		return func(o *UnitOptions) {
+			if o.mutationLimits == nil {
+				o.mutationLimits = make(map[TypeName]int, len(maxPerType))
+			}
+			for t, limit := range maxPerType {
+				o.mutationLimits[t] = limit
+			}
+		}
+	}
+
+	// UnitConfirmMutations specifies the option to require confirmation,
+	// rather than rejecting the save, when more changes are staged for a
+	// type than its mutation guard permits, such as for CLI and admin tools
+	// prompting before mass modifying a table. Such saves result in
+	// UnitConfirmationError without invoking any data mappers, leaving the
+	// work unit intact, and proceed once saved again with Confirm and the
+	// token it provides.
+	UnitConfirmMutations = func() UnitOption {
+		return func(o *UnitOptions) {
+			o.confirmMutations = true
+		}
+	}
+
+	// UnitUpsertFunc defines the function to be used for upserting entities
+	// in the underlying data store, such as for data stores that natively
+	// support inserting an entity or updating it if it already exists.
+	UnitUpsertFunc = func(t TypeName, upsertFunc UnitDataMapperFunc) UnitOption {
+		return func(o *UnitOptions) {
+			if o.upsertFuncs == nil {
+				o.upsertFuncs = make(map[TypeName]UnitDataMapperFunc)
+			}
+			o.upsertFuncs[t] = upsertFunc
+			o.upsertFuncsLen = o.upsertFuncsLen + 1
+		}
+	}
+
+	// UnitPatchFunc defines the function to be used for applying partial
+	// updates to existing entities in the underlying data store.
+	UnitPatchFunc = func(t TypeName, patchFunc UnitPatchDataMapperFunc) UnitOption {
+		return func(o *UnitOptions) {
+			if o.patchFuncs == nil {
+				o.patchFuncs = make(map[TypeName]UnitPatchDataMapperFunc)
+			}
+			o.patchFuncs[t] = patchFunc
+			o.patchFuncsLen = o.patchFuncsLen + 1
+		}
+	}
+
+	// UnitDeleteWhereFunc defines the function to be used for deleting the
+	// entities matching the provided criteria in the underlying data store.
+	// The function is provided the criteria rather than the entities.
+	UnitDeleteWhereFunc = func(t TypeName, deleteWhereFunc UnitDataMapperFunc) UnitOption {
+		return func(o *UnitOptions) {
+			if o.deleteWhereFuncs == nil {
+				o.deleteWhereFuncs = make(map[TypeName]UnitDataMapperFunc)
+			}
+			o.deleteWhereFuncs[t] = deleteWhereFunc
+			o.deleteWhereFuncsLen = o.deleteWhereFuncsLen + 1
+		}
+	}
+
+	// UnitMaxOperationsPerTransaction specifies the option to split the
+	// operations of a work unit across multiple sequential transactions, each
+	// containing at most the provided number of operations. Each chunk is
+	// retried independently, and should a chunk fail, the chunks preceding it
+	// remain committed while it and the chunks following it are not applied.
+	// The outcome of each chunk is reported via UnitPartialSaveError. Only
+	// applies to work units that leverage the work.UnitDB option.
+	UnitMaxOperationsPerTransaction = func(n int) UnitOption {
+		return func(o *UnitOptions) {
+			o.maxOperationsPerTransaction = n
+		}
+	}
+
+	// UnitRefreshFunc defines the function to be used for reloading the
+	// entities of the provided type from the data store once the work unit
+	// is saved, such as to capture defaults, computed columns, or values set
+	// by triggers. The function is expected to update the provided entities
+	// in place, and is only invoked when the UnitRefreshAfterSave option is
+	// specified.
+	UnitRefreshFunc = func(t TypeName, refreshFunc UnitDataMapperFunc) UnitOption {
+		return func(o *UnitOptions) {
+			if o.refreshFuncs == nil {
+				o.refreshFuncs = make(map[TypeName]UnitDataMapperFunc)
+			}
+			o.refreshFuncs[t] = refreshFunc
+		}
+	}
+
+	// UnitRefreshAfterSave specifies the option to reload the added,
+	// upserted, and altered entities from the data store once the work unit
+	// is saved, leveraging the functions provided by UnitRefreshFunc. The
+	// refreshed entities are cached.
+	UnitRefreshAfterSave = func() UnitOption {
+		return func(o *UnitOptions) {
+			o.refreshAfterSave = true
+		}
+	}
+
+	// UnitCommitTokens specifies the option to capture and await commit
+	// tokens with the provided tokenizer, enforcing read-your-writes across
+	// work units when reading from replicas. The token of a successful save
+	// is provided by Unit.CommitToken. Only applies to work units that
+	// leverage the work.UnitDB option.
+	UnitCommitTokens = func(tokenizer UnitCommitTokenizer) UnitOption {
+		return func(o *UnitOptions) {
+			o.commitTokenizer = tokenizer
+		}
+	}
+
+	// UnitWaitForToken specifies the option to await the position identified
+	// by the provided commit token, captured by a prior work unit, before
+	// each transaction of the work unit is started. Callers reading from a
+	// replica outside of a transaction can await it with Unit.AwaitToken.
+	UnitWaitForToken = func(token string) UnitOption {
+		return func(o *UnitOptions) {
+			o.awaitToken = token
+		}
+	}
+
+	// UnitWithIDGenerator specifies the option to assign identifiers generated
+	// by the provided generator to added entities lacking one. Identifiers
+	// are assigned through a SetID(interface{}) method when present, or
+	// otherwise to the field tagged with `work:"id"`, which requires the
+	// entity to be added as a pointer. UnitUUIDv7 and UnitULID are provided.
+	UnitWithIDGenerator = func(gen UnitIDGenerator) UnitOption {
+		return func(o *UnitOptions) {
+			o.idGenerator = gen
+		}
+	}
+
+	// UnitQuarantineAfter specifies the option to apply entities being
+	// inserted, upserted, updated, or deleted individually, quarantining
+	// those that fail the provided number of times rather than failing the
+	// save, so that the remaining entities are committed. The quarantined
+	// entities are provided by Unit.Quarantined. For work units that
+	// leverage the work.UnitDB option, each entity is isolated within a
+	// savepoint.
+	UnitQuarantineAfter = func(failures int) UnitOption {
+		return func(o *UnitOptions) {
+			o.quarantineAfter = failures
+		}
+	}
+
+	// UnitPersistQuarantined specifies the option to provide the entities
+	// quarantined during a successful save to the provided sink.
+	UnitPersistQuarantined = func(sink UnitQuarantineSink) UnitOption {
+		return func(o *UnitOptions) {
+			o.quarantineSink = sink
+		}
+	}
+
+	// UnitChangeRecords specifies the option to emit change records for each
+	// mutation committed by the work unit to the provided sink.
+	UnitChangeRecords = func(sink UnitChangeRecordSink) UnitOption {
+		return func(o *UnitOptions) {
+			o.changeRecordSink = sink
+		}
+	}
+
+	// UnitCodecs specifies the option to serialize entities of each type with
+	// the provided codec for that type when requested by actions, such as
+	// with UnitActionContext.Payloads. Codecs are merged with those
+	// specified previously.
+	UnitCodecs = func(codecs map[TypeName]UnitCodec) UnitOption {
+		return func(o *UnitOptions) {
+			if o.codecs == nil {
+				o.codecs = make(map[TypeName]UnitCodec, len(codecs))
+			}
+			for t, c := range codecs {
+				o.codecs[t] = c
+			}
+		}
+	}
+
+	// UnitDefaultCodec specifies the option to serialize entities of types
+	// without a codec provided with the work.UnitCodecs option with the
+	// provided codec, such as work.UnitJSONCodec.
+	UnitDefaultCodec = func(c UnitCodec) UnitOption {
+		return func(o *UnitOptions) {
+			o.defaultCodec = c
+		}
+	}
+
 	// UnitWithCacheClient defines the cache client to be used.
 	UnitWithCacheClient = func(cc UnitCacheClient) UnitOption {
 		return func(o *UnitOptions) {
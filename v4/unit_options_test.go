@@ -17,6 +17,9 @@ package work
 
 import (
 	"context"
+	"database/sql"
+	"errors"
+	"fmt"
 	"log"
 	"log/slog"
 	"testing"
@@ -121,6 +124,226 @@ func (s *UnitOptionsTestSuite) TestUnitDeleteFunc() {
 	s.NotNil(s.sut.deleteFuncs)
 }
 
+func (s *UnitOptionsTestSuite) TestUnitUpsertFunc() {
+	// arrange.
+	t := TypeNameOf(test.Foo{})
+	var f UnitDataMapperFunc
+
+	// action.
+	UnitUpsertFunc(t, f)(s.sut)
+
+	// assert.
+	s.NotNil(s.sut.upsertFuncs)
+	s.True(s.sut.hasDataMapperFuncs())
+}
+
+func (s *UnitOptionsTestSuite) TestUnitPatchFunc() {
+	// arrange.
+	t := TypeNameOf(test.Foo{})
+	var f UnitPatchDataMapperFunc
+
+	// action.
+	UnitPatchFunc(t, f)(s.sut)
+
+	// assert.
+	s.NotNil(s.sut.patchFuncs)
+	s.True(s.sut.hasDataMapperFuncs())
+}
+
+func (s *UnitOptionsTestSuite) TestUnitDeleteWhereFunc() {
+	// arrange.
+	t := TypeNameOf(test.Foo{})
+	var f UnitDataMapperFunc
+
+	// action.
+	UnitDeleteWhereFunc(t, f)(s.sut)
+
+	// assert.
+	s.NotNil(s.sut.deleteWhereFuncs)
+	s.True(s.sut.hasDataMapperFuncs())
+}
+
+func (s *UnitOptionsTestSuite) TestUnitAfterRemoveWhereActions() {
+	// arrange.
+	same := false
+	a := func(context UnitActionContext) { same = true }
+
+	// action.
+	UnitAfterRemoveWhereActions(a)(s.sut)
+
+	// assert.
+	actions := s.sut.actions[UnitActionTypeAfterRemoveWhere]
+	s.Len(actions, 1)
+	s.Condition(func() bool {
+		actions[0].action(UnitActionContext{})
+		return same
+	})
+}
+
+func (s *UnitOptionsTestSuite) TestUnitActionsWithPriority() {
+	// arrange.
+	var order []int
+	action := func(i int) UnitAction {
+		return func(UnitActionContext) { order = append(order, i) }
+	}
+
+	// action.
+	UnitBeforeSaveActions(action(1))(s.sut)
+	UnitBeforeSaveActionsWithPriority(10, action(2))(s.sut)
+	UnitActionsWithPriority(UnitActionTypeBeforeSave, -1, action(3))(s.sut)
+	UnitBeforeSaveActionsWithPriority(10, action(4))(s.sut)
+	UnitBeforeSaveActions(action(5))(s.sut)
+
+	// assert.
+	actions := s.sut.actions[UnitActionTypeBeforeSave]
+	s.Len(actions, 5)
+	for _, a := range actions {
+		a.action(UnitActionContext{})
+	}
+	s.Equal([]int{2, 4, 1, 5, 3}, order)
+}
+
+func (s *UnitOptionsTestSuite) TestUnitNamedAction() {
+	// arrange.
+	same := false
+	a := func(context UnitActionContext) { same = true }
+
+	// action.
+	UnitNamedAction("audit", UnitActionTypeAfterSave, a)(s.sut)
+
+	// assert.
+	actions := s.sut.actions[UnitActionTypeAfterSave]
+	s.Len(actions, 1)
+	s.Equal("audit", actions[0].name)
+	s.Condition(func() bool {
+		actions[0].action(UnitActionContext{})
+		return same
+	})
+}
+
+func (s *UnitOptionsTestSuite) TestUnitConditionalAction() {
+	// arrange.
+	predicate := func(ctx UnitActionContext) bool { return ctx.Modified(TypeNameOf(test.Foo{})) }
+	a := func(context UnitActionContext) {}
+
+	// action.
+	UnitConditionalAction(UnitActionTypeAfterSave, predicate, a)(s.sut)
+
+	// assert.
+	actions := s.sut.actions[UnitActionTypeAfterSave]
+	s.Len(actions, 1)
+	s.NotNil(actions[0].when)
+	s.False(actions[0].when(UnitActionContext{}))
+}
+
+func (s *UnitOptionsTestSuite) TestUnitHaltActionsOnFailure() {
+	// action.
+	UnitHaltActionsOnFailure()(s.sut)
+
+	// assert.
+	s.True(s.sut.haltActionsOnFailure)
+}
+
+func (s *UnitOptionsTestSuite) TestUnitRollbackActions() {
+	// arrange.
+	var cause error
+	a := func(ctx UnitRollbackActionContext) { cause = ctx.Err }
+
+	// action.
+	UnitRollbackActions(a)(s.sut)
+
+	// assert.
+	s.Len(s.sut.rollbackActions, 1)
+	s.Condition(func() bool {
+		s.sut.rollbackActions[0](UnitRollbackActionContext{Err: errors.New("whoa")})
+		return cause != nil
+	})
+}
+
+func (s *UnitOptionsTestSuite) TestUnitSaveActions() {
+	// arrange.
+	var duration time.Duration
+	a := func(ctx UnitSaveActionContext) { duration = ctx.Duration }
+
+	// action.
+	UnitSaveActions(a)(s.sut)
+
+	// assert.
+	s.Len(s.sut.saveActions, 1)
+	s.Condition(func() bool {
+		s.sut.saveActions[0](UnitSaveActionContext{Duration: time.Second})
+		return duration == time.Second
+	})
+}
+
+func (s *UnitOptionsTestSuite) TestUnitAfterPatchActions() {
+	// arrange.
+	same := false
+	a := func(context UnitActionContext) { same = true }
+
+	// action.
+	UnitAfterPatchActions(a)(s.sut)
+
+	// assert.
+	actions := s.sut.actions[UnitActionTypeAfterPatch]
+	s.Len(actions, 1)
+	s.Condition(func() bool {
+		actions[0].action(UnitActionContext{})
+		return same
+	})
+}
+
+func (s *UnitOptionsTestSuite) TestUnitAfterUpsertActions() {
+	// arrange.
+	same := false
+	a := func(context UnitActionContext) { same = true }
+
+	// action.
+	UnitAfterUpsertActions(a)(s.sut)
+
+	// assert.
+	actions := s.sut.actions[UnitActionTypeAfterUpsert]
+	s.Len(actions, 1)
+	s.Condition(func() bool {
+		actions[0].action(UnitActionContext{})
+		return same
+	})
+}
+
+func (s *UnitOptionsTestSuite) TestUnitAfterUpsertsActions() {
+	// arrange.
+	same := false
+	a := func(context UnitActionContext) { same = true }
+
+	// action.
+	UnitAfterUpsertsActions(a)(s.sut)
+
+	// assert.
+	actions := s.sut.actions[UnitActionTypeAfterUpserts]
+	s.Len(actions, 1)
+	s.Condition(func() bool {
+		actions[0].action(UnitActionContext{})
+		return same
+	})
+}
+
+func (s *UnitOptionsTestSuite) TestUnitBeforeUpsertsActions() {
+	// arrange.
+	same := false
+	a := func(context UnitActionContext) { same = true }
+
+	// action.
+	UnitBeforeUpsertsActions(a)(s.sut)
+
+	// assert.
+	actions := s.sut.actions[UnitActionTypeBeforeUpserts]
+	s.Len(actions, 1)
+	s.Condition(func() bool {
+		actions[0].action(UnitActionContext{})
+		return same
+	})
+}
+
 func (s *UnitOptionsTestSuite) TestUnitZapLogger() {
 	// arrange.
 	c := zap.NewDevelopmentConfig()
@@ -178,6 +401,40 @@ func (s *UnitOptionsTestSuite) TestUnitLogger() {
 	s.IsType(&adapters.LogrusLogger{}, s.sut.logger)
 }
 
+// stubTracer represents a tracer whose spans are never started.
+type stubTracer struct{}
+
+func (stubTracer) Start(ctx context.Context, name string, attrs ...any) (context.Context, UnitSpan) {
+	return ctx, nil
+}
+
+type stubCodec struct {
+	contentType string
+}
+
+func (c stubCodec) ContentType() string { return c.contentType }
+
+func (c stubCodec) Encode(entity interface{}) ([]byte, error) { return nil, nil }
+
+func (s *UnitOptionsTestSuite) TestUnitWithTracer() {
+	// action.
+	UnitWithTracer(stubTracer{})(s.sut)
+
+	// assert.
+	s.Equal(stubTracer{}, s.sut.tracer)
+}
+
+func (s *UnitOptionsTestSuite) TestUnitWithMetricsCollector() {
+	// arrange.
+	c := adapters.NewNopCollector()
+
+	// action.
+	UnitWithMetricsCollector(c)(s.sut)
+
+	// assert.
+	s.Equal(c, s.sut.metricsCollector)
+}
+
 func (s *UnitOptionsTestSuite) TestUnitScope() {
 	// arrange.
 	ts := tally.NewTestScope("test", map[string]string{})
@@ -201,7 +458,7 @@ func (s *UnitOptionsTestSuite) TestUnitAfterRegisterActions() {
 	actions := s.sut.actions[UnitActionTypeAfterRegister]
 	s.Len(actions, 1)
 	s.Condition(func() bool {
-		actions[0](UnitActionContext{})
+		actions[0].action(UnitActionContext{})
 		return same
 	})
 }
@@ -218,7 +475,7 @@ func (s *UnitOptionsTestSuite) TestUnitAfterAddActions() {
 	actions := s.sut.actions[UnitActionTypeAfterAdd]
 	s.Len(actions, 1)
 	s.Condition(func() bool {
-		actions[0](UnitActionContext{})
+		actions[0].action(UnitActionContext{})
 		return same
 	})
 }
@@ -235,7 +492,7 @@ func (s *UnitOptionsTestSuite) TestUnitAfterAlterActions() {
 	actions := s.sut.actions[UnitActionTypeAfterAlter]
 	s.Len(actions, 1)
 	s.Condition(func() bool {
-		actions[0](UnitActionContext{})
+		actions[0].action(UnitActionContext{})
 		return same
 	})
 }
@@ -252,7 +509,7 @@ func (s *UnitOptionsTestSuite) TestUnitAfterRemoveActions() {
 	actions := s.sut.actions[UnitActionTypeAfterRemove]
 	s.Len(actions, 1)
 	s.Condition(func() bool {
-		actions[0](UnitActionContext{})
+		actions[0].action(UnitActionContext{})
 		return same
 	})
 }
@@ -269,7 +526,7 @@ func (s *UnitOptionsTestSuite) TestUnitAfterInsertsActions() {
 	actions := s.sut.actions[UnitActionTypeAfterInserts]
 	s.Len(actions, 1)
 	s.Condition(func() bool {
-		actions[0](UnitActionContext{})
+		actions[0].action(UnitActionContext{})
 		return same
 	})
 }
@@ -286,7 +543,7 @@ func (s *UnitOptionsTestSuite) TestUnitAfterUpdatesActions() {
 	actions := s.sut.actions[UnitActionTypeAfterUpdates]
 	s.Len(actions, 1)
 	s.Condition(func() bool {
-		actions[0](UnitActionContext{})
+		actions[0].action(UnitActionContext{})
 		return same
 	})
 }
@@ -303,7 +560,7 @@ func (s *UnitOptionsTestSuite) TestUnitAfterDeletesActions() {
 	actions := s.sut.actions[UnitActionTypeAfterDeletes]
 	s.Len(actions, 1)
 	s.Condition(func() bool {
-		actions[0](UnitActionContext{})
+		actions[0].action(UnitActionContext{})
 		return same
 	})
 }
@@ -320,7 +577,7 @@ func (s *UnitOptionsTestSuite) TestUnitAfterRollbackActions() {
 	actions := s.sut.actions[UnitActionTypeAfterRollback]
 	s.Len(actions, 1)
 	s.Condition(func() bool {
-		actions[0](UnitActionContext{})
+		actions[0].action(UnitActionContext{})
 		return same
 	})
 }
@@ -337,7 +594,7 @@ func (s *UnitOptionsTestSuite) TestUnitAfterSaveActions() {
 	actions := s.sut.actions[UnitActionTypeAfterSave]
 	s.Len(actions, 1)
 	s.Condition(func() bool {
-		actions[0](UnitActionContext{})
+		actions[0].action(UnitActionContext{})
 		return same
 	})
 }
@@ -354,7 +611,7 @@ func (s *UnitOptionsTestSuite) TestUnitBeforeInsertsActions() {
 	actions := s.sut.actions[UnitActionTypeBeforeInserts]
 	s.Len(actions, 1)
 	s.Condition(func() bool {
-		actions[0](UnitActionContext{})
+		actions[0].action(UnitActionContext{})
 		return same
 	})
 }
@@ -371,7 +628,7 @@ func (s *UnitOptionsTestSuite) TestUnitBeforeUpdatesActions() {
 	actions := s.sut.actions[UnitActionTypeBeforeUpdates]
 	s.Len(actions, 1)
 	s.Condition(func() bool {
-		actions[0](UnitActionContext{})
+		actions[0].action(UnitActionContext{})
 		return same
 	})
 }
@@ -388,7 +645,7 @@ func (s *UnitOptionsTestSuite) TestUnitBeforeDeletesActions() {
 	actions := s.sut.actions[UnitActionTypeBeforeDeletes]
 	s.Len(actions, 1)
 	s.Condition(func() bool {
-		actions[0](UnitActionContext{})
+		actions[0].action(UnitActionContext{})
 		return same
 	})
 }
@@ -405,7 +662,7 @@ func (s *UnitOptionsTestSuite) TestUnitBeforeRollbackActions() {
 	actions := s.sut.actions[UnitActionTypeBeforeRollback]
 	s.Len(actions, 1)
 	s.Condition(func() bool {
-		actions[0](UnitActionContext{})
+		actions[0].action(UnitActionContext{})
 		return same
 	})
 }
@@ -422,7 +679,7 @@ func (s *UnitOptionsTestSuite) TestUnitBeforeSaveActions() {
 	actions := s.sut.actions[UnitActionTypeBeforeSave]
 	s.Len(actions, 1)
 	s.Condition(func() bool {
-		actions[0](UnitActionContext{})
+		actions[0].action(UnitActionContext{})
 		return same
 	})
 }
@@ -467,6 +724,58 @@ func (s *UnitOptionsTestSuite) TestUnitRetryDelay() {
 	s.Equal(delay, s.sut.retryDelay)
 }
 
+func (s *UnitOptionsTestSuite) TestUnitWithSessionSettings() {
+
+	// action.
+	UnitWithSessionSettings(map[string]string{"app.tenant_id": "28"})(s.sut)
+	UnitWithSessionSettings(map[string]string{"app.user_id": "1992"})(s.sut)
+
+	// assert.
+	s.Equal(map[string]string{"app.tenant_id": "28", "app.user_id": "1992"}, s.sut.sessionSettings)
+}
+
+func (s *UnitOptionsTestSuite) TestUnitQuarantineAfter() {
+	// arrange.
+	failures := 3
+
+	// action.
+	UnitQuarantineAfter(failures)(s.sut)
+
+	// assert.
+	s.Equal(failures, s.sut.quarantineAfter)
+}
+
+func (s *UnitOptionsTestSuite) TestUnitRollbackRetryAttempts_Negative() {
+
+	// action.
+	UnitRollbackRetryAttempts(-1)(s.sut)
+
+	// assert.
+	s.Zero(s.sut.rollbackRetryAttempts)
+}
+
+func (s *UnitOptionsTestSuite) TestUnitRollbackRetryAttempts_NotNegative() {
+	// arrange.
+	attempts := 5
+
+	// action.
+	UnitRollbackRetryAttempts(attempts)(s.sut)
+
+	// assert.
+	s.Equal(attempts, s.sut.rollbackRetryAttempts)
+}
+
+func (s *UnitOptionsTestSuite) TestUnitRollbackRetryDelay() {
+	// arrange.
+	delay := 10 * time.Second
+
+	// action.
+	UnitRollbackRetryDelay(delay)(s.sut)
+
+	// assert.
+	s.Equal(delay, s.sut.rollbackRetryDelay)
+}
+
 func (s *UnitOptionsTestSuite) TestUnitRetryMaximumJitter() {
 	// arrange.
 	delay := 10 * time.Second
@@ -500,6 +809,438 @@ func (s *UnitOptionsTestSuite) TestUnitWithCacheClient() {
 	s.Equal(cacheClient, s.sut.cacheClient)
 }
 
+func (s *UnitOptionsTestSuite) TestUnitDeferConstraints_All() {
+
+	// action.
+	UnitDeferConstraints()(s.sut)
+
+	// assert.
+	s.True(s.sut.deferConstraints)
+	s.Empty(s.sut.deferredConstraints)
+}
+
+func (s *UnitOptionsTestSuite) TestUnitDeferConstraints_Named() {
+	// arrange.
+	constraints := []string{"fk_foo_bar", "fk_bar_foo"}
+
+	// action.
+	UnitDeferConstraints(constraints...)(s.sut)
+
+	// assert.
+	s.True(s.sut.deferConstraints)
+	s.Equal(constraints, s.sut.deferredConstraints)
+}
+
+func (s *UnitOptionsTestSuite) TestUnitRejectNilEntities() {
+	// action.
+	UnitRejectNilEntities()(s.sut)
+
+	// assert.
+	s.True(s.sut.rejectNilEntities)
+}
+
+func (s *UnitOptionsTestSuite) TestUnitStrictTracking() {
+	// action.
+	UnitStrictTracking()(s.sut)
+
+	// assert.
+	s.True(s.sut.strictTracking)
+}
+
+func (s *UnitOptionsTestSuite) TestUnitOnRetry() {
+	// arrange.
+	f := func(attempt uint, err error, rCtx UnitRetryContext) {}
+
+	// action.
+	UnitOnRetry(f)(s.sut)
+	UnitOnRetry(f)(s.sut)
+
+	// assert.
+	s.Len(s.sut.retryFuncs, 2)
+}
+
+func (s *UnitOptionsTestSuite) TestUnitGoroutineAudit() {
+	// action.
+	UnitGoroutineAudit(UnitGoroutineAuditPanic)(s.sut)
+
+	// assert.
+	s.Equal(UnitGoroutineAuditPanic, s.sut.goroutineAudit)
+}
+
+func (s *UnitOptionsTestSuite) TestUnitMergeAlterations() {
+	// action.
+	UnitMergeAlterations()(s.sut)
+
+	// assert.
+	s.True(s.sut.mergeAlterations)
+}
+
+func (s *UnitOptionsTestSuite) TestUnitStaleAfter() {
+	// action.
+	UnitStaleAfter(time.Minute, true)(s.sut)
+
+	// assert.
+	s.Equal(time.Minute, s.sut.staleAfter)
+	s.True(s.sut.evictStale)
+}
+
+func (s *UnitOptionsTestSuite) TestUnitPreflightPing() {
+	// action.
+	UnitPreflightPing()(s.sut)
+
+	// assert.
+	s.True(s.sut.preflightPing)
+}
+
+func (s *UnitOptionsTestSuite) TestUnitTrackActive() {
+	// action.
+	UnitTrackActive()(s.sut)
+
+	// assert.
+	s.True(s.sut.trackActive)
+}
+
+func (s *UnitOptionsTestSuite) TestUnitDetectLeaks() {
+	// action.
+	UnitDetectLeaks()(s.sut)
+
+	// assert.
+	s.True(s.sut.detectLeaks)
+}
+
+func (s *UnitOptionsTestSuite) TestUnitSaveMiddleware() {
+	// arrange.
+	noop := func(next UnitSaveFunc) UnitSaveFunc { return next }
+
+	// action.
+	UnitSaveMiddleware(noop)(s.sut)
+	UnitSaveMiddleware(noop, noop)(s.sut)
+
+	// assert.
+	s.Len(s.sut.saveMiddleware, 3)
+}
+
+func (s *UnitOptionsTestSuite) TestUnitAppendOnly() {
+	// action.
+	UnitAppendOnly()(s.sut)
+
+	// assert.
+	s.True(s.sut.appendOnly)
+}
+
+func (s *UnitOptionsTestSuite) TestUnitVerifyStatements() {
+	// action.
+	UnitVerifyStatements("SELECT 1")(s.sut)
+	UnitVerifyStatements("SELECT 2", "SELECT 3")(s.sut)
+
+	// assert.
+	s.Equal([]string{"SELECT 1", "SELECT 2", "SELECT 3"}, s.sut.verifyStatements)
+}
+
+func (s *UnitOptionsTestSuite) TestUnitReadIsolation() {
+	// action.
+	UnitReadIsolation(sql.LevelSerializable)(s.sut)
+
+	// assert.
+	s.Equal(sql.LevelSerializable, s.sut.readIsolation)
+}
+
+func (s *UnitOptionsTestSuite) TestUnitEventStore() {
+	// arrange.
+	fooType := TypeNameOf(test.Foo{})
+	appendFunc := func(context.Context, UnitMapperContext, []UnitEventStream) error { return nil }
+	snapshot := func(context.Context, UnitMapperContext, ...interface{}) error { return nil }
+
+	// action.
+	UnitSnapshotFunc(fooType, snapshot)(s.sut)
+	UnitEventStore(appendFunc, fooType)(s.sut)
+
+	// assert.
+	s.Require().NotNil(s.sut.events)
+	s.Contains(s.sut.events.types, fooType)
+	s.Contains(s.sut.events.snapshots, fooType)
+	s.Contains(s.sut.insertFuncs, fooType)
+	s.Contains(s.sut.updateFuncs, fooType)
+	s.NotContains(s.sut.deleteFuncs, fooType)
+	s.Len(s.sut.saveActions, 1)
+}
+
+func (s *UnitOptionsTestSuite) TestUnitSnapshotEvery() {
+	// arrange.
+	fooType := TypeNameOf(test.Foo{})
+	barType := TypeNameOf(test.Bar{})
+
+	// action.
+	UnitSnapshotEvery(fooType, 100)(s.sut)
+	UnitSnapshotEvery(barType, 0)(s.sut)
+
+	// assert.
+	s.Require().NotNil(s.sut.events)
+	s.Equal(100, s.sut.events.snapshotEvery[fooType])
+	s.Equal(1, s.sut.events.snapshotEvery[barType])
+}
+
+func (s *UnitOptionsTestSuite) TestUnitWithAggregateBoundaries() {
+	// arrange.
+	fooType, barType := TypeNameOf(test.Foo{}), TypeNameOf(test.Bar{})
+	bizType, bazType := TypeNameOf(test.Biz{}), TypeNameOf(test.Baz{})
+
+	// action.
+	UnitWithAggregateBoundaries(map[TypeName][]TypeName{fooType: {barType, bizType}})(s.sut)
+	UnitWithAggregateBoundaries(map[TypeName][]TypeName{bazType: {bizType}})(s.sut)
+
+	// assert.
+	s.Equal(map[TypeName][]TypeName{
+		barType: {fooType},
+		bizType: {bazType, fooType},
+	}, s.sut.aggregateRoots)
+}
+
+func (s *UnitOptionsTestSuite) TestUnitLockTokens() {
+	// arrange.
+	tokenFunc := func(interface{}) (string, error) { return "token", nil }
+
+	// action.
+	UnitLockTokens(tokenFunc)(s.sut)
+
+	// assert.
+	s.Require().NotNil(s.sut.lockTokenFunc)
+	token, err := s.sut.lockTokenFunc(test.Foo{})
+	s.NoError(err)
+	s.Equal("token", token)
+
+	// action.
+	UnitLockTokens(nil)(s.sut)
+
+	// assert.
+	s.Require().NotNil(s.sut.lockTokenFunc)
+	token, err = s.sut.lockTokenFunc(test.Foo{ID: 28})
+	s.NoError(err)
+	s.Len(token, 64)
+}
+
+func (s *UnitOptionsTestSuite) TestUnitRetryHistory() {
+	// action.
+	UnitRetryHistory(5)(s.sut)
+
+	// assert.
+	s.True(s.sut.retryHistory)
+	s.Equal(5, s.sut.retryHistoryLimit)
+}
+
+func (s *UnitOptionsTestSuite) TestUnitMutationGuard() {
+	// arrange.
+	fooType, barType := TypeNameOf(test.Foo{}), TypeNameOf(test.Bar{})
+
+	// action.
+	UnitMutationGuard(map[TypeName]int{fooType: 10, barType: 20})(s.sut)
+	UnitMutationGuard(map[TypeName]int{fooType: 100})(s.sut)
+
+	// assert.
+	s.Equal(map[TypeName]int{fooType: 100, barType: 20}, s.sut.mutationLimits)
+}
+
+func (s *UnitOptionsTestSuite) TestUnitConfirmMutations() {
+	// action.
+	UnitConfirmMutations()(s.sut)
+
+	// assert.
+	s.True(s.sut.confirmMutations)
+	s.NotNil(s.sut.mutationConfirmation())
+}
+
+func (s *UnitOptionsTestSuite) TestUnitCodecs() {
+	// arrange.
+	fooType, barType := TypeNameOf(test.Foo{}), TypeNameOf(test.Bar{})
+	fooCodec, barCodec := stubCodec{"foo"}, stubCodec{"bar"}
+
+	// action.
+	UnitCodecs(map[TypeName]UnitCodec{fooType: UnitJSONCodec, barType: barCodec})(s.sut)
+	UnitCodecs(map[TypeName]UnitCodec{fooType: fooCodec})(s.sut)
+
+	// assert.
+	s.Equal(map[TypeName]UnitCodec{fooType: fooCodec, barType: barCodec}, s.sut.codecs)
+}
+
+func (s *UnitOptionsTestSuite) TestUnitDefaultCodec() {
+	// action.
+	UnitDefaultCodec(UnitJSONCodec)(s.sut)
+
+	// assert.
+	s.Equal(UnitJSONCodec, s.sut.defaultCodec)
+}
+
+func (s *UnitOptionsTestSuite) TestUnitDriverName() {
+	// action.
+	UnitDriverName("pgx")(s.sut)
+
+	// assert.
+	s.Equal("pgx", s.sut.driverName)
+}
+
+func (s *UnitOptionsTestSuite) TestUnitAllowTypes() {
+	// action.
+	UnitAllowTypes("foo")(s.sut)
+	UnitAllowTypes("bar")(s.sut)
+	UnitDenyTypes("baz")(s.sut)
+
+	// assert.
+	s.Equal(map[TypeName]struct{}{"foo": {}, "bar": {}}, s.sut.allowedTypes)
+	s.Equal(map[TypeName]struct{}{"baz": {}}, s.sut.deniedTypes)
+}
+
+func (s *UnitOptionsTestSuite) TestUnitWithAuthorizer() {
+	// action.
+	UnitWithAuthorizer(func(context.Context, UnitOperation, interface{}) error { return nil })(s.sut)
+
+	// assert.
+	s.NotNil(s.sut.authorizer)
+}
+
+func (s *UnitOptionsTestSuite) TestUnitCacheEncryption() {
+	// arrange.
+	key := []byte("0123456789abcdef")
+
+	// action.
+	UnitCacheEncryption(UnitStaticCacheKey(key))(s.sut)
+
+	// assert.
+	s.Require().NotNil(s.sut.cacheKeys)
+	k, err := s.sut.cacheKeys(context.Background())
+	s.NoError(err)
+	s.Equal(key, k)
+}
+
+func (s *UnitOptionsTestSuite) TestUnitCacheFailurePolicy() {
+	// action.
+	UnitCacheFailurePolicy(UnitCacheFailureModeIgnore)(s.sut)
+
+	// assert.
+	s.Equal(UnitCacheFailureModeIgnore, s.sut.cacheFailureMode)
+}
+
+func (s *UnitOptionsTestSuite) TestUnitCacheInvalidationRetry() {
+	// action.
+	UnitCacheInvalidationRetry(-1, time.Second)(s.sut)
+
+	// assert.
+	s.Zero(s.sut.invalidationRetryAttempts)
+	s.Equal(time.Second, s.sut.invalidationRetryDelay)
+}
+
+func (s *UnitOptionsTestSuite) TestUnitCacheInvalidationQueueSize() {
+	// action.
+	UnitCacheInvalidationQueueSize(0)(s.sut)
+
+	// assert.
+	s.Equal(1, s.sut.invalidationQueueSize)
+}
+
+func (s *UnitOptionsTestSuite) TestUnitMetricScope() {
+	// action.
+	UnitMetricScope("uow")(s.sut)
+
+	// assert.
+	s.Equal("uow", s.sut.metricScope)
+}
+
+func (s *UnitOptionsTestSuite) TestUnitWithMetricNames() {
+	// arrange.
+	s.sut.metricNames = defaultUnitMetricNames()
+
+	// action.
+	UnitWithMetricNames(UnitMetricNames{Save: "commit"})(s.sut)
+	UnitWithMetricNames(UnitMetricNames{Insert: "created"})(s.sut)
+
+	// assert.
+	s.Equal("commit", s.sut.metricNames.Save)
+	s.Equal("created", s.sut.metricNames.Insert)
+	s.Equal(saveSuccess, s.sut.metricNames.SaveSuccess)
+	s.Equal(rollback, s.sut.metricNames.Rollback)
+}
+
+func (s *UnitOptionsTestSuite) TestDescribeUnitOptions() {
+	// arrange.
+	db, _, _ := sqlmock.New()
+	fooType := TypeNameOf(test.Foo{})
+
+	// action.
+	d := DescribeUnitOptions(
+		UnitDB(db),
+		UnitDataMappers(map[TypeName]UnitDataMapper{fooType: &noOpDataMapper{}}),
+		UnitRetryAttempts(5),
+		UnitRetryType(UnitRetryDelayTypeBackOff),
+		UnitWithSessionSettings(map[string]string{"app.tenant": "acme", "app.api_token": "hunter2"}),
+		UnitSQLComments(map[string]string{"service": "orders", "Password": "hunter2"}),
+		UnitStrictTracking(),
+	)
+
+	// assert.
+	s.Equal("sql", d.Type)
+	s.NotEmpty(d.Driver)
+	s.Equal(5, d.RetryAttempts)
+	s.Equal("backoff", d.RetryType)
+	s.Equal([]string{"insert", "update", "delete"}, d.DataMappers[fooType])
+	s.Equal(map[string]string{"app.tenant": "acme", "app.api_token": redacted}, d.SessionSettings)
+	s.Equal(map[string]string{"service": "orders", "Password": redacted}, d.SQLCommentTags)
+	s.Equal([]string{"strictTracking"}, d.Options)
+	s.Greater(d.Actions, 0)
+	s.NotContains(fmt.Sprint(d.KeyValues()...), "hunter2")
+	s.Zero(len(d.KeyValues()) % 2)
+}
+
+func (s *UnitOptionsTestSuite) TestDescribeUnitOptions_Defaults() {
+	// action.
+	d := DescribeUnitOptions(DisableDefaultLoggingActions())
+
+	// assert.
+	s.Equal("best_effort", d.Type)
+	s.Empty(d.Driver)
+	s.Equal("*adapters.NopCollector", d.MetricsCollector)
+	s.Equal(3, d.RetryAttempts)
+	s.Equal("fixed", d.RetryType)
+	s.Zero(d.Actions)
+	s.Empty(d.DataMappers)
+	s.Empty(d.Options)
+}
+
+func (s *UnitOptionsTestSuite) TestUnitDeps() {
+	// arrange.
+	db, _, _ := sqlmock.New()
+	logger := adapters.NewZapLogger(zap.NewNop())
+	collector := adapters.NewNopCollector()
+	cacheClient := &memoryCacheClient{}
+	deps := UnitDeps{
+		DB:      db,
+		Logger:  logger,
+		Metrics: collector,
+		Mappers: map[TypeName]UnitDataMapper{TypeNameOf(test.Foo{}): &noOpDataMapper{}},
+		Cache:   cacheClient,
+		Options: []UnitOption{UnitRetryAttempts(5)},
+	}
+
+	// action.
+	for _, opt := range deps.options() {
+		opt(s.sut)
+	}
+
+	// assert.
+	s.Equal(db, s.sut.db)
+	s.Equal(logger, s.sut.logger)
+	s.Equal(collector, s.sut.metricsCollector)
+	s.Len(s.sut.insertFuncs, 1)
+	s.Equal(cacheClient, s.sut.cacheClient)
+	s.Equal(5, s.sut.retryAttempts)
+}
+
+func (s *UnitOptionsTestSuite) TestUnitDeps_Empty() {
+	// action.
+	opts := UnitDeps{}.options()
+
+	// assert.
+	s.Empty(opts)
+}
+
 func (s *UnitOptionsTestSuite) TearDownTest() {
 	s.sut = nil
 }
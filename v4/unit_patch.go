@@ -0,0 +1,28 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package work
+
+// UnitPatch represents a partial update to an existing entity, such as one
+// described by a JSON merge patch.
+type UnitPatch struct {
+	// TypeName is the type name of the entity being patched.
+	TypeName TypeName
+	// ID is the identity of the entity being patched.
+	ID interface{}
+	// Fields are the fields of the entity to modify, keyed by field name,
+	// along with their new values.
+	Fields map[string]interface{}
+}
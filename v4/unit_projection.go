@@ -0,0 +1,38 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package work
+
+// UnitProjectionBuilder builds the read model rows derived from the provided
+// source entity, such as denormalized rows of a CQRS read table.
+type UnitProjectionBuilder func(entity interface{}) []interface{}
+
+// project provides the provided entities along with the rows derived from
+// them by the projection builders registered for their types, which are
+// themselves projected. Projection builders must therefore not derive rows
+// from one another in a cycle.
+func (u *unit) project(entities []interface{}) []interface{} {
+	if len(u.projections) == 0 {
+		return entities
+	}
+	projected := make([]interface{}, len(entities))
+	copy(projected, entities)
+	for i := 0; i < len(projected); i++ {
+		for _, build := range u.projections[TypeNameOf(projected[i])] {
+			projected = append(projected, build(projected[i])...)
+		}
+	}
+	return projected
+}
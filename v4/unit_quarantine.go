@@ -0,0 +1,186 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package work
+
+import "context"
+
+const quarantineSavepoint = "work_quarantine"
+
+// UnitQuarantinedEntity represents an entity that was excluded from a save
+// after its data mapper repeatedly failed to apply it.
+type UnitQuarantinedEntity struct {
+	// TypeName is the type name of the entity.
+	TypeName TypeName
+	// Entity is the entity that was quarantined.
+	Entity interface{}
+	// Failures is the number of times the data mapper failed to apply the
+	// entity.
+	Failures int
+	// Err is the error encountered during the last failure.
+	Err error
+}
+
+// UnitQuarantineSink represents a destination for quarantined entities, such
+// as a table or queue that is reviewed later on.
+type UnitQuarantineSink interface {
+	// Quarantine persists the provided quarantined entities.
+	Quarantine(context.Context, []UnitQuarantinedEntity) error
+}
+
+// unitIsolator applies an entity such that its failure does not affect the
+// entities applied before it, providing the error encountered by the data
+// mapper separately from the error encountered while isolating it.
+type unitIsolator func(apply func() error) (applyErr error, err error)
+
+// isolated applies the entity without additional isolation, which is
+// sufficient when the data store does not share state across operations.
+func isolated(apply func() error) (error, error) {
+	return apply(), nil
+}
+
+// savepoint isolates each entity within a savepoint of the provided
+// transaction, so that a failing entity can be rolled back without
+// aborting the transaction.
+func (u *sqlUnit) savepoint(ctx context.Context, mCtx UnitMapperContext) unitIsolator {
+	return func(apply func() error) (error, error) {
+		if _, err := mCtx.Tx.ExecContext(ctx, "SAVEPOINT "+quarantineSavepoint); err != nil {
+			return nil, err
+		}
+		if applyErr := apply(); applyErr != nil {
+			_, err := mCtx.Tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT "+quarantineSavepoint)
+			return applyErr, err
+		}
+		_, err := mCtx.Tx.ExecContext(ctx, "RELEASE SAVEPOINT "+quarantineSavepoint)
+		return nil, err
+	}
+}
+
+// applyEntities applies the provided entities within the transaction of the
+// work unit, isolating each entity within a savepoint when quarantining.
+func (u *sqlUnit) applyEntities(
+	ctx context.Context,
+	mCtx UnitMapperContext,
+	typeName TypeName,
+	f UnitDataMapperFunc,
+	entities []interface{},
+) error {
+	_, err := u.apply(ctx, mCtx, typeName, f, u.savepoint(ctx, mCtx), entities)
+	return err
+}
+
+// apply invokes the data mapper function with the provided entities. When
+// quarantining is enabled, each entity is applied individually, and entities
+// that fail the configured number of times are quarantined rather than
+// failing the save. The entities that were applied are provided.
+func (u *unit) apply(
+	ctx context.Context,
+	mCtx UnitMapperContext,
+	typeName TypeName,
+	f UnitDataMapperFunc,
+	isolate unitIsolator,
+	entities []interface{},
+) ([]interface{}, error) {
+	mCtx.typeName = typeName
+	f = u.resolving(typeName, f)
+	if u.quarantineAfter <= 0 {
+		return entities, f(ctx, mCtx, entities...)
+	}
+	applied := make([]interface{}, 0, len(entities))
+	for _, entity := range entities {
+		var applyErr, err error
+		for failures := 1; failures <= u.quarantineAfter; failures++ {
+			e := entity
+			if applyErr, err = isolate(func() error { return f(ctx, mCtx, e) }); err != nil {
+				return applied, err
+			}
+			if applyErr == nil {
+				applied = append(applied, entity)
+				break
+			}
+			u.logger.Warn("unable to apply entity", "typeName", typeName.String(),
+				"failures", failures, "error", applyErr.Error())
+			if failures == u.quarantineAfter {
+				u.quarantine(UnitQuarantinedEntity{
+					TypeName: typeName,
+					Entity:   entity,
+					Failures: failures,
+					Err:      applyErr,
+				})
+			}
+		}
+	}
+	return applied, nil
+}
+
+func (u *unit) quarantine(q UnitQuarantinedEntity) {
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+	u.quarantined = append(u.quarantined, q)
+	u.scope.counter(u.metrics.Quarantine, 1)
+	u.logger.Error("quarantined entity", "typeName", q.TypeName.String(),
+		"failures", q.Failures, "error", q.Err.Error())
+}
+
+// resetQuarantined discards the entities quarantined beyond the provided
+// mark, such as those quarantined during a save attempt that was rolled back.
+func (u *unit) resetQuarantined(mark int) {
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+	if mark < len(u.quarantined) {
+		u.quarantined = u.quarantined[:mark]
+	}
+}
+
+func (u *unit) quarantineMark() int {
+	u.mutex.RLock()
+	defer u.mutex.RUnlock()
+	return len(u.quarantined)
+}
+
+// isQuarantined determines if the provided entity was quarantined. The
+// caller is expected to hold the work unit lock.
+func (u *unit) isQuarantined(typeName TypeName, entity interface{}) bool {
+	for _, q := range u.quarantined {
+		if q.TypeName == typeName && sameIdentity(q.Entity, entity) {
+			return true
+		}
+	}
+	return false
+}
+
+// Quarantined provides the entities that were quarantined during the most
+// recent save.
+func (u *unit) Quarantined() []UnitQuarantinedEntity {
+	u.mutex.RLock()
+	defer u.mutex.RUnlock()
+	quarantined := make([]UnitQuarantinedEntity, len(u.quarantined))
+	copy(quarantined, u.quarantined)
+	return quarantined
+}
+
+// persistQuarantined provides the quarantined entities to the configured
+// sink. Since the remaining entities have already been saved, failures are
+// logged rather than returned.
+func (u *unit) persistQuarantined(ctx context.Context) {
+	quarantined := u.Quarantined()
+	if u.quarantineSink == nil || len(quarantined) == 0 {
+		return
+	}
+	if err := u.quarantineSink.Quarantine(ctx, quarantined); err != nil {
+		u.logger.Error("unable to persist quarantined entities",
+			"error", err.Error(), "count", len(quarantined))
+	}
+}
@@ -0,0 +1,267 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package work
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/multierr"
+)
+
+var (
+	// ErrUnitReadOnly represents the error that is returned when staging
+	// additions, alterations, removals, upserts, or patches into a read-only
+	// work unit.
+	ErrUnitReadOnly = errors.New("work unit is read-only")
+
+	// ErrReadOnlyUnitDB represents the error that is returned when creating
+	// a read-only work unit without the work.UnitDB option.
+	ErrReadOnlyUnitDB = errors.New("read-only work units require a database")
+
+	// ErrReadOnlyUnitDiscarded represents the error that is returned when
+	// reading from, or saving, a read-only work unit that was discarded.
+	ErrReadOnlyUnitDiscarded = errors.New("read-only work unit was discarded")
+)
+
+// UnitReadFunc represents a function performing reads, such as those of
+// finders or data mappers, within the transaction of a read-only work unit.
+type UnitReadFunc func(context.Context, UnitMapperContext) error
+
+// ReadOnlyUnit represents a work unit whose reads are performed within a
+// single read-only transaction, such that reads spanning multiple queries
+// observe a consistent snapshot of the data store. Entities can be
+// registered, but staging additions, alterations, removals, upserts, or
+// patches results in ErrUnitReadOnly. Saving the work unit ends its
+// transaction, as does discarding it.
+type ReadOnlyUnit interface {
+	Unit
+
+	// Read performs the provided function within the read-only transaction
+	// of the work unit, beginning the transaction upon the first read.
+	// Should the function fail, the transaction is rolled back, and the
+	// subsequent read begins a new one.
+	Read(context.Context, UnitReadFunc) error
+
+	// Discard rolls back the read-only transaction of the work unit, if one
+	// has begun, releasing its connection. Discarded work units can no longer
+	// be read from or saved, so callers that may not save the work unit
+	// should defer discarding it.
+	Discard(context.Context) error
+}
+
+type readOnlyUnit struct {
+	*sqlUnit
+
+	isolation sql.IsolationLevel
+	readID    string
+	txMutex   sync.Mutex
+	conn      *sql.Conn
+	tx        *sql.Tx
+	guard     *unitTxGuard
+}
+
+// NewReadOnlyUnit creates a new read-only work unit with the provided
+// options, which must include the work.UnitDB option. Data mappers are not
+// required, since no changes are saved.
+func NewReadOnlyUnit(opts ...UnitOption) (ReadOnlyUnit, error) {
+	opts = append([]UnitOption{UnitReadIsolation(sql.LevelRepeatableRead)}, opts...)
+	opts = append(opts, func(o *UnitOptions) { o.readOnly = true })
+	o := options(opts)
+	if o.db == nil {
+		return nil, ErrReadOnlyUnitDB
+	}
+	u, err := newUnit(o)
+	if err != nil {
+		return nil, err
+	}
+	return &readOnlyUnit{sqlUnit: u.(*sqlUnit), isolation: o.readIsolation, readID: uuid.NewString()}, nil
+}
+
+// readOnlyErr provides ErrUnitReadOnly when the provided staging operation is
+// performed on a read-only work unit.
+func (u *unit) readOnlyErr(operation string) error {
+	if !u.readOnly {
+		return nil
+	}
+	err := fmt.Errorf("%w: %s is not permitted", ErrUnitReadOnly, operation)
+	u.logger.Error(err.Error())
+	return err
+}
+
+// canRegister determines if entities of the provided type can be registered,
+// which requires a data mapper function unless the work unit is read-only.
+func (u *unit) canRegister(t TypeName) bool {
+	return u.readOnly || u.hasDeleteFunc(t) || u.hasInsertFunc(t) || u.hasUpdateFunc(t) || u.hasUpsertFunc(t)
+}
+
+// begin begins the read-only transaction, unless it has already begun.
+func (u *readOnlyUnit) begin(ctx context.Context) (err error) {
+	if u.tx != nil {
+		return
+	}
+	if err = u.AwaitToken(ctx); err != nil {
+		return
+	}
+	if u.conn, err = u.db.Conn(ctx); err != nil {
+		return
+	}
+	// the transaction spans multiple reads, so it must outlive the context
+	// of the read beginning it.
+	opts := &sql.TxOptions{Isolation: u.isolation, ReadOnly: true}
+	if u.tx, err = u.conn.BeginTx(context.Background(), opts); err != nil {
+		u.conn.Close()
+		u.conn = nil
+		return
+	}
+	u.guard = u.txGuard()
+	return
+}
+
+// end ends the read-only transaction, committing it when the provided commit
+// flag is set and rolling it back otherwise.
+func (u *readOnlyUnit) end(ctx context.Context, commit bool) (err error) {
+	if u.tx == nil {
+		return
+	}
+	if commit {
+		err = u.tx.Commit()
+	} else {
+		err = u.rollback(ctx, u.tx)
+	}
+	u.guard.close()
+	err = multierr.Append(err, u.conn.Close())
+	u.tx, u.conn, u.guard = nil, nil, nil
+	return
+}
+
+// discardedErr provides ErrReadOnlyUnitDiscarded when the work unit has been
+// discarded.
+func (u *readOnlyUnit) discardedErr() error {
+	if u.Status() == UnitStatusDiscarded {
+		u.logger.Error(ErrReadOnlyUnitDiscarded.Error())
+		return ErrReadOnlyUnitDiscarded
+	}
+	return nil
+}
+
+func (u *readOnlyUnit) Read(ctx context.Context, f UnitReadFunc) (err error) {
+	u.checkGoroutine("Read")
+	if err = u.discardedErr(); err != nil {
+		return
+	}
+	if err = u.terminalErr(); err != nil {
+		return
+	}
+	u.txMutex.Lock()
+	defer u.txMutex.Unlock()
+	if err = u.begin(ctx); err != nil {
+		u.logger.Error(err.Error())
+		return
+	}
+	mCtx := UnitMapperContext{
+		Tx:          u.tx,
+		SaveID:      u.readID,
+		AttemptID:   uuid.NewString(),
+		conn:        u.conn,
+		guard:       u.guard,
+		commentTags: u.sqlCommentTags,
+		driverName:  u.driverName,
+		attempt:     1,
+		maxAttempts: 1,
+	}
+	if err = f(ctx, mCtx); err != nil {
+		err = multierr.Append(err, u.end(ctx, false))
+		u.logger.Error(err.Error())
+	}
+	return
+}
+
+// Save ends the read-only transaction of the work unit, if one has begun.
+func (u *readOnlyUnit) Save(ctx context.Context, opts ...UnitSaveOption) (err error) {
+	ctx, end := u.traceSave(ctx)
+	defer func() { end(err) }()
+	return u.withSaveMiddleware(u.saveAll)(ctx)
+}
+
+// saveAll ends the read-only transaction of the work unit, emitting the same
+// metrics and executing the same actions as the saves of other work units.
+func (u *readOnlyUnit) saveAll(ctx context.Context) (err error) {
+	u.checkGoroutine("Save")
+	defer u.beginSaving()()
+	if err = u.discardedErr(); err != nil {
+		return
+	}
+	if err = u.terminalErr(); err != nil {
+		return
+	}
+	if err = u.executeActions(ctx, UnitActionTypeBeforeSave); err != nil {
+		return
+	}
+
+	//setup timer.
+	stop := u.scope.start(u.metrics.Save)
+	start := time.Now()
+	defer func() {
+		stop()
+		u.executeSaveActions(ctx, time.Since(start), err)
+		if err == nil {
+			u.transition(UnitStatusCompleted)
+			u.scope.counter(u.metrics.SaveSuccess, 1)
+			u.executeActions(ctx, UnitActionTypeAfterSave)
+		} else {
+			u.transition(UnitStatusFailed)
+			err = u.classify(err)
+			u.logger.Error(err.Error())
+		}
+	}()
+
+	u.txMutex.Lock()
+	defer u.txMutex.Unlock()
+	err = u.end(ctx, true)
+	return
+}
+
+// Discard rolls back the read-only transaction of the work unit, if one has
+// begun, releasing its connection.
+func (u *readOnlyUnit) Discard(ctx context.Context) (err error) {
+	u.txMutex.Lock()
+	defer u.txMutex.Unlock()
+	if !u.transition(UnitStatusDiscarded) {
+		return
+	}
+	if err = u.end(ctx, false); err != nil {
+		u.logger.Error(err.Error())
+	}
+	return
+}
+
+func (u *readOnlyUnit) SaveBackground() error {
+	return u.Save(context.Background())
+}
+
+// SaveAsync ends the read-only transaction of the work unit in a new
+// goroutine, providing the future for the outcome.
+func (u *readOnlyUnit) SaveAsync(ctx context.Context) *UnitSaveFuture {
+	return NewUnitSaveFuture(ctx, func(ctx context.Context) error {
+		return u.Save(ctx)
+	})
+}
@@ -0,0 +1,47 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package work
+
+import (
+	"fmt"
+	"sync/atomic"
+)
+
+// beginSaving marks the work unit as saving, returning the function that
+// unmarks it.
+func (u *unit) beginSaving() func() {
+	atomic.AddInt32(&u.saving, 1)
+	return func() { atomic.AddInt32(&u.saving, -1) }
+}
+
+// checkReentrancy panics when the provided staging operation is performed
+// while the work unit is being saved, such as by a data mapper or action
+// calling back into the work unit. Staging entities while saving mutates the
+// entities being iterated, so the outcome of the save would be undefined.
+func (u *unit) checkReentrancy(operation string) {
+	if !u.detectReentrancy || atomic.LoadInt32(&u.saving) == 0 {
+		return
+	}
+	if u.deferredStagingPasses > 0 && u.db != nil {
+		// entities staged while saving are applied in deferred passes.
+		return
+	}
+	msg := fmt.Sprintf("work: %s called while the work unit is being saved; "+
+		"data mappers and actions must not stage entities into the work unit "+
+		"they are saving, and should stage them into a new work unit instead", operation)
+	u.logger.Error(msg)
+	panic(msg)
+}
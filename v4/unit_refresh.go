@@ -0,0 +1,99 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package work
+
+import (
+	"context"
+	"database/sql"
+
+	"go.uber.org/multierr"
+)
+
+// saved provides the added, upserted, and altered entities of the work unit
+// that were not quarantined, organized by type name.
+func (u *unit) saved() map[TypeName][]interface{} {
+	u.mutex.RLock()
+	defer u.mutex.RUnlock()
+	saved := make(map[TypeName][]interface{})
+	for _, staged := range []map[TypeName][]interface{}{u.additions, u.upserts, u.alterations} {
+		for t, entities := range staged {
+			for _, entity := range entities {
+				if !u.isQuarantined(t, entity) {
+					saved[t] = append(saved[t], entity)
+				}
+			}
+		}
+	}
+	return saved
+}
+
+// refreshEntities reloads the saved entities from the data store and caches
+// them.
+func (u *unit) refreshEntities(ctx context.Context, mCtx UnitMapperContext) (err error) {
+	var refreshed []interface{}
+	for t, entities := range u.saved() {
+		f, ok := u.refreshFunc(t)
+		if !ok {
+			continue
+		}
+		if err = f(ctx, mCtx, entities...); err != nil {
+			u.logger.Error(err.Error(), "typeName", t.String())
+			return
+		}
+		refreshed = append(refreshed, entities...)
+	}
+	if cacheErr := u.cached.storeAll(ctx, refreshed); cacheErr != nil {
+		u.logger.Warn(cacheErr.Error())
+	}
+	return
+}
+
+// refresh reloads the saved entities from the data store. Since the entities
+// have already been saved, failures are logged rather than returned.
+func (u *bestEffortUnit) refresh(ctx context.Context, mCtx UnitMapperContext) {
+	if !u.refreshAfterSave {
+		return
+	}
+	if err := u.refreshEntities(ctx, mCtx); err != nil {
+		u.logger.Warn("unable to refresh entities after save", "error", err.Error())
+	}
+}
+
+// refresh reloads the saved entities from the data store within a read-only
+// transaction. Since the entities have already been saved, failures are
+// logged rather than returned.
+func (u *sqlUnit) refresh(ctx context.Context, mCtx UnitMapperContext) {
+	if !u.refreshAfterSave {
+		return
+	}
+	err := u.AwaitToken(ctx)
+	var tx *sql.Tx
+	if err == nil {
+		tx, err = u.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	}
+	if err == nil {
+		mCtx.Tx = tx
+		mCtx.commentTags = u.sqlCommentTags
+		if err = u.refreshEntities(ctx, mCtx); err != nil {
+			err = multierr.Combine(err, tx.Rollback())
+		} else {
+			err = tx.Commit()
+		}
+	}
+	if err != nil {
+		u.logger.Warn("unable to refresh entities after save", "error", err.Error())
+	}
+}
@@ -0,0 +1,149 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package work
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/avast/retry-go/v4"
+)
+
+// UnitRetryContext describes the save of a work unit that is being retried.
+type UnitRetryContext struct {
+	// Context is the context provided when saving the work unit.
+	Context context.Context
+	// SaveID identifies the save being retried.
+	SaveID string
+	// MaxAttempts is the maximum number of attempts, where zero indicates
+	// that attempts are performed until the save succeeds.
+	MaxAttempts int
+}
+
+// UnitRetryFunc is invoked before the save of a work unit is retried, with
+// the zero-based attempt that failed and the error it failed with.
+type UnitRetryFunc func(attempt uint, err error, rCtx UnitRetryContext)
+
+// onRetry provides the retry option that logs and counts each failed
+// attempt of the save identified by the provided save ID, invoking the retry
+// functions of the work unit when another attempt follows.
+func (u *unit) onRetry(ctx context.Context, saveID string, reset func()) retry.Option {
+	return retry.OnRetry(func(attempt uint, err error) {
+		if reset != nil {
+			reset()
+		}
+		u.logger.Warn("attempted retry", "attempt", int(attempt+1), "error", err.Error())
+		u.scope.counter(u.metrics.RetryAttempt, 1)
+		if last := u.retryAttempts; last != 0 && int(attempt+1) >= last {
+			return
+		}
+		rCtx := UnitRetryContext{Context: ctx, SaveID: saveID, MaxAttempts: u.retryAttempts}
+		for _, f := range u.retryFuncs {
+			f(attempt, err, rCtx)
+		}
+	})
+}
+
+// UnitRetryAttempt describes a failed attempt to save a work unit.
+type UnitRetryAttempt struct {
+	// Attempt is the one-based number of the attempt.
+	Attempt int
+	// AttemptID identifies the attempt, as provided to the data mappers.
+	AttemptID string
+	// Time is when the attempt failed.
+	Time time.Time
+	// Err is the error the attempt failed with.
+	Err error
+}
+
+// UnitRetryError represents the error that is returned when saving a work
+// unit fails while retry history is enabled, providing the error of each
+// failed attempt so that saves failing for the same reason each attempt can
+// be told apart from saves flapping between causes.
+type UnitRetryError struct {
+	// Attempts are the failed attempts, in the order they were attempted.
+	// Only the most recent attempts are provided when the history is capped.
+	Attempts []UnitRetryAttempt
+	// Omitted is the number of earlier failed attempts omitted due to the
+	// history being capped.
+	Omitted int
+	// Err is the error the final attempt failed with.
+	Err error
+}
+
+// Error provides the error message.
+func (e *UnitRetryError) Error() string {
+	return fmt.Sprintf("%d attempts failed: %v", len(e.Attempts)+e.Omitted, e.Err)
+}
+
+// Unwrap provides the error the final attempt failed with.
+func (e *UnitRetryError) Unwrap() error {
+	return e.Err
+}
+
+// Errors provides the messages of the errors of each failed attempt, in the
+// order they were attempted.
+func (e *UnitRetryError) Errors() []string {
+	messages := make([]string, 0, len(e.Attempts))
+	for _, a := range e.Attempts {
+		messages = append(messages, a.Err.Error())
+	}
+	return messages
+}
+
+// unitRetryHistory records the failed attempts of the save of a work unit.
+type unitRetryHistory struct {
+	enabled  bool
+	limit    int
+	attempts []UnitRetryAttempt
+	omitted  int
+}
+
+// reset discards the attempts recorded for a previous save.
+func (h *unitRetryHistory) reset() {
+	h.attempts, h.omitted = nil, 0
+}
+
+// record records the provided error for the attempt with the provided
+// mapper context, if it failed, providing the error.
+func (h *unitRetryHistory) record(mCtx UnitMapperContext, err error) error {
+	if !h.enabled || err == nil {
+		return err
+	}
+	h.attempts = append(h.attempts, UnitRetryAttempt{
+		Attempt:   mCtx.Attempt(),
+		AttemptID: mCtx.AttemptID,
+		Time:      time.Now(),
+		Err:       err,
+	})
+	if h.limit > 0 && len(h.attempts) > h.limit {
+		h.omitted = h.omitted + 1
+		h.attempts = h.attempts[1:]
+	}
+	return err
+}
+
+// wrap provides the provided error of a failed save as a UnitRetryError
+// describing the recorded attempts.
+func (h *unitRetryHistory) wrap(err error) error {
+	if !h.enabled || err == nil || len(h.attempts) == 0 {
+		return err
+	}
+	attempts := make([]UnitRetryAttempt, len(h.attempts))
+	copy(attempts, h.attempts)
+	return &UnitRetryError{Attempts: attempts, Omitted: h.omitted, Err: err}
+}
@@ -0,0 +1,109 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package work
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+)
+
+// UnitSavePanicError represents the error that completes the future of a
+// save that panicked, since the panic cannot be recovered by the caller
+// awaiting the future.
+type UnitSavePanicError struct {
+	// Value is the value the save panicked with.
+	Value interface{}
+	// Stack is the stack trace of the goroutine when the save panicked.
+	Stack []byte
+}
+
+// Error provides the error message.
+func (e *UnitSavePanicError) Error() string {
+	return fmt.Sprintf("panic: unable to save work unit: %v", e.Value)
+}
+
+// Unwrap provides the value the save panicked with when it is an error.
+func (e *UnitSavePanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
+// UnitSaveFuture represents the eventual outcome of saving a work unit,
+// allowing callers to overlap the save with other work and join it later.
+type UnitSaveFuture struct {
+	done chan struct{}
+	err  error
+}
+
+// newUnitSaveFuture creates a new future that has yet to complete.
+func newUnitSaveFuture() *UnitSaveFuture {
+	return &UnitSaveFuture{done: make(chan struct{})}
+}
+
+// NewUnitSaveFuture performs the provided save in a new goroutine with the
+// provided context, providing the future for its outcome. It allows types
+// wrapping work units to implement SaveAsync. The new goroutine is exempt
+// from the goroutine audits of work units, and a panic within the save
+// completes the future with a UnitSavePanicError.
+func NewUnitSaveFuture(ctx context.Context, save func(context.Context) error) *UnitSaveFuture {
+	f := newUnitSaveFuture()
+	go func() {
+		var err error
+		defer func() {
+			if r := recover(); r != nil {
+				err = &UnitSavePanicError{Value: r, Stack: debug.Stack()}
+			}
+			f.complete(err)
+		}()
+		defer sanctionGoroutine()()
+		err = save(ctx)
+	}()
+	return f
+}
+
+// complete records the outcome of the save.
+func (f *UnitSaveFuture) complete(err error) {
+	f.err = err
+	close(f.done)
+}
+
+// Done provides a channel that is closed once the save has completed.
+func (f *UnitSaveFuture) Done() <-chan struct{} {
+	return f.done
+}
+
+// Err provides the error encountered when saving, which is nil until the
+// save has completed.
+func (f *UnitSaveFuture) Err() error {
+	select {
+	case <-f.done:
+		return f.err
+	default:
+		return nil
+	}
+}
+
+// Wait blocks until the save has completed, providing the error encountered
+// when saving, or until the provided context is done.
+func (f *UnitSaveFuture) Wait(ctx context.Context) error {
+	select {
+	case <-f.done:
+		return f.err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,191 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package work
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// UnitSavePhase represents a phase of saving a work unit.
+type UnitSavePhase string
+
+// The various phases of saving a work unit.
+const (
+	// UnitSavePhaseBegin indicates the phase that starts the transaction.
+	UnitSavePhaseBegin UnitSavePhase = "begin"
+	// UnitSavePhaseInserts indicates the phase that inserts added entities.
+	UnitSavePhaseInserts UnitSavePhase = "inserts"
+	// UnitSavePhaseUpserts indicates the phase that upserts entities.
+	UnitSavePhaseUpserts UnitSavePhase = "upserts"
+	// UnitSavePhaseUpdates indicates the phase that updates altered and
+	// patched entities.
+	UnitSavePhaseUpdates UnitSavePhase = "updates"
+	// UnitSavePhaseDeletes indicates the phase that deletes removed entities.
+	UnitSavePhaseDeletes UnitSavePhase = "deletes"
+	// UnitSavePhaseCommit indicates the phase that commits the transaction.
+	UnitSavePhaseCommit UnitSavePhase = "commit"
+	// UnitSavePhaseBulk indicates the phase that applies the changes with a
+	// single bulk write.
+	UnitSavePhaseBulk UnitSavePhase = "bulk"
+)
+
+// UnitTypeCounts represents the number of entities of a single type staged
+// within a work unit.
+type UnitTypeCounts struct {
+	Additions       int
+	Upserts         int
+	Alterations     int
+	Patches         int
+	Removals        int
+	RemovalCriteria int
+}
+
+// UnitSaveDiagnostics represents a snapshot of a save that exceeded the slow
+// save threshold.
+type UnitSaveDiagnostics struct {
+	// SaveID uniquely identifies the save.
+	SaveID string
+	// Duration is the time taken by the save.
+	Duration time.Duration
+	// Err is the error encountered by the save, if any.
+	Err error
+	// Counts are the number of entities staged, organized by type name.
+	Counts map[TypeName]UnitTypeCounts
+	// Phases are the time taken by each phase of the save, accumulated
+	// across attempts.
+	Phases map[UnitSavePhase]time.Duration
+	// Attempts is the number of attempts performed by the save.
+	Attempts int
+	// Stacks are the stack traces of all goroutines, which are only captured
+	// when the work.UnitSlowSaveStacks option is used.
+	Stacks []byte
+}
+
+// UnitSlowSaveHandler handles the diagnostics of a save that exceeded the
+// slow save threshold.
+type UnitSlowSaveHandler func(UnitSaveDiagnostics)
+
+// unitSaveTimings tracks the phases and attempts of a save.
+type unitSaveTimings struct {
+	mutex    sync.Mutex
+	phases   map[UnitSavePhase]time.Duration
+	attempts int
+}
+
+func (t *unitSaveTimings) reset() {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.phases = make(map[UnitSavePhase]time.Duration)
+	t.attempts = 0
+}
+
+func (t *unitSaveTimings) record(phase UnitSavePhase, d time.Duration) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if t.phases == nil {
+		t.phases = make(map[UnitSavePhase]time.Duration)
+	}
+	t.phases[phase] = t.phases[phase] + d
+}
+
+func (t *unitSaveTimings) attempt() {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.attempts = t.attempts + 1
+}
+
+// timed performs the provided save phase, tracking and emitting the time
+// taken.
+func (u *unit) timed(phase UnitSavePhase, f func() error) error {
+	start := time.Now()
+	defer func() {
+		d := time.Since(start)
+		u.timings.record(phase, d)
+		u.scope.timer(fmt.Sprintf("%s.%s", u.metrics.Save, phase), d)
+	}()
+	return f()
+}
+
+// counts provides the number of entities staged, organized by type name.
+func (u *unit) counts() map[TypeName]UnitTypeCounts {
+	u.mutex.RLock()
+	defer u.mutex.RUnlock()
+	counts := make(map[TypeName]UnitTypeCounts)
+	update := func(t TypeName, f func(*UnitTypeCounts)) {
+		c := counts[t]
+		f(&c)
+		counts[t] = c
+	}
+	for t, e := range u.additions {
+		update(t, func(c *UnitTypeCounts) { c.Additions = len(e) })
+	}
+	for t, e := range u.upserts {
+		update(t, func(c *UnitTypeCounts) { c.Upserts = len(e) })
+	}
+	for t, e := range u.alterations {
+		update(t, func(c *UnitTypeCounts) { c.Alterations = len(e) })
+	}
+	for t, p := range u.patches {
+		update(t, func(c *UnitTypeCounts) { c.Patches = len(p) })
+	}
+	for t, e := range u.removals {
+		update(t, func(c *UnitTypeCounts) { c.Removals = len(e) })
+	}
+	for t, e := range u.removalCriteria {
+		update(t, func(c *UnitTypeCounts) { c.RemovalCriteria = len(e) })
+	}
+	return counts
+}
+
+// detectSlowSave invokes the slow save handler with the diagnostics of the
+// save when it exceeds the slow save threshold.
+func (u *unit) detectSlowSave(saveID string, d time.Duration, err error) {
+	if u.slowSaveHandler == nil || d < u.slowSaveThreshold {
+		return
+	}
+	u.timings.mutex.Lock()
+	phases := make(map[UnitSavePhase]time.Duration, len(u.timings.phases))
+	for p, pd := range u.timings.phases {
+		phases[p] = pd
+	}
+	attempts := u.timings.attempts
+	u.timings.mutex.Unlock()
+
+	diagnostics := UnitSaveDiagnostics{
+		SaveID:   saveID,
+		Duration: d,
+		Err:      err,
+		Counts:   u.counts(),
+		Phases:   phases,
+		Attempts: attempts,
+	}
+	if u.slowSaveStacks {
+		buf := make([]byte, 64<<10)
+		for {
+			n := runtime.Stack(buf, true)
+			if n < len(buf) {
+				diagnostics.Stacks = buf[:n]
+				break
+			}
+			buf = make([]byte, 2*len(buf))
+		}
+	}
+	u.logger.Warn("slow save detected", "saveID", saveID, "duration", d.String())
+	u.slowSaveHandler(diagnostics)
+}
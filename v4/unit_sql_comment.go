@@ -0,0 +1,59 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package work
+
+import (
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// sqlComment provides the comment for the provided tags, following the
+// sqlcommenter conventions: keys are sorted, and both keys and values are
+// URL encoded, with values enclosed in single quotes.
+func sqlComment(tags map[string]string) string {
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	pairs := make([]string, 0, len(keys))
+	for _, k := range keys {
+		// single quotes are percent encoded, so the values need no escaping.
+		pairs = append(pairs, url.QueryEscape(k)+"='"+url.PathEscape(tags[k])+"'")
+	}
+	return "/*" + strings.Join(pairs, ",") + "*/"
+}
+
+// Comment annotates the provided statement with a comment identifying the
+// work unit, following the sqlcommenter conventions, when the
+// work.UnitSQLComments option is used. Statements that already contain a
+// comment are left unchanged.
+func (mCtx UnitMapperContext) Comment(query string) string {
+	if mCtx.commentTags == nil || strings.Contains(query, "/*") || strings.Contains(query, "--") {
+		return query
+	}
+	tags := make(map[string]string, len(mCtx.commentTags)+2)
+	for k, v := range mCtx.commentTags {
+		tags[k] = v
+	}
+	tags["save_id"] = mCtx.SaveID
+	if mCtx.AttemptID != "" {
+		tags["attempt_id"] = mCtx.AttemptID
+	}
+	trimmed := strings.TrimRight(query, " \t\n;")
+	return trimmed + " " + sqlComment(tags) + query[len(trimmed):]
+}
@@ -0,0 +1,104 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package work
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ErrUnitEvicted represents the error that is returned when staging entities
+// into, or saving, a work unit whose staged changes were discarded for being
+// open and dirty for too long.
+var ErrUnitEvicted = errors.New("work unit was evicted for being stale")
+
+// unitStaleness tracks the staleness of a work unit.
+type unitStaleness struct {
+	once  sync.Once
+	mutex sync.Mutex
+	timer *time.Timer
+}
+
+// heartbeat starts tracking the staleness of the work unit once it becomes
+// dirty, when a staleness threshold is configured. Work units that remain
+// dirty without being saved past the threshold are reported, repeatedly,
+// every threshold thereafter, until they are saved or evicted.
+func (u *unit) heartbeat() {
+	if u.staleAfter <= 0 {
+		return
+	}
+	u.staleness.once.Do(func() {
+		u.staleness.mutex.Lock()
+		defer u.staleness.mutex.Unlock()
+		u.staleness.timer = time.AfterFunc(u.staleAfter-time.Since(u.created), u.checkStale)
+	})
+}
+
+// stopStale stops tracking the staleness of the work unit.
+func (u *unit) stopStale() {
+	u.staleness.mutex.Lock()
+	defer u.staleness.mutex.Unlock()
+	if u.staleness.timer != nil {
+		u.staleness.timer.Stop()
+	}
+}
+
+// checkStale reports the work unit when it remains dirty without being
+// saved, evicting it when configured to.
+func (u *unit) checkStale() {
+	if UnitStatus(atomic.LoadInt32(&u.status)).terminal() {
+		return
+	}
+	u.mutex.Lock()
+	dirty := u.dirty()
+	if dirty && u.evictStale && atomic.LoadInt32(&u.saving) == 0 {
+		u.discard()
+		u.transition(UnitStatusDiscarded)
+	}
+	u.mutex.Unlock()
+	if !dirty {
+		return
+	}
+	age := time.Since(u.created)
+	u.scope.counter(u.metrics.Stale, 1)
+	if u.Status() == UnitStatusDiscarded {
+		u.scope.counter(u.metrics.StaleEvicted, 1)
+		u.logger.Error("evicted stale work unit", "age", age.String())
+		return
+	}
+	u.logger.Warn("work unit has been open and dirty past the staleness threshold", "age", age.String())
+	u.staleness.mutex.Lock()
+	defer u.staleness.mutex.Unlock()
+	// work units that became terminal since are no longer tracked.
+	if !UnitStatus(atomic.LoadInt32(&u.status)).terminal() {
+		u.staleness.timer.Reset(u.staleAfter)
+	}
+}
+
+// discard discards the changes staged within the work unit. The lock of the
+// work unit must be held.
+func (u *unit) discard() {
+	u.additions = make(map[TypeName][]interface{})
+	u.alterations = make(map[TypeName][]interface{})
+	u.removals = make(map[TypeName][]interface{})
+	u.upserts = make(map[TypeName][]interface{})
+	u.removalCriteria = make(map[TypeName][]interface{})
+	u.patches = make(map[TypeName][]UnitPatch)
+	u.additionCount, u.alterationCount, u.removalCount, u.upsertCount = 0, 0, 0, 0
+	u.criteriaCount, u.patchCount = 0, 0
+}
@@ -0,0 +1,112 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package work
+
+import (
+	"errors"
+	"sync/atomic"
+)
+
+// ErrUnitCompleted represents the error that is returned when staging
+// entities into, or saving, a work unit that has already been saved
+// successfully.
+var ErrUnitCompleted = errors.New("work unit has already been saved")
+
+// UnitStatus represents the status of a work unit within its lifecycle.
+type UnitStatus int32
+
+const (
+	// UnitStatusPending indicates that the work unit has not been saved.
+	UnitStatusPending UnitStatus = iota
+	// UnitStatusSaving indicates that the work unit is being saved.
+	UnitStatusSaving
+	// UnitStatusCompleted indicates that the work unit was saved
+	// successfully. Completed work units can no longer be staged into or
+	// saved.
+	UnitStatusCompleted
+	// UnitStatusFailed indicates that the most recent save of the work unit
+	// failed. Failed work units can be staged into and saved again.
+	UnitStatusFailed
+	// UnitStatusDiscarded indicates that the staged changes of the work unit
+	// were discarded, such as when it is evicted for being stale.
+	UnitStatusDiscarded
+)
+
+var unitStatusNames = map[UnitStatus]string{
+	UnitStatusPending:   "pending",
+	UnitStatusSaving:    "saving",
+	UnitStatusCompleted: "completed",
+	UnitStatusFailed:    "failed",
+	UnitStatusDiscarded: "discarded",
+}
+
+// String provides the name of the status.
+func (s UnitStatus) String() string {
+	return unitStatusNames[s]
+}
+
+// terminal determines if the status ends the lifecycle of the work unit.
+func (s UnitStatus) terminal() bool {
+	return s == UnitStatusCompleted || s == UnitStatusDiscarded
+}
+
+// Status provides the status of the work unit.
+func (u *unit) Status() UnitStatus {
+	s := UnitStatus(atomic.LoadInt32(&u.status))
+	if !s.terminal() && atomic.LoadInt32(&u.saving) != 0 {
+		return UnitStatusSaving
+	}
+	return s
+}
+
+// transition moves the work unit to the provided status, unless its
+// lifecycle has already ended, indicating whether it was moved.
+func (u *unit) transition(s UnitStatus) bool {
+	for {
+		current := atomic.LoadInt32(&u.status)
+		if UnitStatus(current).terminal() {
+			return false
+		}
+		if atomic.CompareAndSwapInt32(&u.status, current, int32(s)) {
+			if s.terminal() {
+				u.untrack()
+				u.stopStale()
+			}
+			// the cache entries of discarded changes need no invalidation,
+			// while those of saved changes continue to be retried.
+			if s == UnitStatusDiscarded {
+				u.stopInvalidation()
+			}
+			return true
+		}
+	}
+}
+
+// terminalErr provides ErrUnitCompleted when the work unit has already been
+// saved successfully, and ErrUnitEvicted when its staged changes were
+// discarded.
+func (u *unit) terminalErr() (err error) {
+	switch UnitStatus(atomic.LoadInt32(&u.status)) {
+	case UnitStatusCompleted:
+		err = ErrUnitCompleted
+	case UnitStatusDiscarded:
+		err = ErrUnitEvicted
+	default:
+		return nil
+	}
+	u.logger.Error(err.Error())
+	return err
+}
@@ -16,11 +16,15 @@
 package work_test
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"log"
+	"runtime"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/freerware/work/v4"
 	"github.com/freerware/work/v4/internal/mock"
@@ -29,6 +33,7 @@ import (
 	"github.com/stretchr/testify/suite"
 	"github.com/uber-go/tally/v4"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
 )
 
 type UnitTestSuite struct {
@@ -203,20 +208,1422 @@ func (s *UnitTestSuite) TestUnit_Add_MissingDataMapper() {
 	s.Error(err)
 }
 
+func (s *UnitTestSuite) TestUnit_Add_InvalidEntity() {
+
+	// arrange.
+	ctx := context.Background()
+	var foo *test.Foo
+
+	// action.
+	err := s.sut.Add(ctx, test.Foo{ID: 28}, foo)
+
+	// assert.
+	s.ErrorIs(err, work.ErrInvalidEntity)
+	var invalid *work.UnitInvalidEntityError
+	s.Require().ErrorAs(err, &invalid)
+	s.Equal(foo, invalid.Entity)
+	_, ok := s.sut.StateOf(test.Foo{ID: 28})
+	s.False(ok)
+}
+
+func (s *UnitTestSuite) TestUnit_RejectNilEntities() {
+
+	// arrange.
+	ctx := context.Background()
+	dm := make(map[work.TypeName]work.UnitDataMapper)
+	for t, m := range s.mappers {
+		dm[t] = m
+	}
+	sut, err := work.NewUnit(work.UnitDataMappers(dm), work.UnitRejectNilEntities())
+	s.Require().NoError(err)
+
+	// action + assert.
+	s.ErrorIs(sut.Add(ctx, test.Foo{}), work.ErrInvalidEntity)
+	s.ErrorIs(sut.Alter(ctx, &test.Bar{}), work.ErrInvalidEntity)
+	s.ErrorIs(sut.Remove(ctx, nil), work.ErrInvalidEntity)
+	s.NoError(sut.Add(ctx, test.Foo{ID: 28}))
+	s.NoError(s.sut.Add(ctx, test.Foo{}))
+}
+
+func (s *UnitTestSuite) TestUnit_StrictTracking() {
+
+	// arrange.
+	ctx := context.Background()
+	dm := make(map[work.TypeName]work.UnitDataMapper)
+	for t, m := range s.mappers {
+		dm[t] = m
+	}
+	sut, err := work.NewUnit(work.UnitDataMappers(dm), work.UnitStrictTracking())
+	s.Require().NoError(err)
+	s.Require().NoError(sut.Register(ctx, test.Foo{ID: 28}))
+	s.Require().NoError(sut.Add(ctx, test.Bar{ID: "28"}))
+
+	// action + assert.
+	s.ErrorIs(sut.Alter(ctx, test.Foo{ID: 1992}), work.ErrUntrackedEntity)
+	s.ErrorIs(sut.Remove(ctx, test.Bar{ID: "1992"}), work.ErrUntrackedEntity)
+	s.NoError(sut.Alter(ctx, test.Foo{ID: 28}))
+	s.NoError(sut.Remove(ctx, test.Bar{ID: "28"}))
+}
+
+func (s *UnitTestSuite) TestUnit_GoroutineAudit() {
+
+	// arrange.
+	ctx := context.Background()
+	dm := make(map[work.TypeName]work.UnitDataMapper)
+	for t, m := range s.mappers {
+		dm[t] = m
+	}
+	sut, err := work.NewUnit(work.UnitDataMappers(dm), work.UnitGoroutineAudit(work.UnitGoroutineAuditPanic))
+	s.Require().NoError(err)
+	s.Require().NotPanics(func() { sut.Add(ctx, test.Foo{ID: 28}) })
+
+	// action.
+	var recovered interface{}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		defer func() { recovered = recover() }()
+		sut.Add(ctx, test.Foo{ID: 1992})
+	}()
+	<-done
+
+	// assert.
+	s.Require().NotNil(recovered)
+	s.Contains(recovered, "Add called by goroutine")
+}
+
+func (s *UnitTestSuite) TestUnit_GoroutineAudit_Log() {
+
+	// arrange.
+	ctx := context.Background()
+	dm := make(map[work.TypeName]work.UnitDataMapper)
+	for t, m := range s.mappers {
+		dm[t] = m
+	}
+	var buf bytes.Buffer
+	sut, err := work.NewUnit(
+		work.UnitDataMappers(dm),
+		work.UnitWithStandardLogger(log.New(&buf, "", 0)),
+		work.UnitGoroutineAudit(work.UnitGoroutineAuditLog),
+	)
+	s.Require().NoError(err)
+
+	// action.
+	errs := make(chan error, 1)
+	go func() { errs <- sut.Add(ctx, test.Foo{ID: 28}) }()
+
+	// assert.
+	s.NoError(<-errs)
+	s.Contains(buf.String(), "Add called by goroutine")
+}
+
+func (s *UnitTestSuite) TestUnit_GoroutineAudit_Group() {
+
+	// arrange.
+	ctx := context.Background()
+	dm := make(map[work.TypeName]work.UnitDataMapper)
+	for t, m := range s.mappers {
+		dm[t] = m
+	}
+	sut, err := work.NewUnit(work.UnitDataMappers(dm), work.UnitGoroutineAudit(work.UnitGoroutineAuditPanic))
+	s.Require().NoError(err)
+	g, gctx := sut.Group(ctx)
+
+	// action.
+	for _, foo := range []test.Foo{{ID: 28}, {ID: 1992}} {
+		foo := foo
+		g.Go(func() error { return sut.Add(gctx, foo) })
+	}
+
+	// assert.
+	s.NotPanics(func() { s.NoError(g.Wait()) })
+}
+
+func (s *UnitTestSuite) TestUnit_GoroutineAudit_SaveAsync() {
+
+	// arrange.
+	ctx := context.Background()
+	foo := test.Foo{ID: 28}
+	dm := make(map[work.TypeName]work.UnitDataMapper)
+	for t, m := range s.mappers {
+		dm[t] = m
+	}
+	sut, err := work.NewUnit(work.UnitDataMappers(dm), work.UnitGoroutineAudit(work.UnitGoroutineAuditPanic))
+	s.Require().NoError(err)
+	s.Require().NoError(sut.Add(ctx, foo))
+	s.mappers[work.TypeNameOf(foo)].EXPECT().Insert(gomock.Any(), gomock.Any(), foo).Return(nil)
+
+	// action.
+	err = sut.SaveAsync(ctx).Wait(ctx)
+
+	// assert.
+	s.NoError(err)
+}
+
+func (s *UnitTestSuite) TestUnit_GoroutineAudit_BatchingUniter() {
+
+	// arrange.
+	ctx := context.Background()
+	foos := []interface{}{test.Foo{ID: 28}, test.Foo{ID: 1992}}
+	dm := make(map[work.TypeName]work.UnitDataMapper)
+	for t, m := range s.mappers {
+		dm[t] = m
+	}
+	s.mappers[work.TypeNameOf(test.Foo{})].EXPECT().Insert(gomock.Any(), gomock.Any(), foos[0]).Return(nil)
+	s.mappers[work.TypeNameOf(test.Foo{})].EXPECT().Insert(gomock.Any(), gomock.Any(), foos[1]).Return(nil)
+	sut, err := work.NewBatchingUniter(time.Millisecond, 0,
+		work.UnitDataMappers(dm), work.UnitGoroutineAudit(work.UnitGoroutineAuditPanic))
+	s.Require().NoError(err)
+	defer sut.Close()
+	timed, err := sut.Unit()
+	s.Require().NoError(err)
+	s.Require().NoError(timed.Add(ctx, foos[0]))
+	full, err := work.NewBatchingUniter(time.Hour, 1,
+		work.UnitDataMappers(dm), work.UnitGoroutineAudit(work.UnitGoroutineAuditPanic))
+	s.Require().NoError(err)
+	defer full.Close()
+	flushed, err := full.Unit()
+	s.Require().NoError(err)
+	s.Require().NoError(flushed.Add(ctx, foos[1]))
+
+	// action.
+	errTimed := timed.Save(ctx)
+	errFlushed := flushed.Save(ctx)
+
+	// assert.
+	s.NoError(errTimed)
+	s.NoError(errFlushed)
+}
+
+func (s *UnitTestSuite) TestNewUnitSaveFuture_Panic() {
+
+	// arrange.
+	ctx := context.Background()
+	cause := errors.New("whoa")
+
+	// action.
+	f := work.NewUnitSaveFuture(ctx, func(ctx context.Context) error { panic(cause) })
+
+	// assert.
+	err := f.Wait(ctx)
+	var panicErr *work.UnitSavePanicError
+	s.Require().ErrorAs(err, &panicErr)
+	s.Equal(cause, panicErr.Value)
+	s.NotEmpty(panicErr.Stack)
+	s.ErrorIs(err, cause)
+}
+
+func (s *UnitTestSuite) TestTransfer() {
+
+	// arrange.
+	ctx := context.Background()
+	dm := make(map[work.TypeName]work.UnitDataMapper)
+	for t, m := range s.mappers {
+		dm[t] = m
+	}
+	from, err := work.NewUnit(work.UnitDataMappers(dm))
+	s.Require().NoError(err)
+	to, err := work.NewUnit(work.UnitDataMappers(dm))
+	s.Require().NoError(err)
+	foo, bar, baz := test.Foo{ID: 28}, test.Bar{ID: "28"}, test.Baz{Identifier: "28"}
+	s.Require().NoError(from.Register(ctx, foo, baz))
+	s.Require().NoError(from.Alter(ctx, foo))
+	s.Require().NoError(from.Add(ctx, bar))
+
+	// action.
+	err = work.Transfer(ctx, from, to, foo, bar)
+
+	// assert.
+	s.Require().NoError(err)
+	state, ok := to.StateOf(foo)
+	s.True(ok)
+	s.Equal(work.EntityStateAltered, state)
+	state, ok = to.StateOf(bar)
+	s.True(ok)
+	s.Equal(work.EntityStateAdded, state)
+	_, ok = from.StateOf(foo)
+	s.False(ok)
+	_, ok = from.StateOf(bar)
+	s.False(ok)
+	_, ok = from.StateOf(baz)
+	s.True(ok)
+	cached, err := to.Cached().Load(ctx, work.TypeNameOf(foo), foo.ID)
+	s.Require().NoError(err)
+	s.Equal(foo, cached)
+}
+
+func (s *UnitTestSuite) TestTransfer_NotStaged() {
+
+	// arrange.
+	ctx := context.Background()
+	dm := make(map[work.TypeName]work.UnitDataMapper)
+	for t, m := range s.mappers {
+		dm[t] = m
+	}
+	from, err := work.NewUnit(work.UnitDataMappers(dm))
+	s.Require().NoError(err)
+	to, err := work.NewUnit(work.UnitDataMappers(dm))
+	s.Require().NoError(err)
+	foo := test.Foo{ID: 28}
+	s.Require().NoError(from.Add(ctx, foo))
+
+	// action.
+	err = work.Transfer(ctx, from, to, foo, test.Foo{ID: 1992})
+
+	// assert.
+	s.ErrorIs(err, work.ErrEntityNotStaged)
+	state, ok := from.StateOf(foo)
+	s.True(ok)
+	s.Equal(work.EntityStateAdded, state)
+	_, ok = to.StateOf(foo)
+	s.False(ok)
+}
+
+func (s *UnitTestSuite) TestUnit_ConflictResolver() {
+	tests := []struct {
+		name       string
+		resolution work.UnitResolution
+		state      work.EntityState
+		err        error
+	}{
+		{"Ours", work.UnitResolution{Choice: work.UnitResolutionOurs}, work.EntityStateRemoved, nil},
+		{"Theirs", work.UnitResolution{Choice: work.UnitResolutionTheirs}, work.EntityStateAdded, nil},
+		{"Merge", work.UnitResolution{Choice: work.UnitResolutionMerge, Merged: test.Foo{ID: 28}}, work.EntityStateRemoved, nil},
+		{"Fail", work.UnitResolution{Choice: work.UnitResolutionFail}, work.EntityStateAdded, work.ErrConflict},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+
+			// arrange.
+			ctx := context.Background()
+			dm := make(map[work.TypeName]work.UnitDataMapper)
+			for t, m := range s.mappers {
+				dm[t] = m
+			}
+			foo := test.Foo{ID: 28}
+			var conflicts []work.UnitConflict
+			resolver := func(c work.UnitConflict) work.UnitResolution {
+				conflicts = append(conflicts, c)
+				return tt.resolution
+			}
+			sut, err := work.NewUnit(work.UnitDataMappers(dm), work.UnitConflictResolver(resolver))
+			s.Require().NoError(err)
+			s.Require().NoError(sut.Add(ctx, foo))
+
+			// action.
+			err = sut.Remove(ctx, foo)
+
+			// assert.
+			s.ErrorIs(err, tt.err)
+			state, ok := sut.StateOf(foo)
+			s.True(ok)
+			s.Equal(tt.state, state)
+			s.Require().Len(conflicts, 1)
+			s.Equal(work.EntityStateAdded, conflicts[0].TheirsState)
+			s.Equal(work.EntityStateRemoved, conflicts[0].OursState)
+			s.False(conflicts[0].Stale)
+		})
+	}
+}
+
+func (s *UnitTestSuite) TestUnit_ConflictResolver_UsesUnit() {
+	// arrange.
+	ctx := context.Background()
+	dm := make(map[work.TypeName]work.UnitDataMapper)
+	for t, m := range s.mappers {
+		dm[t] = m
+	}
+	foo := test.Foo{ID: 28}
+	var sut work.Unit
+	var states []work.EntityState
+	resolver := func(c work.UnitConflict) work.UnitResolution {
+		state, _ := sut.StateOf(c.Ours)
+		states = append(states, state)
+		return work.UnitResolution{Choice: work.UnitResolutionOurs}
+	}
+	sut, err := work.NewUnit(work.UnitDataMappers(dm), work.UnitConflictResolver(resolver))
+	s.Require().NoError(err)
+	s.Require().NoError(sut.Add(ctx, foo))
+
+	// action.
+	err = sut.Remove(ctx, foo)
+
+	// assert.
+	s.Require().NoError(err)
+	s.Equal([]work.EntityState{work.EntityStateAdded}, states)
+	state, ok := sut.StateOf(foo)
+	s.True(ok)
+	s.Equal(work.EntityStateRemoved, state)
+}
+
+func (s *UnitTestSuite) TestUnit_StaleAfter() {
+
+	// arrange.
+	ctx := context.Background()
+	dm := make(map[work.TypeName]work.UnitDataMapper)
+	for t, m := range s.mappers {
+		dm[t] = m
+	}
+	scope := tally.NewTestScope("test", map[string]string{})
+	sut, err := work.NewUnit(
+		work.UnitDataMappers(dm),
+		work.UnitTallyMetricScope(scope),
+		work.UnitStaleAfter(time.Millisecond, false),
+	)
+	s.Require().NoError(err)
+
+	// action.
+	s.Require().NoError(sut.Add(ctx, test.Foo{ID: 28}))
+
+	// assert.
+	s.Eventually(func() bool {
+		c, ok := scope.Snapshot().Counters()["test.unit.stale+unit_type=best_effort"]
+		return ok && c.Value() >= 2
+	}, time.Second, time.Millisecond)
+	s.NotContains(scope.Snapshot().Counters(), "test.unit.stale.evicted+unit_type=best_effort")
+	state, ok := sut.StateOf(test.Foo{ID: 28})
+	s.True(ok)
+	s.Equal(work.EntityStateAdded, state)
+
+	// saving stops the reports.
+	s.mappers[work.TypeNameOf(test.Foo{})].EXPECT().Insert(ctx, gomock.Any(), test.Foo{ID: 28}).Return(nil)
+	s.NoError(sut.Save(ctx))
+	reports := func() int64 {
+		return scope.Snapshot().Counters()["test.unit.stale+unit_type=best_effort"].Value()
+	}
+	saved := reports()
+	time.Sleep(20 * time.Millisecond)
+	s.LessOrEqual(reports(), saved+1)
+}
+
+func (s *UnitTestSuite) TestUnit_StaleAfter_Evict() {
+
+	// arrange.
+	ctx := context.Background()
+	dm := make(map[work.TypeName]work.UnitDataMapper)
+	for t, m := range s.mappers {
+		dm[t] = m
+	}
+	scope := tally.NewTestScope("test", map[string]string{})
+	sut, err := work.NewUnit(
+		work.UnitDataMappers(dm),
+		work.UnitTallyMetricScope(scope),
+		work.UnitStaleAfter(time.Millisecond, true),
+	)
+	s.Require().NoError(err)
+	s.Require().NoError(sut.Add(ctx, test.Foo{ID: 28}))
+	s.Require().Eventually(func() bool {
+		_, ok := scope.Snapshot().Counters()["test.unit.stale.evicted+unit_type=best_effort"]
+		return ok
+	}, time.Second, time.Millisecond)
+
+	// action.
+	err = sut.Save(ctx)
+
+	// assert.
+	s.ErrorIs(err, work.ErrUnitEvicted)
+	_, ok := sut.StateOf(test.Foo{ID: 28})
+	s.False(ok)
+	s.Equal(work.UnitStatusDiscarded, sut.Status())
+}
+
+func (s *UnitTestSuite) TestUnit_StaleAfter_Evict_Staging() {
+
+	// arrange.
+	ctx := context.Background()
+	dm := make(map[work.TypeName]work.UnitDataMapper)
+	for t, m := range s.mappers {
+		dm[t] = m
+	}
+	sut, err := work.NewUnit(
+		work.UnitDataMappers(dm),
+		work.UnitStaleAfter(time.Millisecond, true),
+	)
+	s.Require().NoError(err)
+	foo := test.Foo{ID: 28}
+	s.Require().NoError(sut.Add(ctx, foo))
+	s.Require().Eventually(func() bool {
+		return sut.Status() == work.UnitStatusDiscarded
+	}, time.Second, time.Millisecond)
+
+	// action.
+	errs := []error{
+		sut.Register(ctx, foo),
+		sut.Add(ctx, foo),
+		sut.Alter(ctx, foo),
+		sut.Remove(ctx, foo),
+		sut.Upsert(ctx, foo),
+		sut.Patch(ctx, work.TypeNameOf(foo), foo.ID, map[string]interface{}{"id": 1992}),
+	}
+
+	// assert.
+	for _, err := range errs {
+		s.ErrorIs(err, work.ErrUnitEvicted)
+	}
+	_, ok := sut.StateOf(foo)
+	s.False(ok)
+}
+
+func (s *UnitTestSuite) TestTransfer_PatchesAndCriteria() {
+
+	// arrange.
+	ctx := context.Background()
+	fooType := work.TypeNameOf(test.Foo{})
+	var patches []work.UnitPatch
+	var criteria []interface{}
+	opts := []work.UnitOption{
+		work.UnitPatchFunc(fooType, func(ctx context.Context, mCtx work.UnitMapperContext, p ...work.UnitPatch) error {
+			patches = append(patches, p...)
+			return nil
+		}),
+		work.UnitDeleteWhereFunc(fooType, func(ctx context.Context, mCtx work.UnitMapperContext, c ...interface{}) error {
+			criteria = append(criteria, c...)
+			return nil
+		}),
+	}
+	from, err := work.NewUnit(opts...)
+	s.Require().NoError(err)
+	to, err := work.NewUnit(opts...)
+	s.Require().NoError(err)
+	foo := test.Foo{ID: 28}
+	fields := map[string]interface{}{"id": 1992}
+	s.Require().NoError(from.Patch(ctx, fooType, foo.ID, fields))
+	s.Require().NoError(from.Patch(ctx, fooType, 1992, fields))
+	s.Require().NoError(from.RemoveWhere(ctx, fooType, "id > 2000"))
+
+	// action.
+	err = work.Transfer(ctx, from, to, foo)
+
+	// assert.
+	s.Require().NoError(err)
+	s.Require().NoError(to.Save(ctx))
+	s.Equal([]work.UnitPatch{{TypeName: fooType, ID: foo.ID, Fields: fields}}, patches)
+	s.Equal([]interface{}{"id > 2000"}, criteria)
+	patches, criteria = nil, nil
+	s.Require().NoError(from.Save(ctx))
+	s.Equal([]work.UnitPatch{{TypeName: fooType, ID: 1992, Fields: fields}}, patches)
+	s.Empty(criteria)
+}
+
+func (s *UnitTestSuite) TestTransfer_MissingDataMapper() {
+
+	// arrange.
+	ctx := context.Background()
+	fooType := work.TypeNameOf(test.Foo{})
+	noop := func(ctx context.Context, mCtx work.UnitMapperContext, entities ...interface{}) error {
+		return nil
+	}
+	from, err := work.NewUnit(
+		work.UnitInsertFunc(fooType, noop),
+		work.UnitUpdateFunc(fooType, noop),
+	)
+	s.Require().NoError(err)
+	to, err := work.NewUnit(work.UnitInsertFunc(fooType, noop))
+	s.Require().NoError(err)
+	foo := test.Foo{ID: 28}
+	s.Require().NoError(from.Register(ctx, foo))
+	s.Require().NoError(from.Alter(ctx, foo))
+
+	// action.
+	err = work.Transfer(ctx, from, to, foo)
+
+	// assert.
+	s.ErrorIs(err, work.ErrMissingDataMapper)
+	state, ok := from.StateOf(foo)
+	s.True(ok)
+	s.Equal(work.EntityStateAltered, state)
+	_, ok = to.StateOf(foo)
+	s.False(ok)
+}
+
+func (s *UnitTestSuite) TestTransfer_MergeAlterations() {
+
+	// arrange.
+	ctx := context.Background()
+	fooType := work.TypeNameOf(test.Foo{})
+	var updates [][]interface{}
+	update := func(ctx context.Context, mCtx work.UnitMapperContext, entities ...interface{}) error {
+		updates = append(updates, entities)
+		return nil
+	}
+	from, err := work.NewUnit(work.UnitUpdateFunc(fooType, update))
+	s.Require().NoError(err)
+	to, err := work.NewUnit(work.UnitUpdateFunc(fooType, update), work.UnitMergeAlterations())
+	s.Require().NoError(err)
+	foo := test.Foo{ID: 28}
+	s.Require().NoError(to.Register(ctx, foo))
+	s.Require().NoError(to.Alter(ctx, foo))
+	s.Require().NoError(from.Register(ctx, foo))
+	s.Require().NoError(from.Alter(ctx, foo))
+
+	// action.
+	err = work.Transfer(ctx, from, to, foo)
+
+	// assert.
+	s.Require().NoError(err)
+	s.Require().NoError(to.Save(ctx))
+	s.Equal([][]interface{}{{foo}}, updates)
+}
+
+func (s *UnitTestSuite) TestTransfer_Evicted() {
+
+	// arrange.
+	ctx := context.Background()
+	dm := make(map[work.TypeName]work.UnitDataMapper)
+	for t, m := range s.mappers {
+		dm[t] = m
+	}
+	from, err := work.NewUnit(work.UnitDataMappers(dm))
+	s.Require().NoError(err)
+	to, err := work.NewUnit(
+		work.UnitDataMappers(dm),
+		work.UnitStaleAfter(time.Millisecond, true),
+	)
+	s.Require().NoError(err)
+	foo := test.Foo{ID: 28}
+	s.Require().NoError(from.Add(ctx, foo))
+	s.Require().NoError(to.Add(ctx, test.Foo{ID: 1992}))
+	s.Require().Eventually(func() bool {
+		return to.Status() == work.UnitStatusDiscarded
+	}, time.Second, time.Millisecond)
+
+	// action.
+	err = work.Transfer(ctx, from, to, foo)
+
+	// assert.
+	s.ErrorIs(err, work.ErrUnitEvicted)
+	state, ok := from.StateOf(foo)
+	s.True(ok)
+	s.Equal(work.EntityStateAdded, state)
+	_, ok = to.StateOf(foo)
+	s.False(ok)
+}
+
+func (s *UnitTestSuite) TestUnit_DetectLeaks() {
+
+	// arrange.
+	ctx := context.Background()
+	dm := make(map[work.TypeName]work.UnitDataMapper)
+	for t, m := range s.mappers {
+		dm[t] = m
+	}
+	core, logs := observer.New(zap.ErrorLevel)
+	leak := func(entities ...interface{}) {
+		sut, err := work.NewUnit(
+			work.UnitDataMappers(dm),
+			work.UnitWithZapLogger(zap.New(core)),
+			work.UnitDetectLeaks(),
+		)
+		s.Require().NoError(err)
+		s.Require().NoError(sut.Add(ctx, entities...))
+	}
+
+	// action.
+	leak()
+	leak(test.Foo{ID: 28})
+
+	// assert.
+	msg := "work unit was garbage collected without saving its staged changes"
+	s.Require().Eventually(func() bool {
+		runtime.GC()
+		return logs.FilterMessage(msg).Len() > 0
+	}, time.Second, 10*time.Millisecond)
+	entry := logs.FilterMessage(msg).All()[0]
+	s.Contains(entry.ContextMap()["stack"], "TestUnit_DetectLeaks")
+	s.Equal(1, logs.FilterMessage(msg).Len())
+}
+
+func (s *UnitTestSuite) TestUnit_DetectLeaks_TrackedStale() {
+
+	// arrange.
+	ctx := context.Background()
+	dm := make(map[work.TypeName]work.UnitDataMapper)
+	for t, m := range s.mappers {
+		dm[t] = m
+	}
+	core, logs := observer.New(zap.ErrorLevel)
+	active := len(work.ActiveUnits())
+	leak := func() {
+		sut, err := work.NewUnit(
+			work.UnitDataMappers(dm),
+			work.UnitWithZapLogger(zap.New(core)),
+			work.UnitDetectLeaks(),
+			work.UnitTrackActive(),
+			work.UnitStaleAfter(time.Hour, false),
+		)
+		s.Require().NoError(err)
+		s.Require().NoError(sut.Add(ctx, test.Foo{ID: 28}))
+	}
+
+	// action.
+	leak()
+
+	// assert.
+	msg := "work unit was garbage collected without saving its staged changes"
+	s.Require().Eventually(func() bool {
+		runtime.GC()
+		return logs.FilterMessage(msg).Len() > 0
+	}, time.Second, 10*time.Millisecond)
+	s.Len(work.ActiveUnits(), active)
+}
+
+func (s *UnitTestSuite) TestUnit_SaveMiddleware() {
+
+	// arrange.
+	ctx := context.Background()
+	dm := make(map[work.TypeName]work.UnitDataMapper)
+	for t, m := range s.mappers {
+		dm[t] = m
+	}
+	var calls []string
+	middleware := func(name string) work.UnitSaveMiddlewareFunc {
+		return func(next work.UnitSaveFunc) work.UnitSaveFunc {
+			return func(ctx context.Context) error {
+				calls = append(calls, name+".before")
+				err := next(ctx)
+				calls = append(calls, name+".after")
+				return err
+			}
+		}
+	}
+	sut, err := work.NewUnit(
+		work.UnitDataMappers(dm),
+		work.UnitSaveMiddleware(middleware("outer")),
+		work.UnitSaveMiddleware(middleware("inner")),
+		work.UnitAfterSaveActions(func(work.UnitActionContext) { calls = append(calls, "save") }),
+	)
+	s.Require().NoError(err)
+	s.Require().NoError(sut.Add(ctx, test.Foo{ID: 28}))
+	s.mappers[work.TypeNameOf(test.Foo{})].EXPECT().Insert(ctx, gomock.Any(), test.Foo{ID: 28}).Return(nil)
+
+	// action.
+	err = sut.Save(ctx)
+
+	// assert.
+	s.Require().NoError(err)
+	s.Equal([]string{"outer.before", "inner.before", "save", "inner.after", "outer.after"}, calls)
+}
+
+func (s *UnitTestSuite) TestUnit_SaveMiddleware_ShortCircuit() {
+
+	// arrange.
+	ctx := context.Background()
+	dm := make(map[work.TypeName]work.UnitDataMapper)
+	for t, m := range s.mappers {
+		dm[t] = m
+	}
+	budgetErr := errors.New("fault budget exhausted")
+	sut, err := work.NewUnit(
+		work.UnitDataMappers(dm),
+		work.UnitSaveMiddleware(func(next work.UnitSaveFunc) work.UnitSaveFunc {
+			return func(ctx context.Context) error { return budgetErr }
+		}),
+	)
+	s.Require().NoError(err)
+	s.Require().NoError(sut.Add(ctx, test.Foo{ID: 28}))
+
+	// action.
+	err = sut.Save(ctx)
+
+	// assert.
+	s.ErrorIs(err, budgetErr)
+	s.Equal(work.UnitStatusPending, sut.Status())
+}
+
+// recordedSpan represents a span recorded by a recordingTracer.
+type recordedSpan struct {
+	name  string
+	attrs []any
+	err   error
+}
+
+// recordingTracer represents a tracer recording the spans it starts once
+// they are ended.
+type recordingTracer struct {
+	mutex sync.Mutex
+	spans []recordedSpan
+}
+
+func (t *recordingTracer) Start(ctx context.Context, name string, attrs ...any) (context.Context, work.UnitSpan) {
+	return ctx, &recordingSpan{tracer: t, span: recordedSpan{name: name, attrs: attrs}}
+}
+
+// recordingSpan represents a span started by a recordingTracer.
+type recordingSpan struct {
+	tracer *recordingTracer
+	span   recordedSpan
+}
+
+func (s *recordingSpan) End(err error) {
+	s.span.err = err
+	s.tracer.mutex.Lock()
+	defer s.tracer.mutex.Unlock()
+	s.tracer.spans = append(s.tracer.spans, s.span)
+}
+
+func (s *UnitTestSuite) TestUnit_WithTracer() {
+
+	// arrange.
+	ctx := context.Background()
+	foo, bar := test.Foo{ID: 28}, test.Bar{ID: "28"}
+	fooType, barType := work.TypeNameOf(foo), work.TypeNameOf(bar)
+	dm := make(map[work.TypeName]work.UnitDataMapper)
+	for t, m := range s.mappers {
+		dm[t] = m
+	}
+	tracer := &recordingTracer{}
+	sut, err := work.NewUnit(work.UnitDataMappers(dm), work.UnitWithTracer(tracer), work.UnitRetryAttempts(1))
+	s.Require().NoError(err)
+	s.Require().NoError(sut.Add(ctx, foo))
+	s.Require().NoError(sut.Alter(ctx, bar))
+	s.mappers[fooType].EXPECT().Insert(ctx, gomock.Any(), foo).Return(nil)
+	s.mappers[barType].EXPECT().Update(ctx, gomock.Any(), bar).Return(errors.New("whoa"))
+	s.mappers[fooType].EXPECT().Delete(ctx, gomock.Any(), foo).Return(nil)
+
+	// action.
+	err = sut.Save(ctx)
+
+	// assert.
+	s.Require().Error(err)
+	s.Require().Len(tracer.spans, 5)
+	s.Equal(recordedSpan{
+		name: "work.unit.mapper.insert",
+		attrs: []any{"unit_type", "best_effort", "type_name", fooType.String(),
+			"entity_count", 1, "attempt", 1, "compensating", false},
+	}, tracer.spans[0])
+	s.Equal("work.unit.mapper.update", tracer.spans[1].name)
+	s.EqualError(tracer.spans[1].err, "whoa")
+	s.Equal(recordedSpan{
+		name: "work.unit.mapper.delete",
+		attrs: []any{"unit_type", "best_effort", "type_name", fooType.String(),
+			"entity_count", 1, "attempt", 1, "compensating", true},
+	}, tracer.spans[2])
+	s.Equal(recordedSpan{name: "work.unit.rollback", attrs: []any{"unit_type", "best_effort"}}, tracer.spans[3])
+	s.Equal(work.UnitSpanSave, tracer.spans[4].name)
+	s.Equal([]any{"unit_type", "best_effort", "insert_count", 1, "update_count", 1, "delete_count", 0,
+		"upsert_count", 0, "patch_count", 0, "delete_where_count", 0}, tracer.spans[4].attrs)
+	s.Error(tracer.spans[4].err)
+}
+
+// recordingCollector represents a metrics collector recording the counters
+// incremented and the timers recorded, keyed by name.
+type recordingCollector struct {
+	mutex    sync.Mutex
+	counters map[string]int64
+	timers   map[string]int
+	tags     map[string]map[string]string
+}
+
+func (c *recordingCollector) Counter(name string, tags map[string]string, value int64) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.counters[name] += value
+	c.tags[name] = tags
+}
+
+func (c *recordingCollector) Timer(name string, tags map[string]string, d time.Duration) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.timers[name]++
+	c.tags[name] = tags
+}
+
+func (s *UnitTestSuite) TestUnit_WithMetricsCollector() {
+
+	// arrange.
+	ctx := context.Background()
+	foo := test.Foo{ID: 28}
+	fooType := work.TypeNameOf(foo)
+	dm := make(map[work.TypeName]work.UnitDataMapper)
+	for t, m := range s.mappers {
+		dm[t] = m
+	}
+	collector := &recordingCollector{
+		counters: make(map[string]int64),
+		timers:   make(map[string]int),
+		tags:     make(map[string]map[string]string),
+	}
+	scope := tally.NewTestScope("test", map[string]string{})
+	var actionMetrics work.UnitMetricsCollector
+	sut, err := work.NewUnit(
+		work.UnitDataMappers(dm),
+		work.UnitTallyMetricScope(scope),
+		work.UnitWithMetricsCollector(collector),
+		work.UnitMetricScope("uow"),
+		work.UnitAfterSaveActions(func(ctx work.UnitActionContext) {
+			actionMetrics = ctx.Metrics
+		}),
+	)
+	s.Require().NoError(err)
+	s.Require().NoError(sut.Add(ctx, foo))
+	s.mappers[fooType].EXPECT().Insert(ctx, gomock.Any(), foo).Return(nil)
+
+	// action.
+	err = sut.Save(ctx)
+
+	// assert.
+	s.Require().NoError(err)
+	s.EqualValues(1, collector.counters["uow.save.success"])
+	s.EqualValues(1, collector.counters["uow.insert"])
+	s.Equal(1, collector.timers["uow.save"])
+	s.Equal(map[string]string{"unit_type": "best_effort"}, collector.tags["uow.save.success"])
+	s.Empty(scope.Snapshot().Counters())
+	s.Require().NotNil(actionMetrics)
+	actionMetrics.Counter("custom", map[string]string{"tenant": "acme"}, 2)
+	s.EqualValues(2, collector.counters["uow.custom"])
+	s.Equal(map[string]string{"unit_type": "best_effort", "tenant": "acme"}, collector.tags["uow.custom"])
+}
+
+func (s *UnitTestSuite) TestUnit_Status() {
+
+	// arrange.
+	ctx := context.Background()
+	foo := test.Foo{ID: 28}
+	fooType := work.TypeNameOf(foo)
+	dm := make(map[work.TypeName]work.UnitDataMapper)
+	for t, m := range s.mappers {
+		dm[t] = m
+	}
+	sut, err := work.NewUnit(work.UnitDataMappers(dm), work.UnitRetryAttempts(1))
+	s.Require().NoError(err)
+	s.Equal(work.UnitStatusPending, sut.Status())
+	s.Require().NoError(sut.Add(ctx, foo))
+	gomock.InOrder(
+		s.mappers[fooType].EXPECT().Insert(ctx, gomock.Any(), foo).Return(errors.New("whoa")),
+		s.mappers[fooType].EXPECT().Insert(ctx, gomock.Any(), foo).
+			DoAndReturn(func(ctx context.Context, mCtx work.UnitMapperContext, e ...interface{}) error {
+				s.Equal(work.UnitStatusSaving, sut.Status())
+				return nil
+			}),
+	)
+
+	// action + assert.
+	s.Error(sut.Save(ctx))
+	s.Equal(work.UnitStatusFailed, sut.Status())
+	s.Require().NoError(sut.Save(ctx))
+	s.Equal(work.UnitStatusCompleted, sut.Status())
+	s.ErrorIs(sut.Save(ctx), work.ErrUnitCompleted)
+	s.ErrorIs(sut.Add(ctx, test.Foo{ID: 29}), work.ErrUnitCompleted)
+	s.ErrorIs(sut.Remove(ctx, foo), work.ErrUnitCompleted)
+	s.Equal(work.UnitStatusCompleted, sut.Status())
+	s.Equal("completed", sut.Status().String())
+}
+
+func (s *UnitTestSuite) TestUnit_ActionContext() {
+
+	// arrange.
+	type key struct{}
+	ctx := context.WithValue(context.Background(), key{}, "trace")
+	foo := test.Foo{ID: 28}
+	dm := make(map[work.TypeName]work.UnitDataMapper)
+	for t, m := range s.mappers {
+		dm[t] = m
+	}
+	var values []interface{}
+	record := func(aCtx work.UnitActionContext) {
+		values = append(values, aCtx.Context.Value(key{}))
+	}
+	var saved interface{}
+	sut, err := work.NewUnit(
+		work.UnitDataMappers(dm),
+		work.UnitAfterAddActions(record),
+		work.UnitBeforeSaveActions(record),
+		work.UnitSaveActions(func(aCtx work.UnitSaveActionContext) {
+			saved = aCtx.Context.Value(key{})
+		}),
+	)
+	s.Require().NoError(err)
+	s.mappers[work.TypeNameOf(foo)].EXPECT().Insert(ctx, gomock.Any(), foo).Return(nil)
+
+	// action.
+	s.Require().NoError(sut.Add(ctx, foo))
+	s.Require().NoError(sut.Save(ctx))
+
+	// assert.
+	s.Equal([]interface{}{"trace", "trace"}, values)
+	s.Equal("trace", saved)
+}
+
+func (s *UnitTestSuite) TestUnit_AllowTypes() {
+
+	// arrange.
+	ctx := context.Background()
+	foo, bar := test.Foo{ID: 28}, test.Bar{ID: "28"}
+	fooType, barType := work.TypeNameOf(foo), work.TypeNameOf(bar)
+	dm := make(map[work.TypeName]work.UnitDataMapper)
+	for t, m := range s.mappers {
+		dm[t] = m
+	}
+	tests := []struct {
+		name string
+		opt  work.UnitOption
+	}{
+		{name: "Allow", opt: work.UnitAllowTypes(fooType)},
+		{name: "Deny", opt: work.UnitDenyTypes(barType)},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			sut, err := work.NewUnit(work.UnitDataMappers(dm), tt.opt)
+			s.Require().NoError(err)
+
+			// action + assert.
+			s.NoError(sut.Add(ctx, foo))
+			err = sut.Add(ctx, bar)
+			var notAllowed *work.UnitTypeNotAllowedError
+			s.Require().ErrorAs(err, &notAllowed)
+			s.Equal(barType, notAllowed.TypeName)
+			s.ErrorIs(sut.Alter(ctx, bar), work.ErrTypeNotAllowed)
+			s.ErrorIs(sut.Register(ctx, bar), work.ErrTypeNotAllowed)
+			s.ErrorIs(sut.Patch(ctx, barType, "28", map[string]interface{}{}), work.ErrTypeNotAllowed)
+			_, ok := sut.StateOf(bar)
+			s.False(ok)
+		})
+	}
+}
+
+func (s *UnitTestSuite) TestUnit_MutationGuard() {
+
+	// arrange.
+	ctx := context.Background()
+	fooType, barType := work.TypeNameOf(test.Foo{}), work.TypeNameOf(test.Bar{})
+	dm := make(map[work.TypeName]work.UnitDataMapper)
+	for t, m := range s.mappers {
+		dm[t] = m
+	}
+	guard := work.UnitMutationGuard(map[work.TypeName]int{fooType: 2, barType: 5})
+
+	s.Run("Exceeded", func() {
+		scope := tally.NewTestScope("test", map[string]string{})
+		sut, err := work.NewUnit(work.UnitDataMappers(dm), work.UnitTallyMetricScope(scope), guard)
+		s.Require().NoError(err)
+		s.Require().NoError(sut.Add(ctx, test.Foo{ID: 1}, test.Bar{ID: "1"}))
+		s.Require().NoError(sut.Alter(ctx, test.Foo{ID: 2}))
+		s.Require().NoError(sut.Remove(ctx, test.Foo{ID: 3}))
+
+		// action.
+		err = sut.Save(ctx)
+
+		// assert.
+		var guardErr *work.UnitMutationGuardError
+		s.Require().ErrorAs(err, &guardErr)
+		s.ErrorIs(err, work.ErrMutationLimitExceeded)
+		s.Equal(work.UnitMutationGuardError{TypeName: fooType, Count: 3, Limit: 2}, *guardErr)
+		s.Contains(scope.Snapshot().Counters(), "test.unit.mutation.guard+unit_type=best_effort")
+	})
+
+	s.Run("WithinLimit", func() {
+		sut, err := work.NewUnit(work.UnitDataMappers(dm), guard)
+		s.Require().NoError(err)
+		s.Require().NoError(sut.Add(ctx, test.Foo{ID: 1}, test.Foo{ID: 2}))
+		s.mappers[fooType].EXPECT().Insert(ctx, gomock.Any(), test.Foo{ID: 1}, test.Foo{ID: 2}).Return(nil)
+
+		// action.
+		err = sut.Save(ctx)
+
+		// assert.
+		s.NoError(err)
+	})
+}
+
+func (s *UnitTestSuite) TestUnit_ConfirmMutations() {
+
+	// arrange.
+	ctx := context.Background()
+	fooType := work.TypeNameOf(test.Foo{})
+	dm := make(map[work.TypeName]work.UnitDataMapper)
+	for t, m := range s.mappers {
+		dm[t] = m
+	}
+	guard := work.UnitMutationGuard(map[work.TypeName]int{fooType: 1})
+	summary := []work.UnitMutationSummary{{TypeName: fooType, Count: 2, Limit: 1}}
+
+	s.Run("Confirmed", func() {
+		sut, err := work.NewUnit(work.UnitDataMappers(dm), guard, work.UnitConfirmMutations())
+		s.Require().NoError(err)
+		s.Require().NoError(sut.Add(ctx, test.Foo{ID: 1}, test.Foo{ID: 2}))
+		err = sut.Save(ctx)
+		var confirmErr *work.UnitConfirmationError
+		s.Require().ErrorAs(err, &confirmErr)
+		s.mappers[fooType].EXPECT().Insert(ctx, gomock.Any(), test.Foo{ID: 1}, test.Foo{ID: 2}).Return(nil)
+
+		// action.
+		err = sut.Save(ctx, work.Confirm(confirmErr.Token))
+
+		// assert.
+		s.NoError(err)
+		s.Equal(work.UnitStatusCompleted, sut.Status())
+	})
+
+	s.Run("Unconfirmed", func() {
+		sut, err := work.NewUnit(work.UnitDataMappers(dm), guard, work.UnitConfirmMutations())
+		s.Require().NoError(err)
+		s.Require().NoError(sut.Add(ctx, test.Foo{ID: 1}, test.Foo{ID: 2}))
+
+		// action.
+		err = sut.Save(ctx)
+
+		// assert.
+		var confirmErr *work.UnitConfirmationError
+		s.Require().ErrorAs(err, &confirmErr)
+		s.ErrorIs(err, work.ErrConfirmationRequired)
+		s.NotEmpty(confirmErr.Token)
+		s.Equal(summary, confirmErr.Summary)
+		s.NotEqual(work.UnitStatusFailed, sut.Status())
+	})
+
+	s.Run("InvalidToken", func() {
+		sut, err := work.NewUnit(work.UnitDataMappers(dm), guard, work.UnitConfirmMutations())
+		s.Require().NoError(err)
+		s.Require().NoError(sut.Add(ctx, test.Foo{ID: 1}, test.Foo{ID: 2}))
+		s.Require().ErrorIs(sut.Save(ctx), work.ErrConfirmationRequired)
+
+		// action.
+		err = sut.Save(ctx, work.Confirm("bogus"))
+
+		// assert.
+		s.ErrorIs(err, work.ErrConfirmationRequired)
+	})
+
+	s.Run("ChangedSinceToken", func() {
+		sut, err := work.NewUnit(work.UnitDataMappers(dm), guard, work.UnitConfirmMutations())
+		s.Require().NoError(err)
+		s.Require().NoError(sut.Add(ctx, test.Foo{ID: 1}, test.Foo{ID: 2}))
+		err = sut.Save(ctx)
+		var confirmErr *work.UnitConfirmationError
+		s.Require().ErrorAs(err, &confirmErr)
+		token := confirmErr.Token
+		s.Require().NoError(sut.Add(ctx, test.Foo{ID: 3}))
+
+		// action.
+		err = sut.Save(ctx, work.Confirm(token))
+
+		// assert.
+		s.Require().ErrorAs(err, &confirmErr)
+		s.NotEqual(token, confirmErr.Token)
+		s.Equal(3, confirmErr.Summary[0].Count)
+	})
+}
+
+func (s *UnitTestSuite) TestUnit_WithAggregateBoundaries() {
+
+	// arrange.
+	ctx := context.Background()
+	foo, bar := test.Foo{ID: 28}, test.Bar{ID: "28"}
+	fooType, barType := work.TypeNameOf(foo), work.TypeNameOf(bar)
+	dm := make(map[work.TypeName]work.UnitDataMapper)
+	for t, m := range s.mappers {
+		dm[t] = m
+	}
+	boundaries := work.UnitWithAggregateBoundaries(map[work.TypeName][]work.TypeName{fooType: {barType}})
+
+	s.Run("WithoutRoot", func() {
+		sut, err := work.NewUnit(work.UnitDataMappers(dm), boundaries)
+		s.Require().NoError(err)
+		s.Require().NoError(sut.Alter(ctx, bar))
+
+		// action.
+		err = sut.Save(ctx)
+
+		// assert.
+		var boundaryErr *work.UnitAggregateBoundaryError
+		s.Require().ErrorAs(err, &boundaryErr)
+		s.ErrorIs(err, work.ErrAggregateBoundary)
+		s.Equal(barType, boundaryErr.TypeName)
+		s.Equal([]work.TypeName{fooType}, boundaryErr.Roots)
+	})
+
+	s.Run("WithRegisteredRoot", func() {
+		sut, err := work.NewUnit(work.UnitDataMappers(dm), boundaries)
+		s.Require().NoError(err)
+		s.Require().NoError(sut.Register(ctx, foo))
+		s.Require().NoError(sut.Alter(ctx, bar))
+		s.mappers[barType].EXPECT().Update(ctx, gomock.Any(), bar).Return(nil)
+
+		// action.
+		err = sut.Save(ctx)
+
+		// assert.
+		s.NoError(err)
+	})
+
+	s.Run("WithStagedRoot", func() {
+		sut, err := work.NewUnit(work.UnitDataMappers(dm), boundaries)
+		s.Require().NoError(err)
+		s.Require().NoError(sut.Add(ctx, foo, bar))
+		s.mappers[fooType].EXPECT().Insert(ctx, gomock.Any(), foo).Return(nil)
+		s.mappers[barType].EXPECT().Insert(ctx, gomock.Any(), bar).Return(nil)
+
+		// action.
+		err = sut.Save(ctx)
+
+		// assert.
+		s.NoError(err)
+	})
+}
+
 func (s *UnitTestSuite) TestUnit_Add() {
 
 	// arrange.
-	entities := []interface{}{
-		test.Foo{ID: 28},
-		test.Bar{ID: "28"},
+	entities := []interface{}{
+		test.Foo{ID: 28},
+		test.Bar{ID: "28"},
+	}
+	ctx := context.Background()
+
+	// action.
+	err := s.sut.Add(ctx, entities...)
+
+	// assert.
+	s.NoError(err)
+}
+
+func (s *UnitTestSuite) TestUnit_AddBackground() {
+
+	// arrange.
+	foo := test.Foo{ID: 28}
+
+	// action.
+	err := s.sut.AddBackground(foo)
+
+	// assert.
+	s.NoError(err)
+	state, ok := s.sut.StateOf(foo)
+	s.True(ok)
+	s.Equal(work.EntityStateAdded, state)
+}
+
+func (s *UnitTestSuite) TestUnit_Add_HaltActionsOnFailure() {
+
+	// arrange.
+	ctx := context.Background()
+	foo := test.Foo{ID: 28}
+	t := work.TypeNameOf(foo)
+	executed := false
+	opts := []work.UnitOption{
+		work.UnitDataMappers(map[work.TypeName]work.UnitDataMapper{t: s.mappers[t]}),
+		work.UnitHaltActionsOnFailure(),
+		work.UnitActionsWithPriority(work.UnitActionTypeBeforeAdd, 10,
+			func(work.UnitActionContext) { panic("unauthorized") }),
+		work.UnitActionsWithPriority(work.UnitActionTypeBeforeAdd, 0,
+			func(work.UnitActionContext) { executed = true }),
+	}
+	var err error
+	s.sut, err = work.NewUnit(opts...)
+	s.Require().NoError(err)
+
+	// action.
+	err = s.sut.Add(ctx, foo)
+
+	// assert.
+	s.ErrorIs(err, work.ErrUnitActionFailed)
+	s.ErrorContains(err, "unauthorized")
+	s.False(executed)
+	_, ok := s.sut.StateOf(foo)
+	s.False(ok)
+}
+
+func (s *UnitTestSuite) TestUnit_ClearActions() {
+
+	// arrange.
+	ctx := context.Background()
+	foo := test.Foo{ID: 28}
+	t := work.TypeNameOf(foo)
+	executed := false
+	opts := []work.UnitOption{
+		work.UnitDataMappers(map[work.TypeName]work.UnitDataMapper{t: s.mappers[t]}),
+		work.UnitAfterAddActions(func(work.UnitActionContext) { executed = true }),
+	}
+	var err error
+	s.sut, err = work.NewUnit(opts...)
+	s.Require().NoError(err)
+
+	// action.
+	s.sut.ClearActions(work.UnitActionTypeAfterAdd)
+	err = s.sut.Add(ctx, foo)
+
+	// assert.
+	s.NoError(err)
+	s.False(executed)
+}
+
+func (s *UnitTestSuite) TestUnit_RemoveAction() {
+
+	// arrange.
+	ctx := context.Background()
+	foo := test.Foo{ID: 28}
+	t := work.TypeNameOf(foo)
+	var executed []string
+	action := func(name string) work.UnitAction {
+		return func(work.UnitActionContext) { executed = append(executed, name) }
+	}
+	opts := []work.UnitOption{
+		work.UnitDataMappers(map[work.TypeName]work.UnitDataMapper{t: s.mappers[t]}),
+		work.UnitNamedAction("audit", work.UnitActionTypeBeforeAdd, action("audit")),
+		work.UnitNamedAction("audit", work.UnitActionTypeAfterAdd, action("audit")),
+		work.UnitNamedAction("notify", work.UnitActionTypeAfterAdd, action("notify")),
+	}
+	var err error
+	s.sut, err = work.NewUnit(opts...)
+	s.Require().NoError(err)
+
+	// action.
+	removed := s.sut.RemoveAction("audit")
+	err = s.sut.Add(ctx, foo)
+
+	// assert.
+	s.True(removed)
+	s.False(s.sut.RemoveAction("missing"))
+	s.NoError(err)
+	s.Equal([]string{"notify"}, executed)
+}
+
+func (s *UnitTestSuite) TestUnit_ConditionalAction() {
+
+	// arrange.
+	ctx := context.Background()
+	foo, bar := test.Foo{ID: 28}, test.Bar{ID: "28"}
+	fooType, barType := work.TypeNameOf(foo), work.TypeNameOf(bar)
+	var executed int
+	predicate := func(ctx work.UnitActionContext) bool { return ctx.Modified(barType) }
+	opts := []work.UnitOption{
+		work.UnitDataMappers(map[work.TypeName]work.UnitDataMapper{
+			fooType: s.mappers[fooType],
+			barType: s.mappers[barType],
+		}),
+		work.UnitConditionalAction(work.UnitActionTypeAfterAdd, predicate,
+			func(work.UnitActionContext) { executed++ }),
+	}
+	var err error
+	s.sut, err = work.NewUnit(opts...)
+	s.Require().NoError(err)
+
+	// action.
+	errFoo := s.sut.Add(ctx, foo)
+	executedAfterFoo := executed
+	errBar := s.sut.Add(ctx, bar)
+
+	// assert.
+	s.NoError(errFoo)
+	s.NoError(errBar)
+	s.Zero(executedAfterFoo)
+	s.Equal(1, executed)
+}
+
+func (s *UnitTestSuite) TestUnit_ActionContextChanges() {
+
+	// arrange.
+	ctx := context.Background()
+	foo, bar := test.Foo{ID: 28}, test.Bar{ID: "28"}
+	fooType, barType := work.TypeNameOf(foo), work.TypeNameOf(bar)
+	var changes work.UnitChanges
+	opts := []work.UnitOption{
+		work.UnitDataMappers(map[work.TypeName]work.UnitDataMapper{
+			fooType: s.mappers[fooType],
+			barType: s.mappers[barType],
+		}),
+		work.UnitAfterRemoveActions(func(ctx work.UnitActionContext) {
+			changes = ctx.Changes()
+		}),
+	}
+	var err error
+	s.sut, err = work.NewUnit(opts...)
+	s.Require().NoError(err)
+	s.Require().NoError(s.sut.Add(ctx, foo))
+
+	// action.
+	err = s.sut.Remove(ctx, bar)
+
+	// assert.
+	s.NoError(err)
+	s.Equal(map[work.TypeName][]interface{}{fooType: {foo}}, changes.Additions)
+	s.Equal(map[work.TypeName][]interface{}{barType: {bar}}, changes.Removals)
+	s.Empty(changes.Alterations)
+	s.Empty(changes.Upserts)
+	s.Empty(changes.Patches)
+}
+
+type prefixCodec struct {
+	prefix string
+}
+
+func (c prefixCodec) ContentType() string { return "text/plain" }
+
+func (c prefixCodec) Encode(entity interface{}) ([]byte, error) {
+	return []byte(fmt.Sprintf("%s:%v", c.prefix, entity)), nil
+}
+
+func (s *UnitTestSuite) TestUnit_ActionContextPayloads() {
+
+	// arrange.
+	ctx := context.Background()
+	foo, bar := test.Foo{ID: 28}, test.Bar{ID: "28"}
+	fooType, barType := work.TypeNameOf(foo), work.TypeNameOf(bar)
+	var payloads work.UnitPayloads
+	var payload work.UnitPayload
+	var payloadsErr, payloadErr error
+	opts := []work.UnitOption{
+		work.UnitDataMappers(map[work.TypeName]work.UnitDataMapper{
+			fooType: s.mappers[fooType],
+			barType: s.mappers[barType],
+		}),
+		work.UnitCodecs(map[work.TypeName]work.UnitCodec{fooType: prefixCodec{"foo"}}),
+		work.UnitDefaultCodec(work.UnitJSONCodec),
+		work.UnitAfterRemoveActions(func(ctx work.UnitActionContext) {
+			payloads, payloadsErr = ctx.Payloads()
+			payload, payloadErr = ctx.Encode(foo)
+		}),
 	}
+	var err error
+	s.sut, err = work.NewUnit(opts...)
+	s.Require().NoError(err)
+	s.Require().NoError(s.sut.Add(ctx, foo))
+
+	// action.
+	err = s.sut.Remove(ctx, bar)
+
+	// assert.
+	s.NoError(err)
+	s.Require().NoError(payloadsErr)
+	s.Require().NoError(payloadErr)
+	fooPayload := work.UnitPayload{TypeName: fooType, ContentType: "text/plain", Data: []byte("foo:{28}")}
+	s.Equal(map[work.TypeName][]work.UnitPayload{fooType: {fooPayload}}, payloads.Additions)
+	s.Equal(map[work.TypeName][]work.UnitPayload{
+		barType: {{TypeName: barType, ContentType: "application/json", Data: []byte(`{"ID":"28"}`)}},
+	}, payloads.Removals)
+	s.Empty(payloads.Alterations)
+	s.Empty(payloads.Upserts)
+	s.Equal(fooPayload, payload)
+}
+
+func (s *UnitTestSuite) TestUnit_ActionContextPayloads_MissingCodec() {
+
+	// arrange.
 	ctx := context.Background()
+	foo, bar := test.Foo{ID: 28}, test.Bar{ID: "28"}
+	fooType, barType := work.TypeNameOf(foo), work.TypeNameOf(bar)
+	var payloadsErr, payloadErr error
+	opts := []work.UnitOption{
+		work.UnitDataMappers(map[work.TypeName]work.UnitDataMapper{
+			fooType: s.mappers[fooType],
+			barType: s.mappers[barType],
+		}),
+		work.UnitCodecs(map[work.TypeName]work.UnitCodec{fooType: work.UnitJSONCodec}),
+		work.UnitAfterAddActions(func(ctx work.UnitActionContext) {
+			_, payloadsErr = ctx.Payloads()
+			_, payloadErr = ctx.Encode(bar)
+		}),
+	}
+	var err error
+	s.sut, err = work.NewUnit(opts...)
+	s.Require().NoError(err)
 
 	// action.
-	err := s.sut.Add(ctx, entities...)
+	err = s.sut.Add(ctx, foo, bar)
 
 	// assert.
 	s.NoError(err)
+	s.ErrorIs(payloadsErr, work.ErrMissingCodec)
+	s.ErrorIs(payloadErr, work.ErrMissingCodec)
 }
 
 func (s *UnitTestSuite) TestUnit_ConcurrentAdd() {
@@ -399,6 +1806,131 @@ func (s *UnitTestSuite) TestUnit_ConcurrentRemove() {
 	s.NoError(err2)
 }
 
+func (s *UnitTestSuite) TestUnit_Upsert_MissingDataMapper() {
+
+	// arrange.
+	entities := []interface{}{
+		test.Foo{ID: 28},
+	}
+	ctx := context.Background()
+
+	// action.
+	err := s.sut.Upsert(ctx, entities...)
+
+	// assert.
+	s.ErrorIs(err, work.ErrMissingDataMapper)
+}
+
+func (s *UnitTestSuite) TestUnit_Upsert() {
+
+	// arrange.
+	entities := []interface{}{
+		test.Foo{ID: 28},
+		test.Bar{ID: "28"},
+	}
+	upsertFunc := func(ctx context.Context, mCtx work.UnitMapperContext, e ...interface{}) error {
+		return nil
+	}
+	opts := []work.UnitOption{
+		work.UnitUpsertFunc(work.TypeNameOf(test.Foo{}), upsertFunc),
+		work.UnitUpsertFunc(work.TypeNameOf(test.Bar{}), upsertFunc),
+	}
+	var err error
+	s.sut, err = work.NewUnit(opts...)
+	s.Require().NoError(err)
+	ctx := context.Background()
+
+	// action.
+	err = s.sut.Upsert(ctx, entities...)
+
+	// assert.
+	s.NoError(err)
+	state, ok := s.sut.StateOf(entities[0])
+	s.True(ok)
+	s.Equal(work.EntityStateUpserted, state)
+}
+
+func (s *UnitTestSuite) TestUnit_Patch_MissingDataMapper() {
+
+	// arrange.
+	ctx := context.Background()
+	fields := map[string]interface{}{"name": "foo"}
+
+	// action.
+	err := s.sut.Patch(ctx, work.TypeNameOf(test.Foo{}), 28, fields)
+
+	// assert.
+	s.ErrorIs(err, work.ErrMissingDataMapper)
+}
+
+func (s *UnitTestSuite) TestUnit_Patch_InvalidatesCache() {
+
+	// arrange.
+	ctx := context.Background()
+	foo := test.Foo{ID: 28}
+	t := work.TypeNameOf(foo)
+	patchFunc := func(ctx context.Context, mCtx work.UnitMapperContext, p ...work.UnitPatch) error {
+		return nil
+	}
+	opts := []work.UnitOption{
+		work.UnitDataMappers(map[work.TypeName]work.UnitDataMapper{t: s.mappers[t]}),
+		work.UnitPatchFunc(t, patchFunc),
+	}
+	var err error
+	s.sut, err = work.NewUnit(opts...)
+	s.Require().NoError(err)
+	s.Require().NoError(s.sut.Register(ctx, foo))
+
+	// action.
+	err = s.sut.Patch(ctx, t, foo.ID, map[string]interface{}{"name": "foo"})
+
+	// assert.
+	s.NoError(err)
+	cached, err := s.sut.Cached().Load(ctx, t, foo.ID)
+	s.NoError(err)
+	s.Nil(cached)
+}
+
+func (s *UnitTestSuite) TestUnit_RemoveWhere_MissingDataMapper() {
+
+	// arrange.
+	ctx := context.Background()
+
+	// action.
+	err := s.sut.RemoveWhere(ctx, work.TypeNameOf(test.Foo{}), "name = 'foo'")
+
+	// assert.
+	s.ErrorIs(err, work.ErrMissingDataMapper)
+}
+
+func (s *UnitTestSuite) TestUnit_RemoveWhere_InvalidatesCache() {
+
+	// arrange.
+	ctx := context.Background()
+	foo := test.Foo{ID: 28}
+	t := work.TypeNameOf(foo)
+	deleteWhereFunc := func(ctx context.Context, mCtx work.UnitMapperContext, c ...interface{}) error {
+		return nil
+	}
+	opts := []work.UnitOption{
+		work.UnitDataMappers(map[work.TypeName]work.UnitDataMapper{t: s.mappers[t]}),
+		work.UnitDeleteWhereFunc(t, deleteWhereFunc),
+	}
+	var err error
+	s.sut, err = work.NewUnit(opts...)
+	s.Require().NoError(err)
+	s.Require().NoError(s.sut.Register(ctx, foo))
+
+	// action.
+	err = s.sut.RemoveWhere(ctx, t, "name = 'foo'")
+
+	// assert.
+	s.NoError(err)
+	cached, err := s.sut.Cached().Load(ctx, t, foo.ID)
+	s.NoError(err)
+	s.Nil(cached)
+}
+
 func (s *UnitTestSuite) TestUnit_Register_Empty() {
 
 	// arrange.
@@ -435,6 +1967,97 @@ func (s *UnitTestSuite) TestUnit_Register_MissingDataMapper() {
 	s.EqualError(err, work.ErrMissingDataMapper.Error())
 }
 
+func (s *UnitTestSuite) TestUnit_RegisterBatch() {
+
+	// arrange.
+	ctx := context.Background()
+	entities := []interface{}{test.Foo{ID: 28}, test.Foo{ID: 29}}
+	t := work.TypeNameOf(test.Foo{})
+
+	// action.
+	err := s.sut.RegisterBatch(ctx, t, entities)
+
+	// assert.
+	s.NoError(err)
+	for _, entity := range entities {
+		state, ok := s.sut.StateOf(entity)
+		s.True(ok)
+		s.Equal(work.EntityStateRegistered, state)
+		cached, err := s.sut.Cached().Load(ctx, t, entity.(test.Foo).ID)
+		s.NoError(err)
+		s.Equal(entity, cached)
+	}
+}
+
+func (s *UnitTestSuite) TestUnit_RegisterBatch_Empty() {
+
+	// arrange.
+	ctx := context.Background()
+
+	// action.
+	err := s.sut.RegisterBatch(ctx, work.TypeNameOf(test.Biz{}), nil)
+
+	// assert.
+	s.NoError(err)
+}
+
+func (s *UnitTestSuite) TestUnit_RegisterBatch_MissingDataMapper() {
+
+	// arrange.
+	entities := []interface{}{test.Bar{ID: "28"}}
+	mappers := map[work.TypeName]work.UnitDataMapper{
+		work.TypeNameOf(test.Foo{}): &mock.UnitDataMapper{},
+	}
+	ctx := context.Background()
+	var err error
+	opts := []work.UnitOption{work.UnitDataMappers(mappers)}
+	s.sut, err = work.NewUnit(opts...)
+	s.Require().NoError(err)
+
+	// action.
+	err = s.sut.RegisterBatch(ctx, work.TypeNameOf(test.Bar{}), entities)
+
+	// assert.
+	s.ErrorIs(err, work.ErrMissingDataMapper)
+}
+
+func (s *UnitTestSuite) TestUnit_RegisterBatch_TypeMismatch() {
+
+	// arrange.
+	ctx := context.Background()
+	entities := []interface{}{test.Foo{ID: 28}, test.Biz{Identifier: "28"}}
+
+	// action.
+	err := s.sut.RegisterBatch(ctx, work.TypeNameOf(test.Foo{}), entities)
+
+	// assert.
+	var invalid *work.UnitInvalidEntityError
+	s.Require().ErrorAs(err, &invalid)
+	s.ErrorIs(err, work.ErrInvalidEntity)
+	s.Equal(test.Biz{Identifier: "28"}, invalid.Entity)
+	_, ok := s.sut.StateOf(test.Foo{ID: 28})
+	s.False(ok)
+}
+
+func (s *UnitTestSuite) TestUnit_RegisterBatch_Completed() {
+
+	// arrange.
+	ctx := context.Background()
+	foo := test.Foo{ID: 28}
+	s.Require().NoError(s.sut.Add(ctx, foo))
+	s.mappers[work.TypeNameOf(foo)].EXPECT().Insert(ctx, gomock.Any(), foo).Return(nil)
+	s.Require().NoError(s.sut.Save(ctx))
+
+	// action.
+	err := s.sut.RegisterBatch(ctx, work.TypeNameOf(foo), []interface{}{test.Foo{ID: 29}})
+
+	// assert.
+	s.ErrorIs(err, work.ErrUnitCompleted)
+	s.ErrorIs(s.sut.Register(ctx, test.Foo{ID: 29}), work.ErrUnitCompleted)
+	_, ok := s.sut.StateOf(test.Foo{ID: 29})
+	s.False(ok)
+}
+
 func (s *UnitTestSuite) TestUnit_Register() {
 
 	// arrange.
@@ -642,6 +2265,244 @@ func (s *UnitTestSuite) TestUnit_Remove_CacheInvalidationError() {
 	s.EqualError(err, cacheInvalidationError.Error())
 }
 
+func (s *UnitTestSuite) TestUnit_Alter_CacheFailurePolicy() {
+	// arrange.
+	ctx := context.Background()
+	foo := test.Foo{ID: 28}
+	tFoo := work.TypeNameOf(foo)
+	fooKey := fmt.Sprintf("%s-%v", string(tFoo), foo.ID)
+	cacheInvalidationError := errors.New("cache invalidation failed!")
+	tests := []struct {
+		name    string
+		mode    work.UnitCacheFailureMode
+		wantErr bool
+	}{
+		{name: "Ignore", mode: work.UnitCacheFailureModeIgnore},
+		{name: "Warn", mode: work.UnitCacheFailureModeWarn},
+		{name: "Fail", mode: work.UnitCacheFailureModeFail, wantErr: true},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			// initialize mocks.
+			mc := gomock.NewController(s.T())
+			cacheClient := mock.NewUnitCacheClient(mc)
+			cacheClient.EXPECT().Set(ctx, fooKey, foo).Return(nil)
+			cacheClient.EXPECT().Delete(ctx, fooKey).Return(cacheInvalidationError)
+			scope := tally.NewTestScope("test", map[string]string{})
+
+			// construct SUT.
+			dm := map[work.TypeName]work.UnitDataMapper{tFoo: mock.NewUnitDataMapper(mc)}
+			sut, err := work.NewUnit(
+				work.UnitDataMappers(dm),
+				work.UnitWithCacheClient(cacheClient),
+				work.UnitTallyMetricScope(scope),
+				work.UnitCacheFailurePolicy(tt.mode),
+			)
+			s.Require().NoError(err)
+			s.Require().NoError(sut.Register(ctx, foo))
+
+			// action.
+			err = sut.Alter(ctx, foo)
+
+			// assert.
+			if tt.wantErr {
+				s.EqualError(err, cacheInvalidationError.Error())
+			} else {
+				s.NoError(err)
+			}
+			state, ok := sut.StateOf(foo)
+			s.True(ok)
+			s.Equal(work.EntityStateAltered, state)
+			c, ok := scope.Snapshot().Counters()["test.unit.cache.error+operation=delete,unit_type=best_effort"]
+			s.Require().True(ok)
+			s.Equal(int64(1), c.Value())
+		})
+	}
+}
+
+func (s *UnitTestSuite) TestUnit_Register_CacheFailurePolicyFail() {
+	// arrange.
+	ctx := context.Background()
+	foo := test.Foo{ID: 28}
+	tFoo := work.TypeNameOf(foo)
+	cacheError := errors.New("cache set failed!")
+
+	// initialize mocks.
+	s.mc = gomock.NewController(s.T())
+	cacheClient := mock.NewUnitCacheClient(s.mc)
+	cacheClient.
+		EXPECT().
+		Set(ctx, fmt.Sprintf("%s-%v", string(tFoo), foo.ID), foo).
+		Return(cacheError)
+
+	// construct SUT.
+	dm := map[work.TypeName]work.UnitDataMapper{tFoo: mock.NewUnitDataMapper(s.mc)}
+	sut, err := work.NewUnit(
+		work.UnitDataMappers(dm),
+		work.UnitWithCacheClient(cacheClient),
+		work.UnitCacheFailurePolicy(work.UnitCacheFailureModeFail),
+	)
+	s.Require().NoError(err)
+
+	// action.
+	err = sut.Register(ctx, foo)
+
+	// assert.
+	s.EqualError(err, cacheError.Error())
+}
+
+func (s *UnitTestSuite) TestUnit_Alter_CacheInvalidationRetry() {
+	// arrange.
+	ctx := context.Background()
+	foo := test.Foo{ID: 28}
+	tFoo := work.TypeNameOf(foo)
+	fooKey := fmt.Sprintf("%s-%v", string(tFoo), foo.ID)
+	cacheInvalidationError := errors.New("cache invalidation failed!")
+	tests := []struct {
+		name      string
+		failures  int
+		retries   int64
+		abandoned bool
+	}{
+		{name: "Converges", failures: 2, retries: 2},
+		{name: "Abandoned", failures: 4, retries: 3, abandoned: true},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			// initialize mocks.
+			mc := gomock.NewController(s.T())
+			cacheClient := mock.NewUnitCacheClient(mc)
+			cacheClient.EXPECT().Set(ctx, fooKey, foo).Return(nil)
+			cacheClient.EXPECT().Delete(gomock.Any(), fooKey).Return(cacheInvalidationError).Times(tt.failures)
+			if !tt.abandoned {
+				cacheClient.EXPECT().Delete(gomock.Any(), fooKey).Return(nil)
+			}
+			scope := tally.NewTestScope("test", map[string]string{})
+
+			// construct SUT.
+			dm := map[work.TypeName]work.UnitDataMapper{tFoo: mock.NewUnitDataMapper(mc)}
+			sut, err := work.NewUnit(
+				work.UnitDataMappers(dm),
+				work.UnitWithCacheClient(cacheClient),
+				work.UnitTallyMetricScope(scope),
+				work.UnitCacheFailurePolicy(work.UnitCacheFailureModeWarn),
+				work.UnitCacheInvalidationRetry(3, time.Millisecond),
+			)
+			s.Require().NoError(err)
+			s.Require().NoError(sut.Register(ctx, foo))
+
+			// action.
+			err = sut.Alter(ctx, foo)
+
+			// assert.
+			s.Require().NoError(err)
+			retries := "test.unit.cache.invalidation.retry+unit_type=best_effort"
+			abandoned := "test.unit.cache.invalidation.failure+unit_type=best_effort"
+			if tt.abandoned {
+				s.Eventually(func() bool {
+					_, ok := scope.Snapshot().Counters()[abandoned]
+					return ok
+				}, time.Second, time.Millisecond)
+			} else {
+				s.Eventually(func() bool {
+					_, ok := scope.Snapshot().Counters()["test.unit.cache.delete+unit_type=best_effort"]
+					return ok
+				}, time.Second, time.Millisecond)
+				s.NotContains(scope.Snapshot().Counters(), abandoned)
+			}
+			s.Equal(tt.retries, scope.Snapshot().Counters()[retries].Value())
+		})
+	}
+}
+
+func (s *UnitTestSuite) TestUnit_Alter_CacheInvalidationQueueFull() {
+	// arrange.
+	ctx := context.Background()
+	foo, bar := test.Foo{ID: 28}, test.Foo{ID: 1992}
+	tFoo := work.TypeNameOf(foo)
+	cacheInvalidationError := errors.New("cache invalidation failed!")
+	mc := gomock.NewController(s.T())
+	cacheClient := mock.NewUnitCacheClient(mc)
+	cacheClient.EXPECT().Set(ctx, gomock.Any(), gomock.Any()).Return(nil).Times(2)
+	cacheClient.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(cacheInvalidationError).Times(3)
+	scope := tally.NewTestScope("test", map[string]string{})
+	dm := map[work.TypeName]work.UnitDataMapper{tFoo: mock.NewUnitDataMapper(mc)}
+	sut, err := work.NewUnit(
+		work.UnitDataMappers(dm),
+		work.UnitWithCacheClient(cacheClient),
+		work.UnitTallyMetricScope(scope),
+		work.UnitCacheFailurePolicy(work.UnitCacheFailureModeWarn),
+		work.UnitCacheInvalidationRetry(3, time.Hour),
+		work.UnitCacheInvalidationQueueSize(1),
+		work.UnitStaleAfter(50*time.Millisecond, true),
+	)
+	s.Require().NoError(err)
+	s.Require().NoError(sut.Register(ctx, foo, bar))
+	s.Require().NoError(sut.Alter(ctx, foo))
+
+	// action.
+	dedupedErr := sut.Alter(ctx, foo)
+	err = sut.Alter(ctx, bar)
+
+	// assert.
+	s.NoError(dedupedErr)
+	s.ErrorIs(err, work.ErrCacheInvalidationQueueFull)
+	abandoned := "test.unit.cache.invalidation.failure+unit_type=best_effort"
+	s.EqualValues(1, scope.Snapshot().Counters()[abandoned].Value())
+	// evicting the work unit abandons the queued invalidation.
+	s.Require().Eventually(func() bool {
+		return scope.Snapshot().Counters()[abandoned].Value() == 2
+	}, time.Second, time.Millisecond)
+	s.Equal(work.UnitStatusDiscarded, sut.Status())
+	s.NotContains(scope.Snapshot().Counters(), "test.unit.cache.invalidation.retry+unit_type=best_effort")
+}
+
+func (s *UnitTestSuite) TestUnit_CacheMultiClient() {
+	// arrange.
+	ctx := context.Background()
+	foo := test.Foo{ID: 28}
+	baz := test.Baz{Identifier: "28"}
+	tFoo := work.TypeNameOf(foo)
+	tBaz := work.TypeNameOf(baz)
+	fooKey := fmt.Sprintf("%s-%v", string(tFoo), foo.ID)
+	bazKey := fmt.Sprintf("%s-%v", string(tBaz), baz.Identifier)
+
+	// initialize mocks.
+	s.mc = gomock.NewController(s.T())
+	cacheClient := mock.NewUnitCacheMultiClient(s.mc)
+	cacheClient.
+		EXPECT().
+		SetMulti(ctx, map[string]interface{}{fooKey: foo, bazKey: baz}).
+		Return(nil)
+	cacheClient.
+		EXPECT().
+		DeleteMulti(ctx, []string{fooKey, bazKey}).
+		Return(nil)
+
+	s.mappers = make(map[work.TypeName]*mock.UnitDataMapper)
+	s.mappers[tFoo] = mock.NewUnitDataMapper(s.mc)
+	s.mappers[tBaz] = mock.NewUnitDataMapper(s.mc)
+
+	// construct SUT.
+	dm := make(map[work.TypeName]work.UnitDataMapper)
+	for t, m := range s.mappers {
+		dm[t] = m
+	}
+
+	var err error
+	opts := []work.UnitOption{work.UnitDataMappers(dm), work.UnitWithCacheClient(cacheClient)}
+	s.sut, err = work.NewUnit(opts...)
+	s.Require().NoError(err)
+
+	// action.
+	errRegister := s.sut.Register(ctx, foo, baz)
+	errRemove := s.sut.Remove(ctx, foo, baz)
+
+	// assert.
+	s.NoError(errRegister)
+	s.NoError(errRemove)
+}
+
 func (s *UnitTestSuite) TearDownTest() {
 	s.sut = nil
 }
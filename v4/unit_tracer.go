@@ -0,0 +1,124 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package work
+
+import "context"
+
+// The names of the spans started by work units.
+const (
+	// UnitSpanSave is the name of the span tracing each save.
+	UnitSpanSave = "work.unit.save"
+	// UnitSpanRollback is the name of the span tracing each rollback.
+	UnitSpanRollback = "work.unit.rollback"
+	// UnitSpanMapperPrefix prefixes the operation within the name of the
+	// span tracing each invocation of a data mapper function, such as
+	// "work.unit.mapper.insert".
+	UnitSpanMapperPrefix = "work.unit.mapper."
+)
+
+// UnitSpan represents a span tracing an operation performed by a work unit.
+type UnitSpan interface {
+	// End ends the span, recording the provided error, if any.
+	End(error)
+}
+
+// UnitTracer represents a type responsible for tracing the operations
+// performed by work units, such as to correlate the work performed against
+// the data store with incoming requests. Attributes are provided as
+// alternating keys and values, in the same manner as for UnitLogger.
+type UnitTracer interface {
+	// Start starts a span with the provided name and attributes as a child
+	// of the span within the provided context, if any, providing the context
+	// containing the span started.
+	Start(ctx context.Context, name string, attrs ...any) (context.Context, UnitSpan)
+}
+
+// unitType provides the type of the work unit, either "sql" or
+// "best_effort".
+func (u *unit) unitType() string {
+	if u.db != nil {
+		return sqlUnitTag["unit_type"]
+	}
+	return bestEffortUnitTag["unit_type"]
+}
+
+// startSpan starts a span with the provided name and attributes when the
+// work unit has a tracer, providing the context containing the span and the
+// function ending it.
+func (u *unit) startSpan(ctx context.Context, name string, attrs ...any) (context.Context, func(error)) {
+	if u.tracer == nil {
+		return ctx, func(error) {}
+	}
+	attrs = append([]any{"unit_type", u.unitType()}, attrs...)
+	ctx, span := u.tracer.Start(ctx, name, attrs...)
+	return ctx, span.End
+}
+
+// traceSave starts the span tracing a save of the work unit.
+func (u *unit) traceSave(ctx context.Context) (context.Context, func(error)) {
+	if u.tracer == nil {
+		return ctx, func(error) {}
+	}
+	u.mutex.RLock()
+	attrs := []any{
+		"insert_count", u.additionCount,
+		"update_count", u.alterationCount,
+		"delete_count", u.removalCount,
+		"upsert_count", u.upsertCount,
+		"patch_count", u.patchCount,
+		"delete_where_count", u.criteriaCount,
+	}
+	u.mutex.RUnlock()
+	return u.startSpan(ctx, UnitSpanSave, attrs...)
+}
+
+// traced provides the provided data mapper function performing the provided
+// operation for entities of the provided type, tracing each invocation when
+// the work unit has a tracer.
+func (u *unit) traced(operation UnitOperation, t TypeName, f UnitDataMapperFunc) UnitDataMapperFunc {
+	if u.tracer == nil {
+		return f
+	}
+	return func(ctx context.Context, mCtx UnitMapperContext, entities ...interface{}) (err error) {
+		ctx, end := u.startSpan(ctx, UnitSpanMapperPrefix+string(operation),
+			"type_name", t.String(),
+			"entity_count", len(entities),
+			"attempt", mCtx.Attempt(),
+			"compensating", mCtx.Compensating(),
+		)
+		defer func() { end(err) }()
+		return f(ctx, mCtx, entities...)
+	}
+}
+
+// tracedPatch provides the provided patch data mapper function for entities
+// of the provided type, tracing each invocation when the work unit has a
+// tracer.
+func (u *unit) tracedPatch(t TypeName, f UnitPatchDataMapperFunc) UnitPatchDataMapperFunc {
+	if u.tracer == nil {
+		return f
+	}
+	return func(ctx context.Context, mCtx UnitMapperContext, patches ...UnitPatch) (err error) {
+		ctx, end := u.startSpan(ctx, UnitSpanMapperPrefix+string(UnitOperationPatch),
+			"type_name", t.String(),
+			"entity_count", len(patches),
+			"attempt", mCtx.Attempt(),
+			"compensating", mCtx.Compensating(),
+		)
+		defer func() { end(err) }()
+		return f(ctx, mCtx, patches...)
+	}
+}
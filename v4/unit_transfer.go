@@ -0,0 +1,262 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package work
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync/atomic"
+)
+
+var (
+	// ErrTransferUnsupported represents the error that is returned when
+	// transferring entities between work units that were not created by this
+	// package, such as work units wrapped by another type.
+	ErrTransferUnsupported = errors.New("entities can only be transferred between work units created by this package")
+
+	// ErrTransferWhileSaving represents the error that is returned when
+	// transferring entities to or from a work unit that is being saved.
+	ErrTransferWhileSaving = errors.New("entities cannot be transferred to or from a work unit being saved")
+
+	// ErrEntityNotStaged represents the error that is returned when
+	// transferring an entity that is not staged within the source work unit.
+	ErrEntityNotStaged = errors.New("entity is not staged within the work unit")
+)
+
+// base provides the underlying work unit.
+func (u *unit) base() *unit {
+	return u
+}
+
+// take removes the entities sharing the same identity as the entity provided
+// from the provided entities by type, providing those removed.
+func take(entities map[TypeName][]interface{}, entity interface{}) []interface{} {
+	t := TypeNameOf(entity)
+	var taken, kept []interface{}
+	for _, e := range entities[t] {
+		if sameIdentity(e, entity) {
+			taken = append(taken, e)
+		} else {
+			kept = append(kept, e)
+		}
+	}
+	if len(taken) > 0 {
+		entities[t] = kept
+	}
+	return taken
+}
+
+// patchedBy determines if the provided patch applies to the entity provided.
+func patchedBy(p UnitPatch, entity interface{}) bool {
+	entityID, ok := id(entity)
+	return ok && cacheKey(p.TypeName, p.ID) == cacheKey(TypeNameOf(entity), entityID)
+}
+
+// takePatches removes the patches applying to the entity provided from the
+// provided patches by type, providing those removed.
+func takePatches(patches map[TypeName][]UnitPatch, entity interface{}) []UnitPatch {
+	t := TypeNameOf(entity)
+	var taken, kept []UnitPatch
+	for _, p := range patches[t] {
+		if patchedBy(p, entity) {
+			taken = append(taken, p)
+		} else {
+			kept = append(kept, p)
+		}
+	}
+	if len(taken) > 0 {
+		patches[t] = kept
+	}
+	return taken
+}
+
+// patched determines if any of the provided patches apply to the entity
+// provided.
+func patched(patches map[TypeName][]UnitPatch, entity interface{}) bool {
+	for _, p := range patches[TypeNameOf(entity)] {
+		if patchedBy(p, entity) {
+			return true
+		}
+	}
+	return false
+}
+
+// transferable determines if the provided work unit has the data mapper
+// functions needed to save the provided entity in each of the states it is
+// staged in within the work unit, along with the removal criteria staged for
+// its type. The locks of both work units must be held.
+func (u *unit) transferable(dst *unit, entity interface{}) bool {
+	t := TypeNameOf(entity)
+	return !(contains(u.additions, entity) && !dst.hasInsertFunc(t)) &&
+		!(contains(u.alterations, entity) && !dst.hasUpdateFunc(t)) &&
+		!(contains(u.removals, entity) && !dst.hasDeleteFunc(t)) &&
+		!(contains(u.upserts, entity) && !dst.hasUpsertFunc(t)) &&
+		!(patched(u.patches, entity) && !dst.hasPatchFunc(t)) &&
+		!(len(u.removalCriteria[t]) > 0 && !dst.hasDeleteWhereFunc(t))
+}
+
+// Transfer atomically moves the provided entities, along with each of the
+// states they are staged in, the patches staged for their identities, and
+// their cached registered state, from one work unit to another, such as to
+// consolidate many small work units into one that is saved in bulk. As the
+// entities they match are unknown, the removal criteria staged for the types
+// of the entities are moved along with them. Either all of the entities are
+// transferred, or none of them are.
+func Transfer(ctx context.Context, from, to Unit, entities ...interface{}) error {
+	type baser interface{ base() *unit }
+	f, fromOK := from.(baser)
+	t, toOK := to.(baser)
+	if !fromOK || !toOK {
+		return ErrTransferUnsupported
+	}
+	src, dst := f.base(), t.base()
+	if src == dst || len(entities) == 0 {
+		return nil
+	}
+	if err := dst.validate(entities); err != nil {
+		return err
+	}
+
+	unlock := lockPair(src, dst)
+	if atomic.LoadInt32(&src.saving) != 0 || atomic.LoadInt32(&dst.saving) != 0 {
+		unlock()
+		return ErrTransferWhileSaving
+	}
+	for _, u := range []*unit{src, dst} {
+		if err := u.terminalErr(); err != nil {
+			unlock()
+			return err
+		}
+	}
+	for _, entity := range entities {
+		typeName := TypeNameOf(entity)
+		if _, ok := src.stateOf(entity); !ok && !patched(src.patches, entity) {
+			unlock()
+			src.logger.Error(ErrEntityNotStaged.Error(), "typeName", typeName.String())
+			return ErrEntityNotStaged
+		}
+		if !src.transferable(dst, entity) {
+			unlock()
+			dst.logger.Error(ErrMissingDataMapper.Error(), "typeName", typeName.String())
+			return ErrMissingDataMapper
+		}
+	}
+	var registered []interface{}
+	for _, entity := range entities {
+		typeName := TypeNameOf(entity)
+		moved := take(src.additions, entity)
+		dst.additions[typeName] = append(dst.additions[typeName], moved...)
+		src.additionCount, dst.additionCount = src.additionCount-len(moved), dst.additionCount+len(moved)
+		moved = take(src.alterations, entity)
+		for _, e := range moved {
+			dst.stageAlteration(typeName, e)
+		}
+		src.alterationCount = src.alterationCount - len(moved)
+		moved = take(src.removals, entity)
+		dst.removals[typeName] = append(dst.removals[typeName], moved...)
+		src.removalCount, dst.removalCount = src.removalCount-len(moved), dst.removalCount+len(moved)
+		moved = take(src.upserts, entity)
+		dst.upserts[typeName] = append(dst.upserts[typeName], moved...)
+		src.upsertCount, dst.upsertCount = src.upsertCount-len(moved), dst.upsertCount+len(moved)
+		patches := takePatches(src.patches, entity)
+		dst.patches[typeName] = append(dst.patches[typeName], patches...)
+		src.patchCount, dst.patchCount = src.patchCount-len(patches), dst.patchCount+len(patches)
+		criteria := src.removalCriteria[typeName]
+		src.removalCriteria[typeName] = nil
+		dst.removalCriteria[typeName] = append(dst.removalCriteria[typeName], criteria...)
+		src.criteriaCount, dst.criteriaCount = src.criteriaCount-len(criteria), dst.criteriaCount+len(criteria)
+		moved = take(src.registered, entity)
+		dst.registered[typeName] = append(dst.registered[typeName], moved...)
+		src.registerCount, dst.registerCount = src.registerCount-len(moved), dst.registerCount+len(moved)
+		registered = append(registered, moved...)
+	}
+	unlock()
+
+	moveCached(ctx, src, dst, registered)
+	return nil
+}
+
+// lockPair acquires the locks of the provided work units in a consistent
+// order, preventing deadlocks between concurrent transfers in opposing
+// directions, and provides the function releasing them.
+func lockPair(a, b *unit) func() {
+	if reflect.ValueOf(a).Pointer() > reflect.ValueOf(b).Pointer() {
+		a, b = b, a
+	}
+	a.mutex.Lock()
+	b.mutex.Lock()
+	return func() {
+		b.mutex.Unlock()
+		a.mutex.Unlock()
+	}
+}
+
+// moveCached moves the cached state of the provided registered entities from
+// one work unit to another, removing it from the source cache first in case
+// both work units share the same cache.
+func moveCached(ctx context.Context, src, dst *unit, registered []interface{}) {
+	if len(registered) == 0 {
+		return
+	}
+	if err := src.cached.deleteAll(ctx, registered); err != nil {
+		src.logger.Warn(err.Error())
+	}
+	if err := dst.cached.storeAll(ctx, registered); err != nil {
+		dst.logger.Warn(err.Error())
+	}
+}
+
+// drainInto moves everything staged within the work unit into the provided
+// work unit, providing the registered entities that were moved. The locks of
+// both work units must be held.
+func (u *unit) drainInto(dst *unit) (registered []interface{}) {
+	move := func(src, dst map[TypeName][]interface{}) int {
+		n := 0
+		for t, entities := range src {
+			dst[t] = append(dst[t], entities...)
+			n = n + len(entities)
+		}
+		return n
+	}
+	for _, entities := range u.registered {
+		registered = append(registered, entities...)
+	}
+	dst.additionCount = dst.additionCount + move(u.additions, dst.additions)
+	for t, entities := range u.alterations {
+		for _, entity := range entities {
+			dst.stageAlteration(t, entity)
+		}
+	}
+	dst.removalCount = dst.removalCount + move(u.removals, dst.removals)
+	dst.upsertCount = dst.upsertCount + move(u.upserts, dst.upserts)
+	dst.criteriaCount = dst.criteriaCount + move(u.removalCriteria, dst.removalCriteria)
+	dst.registerCount = dst.registerCount + move(u.registered, dst.registered)
+	for t, patches := range u.patches {
+		dst.patches[t] = append(dst.patches[t], patches...)
+		dst.patchCount = dst.patchCount + len(patches)
+	}
+	u.additions = make(map[TypeName][]interface{})
+	u.alterations = make(map[TypeName][]interface{})
+	u.removals = make(map[TypeName][]interface{})
+	u.upserts = make(map[TypeName][]interface{})
+	u.removalCriteria = make(map[TypeName][]interface{})
+	u.registered = make(map[TypeName][]interface{})
+	u.patches = make(map[TypeName][]UnitPatch)
+	u.additionCount, u.alterationCount, u.removalCount, u.upsertCount = 0, 0, 0, 0
+	u.criteriaCount, u.registerCount, u.patchCount = 0, 0, 0
+	return
+}
@@ -0,0 +1,209 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package work
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+var (
+	// ErrTxLeaked represents the error that is returned when the transaction
+	// provided to data mappers is used after the save attempt it belongs to
+	// has completed, such as by a goroutine that captured it.
+	ErrTxLeaked = errors.New("transaction used after save attempt completed")
+
+	// ErrMissingTx represents the error that is returned when accessing the
+	// transaction of a mapper context that has none, such as for work units
+	// not created with the work.UnitDB option.
+	ErrMissingTx = errors.New("no transaction available")
+)
+
+// missingTxConnector represents a database connector whose connections fail
+// with ErrMissingTx.
+type missingTxConnector struct{}
+
+// Connect fails with ErrMissingTx.
+func (missingTxConnector) Connect(context.Context) (driver.Conn, error) {
+	return nil, ErrMissingTx
+}
+
+// Open fails with ErrMissingTx.
+func (missingTxConnector) Open(string) (driver.Conn, error) {
+	return nil, ErrMissingTx
+}
+
+// Driver provides the connector as its own driver.
+func (c missingTxConnector) Driver() driver.Driver {
+	return c
+}
+
+var (
+	missingTxOnce sync.Once
+	missingTxDB   *sql.DB
+)
+
+// missingTxRow provides the row reporting ErrMissingTx, since rows cannot be
+// constructed with an error directly.
+func missingTxRow(ctx context.Context) *sql.Row {
+	missingTxOnce.Do(func() { missingTxDB = sql.OpenDB(missingTxConnector{}) })
+	return missingTxDB.QueryRowContext(ctx, "")
+}
+
+// UnitTxLeakError represents the error that is returned when the transaction
+// provided to a data mapper is used after the save attempt it belongs to has
+// completed, identifying the offending data mapper.
+type UnitTxLeakError struct {
+	// TypeName is the type of the entities of the offending data mapper.
+	TypeName TypeName
+	// SaveID identifies the save the transaction belonged to.
+	SaveID string
+}
+
+// Error provides the error message.
+func (e *UnitTxLeakError) Error() string {
+	return fmt.Sprintf("%v: type %s, save %s", ErrTxLeaked, e.TypeName, e.SaveID)
+}
+
+// Unwrap provides ErrTxLeaked.
+func (e *UnitTxLeakError) Unwrap() error {
+	return ErrTxLeaked
+}
+
+// unitTxGuard detects usage of the transaction of a save attempt once the
+// attempt has completed.
+type unitTxGuard struct {
+	done   int32
+	report func(*UnitTxLeakError)
+}
+
+// close marks the save attempt as completed.
+func (g *unitTxGuard) close() {
+	atomic.StoreInt32(&g.done, 1)
+}
+
+// txGuard provides a guard for the transaction of a save attempt, reporting
+// usages once the attempt has completed.
+func (u *unit) txGuard() *unitTxGuard {
+	return &unitTxGuard{report: func(err *UnitTxLeakError) {
+		u.scope.counter(u.metrics.TxLeak, 1)
+		u.logger.Error(err.Error(), "typeName", err.TypeName.String(), "saveId", err.SaveID)
+	}}
+}
+
+// leaked determines if the transaction is used after the save attempt it
+// belongs to has completed, reporting the usage and providing the error
+// describing it when so.
+func (mCtx UnitMapperContext) leaked() error {
+	if mCtx.guard == nil || atomic.LoadInt32(&mCtx.guard.done) == 0 {
+		return nil
+	}
+	err := &UnitTxLeakError{TypeName: mCtx.typeName, SaveID: mCtx.SaveID}
+	mCtx.guard.report(err)
+	return err
+}
+
+// ExecContext executes the provided statement within the transaction of the
+// work unit, annotated with a comment identifying the work unit. Usages after
+// the save attempt has completed are reported and result in ErrTxLeaked, and
+// ErrMissingTx results without a transaction.
+func (mCtx UnitMapperContext) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	if mCtx.Tx == nil {
+		return nil, ErrMissingTx
+	}
+	if err := mCtx.leaked(); err != nil {
+		return nil, err
+	}
+	return mCtx.Tx.ExecContext(ctx, mCtx.Comment(query), args...)
+}
+
+// QueryContext executes the provided query within the transaction of the
+// work unit, annotated with a comment identifying the work unit. Usages after
+// the save attempt has completed are reported and result in ErrTxLeaked, and
+// ErrMissingTx results without a transaction.
+func (mCtx UnitMapperContext) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	if mCtx.Tx == nil {
+		return nil, ErrMissingTx
+	}
+	if err := mCtx.leaked(); err != nil {
+		return nil, err
+	}
+	return mCtx.Tx.QueryContext(ctx, mCtx.Comment(query), args...)
+}
+
+// QueryRowContext executes the provided query, which is expected to return
+// at most one row, within the transaction of the work unit, annotated with a
+// comment identifying the work unit. Usages after the save attempt has
+// completed are reported, and the row reports the transaction as done.
+// Without a transaction, the row reports ErrMissingTx.
+func (mCtx UnitMapperContext) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	if mCtx.Tx == nil {
+		return missingTxRow(ctx)
+	}
+	mCtx.leaked()
+	return mCtx.Tx.QueryRowContext(ctx, mCtx.Comment(query), args...)
+}
+
+// PrepareContext prepares the provided statement within the transaction of
+// the work unit, annotated with a comment identifying the work unit. Usages
+// after the save attempt has completed are reported and result in
+// ErrTxLeaked, and ErrMissingTx results without a transaction.
+func (mCtx UnitMapperContext) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	if mCtx.Tx == nil {
+		return nil, ErrMissingTx
+	}
+	if err := mCtx.leaked(); err != nil {
+		return nil, err
+	}
+	return mCtx.Tx.PrepareContext(ctx, mCtx.Comment(query))
+}
+
+// Raw provides the driver connection the transaction of the work unit is
+// open on to the provided function, allowing data mappers to leverage the
+// capabilities specific to the driver, such as the COPY protocol of pgx.
+// Statements issued through the driver connection participate in the
+// transaction. Usages after the save attempt has completed result in
+// ErrTxLeaked.
+func (mCtx UnitMapperContext) Raw(f func(driverConn interface{}) error) error {
+	if mCtx.conn == nil {
+		return ErrMissingTx
+	}
+	if err := mCtx.leaked(); err != nil {
+		return err
+	}
+	return mCtx.conn.Raw(f)
+}
+
+// WrapTx provides the value produced by the provided function from the
+// transaction of the provided mapper context, such as an ORM session bound to
+// it. Statements executed through the value are neither annotated nor guarded
+// against usage after the save attempt has completed, so prefer wrapping the
+// mapper context itself when the ORM accepts an interface. Wrapping after the
+// save attempt has completed results in ErrTxLeaked.
+func WrapTx[T any](mCtx UnitMapperContext, wrap func(*sql.Tx) T) (wrapped T, err error) {
+	if mCtx.Tx == nil {
+		return wrapped, ErrMissingTx
+	}
+	if err = mCtx.leaked(); err != nil {
+		return
+	}
+	return wrap(mCtx.Tx), nil
+}
@@ -0,0 +1,70 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package work
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrTypeNotAllowed represents the error that is returned when staging an
+// entity whose type is not allowed within the work unit.
+var ErrTypeNotAllowed = errors.New("entity type is not allowed within the work unit")
+
+// UnitTypeNotAllowedError represents the error that is returned when
+// staging an entity whose type is not allowed within the work unit,
+// describing the type.
+type UnitTypeNotAllowedError struct {
+	// TypeName is the type that is not allowed.
+	TypeName TypeName
+}
+
+// Error provides the error message.
+func (e *UnitTypeNotAllowedError) Error() string {
+	return fmt.Sprintf("%s: %s", ErrTypeNotAllowed, e.TypeName)
+}
+
+// Unwrap provides ErrTypeNotAllowed, so that the error can be identified
+// with errors.Is.
+func (e *UnitTypeNotAllowedError) Unwrap() error {
+	return ErrTypeNotAllowed
+}
+
+// typeSet provides the set of the provided types, adding them to the
+// provided set, if any.
+func typeSet(set map[TypeName]struct{}, types []TypeName) map[TypeName]struct{} {
+	if set == nil {
+		set = make(map[TypeName]struct{}, len(types))
+	}
+	for _, t := range types {
+		set[t] = struct{}{}
+	}
+	return set
+}
+
+// allowed determines if entities of the provided type can be staged within
+// the work unit, such that the type is allowed, when types are allowed
+// explicitly, and is not denied.
+func (u *unit) allowed(t TypeName) error {
+	_, allowed := u.allowedTypes[t]
+	_, denied := u.deniedTypes[t]
+	if (u.allowedTypes != nil && !allowed) || denied {
+		err := &UnitTypeNotAllowedError{TypeName: t}
+		u.logger.Error(err.Error(), "typeName", t.String())
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package work
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// UnitUniqueCheckFunc verifies that the provided entities, which are about
+// to be inserted, upserted, or updated, do not violate a uniqueness
+// constraint, returning an error describing the violation otherwise.
+type UnitUniqueCheckFunc func(context.Context, UnitMapperContext, ...interface{}) error
+
+// UnitUniquenessError represents the error that is returned when a
+// uniqueness check fails, before any of the changes within the work unit
+// are applied.
+type UnitUniquenessError struct {
+	// TypeName is the type of the entities that failed the check.
+	TypeName TypeName
+	// Err is the error returned by the check.
+	Err error
+}
+
+// Error provides the error message.
+func (e *UnitUniquenessError) Error() string {
+	return fmt.Sprintf("uniqueness check failed for %s: %v", e.TypeName, e.Err)
+}
+
+// Unwrap provides the error returned by the check.
+func (e *UnitUniquenessError) Unwrap() error {
+	return e.Err
+}
+
+// checkUniqueness executes the uniqueness checks for the entities within the
+// provided maps of entities by type, in order of type name.
+func (u *unit) checkUniqueness(ctx context.Context, mCtx UnitMapperContext, staged ...map[TypeName][]interface{}) error {
+	if len(u.uniqueChecks) == 0 {
+		return nil
+	}
+	typeNames := make([]TypeName, 0, len(u.uniqueChecks))
+	for t := range u.uniqueChecks {
+		typeNames = append(typeNames, t)
+	}
+	sort.Slice(typeNames, func(i, j int) bool { return typeNames[i] < typeNames[j] })
+	for _, t := range typeNames {
+		var entities []interface{}
+		for _, m := range staged {
+			entities = append(entities, m[t]...)
+		}
+		if len(entities) == 0 {
+			continue
+		}
+		for _, check := range u.uniqueChecks[t] {
+			if err := check(ctx, mCtx, entities...); err != nil {
+				return &UnitUniquenessError{TypeName: t, Err: err}
+			}
+		}
+	}
+	return nil
+}
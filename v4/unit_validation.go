@@ -0,0 +1,157 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package work
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// ErrInvalidEntity represents the error that is returned when an entity
+// cannot be staged within a work unit, such as nil entities or entities
+// whose identity cannot be determined.
+var ErrInvalidEntity = errors.New("invalid entity")
+
+// ErrUntrackedEntity represents the error that is returned when an entity
+// that has been neither registered nor added is altered or removed while
+// strict tracking is enabled.
+var ErrUntrackedEntity = errors.New("entity must be registered or added before it is altered or removed")
+
+// UnitInvalidEntityError represents the error that is returned when an
+// entity cannot be staged within a work unit, describing why.
+type UnitInvalidEntityError struct {
+	// Entity is the entity that could not be staged.
+	Entity interface{}
+	// Reason describes why the entity could not be staged.
+	Reason string
+}
+
+// Error provides the error message.
+func (e *UnitInvalidEntityError) Error() string {
+	return fmt.Sprintf("%s: %s", ErrInvalidEntity, e.Reason)
+}
+
+// Unwrap provides ErrInvalidEntity, so that the error can be identified
+// with errors.Is.
+func (e *UnitInvalidEntityError) Unwrap() error {
+	return ErrInvalidEntity
+}
+
+// validateEntity determines if the provided entity can be staged, such that
+// it is not nil, and its identity, if any, can be determined and compared.
+func validateEntity(entity interface{}) (err error) {
+	if entity == nil {
+		return &UnitInvalidEntityError{Entity: entity, Reason: "entity is nil"}
+	}
+	t := TypeNameOf(entity)
+	switch v := reflect.ValueOf(entity); v.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice, reflect.Func, reflect.Chan, reflect.Interface:
+		if v.IsNil() {
+			return &UnitInvalidEntityError{
+				Entity: entity, Reason: fmt.Sprintf("entity of type %s is nil", t)}
+		}
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = &UnitInvalidEntityError{
+				Entity: entity, Reason: fmt.Sprintf("identity of entity of type %s panicked: %v", t, r)}
+		}
+	}()
+	if entityID, ok := id(entity); ok && entityID != nil && !reflect.ValueOf(entityID).Comparable() {
+		return &UnitInvalidEntityError{
+			Entity: entity,
+			Reason: fmt.Sprintf("identity of type %T of entity of type %s is not comparable", entityID, t),
+		}
+	}
+	return nil
+}
+
+// validateNonZero determines if the provided entity, or the value it points
+// to, is not the zero value for its type.
+func validateNonZero(entity interface{}) error {
+	v := reflect.ValueOf(entity)
+	for v.Kind() == reflect.Ptr && !v.IsNil() {
+		v = v.Elem()
+	}
+	if v.IsZero() {
+		return &UnitInvalidEntityError{
+			Entity: entity, Reason: fmt.Sprintf("entity of type %s is the zero value", TypeNameOf(entity))}
+	}
+	return nil
+}
+
+// validate determines if the provided entities can be staged.
+func (u *unit) validate(entities []interface{}) error {
+	for _, entity := range entities {
+		err := validateEntity(entity)
+		if err == nil && u.rejectNilEntities {
+			err = validateNonZero(entity)
+		}
+		if err != nil {
+			u.logger.Error(err.Error(), "typeName", TypeNameOf(entity).String())
+			return err
+		}
+		if err = u.allowed(TypeNameOf(entity)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateType validates that the provided entities are of the provided type.
+func (u *unit) validateType(t TypeName, entities []interface{}) error {
+	for _, entity := range entities {
+		if actual := TypeNameOf(entity); actual != t {
+			err := &UnitInvalidEntityError{
+				Entity: entity, Reason: fmt.Sprintf("entity of type %s is not of type %s", actual, t)}
+			u.logger.Error(err.Error(), "typeName", t.String())
+			return err
+		}
+	}
+	return nil
+}
+
+// projected validates the provided entities, and provides them along with
+// the validated rows derived from them by the projection builders.
+func (u *unit) projected(entities []interface{}) ([]interface{}, error) {
+	if err := u.validate(entities); err != nil {
+		return nil, err
+	}
+	projected := u.project(entities)
+	if err := u.validate(projected[len(entities):]); err != nil {
+		return nil, err
+	}
+	return projected, nil
+}
+
+// checkTracked determines if the provided entities are tracked by the work
+// unit when strict tracking is enabled, such that they have been staged
+// previously.
+func (u *unit) checkTracked(entities []interface{}) error {
+	if !u.strictTracking {
+		return nil
+	}
+	u.mutex.RLock()
+	defer u.mutex.RUnlock()
+	for _, entity := range entities {
+		if _, ok := u.stateOf(entity); !ok {
+			u.logger.Error(ErrUntrackedEntity.Error(), "typeName", TypeNameOf(entity).String())
+			return ErrUntrackedEntity
+		}
+	}
+	return nil
+}
@@ -15,13 +15,31 @@
 
 package work
 
-//Uniter represents a factory for work units.
+import "context"
+
+// Uniter represents a factory for work units.
 type Uniter interface {
 
 	//Unit constructs a new work unit.
 	Unit() (Unit, error)
 }
 
+// VerifiableUniter represents a factory for work units capable of verifying
+// the configuration of the work units it constructs. The uniters provided by
+// NewUniter are verifiable, which callers determine with a type assertion:
+//
+//	if v, ok := uniter.(work.VerifiableUniter); ok {
+//		r := v.Verify(ctx)
+//		...
+//	}
+type VerifiableUniter interface {
+	Uniter
+
+	// Verify verifies the configuration of the work units the uniter
+	// constructs, such as at startup, reporting the outcome of each check.
+	Verify(context.Context) UniterReadiness
+}
+
 type uniter struct {
 	options []UnitOption
 }
@@ -35,3 +53,8 @@ func NewUniter(options ...UnitOption) Uniter {
 func (u uniter) Unit() (Unit, error) {
 	return NewUnit(u.options...)
 }
+
+// Verify verifies the configuration of the work units the uniter constructs.
+func (u uniter) Verify(ctx context.Context) UniterReadiness {
+	return VerifyUnitOptions(ctx, u.options...)
+}
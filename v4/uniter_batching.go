@@ -0,0 +1,251 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package work
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrBatchingUniterClosed represents the error that is returned when work
+	// units are submitted to a batching uniter that has been closed.
+	ErrBatchingUniterClosed = errors.New("batching uniter is closed")
+
+	// ErrInvalidFlushInterval represents the error that is returned when
+	// creating a batching uniter with a flush interval that is not positive.
+	ErrInvalidFlushInterval = errors.New("flush interval must be positive")
+)
+
+// unitBatch represents the work units coalesced into a single work unit.
+type unitBatch struct {
+	unit    Unit
+	futures []*UnitSaveFuture
+}
+
+// BatchingUniter represents a uniter that coalesces the changes of many
+// short-lived work units into consolidated work units, saving them
+// periodically in bulk rather than saving each work unit in its own
+// transaction. The options of the batching uniter are applied to both the
+// short-lived and consolidated work units, and the actions for saves are
+// executed for the consolidated work units. Repeated alterations of the same
+// identity are merged within each consolidated work unit.
+type BatchingUniter struct {
+	options  []UnitOption
+	maxBatch int
+
+	mutex   sync.Mutex
+	batch   *unitBatch
+	closed  bool
+	flushes sync.WaitGroup
+	saving  sync.Mutex
+	stop    chan struct{}
+	stopped chan struct{}
+}
+
+// NewBatchingUniter creates a new batching uniter with the provided unit
+// options, saving the work units submitted to it every flush interval, or
+// as soon as the provided maximum number of work units are submitted, where
+// zero indicates no maximum. ErrInvalidFlushInterval is returned when the
+// flush interval is not positive.
+func NewBatchingUniter(flushInterval time.Duration, maxBatch int, options ...UnitOption) (*BatchingUniter, error) {
+	if flushInterval <= 0 {
+		return nil, ErrInvalidFlushInterval
+	}
+	b := &BatchingUniter{
+		options:  options,
+		maxBatch: maxBatch,
+		stop:     make(chan struct{}),
+		stopped:  make(chan struct{}),
+	}
+	go b.run(flushInterval)
+	return b, nil
+}
+
+// run flushes the pending batch every flush interval until stopped.
+func (b *BatchingUniter) run(flushInterval time.Duration) {
+	defer close(b.stopped)
+	ticker := time.NewTicker(flushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			b.mutex.Lock()
+			batch := b.batch
+			b.batch = nil
+			b.mutex.Unlock()
+			b.flush(batch)
+		case <-b.stop:
+			return
+		}
+	}
+}
+
+// flush saves the provided batch, completing the futures of each work unit
+// within it. Batches are saved one at a time, in the order they are flushed.
+func (b *BatchingUniter) flush(batch *unitBatch) {
+	if batch == nil {
+		return
+	}
+	// batches are saved by the goroutines of the batching uniter rather than
+	// the goroutine that created them.
+	defer sanctionGoroutine()()
+	b.saving.Lock()
+	defer b.saving.Unlock()
+	err := batch.unit.Save(context.Background())
+	for _, f := range batch.futures {
+		f.complete(err)
+	}
+}
+
+// Unit constructs a new work unit, whose changes are saved as part of a
+// consolidated work unit once it is saved. Saving the work unit blocks until
+// the consolidated work unit is saved, or until the provided context is done.
+func (b *BatchingUniter) Unit() (Unit, error) {
+	u, err := NewUnit(b.options...)
+	if err != nil {
+		return nil, err
+	}
+	return &batchedUnit{Unit: u, uniter: b}, nil
+}
+
+// Verify verifies the configuration of the work units the batching uniter
+// constructs.
+func (b *BatchingUniter) Verify(ctx context.Context) UniterReadiness {
+	return VerifyUnitOptions(ctx, b.options...)
+}
+
+// Submit moves the changes staged within the provided work unit into the
+// pending batch, providing the future for the outcome of saving the batch.
+// The provided work unit must have been created by this package.
+func (b *BatchingUniter) Submit(ctx context.Context, u Unit) (*UnitSaveFuture, error) {
+	if bu, ok := u.(*batchedUnit); ok {
+		u = bu.Unit
+	}
+	src, ok := u.(interface{ base() *unit })
+	if !ok {
+		return nil, ErrTransferUnsupported
+	}
+	b.mutex.Lock()
+	if b.closed {
+		b.mutex.Unlock()
+		return nil, ErrBatchingUniterClosed
+	}
+	if b.batch == nil {
+		// alterations of the same identity across work units are merged, so
+		// that only a single update is issued for each of them.
+		options := append(append([]UnitOption{}, b.options...), UnitMergeAlterations())
+		batched, err := NewUnit(options...)
+		if err != nil {
+			b.mutex.Unlock()
+			return nil, err
+		}
+		b.batch = &unitBatch{unit: batched}
+	}
+	batch := b.batch
+	dst := batch.unit.(interface{ base() *unit }).base()
+	unlock := lockPair(src.base(), dst)
+	if err := src.base().terminalErr(); err != nil {
+		unlock()
+		// batches are only pending once a work unit is submitted to them.
+		if len(batch.futures) == 0 {
+			b.batch = nil
+		}
+		b.mutex.Unlock()
+		return nil, err
+	}
+	registered := src.base().drainInto(dst)
+	unlock()
+	// the changes of the work unit are now tracked by the pending batch.
+	src.base().untrack()
+	f := newUnitSaveFuture()
+	batch.futures = append(batch.futures, f)
+	full := b.maxBatch > 0 && len(batch.futures) >= b.maxBatch
+	if full {
+		b.batch = nil
+		b.flushes.Add(1)
+	}
+	b.mutex.Unlock()
+
+	moveCached(ctx, src.base(), dst, registered)
+	if full {
+		go func() {
+			defer b.flushes.Done()
+			b.flush(batch)
+		}()
+	}
+	return f, nil
+}
+
+// Close stops the periodic flushing of the batching uniter and saves the
+// pending batch, providing the error encountered when saving it. Work units
+// can no longer be submitted once the batching uniter is closed.
+func (b *BatchingUniter) Close() error {
+	b.mutex.Lock()
+	if b.closed {
+		b.mutex.Unlock()
+		return nil
+	}
+	b.closed = true
+	batch := b.batch
+	b.batch = nil
+	b.mutex.Unlock()
+
+	close(b.stop)
+	<-b.stopped
+	b.flushes.Wait()
+	if batch == nil {
+		return nil
+	}
+	b.flush(batch)
+	return batch.futures[0].Err()
+}
+
+// batchedUnit represents a work unit whose changes are saved as part of a
+// consolidated work unit.
+type batchedUnit struct {
+	Unit
+
+	uniter *BatchingUniter
+}
+
+// Save submits the changes within the work unit to the batching uniter,
+// blocking until they are saved or the provided context is done. Save
+// options are not applied, as the changes are saved alongside those of
+// other work units.
+func (u *batchedUnit) Save(ctx context.Context, _ ...UnitSaveOption) error {
+	f, err := u.uniter.Submit(ctx, u.Unit)
+	if err != nil {
+		return err
+	}
+	return f.Wait(ctx)
+}
+
+// SaveBackground submits the changes within the work unit to the batching
+// uniter, blocking until they are saved.
+func (u *batchedUnit) SaveBackground() error {
+	return u.Save(context.Background())
+}
+
+// SaveAsync submits the changes within the work unit to the batching uniter
+// in a new goroutine, providing the future for the outcome of saving them.
+func (u *batchedUnit) SaveAsync(ctx context.Context) *UnitSaveFuture {
+	return NewUnitSaveFuture(ctx, func(ctx context.Context) error {
+		return u.Save(ctx)
+	})
+}
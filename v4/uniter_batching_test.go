@@ -0,0 +1,228 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package work_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/freerware/work/v4"
+	"github.com/freerware/work/v4/internal/test"
+	"github.com/stretchr/testify/suite"
+)
+
+type BatchingUniterTestSuite struct {
+	suite.Suite
+
+	// system under test.
+	sut *work.BatchingUniter
+
+	// state.
+	mutex   sync.Mutex
+	inserts [][]interface{}
+	updates [][]interface{}
+}
+
+func TestBatchingUniterTestSuite(t *testing.T) {
+	suite.Run(t, new(BatchingUniterTestSuite))
+}
+
+func (s *BatchingUniterTestSuite) SetupTest() {
+	s.inserts = nil
+	s.updates = nil
+}
+
+// options provides the unit options recording each invocation of the insert
+// data mapper function.
+func (s *BatchingUniterTestSuite) options() []work.UnitOption {
+	insert := func(ctx context.Context, mCtx work.UnitMapperContext, entities ...interface{}) error {
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+		s.inserts = append(s.inserts, entities)
+		return nil
+	}
+	update := func(ctx context.Context, mCtx work.UnitMapperContext, entities ...interface{}) error {
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+		s.updates = append(s.updates, entities)
+		return nil
+	}
+	noop := func(ctx context.Context, mCtx work.UnitMapperContext, entities ...interface{}) error {
+		return nil
+	}
+	fooType := work.TypeNameOf(test.Foo{})
+	return []work.UnitOption{
+		work.UnitInsertFunc(fooType, insert),
+		work.UnitUpdateFunc(fooType, update),
+		work.UnitDeleteFunc(fooType, noop),
+	}
+}
+
+func (s *BatchingUniterTestSuite) TestBatchingUniter_MaxBatch() {
+	// arrange.
+	ctx := context.Background()
+	sut, err := work.NewBatchingUniter(time.Hour, 2, s.options()...)
+	s.Require().NoError(err)
+	s.sut = sut
+	errs := make(chan error, 2)
+
+	// action.
+	for _, foo := range []test.Foo{{ID: 28}, {ID: 1992}} {
+		u, err := s.sut.Unit()
+		s.Require().NoError(err)
+		s.Require().NoError(u.Add(ctx, foo))
+		go func() { errs <- u.Save(ctx) }()
+	}
+
+	// assert.
+	s.NoError(<-errs)
+	s.NoError(<-errs)
+	s.Require().Len(s.inserts, 1)
+	s.ElementsMatch([]interface{}{test.Foo{ID: 28}, test.Foo{ID: 1992}}, s.inserts[0])
+}
+
+func (s *BatchingUniterTestSuite) TestBatchingUniter_FlushInterval() {
+	// arrange.
+	ctx := context.Background()
+	sut, err := work.NewBatchingUniter(10*time.Millisecond, 0, s.options()...)
+	s.Require().NoError(err)
+	s.sut = sut
+	u, err := s.sut.Unit()
+	s.Require().NoError(err)
+	s.Require().NoError(u.Add(ctx, test.Foo{ID: 28}))
+
+	// action.
+	err = u.Save(ctx)
+
+	// assert.
+	s.Require().NoError(err)
+	s.Equal([][]interface{}{{test.Foo{ID: 28}}}, s.inserts)
+}
+
+func (s *BatchingUniterTestSuite) TestBatchingUniter_Close() {
+	// arrange.
+	ctx := context.Background()
+	sut, err := work.NewBatchingUniter(time.Hour, 0, s.options()...)
+	s.Require().NoError(err)
+	s.sut = sut
+	u, err := s.sut.Unit()
+	s.Require().NoError(err)
+	s.Require().NoError(u.Add(ctx, test.Foo{ID: 28}))
+	f, err := s.sut.Submit(ctx, u)
+	s.Require().NoError(err)
+
+	// action.
+	err = s.sut.Close()
+
+	// assert.
+	s.Require().NoError(err)
+	s.NoError(f.Wait(ctx))
+	s.Equal([][]interface{}{{test.Foo{ID: 28}}}, s.inserts)
+	_, err = s.sut.Submit(ctx, u)
+	s.ErrorIs(err, work.ErrBatchingUniterClosed)
+}
+
+func (s *BatchingUniterTestSuite) TestBatchingUniter_MergeAlterations() {
+	// arrange.
+	ctx := context.Background()
+	sut, err := work.NewBatchingUniter(time.Hour, 0, s.options()...)
+	s.Require().NoError(err)
+	s.sut = sut
+	var futures []*work.UnitSaveFuture
+	for _, foo := range []test.Foo{{ID: 28}, {ID: 28}, {ID: 1992}} {
+		u, err := s.sut.Unit()
+		s.Require().NoError(err)
+		s.Require().NoError(u.Alter(ctx, foo))
+		f, err := s.sut.Submit(ctx, u)
+		s.Require().NoError(err)
+		futures = append(futures, f)
+	}
+
+	// action.
+	err = s.sut.Close()
+
+	// assert.
+	s.Require().NoError(err)
+	for _, f := range futures {
+		s.NoError(f.Wait(ctx))
+	}
+	s.Require().Len(s.updates, 1)
+	s.Len(s.updates[0], 2)
+}
+
+func (s *BatchingUniterTestSuite) TestBatchingUniter_InvalidFlushInterval() {
+	// action.
+	sut, err := work.NewBatchingUniter(0, 0, s.options()...)
+
+	// assert.
+	s.ErrorIs(err, work.ErrInvalidFlushInterval)
+	s.Nil(sut)
+}
+
+func (s *BatchingUniterTestSuite) TestBatchingUniter_Submit_Untracks() {
+	// arrange.
+	ctx := context.Background()
+	active := len(work.ActiveUnits())
+	sut, err := work.NewBatchingUniter(time.Hour, 0, append(s.options(), work.UnitTrackActive())...)
+	s.Require().NoError(err)
+	s.sut = sut
+	u, err := s.sut.Unit()
+	s.Require().NoError(err)
+	s.Require().NoError(u.Add(ctx, test.Foo{ID: 28}))
+
+	// action.
+	f, err := s.sut.Submit(ctx, u)
+
+	// assert.
+	s.Require().NoError(err)
+	// only the pending batch remains tracked.
+	s.Len(work.ActiveUnits(), active+1)
+	s.Require().NoError(s.sut.Close())
+	s.NoError(f.Wait(ctx))
+	s.Len(work.ActiveUnits(), active)
+}
+
+func (s *BatchingUniterTestSuite) TestBatchingUniter_Submit_Evicted() {
+	// arrange.
+	ctx := context.Background()
+	sut, err := work.NewBatchingUniter(time.Hour, 0, s.options()...)
+	s.Require().NoError(err)
+	s.sut = sut
+	u, err := work.NewUnit(append(s.options(), work.UnitStaleAfter(time.Millisecond, true))...)
+	s.Require().NoError(err)
+	s.Require().NoError(u.Add(ctx, test.Foo{ID: 28}))
+	s.Require().Eventually(func() bool {
+		return u.Status() == work.UnitStatusDiscarded
+	}, time.Second, time.Millisecond)
+
+	// action.
+	f, err := s.sut.Submit(ctx, u)
+
+	// assert.
+	s.ErrorIs(err, work.ErrUnitEvicted)
+	s.Nil(f)
+	s.NoError(s.sut.Close())
+	s.Empty(s.inserts)
+}
+
+func (s *BatchingUniterTestSuite) TearDownTest() {
+	if s.sut != nil {
+		s.sut.Close()
+	}
+	s.sut = nil
+}
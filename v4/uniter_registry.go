@@ -0,0 +1,124 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package work
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+var (
+	// ErrUniterNotRegistered represents the error that is returned when no
+	// uniter is registered with the requested name.
+	ErrUniterNotRegistered = errors.New("no uniter registered with name")
+
+	// ErrUniterAlreadyRegistered represents the error that is returned when a
+	// uniter is already registered with the provided name.
+	ErrUniterAlreadyRegistered = errors.New("uniter already registered with name")
+
+	// DefaultRegistry is the registry leveraged by Register and Get.
+	DefaultRegistry = NewRegistry()
+)
+
+// Registry represents a collection of named uniters, such as those
+// configured differently for each module of an application.
+type Registry struct {
+	mutex   sync.RWMutex
+	uniters map[string]Uniter
+}
+
+// NewRegistry creates a new registry without any uniters.
+func NewRegistry() *Registry {
+	return &Registry{uniters: make(map[string]Uniter)}
+}
+
+// Register registers the provided uniter with the provided name.
+func (r *Registry) Register(name string, uniter Uniter) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if _, ok := r.uniters[name]; ok {
+		return fmt.Errorf("%w: %s", ErrUniterAlreadyRegistered, name)
+	}
+	r.uniters[name] = uniter
+	return nil
+}
+
+// Get retrieves the uniter registered with the provided name.
+func (r *Registry) Get(name string) (Uniter, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	uniter, ok := r.uniters[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUniterNotRegistered, name)
+	}
+	return uniter, nil
+}
+
+// Names provides the names of the registered uniters in sorted order.
+func (r *Registry) Names() []string {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	names := make([]string, 0, len(r.uniters))
+	for name := range r.uniters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Register registers the provided uniter with the provided name within the
+// default registry.
+func Register(name string, uniter Uniter) error {
+	return DefaultRegistry.Register(name, uniter)
+}
+
+// Get retrieves the uniter registered with the provided name within the
+// default registry.
+func Get(name string) (Uniter, error) {
+	return DefaultRegistry.Get(name)
+}
+
+// NamedUniter represents a uniter along with the name it is registered
+// with.
+type NamedUniter struct {
+	Name   string
+	Uniter Uniter
+}
+
+// NewRegistryOf creates a new registry containing the provided uniters. It
+// is suitable as a provider for dependency injection containers such as
+// go.uber.org/fx or github.com/google/wire.
+func NewRegistryOf(uniters ...NamedUniter) (*Registry, error) {
+	r := NewRegistry()
+	for _, u := range uniters {
+		if err := r.Register(u.Name, u.Uniter); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+// UniterProvider provides a constructor retrieving the uniter registered
+// with the provided name from a registry, suitable as a provider for
+// dependency injection containers such as go.uber.org/fx or
+// github.com/google/wire, where it is typically annotated with the name.
+func UniterProvider(name string) func(*Registry) (Uniter, error) {
+	return func(r *Registry) (Uniter, error) {
+		return r.Get(name)
+	}
+}
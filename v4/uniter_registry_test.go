@@ -0,0 +1,109 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package work_test
+
+import (
+	"testing"
+
+	"github.com/freerware/work/v4"
+	"github.com/stretchr/testify/suite"
+)
+
+// namedUniter represents a uniter distinguishable by its name.
+type namedUniter string
+
+func (u namedUniter) Unit() (work.Unit, error) {
+	return nil, nil
+}
+
+type RegistryTestSuite struct {
+	suite.Suite
+
+	// system under test.
+	sut *work.Registry
+}
+
+func TestRegistryTestSuite(t *testing.T) {
+	suite.Run(t, new(RegistryTestSuite))
+}
+
+func (s *RegistryTestSuite) SetupTest() {
+	s.sut = work.NewRegistry()
+}
+
+func (s *RegistryTestSuite) TestRegistry_Get() {
+	// arrange.
+	orders := namedUniter("orders")
+	s.Require().NoError(s.sut.Register("orders", orders))
+
+	// action.
+	uniter, err := s.sut.Get("orders")
+
+	// assert.
+	s.Require().NoError(err)
+	s.Equal(orders, uniter)
+	s.Equal([]string{"orders"}, s.sut.Names())
+}
+
+func (s *RegistryTestSuite) TestRegistry_GetNotRegistered() {
+	// action.
+	_, err := s.sut.Get("orders")
+
+	// assert.
+	s.ErrorIs(err, work.ErrUniterNotRegistered)
+}
+
+func (s *RegistryTestSuite) TestRegistry_RegisterAlreadyRegistered() {
+	// arrange.
+	s.Require().NoError(s.sut.Register("orders", work.NewUniter()))
+
+	// action.
+	err := s.sut.Register("orders", work.NewUniter())
+
+	// assert.
+	s.ErrorIs(err, work.ErrUniterAlreadyRegistered)
+}
+
+func (s *RegistryTestSuite) TestNewRegistryOf() {
+	// arrange.
+	orders, billing := namedUniter("orders"), namedUniter("billing")
+
+	// action.
+	r, err := work.NewRegistryOf(
+		work.NamedUniter{Name: "orders", Uniter: orders},
+		work.NamedUniter{Name: "billing", Uniter: billing})
+
+	// assert.
+	s.Require().NoError(err)
+	s.Equal([]string{"billing", "orders"}, r.Names())
+	uniter, err := work.UniterProvider("billing")(r)
+	s.Require().NoError(err)
+	s.Equal(billing, uniter)
+}
+
+func (s *RegistryTestSuite) TestNewRegistryOf_Duplicate() {
+	// action.
+	_, err := work.NewRegistryOf(
+		work.NamedUniter{Name: "orders", Uniter: work.NewUniter()},
+		work.NamedUniter{Name: "orders", Uniter: work.NewUniter()})
+
+	// assert.
+	s.ErrorIs(err, work.ErrUniterAlreadyRegistered)
+}
+
+func (s *RegistryTestSuite) TearDownTest() {
+	s.sut = nil
+}
@@ -16,7 +16,10 @@
 package work_test
 
 import (
+	"context"
 	"database/sql"
+	"errors"
+	"regexp"
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
@@ -93,6 +96,96 @@ func (s *UniterTestSuite) TestUniter() {
 	}
 }
 
+func (s *UniterTestSuite) TestUniter_Verify() {
+	// arrange.
+	ctx := context.Background()
+	fooType, barType := work.TypeNameOf(test.Foo{}), work.TypeNameOf(test.Bar{})
+	insert := func(context.Context, work.UnitMapperContext, ...interface{}) error { return nil }
+
+	s.Run("Ready", func() {
+		s._db.ExpectPrepare(regexp.QuoteMeta("SELECT id FROM foo"))
+		dm := make(map[work.TypeName]work.UnitDataMapper)
+		for t, m := range s.mappers {
+			dm[t] = m
+		}
+		sut := work.NewUniter(work.UnitDataMappers(dm), work.UnitDB(s.db), work.UnitVerifyStatements("SELECT id FROM foo"))
+
+		// action.
+		r := sut.(work.VerifiableUniter).Verify(ctx)
+
+		// assert.
+		s.True(r.Ready())
+		s.NoError(r.Err())
+		names := make([]string, 0, len(r.Checks))
+		for _, c := range r.Checks {
+			names = append(names, c.Name)
+		}
+		s.Equal([]string{
+			"options",
+			"mappers:" + barType.String(),
+			"mappers:" + fooType.String(),
+			"db",
+			"statement:SELECT id FROM foo",
+		}, names)
+		s.NoError(s._db.ExpectationsWereMet())
+	})
+
+	s.Run("MissingMappers", func() {
+		sut := work.NewUniter(
+			work.UnitInsertFunc(fooType, insert),
+			work.UnitAllowTypes(fooType, barType))
+
+		// action.
+		r := sut.(work.VerifiableUniter).Verify(ctx)
+
+		// assert.
+		s.False(r.Ready())
+		s.ErrorIs(r.Err(), work.ErrMissingDataMapper)
+		var missing *work.UnitMissingMappersError
+		s.Require().ErrorAs(r.Checks[1].Err, &missing)
+		s.Equal(barType, missing.TypeName)
+		s.Equal([]work.UnitOperation{
+			work.UnitOperationInsert, work.UnitOperationUpdate, work.UnitOperationDelete,
+		}, missing.Operations)
+		s.Require().ErrorAs(r.Checks[2].Err, &missing)
+		s.Equal([]work.UnitOperation{work.UnitOperationUpdate, work.UnitOperationDelete}, missing.Operations)
+	})
+
+	s.Run("AppendOnly", func() {
+		sut := work.NewUniter(work.UnitInsertFunc(fooType, insert), work.UnitAppendOnly())
+
+		// action.
+		r := sut.(work.VerifiableUniter).Verify(ctx)
+
+		// assert.
+		s.True(r.Ready())
+	})
+
+	s.Run("StatementError", func() {
+		s._db.ExpectPrepare("SELECT missing FROM foo").WillReturnError(errors.New("whoa"))
+		sut := work.NewUniter(
+			work.UnitInsertFunc(fooType, insert),
+			work.UnitAppendOnly(),
+			work.UnitDB(s.db),
+			work.UnitVerifyStatements("SELECT missing FROM foo"))
+
+		// action.
+		r := sut.(work.VerifiableUniter).Verify(ctx)
+
+		// assert.
+		s.False(r.Ready())
+		s.EqualError(r.Err(), "whoa")
+	})
+
+	s.Run("NoDataMappers", func() {
+		// action.
+		r := work.NewUniter().(work.VerifiableUniter).Verify(ctx)
+
+		// assert.
+		s.ErrorIs(r.Err(), work.ErrNoDataMapper)
+	})
+}
+
 func (s *UniterTestSuite) TearDownTest() {
 	s.sut = nil
 	s.mappers = nil
@@ -0,0 +1,200 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package work
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"go.uber.org/multierr"
+)
+
+// UnitMissingMappersError represents the error that is returned when
+// verifying a uniter whose work units lack the data mapper functions
+// required for a type.
+type UnitMissingMappersError struct {
+	// TypeName is the type lacking data mapper functions.
+	TypeName TypeName
+	// Operations are the operations lacking data mapper functions.
+	Operations []UnitOperation
+}
+
+// Error provides the error message.
+func (e *UnitMissingMappersError) Error() string {
+	return fmt.Sprintf("%v: type %s, operations %v", ErrMissingDataMapper, e.TypeName, e.Operations)
+}
+
+// Unwrap provides ErrMissingDataMapper.
+func (e *UnitMissingMappersError) Unwrap() error {
+	return ErrMissingDataMapper
+}
+
+// UniterCheck represents the outcome of a single check performed when
+// verifying a uniter.
+type UniterCheck struct {
+	// Name identifies the check, such as "options", "mappers:<type>", "db",
+	// or "statement:<statement>".
+	Name string
+	// Err is the error encountered by the check, if any.
+	Err error
+	// Duration is the time taken to perform the check.
+	Duration time.Duration
+}
+
+// UniterReadiness represents the outcome of verifying the configuration of
+// the work units constructed by a uniter, such as at startup so that
+// misconfiguration is caught before the first save.
+type UniterReadiness struct {
+	// Checks are the outcomes of each check performed, in the order they
+	// were performed.
+	Checks []UniterCheck
+}
+
+// Ready indicates if every check passed.
+func (r UniterReadiness) Ready() bool {
+	return r.Err() == nil
+}
+
+// Err provides the errors encountered by the checks that failed, if any.
+func (r UniterReadiness) Err() (err error) {
+	for _, c := range r.Checks {
+		err = multierr.Append(err, c.Err)
+	}
+	return
+}
+
+// check performs the provided check with the provided name, recording its
+// outcome.
+func (r *UniterReadiness) check(name string, f func() error) {
+	start := time.Now()
+	err := f()
+	r.Checks = append(r.Checks, UniterCheck{Name: name, Err: err, Duration: time.Since(start)})
+}
+
+// referencedTypes provides the types that have data mapper functions or are
+// referenced by the options, in sorted order.
+func (uo *UnitOptions) referencedTypes() []TypeName {
+	referenced := make(map[TypeName]struct{})
+	for _, types := range [][]TypeName{
+		mapperTypes(uo.insertFuncs),
+		mapperTypes(uo.updateFuncs),
+		mapperTypes(uo.deleteFuncs),
+		mapperTypes(uo.upsertFuncs),
+		patchMapperTypes(uo.patchFuncs),
+		mapperTypes(uo.deleteWhereFuncs),
+		mapperTypes(uo.refreshFuncs),
+	} {
+		for _, t := range types {
+			referenced[t] = struct{}{}
+		}
+	}
+	for t := range uo.allowedTypes {
+		referenced[t] = struct{}{}
+	}
+	for t := range uo.mutationLimits {
+		referenced[t] = struct{}{}
+	}
+	for root, members := range uo.aggregateRoots {
+		referenced[root] = struct{}{}
+		for _, t := range members {
+			referenced[t] = struct{}{}
+		}
+	}
+	types := make([]TypeName, 0, len(referenced))
+	for t := range referenced {
+		types = append(types, t)
+	}
+	sort.Slice(types, func(i, j int) bool { return types[i] < types[j] })
+	return types
+}
+
+// missingOperations provides the operations lacking the data mapper
+// functions required for the provided type. Inserts are always required,
+// whereas updates and deletes are not for append-only work units or
+// event-sourced types.
+func (uo *UnitOptions) missingOperations(t TypeName) (missing []UnitOperation) {
+	if _, ok := uo.bulkTypes[t]; ok {
+		return
+	}
+	if _, ok := uo.insertFuncs[t]; !ok {
+		missing = append(missing, UnitOperationInsert)
+	}
+	if uo.appendOnly {
+		return
+	}
+	if uo.events != nil {
+		if _, ok := uo.events.types[t]; ok {
+			return
+		}
+	}
+	if _, ok := uo.updateFuncs[t]; !ok {
+		missing = append(missing, UnitOperationUpdate)
+	}
+	if _, ok := uo.deleteFuncs[t]; !ok {
+		missing = append(missing, UnitOperationDelete)
+	}
+	return
+}
+
+// Verify verifies the configuration described by the options, checking that
+// work units can be constructed, that every type has the data mapper
+// functions it requires, that the database is reachable, and that the
+// statements provided with the work.UnitVerifyStatements option can be
+// prepared.
+func (uo *UnitOptions) Verify(ctx context.Context) UniterReadiness {
+	var r UniterReadiness
+	r.check("options", func() error {
+		if !uo.hasDataMapperFuncs() && !uo.readOnly {
+			return ErrNoDataMapper
+		}
+		return nil
+	})
+	for _, t := range uo.referencedTypes() {
+		t := t
+		r.check("mappers:"+t.String(), func() error {
+			if missing := uo.missingOperations(t); len(missing) > 0 {
+				return &UnitMissingMappersError{TypeName: t, Operations: missing}
+			}
+			return nil
+		})
+	}
+	if uo.db == nil {
+		return r
+	}
+	r.check("db", func() error {
+		return uo.db.PingContext(ctx)
+	})
+	for _, statement := range uo.verifyStatements {
+		statement := statement
+		r.check("statement:"+statement, func() error {
+			stmt, err := uo.db.PrepareContext(ctx, statement)
+			if err != nil {
+				return err
+			}
+			return stmt.Close()
+		})
+	}
+	return r
+}
+
+// VerifyUnitOptions verifies the configuration of work units created with
+// the provided options, including defaults.
+func VerifyUnitOptions(ctx context.Context, opts ...UnitOption) UniterReadiness {
+	o := options(opts)
+	return o.Verify(ctx)
+}
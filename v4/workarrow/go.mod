@@ -0,0 +1,30 @@
+module github.com/freerware/work/v4/workarrow
+
+go 1.25.0
+
+replace github.com/freerware/work/v4 => ../
+
+require (
+	github.com/apache/arrow-go/v18 v18.8.0
+	github.com/freerware/work/v4 v4.0.0
+	github.com/stretchr/testify v1.12.1
+)
+
+require (
+	github.com/avast/retry-go/v4 v4.6.0 // indirect
+	github.com/goccy/go-json v0.10.6 // indirect
+	github.com/golang/mock v1.6.0 // indirect
+	github.com/google/flatbuffers v25.12.19+incompatible // indirect
+	github.com/google/uuid v1.6.0 // indirect
+	github.com/klauspost/cpuid/v2 v2.4.0 // indirect
+	github.com/sirupsen/logrus v1.9.3 // indirect
+	github.com/twmb/murmur3 v1.1.6 // indirect
+	github.com/uber-go/tally/v4 v4.1.16 // indirect
+	github.com/zeebo/xxh3 v1.1.0 // indirect
+	go.uber.org/atomic v1.10.0 // indirect
+	go.uber.org/multierr v1.11.0 // indirect
+	go.uber.org/zap v1.27.0 // indirect
+	go.yaml.in/yaml/v3 v3.0.5 // indirect
+	golang.org/x/exp v0.0.0-20260112195511-716be5621a96 // indirect
+	golang.org/x/sys v0.47.0 // indirect
+)
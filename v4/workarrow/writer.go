@@ -0,0 +1,221 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package workarrow stages the entities of very large work units into
+// columnar Apache Arrow record batches, so that analytical stores such as
+// ClickHouse and BigQuery can ingest them with efficient bulk writes.
+//
+// A ColumnarMapper describes the Arrow schema of an entity type and appends
+// entities to a record builder. A Writer groups the entities staged within a
+// work unit by type and operation into record batches, which are written
+// with a single call to the Sink for each batch:
+//
+//	w := workarrow.NewWriter(sink, map[work.TypeName]workarrow.ColumnarMapper{
+//		work.TypeNameOf(Event{}): eventMapper{},
+//	}, workarrow.BatchSize(65536))
+//	u, err := work.NewUnit(w.Options()...)
+package workarrow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/freerware/work/v4"
+)
+
+// ErrPatchUnsupported represents the error that is returned for patches,
+// which cannot be represented as rows of a record batch.
+var ErrPatchUnsupported = errors.New("patches cannot be written as record batches")
+
+// ColumnarMapper maps the entities of a particular type into the rows of
+// Arrow record batches.
+type ColumnarMapper interface {
+	// Schema provides the schema of the record batches.
+	Schema() *arrow.Schema
+	// Append appends the provided entity as a row of the record batch being
+	// built with the provided builder.
+	Append(*array.RecordBuilder, interface{}) error
+}
+
+// Sink writes record batches to an analytical store.
+type Sink interface {
+	// Write writes the provided record batch, whose rows are the entities
+	// with the provided type name the provided operation is applied for.
+	// Sinks that only support appending rows are expected to reject
+	// operations other than work.UnitOperationInsert.
+	Write(context.Context, work.UnitOperation, work.TypeName, arrow.RecordBatch) error
+}
+
+// Options represents the configuration options for the writer.
+type Options struct {
+	batchSize int
+	allocator memory.Allocator
+}
+
+// Option applies an option to the provided configuration.
+type Option func(*Options)
+
+var (
+	// BatchSize defines the maximum number of rows within each record
+	// batch, which defaults to 10000.
+	BatchSize = func(size int) Option {
+		if size < 1 {
+			size = 1
+		}
+		return func(o *Options) {
+			o.batchSize = size
+		}
+	}
+
+	// Allocator defines the allocator the record batches are built with,
+	// which defaults to the Go allocator.
+	Allocator = func(allocator memory.Allocator) Option {
+		return func(o *Options) {
+			o.allocator = allocator
+		}
+	}
+)
+
+// Writer writes the entities staged within work units as record batches.
+type Writer struct {
+	sink    Sink
+	mappers map[work.TypeName]ColumnarMapper
+	options Options
+}
+
+// NewWriter constructs a writer that writes record batches with the provided
+// sink for the entity types with the provided mappers.
+func NewWriter(sink Sink, mappers map[work.TypeName]ColumnarMapper, opts ...Option) *Writer {
+	o := Options{batchSize: 10000, allocator: memory.NewGoAllocator()}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return &Writer{sink: sink, mappers: mappers, options: o}
+}
+
+// Options provides the options for work units that write the entities of
+// the types with mappers as record batches. Bulk writes are only performed
+// by work units without a database, since SQL work units apply all changes
+// within their transaction.
+func (w *Writer) Options() []work.UnitOption {
+	types := make([]work.TypeName, 0, len(w.mappers))
+	for t := range w.mappers {
+		types = append(types, t)
+	}
+	return []work.UnitOption{work.UnitBulkWriter(w.Write, types...)}
+}
+
+// Records builds the record batches for the provided entities with the
+// provided mapper, each containing at most the batch size of rows. The
+// record batches must be released once they are no longer needed.
+func (w *Writer) Records(mapper ColumnarMapper, entities []interface{}) (records []arrow.RecordBatch, err error) {
+	b := array.NewRecordBuilder(w.options.allocator, mapper.Schema())
+	defer b.Release()
+	defer func() {
+		if err != nil {
+			for _, r := range records {
+				r.Release()
+			}
+			records = nil
+		}
+	}()
+	rows := 0
+	for _, entity := range entities {
+		if err = mapper.Append(b, entity); err != nil {
+			return
+		}
+		if rows = rows + 1; rows == w.options.batchSize {
+			records = append(records, b.NewRecordBatch())
+			rows = 0
+		}
+	}
+	if rows > 0 {
+		records = append(records, b.NewRecordBatch())
+	}
+	return
+}
+
+// batch represents the operations for entities of the same type sharing the
+// same operation, in the order they were provided.
+type batch struct {
+	operation work.UnitOperation
+	typeName  work.TypeName
+	indices   []int
+	entities  []interface{}
+}
+
+// write writes the provided batch, providing the error for each of its
+// entities.
+func (w *Writer) write(ctx context.Context, b *batch, errs []error) {
+	fail := func(indices []int, err error) {
+		for _, i := range indices {
+			errs[i] = err
+		}
+	}
+	mapper := w.mappers[b.typeName]
+	records, err := w.Records(mapper, b.entities)
+	if err != nil {
+		fail(b.indices, err)
+		return
+	}
+	offset := 0
+	for _, r := range records {
+		rows := int(r.NumRows())
+		if err := w.sink.Write(ctx, b.operation, b.typeName, r); err != nil {
+			fail(b.indices[offset:offset+rows], err)
+		}
+		offset = offset + rows
+		r.Release()
+	}
+}
+
+// Write writes the provided operations as record batches grouped by type and
+// operation, providing the outcome of each operation. Record batches that
+// fail to be written fail each of the operations within them. It satisfies
+// work.UnitBulkWriteFunc.
+func (w *Writer) Write(
+	ctx context.Context, mCtx work.UnitMapperContext, operations []work.UnitBulkOperation) ([]error, error) {
+	errs := make([]error, len(operations))
+	var batches []*batch
+	grouped := make(map[work.UnitOperation]map[work.TypeName]*batch)
+	for i, op := range operations {
+		if op.Operation == work.UnitOperationPatch {
+			errs[i] = ErrPatchUnsupported
+			continue
+		}
+		if _, ok := w.mappers[op.TypeName]; !ok {
+			return nil, fmt.Errorf("no columnar mapper for type %s", op.TypeName)
+		}
+		if grouped[op.Operation] == nil {
+			grouped[op.Operation] = make(map[work.TypeName]*batch)
+		}
+		b, ok := grouped[op.Operation][op.TypeName]
+		if !ok {
+			b = &batch{operation: op.Operation, typeName: op.TypeName}
+			grouped[op.Operation][op.TypeName] = b
+			batches = append(batches, b)
+		}
+		b.indices = append(b.indices, i)
+		b.entities = append(b.entities, op.Entity)
+	}
+	for _, b := range batches {
+		w.write(ctx, b, errs)
+	}
+	return errs, nil
+}
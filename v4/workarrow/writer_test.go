@@ -0,0 +1,183 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package workarrow_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/freerware/work/v4"
+	"github.com/freerware/work/v4/internal/test"
+	"github.com/freerware/work/v4/workarrow"
+	"github.com/stretchr/testify/suite"
+)
+
+type fooMapper struct{}
+
+func (fooMapper) Schema() *arrow.Schema {
+	return arrow.NewSchema([]arrow.Field{{Name: "id", Type: arrow.PrimitiveTypes.Int64}}, nil)
+}
+
+func (fooMapper) Append(b *array.RecordBuilder, entity interface{}) error {
+	foo, ok := entity.(test.Foo)
+	if !ok {
+		return errors.New("not a foo")
+	}
+	b.Field(0).(*array.Int64Builder).Append(int64(foo.ID))
+	return nil
+}
+
+type write struct {
+	operation work.UnitOperation
+	typeName  work.TypeName
+	ids       []int64
+}
+
+type sink struct {
+	writes []write
+	errs   []error
+}
+
+func (s *sink) Write(ctx context.Context, op work.UnitOperation, t work.TypeName, r arrow.RecordBatch) (err error) {
+	ids := r.Column(0).(*array.Int64).Int64Values()
+	s.writes = append(s.writes, write{operation: op, typeName: t, ids: append([]int64(nil), ids...)})
+	if len(s.errs) > 0 {
+		err, s.errs = s.errs[0], s.errs[1:]
+	}
+	return
+}
+
+type WriterTestSuite struct {
+	suite.Suite
+
+	sink  *sink
+	alloc *memory.CheckedAllocator
+	sut   *workarrow.Writer
+}
+
+func TestWriterTestSuite(t *testing.T) {
+	suite.Run(t, new(WriterTestSuite))
+}
+
+func (s *WriterTestSuite) SetupTest() {
+	s.sink = &sink{}
+	s.alloc = memory.NewCheckedAllocator(memory.NewGoAllocator())
+	s.sut = workarrow.NewWriter(s.sink, map[work.TypeName]workarrow.ColumnarMapper{
+		work.TypeNameOf(test.Foo{}): fooMapper{},
+	}, workarrow.BatchSize(2), workarrow.Allocator(s.alloc))
+}
+
+func (s *WriterTestSuite) TearDownTest() {
+	s.alloc.AssertSize(s.T(), 0)
+}
+
+func (s *WriterTestSuite) TestWriter_Save() {
+	// arrange.
+	ctx := context.Background()
+	u, err := work.NewUnit(s.sut.Options()...)
+	s.Require().NoError(err)
+	s.Require().NoError(u.Add(ctx, test.Foo{ID: 1}, test.Foo{ID: 2}, test.Foo{ID: 3}))
+	s.Require().NoError(u.Upsert(ctx, test.Foo{ID: 4}))
+
+	// action.
+	err = u.Save(ctx)
+
+	// assert.
+	s.Require().NoError(err)
+	fooType := work.TypeNameOf(test.Foo{})
+	s.ElementsMatch([]write{
+		{operation: work.UnitOperationInsert, typeName: fooType, ids: []int64{1, 2}},
+		{operation: work.UnitOperationInsert, typeName: fooType, ids: []int64{3}},
+		{operation: work.UnitOperationUpsert, typeName: fooType, ids: []int64{4}},
+	}, s.sink.writes)
+}
+
+func (s *WriterTestSuite) TestWriter_Write_SinkError() {
+	// arrange.
+	ctx := context.Background()
+	fooType := work.TypeNameOf(test.Foo{})
+	ops := []work.UnitBulkOperation{
+		{Operation: work.UnitOperationInsert, TypeName: fooType, Entity: test.Foo{ID: 1}},
+		{Operation: work.UnitOperationInsert, TypeName: fooType, Entity: test.Foo{ID: 2}},
+		{Operation: work.UnitOperationInsert, TypeName: fooType, Entity: test.Foo{ID: 3}},
+	}
+	s.sink.errs = []error{errors.New("whoa")}
+
+	// action.
+	errs, err := s.sut.Write(ctx, work.UnitMapperContext{}, ops)
+
+	// assert.
+	s.Require().NoError(err)
+	s.Require().Len(errs, 3)
+	s.EqualError(errs[0], "whoa")
+	s.EqualError(errs[1], "whoa")
+	s.NoError(errs[2])
+}
+
+func (s *WriterTestSuite) TestWriter_Write_AppendError() {
+	// arrange.
+	ctx := context.Background()
+	fooType := work.TypeNameOf(test.Foo{})
+	ops := []work.UnitBulkOperation{
+		{Operation: work.UnitOperationInsert, TypeName: fooType, Entity: test.Foo{ID: 1}},
+		{Operation: work.UnitOperationInsert, TypeName: fooType, Entity: test.Foo{ID: 2}},
+		{Operation: work.UnitOperationInsert, TypeName: fooType, Entity: "bad"},
+	}
+
+	// action.
+	errs, err := s.sut.Write(ctx, work.UnitMapperContext{}, ops)
+
+	// assert.
+	s.Require().NoError(err)
+	for _, err := range errs {
+		s.EqualError(err, "not a foo")
+	}
+	s.Empty(s.sink.writes)
+}
+
+func (s *WriterTestSuite) TestWriter_Write_Patch() {
+	// arrange.
+	ctx := context.Background()
+	fooType := work.TypeNameOf(test.Foo{})
+	ops := []work.UnitBulkOperation{
+		{Operation: work.UnitOperationPatch, TypeName: fooType, Patch: work.UnitPatch{TypeName: fooType, ID: 1}},
+	}
+
+	// action.
+	errs, err := s.sut.Write(ctx, work.UnitMapperContext{}, ops)
+
+	// assert.
+	s.Require().NoError(err)
+	s.ErrorIs(errs[0], workarrow.ErrPatchUnsupported)
+}
+
+func (s *WriterTestSuite) TestWriter_Write_UnknownType() {
+	// arrange.
+	ctx := context.Background()
+	ops := []work.UnitBulkOperation{
+		{Operation: work.UnitOperationInsert, TypeName: work.TypeNameOf(test.Bar{}), Entity: test.Bar{ID: "1"}},
+	}
+
+	// action.
+	_, err := s.sut.Write(ctx, work.UnitMapperContext{}, ops)
+
+	// assert.
+	s.Error(err)
+}
@@ -0,0 +1,54 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+// Package workbun bridges bun queries into the transactions of work units.
+//
+// Conn provides the connection that bun queries run on within a data mapper,
+// executing them within the transaction of the save attempt:
+//
+//	func (m FooMapper) Insert(ctx context.Context, mCtx work.UnitMapperContext, foos ...interface{}) error {
+//		models := make([]*Foo, 0, len(foos))
+//		for _, f := range foos {
+//			models = append(models, f.(*Foo))
+//		}
+//		_, err := m.db.NewInsert().Conn(workbun.Conn(mCtx)).Model(&models).Exec(ctx)
+//		return err
+//	}
+package workbun
+
+import (
+	"context"
+
+	"github.com/freerware/work/v4"
+	"github.com/uptrace/bun"
+)
+
+// Conn provides the bun connection that executes queries within the
+// transaction of the provided mapper context, detecting usages of the
+// transaction after the save attempt has completed.
+func Conn(mCtx work.UnitMapperContext) bun.IConn {
+	return mCtx
+}
+
+// MapperFunc performs a data mapping operation for the provided entities
+// with the provided bun connection.
+type MapperFunc func(context.Context, bun.IConn, ...interface{}) error
+
+// Func adapts the provided function into a data mapper function, providing
+// it with the connection for the transaction of each save attempt.
+func Func(f MapperFunc) work.UnitDataMapperFunc {
+	return func(ctx context.Context, mCtx work.UnitMapperContext, entities ...interface{}) error {
+		return f(ctx, Conn(mCtx), entities...)
+	}
+}
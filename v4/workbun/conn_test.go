@@ -0,0 +1,71 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package workbun_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/freerware/work/v4"
+	"github.com/freerware/work/v4/internal/test"
+	"github.com/freerware/work/v4/workbun"
+	"github.com/stretchr/testify/suite"
+	"github.com/uptrace/bun"
+)
+
+type ConnTestSuite struct {
+	suite.Suite
+}
+
+func TestConnTestSuite(t *testing.T) {
+	suite.Run(t, new(ConnTestSuite))
+}
+
+func (s *ConnTestSuite) TestFunc() {
+	// arrange.
+	ctx := context.Background()
+	db, mock, err := sqlmock.New()
+	s.Require().NoError(err)
+	defer db.Close()
+	insert := workbun.Func(func(ctx context.Context, conn bun.IConn, foos ...interface{}) error {
+		for _, f := range foos {
+			if _, err := conn.ExecContext(ctx, "INSERT INTO foos (id) VALUES (?)", f.(test.Foo).ID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	noop := func(context.Context, work.UnitMapperContext, ...interface{}) error { return nil }
+	fooType := work.TypeNameOf(test.Foo{})
+	sut, err := work.NewUnit(
+		work.UnitDB(db),
+		work.UnitInsertFunc(fooType, insert),
+		work.UnitUpdateFunc(fooType, noop),
+		work.UnitDeleteFunc(fooType, noop),
+	)
+	s.Require().NoError(err)
+	s.Require().NoError(sut.Add(ctx, test.Foo{ID: 28}))
+	mock.ExpectBegin()
+	mock.ExpectExec(`^INSERT INTO foos \(id\) VALUES \(\?\)$`).WithArgs(28).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	// action.
+	err = sut.Save(ctx)
+
+	// assert.
+	s.Require().NoError(err)
+	s.NoError(mock.ExpectationsWereMet())
+}
@@ -0,0 +1,33 @@
+module github.com/freerware/work/v4/workbun
+
+go 1.23.0
+
+replace github.com/freerware/work/v4 => ../
+
+require (
+	github.com/DATA-DOG/go-sqlmock v1.5.2
+	github.com/freerware/work/v4 v4.0.0
+	github.com/stretchr/testify v1.10.0
+	github.com/uptrace/bun v1.2.15
+)
+
+require (
+	github.com/avast/retry-go/v4 v4.6.0 // indirect
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/golang/mock v1.6.0 // indirect
+	github.com/google/uuid v1.6.0 // indirect
+	github.com/jinzhu/inflection v1.0.0 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	github.com/puzpuzpuz/xsync/v3 v3.5.1 // indirect
+	github.com/sirupsen/logrus v1.9.3 // indirect
+	github.com/tmthrgd/go-hex v0.0.0-20190904060850-447a3041c3bc // indirect
+	github.com/twmb/murmur3 v1.1.6 // indirect
+	github.com/uber-go/tally/v4 v4.1.16 // indirect
+	github.com/vmihailenco/msgpack/v5 v5.4.1 // indirect
+	github.com/vmihailenco/tagparser/v2 v2.0.0 // indirect
+	go.uber.org/atomic v1.10.0 // indirect
+	go.uber.org/multierr v1.11.0 // indirect
+	go.uber.org/zap v1.27.0 // indirect
+	golang.org/x/sys v0.34.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
@@ -0,0 +1,231 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package workclickhouse writes the additions staged within work units to
+// ClickHouse tables with asynchronous INSERT batches, for event and metric
+// tables where transactional semantics do not fit.
+//
+// Additions are grouped by type into multi-row INSERT statements issued with
+// the async_insert setting, so that ClickHouse buffers and flushes them
+// efficiently. The tables are append-only: alterations, removals, upserts,
+// and patches are rejected, and inserts that succeeded are not rolled back
+// should others fail. Since saves are retried in their entirety, each
+// addition is written at least once, and may be written more than once:
+//
+//	ins := workclickhouse.NewInserter(db, map[work.TypeName]workclickhouse.Table{
+//		work.TypeNameOf(Event{}): {
+//			Name:    "events",
+//			Columns: []string{"id", "name", "created_at"},
+//			Values: func(entity interface{}) ([]interface{}, error) {
+//				e := entity.(Event)
+//				return []interface{}{e.ID, e.Name, e.CreatedAt}, nil
+//			},
+//		},
+//	})
+//	u, err := work.NewUnit(ins.Options()...)
+package workclickhouse
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/freerware/work/v4"
+)
+
+var (
+	// ErrAppendOnly represents the error that is returned for operations
+	// other than inserts, which append-only tables do not support.
+	ErrAppendOnly = errors.New("append-only table only supports inserts")
+	// ErrNoColumns represents the error that is returned when building an
+	// insert into a table without any columns.
+	ErrNoColumns = errors.New("insert requires at least one column")
+	// ErrNoEntities represents the error that is returned when building an
+	// insert without any entities to insert.
+	ErrNoEntities = errors.New("insert requires at least one entity")
+)
+
+// Table describes the ClickHouse table the entities of a particular type
+// are inserted into.
+type Table struct {
+	// Name is the name of the table.
+	Name string
+	// Columns are the names of the columns to insert.
+	Columns []string
+	// Values provides the values of each of the columns for the provided
+	// entity, in order.
+	Values func(entity interface{}) ([]interface{}, error)
+}
+
+// Build provides the statement inserting the rows for the provided entities
+// with the provided settings, along with its arguments:
+//
+//	INSERT INTO events (id, name) SETTINGS async_insert = 1 VALUES (?, ?), (?, ?)
+func (t Table) Build(settings []string, entities ...interface{}) (query string, args []interface{}, err error) {
+	if len(t.Columns) == 0 {
+		return "", nil, ErrNoColumns
+	}
+	if len(entities) == 0 {
+		return "", nil, ErrNoEntities
+	}
+	row := "(" + strings.TrimSuffix(strings.Repeat("?, ", len(t.Columns)), ", ") + ")"
+	var b strings.Builder
+	fmt.Fprintf(&b, "INSERT INTO %s (%s)", t.Name, strings.Join(t.Columns, ", "))
+	if len(settings) > 0 {
+		fmt.Fprintf(&b, " SETTINGS %s", strings.Join(settings, ", "))
+	}
+	b.WriteString(" VALUES ")
+	for i, entity := range entities {
+		values, err := t.Values(entity)
+		if err != nil {
+			return "", nil, err
+		}
+		if len(values) != len(t.Columns) {
+			return "", nil, fmt.Errorf(
+				"insert into %s provided %d values for %d columns", t.Name, len(values), len(t.Columns))
+		}
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteString(row)
+		args = append(args, values...)
+	}
+	return b.String(), args, nil
+}
+
+// Options represents the configuration options for the inserter.
+type Options struct {
+	batchSize int
+	wait      bool
+}
+
+// Option applies an option to the provided configuration.
+type Option func(*Options)
+
+var (
+	// BatchSize defines the maximum number of rows inserted by each
+	// statement, which defaults to 10000.
+	BatchSize = func(size int) Option {
+		if size < 1 {
+			size = 1
+		}
+		return func(o *Options) {
+			o.batchSize = size
+		}
+	}
+
+	// FireAndForget specifies that inserts return once ClickHouse has
+	// accepted them into its buffer, rather than once they are flushed.
+	// Failures to flush are then not reported, weakening the at-least-once
+	// semantics in exchange for lower latency.
+	FireAndForget = func() Option {
+		return func(o *Options) {
+			o.wait = false
+		}
+	}
+)
+
+// Inserter inserts the additions staged within work units into ClickHouse
+// tables with asynchronous INSERT batches.
+type Inserter struct {
+	db      *sql.DB
+	tables  map[work.TypeName]Table
+	options Options
+}
+
+// NewInserter constructs an inserter that inserts with the provided database
+// into the tables of the types provided.
+func NewInserter(db *sql.DB, tables map[work.TypeName]Table, opts ...Option) *Inserter {
+	o := Options{batchSize: 10000, wait: true}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return &Inserter{db: db, tables: tables, options: o}
+}
+
+// Options provides the options for work units that insert the entities of
+// the types with tables.
+func (i *Inserter) Options() []work.UnitOption {
+	types := make([]work.TypeName, 0, len(i.tables))
+	for t := range i.tables {
+		types = append(types, t)
+	}
+	return []work.UnitOption{work.UnitBulkWriter(i.Write, types...)}
+}
+
+func (i *Inserter) settings() []string {
+	wait := 0
+	if i.options.wait {
+		wait = 1
+	}
+	return []string{"async_insert = 1", fmt.Sprintf("wait_for_async_insert = %d", wait)}
+}
+
+// insert inserts the provided entities into the provided table in batches,
+// providing the error for each of the entities at the provided indices.
+func (i *Inserter) insert(ctx context.Context, t Table, indices []int, entities []interface{}, errs []error) {
+	for len(entities) > 0 {
+		n := len(entities)
+		if n > i.options.batchSize {
+			n = i.options.batchSize
+		}
+		query, args, err := t.Build(i.settings(), entities[:n]...)
+		if err == nil {
+			_, err = i.db.ExecContext(ctx, query, args...)
+		}
+		if err != nil {
+			for _, idx := range indices[:n] {
+				errs[idx] = err
+			}
+		}
+		indices, entities = indices[n:], entities[n:]
+	}
+}
+
+// Write inserts the entities of the provided insert operations, grouped by
+// type, providing the outcome of each operation. Operations other than
+// inserts fail with ErrAppendOnly, and compensating operations are skipped,
+// since asynchronous inserts cannot be undone. It satisfies
+// work.UnitBulkWriteFunc.
+func (i *Inserter) Write(
+	ctx context.Context, mCtx work.UnitMapperContext, operations []work.UnitBulkOperation) ([]error, error) {
+	errs := make([]error, len(operations))
+	if mCtx.Compensating() {
+		return errs, nil
+	}
+	var types []work.TypeName
+	indices := make(map[work.TypeName][]int)
+	entities := make(map[work.TypeName][]interface{})
+	for idx, op := range operations {
+		if op.Operation != work.UnitOperationInsert {
+			errs[idx] = ErrAppendOnly
+			continue
+		}
+		if _, ok := i.tables[op.TypeName]; !ok {
+			return nil, fmt.Errorf("no table for type %s", op.TypeName)
+		}
+		if _, ok := indices[op.TypeName]; !ok {
+			types = append(types, op.TypeName)
+		}
+		indices[op.TypeName] = append(indices[op.TypeName], idx)
+		entities[op.TypeName] = append(entities[op.TypeName], op.Entity)
+	}
+	for _, t := range types {
+		i.insert(ctx, i.tables[t], indices[t], entities[t], errs)
+	}
+	return errs, nil
+}
@@ -0,0 +1,206 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package workclickhouse_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/freerware/work/v4"
+	"github.com/freerware/work/v4/internal/test"
+	"github.com/freerware/work/v4/workclickhouse"
+	"github.com/stretchr/testify/suite"
+)
+
+const settings = "SETTINGS async_insert = 1, wait_for_async_insert = 1"
+
+type InserterTestSuite struct {
+	suite.Suite
+
+	db     sqlmock.Sqlmock
+	tables map[work.TypeName]workclickhouse.Table
+	sut    *workclickhouse.Inserter
+}
+
+func TestInserterTestSuite(t *testing.T) {
+	suite.Run(t, new(InserterTestSuite))
+}
+
+func (s *InserterTestSuite) SetupTest() {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	s.Require().NoError(err)
+	s.db = mock
+	s.tables = map[work.TypeName]workclickhouse.Table{
+		work.TypeNameOf(test.Foo{}): {
+			Name:    "foos",
+			Columns: []string{"id"},
+			Values: func(entity interface{}) ([]interface{}, error) {
+				return []interface{}{entity.(test.Foo).ID}, nil
+			},
+		},
+	}
+	s.sut = workclickhouse.NewInserter(db, s.tables, workclickhouse.BatchSize(2))
+}
+
+func (s *InserterTestSuite) TearDownTest() {
+	s.NoError(s.db.ExpectationsWereMet())
+}
+
+func (s *InserterTestSuite) unit(opts ...work.UnitOption) work.Unit {
+	u, err := work.NewUnit(append(s.sut.Options(), opts...)...)
+	s.Require().NoError(err)
+	return u
+}
+
+func (s *InserterTestSuite) TestInserter_Save() {
+	// arrange.
+	ctx := context.Background()
+	u := s.unit()
+	s.Require().NoError(u.Add(ctx, test.Foo{ID: 1}, test.Foo{ID: 2}, test.Foo{ID: 3}))
+	s.db.ExpectExec("INSERT INTO foos (id) "+settings+" VALUES (?), (?)").
+		WithArgs(1, 2).WillReturnResult(sqlmock.NewResult(0, 2))
+	s.db.ExpectExec("INSERT INTO foos (id) " + settings + " VALUES (?)").
+		WithArgs(3).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	// action.
+	err := u.Save(ctx)
+
+	// assert.
+	s.NoError(err)
+}
+
+func (s *InserterTestSuite) TestInserter_Save_AtLeastOnce() {
+	// arrange.
+	ctx := context.Background()
+	u := s.unit(work.UnitRetryAttempts(2))
+	s.Require().NoError(u.Add(ctx, test.Foo{ID: 1}, test.Foo{ID: 2}, test.Foo{ID: 3}))
+	s.db.ExpectExec("INSERT INTO foos (id) "+settings+" VALUES (?), (?)").
+		WithArgs(1, 2).WillReturnResult(sqlmock.NewResult(0, 2))
+	s.db.ExpectExec("INSERT INTO foos (id) " + settings + " VALUES (?)").
+		WithArgs(3).WillReturnError(errors.New("whoa"))
+	// the successful batch is not rolled back, and is inserted again.
+	s.db.ExpectExec("INSERT INTO foos (id) "+settings+" VALUES (?), (?)").
+		WithArgs(1, 2).WillReturnResult(sqlmock.NewResult(0, 2))
+	s.db.ExpectExec("INSERT INTO foos (id) " + settings + " VALUES (?)").
+		WithArgs(3).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	// action.
+	err := u.Save(ctx)
+
+	// assert.
+	s.NoError(err)
+}
+
+func (s *InserterTestSuite) TestInserter_Save_AppendOnly() {
+	// arrange.
+	ctx := context.Background()
+	u := s.unit(work.UnitRetryAttempts(1))
+	s.Require().NoError(u.Alter(ctx, test.Foo{ID: 1}))
+
+	// action.
+	err := u.Save(ctx)
+
+	// assert.
+	s.ErrorIs(err, workclickhouse.ErrAppendOnly)
+}
+
+func (s *InserterTestSuite) TestInserter_Write_FireAndForget() {
+	// arrange.
+	ctx := context.Background()
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	s.Require().NoError(err)
+	sut := workclickhouse.NewInserter(db, s.tables, workclickhouse.FireAndForget())
+	ops := []work.UnitBulkOperation{
+		{Operation: work.UnitOperationInsert, TypeName: work.TypeNameOf(test.Foo{}), Entity: test.Foo{ID: 1}},
+	}
+	mock.ExpectExec("INSERT INTO foos (id) SETTINGS async_insert = 1, wait_for_async_insert = 0 VALUES (?)").
+		WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	// action.
+	errs, err := sut.Write(ctx, work.UnitMapperContext{}, ops)
+
+	// assert.
+	s.Require().NoError(err)
+	s.Equal([]error{nil}, errs)
+	s.NoError(mock.ExpectationsWereMet())
+}
+
+func (s *InserterTestSuite) TestInserter_Write_UnknownType() {
+	// arrange.
+	ops := []work.UnitBulkOperation{
+		{Operation: work.UnitOperationInsert, TypeName: work.TypeNameOf(test.Bar{}), Entity: test.Bar{ID: "1"}},
+	}
+
+	// action.
+	_, err := s.sut.Write(context.Background(), work.UnitMapperContext{}, ops)
+
+	// assert.
+	s.Error(err)
+}
+
+func (s *InserterTestSuite) TestTable_Build() {
+	tests := []struct {
+		name     string
+		table    workclickhouse.Table
+		entities []interface{}
+		query    string
+		args     []interface{}
+		err      error
+	}{
+		{
+			name:     "NoColumns",
+			table:    workclickhouse.Table{Name: "foos"},
+			entities: []interface{}{test.Foo{ID: 1}},
+			err:      workclickhouse.ErrNoColumns,
+		},
+		{
+			name:  "NoEntities",
+			table: s.tables[work.TypeNameOf(test.Foo{})],
+			err:   workclickhouse.ErrNoEntities,
+		},
+		{
+			name: "MultipleColumns",
+			table: workclickhouse.Table{
+				Name:    "foos",
+				Columns: []string{"id", "double"},
+				Values: func(entity interface{}) ([]interface{}, error) {
+					id := entity.(test.Foo).ID
+					return []interface{}{id, id * 2}, nil
+				},
+			},
+			entities: []interface{}{test.Foo{ID: 1}, test.Foo{ID: 2}},
+			query:    "INSERT INTO foos (id, double) VALUES (?, ?), (?, ?)",
+			args:     []interface{}{1, 2, 2, 4},
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			// action.
+			query, args, err := tt.table.Build(nil, tt.entities...)
+
+			// assert.
+			if tt.err != nil {
+				s.ErrorIs(err, tt.err)
+				return
+			}
+			s.Require().NoError(err)
+			s.Equal(tt.query, query)
+			s.Equal(tt.args, args)
+		})
+	}
+}
@@ -0,0 +1,155 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package workdlq provides a dead letter queue shared by the side effects
+// performed after work units are committed, such as synchronizing search
+// indices and notifying webhooks. Side effects that fail after exhausting
+// their retries are persisted along with their payload and error, so that
+// changes that were committed but never published can be replayed once the
+// failure is resolved:
+//
+//	q := workdlq.NewQueue(workdlq.NewSQLStore(db, "postgres"))
+//	s := worksearch.NewSyncer(client, mappings, worksearch.DeadLetterQueue(q))
+//	...
+//	report, err := q.Replay(ctx, 100)
+package workdlq
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrNoHandler represents the error that is recorded for letters of a kind
+// without a replay handler.
+var ErrNoHandler = errors.New("no replay handler for kind")
+
+// Letter represents a side effect that failed after its changes were
+// committed.
+type Letter struct {
+	// ID uniquely identifies the letter.
+	ID string
+	// Kind identifies the side effect, which determines the handler the
+	// letter is replayed with, such as "worksearch.bulk".
+	Kind string
+	// Payload is the JSON encoded payload of the side effect.
+	Payload []byte
+	// Error is the message of the most recent failure of the side effect.
+	Error string
+	// Attempts is the number of times the side effect was replayed.
+	Attempts int
+	// Created is when the letter was published.
+	Created time.Time
+}
+
+// Store represents a persistent store of letters.
+type Store interface {
+	// Put stores the provided letter.
+	Put(context.Context, Letter) error
+	// Update replaces the stored letter with the same identifier.
+	Update(context.Context, Letter) error
+	// Delete removes the letter with the provided identifier.
+	Delete(context.Context, string) error
+	// List provides up to the provided number of letters, oldest first.
+	List(context.Context, int) ([]Letter, error)
+}
+
+// ReplayFunc performs the side effect with the provided payload again.
+type ReplayFunc func(ctx context.Context, payload []byte) error
+
+// ReplayReport represents the outcome of replaying letters.
+type ReplayReport struct {
+	// Replayed is the number of letters replayed successfully, which are
+	// removed from the store.
+	Replayed int
+	// Failed is the number of letters that failed to be replayed, which
+	// remain in the store with their error and attempts updated.
+	Failed int
+}
+
+// Queue is a dead letter queue of failed side effects.
+type Queue struct {
+	store    Store
+	handlers map[string]ReplayFunc
+}
+
+// NewQueue constructs a queue that persists letters with the provided
+// store.
+func NewQueue(store Store) *Queue {
+	return &Queue{store: store, handlers: make(map[string]ReplayFunc)}
+}
+
+// Handle registers the provided function to replay letters of the provided
+// kind, replacing any function previously registered for it. Handlers are
+// expected to be registered before letters are replayed.
+func (q *Queue) Handle(kind string, f ReplayFunc) {
+	q.handlers[kind] = f
+}
+
+// Publish persists the side effect of the provided kind with the provided
+// payload, encoded as JSON, that failed with the provided error.
+func (q *Queue) Publish(ctx context.Context, kind string, payload interface{}, cause error) error {
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("unable to encode %s payload: %w", kind, err)
+	}
+	letter := Letter{
+		ID:      uuid.NewString(),
+		Kind:    kind,
+		Payload: b,
+		Created: time.Now().UTC(),
+	}
+	if cause != nil {
+		letter.Error = cause.Error()
+	}
+	return q.store.Put(ctx, letter)
+}
+
+// Replay replays up to the provided number of letters, oldest first, with
+// the handlers registered for their kind. Letters replayed successfully are
+// removed, while those that fail remain for a later replay. An error is
+// returned when the store fails.
+func (q *Queue) Replay(ctx context.Context, limit int) (report ReplayReport, err error) {
+	letters, err := q.store.List(ctx, limit)
+	if err != nil {
+		return
+	}
+	for _, letter := range letters {
+		var replayErr error
+		if f, ok := q.handlers[letter.Kind]; ok {
+			replayErr = f(ctx, letter.Payload)
+		} else {
+			replayErr = fmt.Errorf("%w %q", ErrNoHandler, letter.Kind)
+		}
+		if replayErr == nil {
+			if err = q.store.Delete(ctx, letter.ID); err != nil {
+				return
+			}
+			report.Replayed = report.Replayed + 1
+			continue
+		}
+		letter.Error = replayErr.Error()
+		letter.Attempts = letter.Attempts + 1
+		if err = q.store.Update(ctx, letter); err != nil {
+			return
+		}
+		report.Failed = report.Failed + 1
+	}
+	return
+}
@@ -0,0 +1,149 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package workdlq_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/freerware/work/v4/workdlq"
+	"github.com/stretchr/testify/suite"
+)
+
+type QueueTestSuite struct {
+	suite.Suite
+
+	store *workdlq.MemoryStore
+	sut   *workdlq.Queue
+}
+
+func TestQueueTestSuite(t *testing.T) {
+	suite.Run(t, new(QueueTestSuite))
+}
+
+func (s *QueueTestSuite) SetupTest() {
+	s.store = workdlq.NewMemoryStore()
+	s.sut = workdlq.NewQueue(s.store)
+}
+
+func (s *QueueTestSuite) TestQueue_Publish() {
+	// arrange.
+	ctx := context.Background()
+
+	// action.
+	err := s.sut.Publish(ctx, "foo", map[string]int{"id": 1}, errors.New("whoa"))
+
+	// assert.
+	s.Require().NoError(err)
+	letters, err := s.store.List(ctx, 10)
+	s.Require().NoError(err)
+	s.Require().Len(letters, 1)
+	s.NotEmpty(letters[0].ID)
+	s.Equal("foo", letters[0].Kind)
+	s.JSONEq(`{"id":1}`, string(letters[0].Payload))
+	s.Equal("whoa", letters[0].Error)
+	s.Zero(letters[0].Attempts)
+	s.False(letters[0].Created.IsZero())
+}
+
+func (s *QueueTestSuite) TestQueue_Publish_EncodeError() {
+	// action.
+	err := s.sut.Publish(context.Background(), "foo", make(chan int), errors.New("whoa"))
+
+	// assert.
+	s.Error(err)
+}
+
+func (s *QueueTestSuite) TestQueue_Replay() {
+	// arrange.
+	ctx := context.Background()
+	s.Require().NoError(s.sut.Publish(ctx, "foo", 1, errors.New("whoa")))
+	s.Require().NoError(s.sut.Publish(ctx, "foo", 2, errors.New("whoa")))
+	var replayed []int
+	s.sut.Handle("foo", func(ctx context.Context, payload []byte) error {
+		var id int
+		s.Require().NoError(json.Unmarshal(payload, &id))
+		replayed = append(replayed, id)
+		return nil
+	})
+
+	// action.
+	report, err := s.sut.Replay(ctx, 10)
+
+	// assert.
+	s.Require().NoError(err)
+	s.Equal(workdlq.ReplayReport{Replayed: 2}, report)
+	s.ElementsMatch([]int{1, 2}, replayed)
+	letters, err := s.store.List(ctx, 10)
+	s.Require().NoError(err)
+	s.Empty(letters)
+}
+
+func (s *QueueTestSuite) TestQueue_Replay_Failure() {
+	// arrange.
+	ctx := context.Background()
+	s.Require().NoError(s.sut.Publish(ctx, "foo", 1, errors.New("whoa")))
+	s.sut.Handle("foo", func(ctx context.Context, payload []byte) error {
+		return errors.New("still down")
+	})
+
+	// action.
+	report, err := s.sut.Replay(ctx, 10)
+
+	// assert.
+	s.Require().NoError(err)
+	s.Equal(workdlq.ReplayReport{Failed: 1}, report)
+	letters, err := s.store.List(ctx, 10)
+	s.Require().NoError(err)
+	s.Require().Len(letters, 1)
+	s.Equal("still down", letters[0].Error)
+	s.Equal(1, letters[0].Attempts)
+}
+
+func (s *QueueTestSuite) TestQueue_Replay_NoHandler() {
+	// arrange.
+	ctx := context.Background()
+	s.Require().NoError(s.sut.Publish(ctx, "bar", 1, errors.New("whoa")))
+
+	// action.
+	report, err := s.sut.Replay(ctx, 10)
+
+	// assert.
+	s.Require().NoError(err)
+	s.Equal(workdlq.ReplayReport{Failed: 1}, report)
+	letters, err := s.store.List(ctx, 10)
+	s.Require().NoError(err)
+	s.Require().Len(letters, 1)
+	s.Contains(letters[0].Error, workdlq.ErrNoHandler.Error())
+}
+
+func (s *QueueTestSuite) TestMemoryStore_List_Limit() {
+	// arrange.
+	ctx := context.Background()
+	for i := 0; i < 3; i++ {
+		s.Require().NoError(s.sut.Publish(ctx, "foo", i, nil))
+	}
+
+	// action.
+	letters, err := s.store.List(ctx, 2)
+
+	// assert.
+	s.Require().NoError(err)
+	s.Len(letters, 2)
+	s.Empty(letters[0].Error)
+}
@@ -0,0 +1,170 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package workdlq
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/freerware/work/v4/worksql"
+)
+
+// MemoryStore is a Store that keeps letters in memory, which is suitable
+// for tests and for side effects that need not survive restarts.
+type MemoryStore struct {
+	mutex   sync.Mutex
+	letters map[string]Letter
+}
+
+// NewMemoryStore constructs an empty memory store.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{letters: make(map[string]Letter)}
+}
+
+// Put stores the provided letter.
+func (s *MemoryStore) Put(ctx context.Context, letter Letter) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.letters[letter.ID] = letter
+	return nil
+}
+
+// Update replaces the stored letter with the same identifier.
+func (s *MemoryStore) Update(ctx context.Context, letter Letter) error {
+	return s.Put(ctx, letter)
+}
+
+// Delete removes the letter with the provided identifier.
+func (s *MemoryStore) Delete(ctx context.Context, id string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	delete(s.letters, id)
+	return nil
+}
+
+// List provides up to the provided number of letters, oldest first.
+func (s *MemoryStore) List(ctx context.Context, limit int) ([]Letter, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	letters := make([]Letter, 0, len(s.letters))
+	for _, letter := range s.letters {
+		letters = append(letters, letter)
+	}
+	sort.Slice(letters, func(i, j int) bool {
+		return letters[i].Created.Before(letters[j].Created)
+	})
+	if limit > 0 && len(letters) > limit {
+		letters = letters[:limit]
+	}
+	return letters, nil
+}
+
+// SQLStore is a Store that persists letters within a table of a SQL
+// database, which is expected to have the following columns:
+//
+//	CREATE TABLE work_dead_letters (
+//		id         VARCHAR(36) PRIMARY KEY,
+//		kind       VARCHAR(255) NOT NULL,
+//		payload    BYTEA NOT NULL,
+//		error      TEXT NOT NULL,
+//		attempts   INTEGER NOT NULL,
+//		created_at TIMESTAMP NOT NULL
+//	);
+type SQLStore struct {
+	db          *sql.DB
+	placeholder worksql.Placeholder
+	options     Options
+}
+
+// Options represents the configuration options for the SQL store.
+type Options struct {
+	table string
+}
+
+// Option applies an option to the provided configuration.
+type Option func(*Options)
+
+var (
+	// Table defines the name of the table letters are persisted within,
+	// which defaults to "work_dead_letters".
+	Table = func(table string) Option {
+		return func(o *Options) {
+			o.table = table
+		}
+	}
+)
+
+// NewSQLStore constructs a store that persists letters with the provided
+// database, formatting statements for the driver registered with the
+// provided name.
+func NewSQLStore(db *sql.DB, driverName string, opts ...Option) *SQLStore {
+	o := Options{table: "work_dead_letters"}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return &SQLStore{db: db, placeholder: worksql.PlaceholderFor(driverName), options: o}
+}
+
+// Put stores the provided letter.
+func (s *SQLStore) Put(ctx context.Context, letter Letter) error {
+	p := s.placeholder
+	query := fmt.Sprintf(
+		"INSERT INTO %s (id, kind, payload, error, attempts, created_at) VALUES (%s, %s, %s, %s, %s, %s)",
+		s.options.table, p(1), p(2), p(3), p(4), p(5), p(6))
+	_, err := s.db.ExecContext(ctx, query,
+		letter.ID, letter.Kind, letter.Payload, letter.Error, letter.Attempts, letter.Created)
+	return err
+}
+
+// Update replaces the error and attempts of the stored letter with the same
+// identifier.
+func (s *SQLStore) Update(ctx context.Context, letter Letter) error {
+	p := s.placeholder
+	query := fmt.Sprintf("UPDATE %s SET error = %s, attempts = %s WHERE id = %s",
+		s.options.table, p(1), p(2), p(3))
+	_, err := s.db.ExecContext(ctx, query, letter.Error, letter.Attempts, letter.ID)
+	return err
+}
+
+// Delete removes the letter with the provided identifier.
+func (s *SQLStore) Delete(ctx context.Context, id string) error {
+	query := fmt.Sprintf("DELETE FROM %s WHERE id = %s", s.options.table, s.placeholder(1))
+	_, err := s.db.ExecContext(ctx, query, id)
+	return err
+}
+
+// List provides up to the provided number of letters, oldest first.
+func (s *SQLStore) List(ctx context.Context, limit int) (letters []Letter, err error) {
+	query := fmt.Sprintf(
+		"SELECT id, kind, payload, error, attempts, created_at FROM %s ORDER BY created_at LIMIT %s",
+		s.options.table, s.placeholder(1))
+	rows, err := s.db.QueryContext(ctx, query, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var l Letter
+		if err = rows.Scan(&l.ID, &l.Kind, &l.Payload, &l.Error, &l.Attempts, &l.Created); err != nil {
+			return nil, err
+		}
+		letters = append(letters, l)
+	}
+	return letters, rows.Err()
+}
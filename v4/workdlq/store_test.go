@@ -0,0 +1,111 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package workdlq_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/freerware/work/v4/workdlq"
+	"github.com/stretchr/testify/suite"
+)
+
+type SQLStoreTestSuite struct {
+	suite.Suite
+
+	mock sqlmock.Sqlmock
+	sut  *workdlq.SQLStore
+}
+
+func TestSQLStoreTestSuite(t *testing.T) {
+	suite.Run(t, new(SQLStoreTestSuite))
+}
+
+func (s *SQLStoreTestSuite) SetupTest() {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	s.Require().NoError(err)
+	s.mock = mock
+	s.sut = workdlq.NewSQLStore(db, "postgres", workdlq.Table("dead_letters"))
+}
+
+func (s *SQLStoreTestSuite) TearDownTest() {
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *SQLStoreTestSuite) TestSQLStore_Put() {
+	// arrange.
+	created := time.Now()
+	letter := workdlq.Letter{ID: "1", Kind: "foo", Payload: []byte("{}"), Error: "whoa", Created: created}
+	s.mock.ExpectExec(
+		"INSERT INTO dead_letters (id, kind, payload, error, attempts, created_at) VALUES ($1, $2, $3, $4, $5, $6)").
+		WithArgs("1", "foo", []byte("{}"), "whoa", 0, created).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	// action.
+	err := s.sut.Put(context.Background(), letter)
+
+	// assert.
+	s.NoError(err)
+}
+
+func (s *SQLStoreTestSuite) TestSQLStore_Update() {
+	// arrange.
+	letter := workdlq.Letter{ID: "1", Error: "still down", Attempts: 2}
+	s.mock.ExpectExec("UPDATE dead_letters SET error = $1, attempts = $2 WHERE id = $3").
+		WithArgs("still down", 2, "1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	// action.
+	err := s.sut.Update(context.Background(), letter)
+
+	// assert.
+	s.NoError(err)
+}
+
+func (s *SQLStoreTestSuite) TestSQLStore_Delete() {
+	// arrange.
+	s.mock.ExpectExec("DELETE FROM dead_letters WHERE id = $1").
+		WithArgs("1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	// action.
+	err := s.sut.Delete(context.Background(), "1")
+
+	// assert.
+	s.NoError(err)
+}
+
+func (s *SQLStoreTestSuite) TestSQLStore_List() {
+	// arrange.
+	created := time.Now()
+	rows := sqlmock.NewRows([]string{"id", "kind", "payload", "error", "attempts", "created_at"}).
+		AddRow("1", "foo", []byte("{}"), "whoa", 1, created)
+	s.mock.ExpectQuery(
+		"SELECT id, kind, payload, error, attempts, created_at FROM dead_letters ORDER BY created_at LIMIT $1").
+		WithArgs(10).
+		WillReturnRows(rows)
+
+	// action.
+	letters, err := s.sut.List(context.Background(), 10)
+
+	// assert.
+	s.Require().NoError(err)
+	s.Equal([]workdlq.Letter{
+		{ID: "1", Kind: "foo", Payload: []byte("{}"), Error: "whoa", Attempts: 1, Created: created},
+	}, letters)
+}
@@ -0,0 +1,89 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+// Package workent adapts ent clients to operate within the transactions of
+// work units, allowing ent users to benefit from their retries, actions, and
+// metrics.
+//
+// Func adapts a function mutating entities with an ent client into a data
+// mapper function, creating the client with a driver bound to the
+// transaction of each save attempt:
+//
+//	newClient := func(drv dialect.Driver) *ent.Client {
+//		return ent.NewClient(ent.Driver(drv))
+//	}
+//	u, err := work.NewUnit(
+//		work.UnitDB(db),
+//		work.UnitInsertFunc(work.TypeNameOf(&ent.User{}), workent.Func(newClient,
+//			func(ctx context.Context, c *ent.Client, users ...interface{}) error {
+//				builders := make([]*ent.UserCreate, 0, len(users))
+//				for _, u := range users {
+//					builders = append(builders, c.User.Create().SetName(u.(*ent.User).Name))
+//				}
+//				return c.User.CreateBulk(builders...).Exec(ctx)
+//			})),
+//	)
+package workent
+
+import (
+	"context"
+
+	"entgo.io/ent/dialect"
+	entsql "entgo.io/ent/dialect/sql"
+	"github.com/freerware/work/v4"
+)
+
+// driver represents an ent driver that executes statements within the
+// transaction of a mapper context. Transactions started with it are no-ops,
+// as the transaction is owned by the work unit.
+type driver struct {
+	entsql.Conn
+	dialect string
+}
+
+// Driver provides an ent driver that executes statements within the
+// transaction of the provided mapper context, detecting usages of the
+// transaction after the save attempt has completed.
+func Driver(mCtx work.UnitMapperContext) dialect.Driver {
+	return driver{Conn: entsql.Conn{ExecQuerier: mCtx}, dialect: dialectOf(mCtx)}
+}
+
+// dialectOf provides the ent dialect for the driver of the provided mapper
+// context.
+func dialectOf(mCtx work.UnitMapperContext) string {
+	switch mCtx.DriverName() {
+	case "postgres", "pgx":
+		return dialect.Postgres
+	case "sqlite3":
+		return dialect.SQLite
+	default:
+		return dialect.MySQL
+	}
+}
+
+// Tx provides a transaction that is committed and rolled back by the work
+// unit rather than by ent.
+func (d driver) Tx(context.Context) (dialect.Tx, error) {
+	return dialect.NopTx(d), nil
+}
+
+// Close is a no-op, as the transaction is owned by the work unit.
+func (d driver) Close() error {
+	return nil
+}
+
+// Dialect provides the dialect of the driver.
+func (d driver) Dialect() string {
+	return d.dialect
+}
@@ -0,0 +1,68 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package workent
+
+import (
+	"context"
+
+	"entgo.io/ent/dialect"
+	"github.com/freerware/work/v4"
+)
+
+// ClientFunc creates an ent client of type C using the provided driver,
+// typically by combining the generated ent.NewClient and ent.Driver.
+type ClientFunc[C any] func(dialect.Driver) C
+
+// MutationFunc applies the mutations for the provided entities with the
+// provided ent client.
+type MutationFunc[C any] func(context.Context, C, ...interface{}) error
+
+// Func adapts the provided mutation function into a data mapper function,
+// creating the client it is provided with from a driver bound to the
+// transaction of each save attempt.
+func Func[C any](newClient ClientFunc[C], f MutationFunc[C]) work.UnitDataMapperFunc {
+	return func(ctx context.Context, mCtx work.UnitMapperContext, entities ...interface{}) error {
+		return f(ctx, newClient(Driver(mCtx)), entities...)
+	}
+}
+
+// Mappers maps the entities of a particular type that are added, altered,
+// and removed within a work unit to ent mutations.
+type Mappers[C any] struct {
+	// NewClient creates the ent client provided to the mutation functions.
+	NewClient ClientFunc[C]
+	// Create creates the entities added within the work unit.
+	Create MutationFunc[C]
+	// Update updates the entities altered within the work unit.
+	Update MutationFunc[C]
+	// Delete deletes the entities removed within the work unit.
+	Delete MutationFunc[C]
+}
+
+// Options provides the options registering the mutation functions as the
+// data mapper functions for the entities of the provided type. Mutation
+// functions that are omitted are not registered.
+func (m Mappers[C]) Options(t work.TypeName) (opts []work.UnitOption) {
+	if m.Create != nil {
+		opts = append(opts, work.UnitInsertFunc(t, Func(m.NewClient, m.Create)))
+	}
+	if m.Update != nil {
+		opts = append(opts, work.UnitUpdateFunc(t, Func(m.NewClient, m.Update)))
+	}
+	if m.Delete != nil {
+		opts = append(opts, work.UnitDeleteFunc(t, Func(m.NewClient, m.Delete)))
+	}
+	return
+}
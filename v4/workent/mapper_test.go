@@ -0,0 +1,76 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package workent_test
+
+import (
+	"context"
+	"testing"
+
+	"entgo.io/ent/dialect"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/freerware/work/v4"
+	"github.com/freerware/work/v4/internal/test"
+	"github.com/freerware/work/v4/workent"
+	"github.com/stretchr/testify/suite"
+)
+
+type MappersTestSuite struct {
+	suite.Suite
+}
+
+func TestMappersTestSuite(t *testing.T) {
+	suite.Run(t, new(MappersTestSuite))
+}
+
+func (s *MappersTestSuite) TestMappers() {
+	// arrange.
+	ctx := context.Background()
+	db, mock, err := sqlmock.New()
+	s.Require().NoError(err)
+	defer db.Close()
+	exec := func(query string) workent.MutationFunc[dialect.Driver] {
+		return func(ctx context.Context, drv dialect.Driver, foos ...interface{}) error {
+			s.Equal(dialect.Postgres, drv.Dialect())
+			for _, f := range foos {
+				if err := drv.Exec(ctx, query, []any{f.(test.Foo).ID}, nil); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}
+	mappers := workent.Mappers[dialect.Driver]{
+		NewClient: func(drv dialect.Driver) dialect.Driver { return drv },
+		Create:    exec("INSERT INTO foos (id) VALUES ($1)"),
+		Update:    exec("UPDATE foos SET id = $1"),
+		Delete:    exec("DELETE FROM foos WHERE id = $1"),
+	}
+	opts := append(mappers.Options(work.TypeNameOf(test.Foo{})), work.UnitDB(db), work.UnitDriverName("pgx"))
+	sut, err := work.NewUnit(opts...)
+	s.Require().NoError(err)
+	s.Require().NoError(sut.Add(ctx, test.Foo{ID: 28}))
+	s.Require().NoError(sut.Remove(ctx, test.Foo{ID: 29}))
+	mock.ExpectBegin()
+	mock.ExpectExec(`^INSERT INTO foos \(id\) VALUES \(\$1\)$`).WithArgs(28).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`^DELETE FROM foos WHERE id = \$1$`).WithArgs(29).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	// action.
+	err = sut.Save(ctx)
+
+	// assert.
+	s.Require().NoError(err)
+	s.NoError(mock.ExpectationsWereMet())
+}
@@ -0,0 +1,81 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package workfx
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/freerware/work/v4"
+)
+
+// retryTypes maps the retry types within the configuration to those of the
+// work units.
+var retryTypes = map[string]work.UnitRetryDelayType{
+	"fixed":   work.UnitRetryDelayTypeFixed,
+	"backoff": work.UnitRetryDelayTypeBackOff,
+	"random":  work.UnitRetryDelayTypeRandom,
+}
+
+// Config represents the configuration of the work units, such as that
+// populated from a configuration file. Zero values retain the defaults of
+// the work units.
+type Config struct {
+	// RetryAttempts is the maximum number of attempts to save a work unit.
+	RetryAttempts int `json:"retryAttempts" yaml:"retryAttempts"`
+	// RetryDelay is the delay between attempts to save a work unit.
+	RetryDelay time.Duration `json:"retryDelay" yaml:"retryDelay"`
+	// RetryMaximumJitter is the maximum jitter added to the delay between
+	// attempts to save a work unit.
+	RetryMaximumJitter time.Duration `json:"retryMaximumJitter" yaml:"retryMaximumJitter"`
+	// RetryType is the manner in which the delay between attempts changes,
+	// either "fixed", "backoff", or "random".
+	RetryType string `json:"retryType" yaml:"retryType"`
+	// MaxOperationsPerTransaction is the maximum number of operations
+	// applied within a single transaction.
+	MaxOperationsPerTransaction int `json:"maxOperationsPerTransaction" yaml:"maxOperationsPerTransaction"`
+	// DisableDefaultLoggingActions indicates if the default logging actions,
+	// which are enabled by default, are disabled.
+	DisableDefaultLoggingActions bool `json:"disableDefaultLoggingActions" yaml:"disableDefaultLoggingActions"`
+}
+
+// Options provides the work unit options described by the configuration.
+func (c Config) Options() ([]work.UnitOption, error) {
+	var opts []work.UnitOption
+	if c.RetryAttempts > 0 {
+		opts = append(opts, work.UnitRetryAttempts(c.RetryAttempts))
+	}
+	if c.RetryDelay > 0 {
+		opts = append(opts, work.UnitRetryDelay(c.RetryDelay))
+	}
+	if c.RetryMaximumJitter > 0 {
+		opts = append(opts, work.UnitRetryMaximumJitter(c.RetryMaximumJitter))
+	}
+	if c.RetryType != "" {
+		retryType, ok := retryTypes[c.RetryType]
+		if !ok {
+			return nil, fmt.Errorf("workfx: unknown retry type %q", c.RetryType)
+		}
+		opts = append(opts, work.UnitRetryType(retryType))
+	}
+	if c.MaxOperationsPerTransaction > 0 {
+		opts = append(opts, work.UnitMaxOperationsPerTransaction(c.MaxOperationsPerTransaction))
+	}
+	if c.DisableDefaultLoggingActions {
+		opts = append(opts, work.DisableDefaultLoggingActions())
+	}
+	return opts, nil
+}
@@ -0,0 +1,28 @@
+module github.com/freerware/work/v4/workfx
+
+go 1.18
+
+replace github.com/freerware/work/v4 => ../
+
+require (
+	github.com/freerware/work/v4 v4.0.0
+	github.com/stretchr/testify v1.10.0
+	go.uber.org/fx v1.20.1
+	go.uber.org/zap v1.27.0
+)
+
+require (
+	github.com/avast/retry-go/v4 v4.6.0 // indirect
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/golang/mock v1.6.0 // indirect
+	github.com/google/uuid v1.6.0 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	github.com/sirupsen/logrus v1.9.3 // indirect
+	github.com/twmb/murmur3 v1.1.6 // indirect
+	github.com/uber-go/tally/v4 v4.1.16 // indirect
+	go.uber.org/atomic v1.10.0 // indirect
+	go.uber.org/dig v1.17.0 // indirect
+	go.uber.org/multierr v1.11.0 // indirect
+	golang.org/x/sys v0.0.0-20220715151400-c0bba94af5f8 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
@@ -0,0 +1,212 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package workfx wires work units into applications built with
+// go.uber.org/fx.
+//
+// The module provides a work.Uniter constructed from the work unit options
+// supplied to the application, along with those described by an optional
+// Config. Saves that are in flight when the application stops are drained
+// before the stop completes:
+//
+//	fx.New(
+//		workfx.Module,
+//		workfx.Options(work.UnitDB(db), work.UnitInsertFunc(ft, insert)),
+//		fx.Invoke(func(uniter work.Uniter) { ... }),
+//	)
+package workfx
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sync"
+
+	"github.com/freerware/work/v4"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+// ErrStopped represents the error that is returned when a work unit is
+// saved after the application has begun stopping.
+var ErrStopped = errors.New("workfx: unable to save work unit - application stopping")
+
+// optionsGroup is the value group the work unit options are provided to.
+const optionsGroup = "work.options"
+
+// Module provides a work.Uniter, along with the work.Registry containing
+// the named uniters provided by NamedUniter.
+var Module = fx.Module("work",
+	fx.Provide(NewUniter),
+	fx.Provide(fx.Annotate(work.NewRegistryOf, fx.ParamTags(`group:"work.uniters"`))),
+)
+
+// Options supplies the provided work unit options to the uniter provided by
+// Module.
+func Options(opts ...work.UnitOption) fx.Option {
+	supplied := make([]interface{}, 0, len(opts))
+	for _, opt := range opts {
+		supplied = append(supplied, fx.Annotated{Group: optionsGroup, Target: opt})
+	}
+	return fx.Supply(supplied...)
+}
+
+// NamedUniter provides a uniter constructed from the provided work unit
+// options, registered with the provided name within the work.Registry
+// provided by Module, and available as a work.Uniter annotated with the
+// provided name. Its saves in flight are drained when the application stops.
+func NamedUniter(name string, opts ...work.UnitOption) fx.Option {
+	constructor := func(lc fx.Lifecycle) (work.Uniter, work.NamedUniter) {
+		u := newUniter(lc, opts)
+		return u, work.NamedUniter{Name: name, Uniter: u}
+	}
+	return fx.Provide(fx.Annotate(constructor,
+		fx.ResultTags(`name:"`+name+`"`, `group:"work.uniters"`)))
+}
+
+// Params represents the dependencies of the uniter provided by Module.
+type Params struct {
+	fx.In
+
+	Lifecycle fx.Lifecycle
+	Options   []work.UnitOption         `group:"work.options"`
+	Config    *Config                   `optional:"true"`
+	DB        *sql.DB                   `optional:"true"`
+	Logger    *zap.Logger               `optional:"true"`
+	Metrics   work.UnitMetricsCollector `optional:"true"`
+}
+
+// NewUniter constructs a work.Uniter from the provided dependencies, whose
+// saves in flight are drained when the application stops.
+func NewUniter(p Params) (work.Uniter, error) {
+	var opts []work.UnitOption
+	if p.Config != nil {
+		configured, err := p.Config.Options()
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, configured...)
+	}
+	if p.DB != nil {
+		opts = append(opts, work.UnitDB(p.DB))
+	}
+	if p.Logger != nil {
+		opts = append(opts, work.UnitWithZapLogger(p.Logger))
+	}
+	if p.Metrics != nil {
+		opts = append(opts, work.UnitWithMetricsCollector(p.Metrics))
+	}
+	return newUniter(p.Lifecycle, append(opts, p.Options...)), nil
+}
+
+// newUniter constructs a uniter from the provided work unit options, whose
+// saves in flight are drained when the application stops.
+func newUniter(lc fx.Lifecycle, opts []work.UnitOption) *uniter {
+	u := &uniter{uniter: work.NewUniter(opts...), options: opts}
+	lc.Append(fx.Hook{OnStop: u.drain})
+	return u
+}
+
+// uniter represents a uniter tracking the saves of its work units in flight.
+type uniter struct {
+	uniter   work.Uniter
+	options  []work.UnitOption
+	mutex    sync.RWMutex
+	stopping bool
+	inFlight sync.WaitGroup
+}
+
+// Unit constructs a new work unit.
+func (u *uniter) Unit() (work.Unit, error) {
+	unit, err := u.uniter.Unit()
+	if err != nil {
+		return nil, err
+	}
+	return &drainedUnit{Unit: unit, uniter: u}, nil
+}
+
+// Verify verifies the configuration of the work units the uniter constructs.
+func (u *uniter) Verify(ctx context.Context) work.UniterReadiness {
+	return work.VerifyUnitOptions(ctx, u.options...)
+}
+
+// track marks the start of a save, returning the function marking its end.
+func (u *uniter) track() (func(), error) {
+	u.mutex.RLock()
+	defer u.mutex.RUnlock()
+	if u.stopping {
+		return nil, ErrStopped
+	}
+	u.inFlight.Add(1)
+	return u.inFlight.Done, nil
+}
+
+// drain prevents further saves, and awaits those in flight until the
+// provided context is done.
+func (u *uniter) drain(ctx context.Context) error {
+	u.mutex.Lock()
+	u.stopping = true
+	u.mutex.Unlock()
+
+	drained := make(chan struct{})
+	go func() {
+		defer close(drained)
+		u.inFlight.Wait()
+	}()
+	select {
+	case <-drained:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// drainedUnit represents a work unit whose saves are tracked by its uniter.
+type drainedUnit struct {
+	work.Unit
+
+	uniter *uniter
+}
+
+// Save commits the new additions, modifications, and removals within the
+// work unit to a persistent store.
+func (u *drainedUnit) Save(ctx context.Context, opts ...work.UnitSaveOption) error {
+	done, err := u.uniter.track()
+	if err != nil {
+		return err
+	}
+	defer done()
+	return u.Unit.Save(ctx, opts...)
+}
+
+// SaveBackground commits the new additions, modifications, and removals
+// within the work unit to a persistent store in the background.
+func (u *drainedUnit) SaveBackground() error {
+	return u.Save(context.Background())
+}
+
+// SaveAsync commits the new additions, modifications, and removals within
+// the work unit to a persistent store in a new goroutine. The save is
+// tracked before the goroutine starts, so that it is drained upon stopping.
+func (u *drainedUnit) SaveAsync(ctx context.Context) *work.UnitSaveFuture {
+	done, err := u.uniter.track()
+	if err != nil {
+		return work.NewUnitSaveFuture(ctx, func(context.Context) error { return err })
+	}
+	return work.NewUnitSaveFuture(ctx, func(ctx context.Context) error {
+		defer done()
+		return u.Unit.Save(ctx)
+	})
+}
@@ -0,0 +1,151 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package workfx_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/freerware/work/v4"
+	"github.com/freerware/work/v4/internal/test"
+	"github.com/freerware/work/v4/workfx"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/fx"
+	"go.uber.org/fx/fxtest"
+)
+
+type ModuleTestSuite struct {
+	suite.Suite
+
+	// dependencies.
+	inserted chan struct{}
+	release  chan struct{}
+	opts     []work.UnitOption
+}
+
+func TestModuleTestSuite(t *testing.T) {
+	suite.Run(t, new(ModuleTestSuite))
+}
+
+func (s *ModuleTestSuite) SetupTest() {
+	s.inserted, s.release = make(chan struct{}), make(chan struct{})
+	fooType := work.TypeNameOf(test.Foo{})
+	insert := func(ctx context.Context, mCtx work.UnitMapperContext, e ...interface{}) error {
+		close(s.inserted)
+		<-s.release
+		return nil
+	}
+	noop := func(ctx context.Context, mCtx work.UnitMapperContext, e ...interface{}) error {
+		return nil
+	}
+	s.opts = []work.UnitOption{
+		work.UnitInsertFunc(fooType, insert),
+		work.UnitDeleteFunc(fooType, noop),
+	}
+}
+
+func (s *ModuleTestSuite) TestModule_DrainsSaves() {
+	// arrange.
+	var uniter work.Uniter
+	app := fxtest.New(s.T(),
+		workfx.Module,
+		workfx.Options(s.opts...),
+		fx.Supply(&workfx.Config{RetryAttempts: 1}),
+		fx.Populate(&uniter),
+	)
+	app.RequireStart()
+	u, err := uniter.Unit()
+	s.Require().NoError(err)
+	s.Require().NoError(u.Add(context.Background(), test.Foo{ID: 28}))
+	saved := make(chan error)
+	go func() { saved <- u.Save(context.Background()) }()
+	<-s.inserted
+
+	// action.
+	stopped := make(chan error)
+	go func() { stopped <- app.Stop(context.Background()) }()
+
+	// assert.
+	select {
+	case <-stopped:
+		s.Fail("stopped before the save in flight completed")
+	case <-time.After(50 * time.Millisecond):
+	}
+	close(s.release)
+	s.NoError(<-saved)
+	s.NoError(<-stopped)
+	s.ErrorIs(u.Save(context.Background()), workfx.ErrStopped)
+}
+
+func (s *ModuleTestSuite) TestModule_NamedUniter() {
+	// arrange.
+	var params struct {
+		fx.In
+
+		Orders   work.Uniter `name:"orders"`
+		Registry *work.Registry
+	}
+
+	// action.
+	app := fxtest.New(s.T(),
+		workfx.Module,
+		workfx.NamedUniter("orders", s.opts...),
+		fx.Populate(&params),
+	)
+	app.RequireStart()
+	defer app.RequireStop()
+
+	// assert.
+	s.Equal([]string{"orders"}, params.Registry.Names())
+	orders, err := params.Registry.Get("orders")
+	s.Require().NoError(err)
+	s.Same(params.Orders, orders)
+}
+
+func (s *ModuleTestSuite) TestConfig_Options() {
+	// arrange.
+	tests := []struct {
+		name   string
+		config workfx.Config
+		count  int
+		err    bool
+	}{
+		{name: "Empty", config: workfx.Config{}, count: 0},
+		{
+			name: "Retries",
+			config: workfx.Config{
+				RetryAttempts: 3, RetryDelay: time.Second, RetryType: "backoff",
+			},
+			count: 3,
+		},
+		{name: "UnknownRetryType", config: workfx.Config{RetryType: "linear"}, err: true},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			// action.
+			opts, err := tt.config.Options()
+
+			// assert.
+			if tt.err {
+				s.Error(err)
+				return
+			}
+			s.Require().NoError(err)
+			s.Len(opts, tt.count)
+		})
+	}
+}
@@ -0,0 +1,58 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+// Package workgorm bridges GORM sessions into the transactions of work units.
+//
+// Session provides a GORM session that executes statements within the
+// transaction of the save attempt:
+//
+//	func (m FooMapper) Insert(ctx context.Context, mCtx work.UnitMapperContext, foos ...interface{}) error {
+//		models := make([]*Foo, 0, len(foos))
+//		for _, f := range foos {
+//			models = append(models, f.(*Foo))
+//		}
+//		return workgorm.Session(m.db, mCtx).WithContext(ctx).Create(&models).Error
+//	}
+package workgorm
+
+import (
+	"context"
+
+	"github.com/freerware/work/v4"
+	"gorm.io/gorm"
+)
+
+// Session provides a new session of the provided GORM database that executes
+// statements within the transaction of the provided mapper context,
+// detecting usages of the transaction after the save attempt has completed.
+// Statements executed by the session are not wrapped within transactions
+// of their own, as the transaction is owned by the work unit.
+func Session(db *gorm.DB, mCtx work.UnitMapperContext) *gorm.DB {
+	tx := db.Session(&gorm.Session{NewDB: true, SkipDefaultTransaction: true})
+	tx.Statement.ConnPool = mCtx
+	return tx
+}
+
+// MapperFunc performs a data mapping operation for the provided entities
+// with the provided GORM session.
+type MapperFunc func(context.Context, *gorm.DB, ...interface{}) error
+
+// Func adapts the provided function into a data mapper function, providing
+// it with a session of the provided GORM database for the transaction of
+// each save attempt.
+func Func(db *gorm.DB, f MapperFunc) work.UnitDataMapperFunc {
+	return func(ctx context.Context, mCtx work.UnitMapperContext, entities ...interface{}) error {
+		return f(ctx, Session(db, mCtx).WithContext(ctx), entities...)
+	}
+}
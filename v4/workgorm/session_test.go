@@ -0,0 +1,79 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package workgorm_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/freerware/work/v4"
+	"github.com/freerware/work/v4/workgorm"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+type Foo struct {
+	ID   int `gorm:"primaryKey;autoIncrement:false"`
+	Name string
+}
+
+type SessionTestSuite struct {
+	suite.Suite
+}
+
+func TestSessionTestSuite(t *testing.T) {
+	suite.Run(t, new(SessionTestSuite))
+}
+
+func (s *SessionTestSuite) TestFunc() {
+	// arrange.
+	ctx := context.Background()
+	db, mock, err := sqlmock.New()
+	s.Require().NoError(err)
+	defer db.Close()
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: db}), &gorm.Config{})
+	s.Require().NoError(err)
+	insert := workgorm.Func(gormDB, func(ctx context.Context, tx *gorm.DB, foos ...interface{}) error {
+		models := make([]*Foo, 0, len(foos))
+		for _, f := range foos {
+			models = append(models, f.(*Foo))
+		}
+		return tx.Create(&models).Error
+	})
+	noop := func(context.Context, work.UnitMapperContext, ...interface{}) error { return nil }
+	fooType := work.TypeNameOf(&Foo{})
+	sut, err := work.NewUnit(
+		work.UnitDB(db),
+		work.UnitInsertFunc(fooType, insert),
+		work.UnitUpdateFunc(fooType, noop),
+		work.UnitDeleteFunc(fooType, noop),
+	)
+	s.Require().NoError(err)
+	s.Require().NoError(sut.Add(ctx, &Foo{ID: 28, Name: "foo"}))
+	mock.ExpectBegin()
+	mock.ExpectExec(`^INSERT INTO "foos" \("id","name"\) VALUES \(\$1,\$2\)`).
+		WithArgs(28, "foo").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	// action.
+	err = sut.Save(ctx)
+
+	// assert.
+	s.Require().NoError(err)
+	s.NoError(mock.ExpectationsWereMet())
+}
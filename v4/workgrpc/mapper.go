@@ -0,0 +1,156 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package workgrpc provides a data mapper that applies the changes committed
+// by work units through a remote persistence service, such as one owned by
+// another team, implementing the DataMapper gRPC service defined in
+// mapperpb/mapper.proto:
+//
+//	m := workgrpc.NewMapper(conn)
+//	u, err := work.NewUnit(work.UnitDataMappers(map[work.TypeName]work.UnitDataMapper{
+//		work.TypeNameOf(Foo{}): m,
+//	}))
+package workgrpc
+
+//go:generate buf generate --template buf.gen.yaml
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/freerware/work/v4"
+	"github.com/freerware/work/v4/workgrpc/mapperpb"
+	"google.golang.org/grpc"
+)
+
+// Options represents the configuration options for the mapper.
+type Options struct {
+	marshal     func(interface{}) ([]byte, error)
+	batchSize   int
+	callOptions []grpc.CallOption
+}
+
+// Option applies an option to the provided configuration.
+type Option func(*Options)
+
+var (
+	// Codec defines the function serializing entities, which defaults to
+	// JSON.
+	Codec = func(marshal func(interface{}) ([]byte, error)) Option {
+		return func(o *Options) {
+			o.marshal = marshal
+		}
+	}
+
+	// BatchSize defines the maximum number of entities sent within each
+	// message of the stream.
+	BatchSize = func(size int) Option {
+		if size < 1 {
+			size = 1
+		}
+		return func(o *Options) {
+			o.batchSize = size
+		}
+	}
+
+	// CallOptions defines the options applied to each call.
+	CallOptions = func(opts ...grpc.CallOption) Option {
+		return func(o *Options) {
+			o.callOptions = append(o.callOptions, opts...)
+		}
+	}
+)
+
+// Mapper is a data mapper that streams entities to a remote persistence
+// service implementing the DataMapper gRPC service. Entities are sent in
+// batches of a single type, and each call fails should the service fail to
+// apply any of them.
+type Mapper struct {
+	client  mapperpb.DataMapperClient
+	options Options
+}
+
+// NewMapper constructs a mapper that streams entities over the provided
+// connection.
+func NewMapper(conn grpc.ClientConnInterface, opts ...Option) *Mapper {
+	o := Options{marshal: json.Marshal, batchSize: 100}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return &Mapper{client: mapperpb.NewDataMapperClient(conn), options: o}
+}
+
+type streamFunc func(context.Context, ...grpc.CallOption) (grpc.ClientStreamingClient[mapperpb.Entities, mapperpb.Result], error)
+
+// batches provides the provided entities serialized into batches of a single
+// type, in the order they were provided.
+func (m *Mapper) batches(mCtx work.UnitMapperContext, entities []interface{}) ([]*mapperpb.Entities, error) {
+	var batches []*mapperpb.Entities
+	var current *mapperpb.Entities
+	for _, entity := range entities {
+		t := work.TypeNameOf(entity).String()
+		if current == nil || current.TypeName != t || len(current.Entities) >= m.options.batchSize {
+			current = &mapperpb.Entities{SaveId: mCtx.SaveID, TypeName: t}
+			batches = append(batches, current)
+		}
+		b, err := m.options.marshal(entity)
+		if err != nil {
+			return nil, fmt.Errorf("unable to serialize %s: %w", t, err)
+		}
+		current.Entities = append(current.Entities, b)
+	}
+	return batches, nil
+}
+
+func (m *Mapper) stream(
+	ctx context.Context, mCtx work.UnitMapperContext, f streamFunc, entities []interface{}) error {
+	if len(entities) == 0 {
+		return nil
+	}
+	batches, err := m.batches(mCtx, entities)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream, err := f(ctx, m.options.callOptions...)
+	if err != nil {
+		return err
+	}
+	for _, batch := range batches {
+		if err = stream.Send(batch); err != nil {
+			// the status of the call is provided when receiving.
+			break
+		}
+	}
+	_, err = stream.CloseAndRecv()
+	return err
+}
+
+// Insert streams the provided entities to the service to be inserted.
+func (m *Mapper) Insert(ctx context.Context, mCtx work.UnitMapperContext, entities ...interface{}) error {
+	return m.stream(ctx, mCtx, m.client.Insert, entities)
+}
+
+// Update streams the provided entities to the service to be updated.
+func (m *Mapper) Update(ctx context.Context, mCtx work.UnitMapperContext, entities ...interface{}) error {
+	return m.stream(ctx, mCtx, m.client.Update, entities)
+}
+
+// Delete streams the provided entities to the service to be deleted.
+func (m *Mapper) Delete(ctx context.Context, mCtx work.UnitMapperContext, entities ...interface{}) error {
+	return m.stream(ctx, mCtx, m.client.Delete, entities)
+}
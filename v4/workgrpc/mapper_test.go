@@ -0,0 +1,190 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package workgrpc_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"sync"
+	"testing"
+
+	"github.com/freerware/work/v4"
+	"github.com/freerware/work/v4/internal/test"
+	"github.com/freerware/work/v4/workgrpc"
+	"github.com/freerware/work/v4/workgrpc/mapperpb"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type received struct {
+	method string
+	batch  *mapperpb.Entities
+}
+
+type server struct {
+	mapperpb.UnimplementedDataMapperServer
+
+	mutex    sync.Mutex
+	received []received
+	err      error
+}
+
+func (s *server) receive(method string, stream grpc.ClientStreamingServer[mapperpb.Entities, mapperpb.Result]) error {
+	var count int64
+	for {
+		batch, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		s.mutex.Lock()
+		s.received = append(s.received, received{method: method, batch: batch})
+		s.mutex.Unlock()
+		count = count + int64(len(batch.Entities))
+	}
+	if s.err != nil {
+		return s.err
+	}
+	return stream.SendAndClose(&mapperpb.Result{Count: count})
+}
+
+func (s *server) Insert(stream grpc.ClientStreamingServer[mapperpb.Entities, mapperpb.Result]) error {
+	return s.receive("insert", stream)
+}
+
+func (s *server) Update(stream grpc.ClientStreamingServer[mapperpb.Entities, mapperpb.Result]) error {
+	return s.receive("update", stream)
+}
+
+func (s *server) Delete(stream grpc.ClientStreamingServer[mapperpb.Entities, mapperpb.Result]) error {
+	return s.receive("delete", stream)
+}
+
+type MapperTestSuite struct {
+	suite.Suite
+
+	server *server
+	grpc   *grpc.Server
+	conn   *grpc.ClientConn
+}
+
+func TestMapperTestSuite(t *testing.T) {
+	suite.Run(t, new(MapperTestSuite))
+}
+
+func (s *MapperTestSuite) SetupTest() {
+	listener := bufconn.Listen(1024 * 1024)
+	s.server = &server{}
+	s.grpc = grpc.NewServer()
+	mapperpb.RegisterDataMapperServer(s.grpc, s.server)
+	go s.grpc.Serve(listener)
+	dial := func(ctx context.Context, _ string) (net.Conn, error) {
+		return listener.DialContext(ctx)
+	}
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(dial),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	s.Require().NoError(err)
+	s.conn = conn
+}
+
+func (s *MapperTestSuite) TearDownTest() {
+	s.conn.Close()
+	s.grpc.Stop()
+}
+
+func (s *MapperTestSuite) TestMapper_Insert() {
+	// arrange.
+	ctx := context.Background()
+	mCtx := work.UnitMapperContext{SaveID: "save"}
+	sut := workgrpc.NewMapper(s.conn, workgrpc.BatchSize(2))
+
+	// action.
+	err := sut.Insert(ctx, mCtx, test.Foo{ID: 1}, test.Foo{ID: 2}, test.Foo{ID: 3}, test.Bar{ID: "4"})
+
+	// assert.
+	s.Require().NoError(err)
+	s.Require().Len(s.server.received, 3)
+	fooType, barType := work.TypeNameOf(test.Foo{}).String(), work.TypeNameOf(test.Bar{}).String()
+	for i, expected := range []struct {
+		typeName string
+		count    int
+	}{{fooType, 2}, {fooType, 1}, {barType, 1}} {
+		r := s.server.received[i]
+		s.Equal("insert", r.method)
+		s.Equal("save", r.batch.SaveId)
+		s.Equal(expected.typeName, r.batch.TypeName)
+		s.Len(r.batch.Entities, expected.count)
+	}
+	var foo test.Foo
+	s.Require().NoError(json.Unmarshal(s.server.received[1].batch.Entities[0], &foo))
+	s.Equal(test.Foo{ID: 3}, foo)
+}
+
+func (s *MapperTestSuite) TestMapper_Error() {
+	// arrange.
+	ctx := context.Background()
+	s.server.err = status.Error(codes.Aborted, "whoa")
+	sut := workgrpc.NewMapper(s.conn)
+
+	// action.
+	err := sut.Update(ctx, work.UnitMapperContext{}, test.Foo{ID: 1})
+
+	// assert.
+	s.Equal(codes.Aborted, status.Code(err))
+	s.Require().Len(s.server.received, 1)
+	s.Equal("update", s.server.received[0].method)
+}
+
+func (s *MapperTestSuite) TestMapper_Empty() {
+	// action.
+	err := workgrpc.NewMapper(s.conn).Delete(context.Background(), work.UnitMapperContext{})
+
+	// assert.
+	s.NoError(err)
+	s.Empty(s.server.received)
+}
+
+func (s *MapperTestSuite) TestMapper_Unit() {
+	// arrange.
+	ctx := context.Background()
+	fooType := work.TypeNameOf(test.Foo{})
+	u, err := work.NewUnit(work.UnitDataMappers(map[work.TypeName]work.UnitDataMapper{
+		fooType: workgrpc.NewMapper(s.conn),
+	}))
+	s.Require().NoError(err)
+	s.Require().NoError(u.Register(ctx, test.Foo{ID: 2}))
+	s.Require().NoError(u.Add(ctx, test.Foo{ID: 1}))
+	s.Require().NoError(u.Remove(ctx, test.Foo{ID: 2}))
+
+	// action.
+	err = u.Save(ctx)
+
+	// assert.
+	s.Require().NoError(err)
+	s.Require().Len(s.server.received, 2)
+	s.Equal("insert", s.server.received[0].method)
+	s.Equal("delete", s.server.received[1].method)
+}
@@ -0,0 +1,218 @@
+// Copyright 2025 Freerware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.12
+// 	protoc        (unknown)
+// source: mapperpb/mapper.proto
+
+package mapperpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Entities is a batch of serialized entities of a single type.
+type Entities struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// save_id uniquely identifies the save of the work unit.
+	SaveId string `protobuf:"bytes,1,opt,name=save_id,json=saveId,proto3" json:"save_id,omitempty"`
+	// type_name is the type name of the entities.
+	TypeName string `protobuf:"bytes,2,opt,name=type_name,json=typeName,proto3" json:"type_name,omitempty"`
+	// entities are the serialized entities.
+	Entities      [][]byte `protobuf:"bytes,3,rep,name=entities,proto3" json:"entities,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Entities) Reset() {
+	*x = Entities{}
+	mi := &file_mapperpb_mapper_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Entities) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Entities) ProtoMessage() {}
+
+func (x *Entities) ProtoReflect() protoreflect.Message {
+	mi := &file_mapperpb_mapper_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Entities.ProtoReflect.Descriptor instead.
+func (*Entities) Descriptor() ([]byte, []int) {
+	return file_mapperpb_mapper_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Entities) GetSaveId() string {
+	if x != nil {
+		return x.SaveId
+	}
+	return ""
+}
+
+func (x *Entities) GetTypeName() string {
+	if x != nil {
+		return x.TypeName
+	}
+	return ""
+}
+
+func (x *Entities) GetEntities() [][]byte {
+	if x != nil {
+		return x.Entities
+	}
+	return nil
+}
+
+// Result is the outcome of applying the streamed entities.
+type Result struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// count is the number of entities applied.
+	Count         int64 `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Result) Reset() {
+	*x = Result{}
+	mi := &file_mapperpb_mapper_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Result) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Result) ProtoMessage() {}
+
+func (x *Result) ProtoReflect() protoreflect.Message {
+	mi := &file_mapperpb_mapper_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Result.ProtoReflect.Descriptor instead.
+func (*Result) Descriptor() ([]byte, []int) {
+	return file_mapperpb_mapper_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Result) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+var File_mapperpb_mapper_proto protoreflect.FileDescriptor
+
+const file_mapperpb_mapper_proto_rawDesc = "" +
+	"\n" +
+	"\x15mapperpb/mapper.proto\x12\x11freerware.work.v1\"\\\n" +
+	"\bEntities\x12\x17\n" +
+	"\asave_id\x18\x01 \x01(\tR\x06saveId\x12\x1b\n" +
+	"\ttype_name\x18\x02 \x01(\tR\btypeName\x12\x1a\n" +
+	"\bentities\x18\x03 \x03(\fR\bentities\"\x1e\n" +
+	"\x06Result\x12\x14\n" +
+	"\x05count\x18\x01 \x01(\x03R\x05count2\xd8\x01\n" +
+	"\n" +
+	"DataMapper\x12B\n" +
+	"\x06Insert\x12\x1b.freerware.work.v1.Entities\x1a\x19.freerware.work.v1.Result(\x01\x12B\n" +
+	"\x06Update\x12\x1b.freerware.work.v1.Entities\x1a\x19.freerware.work.v1.Result(\x01\x12B\n" +
+	"\x06Delete\x12\x1b.freerware.work.v1.Entities\x1a\x19.freerware.work.v1.Result(\x01B0Z.github.com/freerware/work/v4/workgrpc/mapperpbb\x06proto3"
+
+var (
+	file_mapperpb_mapper_proto_rawDescOnce sync.Once
+	file_mapperpb_mapper_proto_rawDescData []byte
+)
+
+func file_mapperpb_mapper_proto_rawDescGZIP() []byte {
+	file_mapperpb_mapper_proto_rawDescOnce.Do(func() {
+		file_mapperpb_mapper_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_mapperpb_mapper_proto_rawDesc), len(file_mapperpb_mapper_proto_rawDesc)))
+	})
+	return file_mapperpb_mapper_proto_rawDescData
+}
+
+var file_mapperpb_mapper_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_mapperpb_mapper_proto_goTypes = []any{
+	(*Entities)(nil), // 0: freerware.work.v1.Entities
+	(*Result)(nil),   // 1: freerware.work.v1.Result
+}
+var file_mapperpb_mapper_proto_depIdxs = []int32{
+	0, // 0: freerware.work.v1.DataMapper.Insert:input_type -> freerware.work.v1.Entities
+	0, // 1: freerware.work.v1.DataMapper.Update:input_type -> freerware.work.v1.Entities
+	0, // 2: freerware.work.v1.DataMapper.Delete:input_type -> freerware.work.v1.Entities
+	1, // 3: freerware.work.v1.DataMapper.Insert:output_type -> freerware.work.v1.Result
+	1, // 4: freerware.work.v1.DataMapper.Update:output_type -> freerware.work.v1.Result
+	1, // 5: freerware.work.v1.DataMapper.Delete:output_type -> freerware.work.v1.Result
+	3, // [3:6] is the sub-list for method output_type
+	0, // [0:3] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_mapperpb_mapper_proto_init() }
+func file_mapperpb_mapper_proto_init() {
+	if File_mapperpb_mapper_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_mapperpb_mapper_proto_rawDesc), len(file_mapperpb_mapper_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_mapperpb_mapper_proto_goTypes,
+		DependencyIndexes: file_mapperpb_mapper_proto_depIdxs,
+		MessageInfos:      file_mapperpb_mapper_proto_msgTypes,
+	}.Build()
+	File_mapperpb_mapper_proto = out.File
+	file_mapperpb_mapper_proto_goTypes = nil
+	file_mapperpb_mapper_proto_depIdxs = nil
+}
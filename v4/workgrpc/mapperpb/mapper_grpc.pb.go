@@ -0,0 +1,206 @@
+// Copyright 2025 Freerware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: mapperpb/mapper.proto
+
+package mapperpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	DataMapper_Insert_FullMethodName = "/freerware.work.v1.DataMapper/Insert"
+	DataMapper_Update_FullMethodName = "/freerware.work.v1.DataMapper/Update"
+	DataMapper_Delete_FullMethodName = "/freerware.work.v1.DataMapper/Delete"
+)
+
+// DataMapperClient is the client API for DataMapper service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// DataMapper applies the changes committed by work units on behalf of a
+// remote persistence service. Entities are streamed in batches, and the
+// response is sent once every batch has been applied. Failing any batch
+// fails the entire call.
+type DataMapperClient interface {
+	// Insert inserts the streamed entities.
+	Insert(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[Entities, Result], error)
+	// Update updates the streamed entities.
+	Update(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[Entities, Result], error)
+	// Delete deletes the streamed entities.
+	Delete(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[Entities, Result], error)
+}
+
+type dataMapperClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewDataMapperClient(cc grpc.ClientConnInterface) DataMapperClient {
+	return &dataMapperClient{cc}
+}
+
+func (c *dataMapperClient) Insert(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[Entities, Result], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &DataMapper_ServiceDesc.Streams[0], DataMapper_Insert_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[Entities, Result]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DataMapper_InsertClient = grpc.ClientStreamingClient[Entities, Result]
+
+func (c *dataMapperClient) Update(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[Entities, Result], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &DataMapper_ServiceDesc.Streams[1], DataMapper_Update_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[Entities, Result]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DataMapper_UpdateClient = grpc.ClientStreamingClient[Entities, Result]
+
+func (c *dataMapperClient) Delete(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[Entities, Result], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &DataMapper_ServiceDesc.Streams[2], DataMapper_Delete_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[Entities, Result]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DataMapper_DeleteClient = grpc.ClientStreamingClient[Entities, Result]
+
+// DataMapperServer is the server API for DataMapper service.
+// All implementations must embed UnimplementedDataMapperServer
+// for forward compatibility.
+//
+// DataMapper applies the changes committed by work units on behalf of a
+// remote persistence service. Entities are streamed in batches, and the
+// response is sent once every batch has been applied. Failing any batch
+// fails the entire call.
+type DataMapperServer interface {
+	// Insert inserts the streamed entities.
+	Insert(grpc.ClientStreamingServer[Entities, Result]) error
+	// Update updates the streamed entities.
+	Update(grpc.ClientStreamingServer[Entities, Result]) error
+	// Delete deletes the streamed entities.
+	Delete(grpc.ClientStreamingServer[Entities, Result]) error
+	mustEmbedUnimplementedDataMapperServer()
+}
+
+// UnimplementedDataMapperServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedDataMapperServer struct{}
+
+func (UnimplementedDataMapperServer) Insert(grpc.ClientStreamingServer[Entities, Result]) error {
+	return status.Errorf(codes.Unimplemented, "method Insert not implemented")
+}
+func (UnimplementedDataMapperServer) Update(grpc.ClientStreamingServer[Entities, Result]) error {
+	return status.Errorf(codes.Unimplemented, "method Update not implemented")
+}
+func (UnimplementedDataMapperServer) Delete(grpc.ClientStreamingServer[Entities, Result]) error {
+	return status.Errorf(codes.Unimplemented, "method Delete not implemented")
+}
+func (UnimplementedDataMapperServer) mustEmbedUnimplementedDataMapperServer() {}
+func (UnimplementedDataMapperServer) testEmbeddedByValue()                    {}
+
+// UnsafeDataMapperServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to DataMapperServer will
+// result in compilation errors.
+type UnsafeDataMapperServer interface {
+	mustEmbedUnimplementedDataMapperServer()
+}
+
+func RegisterDataMapperServer(s grpc.ServiceRegistrar, srv DataMapperServer) {
+	// If the following call pancis, it indicates UnimplementedDataMapperServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&DataMapper_ServiceDesc, srv)
+}
+
+func _DataMapper_Insert_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(DataMapperServer).Insert(&grpc.GenericServerStream[Entities, Result]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DataMapper_InsertServer = grpc.ClientStreamingServer[Entities, Result]
+
+func _DataMapper_Update_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(DataMapperServer).Update(&grpc.GenericServerStream[Entities, Result]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DataMapper_UpdateServer = grpc.ClientStreamingServer[Entities, Result]
+
+func _DataMapper_Delete_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(DataMapperServer).Delete(&grpc.GenericServerStream[Entities, Result]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DataMapper_DeleteServer = grpc.ClientStreamingServer[Entities, Result]
+
+// DataMapper_ServiceDesc is the grpc.ServiceDesc for DataMapper service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var DataMapper_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "freerware.work.v1.DataMapper",
+	HandlerType: (*DataMapperServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Insert",
+			Handler:       _DataMapper_Insert_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "Update",
+			Handler:       _DataMapper_Update_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "Delete",
+			Handler:       _DataMapper_Delete_Handler,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "mapperpb/mapper.proto",
+}
@@ -0,0 +1,501 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package workhook notifies external systems, such as workflow engines and
+// audit collectors, of the outcomes of work units by POSTing signed JSON
+// summaries to webhook URLs, without requiring a message broker:
+//
+//	n := workhook.NewNotifier(secret, []string{"https://audit.example.com/hooks/work"})
+//	u, err := work.NewUnit(append(opts, n.Options()...)...)
+//
+// Summaries are delivered asynchronously, so Close should be called upon
+// shutdown to deliver those that are pending. Each summary is signed with
+// HMAC-SHA256 using the provided secret, along with the time it was sent,
+// which receivers verify with Verify.
+package workhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/avast/retry-go/v4"
+	"github.com/freerware/work/v4"
+	"github.com/freerware/work/v4/workdlq"
+	"github.com/google/uuid"
+	"go.uber.org/multierr"
+)
+
+var (
+	// ErrDeliveryFailed represents the error that is returned when a webhook
+	// responds with an unsuccessful status.
+	ErrDeliveryFailed = errors.New("webhook delivery failed")
+
+	// ErrQueueFull represents the error that is reported when the outcome of
+	// a work unit is dropped because the notifications pending delivery have
+	// reached the size of the queue.
+	ErrQueueFull = errors.New("webhook notification queue is full")
+
+	// ErrNotifierClosed represents the error that is reported when the
+	// outcome of a work unit is dropped because the notifier is closed.
+	ErrNotifierClosed = errors.New("webhook notifier is closed")
+)
+
+// SignatureHeader is the header carrying the signature of each summary,
+// formatted as "sha256=" followed by the hex encoded HMAC-SHA256 of the
+// value of the TimestampHeader, a period, and the request body.
+const SignatureHeader = "X-Work-Signature"
+
+// TimestampHeader is the header carrying the time each summary was sent, as
+// the number of seconds since the Unix epoch, which is covered by the
+// signature so that receivers can reject replayed summaries.
+const TimestampHeader = "X-Work-Timestamp"
+
+// DeadLetterKind is the kind of the letters published to a dead letter queue
+// for deliveries that could not be made.
+const DeadLetterKind = "workhook.delivery"
+
+// Event represents the outcome of a work unit.
+type Event string
+
+// The outcomes of work units that are notified.
+const (
+	// EventSaved indicates the work unit was saved successfully.
+	EventSaved Event = "saved"
+	// EventSaveFailed indicates the work unit failed to save.
+	EventSaveFailed Event = "save_failed"
+	// EventRolledBack indicates the changes applied while saving the work
+	// unit were rolled back successfully.
+	EventRolledBack Event = "rolled_back"
+)
+
+// SaveCounts represents the number of entities staged within a work unit.
+type SaveCounts struct {
+	Additions       int `json:"additions"`
+	Alterations     int `json:"alterations"`
+	Removals        int `json:"removals"`
+	Registers       int `json:"registers"`
+	Upserts         int `json:"upserts"`
+	Patches         int `json:"patches"`
+	RemovalCriteria int `json:"removalCriteria"`
+}
+
+// SaveResult represents the summary of the outcome of a work unit that is
+// sent to webhooks.
+type SaveResult struct {
+	// ID uniquely identifies the notification, and remains the same across
+	// delivery attempts so that receivers can discard duplicates.
+	ID string `json:"id"`
+	// Event is the outcome of the work unit.
+	Event Event `json:"event"`
+	// Time is when the outcome occurred.
+	Time time.Time `json:"time"`
+	// DurationMS is the time taken to save the work unit, in milliseconds,
+	// which is omitted for rollbacks.
+	DurationMS int64 `json:"durationMs,omitempty"`
+	// Error is the message of the error that failed the save or triggered
+	// the rollback, if any.
+	Error string `json:"error,omitempty"`
+	// Attempts are the messages of the errors of each failed attempt to save
+	// the work unit, in the order they were attempted, when the work unit
+	// collects its retry history.
+	Attempts []string `json:"attempts,omitempty"`
+	// Types are the names of the entity types staged for modification, in
+	// sorted order.
+	Types []string `json:"types"`
+	// Counts are the number of entities staged within the work unit.
+	Counts SaveCounts `json:"counts"`
+}
+
+// Options represents the configuration options for the notifier.
+type Options struct {
+	client        *http.Client
+	header        http.Header
+	retryAttempts int
+	retryDelay    time.Duration
+	timeout       time.Duration
+	queue         *workdlq.Queue
+	queueSize     int
+}
+
+// Option applies an option to the provided configuration.
+type Option func(*Options)
+
+var (
+	// HTTPClient defines the client issuing the webhook requests, which
+	// defaults to http.DefaultClient.
+	HTTPClient = func(client *http.Client) Option {
+		return func(o *Options) {
+			o.client = client
+		}
+	}
+
+	// Header defines the headers included with each webhook request.
+	Header = func(header http.Header) Option {
+		return func(o *Options) {
+			o.header = header
+		}
+	}
+
+	// RetryAttempts defines the number of attempts to perform for each
+	// webhook.
+	RetryAttempts = func(attempts int) Option {
+		if attempts < 1 {
+			attempts = 1
+		}
+		return func(o *Options) {
+			o.retryAttempts = attempts
+		}
+	}
+
+	// RetryDelay defines the delay between delivery attempts.
+	RetryDelay = func(delay time.Duration) Option {
+		return func(o *Options) {
+			o.retryDelay = delay
+		}
+	}
+
+	// Timeout defines the maximum duration to spend notifying the webhooks
+	// of a single outcome, including retries.
+	Timeout = func(timeout time.Duration) Option {
+		return func(o *Options) {
+			o.timeout = timeout
+		}
+	}
+
+	// QueueSize defines the maximum number of outcomes pending delivery by
+	// the actions of the notifier, which defaults to 1024. Outcomes occurring
+	// while the queue is full are published to the dead letter queue, if
+	// any, and are otherwise dropped.
+	QueueSize = func(size int) Option {
+		if size < 1 {
+			size = 1
+		}
+		return func(o *Options) {
+			o.queueSize = size
+		}
+	}
+
+	// DeadLetterQueue defines the queue the deliveries that could not be
+	// made after exhausting all retry attempts are published to, which are
+	// replayed with Replay once registered with the queue:
+	//
+	//	q.Handle(workhook.DeadLetterKind, n.Replay)
+	DeadLetterQueue = func(q *workdlq.Queue) Option {
+		return func(o *Options) {
+			o.queue = q
+		}
+	}
+)
+
+// Delivery represents a summary to deliver to a webhook, as published to a
+// dead letter queue.
+type Delivery struct {
+	// URL is the URL of the webhook.
+	URL string `json:"url"`
+	// Body is the JSON encoded summary.
+	Body json.RawMessage `json:"body"`
+}
+
+// notification represents an outcome pending delivery by the actions of the
+// notifier.
+type notification struct {
+	actionCtx work.UnitActionContext
+	result    SaveResult
+}
+
+// Notifier notifies webhooks of the outcomes of work units.
+type Notifier struct {
+	secret  []byte
+	urls    []string
+	options Options
+
+	mutex   sync.RWMutex
+	closed  bool
+	pending chan notification
+	stopped chan struct{}
+}
+
+// NewNotifier constructs a notifier that POSTs the outcomes of work units to
+// the provided webhook URLs, signed with the provided secret.
+func NewNotifier(secret []byte, urls []string, opts ...Option) *Notifier {
+	o := Options{
+		client:        http.DefaultClient,
+		retryAttempts: 3,
+		retryDelay:    100 * time.Millisecond,
+		timeout:       30 * time.Second,
+		queueSize:     1024,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	n := &Notifier{
+		secret:  secret,
+		urls:    urls,
+		options: o,
+		pending: make(chan notification, o.queueSize),
+		stopped: make(chan struct{}),
+	}
+	go n.run()
+	return n
+}
+
+// Sign provides the signature of the provided body sent at the provided
+// timestamp with the provided secret, as carried by the SignatureHeader.
+func Sign(secret []byte, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify determines if the provided signature is the signature of the
+// provided body sent at the provided timestamp with the provided secret, and
+// that the timestamp is within the provided tolerance of the current time.
+func Verify(secret, body []byte, timestamp, signature string, tolerance time.Duration) bool {
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return false
+	}
+	if age := time.Since(time.Unix(seconds, 0)); age > tolerance || age < -tolerance {
+		return false
+	}
+	return hmac.Equal([]byte(Sign(secret, timestamp, body)), []byte(signature))
+}
+
+func (n *Notifier) deliver(ctx context.Context, url string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return retry.Unrecoverable(err)
+	}
+	for k, v := range n.options.header {
+		req.Header[k] = v
+	}
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(TimestampHeader, timestamp)
+	req.Header.Set(SignatureHeader, Sign(n.secret, timestamp, body))
+	resp, err := n.options.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		err = fmt.Errorf("%w: %s: unexpected status %d", ErrDeliveryFailed, url, resp.StatusCode)
+		// client errors other than throttling are not retried.
+		if resp.StatusCode < http.StatusInternalServerError && resp.StatusCode != http.StatusTooManyRequests {
+			return retry.Unrecoverable(err)
+		}
+	}
+	return err
+}
+
+func (n *Notifier) deliverWithRetry(ctx context.Context, url string, body []byte) error {
+	return retry.Do(func() error {
+		return n.deliver(ctx, url, body)
+	},
+		retry.Context(ctx),
+		retry.Attempts(uint(n.options.retryAttempts)),
+		retry.Delay(n.options.retryDelay),
+		retry.DelayType(retry.BackOffDelay),
+		retry.LastErrorOnly(true),
+	)
+}
+
+// Notify POSTs the provided result to each webhook, retrying upon failure.
+// Should all attempts for a webhook fail, the delivery is published to the
+// dead letter queue, if any.
+func (n *Notifier) Notify(ctx context.Context, result SaveResult) error {
+	body, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+	var errs error
+	for _, url := range n.urls {
+		err := n.deliverWithRetry(ctx, url, body)
+		if err != nil {
+			err = multierr.Append(err, n.deadLetter(url, body, err))
+		}
+		errs = multierr.Append(errs, err)
+	}
+	return errs
+}
+
+// deadLetter publishes the provided delivery that could not be made due to
+// the provided error to the dead letter queue, if any.
+func (n *Notifier) deadLetter(url string, body []byte, cause error) error {
+	if n.options.queue == nil {
+		return nil
+	}
+	// the context of the notification is likely done once the retries are
+	// exhausted, so the delivery is published with its own.
+	ctx, cancel := context.WithTimeout(context.Background(), n.options.timeout)
+	defer cancel()
+	return n.options.queue.Publish(ctx, DeadLetterKind, Delivery{URL: url, Body: body}, cause)
+}
+
+// Replay POSTs the JSON encoded delivery of a letter published to a dead
+// letter queue, retrying upon failure. The summary is signed anew, so that
+// receivers verify it with the current secret.
+func (n *Notifier) Replay(ctx context.Context, payload []byte) error {
+	var d Delivery
+	if err := json.Unmarshal(payload, &d); err != nil {
+		return err
+	}
+	return n.deliverWithRetry(ctx, d.URL, d.Body)
+}
+
+func result(event Event, actionCtx work.UnitActionContext, err error) SaveResult {
+	changes := actionCtx.Changes()
+	types := make(map[string]struct{})
+	for _, staged := range []map[work.TypeName][]interface{}{
+		changes.Additions, changes.Alterations, changes.Removals, changes.Upserts,
+	} {
+		for t := range staged {
+			types[t.String()] = struct{}{}
+		}
+	}
+	for t := range changes.Patches {
+		types[t.String()] = struct{}{}
+	}
+	r := SaveResult{
+		ID:    uuid.NewString(),
+		Event: event,
+		Time:  time.Now().UTC(),
+		Types: make([]string, 0, len(types)),
+		Counts: SaveCounts{
+			Additions:       actionCtx.AdditionCount,
+			Alterations:     actionCtx.AlterationCount,
+			Removals:        actionCtx.RemovalCount,
+			Registers:       actionCtx.RegisterCount,
+			Upserts:         actionCtx.UpsertCount,
+			Patches:         actionCtx.PatchCount,
+			RemovalCriteria: actionCtx.RemovalCriteriaCount,
+		},
+	}
+	for t := range types {
+		r.Types = append(r.Types, t)
+	}
+	sort.Strings(r.Types)
+	if err != nil {
+		r.Error = err.Error()
+	}
+	var retryErr *work.UnitRetryError
+	if errors.As(err, &retryErr) {
+		r.Attempts = retryErr.Errors()
+	}
+	return r
+}
+
+// run delivers the outcomes pending delivery until the notifier is closed.
+func (n *Notifier) run() {
+	defer close(n.stopped)
+	for p := range n.pending {
+		ctx, cancel := context.WithTimeout(context.Background(), n.options.timeout)
+		if err := n.Notify(ctx, p.result); err != nil && p.actionCtx.Logger != nil {
+			p.actionCtx.Logger.Error("unable to notify webhooks", "event", string(p.result.Event), "error", err.Error())
+		}
+		cancel()
+	}
+}
+
+// notify queues the provided outcome for delivery, without blocking the work
+// unit. Outcomes that cannot be queued are published to the dead letter
+// queue, if any, and are otherwise dropped.
+func (n *Notifier) notify(actionCtx work.UnitActionContext, r SaveResult) {
+	n.mutex.RLock()
+	err := ErrNotifierClosed
+	if !n.closed {
+		select {
+		case n.pending <- notification{actionCtx: actionCtx, result: r}:
+			err = nil
+		default:
+			err = ErrQueueFull
+		}
+	}
+	n.mutex.RUnlock()
+	if err == nil {
+		return
+	}
+	if body, marshalErr := json.Marshal(r); marshalErr == nil {
+		for _, url := range n.urls {
+			err = multierr.Append(err, n.deadLetter(url, body, err))
+		}
+	}
+	if actionCtx.Logger != nil {
+		actionCtx.Logger.Error("unable to notify webhooks", "event", string(r.Event), "error", err.Error())
+	}
+}
+
+// Close stops accepting the outcomes of work units, and blocks until those
+// pending delivery are delivered, or until the provided context is done.
+func (n *Notifier) Close(ctx context.Context) error {
+	n.mutex.Lock()
+	if !n.closed {
+		n.closed = true
+		close(n.pending)
+	}
+	n.mutex.Unlock()
+	select {
+	case <-n.stopped:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// SaveAction provides the action that notifies the webhooks once a work unit
+// is saved, whether successfully or not. The webhooks are notified
+// asynchronously, and failures are logged with the logger of the work unit.
+func (n *Notifier) SaveAction() work.UnitSaveAction {
+	return func(actionCtx work.UnitSaveActionContext) {
+		event := EventSaved
+		if actionCtx.Err != nil {
+			event = EventSaveFailed
+		}
+		r := result(event, actionCtx.UnitActionContext, actionCtx.Err)
+		r.DurationMS = actionCtx.Duration.Milliseconds()
+		n.notify(actionCtx.UnitActionContext, r)
+	}
+}
+
+// RollbackAction provides the action that notifies the webhooks once a work
+// unit is rolled back. The webhooks are notified asynchronously, and failures
+// are logged with the logger of the work unit.
+func (n *Notifier) RollbackAction() work.UnitRollbackAction {
+	return func(actionCtx work.UnitRollbackActionContext) {
+		n.notify(actionCtx.UnitActionContext, result(EventRolledBack, actionCtx.UnitActionContext, actionCtx.Err))
+	}
+}
+
+// Options provides the options for work units that notify the webhooks of
+// both their saves and rollbacks.
+func (n *Notifier) Options() []work.UnitOption {
+	return []work.UnitOption{
+		work.UnitSaveActions(n.SaveAction()),
+		work.UnitRollbackActions(n.RollbackAction()),
+	}
+}
@@ -0,0 +1,275 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package workhook_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/freerware/work/v4"
+	"github.com/freerware/work/v4/internal/test"
+	"github.com/freerware/work/v4/workdlq"
+	"github.com/freerware/work/v4/workhook"
+	"github.com/stretchr/testify/suite"
+)
+
+type NotifierTestSuite struct {
+	suite.Suite
+
+	server   *httptest.Server
+	mutex    sync.Mutex
+	results  []workhook.SaveResult
+	statuses []int
+	secret   []byte
+}
+
+func TestNotifierTestSuite(t *testing.T) {
+	suite.Run(t, new(NotifierTestSuite))
+}
+
+func (s *NotifierTestSuite) SetupTest() {
+	s.results = nil
+	s.statuses = nil
+	s.secret = []byte("secret")
+	s.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+		body, _ := io.ReadAll(r.Body)
+		s.True(workhook.Verify(s.secret, body,
+			r.Header.Get(workhook.TimestampHeader), r.Header.Get(workhook.SignatureHeader), time.Minute))
+		s.Equal("application/json", r.Header.Get("Content-Type"))
+		var result workhook.SaveResult
+		s.Require().NoError(json.Unmarshal(body, &result))
+		s.results = append(s.results, result)
+		if len(s.statuses) > 0 {
+			w.WriteHeader(s.statuses[0])
+			s.statuses = s.statuses[1:]
+		}
+	}))
+}
+
+func (s *NotifierTestSuite) TearDownTest() {
+	s.server.Close()
+}
+
+func (s *NotifierTestSuite) unit(
+	insert work.UnitDataMapperFunc, n *workhook.Notifier, opts ...work.UnitOption) work.Unit {
+	noop := func(context.Context, work.UnitMapperContext, ...interface{}) error { return nil }
+	fooType := work.TypeNameOf(test.Foo{})
+	opts = append([]work.UnitOption{
+		work.UnitInsertFunc(fooType, insert),
+		work.UnitUpdateFunc(fooType, noop),
+		work.UnitDeleteFunc(fooType, noop),
+		work.UnitRetryAttempts(1),
+	}, append(opts, n.Options()...)...)
+	u, err := work.NewUnit(opts...)
+	s.Require().NoError(err)
+	return u
+}
+
+func (s *NotifierTestSuite) TestNotifier_Saved() {
+	// arrange.
+	ctx := context.Background()
+	noop := func(context.Context, work.UnitMapperContext, ...interface{}) error { return nil }
+	n := workhook.NewNotifier(s.secret, []string{s.server.URL})
+	u := s.unit(noop, n)
+	s.Require().NoError(u.Add(ctx, test.Foo{ID: 1}, test.Foo{ID: 2}))
+
+	// action.
+	err := u.Save(ctx)
+	s.Require().NoError(n.Close(ctx))
+
+	// assert.
+	s.Require().NoError(err)
+	s.Require().Len(s.results, 1)
+	r := s.results[0]
+	s.Equal(workhook.EventSaved, r.Event)
+	s.NotEmpty(r.ID)
+	s.Empty(r.Error)
+	s.Equal([]string{work.TypeNameOf(test.Foo{}).String()}, r.Types)
+	s.Equal(2, r.Counts.Additions)
+}
+
+func (s *NotifierTestSuite) TestNotifier_RolledBack() {
+	// arrange.
+	ctx := context.Background()
+	failing := func(context.Context, work.UnitMapperContext, ...interface{}) error { return errors.New("whoa") }
+	n := workhook.NewNotifier(s.secret, []string{s.server.URL})
+	u := s.unit(failing, n)
+	s.Require().NoError(u.Add(ctx, test.Foo{ID: 1}))
+
+	// action.
+	err := u.Save(ctx)
+	s.Require().NoError(n.Close(ctx))
+
+	// assert.
+	s.Require().Error(err)
+	s.Require().Len(s.results, 2)
+	s.Equal(workhook.EventRolledBack, s.results[0].Event)
+	s.Equal("whoa", s.results[0].Error)
+	s.Equal(workhook.EventSaveFailed, s.results[1].Event)
+	s.Equal("whoa", s.results[1].Error)
+}
+
+func (s *NotifierTestSuite) TestNotifier_SaveFailed_RetryHistory() {
+	// arrange.
+	ctx := context.Background()
+	errs := []error{errors.New("timeout"), errors.New("deadlock")}
+	failing := func(context.Context, work.UnitMapperContext, ...interface{}) (err error) {
+		err, errs = errs[0], errs[1:]
+		return
+	}
+	n := workhook.NewNotifier(s.secret, []string{s.server.URL})
+	u := s.unit(failing, n, work.UnitRetryAttempts(2), work.UnitRetryHistory(0))
+	s.Require().NoError(u.Add(ctx, test.Foo{ID: 1}))
+
+	// action.
+	err := u.Save(ctx)
+	s.Require().NoError(n.Close(ctx))
+
+	// assert.
+	s.Require().Error(err)
+	r := s.results[len(s.results)-1]
+	s.Equal(workhook.EventSaveFailed, r.Event)
+	s.Equal([]string{"timeout", "deadlock"}, r.Attempts)
+}
+
+func (s *NotifierTestSuite) TestNotifier_Notify_Retry() {
+	// arrange.
+	s.statuses = []int{http.StatusServiceUnavailable, http.StatusOK}
+	sut := workhook.NewNotifier(s.secret, []string{s.server.URL}, workhook.RetryDelay(time.Millisecond))
+	result := workhook.SaveResult{ID: "1", Event: workhook.EventSaved}
+
+	// action.
+	err := sut.Notify(context.Background(), result)
+
+	// assert.
+	s.Require().NoError(err)
+	s.Require().Len(s.results, 2)
+	s.Equal(s.results[0].ID, s.results[1].ID)
+}
+
+func (s *NotifierTestSuite) TestNotifier_Notify_ClientError() {
+	// arrange.
+	s.statuses = []int{http.StatusBadRequest}
+	sut := workhook.NewNotifier(s.secret, []string{s.server.URL}, workhook.RetryDelay(time.Millisecond))
+
+	// action.
+	err := sut.Notify(context.Background(), workhook.SaveResult{ID: "1"})
+
+	// assert.
+	s.ErrorIs(err, workhook.ErrDeliveryFailed)
+	s.Len(s.results, 1)
+}
+
+func (s *NotifierTestSuite) TestNotifier_Notify_DeadLetterQueue() {
+	// arrange.
+	ctx := context.Background()
+	s.statuses = []int{http.StatusServiceUnavailable}
+	q := workdlq.NewQueue(workdlq.NewMemoryStore())
+	sut := workhook.NewNotifier(s.secret, []string{s.server.URL},
+		workhook.RetryAttempts(1),
+		workhook.DeadLetterQueue(q),
+	)
+	q.Handle(workhook.DeadLetterKind, sut.Replay)
+	result := workhook.SaveResult{ID: "1", Event: workhook.EventSaved}
+	s.Require().ErrorIs(sut.Notify(ctx, result), workhook.ErrDeliveryFailed)
+
+	// action.
+	report, err := q.Replay(ctx, 10)
+
+	// assert.
+	s.Require().NoError(err)
+	s.Equal(workdlq.ReplayReport{Replayed: 1}, report)
+	s.Require().Len(s.results, 2)
+	s.Equal(s.results[0], s.results[1])
+}
+
+func (s *NotifierTestSuite) TestNotifier_QueueFull() {
+	// arrange.
+	ctx := context.Background()
+	noop := func(context.Context, work.UnitMapperContext, ...interface{}) error { return nil }
+	blocked := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-blocked
+	}))
+	defer server.Close()
+	store := workdlq.NewMemoryStore()
+	q := workdlq.NewQueue(store)
+	n := workhook.NewNotifier(s.secret, []string{server.URL},
+		workhook.QueueSize(1),
+		workhook.DeadLetterQueue(q),
+	)
+	var saves []work.Unit
+	for i := 0; i < 3; i++ {
+		u := s.unit(noop, n)
+		s.Require().NoError(u.Add(ctx, test.Foo{ID: i}))
+		saves = append(saves, u)
+	}
+
+	// action.
+	for _, u := range saves {
+		s.Require().NoError(u.Save(ctx))
+	}
+	close(blocked)
+	s.Require().NoError(n.Close(ctx))
+
+	// assert.
+	letters, err := store.List(ctx, 10)
+	s.Require().NoError(err)
+	s.NotEmpty(letters)
+}
+
+func (s *NotifierTestSuite) TestNotifier_Closed() {
+	// arrange.
+	ctx := context.Background()
+	noop := func(context.Context, work.UnitMapperContext, ...interface{}) error { return nil }
+	n := workhook.NewNotifier(s.secret, []string{s.server.URL})
+	u := s.unit(noop, n)
+	s.Require().NoError(u.Add(ctx, test.Foo{ID: 1}))
+	s.Require().NoError(n.Close(ctx))
+
+	// action.
+	err := u.Save(ctx)
+
+	// assert.
+	s.Require().NoError(err)
+	s.Empty(s.results)
+}
+
+func (s *NotifierTestSuite) TestVerify() {
+	// arrange.
+	body := []byte(`{"id":"1"}`)
+	now := strconv.FormatInt(time.Now().Unix(), 10)
+	stale := strconv.FormatInt(time.Now().Add(-time.Hour).Unix(), 10)
+	signature := workhook.Sign(s.secret, now, body)
+
+	// action + assert.
+	s.True(workhook.Verify(s.secret, body, now, signature, time.Minute))
+	s.False(workhook.Verify([]byte("other"), body, now, signature, time.Minute))
+	s.False(workhook.Verify(s.secret, []byte(`{"id":"2"}`), now, signature, time.Minute))
+	s.False(workhook.Verify(s.secret, body, stale, workhook.Sign(s.secret, stale, body), time.Minute))
+	s.False(workhook.Verify(s.secret, body, stale, signature, time.Hour*2))
+	s.False(workhook.Verify(s.secret, body, "invalid", signature, time.Minute))
+}
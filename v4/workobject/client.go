@@ -0,0 +1,77 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+// Package workobject persists the entities of work units as objects within
+// S3-compatible object storage, so that document-shaped aggregates can be
+// saved with work units without a relational store.
+//
+// Each entity is stored as an object keyed by its type and identity, and is
+// written with conditional requests so that concurrent writers do not
+// silently overwrite one another:
+//
+//	m := workobject.NewMapper(client, workobject.Prefix("aggregates/"))
+//	u, err := work.NewUnit(work.UnitDataMappers(map[work.TypeName]work.UnitDataMapper{
+//		work.TypeNameOf(Order{}): m,
+//	}))
+package workobject
+
+import (
+	"context"
+	"errors"
+)
+
+var (
+	// ErrNotFound represents the error that clients return when the
+	// requested object does not exist.
+	ErrNotFound = errors.New("object not found")
+
+	// ErrPreconditionFailed represents the error that clients return when
+	// the condition of a request is not satisfied, such as when the object
+	// has been modified since it was read.
+	ErrPreconditionFailed = errors.New("object precondition failed")
+)
+
+// Object represents a stored object.
+type Object struct {
+	// Body is the content of the object.
+	Body []byte
+	// ETag is the entity tag identifying the version of the object.
+	ETag string
+}
+
+// Condition represents the condition under which a write is performed.
+type Condition struct {
+	// IfMatch performs the write only when the entity tag of the stored
+	// object matches, when not empty.
+	IfMatch string
+	// IfNoneMatch performs the write only when the object does not exist.
+	IfNoneMatch bool
+}
+
+// Client represents a client for S3-compatible object storage supporting
+// conditional requests, such as an adapter of the AWS SDK that sets the
+// If-Match and If-None-Match headers. Clients return ErrNotFound and
+// ErrPreconditionFailed, or errors wrapping them, for missing objects and
+// unsatisfied conditions respectively.
+type Client interface {
+	// Get retrieves the object with the provided key.
+	Get(ctx context.Context, key string) (Object, error)
+	// Put stores the provided content as the object with the provided key
+	// under the provided condition, providing the entity tag of the stored
+	// object.
+	Put(ctx context.Context, key string, body []byte, condition Condition) (string, error)
+	// Delete removes the object with the provided key under the provided
+	// condition.
+	Delete(ctx context.Context, key string, condition Condition) error
+}
@@ -0,0 +1,186 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package workobject
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"sync"
+
+	"github.com/freerware/work/v4"
+)
+
+// ErrUnidentifiableEntity represents the error that is returned when the
+// identity of an entity cannot be determined.
+var ErrUnidentifiableEntity = errors.New("unable to determine object key - entity identity unresolvable")
+
+// Options represents the configuration options for the mapper.
+type Options struct {
+	prefix    string
+	marshal   func(interface{}) ([]byte, error)
+	unmarshal func([]byte, interface{}) error
+}
+
+// Option applies an option to the provided configuration.
+type Option func(*Options)
+
+var (
+	// Prefix defines the prefix of the keys of the stored objects.
+	Prefix = func(prefix string) Option {
+		return func(o *Options) {
+			o.prefix = prefix
+		}
+	}
+
+	// Codec defines the functions encoding and decoding entities, which
+	// default to JSON.
+	Codec = func(marshal func(interface{}) ([]byte, error), unmarshal func([]byte, interface{}) error) Option {
+		return func(o *Options) {
+			o.marshal = marshal
+			o.unmarshal = unmarshal
+		}
+	}
+)
+
+// Mapper is a data mapper that stores entities as objects. The entity tags
+// of the objects that are loaded or written are tracked, so that updates
+// and deletes only succeed when the objects have not been modified since.
+// Writes that fail their condition result in work.UnitStaleEntityError,
+// which work units resolve with their conflict resolver. Since each object
+// is written individually, the objects written prior to a failure remain
+// written; best effort work units compensate them when rolling back.
+type Mapper struct {
+	client  Client
+	options Options
+	etags   sync.Map
+}
+
+// NewMapper constructs a mapper that stores entities with the provided
+// client.
+func NewMapper(client Client, opts ...Option) *Mapper {
+	o := Options{marshal: json.Marshal, unmarshal: json.Unmarshal}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return &Mapper{client: client, options: o}
+}
+
+// Key provides the key of the object storing the entity with the provided
+// type and identity.
+func (m *Mapper) Key(t work.TypeName, id interface{}) string {
+	return fmt.Sprintf("%s%s/%s", m.options.prefix, url.PathEscape(t.String()), url.PathEscape(fmt.Sprint(id)))
+}
+
+func (m *Mapper) keyOf(entity interface{}) (string, error) {
+	id, ok := work.IDOf(entity)
+	if !ok {
+		return "", ErrUnidentifiableEntity
+	}
+	return m.Key(work.TypeNameOf(entity), id), nil
+}
+
+// Load retrieves the entity with the provided type and identity into the
+// provided entity, which must be a pointer, tracking the version of its
+// object for subsequent writes. Load the entities before altering or
+// removing them within a work unit.
+func (m *Mapper) Load(ctx context.Context, t work.TypeName, id interface{}, entity interface{}) error {
+	key := m.Key(t, id)
+	object, err := m.client.Get(ctx, key)
+	if err != nil {
+		return err
+	}
+	if err = m.options.unmarshal(object.Body, entity); err != nil {
+		return err
+	}
+	m.etags.Store(key, object.ETag)
+	return nil
+}
+
+// etag provides the tracked entity tag of the object with the provided key.
+func (m *Mapper) etag(key string) string {
+	if etag, ok := m.etags.Load(key); ok {
+		return etag.(string)
+	}
+	return ""
+}
+
+// put stores each of the provided entities under the condition provided for
+// their keys, ignoring the condition when the writes are forced.
+func (m *Mapper) put(
+	ctx context.Context, mCtx work.UnitMapperContext, condition func(key string) Condition, entities []interface{}) error {
+	for _, entity := range entities {
+		key, err := m.keyOf(entity)
+		if err != nil {
+			return err
+		}
+		body, err := m.options.marshal(entity)
+		if err != nil {
+			return err
+		}
+		var c Condition
+		if !mCtx.Forced() {
+			c = condition(key)
+		}
+		etag, err := m.client.Put(ctx, key, body, c)
+		if errors.Is(err, ErrPreconditionFailed) {
+			return &work.UnitStaleEntityError{Entity: entity}
+		}
+		if err != nil {
+			return err
+		}
+		m.etags.Store(key, etag)
+	}
+	return nil
+}
+
+// Insert stores the provided entities as new objects, failing for those
+// whose objects already exist.
+func (m *Mapper) Insert(ctx context.Context, mCtx work.UnitMapperContext, entities ...interface{}) error {
+	return m.put(ctx, mCtx, func(string) Condition { return Condition{IfNoneMatch: true} }, entities)
+}
+
+// Update replaces the objects of the provided entities, failing for those
+// whose objects have been modified since they were loaded or written.
+func (m *Mapper) Update(ctx context.Context, mCtx work.UnitMapperContext, entities ...interface{}) error {
+	return m.put(ctx, mCtx, func(key string) Condition { return Condition{IfMatch: m.etag(key)} }, entities)
+}
+
+// Delete removes the objects of the provided entities, failing for those
+// whose objects have been modified since they were loaded or written.
+// Objects that no longer exist are considered removed.
+func (m *Mapper) Delete(ctx context.Context, mCtx work.UnitMapperContext, entities ...interface{}) error {
+	for _, entity := range entities {
+		key, err := m.keyOf(entity)
+		if err != nil {
+			return err
+		}
+		var c Condition
+		if !mCtx.Forced() {
+			c = Condition{IfMatch: m.etag(key)}
+		}
+		err = m.client.Delete(ctx, key, c)
+		if errors.Is(err, ErrPreconditionFailed) {
+			return &work.UnitStaleEntityError{Entity: entity}
+		}
+		if err != nil && !errors.Is(err, ErrNotFound) {
+			return err
+		}
+		m.etags.Delete(key)
+	}
+	return nil
+}
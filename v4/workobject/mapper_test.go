@@ -0,0 +1,194 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package workobject_test
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/freerware/work/v4"
+	"github.com/freerware/work/v4/workobject"
+	"github.com/stretchr/testify/suite"
+)
+
+type Order struct {
+	Key    string
+	Status string
+}
+
+func (o Order) Identifier() interface{} { return o.Key }
+
+// client is an in-memory client honoring conditional requests.
+type client struct {
+	mutex   sync.Mutex
+	objects map[string]workobject.Object
+	version int
+}
+
+func (c *client) Get(ctx context.Context, key string) (workobject.Object, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	object, ok := c.objects[key]
+	if !ok {
+		return workobject.Object{}, workobject.ErrNotFound
+	}
+	return object, nil
+}
+
+func (c *client) check(key string, condition workobject.Condition) error {
+	object, ok := c.objects[key]
+	if condition.IfNoneMatch && ok {
+		return workobject.ErrPreconditionFailed
+	}
+	if condition.IfMatch != "" && (!ok || object.ETag != condition.IfMatch) {
+		return workobject.ErrPreconditionFailed
+	}
+	return nil
+}
+
+func (c *client) Put(ctx context.Context, key string, body []byte, condition workobject.Condition) (string, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if err := c.check(key, condition); err != nil {
+		return "", err
+	}
+	c.version++
+	etag := strconv.Itoa(c.version)
+	c.objects[key] = workobject.Object{Body: body, ETag: etag}
+	return etag, nil
+}
+
+func (c *client) Delete(ctx context.Context, key string, condition workobject.Condition) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if _, ok := c.objects[key]; !ok {
+		return workobject.ErrNotFound
+	}
+	if err := c.check(key, condition); err != nil {
+		return err
+	}
+	delete(c.objects, key)
+	return nil
+}
+
+type MapperTestSuite struct {
+	suite.Suite
+
+	client *client
+	sut    *workobject.Mapper
+}
+
+func TestMapperTestSuite(t *testing.T) {
+	suite.Run(t, new(MapperTestSuite))
+}
+
+func (s *MapperTestSuite) SetupTest() {
+	s.client = &client{objects: make(map[string]workobject.Object)}
+	s.sut = workobject.NewMapper(s.client, workobject.Prefix("aggregates/"))
+}
+
+func (s *MapperTestSuite) unit() work.Unit {
+	u, err := work.NewUnit(work.UnitDataMappers(map[work.TypeName]work.UnitDataMapper{
+		work.TypeNameOf(Order{}): s.sut,
+	}))
+	s.Require().NoError(err)
+	return u
+}
+
+func (s *MapperTestSuite) TestMapper_Key() {
+	s.Equal("aggregates/workobject_test.Order/a%2Fb", s.sut.Key(work.TypeNameOf(Order{}), "a/b"))
+}
+
+func (s *MapperTestSuite) TestMapper_Lifecycle() {
+	// arrange.
+	ctx := context.Background()
+	t := work.TypeNameOf(Order{})
+	u := s.unit()
+	s.Require().NoError(u.Add(ctx, Order{Key: "a", Status: "A"}))
+	s.Require().NoError(u.Save(ctx))
+
+	// action + assert.
+	var loaded Order
+	s.Require().NoError(s.sut.Load(ctx, t, "a", &loaded))
+	s.Equal(Order{Key: "a", Status: "A"}, loaded)
+
+	u = s.unit()
+	loaded.Status = "B"
+	s.Require().NoError(u.Alter(ctx, loaded))
+	s.Require().NoError(u.Save(ctx))
+	s.Require().NoError(s.sut.Load(ctx, t, "a", &loaded))
+	s.Equal("B", loaded.Status)
+
+	u = s.unit()
+	s.Require().NoError(u.Remove(ctx, loaded))
+	s.Require().NoError(u.Save(ctx))
+	s.ErrorIs(s.sut.Load(ctx, t, "a", &loaded), workobject.ErrNotFound)
+}
+
+func (s *MapperTestSuite) TestMapper_Insert_Exists() {
+	// arrange.
+	ctx := context.Background()
+	order := Order{Key: "a", Status: "A"}
+	_, err := s.client.Put(ctx, s.sut.Key(work.TypeNameOf(order), "a"), []byte(`{}`), workobject.Condition{})
+	s.Require().NoError(err)
+	u := s.unit()
+	s.Require().NoError(u.Add(ctx, order))
+
+	// action.
+	err = u.Save(ctx)
+
+	// assert.
+	s.ErrorIs(err, work.ErrStaleEntity)
+}
+
+func (s *MapperTestSuite) TestMapper_Update_Stale() {
+	// arrange.
+	ctx := context.Background()
+	t := work.TypeNameOf(Order{})
+	key := s.sut.Key(t, "a")
+	_, err := s.client.Put(ctx, key, []byte(`{"Key":"a","Status":"A"}`), workobject.Condition{})
+	s.Require().NoError(err)
+	var loaded Order
+	s.Require().NoError(s.sut.Load(ctx, t, "a", &loaded))
+	_, err = s.client.Put(ctx, key, []byte(`{"Key":"a","Status":"C"}`), workobject.Condition{})
+	s.Require().NoError(err)
+	u := s.unit()
+	loaded.Status = "B"
+	s.Require().NoError(u.Alter(ctx, loaded))
+
+	// action.
+	err = u.Save(ctx)
+
+	// assert.
+	s.ErrorIs(err, work.ErrStaleEntity)
+	object, err := s.client.Get(ctx, key)
+	s.Require().NoError(err)
+	s.JSONEq(`{"Key":"a","Status":"C"}`, string(object.Body))
+}
+
+func (s *MapperTestSuite) TestMapper_Delete_Missing() {
+	// arrange.
+	ctx := context.Background()
+	u := s.unit()
+	s.Require().NoError(u.Remove(ctx, Order{Key: "a"}))
+
+	// action.
+	err := u.Save(ctx)
+
+	// assert.
+	s.NoError(err)
+}
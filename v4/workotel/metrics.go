@@ -0,0 +1,81 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package workotel
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/freerware/work/v4"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+type collector struct {
+	meter      metric.Meter
+	counters   sync.Map
+	histograms sync.Map
+}
+
+// NewMetricsCollector creates a metrics collector for work units recording
+// counters and timers with instruments created by meters obtained from the
+// provided meter provider. Counters are recorded with integer counters, and
+// timers with histograms of seconds.
+func NewMetricsCollector(mp metric.MeterProvider) work.UnitMetricsCollector {
+	return &collector{meter: mp.Meter(InstrumentationName)}
+}
+
+// WithMeterProvider provides the option to collect the metrics of work units
+// with instruments created by meters obtained from the provided meter
+// provider.
+func WithMeterProvider(mp metric.MeterProvider) work.UnitOption {
+	return work.UnitWithMetricsCollector(NewMetricsCollector(mp))
+}
+
+// tags converts the provided tags into metric attributes.
+func tags(t map[string]string) metric.MeasurementOption {
+	kvs := make([]attribute.KeyValue, 0, len(t))
+	for k, v := range t {
+		kvs = append(kvs, attribute.String(k, v))
+	}
+	return metric.WithAttributes(kvs...)
+}
+
+func (c *collector) Counter(name string, t map[string]string, value int64) {
+	ctr, ok := c.counters.Load(name)
+	if !ok {
+		created, err := c.meter.Int64Counter(name)
+		if err != nil {
+			otel.Handle(err)
+		}
+		ctr, _ = c.counters.LoadOrStore(name, created)
+	}
+	ctr.(metric.Int64Counter).Add(context.Background(), value, tags(t))
+}
+
+func (c *collector) Timer(name string, t map[string]string, d time.Duration) {
+	h, ok := c.histograms.Load(name)
+	if !ok {
+		created, err := c.meter.Float64Histogram(name, metric.WithUnit("s"))
+		if err != nil {
+			otel.Handle(err)
+		}
+		h, _ = c.histograms.LoadOrStore(name, created)
+	}
+	h.(metric.Float64Histogram).Record(context.Background(), d.Seconds(), tags(t))
+}
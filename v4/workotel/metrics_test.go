@@ -0,0 +1,98 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package workotel_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/freerware/work/v4"
+	"github.com/freerware/work/v4/workotel"
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+type MetricsTestSuite struct {
+	suite.Suite
+
+	reader *sdkmetric.ManualReader
+	mp     *sdkmetric.MeterProvider
+	sut    work.UnitMetricsCollector
+}
+
+func TestMetricsTestSuite(t *testing.T) {
+	suite.Run(t, new(MetricsTestSuite))
+}
+
+func (s *MetricsTestSuite) SetupTest() {
+	s.reader = sdkmetric.NewManualReader()
+	s.mp = sdkmetric.NewMeterProvider(sdkmetric.WithReader(s.reader))
+	s.sut = workotel.NewMetricsCollector(s.mp)
+}
+
+func (s *MetricsTestSuite) collect() map[string]metricdata.Metrics {
+	var rm metricdata.ResourceMetrics
+	s.Require().NoError(s.reader.Collect(context.Background(), &rm))
+	metrics := make(map[string]metricdata.Metrics)
+	for _, sm := range rm.ScopeMetrics {
+		s.Equal(workotel.InstrumentationName, sm.Scope.Name)
+		for _, m := range sm.Metrics {
+			metrics[m.Name] = m
+		}
+	}
+	return metrics
+}
+
+func (s *MetricsTestSuite) TestCounter() {
+	// action.
+	s.sut.Counter("unit.save.success", map[string]string{"unit_type": "sql"}, 2)
+	s.sut.Counter("unit.save.success", map[string]string{"unit_type": "sql"}, 1)
+
+	// assert.
+	m, ok := s.collect()["unit.save.success"]
+	s.Require().True(ok)
+	sum, ok := m.Data.(metricdata.Sum[int64])
+	s.Require().True(ok)
+	s.Require().Len(sum.DataPoints, 1)
+	s.EqualValues(3, sum.DataPoints[0].Value)
+	s.Equal(attribute.NewSet(attribute.String("unit_type", "sql")), sum.DataPoints[0].Attributes)
+}
+
+func (s *MetricsTestSuite) TestTimer() {
+	// action.
+	s.sut.Timer("unit.save", map[string]string{"unit_type": "sql"}, 250*time.Millisecond)
+
+	// assert.
+	m, ok := s.collect()["unit.save"]
+	s.Require().True(ok)
+	s.Equal("s", m.Unit)
+	h, ok := m.Data.(metricdata.Histogram[float64])
+	s.Require().True(ok)
+	s.Require().Len(h.DataPoints, 1)
+	s.EqualValues(1, h.DataPoints[0].Count)
+	s.Equal(0.25, h.DataPoints[0].Sum)
+}
+
+func (s *MetricsTestSuite) TestWithMeterProvider() {
+	// action.
+	d := work.DescribeUnitOptions(workotel.WithMeterProvider(s.mp))
+
+	// assert.
+	s.Contains(d.MetricsCollector, "workotel")
+}
@@ -0,0 +1,99 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package workotel provides a tracer and a metrics collector for work units
+// backed by OpenTelemetry:
+//
+//	u, err := work.NewUnit(
+//		work.UnitDB(db),
+//		work.UnitDataMappers(m),
+//		workotel.WithTracerProvider(otel.GetTracerProvider()),
+//		workotel.WithMeterProvider(otel.GetMeterProvider()),
+//	)
+package workotel
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/freerware/work/v4"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// InstrumentationName is the name of the instrumentation library provided
+// when obtaining tracers from tracer providers and meters from meter
+// providers.
+const InstrumentationName = "github.com/freerware/work/v4"
+
+type tracer struct {
+	tracer trace.Tracer
+}
+
+// NewTracer creates a tracer for work units starting spans with tracers
+// obtained from the provided tracer provider.
+func NewTracer(tp trace.TracerProvider) work.UnitTracer {
+	return &tracer{tracer: tp.Tracer(InstrumentationName)}
+}
+
+// WithTracerProvider provides the option to trace work units with spans
+// started by tracers obtained from the provided tracer provider.
+func WithTracerProvider(tp trace.TracerProvider) work.UnitOption {
+	return work.UnitWithTracer(NewTracer(tp))
+}
+
+func (t *tracer) Start(ctx context.Context, name string, attrs ...any) (context.Context, work.UnitSpan) {
+	ctx, s := t.tracer.Start(ctx, name, trace.WithAttributes(attributes(attrs)...))
+	return ctx, &span{span: s}
+}
+
+// attributes converts the provided alternating keys and values into span
+// attributes, ignoring any trailing key without a value.
+func attributes(attrs []any) []attribute.KeyValue {
+	kvs := make([]attribute.KeyValue, 0, len(attrs)/2)
+	for i := 0; i+1 < len(attrs); i += 2 {
+		key := attribute.Key(fmt.Sprint(attrs[i]))
+		switch v := attrs[i+1].(type) {
+		case string:
+			kvs = append(kvs, key.String(v))
+		case int:
+			kvs = append(kvs, key.Int(v))
+		case int64:
+			kvs = append(kvs, key.Int64(v))
+		case bool:
+			kvs = append(kvs, key.Bool(v))
+		case float64:
+			kvs = append(kvs, key.Float64(v))
+		default:
+			kvs = append(kvs, key.String(fmt.Sprint(v)))
+		}
+	}
+	return kvs
+}
+
+type span struct {
+	span trace.Span
+}
+
+// End ends the span, recording the provided error and marking the span as
+// failed when the error is not nil.
+func (s *span) End(err error) {
+	if err != nil {
+		s.span.RecordError(err)
+		s.span.SetStatus(codes.Error, err.Error())
+	}
+	s.span.End()
+}
@@ -0,0 +1,104 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package workotel_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/freerware/work/v4"
+	"github.com/freerware/work/v4/workotel"
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type OTelTestSuite struct {
+	suite.Suite
+
+	recorder *tracetest.SpanRecorder
+	tracer   work.UnitTracer
+}
+
+func TestOTelTestSuite(t *testing.T) {
+	suite.Run(t, new(OTelTestSuite))
+}
+
+func (s *OTelTestSuite) SetupTest() {
+	s.recorder = tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(s.recorder))
+	s.tracer = workotel.NewTracer(tp)
+}
+
+func (s *OTelTestSuite) TestStart() {
+	// arrange.
+	ctx := context.Background()
+
+	// action.
+	ctx, parent := s.tracer.Start(ctx, work.UnitSpanSave, "unit_type", "sql", "insert_count", 2)
+	_, child := s.tracer.Start(ctx, work.UnitSpanMapperPrefix+"insert", "compensating", false, "other", 1.5)
+	child.End(nil)
+	parent.End(nil)
+
+	// assert.
+	spans := s.recorder.Ended()
+	s.Require().Len(spans, 2)
+	s.Equal(work.UnitSpanMapperPrefix+"insert", spans[0].Name())
+	s.Equal(work.UnitSpanSave, spans[1].Name())
+	s.Equal(spans[1].SpanContext().SpanID(), spans[0].Parent().SpanID())
+	s.ElementsMatch([]attribute.KeyValue{
+		attribute.String("unit_type", "sql"),
+		attribute.Int("insert_count", 2),
+	}, spans[1].Attributes())
+	s.ElementsMatch([]attribute.KeyValue{
+		attribute.Bool("compensating", false),
+		attribute.Float64("other", 1.5),
+	}, spans[0].Attributes())
+	s.Equal(codes.Unset, spans[1].Status().Code)
+	s.Equal(trace.SpanKindInternal, spans[1].SpanKind())
+}
+
+func (s *OTelTestSuite) TestEnd_Error() {
+	// arrange.
+	err := errors.New("whoa")
+	_, span := s.tracer.Start(context.Background(), work.UnitSpanRollback)
+
+	// action.
+	span.End(err)
+
+	// assert.
+	spans := s.recorder.Ended()
+	s.Require().Len(spans, 1)
+	s.Equal(codes.Error, spans[0].Status().Code)
+	s.Equal("whoa", spans[0].Status().Description)
+	s.Require().Len(spans[0].Events(), 1)
+	s.Equal("exception", spans[0].Events()[0].Name)
+}
+
+func (s *OTelTestSuite) TestWithTracerProvider() {
+	// arrange.
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(s.recorder))
+
+	// action.
+	d := work.DescribeUnitOptions(workotel.WithTracerProvider(tp))
+
+	// assert.
+	s.Contains(d.Options, "tracing")
+}
@@ -0,0 +1,141 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+// Package workpgx provides helpers for data mappers of work units using the
+// pgx driver through database/sql.
+//
+// CopyInsert inserts added entities using the COPY protocol once a batch is
+// large enough to benefit from it, and with a single INSERT statement
+// otherwise:
+//
+//	insert := workpgx.CopyInsert{
+//		Table:   pgx.Identifier{"foos"},
+//		Columns: []string{"id", "name"},
+//		Row: func(entity interface{}) ([]interface{}, error) {
+//			f := entity.(Foo)
+//			return []interface{}{f.ID, f.Name}, nil
+//		},
+//		Threshold: 100,
+//	}
+//	u, err := work.NewUnit(
+//		work.UnitDB(db),
+//		work.UnitInsertFunc(work.TypeNameOf(Foo{}), insert.Func()),
+//	)
+package workpgx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/freerware/work/v4"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
+)
+
+// ErrNotPgx represents the error that is returned when copying entities
+// over a connection that is not provided by the pgx driver.
+var ErrNotPgx = errors.New("copy requires a connection provided by the pgx driver")
+
+// CopyInsert inserts entities into a table, using the COPY protocol for
+// batches of at least the threshold size.
+type CopyInsert struct {
+	// Table identifies the table the entities are inserted into.
+	Table pgx.Identifier
+	// Columns are the names of the columns inserted for each entity.
+	Columns []string
+	// Row provides the values of each of the columns for the provided
+	// entity, in order.
+	Row func(entity interface{}) ([]interface{}, error)
+	// Threshold is the minimum number of entities inserted with the COPY
+	// protocol. Smaller batches are inserted with a single INSERT statement,
+	// avoiding the overhead of COPY. Zero indicates every batch is copied.
+	Threshold int
+}
+
+// Exec inserts the provided entities within the transaction of the provided
+// mapper context.
+func (c CopyInsert) Exec(ctx context.Context, mCtx work.UnitMapperContext, entities ...interface{}) error {
+	if len(entities) == 0 {
+		return nil
+	}
+	rows := make([][]interface{}, 0, len(entities))
+	for _, entity := range entities {
+		row, err := c.Row(entity)
+		if err != nil {
+			return err
+		}
+		if len(row) != len(c.Columns) {
+			return fmt.Errorf(
+				"insert into %s provided %d values for %d columns", c.Table.Sanitize(), len(row), len(c.Columns))
+		}
+		rows = append(rows, row)
+	}
+	if len(rows) < c.Threshold {
+		return c.insert(ctx, mCtx, rows)
+	}
+	return c.copy(ctx, mCtx, rows)
+}
+
+// copy inserts the provided rows with the COPY protocol over the connection
+// the transaction of the provided mapper context is open on.
+func (c CopyInsert) copy(ctx context.Context, mCtx work.UnitMapperContext, rows [][]interface{}) error {
+	return mCtx.Raw(func(driverConn interface{}) error {
+		conn, ok := driverConn.(*stdlib.Conn)
+		if !ok {
+			return ErrNotPgx
+		}
+		copied, err := conn.Conn().CopyFrom(ctx, c.Table, c.Columns, pgx.CopyFromRows(rows))
+		if err != nil {
+			return err
+		}
+		if int(copied) != len(rows) {
+			return fmt.Errorf("copy into %s inserted %d of %d rows", c.Table.Sanitize(), copied, len(rows))
+		}
+		return nil
+	})
+}
+
+// insert inserts the provided rows with a single INSERT statement.
+func (c CopyInsert) insert(ctx context.Context, mCtx work.UnitMapperContext, rows [][]interface{}) error {
+	columns := make([]string, 0, len(c.Columns))
+	for _, column := range c.Columns {
+		columns = append(columns, pgx.Identifier{column}.Sanitize())
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "INSERT INTO %s (%s) VALUES ", c.Table.Sanitize(), strings.Join(columns, ", "))
+	args := make([]interface{}, 0, len(rows)*len(c.Columns))
+	for i, row := range rows {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteString("(")
+		for j, value := range row {
+			if j > 0 {
+				b.WriteString(", ")
+			}
+			args = append(args, value)
+			fmt.Fprintf(&b, "$%d", len(args))
+		}
+		b.WriteString(")")
+	}
+	_, err := mCtx.ExecContext(ctx, b.String(), args...)
+	return err
+}
+
+// Func provides the data mapper function inserting the added entities.
+func (c CopyInsert) Func() work.UnitDataMapperFunc {
+	return c.Exec
+}
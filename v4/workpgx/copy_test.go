@@ -0,0 +1,102 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package workpgx_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/freerware/work/v4"
+	"github.com/freerware/work/v4/internal/test"
+	"github.com/freerware/work/v4/workpgx"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/suite"
+)
+
+type CopyInsertTestSuite struct {
+	suite.Suite
+
+	insert workpgx.CopyInsert
+}
+
+func TestCopyInsertTestSuite(t *testing.T) {
+	suite.Run(t, new(CopyInsertTestSuite))
+}
+
+func (s *CopyInsertTestSuite) SetupTest() {
+	s.insert = workpgx.CopyInsert{
+		Table:   pgx.Identifier{"public", "quxes"},
+		Columns: []string{"key", "name"},
+		Row: func(entity interface{}) ([]interface{}, error) {
+			q := entity.(test.Qux)
+			return []interface{}{q.Key, q.Name}, nil
+		},
+		Threshold: 3,
+	}
+}
+
+func (s *CopyInsertTestSuite) save(expect func(sqlmock.Sqlmock), entities ...interface{}) error {
+	ctx := context.Background()
+	db, mock, err := sqlmock.New()
+	s.Require().NoError(err)
+	defer db.Close()
+	noop := func(context.Context, work.UnitMapperContext, ...interface{}) error { return nil }
+	quxType := work.TypeNameOf(test.Qux{})
+	sut, err := work.NewUnit(
+		work.UnitDB(db),
+		work.UnitRetryAttempts(1),
+		work.UnitInsertFunc(quxType, s.insert.Func()),
+		work.UnitUpdateFunc(quxType, noop),
+		work.UnitDeleteFunc(quxType, noop),
+	)
+	s.Require().NoError(err)
+	s.Require().NoError(sut.Add(ctx, entities...))
+	expect(mock)
+	err = sut.Save(ctx)
+	s.NoError(mock.ExpectationsWereMet())
+	return err
+}
+
+func (s *CopyInsertTestSuite) TestExec_BelowThreshold() {
+	// arrange.
+	expect := func(mock sqlmock.Sqlmock) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`^INSERT INTO "public"\."quxes" \("key", "name"\) VALUES \(\$1, \$2\), \(\$3, \$4\)$`).
+			WithArgs("a", "A", "b", "B").
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectCommit()
+	}
+
+	// action.
+	err := s.save(expect, test.Qux{Key: "a", Name: "A"}, test.Qux{Key: "b", Name: "B"})
+
+	// assert.
+	s.NoError(err)
+}
+
+func (s *CopyInsertTestSuite) TestExec_NotPgx() {
+	// arrange.
+	expect := func(mock sqlmock.Sqlmock) {
+		mock.ExpectBegin()
+		mock.ExpectRollback()
+	}
+
+	// action.
+	err := s.save(expect, test.Qux{Key: "a"}, test.Qux{Key: "b"}, test.Qux{Key: "c"})
+
+	// assert.
+	s.ErrorIs(err, workpgx.ErrNotPgx)
+}
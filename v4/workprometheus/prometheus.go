@@ -0,0 +1,234 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package workprometheus provides a metrics collector for work units backed
+// by Prometheus:
+//
+//	u, err := work.NewUnit(
+//		work.UnitDB(db),
+//		work.UnitDataMappers(m),
+//		workprometheus.WithRegisterer(prometheus.DefaultRegisterer),
+//	)
+//
+// Counters are collected as Prometheus counters suffixed with "_total", and
+// timers as histograms of seconds suffixed with "_seconds". Characters that
+// are not permitted within Prometheus names, such as periods, are replaced
+// with underscores, so that "unit.save.success" is collected as
+// "unit_save_success_total".
+package workprometheus
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/freerware/work/v4"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Options represents the configuration options for the collector.
+type Options struct {
+	namespace string
+	buckets   []float64
+}
+
+// Option applies an option to the provided configuration.
+type Option func(*Options)
+
+var (
+	// Namespace defines the namespace prefixing the names of each metric.
+	Namespace = func(namespace string) Option {
+		return func(o *Options) {
+			o.namespace = namespace
+		}
+	}
+
+	// Buckets defines the buckets of the histograms collecting timers, in
+	// seconds, which defaults to prometheus.DefBuckets.
+	Buckets = func(buckets []float64) Option {
+		return func(o *Options) {
+			o.buckets = buckets
+		}
+	}
+)
+
+// counter represents a counter vector along with its label names.
+type counter struct {
+	vec    *prometheus.CounterVec
+	labels []string
+}
+
+// histogram represents a histogram vector along with its label names.
+type histogram struct {
+	vec    *prometheus.HistogramVec
+	labels []string
+}
+
+// Collector collects the metrics emitted by work units as Prometheus
+// metrics, registering each metric with the registerer upon its first
+// emission. The label names of each metric are those of the tags it is
+// first emitted with; tags subsequently emitted with other names are
+// ignored, and labels without a tag are empty.
+type Collector struct {
+	registerer prometheus.Registerer
+	options    Options
+	mutex      sync.Mutex
+	counters   map[string]counter
+	histograms map[string]histogram
+}
+
+// NewCollector creates a collector registering metrics with the provided
+// registerer.
+func NewCollector(registerer prometheus.Registerer, opts ...Option) *Collector {
+	o := Options{buckets: prometheus.DefBuckets}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return &Collector{
+		registerer: registerer,
+		options:    o,
+		counters:   make(map[string]counter),
+		histograms: make(map[string]histogram),
+	}
+}
+
+// WithRegisterer provides the option to collect the metrics of work units
+// as Prometheus metrics registered with the provided registerer.
+func WithRegisterer(registerer prometheus.Registerer, opts ...Option) work.UnitOption {
+	return work.UnitWithMetricsCollector(NewCollector(registerer, opts...))
+}
+
+// sanitize replaces the characters of the provided name that are not
+// permitted within Prometheus names with underscores.
+func sanitize(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '_':
+			return r
+		}
+		return '_'
+	}, name)
+}
+
+// labelNames provides the sanitized names of the provided tags, in sorted
+// order.
+func labelNames(tags map[string]string) []string {
+	names := make([]string, 0, len(tags))
+	for k := range tags {
+		names = append(names, sanitize(k))
+	}
+	sort.Strings(names)
+	return names
+}
+
+// labels provides the values of the provided tags for the provided label
+// names.
+func labels(names []string, tags map[string]string) prometheus.Labels {
+	l := make(prometheus.Labels, len(names))
+	for _, name := range names {
+		l[name] = ""
+	}
+	for k, v := range tags {
+		if _, ok := l[sanitize(k)]; ok {
+			l[sanitize(k)] = v
+		}
+	}
+	return l
+}
+
+// register registers the provided collector, providing the collector that
+// is already registered in its place, if any.
+func (c *Collector) register(collector prometheus.Collector) (prometheus.Collector, error) {
+	err := c.registerer.Register(collector)
+	var registered prometheus.AlreadyRegisteredError
+	if errors.As(err, &registered) {
+		return registered.ExistingCollector, nil
+	}
+	return collector, err
+}
+
+// counter provides the counter with the provided name, registering it with
+// the label names of the provided tags upon first use.
+func (c *Collector) counter(name string, tags map[string]string) (counter, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if ctr, ok := c.counters[name]; ok {
+		return ctr, true
+	}
+	names := labelNames(tags)
+	vec := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: c.options.namespace,
+		Name:      sanitize(name) + "_total",
+		Help:      "The work unit counter " + name + ".",
+	}, names)
+	registered, err := c.register(vec)
+	if err != nil {
+		return counter{}, false
+	}
+	vec, ok := registered.(*prometheus.CounterVec)
+	if !ok {
+		return counter{}, false
+	}
+	c.counters[name] = counter{vec: vec, labels: names}
+	return c.counters[name], true
+}
+
+// histogram provides the histogram with the provided name, registering it
+// with the label names of the provided tags upon first use.
+func (c *Collector) histogram(name string, tags map[string]string) (histogram, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if h, ok := c.histograms[name]; ok {
+		return h, true
+	}
+	names := labelNames(tags)
+	vec := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: c.options.namespace,
+		Name:      sanitize(name) + "_seconds",
+		Help:      "The work unit timer " + name + ".",
+		Buckets:   c.options.buckets,
+	}, names)
+	registered, err := c.register(vec)
+	if err != nil {
+		return histogram{}, false
+	}
+	vec, ok := registered.(*prometheus.HistogramVec)
+	if !ok {
+		return histogram{}, false
+	}
+	c.histograms[name] = histogram{vec: vec, labels: names}
+	return c.histograms[name], true
+}
+
+// Counter increments the counter with the provided name and tags by the
+// provided value. Counters that cannot be registered, such as those
+// conflicting with metrics already registered, are not collected.
+func (c *Collector) Counter(name string, tags map[string]string, value int64) {
+	if ctr, ok := c.counter(name, tags); ok {
+		ctr.vec.With(labels(ctr.labels, tags)).Add(float64(value))
+	}
+}
+
+// Timer observes the provided duration, in seconds, within the histogram
+// with the provided name and tags. Histograms that cannot be registered,
+// such as those conflicting with metrics already registered, are not
+// collected.
+func (c *Collector) Timer(name string, tags map[string]string, d time.Duration) {
+	if h, ok := c.histogram(name, tags); ok {
+		h.vec.With(labels(h.labels, tags)).Observe(d.Seconds())
+	}
+}
@@ -0,0 +1,123 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package workprometheus_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/freerware/work/v4"
+	"github.com/freerware/work/v4/internal/test"
+	"github.com/freerware/work/v4/workprometheus"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/suite"
+)
+
+type PrometheusTestSuite struct {
+	suite.Suite
+
+	registry *prometheus.Registry
+	sut      *workprometheus.Collector
+}
+
+func TestPrometheusTestSuite(t *testing.T) {
+	suite.Run(t, new(PrometheusTestSuite))
+}
+
+func (s *PrometheusTestSuite) SetupTest() {
+	s.registry = prometheus.NewRegistry()
+	s.sut = workprometheus.NewCollector(s.registry, workprometheus.Namespace("app"))
+}
+
+func (s *PrometheusTestSuite) TestCounter() {
+	// action.
+	s.sut.Counter("unit.save.success", map[string]string{"unit_type": "sql"}, 2)
+	s.sut.Counter("unit.save.success", map[string]string{"unit_type": "sql"}, 1)
+	s.sut.Counter("unit.save.success", map[string]string{"unit_type": "best_effort", "other": "x"}, 1)
+
+	// assert.
+	families, err := s.registry.Gather()
+	s.Require().NoError(err)
+	s.Require().Len(families, 1)
+	s.Equal("app_unit_save_success_total", families[0].GetName())
+	s.Len(families[0].GetMetric(), 2)
+	vec := s.counterVec()
+	s.Equal(3.0, testutil.ToFloat64(vec.WithLabelValues("sql")))
+	s.Equal(1.0, testutil.ToFloat64(vec.WithLabelValues("best_effort")))
+}
+
+func (s *PrometheusTestSuite) counterVec() *prometheus.CounterVec {
+	vec := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "app",
+		Name:      "unit_save_success_total",
+		Help:      "The work unit counter unit.save.success.",
+	}, []string{"unit_type"})
+	err := s.registry.Register(vec)
+	var registered prometheus.AlreadyRegisteredError
+	s.Require().ErrorAs(err, &registered)
+	return registered.ExistingCollector.(*prometheus.CounterVec)
+}
+
+func (s *PrometheusTestSuite) TestTimer() {
+	// action.
+	s.sut.Timer("unit.save", map[string]string{"unit_type": "sql"}, 250*time.Millisecond)
+
+	// assert.
+	families, err := s.registry.Gather()
+	s.Require().NoError(err)
+	s.Require().Len(families, 1)
+	s.Equal("app_unit_save_seconds", families[0].GetName())
+	h := families[0].GetMetric()[0].GetHistogram()
+	s.EqualValues(1, h.GetSampleCount())
+	s.Equal(0.25, h.GetSampleSum())
+}
+
+func (s *PrometheusTestSuite) TestCounter_Conflict() {
+	// arrange.
+	s.Require().NoError(s.registry.Register(prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "app",
+		Name:      "unit_insert_total",
+	})))
+
+	// action + assert.
+	s.NotPanics(func() { s.sut.Counter("unit.insert", nil, 1) })
+}
+
+func (s *PrometheusTestSuite) TestWithRegisterer() {
+	// arrange.
+	foo := test.Foo{ID: 28}
+	fooType := work.TypeNameOf(foo)
+	noop := func(context.Context, work.UnitMapperContext, ...interface{}) error { return nil }
+	u, err := work.NewUnit(
+		work.UnitInsertFunc(fooType, noop),
+		work.UnitUpdateFunc(fooType, noop),
+		work.UnitDeleteFunc(fooType, noop),
+		workprometheus.WithRegisterer(s.registry),
+	)
+	s.Require().NoError(err)
+	s.Require().NoError(u.Add(context.Background(), foo))
+
+	// action.
+	err = u.Save(context.Background())
+
+	// assert.
+	s.Require().NoError(err)
+	count, err := testutil.GatherAndCount(s.registry, "unit_save_success_total", "unit_insert_total", "unit_save_seconds")
+	s.Require().NoError(err)
+	s.Equal(3, count)
+}
@@ -0,0 +1,175 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package workreplay records the changes committed by work units into export
+// files, and replays them against a target database, or without saving in a
+// dry run, for load testing and migration rehearsal with production-shaped
+// write traffic.
+//
+// A Recorder exports each successful save as a line of JSON:
+//
+//	f, _ := os.Create("saves.jsonl")
+//	rec := workreplay.NewRecorder(f)
+//	u, err := work.NewUnit(opts, work.UnitSaveActions(rec.Action()))
+//
+// A Runner imports the recorded saves, staging each within a new work unit
+// constructed by the provided uniter before saving it:
+//
+//	r := workreplay.NewRunner(uniter, map[work.TypeName]workreplay.Codec{
+//		work.TypeNameOf(Foo{}): workreplay.Entity[Foo](),
+//	}, workreplay.Concurrency(8))
+//	report, err := r.Run(ctx, f)
+package workreplay
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/freerware/work/v4"
+)
+
+// Patch represents a recorded partial update.
+type Patch struct {
+	// ID is the JSON encoded identity of the entity being patched.
+	ID json.RawMessage `json:"id"`
+	// Fields are the fields of the entity to modify, keyed by field name,
+	// along with their new values.
+	Fields map[string]interface{} `json:"fields"`
+}
+
+// Record represents the changes committed by a single save of a work unit,
+// with entities JSON encoded and organized by type name.
+type Record struct {
+	// Time is when the save completed.
+	Time time.Time `json:"time"`
+	// DurationMS is the time taken by the save, in milliseconds.
+	DurationMS int64 `json:"durationMs"`
+	// Additions are the entities indicated as new.
+	Additions map[string][]json.RawMessage `json:"additions,omitempty"`
+	// Alterations are the entities indicated as modified.
+	Alterations map[string][]json.RawMessage `json:"alterations,omitempty"`
+	// Removals are the entities indicated as removed.
+	Removals map[string][]json.RawMessage `json:"removals,omitempty"`
+	// Upserts are the entities indicated as upserted.
+	Upserts map[string][]json.RawMessage `json:"upserts,omitempty"`
+	// Patches are the partial updates indicated.
+	Patches map[string][]Patch `json:"patches,omitempty"`
+}
+
+func encodeEntities(entities map[work.TypeName][]interface{}) (map[string][]json.RawMessage, error) {
+	if len(entities) == 0 {
+		return nil, nil
+	}
+	encoded := make(map[string][]json.RawMessage, len(entities))
+	for t, e := range entities {
+		for _, entity := range e {
+			b, err := json.Marshal(entity)
+			if err != nil {
+				return nil, fmt.Errorf("unable to encode %s: %w", t, err)
+			}
+			encoded[t.String()] = append(encoded[t.String()], b)
+		}
+	}
+	return encoded, nil
+}
+
+// NewRecord constructs the record of the provided changes, committed at the
+// provided time.
+func NewRecord(changes work.UnitChanges, t time.Time, duration time.Duration) (r Record, err error) {
+	r.Time = t.UTC()
+	r.DurationMS = duration.Milliseconds()
+	if r.Additions, err = encodeEntities(changes.Additions); err != nil {
+		return
+	}
+	if r.Alterations, err = encodeEntities(changes.Alterations); err != nil {
+		return
+	}
+	if r.Removals, err = encodeEntities(changes.Removals); err != nil {
+		return
+	}
+	if r.Upserts, err = encodeEntities(changes.Upserts); err != nil {
+		return
+	}
+	for t, patches := range changes.Patches {
+		if r.Patches == nil {
+			r.Patches = make(map[string][]Patch, len(changes.Patches))
+		}
+		for _, p := range patches {
+			id, err := json.Marshal(p.ID)
+			if err != nil {
+				return r, fmt.Errorf("unable to encode %s patch: %w", t, err)
+			}
+			r.Patches[t.String()] = append(r.Patches[t.String()], Patch{ID: id, Fields: p.Fields})
+		}
+	}
+	return
+}
+
+// Recorder exports the changes committed by work units as lines of JSON.
+type Recorder struct {
+	mutex   sync.Mutex
+	encoder *json.Encoder
+}
+
+// NewRecorder constructs a recorder that exports records to the provided
+// writer.
+func NewRecorder(w io.Writer) *Recorder {
+	return &Recorder{encoder: json.NewEncoder(w)}
+}
+
+// Write exports the provided record.
+func (r *Recorder) Write(record Record) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.encoder.Encode(record)
+}
+
+// Action provides the action that exports the changes of a work unit once
+// it is saved successfully. Failures are logged with the logger of the work
+// unit.
+func (r *Recorder) Action() work.UnitSaveAction {
+	return func(actionCtx work.UnitSaveActionContext) {
+		if actionCtx.Err != nil {
+			return
+		}
+		record, err := NewRecord(actionCtx.Changes(), time.Now(), actionCtx.Duration)
+		if err == nil {
+			err = r.Write(record)
+		}
+		if err != nil && actionCtx.Logger != nil {
+			actionCtx.Logger.Error("unable to record work unit save", "error", err.Error())
+		}
+	}
+}
+
+// Reader imports the records exported by a recorder.
+type Reader struct {
+	decoder *json.Decoder
+}
+
+// NewReader constructs a reader that imports records from the provided
+// reader.
+func NewReader(r io.Reader) *Reader {
+	return &Reader{decoder: json.NewDecoder(r)}
+}
+
+// Next provides the next record, or io.EOF once all records are read.
+func (r *Reader) Next() (record Record, err error) {
+	err = r.decoder.Decode(&record)
+	return
+}
@@ -0,0 +1,237 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package workreplay_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/freerware/work/v4"
+	"github.com/freerware/work/v4/internal/test"
+	"github.com/freerware/work/v4/workreplay"
+	"github.com/stretchr/testify/suite"
+)
+
+type ReplayTestSuite struct {
+	suite.Suite
+
+	mutex    sync.Mutex
+	inserted []interface{}
+	updated  []interface{}
+	deleted  []interface{}
+	patched  []work.UnitPatch
+	saveErr  error
+	uniter   work.Uniter
+	codecs   map[work.TypeName]workreplay.Codec
+}
+
+func TestReplayTestSuite(t *testing.T) {
+	suite.Run(t, new(ReplayTestSuite))
+}
+
+func (s *ReplayTestSuite) SetupTest() {
+	s.inserted, s.updated, s.deleted, s.patched = nil, nil, nil, nil
+	s.saveErr = nil
+	record := func(entities *[]interface{}) work.UnitDataMapperFunc {
+		return func(ctx context.Context, mCtx work.UnitMapperContext, e ...interface{}) error {
+			s.mutex.Lock()
+			defer s.mutex.Unlock()
+			*entities = append(*entities, e...)
+			return s.saveErr
+		}
+	}
+	fooType := work.TypeNameOf(test.Foo{})
+	s.uniter = work.NewUniter(
+		work.UnitInsertFunc(fooType, record(&s.inserted)),
+		work.UnitUpdateFunc(fooType, record(&s.updated)),
+		work.UnitDeleteFunc(fooType, record(&s.deleted)),
+		work.UnitPatchFunc(fooType, func(ctx context.Context, mCtx work.UnitMapperContext, p ...work.UnitPatch) error {
+			s.mutex.Lock()
+			defer s.mutex.Unlock()
+			s.patched = append(s.patched, p...)
+			return nil
+		}),
+		work.UnitRetryAttempts(1),
+	)
+	s.codecs = map[work.TypeName]workreplay.Codec{
+		fooType: workreplay.EntityWithID[test.Foo, int](),
+	}
+}
+
+// recorded provides the saves of the provided work units, as recorded.
+func (s *ReplayTestSuite) recorded(stages ...func(work.Unit) error) *bytes.Buffer {
+	var buf bytes.Buffer
+	rec := workreplay.NewRecorder(&buf)
+	noop := func(context.Context, work.UnitMapperContext, ...interface{}) error { return nil }
+	fooType := work.TypeNameOf(test.Foo{})
+	for _, stage := range stages {
+		u, err := work.NewUnit(
+			work.UnitInsertFunc(fooType, noop),
+			work.UnitUpdateFunc(fooType, noop),
+			work.UnitDeleteFunc(fooType, noop),
+			work.UnitPatchFunc(fooType, func(context.Context, work.UnitMapperContext, ...work.UnitPatch) error { return nil }),
+			work.UnitSaveActions(rec.Action()),
+		)
+		s.Require().NoError(err)
+		s.Require().NoError(stage(u))
+		s.Require().NoError(u.Save(context.Background()))
+	}
+	return &buf
+}
+
+func (s *ReplayTestSuite) TestRecorder_Action() {
+	// arrange.
+	ctx := context.Background()
+
+	// action.
+	buf := s.recorded(func(u work.Unit) error {
+		return u.Add(ctx, test.Foo{ID: 1}, test.Foo{ID: 2})
+	})
+
+	// assert.
+	record, err := workreplay.NewReader(buf).Next()
+	s.Require().NoError(err)
+	fooType := work.TypeNameOf(test.Foo{}).String()
+	s.Require().Len(record.Additions[fooType], 2)
+	s.JSONEq(`{"ID":1}`, string(record.Additions[fooType][0]))
+	s.Empty(record.Alterations)
+	s.False(record.Time.IsZero())
+}
+
+func (s *ReplayTestSuite) TestRunner_Run() {
+	// arrange.
+	ctx := context.Background()
+	fooType := work.TypeNameOf(test.Foo{})
+	buf := s.recorded(
+		func(u work.Unit) error { return u.Add(ctx, test.Foo{ID: 1}) },
+		func(u work.Unit) error {
+			if err := u.Alter(ctx, test.Foo{ID: 2}); err != nil {
+				return err
+			}
+			return u.Remove(ctx, test.Foo{ID: 3})
+		},
+		func(u work.Unit) error {
+			return u.Patch(ctx, fooType, 4, map[string]interface{}{"name": "four"})
+		},
+	)
+	sut := workreplay.NewRunner(s.uniter, s.codecs)
+
+	// action.
+	report, err := sut.Run(ctx, buf)
+
+	// assert.
+	s.Require().NoError(err)
+	s.Equal(workreplay.Report{Records: 3, Saved: 3}, report)
+	s.Equal([]interface{}{test.Foo{ID: 1}}, s.inserted)
+	s.Equal([]interface{}{test.Foo{ID: 2}}, s.updated)
+	s.Equal([]interface{}{test.Foo{ID: 3}}, s.deleted)
+	s.Require().Len(s.patched, 1)
+	s.Equal(4, s.patched[0].ID)
+	s.Equal(map[string]interface{}{"name": "four"}, s.patched[0].Fields)
+}
+
+func (s *ReplayTestSuite) TestRunner_Run_DryRun() {
+	// arrange.
+	ctx := context.Background()
+	buf := s.recorded(func(u work.Unit) error { return u.Add(ctx, test.Foo{ID: 1}) })
+	sut := workreplay.NewRunner(s.uniter, s.codecs, workreplay.DryRun())
+
+	// action.
+	report, err := sut.Run(ctx, buf)
+
+	// assert.
+	s.Require().NoError(err)
+	s.Equal(workreplay.Report{Records: 1}, report)
+	s.Empty(s.inserted)
+}
+
+func (s *ReplayTestSuite) TestRunner_Run_Failures() {
+	// arrange.
+	ctx := context.Background()
+	buf := s.recorded(
+		func(u work.Unit) error { return u.Add(ctx, test.Foo{ID: 1}) },
+		func(u work.Unit) error { return u.Add(ctx, test.Foo{ID: 2}) },
+	)
+	s.saveErr = errors.New("whoa")
+	sut := workreplay.NewRunner(s.uniter, s.codecs, workreplay.Concurrency(2))
+
+	// action.
+	report, err := sut.Run(ctx, buf)
+
+	// assert.
+	s.Require().NoError(err)
+	s.Equal(2, report.Records)
+	s.Equal(2, report.Failed)
+	s.Zero(report.Saved)
+	s.ErrorContains(report.Err, "whoa")
+}
+
+func (s *ReplayTestSuite) TestRunner_Run_UnknownType() {
+	// arrange.
+	ctx := context.Background()
+	buf := s.recorded(func(u work.Unit) error { return u.Add(ctx, test.Foo{ID: 1}) })
+	sut := workreplay.NewRunner(s.uniter, map[work.TypeName]workreplay.Codec{})
+
+	// action.
+	report, err := sut.Run(ctx, buf)
+
+	// assert.
+	s.Require().NoError(err)
+	s.Equal(1, report.Failed)
+	s.ErrorIs(report.Err, workreplay.ErrUnknownType)
+}
+
+func (s *ReplayTestSuite) TestRunner_Run_Speed() {
+	// arrange.
+	ctx := context.Background()
+	fooType := work.TypeNameOf(test.Foo{}).String()
+	var buf bytes.Buffer
+	rec := workreplay.NewRecorder(&buf)
+	now := time.Now()
+	for i, offset := range []time.Duration{0, 100 * time.Millisecond} {
+		record := workreplay.Record{Time: now.Add(offset)}
+		record.Additions = map[string][]json.RawMessage{fooType: {json.RawMessage(fmt.Sprintf(`{"ID":%d}`, i))}}
+		s.Require().NoError(rec.Write(record))
+	}
+	sut := workreplay.NewRunner(s.uniter, s.codecs, workreplay.Speed(2))
+
+	// action.
+	start := time.Now()
+	report, err := sut.Run(ctx, &buf)
+
+	// assert.
+	s.Require().NoError(err)
+	s.Equal(2, report.Saved)
+	s.GreaterOrEqual(time.Since(start), 50*time.Millisecond)
+}
+
+func (s *ReplayTestSuite) TestRunner_Run_Malformed() {
+	// arrange.
+	sut := workreplay.NewRunner(s.uniter, s.codecs)
+
+	// action.
+	_, err := sut.Run(context.Background(), strings.NewReader("{"))
+
+	// assert.
+	s.Error(err)
+}
@@ -0,0 +1,293 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package workreplay
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/freerware/work/v4"
+	"go.uber.org/multierr"
+)
+
+// ErrUnknownType represents the error that is returned when a record
+// contains entities of a type without a codec.
+var ErrUnknownType = errors.New("no codec for type")
+
+// Codec decodes the recorded entities of a particular type.
+type Codec struct {
+	// Entity decodes a recorded entity.
+	Entity func(json.RawMessage) (interface{}, error)
+	// ID decodes the recorded identity of a patched entity. When nil,
+	// identities are decoded as generic JSON values.
+	ID func(json.RawMessage) (interface{}, error)
+}
+
+func decode[T any](b json.RawMessage) (interface{}, error) {
+	var v T
+	if err := json.Unmarshal(b, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// Entity provides the codec that decodes recorded entities as values of
+// type T.
+func Entity[T any]() Codec {
+	return Codec{Entity: decode[T]}
+}
+
+// EntityWithID provides the codec that decodes recorded entities as values
+// of type T, and the identities of patched entities as values of type ID.
+func EntityWithID[T, ID any]() Codec {
+	return Codec{Entity: decode[T], ID: decode[ID]}
+}
+
+// Report represents the outcome of replaying records.
+type Report struct {
+	// Records is the number of records replayed.
+	Records int
+	// Saved is the number of records saved successfully, which is zero for
+	// dry runs.
+	Saved int
+	// Failed is the number of records that failed to be staged or saved.
+	Failed int
+	// Err combines the errors of the records that failed.
+	Err error
+}
+
+// Options represents the configuration options for the runner.
+type Options struct {
+	dryRun      bool
+	concurrency int
+	speed       float64
+}
+
+// Option applies an option to the provided configuration.
+type Option func(*Options)
+
+var (
+	// DryRun specifies that records are staged within work units without
+	// saving them, verifying that each can be decoded and staged.
+	DryRun = func() Option {
+		return func(o *Options) {
+			o.dryRun = true
+		}
+	}
+
+	// Concurrency defines the number of records replayed concurrently,
+	// which defaults to 1.
+	Concurrency = func(n int) Option {
+		if n < 1 {
+			n = 1
+		}
+		return func(o *Options) {
+			o.concurrency = n
+		}
+	}
+
+	// Speed defines the pace at which records are replayed relative to when
+	// they were recorded, such that 1 preserves the recorded pacing and 2
+	// replays twice as fast. Records are replayed as fast as possible by
+	// default.
+	Speed = func(factor float64) Option {
+		return func(o *Options) {
+			o.speed = factor
+		}
+	}
+)
+
+// Runner replays recorded saves within new work units.
+type Runner struct {
+	uniter  work.Uniter
+	codecs  map[work.TypeName]Codec
+	options Options
+}
+
+// NewRunner constructs a runner that replays records within work units
+// constructed by the provided uniter, decoding entities with the codecs of
+// their types.
+func NewRunner(uniter work.Uniter, codecs map[work.TypeName]Codec, opts ...Option) *Runner {
+	o := Options{concurrency: 1}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return &Runner{uniter: uniter, codecs: codecs, options: o}
+}
+
+func (r *Runner) codec(typeName string) (Codec, error) {
+	c, ok := r.codecs[work.TypeName(typeName)]
+	if !ok {
+		return Codec{}, fmt.Errorf("%w %s", ErrUnknownType, typeName)
+	}
+	return c, nil
+}
+
+func (r *Runner) entities(recorded map[string][]json.RawMessage) ([]interface{}, error) {
+	var entities []interface{}
+	for typeName, encoded := range recorded {
+		c, err := r.codec(typeName)
+		if err != nil {
+			return nil, err
+		}
+		for _, b := range encoded {
+			entity, err := c.Entity(b)
+			if err != nil {
+				return nil, fmt.Errorf("unable to decode %s: %w", typeName, err)
+			}
+			entities = append(entities, entity)
+		}
+	}
+	return entities, nil
+}
+
+// stage stages the provided record within the provided work unit.
+func (r *Runner) stage(ctx context.Context, u work.Unit, record Record) error {
+	for _, s := range []struct {
+		entities map[string][]json.RawMessage
+		stage    func(context.Context, ...interface{}) error
+	}{
+		{record.Additions, u.Add},
+		{record.Alterations, u.Alter},
+		{record.Removals, u.Remove},
+		{record.Upserts, u.Upsert},
+	} {
+		entities, err := r.entities(s.entities)
+		if err != nil {
+			return err
+		}
+		if len(entities) == 0 {
+			continue
+		}
+		if err := s.stage(ctx, entities...); err != nil {
+			return err
+		}
+	}
+	for typeName, patches := range record.Patches {
+		c, err := r.codec(typeName)
+		if err != nil {
+			return err
+		}
+		decodeID := c.ID
+		if decodeID == nil {
+			decodeID = decode[interface{}]
+		}
+		for _, p := range patches {
+			id, err := decodeID(p.ID)
+			if err != nil {
+				return fmt.Errorf("unable to decode %s patch: %w", typeName, err)
+			}
+			if err := u.Patch(ctx, work.TypeName(typeName), id, p.Fields); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Replay stages the provided record within a new work unit, saving it
+// unless performing a dry run.
+func (r *Runner) Replay(ctx context.Context, record Record) error {
+	u, err := r.uniter.Unit()
+	if err != nil {
+		return err
+	}
+	if err = r.stage(ctx, u, record); err != nil || r.options.dryRun {
+		return err
+	}
+	return u.Save(ctx)
+}
+
+// wait blocks until the provided record is due to be replayed, relative to
+// the provided time the run started and the time of the first record.
+func (r *Runner) wait(ctx context.Context, started time.Time, first, record Record) error {
+	if r.options.speed <= 0 {
+		return nil
+	}
+	offset := time.Duration(float64(record.Time.Sub(first.Time)) / r.options.speed)
+	delay := time.Until(started.Add(offset))
+	if delay <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// Run replays the records read from the provided reader until all have been
+// replayed. Records that fail are reported rather than stopping the run,
+// while an error is returned when the records cannot be read or the context
+// is done.
+func (r *Runner) Run(ctx context.Context, reader io.Reader) (report Report, err error) {
+	var (
+		mutex   sync.Mutex
+		wg      sync.WaitGroup
+		first   Record
+		started = time.Now()
+		sem     = make(chan struct{}, r.options.concurrency)
+		records = NewReader(reader)
+	)
+	// the report is complete once the records being replayed are.
+	defer wg.Wait()
+	for i := 0; ; i++ {
+		var record Record
+		if record, err = records.Next(); err != nil {
+			if errors.Is(err, io.EOF) {
+				err = nil
+			}
+			return
+		}
+		if i == 0 {
+			first = record
+		}
+		if err = r.wait(ctx, started, first, record); err != nil {
+			return
+		}
+		select {
+		case <-ctx.Done():
+			err = ctx.Err()
+			return
+		case sem <- struct{}{}:
+		}
+		wg.Add(1)
+		go func(record Record) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			replayErr := r.Replay(ctx, record)
+			mutex.Lock()
+			defer mutex.Unlock()
+			report.Records = report.Records + 1
+			if replayErr != nil {
+				report.Failed = report.Failed + 1
+				report.Err = multierr.Append(report.Err, replayErr)
+			} else if !r.options.dryRun {
+				report.Saved = report.Saved + 1
+			}
+		}(record)
+	}
+}
@@ -0,0 +1,328 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package workrest provides a data mapper that applies the changes committed
+// by work units through REST APIs, mapping inserts, updates, and deletes to
+// POST, PUT, and DELETE requests against the resources of each entity type:
+//
+//	m := workrest.NewMapper(http.DefaultClient, map[work.TypeName]workrest.Resource{
+//		work.TypeNameOf(Order{}): {
+//			URL: "https://orders.example.com/orders",
+//			Compensations: map[work.UnitOperation]workrest.Request{
+//				work.UnitOperationDelete: {Method: http.MethodPost, Path: "/{id}/cancel"},
+//			},
+//		},
+//	})
+//	u, err := work.NewUnit(work.UnitDataMappers(m.DataMappers()))
+//
+// Requests cannot be applied atomically, so the mapper is intended for best
+// effort work units, which are those created without the work.UnitDB
+// option, whose rollbacks compensate for the entities applied by earlier
+// data mapper calls. Entities applied by a call that fails partway through
+// are not compensated for, and are reported by PartialFailureError.
+package workrest
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/freerware/work/v4"
+)
+
+var (
+	// ErrRequestFailed represents the error that is returned when a request
+	// is responded to with an unsuccessful status.
+	ErrRequestFailed = errors.New("request failed")
+
+	// ErrMissingResource represents the error that is returned when applying
+	// entities of a type without a resource.
+	ErrMissingResource = errors.New("no resource for entity type")
+)
+
+// PartialFailureError represents the error that is returned when applying
+// entities fails after some of them were applied, which are not compensated
+// for when the work unit is rolled back.
+type PartialFailureError struct {
+	// Operation is the operation being applied.
+	Operation work.UnitOperation
+	// Applied are the entities that were applied before the failure.
+	Applied []interface{}
+	// Remaining are the entities that were not applied, starting with the
+	// entity that failed to be applied.
+	Remaining []interface{}
+	// Err is the error encountered applying the first remaining entity.
+	Err error
+}
+
+// Error provides the error message.
+func (e *PartialFailureError) Error() string {
+	applied := make([]string, 0, len(e.Applied))
+	for _, entity := range e.Applied {
+		id, _ := work.IDOf(entity)
+		applied = append(applied, fmt.Sprintf("%s %v", work.TypeNameOf(entity), id))
+	}
+	return fmt.Sprintf("%v (%s applied to %d of %d entities: %s)", e.Err, e.Operation,
+		len(e.Applied), len(e.Applied)+len(e.Remaining), strings.Join(applied, ", "))
+}
+
+// Unwrap provides the error encountered applying the first remaining entity.
+func (e *PartialFailureError) Unwrap() error {
+	return e.Err
+}
+
+// Request describes a request issued for an entity.
+type Request struct {
+	// Method is the HTTP method of the request. Requests without a method
+	// are not issued, such as for changes that cannot be compensated for.
+	Method string
+	// Path is the path of the request relative to the URL of the resource,
+	// where each occurrence of {id} is replaced with the escaped identifier
+	// of the entity, such as "/{id}/cancel".
+	Path string
+	// Body indicates if the entity is sent as the body of the request.
+	Body bool
+}
+
+// Resource describes the REST API resource that entities of a particular
+// type are applied to.
+type Resource struct {
+	// URL is the URL of the collection of the resource, such as
+	// "https://orders.example.com/orders".
+	URL string
+	// ID provides the identifier of the entity. When omitted, the identity
+	// of the entity as provided by work.IDOf is used.
+	ID func(entity interface{}) (string, error)
+	// Body provides the body of the requests for the entity. When omitted,
+	// the entity itself is used.
+	Body func(entity interface{}) (interface{}, error)
+	// Requests overrides the requests issued for each operation. By default,
+	// inserts are POSTed to the collection, updates are PUT to "/{id}", and
+	// deletes are DELETEd from "/{id}".
+	Requests map[work.UnitOperation]Request
+	// Compensations are the requests issued in place of those for each
+	// operation when compensating for the changes applied earlier within a
+	// save that is being rolled back. For instance, inserted entities are
+	// compensated for by deleting them, which some APIs model as cancelling
+	// them instead.
+	Compensations map[work.UnitOperation]Request
+}
+
+var defaultRequests = map[work.UnitOperation]Request{
+	work.UnitOperationInsert: {Method: http.MethodPost, Body: true},
+	work.UnitOperationUpdate: {Method: http.MethodPut, Path: "/{id}", Body: true},
+	work.UnitOperationDelete: {Method: http.MethodDelete, Path: "/{id}"},
+}
+
+func (r Resource) request(op work.UnitOperation, compensating bool) Request {
+	if req, ok := r.Compensations[op]; ok && compensating {
+		return req
+	}
+	if req, ok := r.Requests[op]; ok {
+		return req
+	}
+	return defaultRequests[op]
+}
+
+func (r Resource) id(entity interface{}) (string, error) {
+	if r.ID != nil {
+		return r.ID(entity)
+	}
+	id, ok := work.IDOf(entity)
+	if !ok {
+		return "", fmt.Errorf("unable to determine identifier for %s", work.TypeNameOf(entity))
+	}
+	return fmt.Sprint(id), nil
+}
+
+func (r Resource) body(entity interface{}) (interface{}, error) {
+	if r.Body != nil {
+		return r.Body(entity)
+	}
+	return entity, nil
+}
+
+// Options represents the configuration options for the mapper.
+type Options struct {
+	header            http.Header
+	idempotencyHeader string
+}
+
+// Option applies an option to the provided configuration.
+type Option func(*Options)
+
+var (
+	// Header defines the headers included with each request, such as for
+	// authorization.
+	Header = func(header http.Header) Option {
+		return func(o *Options) {
+			o.header = header
+		}
+	}
+
+	// IdempotencyKeyHeader defines the name of the header carrying the
+	// idempotency key of each request, which defaults to "Idempotency-Key".
+	// An empty name omits idempotency keys.
+	IdempotencyKeyHeader = func(name string) Option {
+		return func(o *Options) {
+			o.idempotencyHeader = name
+		}
+	}
+)
+
+// Mapper is a data mapper that applies entities through REST APIs. Each
+// entity is applied with its own request, carrying an idempotency key
+// derived from the save attempt, the request, and the identity of the
+// entity, so that requests retried by the HTTP client or intermediaries are
+// applied once. Since the keys differ between attempts, entities
+// compensated for when an attempt is rolled back are applied again when the
+// save is retried. Responses with 409 Conflict or 412 Precondition Failed
+// result in work.UnitStaleEntityError, while deleting entities that do not
+// exist succeeds. Failures occurring after some of the entities provided to
+// a call were applied result in PartialFailureError.
+type Mapper struct {
+	client    *http.Client
+	resources map[work.TypeName]Resource
+	options   Options
+}
+
+// NewMapper constructs a mapper that issues requests with the provided
+// client for the entity types with the provided resources. When a nil
+// http.Client is provided, http.DefaultClient is used.
+func NewMapper(client *http.Client, resources map[work.TypeName]Resource, opts ...Option) *Mapper {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	o := Options{idempotencyHeader: "Idempotency-Key"}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return &Mapper{client: client, resources: resources, options: o}
+}
+
+// DataMappers provides the mapper for each of the entity types with a
+// resource, for use with the work.UnitDataMappers option.
+func (m *Mapper) DataMappers() map[work.TypeName]work.UnitDataMapper {
+	mappers := make(map[work.TypeName]work.UnitDataMapper, len(m.resources))
+	for t := range m.resources {
+		mappers[t] = m
+	}
+	return mappers
+}
+
+// idempotencyKey provides the idempotency key for the provided request.
+func idempotencyKey(mCtx work.UnitMapperContext, req Request, t work.TypeName, id string) string {
+	h := sha256.New()
+	for _, part := range []string{mCtx.AttemptID, req.Method, req.Path, t.String(), id} {
+		h.Write([]byte(part))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func (m *Mapper) do(
+	ctx context.Context, mCtx work.UnitMapperContext, op work.UnitOperation, entity interface{}) error {
+	t := work.TypeNameOf(entity)
+	r, ok := m.resources[t]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrMissingResource, t)
+	}
+	req := r.request(op, mCtx.Compensating())
+	if req.Method == "" {
+		return nil
+	}
+	id, err := r.id(entity)
+	if err != nil {
+		return err
+	}
+	var body io.Reader
+	if req.Body {
+		b, err := r.body(entity)
+		if err != nil {
+			return err
+		}
+		encoded, err := json.Marshal(b)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(encoded)
+	}
+	u := strings.TrimSuffix(r.URL, "/") + strings.ReplaceAll(req.Path, "{id}", url.PathEscape(id))
+	httpReq, err := http.NewRequestWithContext(ctx, req.Method, u, body)
+	if err != nil {
+		return err
+	}
+	for k, v := range m.options.header {
+		httpReq.Header[k] = v
+	}
+	if body != nil {
+		httpReq.Header.Set("Content-Type", "application/json")
+	}
+	if m.options.idempotencyHeader != "" {
+		httpReq.Header.Set(m.options.idempotencyHeader, idempotencyKey(mCtx, req, t, id))
+	}
+	resp, err := m.client.Do(httpReq)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	switch {
+	case resp.StatusCode < http.StatusMultipleChoices:
+		return nil
+	case resp.StatusCode == http.StatusNotFound && req.Method == http.MethodDelete:
+		return nil
+	case resp.StatusCode == http.StatusConflict || resp.StatusCode == http.StatusPreconditionFailed:
+		return &work.UnitStaleEntityError{Entity: entity}
+	}
+	msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	return fmt.Errorf("%w: %s %s: %d %s", ErrRequestFailed, req.Method, u, resp.StatusCode, bytes.TrimSpace(msg))
+}
+
+func (m *Mapper) apply(
+	ctx context.Context, mCtx work.UnitMapperContext, op work.UnitOperation, entities []interface{}) error {
+	for i, entity := range entities {
+		err := m.do(ctx, mCtx, op, entity)
+		if err != nil && i > 0 {
+			return &PartialFailureError{Operation: op, Applied: entities[:i], Remaining: entities[i:], Err: err}
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Insert issues the insert request for each of the provided entities.
+func (m *Mapper) Insert(ctx context.Context, mCtx work.UnitMapperContext, entities ...interface{}) error {
+	return m.apply(ctx, mCtx, work.UnitOperationInsert, entities)
+}
+
+// Update issues the update request for each of the provided entities.
+func (m *Mapper) Update(ctx context.Context, mCtx work.UnitMapperContext, entities ...interface{}) error {
+	return m.apply(ctx, mCtx, work.UnitOperationUpdate, entities)
+}
+
+// Delete issues the delete request for each of the provided entities.
+func (m *Mapper) Delete(ctx context.Context, mCtx work.UnitMapperContext, entities ...interface{}) error {
+	return m.apply(ctx, mCtx, work.UnitOperationDelete, entities)
+}
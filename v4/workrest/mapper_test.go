@@ -0,0 +1,264 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package workrest_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/freerware/work/v4"
+	"github.com/freerware/work/v4/internal/test"
+	"github.com/freerware/work/v4/workrest"
+	"github.com/stretchr/testify/suite"
+)
+
+type request struct {
+	method string
+	path   string
+	key    string
+	auth   string
+	body   string
+}
+
+type MapperTestSuite struct {
+	suite.Suite
+
+	server    *httptest.Server
+	requests  []request
+	statuses  map[string]int
+	resources map[work.TypeName]workrest.Resource
+}
+
+func TestMapperTestSuite(t *testing.T) {
+	suite.Run(t, new(MapperTestSuite))
+}
+
+func (s *MapperTestSuite) SetupTest() {
+	s.requests = nil
+	s.statuses = make(map[string]int)
+	s.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		s.requests = append(s.requests, request{
+			method: r.Method,
+			path:   r.URL.EscapedPath(),
+			key:    r.Header.Get("Idempotency-Key"),
+			auth:   r.Header.Get("Authorization"),
+			body:   string(body),
+		})
+		if status, ok := s.statuses[r.Method+" "+r.URL.Path]; ok {
+			w.WriteHeader(status)
+		}
+	}))
+	s.resources = map[work.TypeName]workrest.Resource{
+		work.TypeNameOf(test.Foo{}): {URL: s.server.URL + "/foos/"},
+		work.TypeNameOf(test.Bar{}): {
+			URL: s.server.URL + "/bars",
+			Compensations: map[work.UnitOperation]workrest.Request{
+				work.UnitOperationDelete: {Method: http.MethodPost, Path: "/{id}/cancel"},
+			},
+		},
+	}
+}
+
+func (s *MapperTestSuite) TearDownTest() {
+	s.server.Close()
+}
+
+func (s *MapperTestSuite) TestMapper() {
+	// arrange.
+	ctx := context.Background()
+	mCtx := work.UnitMapperContext{AttemptID: "attempt"}
+	header := http.Header{"Authorization": []string{"Bearer token"}}
+	sut := workrest.NewMapper(nil, s.resources, workrest.Header(header))
+
+	// action.
+	errInsert := sut.Insert(ctx, mCtx, test.Foo{ID: 1})
+	errUpdate := sut.Update(ctx, mCtx, test.Foo{ID: 2})
+	errDelete := sut.Delete(ctx, mCtx, test.Foo{ID: 3})
+
+	// assert.
+	s.Require().NoError(errInsert)
+	s.Require().NoError(errUpdate)
+	s.Require().NoError(errDelete)
+	s.Require().Len(s.requests, 3)
+	s.Equal(http.MethodPost, s.requests[0].method)
+	s.Equal("/foos", s.requests[0].path)
+	s.JSONEq(`{"ID":1}`, s.requests[0].body)
+	s.Equal(http.MethodPut, s.requests[1].method)
+	s.Equal("/foos/2", s.requests[1].path)
+	s.JSONEq(`{"ID":2}`, s.requests[1].body)
+	s.Equal(http.MethodDelete, s.requests[2].method)
+	s.Equal("/foos/3", s.requests[2].path)
+	s.Empty(s.requests[2].body)
+	keys := make(map[string]struct{})
+	for _, r := range s.requests {
+		s.Equal("Bearer token", r.auth)
+		s.NotEmpty(r.key)
+		keys[r.key] = struct{}{}
+	}
+	s.Len(keys, 3)
+}
+
+func (s *MapperTestSuite) TestMapper_IdempotencyKey() {
+	// arrange.
+	ctx := context.Background()
+	sut := workrest.NewMapper(nil, s.resources)
+
+	// action.
+	s.Require().NoError(sut.Insert(ctx, work.UnitMapperContext{AttemptID: "a"}, test.Foo{ID: 1}))
+	s.Require().NoError(sut.Insert(ctx, work.UnitMapperContext{AttemptID: "a"}, test.Foo{ID: 1}))
+	s.Require().NoError(sut.Insert(ctx, work.UnitMapperContext{AttemptID: "b"}, test.Foo{ID: 1}))
+
+	// assert.
+	s.Require().Len(s.requests, 3)
+	s.Equal(s.requests[0].key, s.requests[1].key)
+	s.NotEqual(s.requests[0].key, s.requests[2].key)
+}
+
+func (s *MapperTestSuite) TestMapper_IdempotencyKeyHeaderOmitted() {
+	// arrange.
+	sut := workrest.NewMapper(nil, s.resources, workrest.IdempotencyKeyHeader(""))
+
+	// action.
+	err := sut.Insert(context.Background(), work.UnitMapperContext{}, test.Foo{ID: 1})
+
+	// assert.
+	s.Require().NoError(err)
+	s.Require().Len(s.requests, 1)
+	s.Empty(s.requests[0].key)
+}
+
+func (s *MapperTestSuite) TestMapper_Errors() {
+	// arrange.
+	ctx := context.Background()
+	s.statuses["PUT /foos/1"] = http.StatusConflict
+	s.statuses["DELETE /foos/2"] = http.StatusNotFound
+	s.statuses["POST /foos"] = http.StatusBadRequest
+	sut := workrest.NewMapper(nil, s.resources)
+
+	// action.
+	errUpdate := sut.Update(ctx, work.UnitMapperContext{}, test.Foo{ID: 1})
+	errDelete := sut.Delete(ctx, work.UnitMapperContext{}, test.Foo{ID: 2})
+	errInsert := sut.Insert(ctx, work.UnitMapperContext{}, test.Foo{ID: 3})
+	errMissing := sut.Insert(ctx, work.UnitMapperContext{}, test.Baz{})
+
+	// assert.
+	var staleErr *work.UnitStaleEntityError
+	s.Require().ErrorAs(errUpdate, &staleErr)
+	s.Equal(test.Foo{ID: 1}, staleErr.Entity)
+	s.NoError(errDelete)
+	s.ErrorIs(errInsert, workrest.ErrRequestFailed)
+	s.ErrorIs(errMissing, workrest.ErrMissingResource)
+}
+
+func (s *MapperTestSuite) TestMapper_PartialFailure() {
+	// arrange.
+	ctx := context.Background()
+	s.statuses["PUT /foos/2"] = http.StatusInternalServerError
+	foos := []interface{}{test.Foo{ID: 1}, test.Foo{ID: 2}, test.Foo{ID: 3}}
+	sut := workrest.NewMapper(nil, s.resources)
+
+	// action.
+	err := sut.Update(ctx, work.UnitMapperContext{}, foos...)
+
+	// assert.
+	s.ErrorIs(err, workrest.ErrRequestFailed)
+	var partialErr *workrest.PartialFailureError
+	s.Require().ErrorAs(err, &partialErr)
+	s.Equal(work.UnitOperationUpdate, partialErr.Operation)
+	s.Equal(foos[:1], partialErr.Applied)
+	s.Equal(foos[1:], partialErr.Remaining)
+	s.Contains(err.Error(), "update applied to 1 of 3 entities: test.Foo 1")
+	s.Equal([]string{"PUT /foos/1", "PUT /foos/2"}, s.paths())
+}
+
+func (s *MapperTestSuite) TestMapper_PartialFailure_FirstEntity() {
+	// arrange.
+	ctx := context.Background()
+	s.statuses["PUT /foos/1"] = http.StatusConflict
+	sut := workrest.NewMapper(nil, s.resources)
+
+	// action.
+	err := sut.Update(ctx, work.UnitMapperContext{}, test.Foo{ID: 1}, test.Foo{ID: 2})
+
+	// assert.
+	var partialErr *workrest.PartialFailureError
+	s.False(errors.As(err, &partialErr))
+	var staleErr *work.UnitStaleEntityError
+	s.ErrorAs(err, &staleErr)
+}
+
+func (s *MapperTestSuite) TestMapper_Compensation() {
+	// arrange.
+	ctx := context.Background()
+	s.statuses["DELETE /foos/1"] = http.StatusInternalServerError
+	sut := workrest.NewMapper(nil, s.resources)
+	u, err := work.NewUnit(work.UnitDataMappers(sut.DataMappers()), work.UnitRetryAttempts(1))
+	s.Require().NoError(err)
+	s.Require().NoError(u.Register(ctx, test.Foo{ID: 1}))
+	s.Require().NoError(u.Add(ctx, test.Bar{ID: "a/b"}))
+	s.Require().NoError(u.Remove(ctx, test.Foo{ID: 1}))
+
+	// action.
+	err = u.Save(ctx)
+
+	// assert.
+	s.ErrorIs(err, workrest.ErrRequestFailed)
+	s.Equal([]string{
+		"POST /bars",
+		"DELETE /foos/1",
+		"PUT /foos/1",
+		"POST /bars/a%2Fb/cancel",
+	}, s.paths())
+}
+
+func (s *MapperTestSuite) TestMapper_CompensationSkipped() {
+	// arrange.
+	ctx := context.Background()
+	s.statuses["DELETE /bars/a"] = http.StatusInternalServerError
+	s.resources[work.TypeNameOf(test.Foo{})] = workrest.Resource{
+		URL: s.server.URL + "/foos",
+		Compensations: map[work.UnitOperation]workrest.Request{
+			work.UnitOperationDelete: {},
+		},
+	}
+	sut := workrest.NewMapper(nil, s.resources)
+	u, err := work.NewUnit(work.UnitDataMappers(sut.DataMappers()), work.UnitRetryAttempts(1))
+	s.Require().NoError(err)
+	s.Require().NoError(u.Register(ctx, test.Bar{ID: "a"}))
+	s.Require().NoError(u.Add(ctx, test.Foo{ID: 1}))
+	s.Require().NoError(u.Remove(ctx, test.Bar{ID: "a"}))
+
+	// action.
+	err = u.Save(ctx)
+
+	// assert.
+	s.ErrorIs(err, workrest.ErrRequestFailed)
+	s.Equal([]string{"POST /foos", "DELETE /bars/a", "PUT /bars/a"}, s.paths())
+}
+
+// paths provides the method and path of each request received.
+func (s *MapperTestSuite) paths() []string {
+	var paths []string
+	for _, r := range s.requests {
+		paths = append(paths, r.method+" "+r.path)
+	}
+	return paths
+}
@@ -0,0 +1,226 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worksearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// ErrBulkFailed represents the error that is returned when one or more of
+// the operations within a bulk request fail.
+var ErrBulkFailed = errors.New("bulk request failed")
+
+// BulkOperationType represents the type of bulk operation.
+type BulkOperationType string
+
+// The types of bulk operations that are performed.
+const (
+	// BulkOperationTypeIndex indicates an operation that indexes a document,
+	// replacing it should it already exist.
+	BulkOperationTypeIndex BulkOperationType = "index"
+	// BulkOperationTypeCreate indicates an operation that indexes a document,
+	// failing should it already exist.
+	BulkOperationTypeCreate BulkOperationType = "create"
+	// BulkOperationTypeUpdate indicates an operation that partially updates
+	// a document, creating it should it not exist.
+	BulkOperationTypeUpdate BulkOperationType = "update"
+	// BulkOperationTypeDelete indicates an operation that deletes a document.
+	BulkOperationTypeDelete BulkOperationType = "delete"
+)
+
+// BulkOperation represents a single operation within a bulk request.
+type BulkOperation struct {
+	// Type is the type of operation.
+	Type BulkOperationType
+	// Index is the name of the index the document belongs to.
+	Index string
+	// ID is the identifier of the document.
+	ID string
+	// Document is the body of the document, which is omitted for deletes.
+	Document interface{}
+	// MustExist indicates that an update fails when the document does not
+	// exist, rather than creating it.
+	MustExist bool
+}
+
+// BulkItem represents the outcome of a single operation within a bulk
+// request.
+type BulkItem struct {
+	// Type is the type of operation.
+	Type BulkOperationType
+	// ID is the identifier of the document.
+	ID string
+	// Status is the HTTP status of the operation.
+	Status int
+	// Error is the error encountered by the operation, if any.
+	Error json.RawMessage
+}
+
+// Failed determines if the operation failed. Deleting a document that does
+// not exist is not a failure.
+func (i BulkItem) Failed() bool {
+	if i.Status == http.StatusNotFound && i.Type == BulkOperationTypeDelete {
+		return false
+	}
+	return i.Status >= http.StatusMultipleChoices
+}
+
+// Client represents a client capable of issuing bulk requests against an
+// Elasticsearch or OpenSearch cluster.
+type Client interface {
+	Bulk(context.Context, []BulkOperation) error
+}
+
+// ItemClient represents a client capable of issuing bulk requests that
+// provides the outcome of each operation within the request.
+type ItemClient interface {
+	Client
+	BulkItems(context.Context, []BulkOperation) ([]BulkItem, error)
+}
+
+// HTTPClient is a Client that issues bulk requests against the bulk API
+// using net/http, which is compatible with both Elasticsearch and OpenSearch.
+type HTTPClient struct {
+	url    string
+	client *http.Client
+	header http.Header
+}
+
+// NewHTTPClient constructs a client that issues bulk requests to the cluster
+// at the provided URL. When a nil http.Client is provided,
+// http.DefaultClient is used.
+func NewHTTPClient(url string, client *http.Client, header http.Header) *HTTPClient {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &HTTPClient{
+		url:    strings.TrimSuffix(url, "/"),
+		client: client,
+		header: header,
+	}
+}
+
+type bulkAction struct {
+	Index string `json:"_index"`
+	ID    string `json:"_id"`
+}
+
+type bulkUpdate struct {
+	Doc         interface{} `json:"doc"`
+	DocAsUpsert bool        `json:"doc_as_upsert"`
+}
+
+type bulkResponse struct {
+	Errors bool `json:"errors"`
+	Items  []map[string]struct {
+		ID     string          `json:"_id"`
+		Status int             `json:"status"`
+		Error  json.RawMessage `json:"error,omitempty"`
+	} `json:"items"`
+}
+
+func encode(operations []BulkOperation) (*bytes.Buffer, error) {
+	body := &bytes.Buffer{}
+	enc := json.NewEncoder(body)
+	for _, op := range operations {
+		action := map[BulkOperationType]bulkAction{
+			op.Type: {Index: op.Index, ID: op.ID},
+		}
+		if err := enc.Encode(action); err != nil {
+			return nil, err
+		}
+		var err error
+		switch op.Type {
+		case BulkOperationTypeIndex, BulkOperationTypeCreate:
+			err = enc.Encode(op.Document)
+		case BulkOperationTypeUpdate:
+			err = enc.Encode(bulkUpdate{Doc: op.Document, DocAsUpsert: !op.MustExist})
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return body, nil
+}
+
+// Bulk issues a single bulk request containing the provided operations.
+func (c *HTTPClient) Bulk(ctx context.Context, operations []BulkOperation) error {
+	items, err := c.BulkItems(ctx, operations)
+	if err != nil {
+		return err
+	}
+	var failures []string
+	for _, item := range items {
+		if item.Failed() {
+			failures = append(failures,
+				fmt.Sprintf("%s %s: %d %s", item.Type, item.ID, item.Status, item.Error))
+		}
+	}
+	if len(failures) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%w: %s", ErrBulkFailed, strings.Join(failures, "; "))
+}
+
+// BulkItems issues a single bulk request containing the provided operations,
+// providing the outcome of each operation in the order they were provided.
+func (c *HTTPClient) BulkItems(ctx context.Context, operations []BulkOperation) ([]BulkItem, error) {
+	if len(operations) == 0 {
+		return nil, nil
+	}
+	body, err := encode(operations)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url+"/_bulk", body)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range c.header {
+		req.Header[k] = v
+	}
+	req.Header.Set("Content-Type", "application/x-ndjson")
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		return nil, fmt.Errorf("%w: unexpected status %d", ErrBulkFailed, resp.StatusCode)
+	}
+	var r bulkResponse
+	if err = json.NewDecoder(resp.Body).Decode(&r); err != nil {
+		return nil, err
+	}
+	items := make([]BulkItem, 0, len(r.Items))
+	for _, item := range r.Items {
+		for t, result := range item {
+			items = append(items, BulkItem{
+				Type:   BulkOperationType(t),
+				ID:     result.ID,
+				Status: result.Status,
+				Error:  result.Error,
+			})
+		}
+	}
+	return items, nil
+}
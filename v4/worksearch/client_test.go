@@ -0,0 +1,133 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worksearch_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/freerware/work/v4/worksearch"
+	"github.com/stretchr/testify/suite"
+)
+
+type HTTPClientTestSuite struct {
+	suite.Suite
+
+	server   *httptest.Server
+	lines    []map[string]interface{}
+	response string
+	status   int
+}
+
+func TestHTTPClientTestSuite(t *testing.T) {
+	suite.Run(t, new(HTTPClientTestSuite))
+}
+
+func (s *HTTPClientTestSuite) SetupTest() {
+	s.lines = nil
+	s.response = `{"errors":false,"items":[]}`
+	s.status = http.StatusOK
+	s.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.Equal("/_bulk", r.URL.Path)
+		s.Equal("application/x-ndjson", r.Header.Get("Content-Type"))
+		scanner := bufio.NewScanner(r.Body)
+		for scanner.Scan() {
+			var line map[string]interface{}
+			s.Require().NoError(json.Unmarshal(scanner.Bytes(), &line))
+			s.lines = append(s.lines, line)
+		}
+		w.WriteHeader(s.status)
+		w.Write([]byte(s.response))
+	}))
+}
+
+func (s *HTTPClientTestSuite) TearDownTest() {
+	s.server.Close()
+}
+
+func (s *HTTPClientTestSuite) TestHTTPClient_Bulk() {
+	// arrange.
+	ctx := context.Background()
+	sut := worksearch.NewHTTPClient(s.server.URL+"/", nil, nil)
+	ops := []worksearch.BulkOperation{
+		{Type: worksearch.BulkOperationTypeIndex, Index: "foos", ID: "1", Document: map[string]interface{}{"name": "a"}},
+		{Type: worksearch.BulkOperationTypeUpdate, Index: "foos", ID: "2", Document: map[string]interface{}{"name": "b"}},
+		{Type: worksearch.BulkOperationTypeDelete, Index: "foos", ID: "3"},
+	}
+
+	// action.
+	err := sut.Bulk(ctx, ops)
+
+	// assert.
+	s.Require().NoError(err)
+	s.Require().Len(s.lines, 5)
+	s.Equal(map[string]interface{}{"index": map[string]interface{}{"_index": "foos", "_id": "1"}}, s.lines[0])
+	s.Equal(map[string]interface{}{"name": "a"}, s.lines[1])
+	s.Equal(map[string]interface{}{"update": map[string]interface{}{"_index": "foos", "_id": "2"}}, s.lines[2])
+	s.Equal(map[string]interface{}{"doc": map[string]interface{}{"name": "b"}, "doc_as_upsert": true}, s.lines[3])
+	s.Equal(map[string]interface{}{"delete": map[string]interface{}{"_index": "foos", "_id": "3"}}, s.lines[4])
+}
+
+func (s *HTTPClientTestSuite) TestHTTPClient_Bulk_ItemErrors() {
+	// arrange.
+	ctx := context.Background()
+	s.response = `{"errors":true,"items":[
+		{"delete":{"_id":"3","status":404}},
+		{"index":{"_id":"1","status":400,"error":{"type":"mapper_parsing_exception"}}}
+	]}`
+	sut := worksearch.NewHTTPClient(s.server.URL, nil, nil)
+	ops := []worksearch.BulkOperation{
+		{Type: worksearch.BulkOperationTypeDelete, Index: "foos", ID: "3"},
+		{Type: worksearch.BulkOperationTypeIndex, Index: "foos", ID: "1", Document: struct{}{}},
+	}
+
+	// action.
+	err := sut.Bulk(ctx, ops)
+
+	// assert.
+	s.ErrorIs(err, worksearch.ErrBulkFailed)
+	s.ErrorContains(err, "mapper_parsing_exception")
+	s.NotContains(err.Error(), "delete 3")
+}
+
+func (s *HTTPClientTestSuite) TestHTTPClient_Bulk_UnexpectedStatus() {
+	// arrange.
+	ctx := context.Background()
+	s.status = http.StatusServiceUnavailable
+	sut := worksearch.NewHTTPClient(s.server.URL, nil, nil)
+	ops := []worksearch.BulkOperation{
+		{Type: worksearch.BulkOperationTypeDelete, Index: "foos", ID: "3"},
+	}
+
+	// action.
+	err := sut.Bulk(ctx, ops)
+
+	// assert.
+	s.ErrorIs(err, worksearch.ErrBulkFailed)
+}
+
+func (s *HTTPClientTestSuite) TestHTTPClient_Bulk_Empty() {
+	// action.
+	err := worksearch.NewHTTPClient(s.server.URL, nil, nil).Bulk(context.Background(), nil)
+
+	// assert.
+	s.NoError(err)
+	s.Empty(s.lines)
+}
@@ -0,0 +1,145 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worksearch
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/freerware/work/v4"
+)
+
+// Store stores entities as documents within Elasticsearch or OpenSearch
+// indices, serving as the primary store for work units rather than as a
+// secondary index kept in sync with another store. The changes staged
+// within a work unit are applied with a single bulk request when it is
+// saved:
+//
+//	s := worksearch.NewStore(client, map[work.TypeName]worksearch.Mapping{
+//		work.TypeNameOf(Foo{}): {Index: "foos"},
+//	})
+//	u, err := work.NewUnit(s.Options()...)
+//
+// Additions are created, failing should the document already exist,
+// upserts are indexed, and alterations and patches update documents that
+// must already exist. Operations that conflict with the stored documents
+// fail with work.UnitStaleEntityError.
+type Store struct {
+	client   ItemClient
+	mappings map[work.TypeName]Mapping
+}
+
+// NewStore constructs a store that issues bulk requests with the provided
+// client for the entity types with the provided mappings.
+func NewStore(client ItemClient, mappings map[work.TypeName]Mapping) *Store {
+	return &Store{client: client, mappings: mappings}
+}
+
+// Options provides the options for work units that store the entities of
+// the mapped types with the store.
+func (s *Store) Options() []work.UnitOption {
+	types := make([]work.TypeName, 0, len(s.mappings))
+	for t := range s.mappings {
+		types = append(types, t)
+	}
+	sort.Slice(types, func(i, j int) bool { return types[i] < types[j] })
+	return []work.UnitOption{work.UnitBulkWriter(s.Write, types...)}
+}
+
+func (s *Store) operation(op work.UnitBulkOperation) (BulkOperation, error) {
+	m, ok := s.mappings[op.TypeName]
+	if !ok {
+		return BulkOperation{}, fmt.Errorf("no mapping for %s", op.TypeName)
+	}
+	if op.Operation == work.UnitOperationPatch {
+		return BulkOperation{
+			Type:      BulkOperationTypeUpdate,
+			Index:     m.Index,
+			ID:        fmt.Sprint(op.Patch.ID),
+			Document:  op.Patch.Fields,
+			MustExist: true,
+		}, nil
+	}
+	id, err := m.id(op.Entity)
+	if err != nil {
+		return BulkOperation{}, err
+	}
+	bulkOp := BulkOperation{Index: m.Index, ID: id}
+	switch op.Operation {
+	case work.UnitOperationInsert:
+		bulkOp.Type = BulkOperationTypeCreate
+	case work.UnitOperationUpsert:
+		bulkOp.Type = BulkOperationTypeIndex
+	case work.UnitOperationUpdate:
+		bulkOp.Type = BulkOperationTypeUpdate
+		bulkOp.MustExist = true
+	case work.UnitOperationDelete:
+		bulkOp.Type = BulkOperationTypeDelete
+		return bulkOp, nil
+	default:
+		return BulkOperation{}, fmt.Errorf("unsupported operation %s for %s", op.Operation, op.TypeName)
+	}
+	bulkOp.Document, err = m.document(op.Entity)
+	return bulkOp, err
+}
+
+// itemErr provides the error for the provided outcome of the provided
+// operation, if any. Documents that already exist when created, or that no
+// longer exist when updated, are stale.
+func itemErr(op work.UnitBulkOperation, item BulkItem) error {
+	if !item.Failed() {
+		return nil
+	}
+	stale := item.Status == http.StatusConflict ||
+		(item.Status == http.StatusNotFound && item.Type == BulkOperationTypeUpdate)
+	switch {
+	case stale && op.Operation == work.UnitOperationPatch:
+		return fmt.Errorf("%w: %s %s", work.ErrStaleEntity, op.TypeName, item.ID)
+	case stale:
+		return &work.UnitStaleEntityError{Entity: op.Entity}
+	}
+	return fmt.Errorf("%w: %s %s: %d %s", ErrBulkFailed, item.Type, item.ID, item.Status, item.Error)
+}
+
+// Write applies the provided operations with a single bulk request,
+// providing the outcome of each operation. It satisfies
+// work.UnitBulkWriteFunc.
+func (s *Store) Write(
+	ctx context.Context, mCtx work.UnitMapperContext, operations []work.UnitBulkOperation) ([]error, error) {
+	bulkOps := make([]BulkOperation, 0, len(operations))
+	for _, op := range operations {
+		bulkOp, err := s.operation(op)
+		if err != nil {
+			return nil, err
+		}
+		bulkOps = append(bulkOps, bulkOp)
+	}
+	items, err := s.client.BulkItems(ctx, bulkOps)
+	if err != nil {
+		return nil, err
+	}
+	if len(items) != len(operations) {
+		return nil, fmt.Errorf("%w: received %d items for %d operations",
+			ErrBulkFailed, len(items), len(operations))
+	}
+	errs := make([]error, len(operations))
+	for i, item := range items {
+		errs[i] = itemErr(operations[i], item)
+	}
+	return errs, nil
+}
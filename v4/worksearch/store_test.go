@@ -0,0 +1,164 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worksearch_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/freerware/work/v4"
+	"github.com/freerware/work/v4/internal/test"
+	"github.com/freerware/work/v4/worksearch"
+	"github.com/stretchr/testify/suite"
+)
+
+type itemClient struct {
+	client
+
+	statuses [][]int
+}
+
+func (c *itemClient) BulkItems(
+	ctx context.Context, ops []worksearch.BulkOperation) ([]worksearch.BulkItem, error) {
+	c.requests = append(c.requests, ops)
+	var statuses []int
+	if len(c.statuses) > 0 {
+		statuses, c.statuses = c.statuses[0], c.statuses[1:]
+	}
+	items := make([]worksearch.BulkItem, len(ops))
+	for i, op := range ops {
+		items[i] = worksearch.BulkItem{Type: op.Type, ID: op.ID, Status: http.StatusOK}
+		if i < len(statuses) {
+			items[i].Status = statuses[i]
+		}
+	}
+	return items, nil
+}
+
+type StoreTestSuite struct {
+	suite.Suite
+
+	client *itemClient
+	sut    *worksearch.Store
+}
+
+func TestStoreTestSuite(t *testing.T) {
+	suite.Run(t, new(StoreTestSuite))
+}
+
+func (s *StoreTestSuite) SetupTest() {
+	s.client = &itemClient{}
+	s.sut = worksearch.NewStore(s.client, map[work.TypeName]worksearch.Mapping{
+		work.TypeNameOf(test.Foo{}): {Index: "foos"},
+	})
+}
+
+func (s *StoreTestSuite) TestStore_Save() {
+	// arrange.
+	ctx := context.Background()
+	fooType := work.TypeNameOf(test.Foo{})
+	u, err := work.NewUnit(s.sut.Options()...)
+	s.Require().NoError(err)
+	s.Require().NoError(u.Register(ctx, test.Foo{ID: 2}, test.Foo{ID: 3}))
+	s.Require().NoError(u.Add(ctx, test.Foo{ID: 1}))
+	s.Require().NoError(u.Upsert(ctx, test.Foo{ID: 4}))
+	s.Require().NoError(u.Alter(ctx, test.Foo{ID: 2}))
+	s.Require().NoError(u.Patch(ctx, fooType, 5, map[string]interface{}{"name": "e"}))
+	s.Require().NoError(u.Remove(ctx, test.Foo{ID: 3}))
+
+	// action.
+	err = u.Save(ctx)
+
+	// assert.
+	s.Require().NoError(err)
+	s.Require().Len(s.client.requests, 1)
+	s.Equal([]worksearch.BulkOperation{
+		{Type: worksearch.BulkOperationTypeCreate, Index: "foos", ID: "1", Document: test.Foo{ID: 1}},
+		{Type: worksearch.BulkOperationTypeIndex, Index: "foos", ID: "4", Document: test.Foo{ID: 4}},
+		{Type: worksearch.BulkOperationTypeUpdate, Index: "foos", ID: "2", Document: test.Foo{ID: 2}, MustExist: true},
+		{Type: worksearch.BulkOperationTypeUpdate, Index: "foos", ID: "5",
+			Document: map[string]interface{}{"name": "e"}, MustExist: true},
+		{Type: worksearch.BulkOperationTypeDelete, Index: "foos", ID: "3"},
+	}, s.client.requests[0])
+}
+
+func (s *StoreTestSuite) TestStore_Save_Stale() {
+	// arrange.
+	ctx := context.Background()
+	s.client.statuses = [][]int{{http.StatusCreated, http.StatusConflict}}
+	opts := append(s.sut.Options(), work.UnitRetryAttempts(1))
+	u, err := work.NewUnit(opts...)
+	s.Require().NoError(err)
+	s.Require().NoError(u.Register(ctx, test.Foo{ID: 2}))
+	s.Require().NoError(u.Add(ctx, test.Foo{ID: 1}))
+	s.Require().NoError(u.Alter(ctx, test.Foo{ID: 2}))
+
+	// action.
+	err = u.Save(ctx)
+
+	// assert.
+	var staleErr *work.UnitStaleEntityError
+	s.Require().ErrorAs(err, &staleErr)
+	s.Equal(test.Foo{ID: 2}, staleErr.Entity)
+	var opErr *work.UnitOperationError
+	s.Require().ErrorAs(err, &opErr)
+	s.Equal(work.UnitOperationUpdate, opErr.Operation)
+	s.Require().Len(s.client.requests, 2)
+	s.Equal([]worksearch.BulkOperation{
+		{Type: worksearch.BulkOperationTypeDelete, Index: "foos", ID: "1"},
+	}, s.client.requests[1])
+}
+
+func (s *StoreTestSuite) TestStore_Save_Failed() {
+	// arrange.
+	ctx := context.Background()
+	s.client.statuses = [][]int{{http.StatusOK, http.StatusBadRequest}}
+	opts := append(s.sut.Options(), work.UnitRetryAttempts(1))
+	u, err := work.NewUnit(opts...)
+	s.Require().NoError(err)
+	s.Require().NoError(u.Register(ctx, test.Foo{ID: 2}, test.Foo{ID: 3}))
+	s.Require().NoError(u.Alter(ctx, test.Foo{ID: 2}))
+	s.Require().NoError(u.Remove(ctx, test.Foo{ID: 3}))
+
+	// action.
+	err = u.Save(ctx)
+
+	// assert.
+	s.ErrorIs(err, worksearch.ErrBulkFailed)
+	s.False(errors.Is(err, work.ErrStaleEntity))
+	s.Require().Len(s.client.requests, 2)
+	s.ElementsMatch([]worksearch.BulkOperation{
+		{Type: worksearch.BulkOperationTypeIndex, Index: "foos", ID: "2", Document: test.Foo{ID: 2}},
+		{Type: worksearch.BulkOperationTypeIndex, Index: "foos", ID: "3", Document: test.Foo{ID: 3}},
+	}, s.client.requests[1])
+}
+
+func (s *StoreTestSuite) TestStore_Write_MissingMapping() {
+	// arrange.
+	ops := []work.UnitBulkOperation{
+		{Operation: work.UnitOperationInsert, TypeName: work.TypeNameOf(test.Bar{}), Entity: test.Bar{ID: "1"}},
+	}
+
+	// action.
+	errs, err := s.sut.Write(context.Background(), work.UnitMapperContext{}, ops)
+
+	// assert.
+	s.Error(err)
+	s.Nil(errs)
+	s.Empty(s.client.requests)
+}
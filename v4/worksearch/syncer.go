@@ -0,0 +1,308 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package worksearch keeps Elasticsearch and OpenSearch indices in sync with
+// the entity changes committed by work units, or stores entities within them
+// directly with a Store.
+//
+// A Syncer maps the additions, alterations, removals, upserts, and patches
+// staged within a work unit into a single bulk request, which is issued once
+// the work unit is saved successfully:
+//
+//	s := worksearch.NewSyncer(client, map[work.TypeName]worksearch.Mapping{
+//		work.TypeNameOf(Foo{}): {Index: "foos"},
+//	})
+//	u, err := work.NewUnit(opts, work.UnitSaveActions(s.Action()))
+package worksearch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/avast/retry-go/v4"
+	"github.com/freerware/work/v4"
+	"github.com/freerware/work/v4/workdlq"
+	"go.uber.org/multierr"
+)
+
+// DeadLetterKind is the kind of the letters published to a dead letter queue
+// for bulk operations that could not be applied.
+const DeadLetterKind = "worksearch.bulk"
+
+// Mapping describes how the entities of a particular type are indexed.
+type Mapping struct {
+	// Index is the name of the index the entities are stored in.
+	Index string
+	// ID provides the document identifier for the entity. When omitted, the
+	// identity of the entity as provided by work.IDOf is used.
+	ID func(entity interface{}) (string, error)
+	// Document provides the document body for the entity. When omitted, the
+	// entity itself is used.
+	Document func(entity interface{}) (interface{}, error)
+	// PatchID provides the document identifier for the entity a patch is
+	// applied to, and is required alongside ID to synchronize patches. When
+	// omitted, the identity of the patch is used.
+	PatchID func(p work.UnitPatch) (string, error)
+}
+
+func (m Mapping) id(entity interface{}) (string, error) {
+	if m.ID != nil {
+		return m.ID(entity)
+	}
+	id, ok := work.IDOf(entity)
+	if !ok {
+		return "", fmt.Errorf("unable to determine document identifier for %s", work.TypeNameOf(entity))
+	}
+	return fmt.Sprint(id), nil
+}
+
+func (m Mapping) patchID(p work.UnitPatch) (string, error) {
+	if m.PatchID != nil {
+		return m.PatchID(p)
+	}
+	// the document identifiers provided by ID may differ from the identities
+	// of the entities, so patches cannot be mapped without PatchID.
+	if m.ID != nil {
+		return "", fmt.Errorf("unable to determine document identifier for patch of %s", p.TypeName)
+	}
+	return fmt.Sprint(p.ID), nil
+}
+
+func (m Mapping) document(entity interface{}) (interface{}, error) {
+	if m.Document != nil {
+		return m.Document(entity)
+	}
+	return entity, nil
+}
+
+// DeadLetterFunc handles the bulk operations that could not be applied after
+// exhausting all retry attempts.
+type DeadLetterFunc func(context.Context, []BulkOperation, error)
+
+// Options represents the configuration options for the syncer.
+type Options struct {
+	retryAttempts int
+	retryDelay    time.Duration
+	timeout       time.Duration
+	deadLetter    DeadLetterFunc
+	queue         *workdlq.Queue
+}
+
+// Option applies an option to the provided configuration.
+type Option func(*Options)
+
+var (
+	// RetryAttempts defines the number of attempts to perform for each bulk
+	// request.
+	RetryAttempts = func(attempts int) Option {
+		if attempts < 1 {
+			attempts = 1
+		}
+		return func(o *Options) {
+			o.retryAttempts = attempts
+		}
+	}
+
+	// RetryDelay defines the delay between bulk request attempts.
+	RetryDelay = func(delay time.Duration) Option {
+		return func(o *Options) {
+			o.retryDelay = delay
+		}
+	}
+
+	// Timeout defines the maximum duration to spend synchronizing the changes
+	// of a single work unit, including retries.
+	Timeout = func(timeout time.Duration) Option {
+		return func(o *Options) {
+			o.timeout = timeout
+		}
+	}
+
+	// DeadLetter defines the function that handles the bulk operations that
+	// could not be applied after exhausting all retry attempts.
+	DeadLetter = func(f DeadLetterFunc) Option {
+		return func(o *Options) {
+			o.deadLetter = f
+		}
+	}
+
+	// DeadLetterQueue defines the queue the bulk operations that could not
+	// be applied after exhausting all retry attempts are published to, which
+	// are replayed with Replay once registered with the queue:
+	//
+	//	q.Handle(worksearch.DeadLetterKind, s.Replay)
+	DeadLetterQueue = func(q *workdlq.Queue) Option {
+		return func(o *Options) {
+			o.queue = q
+		}
+	}
+)
+
+// Syncer synchronizes the entity changes of work units with search indices.
+type Syncer struct {
+	client   Client
+	mappings map[work.TypeName]Mapping
+	options  Options
+}
+
+// NewSyncer constructs a syncer that issues bulk requests with the provided
+// client for the entity types with the provided mappings. Entities of types
+// without a mapping are not synchronized.
+func NewSyncer(client Client, mappings map[work.TypeName]Mapping, opts ...Option) *Syncer {
+	o := Options{
+		retryAttempts: 3,
+		retryDelay:    100 * time.Millisecond,
+		timeout:       30 * time.Second,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return &Syncer{client: client, mappings: mappings, options: o}
+}
+
+func (s *Syncer) entityOperations(
+	t BulkOperationType, entities map[work.TypeName][]interface{}) ([]BulkOperation, error) {
+	var operations []BulkOperation
+	for typeName, e := range entities {
+		m, ok := s.mappings[typeName]
+		if !ok {
+			continue
+		}
+		for _, entity := range e {
+			id, err := m.id(entity)
+			if err != nil {
+				return nil, err
+			}
+			op := BulkOperation{Type: t, Index: m.Index, ID: id}
+			if t != BulkOperationTypeDelete {
+				if op.Document, err = m.document(entity); err != nil {
+					return nil, err
+				}
+			}
+			operations = append(operations, op)
+		}
+	}
+	return operations, nil
+}
+
+// Operations maps the provided changes into bulk operations. Additions and
+// upserts are indexed, alterations and patches update the indexed document,
+// and removals are deleted.
+func (s *Syncer) Operations(changes work.UnitChanges) ([]BulkOperation, error) {
+	var operations []BulkOperation
+	for _, c := range []struct {
+		t        BulkOperationType
+		entities map[work.TypeName][]interface{}
+	}{
+		{BulkOperationTypeIndex, changes.Additions},
+		{BulkOperationTypeIndex, changes.Upserts},
+		{BulkOperationTypeUpdate, changes.Alterations},
+		{BulkOperationTypeDelete, changes.Removals},
+	} {
+		ops, err := s.entityOperations(c.t, c.entities)
+		if err != nil {
+			return nil, err
+		}
+		operations = append(operations, ops...)
+	}
+	for typeName, patches := range changes.Patches {
+		m, ok := s.mappings[typeName]
+		if !ok {
+			continue
+		}
+		for _, p := range patches {
+			id, err := m.patchID(p)
+			if err != nil {
+				return nil, err
+			}
+			operations = append(operations, BulkOperation{
+				Type:     BulkOperationTypeUpdate,
+				Index:    m.Index,
+				ID:       id,
+				Document: p.Fields,
+			})
+		}
+	}
+	return operations, nil
+}
+
+// Sync issues a bulk request for the provided changes, retrying upon
+// failure. Should all attempts fail, the operations are provided to the
+// dead letter function and published to the dead letter queue, if any.
+func (s *Syncer) Sync(ctx context.Context, changes work.UnitChanges) error {
+	operations, err := s.Operations(changes)
+	if err != nil || len(operations) == 0 {
+		return err
+	}
+	err = s.bulk(ctx, operations)
+	if err != nil && s.options.deadLetter != nil {
+		s.options.deadLetter(ctx, operations, err)
+	}
+	if err != nil && s.options.queue != nil {
+		// the context of the sync is likely done once the retries are
+		// exhausted, so the operations are published with their own.
+		pubCtx, cancel := context.WithTimeout(context.Background(), s.options.timeout)
+		defer cancel()
+		err = multierr.Append(err, s.options.queue.Publish(pubCtx, DeadLetterKind, operations, err))
+	}
+	return err
+}
+
+// Replay issues a bulk request for the JSON encoded bulk operations of a
+// letter published to a dead letter queue, retrying upon failure.
+func (s *Syncer) Replay(ctx context.Context, payload []byte) error {
+	var operations []BulkOperation
+	if err := json.Unmarshal(payload, &operations); err != nil {
+		return err
+	}
+	if len(operations) == 0 {
+		return nil
+	}
+	return s.bulk(ctx, operations)
+}
+
+func (s *Syncer) bulk(ctx context.Context, operations []BulkOperation) error {
+	return retry.Do(func() error {
+		return s.client.Bulk(ctx, operations)
+	},
+		retry.Context(ctx),
+		retry.Attempts(uint(s.options.retryAttempts)),
+		retry.Delay(s.options.retryDelay),
+		retry.DelayType(retry.BackOffDelay),
+		retry.LastErrorOnly(true),
+	)
+}
+
+// Action provides the action that synchronizes the changes of a work unit
+// after it is saved successfully, within the context of the save. Failures
+// are logged with the logger of the work unit.
+func (s *Syncer) Action() work.UnitSaveAction {
+	return func(actionCtx work.UnitSaveActionContext) {
+		if actionCtx.Err != nil {
+			return
+		}
+		ctx := actionCtx.Context
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		ctx, cancel := context.WithTimeout(ctx, s.options.timeout)
+		defer cancel()
+		if err := s.Sync(ctx, actionCtx.Changes()); err != nil && actionCtx.Logger != nil {
+			actionCtx.Logger.Error("unable to synchronize search indices", "error", err.Error())
+		}
+	}
+}
@@ -0,0 +1,273 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worksearch_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/freerware/work/v4"
+	"github.com/freerware/work/v4/internal/test"
+	"github.com/freerware/work/v4/workdlq"
+	"github.com/freerware/work/v4/worksearch"
+	"github.com/stretchr/testify/suite"
+)
+
+type client struct {
+	requests [][]worksearch.BulkOperation
+	ctxs     []context.Context
+	errs     []error
+}
+
+func (c *client) Bulk(ctx context.Context, ops []worksearch.BulkOperation) (err error) {
+	c.requests = append(c.requests, ops)
+	c.ctxs = append(c.ctxs, ctx)
+	if len(c.errs) > 0 {
+		err, c.errs = c.errs[0], c.errs[1:]
+	}
+	return
+}
+
+type SyncerTestSuite struct {
+	suite.Suite
+
+	client   *client
+	mappings map[work.TypeName]worksearch.Mapping
+}
+
+func TestSyncerTestSuite(t *testing.T) {
+	suite.Run(t, new(SyncerTestSuite))
+}
+
+func (s *SyncerTestSuite) SetupTest() {
+	s.client = &client{}
+	s.mappings = map[work.TypeName]worksearch.Mapping{
+		work.TypeNameOf(test.Foo{}): {Index: "foos"},
+	}
+}
+
+func (s *SyncerTestSuite) TestSyncer_Operations() {
+	// arrange.
+	fooType := work.TypeNameOf(test.Foo{})
+	changes := work.UnitChanges{
+		Additions:   map[work.TypeName][]interface{}{fooType: {test.Foo{ID: 1}}},
+		Alterations: map[work.TypeName][]interface{}{fooType: {test.Foo{ID: 2}}},
+		Removals: map[work.TypeName][]interface{}{
+			fooType:                     {test.Foo{ID: 3}},
+			work.TypeNameOf(test.Bar{}): {test.Bar{ID: "4"}},
+		},
+		Patches: map[work.TypeName][]work.UnitPatch{
+			fooType: {{TypeName: fooType, ID: 5, Fields: map[string]interface{}{"name": "e"}}},
+		},
+	}
+	sut := worksearch.NewSyncer(s.client, s.mappings)
+
+	// action.
+	ops, err := sut.Operations(changes)
+
+	// assert.
+	s.Require().NoError(err)
+	s.Equal([]worksearch.BulkOperation{
+		{Type: worksearch.BulkOperationTypeIndex, Index: "foos", ID: "1", Document: test.Foo{ID: 1}},
+		{Type: worksearch.BulkOperationTypeUpdate, Index: "foos", ID: "2", Document: test.Foo{ID: 2}},
+		{Type: worksearch.BulkOperationTypeDelete, Index: "foos", ID: "3"},
+		{Type: worksearch.BulkOperationTypeUpdate, Index: "foos", ID: "5", Document: map[string]interface{}{"name": "e"}},
+	}, ops)
+}
+
+func (s *SyncerTestSuite) TestSyncer_Operations_MissingIdentity() {
+	// arrange.
+	bizType := work.TypeNameOf(test.Biz{})
+	s.mappings[bizType] = worksearch.Mapping{Index: "bizs"}
+	changes := work.UnitChanges{
+		Additions: map[work.TypeName][]interface{}{bizType: {test.Biz{}}},
+	}
+	sut := worksearch.NewSyncer(s.client, s.mappings)
+
+	// action.
+	_, err := sut.Operations(changes)
+
+	// assert.
+	s.Error(err)
+}
+
+func (s *SyncerTestSuite) TestSyncer_Operations_PatchID() {
+	// arrange.
+	fooType := work.TypeNameOf(test.Foo{})
+	s.mappings[fooType] = worksearch.Mapping{
+		Index: "foos",
+		ID: func(entity interface{}) (string, error) {
+			return fmt.Sprintf("foo-%d", entity.(test.Foo).ID), nil
+		},
+		PatchID: func(p work.UnitPatch) (string, error) {
+			return fmt.Sprintf("foo-%v", p.ID), nil
+		},
+	}
+	changes := work.UnitChanges{
+		Alterations: map[work.TypeName][]interface{}{fooType: {test.Foo{ID: 2}}},
+		Patches: map[work.TypeName][]work.UnitPatch{
+			fooType: {{TypeName: fooType, ID: 5, Fields: map[string]interface{}{"name": "e"}}},
+		},
+	}
+	sut := worksearch.NewSyncer(s.client, s.mappings)
+
+	// action.
+	ops, err := sut.Operations(changes)
+
+	// assert.
+	s.Require().NoError(err)
+	s.Equal([]worksearch.BulkOperation{
+		{Type: worksearch.BulkOperationTypeUpdate, Index: "foos", ID: "foo-2", Document: test.Foo{ID: 2}},
+		{Type: worksearch.BulkOperationTypeUpdate, Index: "foos", ID: "foo-5", Document: map[string]interface{}{"name": "e"}},
+	}, ops)
+}
+
+func (s *SyncerTestSuite) TestSyncer_Operations_MissingPatchID() {
+	// arrange.
+	fooType := work.TypeNameOf(test.Foo{})
+	s.mappings[fooType] = worksearch.Mapping{
+		Index: "foos",
+		ID: func(entity interface{}) (string, error) {
+			return fmt.Sprintf("foo-%d", entity.(test.Foo).ID), nil
+		},
+	}
+	changes := work.UnitChanges{
+		Patches: map[work.TypeName][]work.UnitPatch{
+			fooType: {{TypeName: fooType, ID: 5, Fields: map[string]interface{}{"name": "e"}}},
+		},
+	}
+	sut := worksearch.NewSyncer(s.client, s.mappings)
+
+	// action.
+	_, err := sut.Operations(changes)
+
+	// assert.
+	s.Error(err)
+}
+
+func (s *SyncerTestSuite) TestSyncer_Sync_DeadLetter() {
+	// arrange.
+	ctx := context.Background()
+	fooType := work.TypeNameOf(test.Foo{})
+	changes := work.UnitChanges{
+		Removals: map[work.TypeName][]interface{}{fooType: {test.Foo{ID: 3}}},
+	}
+	s.client.errs = []error{errors.New("whoa"), errors.New("whoa")}
+	var deadLettered []worksearch.BulkOperation
+	sut := worksearch.NewSyncer(s.client, s.mappings,
+		worksearch.RetryAttempts(2),
+		worksearch.RetryDelay(time.Millisecond),
+		worksearch.DeadLetter(func(ctx context.Context, ops []worksearch.BulkOperation, err error) {
+			deadLettered = ops
+		}),
+	)
+
+	// action.
+	err := sut.Sync(ctx, changes)
+
+	// assert.
+	s.EqualError(err, "whoa")
+	s.Len(s.client.requests, 2)
+	s.Len(deadLettered, 1)
+}
+
+func (s *SyncerTestSuite) TestSyncer_Sync_DeadLetterQueue() {
+	// arrange.
+	ctx := context.Background()
+	fooType := work.TypeNameOf(test.Foo{})
+	changes := work.UnitChanges{
+		Additions: map[work.TypeName][]interface{}{fooType: {test.Foo{ID: 3}}},
+	}
+	s.client.errs = []error{errors.New("whoa")}
+	q := workdlq.NewQueue(workdlq.NewMemoryStore())
+	sut := worksearch.NewSyncer(s.client, s.mappings,
+		worksearch.RetryAttempts(1),
+		worksearch.DeadLetterQueue(q),
+	)
+	q.Handle(worksearch.DeadLetterKind, sut.Replay)
+	s.Require().EqualError(sut.Sync(ctx, changes), "whoa")
+
+	// action.
+	report, err := q.Replay(ctx, 10)
+
+	// assert.
+	s.Require().NoError(err)
+	s.Equal(workdlq.ReplayReport{Replayed: 1}, report)
+	s.Require().Len(s.client.requests, 2)
+	s.Require().Len(s.client.requests[1], 1)
+	op := s.client.requests[1][0]
+	s.Equal(worksearch.BulkOperationTypeIndex, op.Type)
+	s.Equal("foos", op.Index)
+	s.Equal("3", op.ID)
+	s.NotNil(op.Document)
+}
+
+func (s *SyncerTestSuite) TestSyncer_Action() {
+	// arrange.
+	ctx := context.Background()
+	foo := test.Foo{ID: 1}
+	fooType := work.TypeNameOf(foo)
+	s.client.errs = []error{errors.New("whoa")}
+	mapperFunc := func(context.Context, work.UnitMapperContext, ...interface{}) error { return nil }
+	sut := worksearch.NewSyncer(s.client, s.mappings, worksearch.RetryDelay(time.Millisecond))
+	u, err := work.NewUnit(
+		work.UnitInsertFunc(fooType, mapperFunc),
+		work.UnitDeleteFunc(fooType, mapperFunc),
+		work.UnitSaveActions(sut.Action()),
+	)
+	s.Require().NoError(err)
+	s.Require().NoError(u.Add(ctx, foo))
+
+	// action.
+	err = u.Save(ctx)
+
+	// assert.
+	s.Require().NoError(err)
+	s.Len(s.client.requests, 2)
+	s.Equal([]worksearch.BulkOperation{
+		{Type: worksearch.BulkOperationTypeIndex, Index: "foos", ID: "1", Document: foo},
+	}, s.client.requests[1])
+}
+
+func (s *SyncerTestSuite) TestSyncer_Action_Context() {
+	// arrange.
+	type key struct{}
+	ctx := context.WithValue(context.Background(), key{}, "value")
+	foo := test.Foo{ID: 1}
+	fooType := work.TypeNameOf(foo)
+	mapperFunc := func(context.Context, work.UnitMapperContext, ...interface{}) error { return nil }
+	sut := worksearch.NewSyncer(s.client, s.mappings, worksearch.Timeout(time.Minute))
+	u, err := work.NewUnit(
+		work.UnitInsertFunc(fooType, mapperFunc),
+		work.UnitDeleteFunc(fooType, mapperFunc),
+		work.UnitSaveActions(sut.Action()),
+	)
+	s.Require().NoError(err)
+	s.Require().NoError(u.Add(ctx, foo))
+
+	// action.
+	err = u.Save(ctx)
+
+	// assert.
+	s.Require().NoError(err)
+	s.Require().Len(s.client.ctxs, 1)
+	s.Equal("value", s.client.ctxs[0].Value(key{}))
+	_, ok := s.client.ctxs[0].Deadline()
+	s.True(ok)
+}
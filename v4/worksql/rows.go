@@ -0,0 +1,67 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+// Package worksql provides helpers for integrating work units with code that
+// uses database/sql directly.
+//
+// RegisterRows scans the entities loaded by a query and registers them with a
+// work unit, placing them in its cache:
+//
+//	rows, err := db.QueryContext(ctx, "SELECT id, name FROM foos")
+//	if err != nil {
+//		return err
+//	}
+//	err = worksql.RegisterRows(ctx, u, rows, func(r *sql.Rows) (interface{}, error) {
+//		var f Foo
+//		err := r.Scan(&f.ID, &f.Name)
+//		return f, err
+//	})
+package worksql
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/freerware/work/v4"
+	"go.uber.org/multierr"
+)
+
+// Scanner scans the entity at the current row.
+type Scanner func(*sql.Rows) (interface{}, error)
+
+// RegisterRows scans each of the provided rows into an entity using the
+// provided scanner, and registers the entities with the provided work unit
+// once all rows have been scanned. The rows are always closed. No entities
+// are registered when scanning or iterating the rows fails.
+func RegisterRows(ctx context.Context, u work.Unit, rows *sql.Rows, scanner Scanner) (err error) {
+	defer func() {
+		err = multierr.Append(err, rows.Close())
+	}()
+
+	var entities []interface{}
+	for rows.Next() {
+		entity, scanErr := scanner(rows)
+		if scanErr != nil {
+			return scanErr
+		}
+		entities = append(entities, entity)
+	}
+	if err = rows.Err(); err != nil {
+		return
+	}
+	if len(entities) == 0 {
+		return
+	}
+	return u.Register(ctx, entities...)
+}
@@ -0,0 +1,123 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package worksql_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/freerware/work/v4"
+	"github.com/freerware/work/v4/internal/mock"
+	"github.com/freerware/work/v4/internal/test"
+	"github.com/freerware/work/v4/worksql"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+)
+
+type RowsTestSuite struct {
+	suite.Suite
+
+	db   *sql.DB
+	mock sqlmock.Sqlmock
+	sut  work.Unit
+}
+
+func TestRowsTestSuite(t *testing.T) {
+	suite.Run(t, new(RowsTestSuite))
+}
+
+func (s *RowsTestSuite) SetupTest() {
+	var err error
+	s.db, s.mock, err = sqlmock.New()
+	s.Require().NoError(err)
+	dm := map[work.TypeName]work.UnitDataMapper{
+		work.TypeNameOf(test.Foo{}): mock.NewUnitDataMapper(gomock.NewController(s.T())),
+	}
+	s.sut, err = work.NewUnit(work.UnitDataMappers(dm))
+	s.Require().NoError(err)
+}
+
+func (s *RowsTestSuite) query(rows *sqlmock.Rows) *sql.Rows {
+	s.mock.ExpectQuery("SELECT id FROM foos").WillReturnRows(rows)
+	r, err := s.db.Query("SELECT id FROM foos")
+	s.Require().NoError(err)
+	return r
+}
+
+func scanFoo(r *sql.Rows) (interface{}, error) {
+	var f test.Foo
+	err := r.Scan(&f.ID)
+	return f, err
+}
+
+func (s *RowsTestSuite) TestRegisterRows() {
+	// arrange.
+	ctx := context.Background()
+	rows := s.query(sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(2))
+
+	// action.
+	err := worksql.RegisterRows(ctx, s.sut, rows, scanFoo)
+
+	// assert.
+	s.Require().NoError(err)
+	for _, id := range []int{1, 2} {
+		cached, err := s.sut.Cached().Load(ctx, work.TypeNameOf(test.Foo{}), id)
+		s.NoError(err)
+		s.Equal(test.Foo{ID: id}, cached)
+	}
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *RowsTestSuite) TestRegisterRows_ScanError() {
+	// arrange.
+	ctx := context.Background()
+	rows := s.query(sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(2))
+	scanErr := errors.New("whoa")
+	scanner := func(r *sql.Rows) (interface{}, error) {
+		return nil, scanErr
+	}
+
+	// action.
+	err := worksql.RegisterRows(ctx, s.sut, rows, scanner)
+
+	// assert.
+	s.ErrorIs(err, scanErr)
+	cached, err := s.sut.Cached().Load(ctx, work.TypeNameOf(test.Foo{}), 1)
+	s.NoError(err)
+	s.Nil(cached)
+}
+
+func (s *RowsTestSuite) TestRegisterRows_RowError() {
+	// arrange.
+	ctx := context.Background()
+	rowErr := errors.New("whoa")
+	rows := s.query(sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(2).RowError(1, rowErr))
+
+	// action.
+	err := worksql.RegisterRows(ctx, s.sut, rows, scanFoo)
+
+	// assert.
+	s.ErrorIs(err, rowErr)
+	cached, err := s.sut.Cached().Load(ctx, work.TypeNameOf(test.Foo{}), 1)
+	s.NoError(err)
+	s.Nil(cached)
+}
+
+func (s *RowsTestSuite) TearDownTest() {
+	s.db.Close()
+}
@@ -0,0 +1,172 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package worksql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/freerware/work/v4"
+)
+
+var (
+	// ErrNoColumns represents the error that is returned when building a
+	// multi-row update without any columns to update.
+	ErrNoColumns = errors.New("multi-row update requires at least one column")
+	// ErrNoEntities represents the error that is returned when building a
+	// multi-row update without any entities to update.
+	ErrNoEntities = errors.New("multi-row update requires at least one entity")
+)
+
+// Placeholder formats the placeholder for the statement argument at the
+// provided position, starting at one.
+type Placeholder func(int) string
+
+var (
+	// Question formats placeholders as question marks, as expected by MySQL
+	// and SQLite drivers.
+	Question Placeholder = func(int) string { return "?" }
+	// Dollar formats placeholders as numbered parameters, as expected by
+	// PostgreSQL drivers.
+	Dollar Placeholder = func(n int) string { return fmt.Sprintf("$%d", n) }
+)
+
+// PlaceholderFor provides the placeholder format expected by the driver
+// registered with the provided name.
+func PlaceholderFor(driverName string) Placeholder {
+	switch driverName {
+	case "postgres", "pgx":
+		return Dollar
+	default:
+		return Question
+	}
+}
+
+// MultiUpdate updates many rows of a table with a single statement, setting
+// each column with a CASE expression keyed by the identity of each row:
+//
+//	UPDATE foos SET
+//		name = CASE id WHEN ? THEN ? WHEN ? THEN ? END
+//	WHERE id IN (?, ?)
+//
+// Issuing a single statement for a batch of altered entities avoids the
+// round trip of updating each row individually.
+type MultiUpdate struct {
+	// Table is the name of the table to update.
+	Table string
+	// Key is the name of the column identifying each row.
+	Key string
+	// Columns are the names of the columns to update.
+	Columns []string
+	// Casts are the SQL types the values of columns are cast to, keyed by
+	// column, for drivers unable to infer the type of parameters used within
+	// CASE expressions, such as PostgreSQL drivers.
+	Casts map[string]string
+	// Values provides the key of the row for the provided entity, and the
+	// values of each of the columns, in order.
+	Values func(entity interface{}) (key interface{}, values []interface{}, err error)
+	// BatchSize is the maximum number of rows updated by each statement, so
+	// that large batches remain within the parameter limits of the driver.
+	// Zero indicates all rows are updated by a single statement.
+	BatchSize int
+}
+
+// Build provides the statement updating the rows for the provided entities,
+// along with its arguments, formatting placeholders with the provided
+// placeholder format.
+func (u MultiUpdate) Build(placeholder Placeholder, entities ...interface{}) (query string, args []interface{}, err error) {
+	if len(u.Columns) == 0 {
+		return "", nil, ErrNoColumns
+	}
+	if len(entities) == 0 {
+		return "", nil, ErrNoEntities
+	}
+	keys := make([]interface{}, 0, len(entities))
+	rows := make([][]interface{}, 0, len(entities))
+	for _, entity := range entities {
+		key, values, err := u.Values(entity)
+		if err != nil {
+			return "", nil, err
+		}
+		if len(values) != len(u.Columns) {
+			return "", nil, fmt.Errorf(
+				"multi-row update of %s provided %d values for %d columns", u.Table, len(values), len(u.Columns))
+		}
+		keys = append(keys, key)
+		rows = append(rows, values)
+	}
+
+	var b strings.Builder
+	param := func(v interface{}, cast string) {
+		args = append(args, v)
+		if cast == "" {
+			b.WriteString(placeholder(len(args)))
+			return
+		}
+		fmt.Fprintf(&b, "CAST(%s AS %s)", placeholder(len(args)), cast)
+	}
+	fmt.Fprintf(&b, "UPDATE %s SET ", u.Table)
+	for i, column := range u.Columns {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		fmt.Fprintf(&b, "%s = CASE %s", column, u.Key)
+		for j, key := range keys {
+			b.WriteString(" WHEN ")
+			param(key, "")
+			b.WriteString(" THEN ")
+			param(rows[j][i], u.Casts[column])
+		}
+		b.WriteString(" END")
+	}
+	fmt.Fprintf(&b, " WHERE %s IN (", u.Key)
+	for i, key := range keys {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		param(key, "")
+	}
+	b.WriteString(")")
+	return b.String(), args, nil
+}
+
+// Exec updates the rows for the provided entities within the transaction of
+// the provided mapper context, formatting placeholders for its driver.
+func (u MultiUpdate) Exec(ctx context.Context, mCtx work.UnitMapperContext, entities ...interface{}) error {
+	placeholder := PlaceholderFor(mCtx.DriverName())
+	for len(entities) > 0 {
+		batch := entities
+		if u.BatchSize > 0 && len(batch) > u.BatchSize {
+			batch = batch[:u.BatchSize]
+		}
+		entities = entities[len(batch):]
+		query, args, err := u.Build(placeholder, batch...)
+		if err != nil {
+			return err
+		}
+		if _, err = mCtx.ExecContext(ctx, query, args...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Func provides the data mapper function updating the altered entities with
+// the multi-row update.
+func (u MultiUpdate) Func() work.UnitDataMapperFunc {
+	return u.Exec
+}
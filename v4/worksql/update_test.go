@@ -0,0 +1,141 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package worksql_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/freerware/work/v4"
+	"github.com/freerware/work/v4/internal/test"
+	"github.com/freerware/work/v4/worksql"
+	"github.com/stretchr/testify/suite"
+)
+
+type MultiUpdateTestSuite struct {
+	suite.Suite
+
+	sut worksql.MultiUpdate
+}
+
+func TestMultiUpdateTestSuite(t *testing.T) {
+	suite.Run(t, new(MultiUpdateTestSuite))
+}
+
+func (s *MultiUpdateTestSuite) SetupTest() {
+	s.sut = worksql.MultiUpdate{
+		Table:   "quxes",
+		Key:     "key",
+		Columns: []string{"name"},
+		Values: func(entity interface{}) (interface{}, []interface{}, error) {
+			q := entity.(test.Qux)
+			return q.Key, []interface{}{q.Name}, nil
+		},
+	}
+}
+
+func (s *MultiUpdateTestSuite) TestBuild() {
+	tests := []struct {
+		name        string
+		placeholder worksql.Placeholder
+		casts       map[string]string
+		query       string
+	}{
+		{
+			name:        "Question",
+			placeholder: worksql.Question,
+			query:       "UPDATE quxes SET name = CASE key WHEN ? THEN ? WHEN ? THEN ? END WHERE key IN (?, ?)",
+		},
+		{
+			name:        "DollarWithCasts",
+			placeholder: worksql.Dollar,
+			casts:       map[string]string{"name": "text"},
+			query: "UPDATE quxes SET name = CASE key WHEN $1 THEN CAST($2 AS text) " +
+				"WHEN $3 THEN CAST($4 AS text) END WHERE key IN ($5, $6)",
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			// arrange.
+			s.sut.Casts = tt.casts
+
+			// action.
+			query, args, err := s.sut.Build(tt.placeholder, test.Qux{Key: "a", Name: "A"}, test.Qux{Key: "b", Name: "B"})
+
+			// assert.
+			s.Require().NoError(err)
+			s.Equal(tt.query, query)
+			s.Equal([]interface{}{"a", "A", "b", "B", "a", "b"}, args)
+		})
+	}
+}
+
+func (s *MultiUpdateTestSuite) TestBuild_Errors() {
+	// arrange.
+	valuesErr := errors.New("whoa")
+	noColumns := s.sut
+	noColumns.Columns = nil
+	failingValues := s.sut
+	failingValues.Values = func(interface{}) (interface{}, []interface{}, error) { return nil, nil, valuesErr }
+	missingValues := s.sut
+	missingValues.Columns = []string{"name", "description"}
+
+	// action + assert.
+	_, _, err := noColumns.Build(worksql.Question, test.Qux{})
+	s.ErrorIs(err, worksql.ErrNoColumns)
+	_, _, err = s.sut.Build(worksql.Question)
+	s.ErrorIs(err, worksql.ErrNoEntities)
+	_, _, err = failingValues.Build(worksql.Question, test.Qux{})
+	s.ErrorIs(err, valuesErr)
+	_, _, err = missingValues.Build(worksql.Question, test.Qux{})
+	s.EqualError(err, "multi-row update of quxes provided 1 values for 2 columns")
+}
+
+func (s *MultiUpdateTestSuite) TestFunc() {
+	// arrange.
+	ctx := context.Background()
+	db, mock, err := sqlmock.New()
+	s.Require().NoError(err)
+	defer db.Close()
+	s.sut.BatchSize = 2
+	noop := func(context.Context, work.UnitMapperContext, ...interface{}) error { return nil }
+	quxType := work.TypeNameOf(test.Qux{})
+	sut, err := work.NewUnit(
+		work.UnitDB(db),
+		work.UnitDriverName("postgres"),
+		work.UnitInsertFunc(quxType, noop),
+		work.UnitUpdateFunc(quxType, s.sut.Func()),
+		work.UnitDeleteFunc(quxType, noop),
+	)
+	s.Require().NoError(err)
+	s.Require().NoError(sut.Alter(ctx, test.Qux{Key: "a", Name: "A"}, test.Qux{Key: "b", Name: "B"}, test.Qux{Key: "c", Name: "C"}))
+	mock.ExpectBegin()
+	mock.ExpectExec(`^UPDATE quxes SET name = CASE key WHEN \$1 THEN \$2 WHEN \$3 THEN \$4 END WHERE key IN \(\$5, \$6\)$`).
+		WithArgs("a", "A", "b", "B", "a", "b").
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec(`^UPDATE quxes SET name = CASE key WHEN \$1 THEN \$2 END WHERE key IN \(\$3\)$`).
+		WithArgs("c", "C", "c").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	// action.
+	err = sut.Save(ctx)
+
+	// assert.
+	s.Require().NoError(err)
+	s.NoError(mock.ExpectationsWereMet())
+}
@@ -0,0 +1,87 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+// Package worksqlc runs sqlc-generated queries within the transactions of
+// work units.
+//
+// The mapper context of a work unit satisfies the DBTX interface generated by
+// sqlc for database/sql, so the generated queries can be created directly
+// from it rather than calling WithTx within every data mapper:
+//
+//	func (m FooMapper) Insert(ctx context.Context, mCtx work.UnitMapperContext, foos ...interface{}) error {
+//		q := db.New(worksqlc.New(mCtx))
+//		for _, f := range foos {
+//			if err := q.CreateFoo(ctx, f.(Foo).Name); err != nil {
+//				return err
+//			}
+//		}
+//		return nil
+//	}
+//
+// Func adapts functions accepting the generated queries into data mapper
+// functions:
+//
+//	newQueries := func(tx worksqlc.DBTX) *db.Queries { return db.New(tx) }
+//	u, err := work.NewUnit(
+//		work.UnitDB(sqlDB),
+//		work.UnitInsertFunc(work.TypeNameOf(Foo{}), worksqlc.Func(newQueries,
+//			func(ctx context.Context, q *db.Queries, foos ...interface{}) error {
+//				for _, f := range foos {
+//					if err := q.CreateFoo(ctx, f.(Foo).Name); err != nil {
+//						return err
+//					}
+//				}
+//				return nil
+//			})),
+//	)
+package worksqlc
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/freerware/work/v4"
+)
+
+// DBTX represents the interface generated by sqlc for executing queries with
+// database/sql.
+type DBTX interface {
+	ExecContext(context.Context, string, ...interface{}) (sql.Result, error)
+	PrepareContext(context.Context, string) (*sql.Stmt, error)
+	QueryContext(context.Context, string, ...interface{}) (*sql.Rows, error)
+	QueryRowContext(context.Context, string, ...interface{}) *sql.Row
+}
+
+// New provides the DBTX that executes queries within the transaction of the
+// provided mapper context, detecting usages of the transaction after the
+// save attempt has completed.
+func New(mCtx work.UnitMapperContext) DBTX {
+	return mCtx
+}
+
+// QueriesFunc creates the sqlc-generated queries of type Q using the
+// provided DBTX, typically by calling the generated New function.
+type QueriesFunc[Q any] func(DBTX) Q
+
+// MapperFunc performs a data mapping operation for the provided entities
+// with the provided sqlc-generated queries.
+type MapperFunc[Q any] func(context.Context, Q, ...interface{}) error
+
+// Func adapts the provided function into a data mapper function, creating
+// the queries it is provided with from the transaction of each save attempt.
+func Func[Q any](newQueries QueriesFunc[Q], f MapperFunc[Q]) work.UnitDataMapperFunc {
+	return func(ctx context.Context, mCtx work.UnitMapperContext, entities ...interface{}) error {
+		return f(ctx, newQueries(New(mCtx)), entities...)
+	}
+}
@@ -0,0 +1,82 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package worksqlc_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/freerware/work/v4"
+	"github.com/freerware/work/v4/internal/test"
+	"github.com/freerware/work/v4/worksqlc"
+	"github.com/stretchr/testify/suite"
+)
+
+// queries mimics the queries generated by sqlc.
+type queries struct {
+	db worksqlc.DBTX
+}
+
+func (q *queries) CreateFoo(ctx context.Context, id int) error {
+	_, err := q.db.ExecContext(ctx, "INSERT INTO foos (id) VALUES ($1)", id)
+	return err
+}
+
+type QueriesTestSuite struct {
+	suite.Suite
+}
+
+func TestQueriesTestSuite(t *testing.T) {
+	suite.Run(t, new(QueriesTestSuite))
+}
+
+func (s *QueriesTestSuite) TestFunc() {
+	// arrange.
+	ctx := context.Background()
+	db, mock, err := sqlmock.New()
+	s.Require().NoError(err)
+	defer db.Close()
+	newQueries := func(tx worksqlc.DBTX) *queries { return &queries{db: tx} }
+	insert := worksqlc.Func(newQueries, func(ctx context.Context, q *queries, foos ...interface{}) error {
+		for _, f := range foos {
+			if err := q.CreateFoo(ctx, f.(test.Foo).ID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	noop := func(context.Context, work.UnitMapperContext, ...interface{}) error { return nil }
+	fooType := work.TypeNameOf(test.Foo{})
+	sut, err := work.NewUnit(
+		work.UnitDB(db),
+		work.UnitInsertFunc(fooType, insert),
+		work.UnitUpdateFunc(fooType, noop),
+		work.UnitDeleteFunc(fooType, noop),
+	)
+	s.Require().NoError(err)
+	s.Require().NoError(sut.Add(ctx, test.Foo{ID: 28}, test.Foo{ID: 29}))
+	mock.ExpectBegin()
+	mock.ExpectExec(`^INSERT INTO foos \(id\) VALUES \(\$1\)$`).WithArgs(28).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`^INSERT INTO foos \(id\) VALUES \(\$1\)$`).WithArgs(29).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	// action.
+	err = sut.Save(ctx)
+
+	// assert.
+	s.Require().NoError(err)
+	s.NoError(mock.ExpectationsWereMet())
+}
@@ -0,0 +1,96 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+// Package worksquirrel integrates squirrel statement building with the
+// transactions of work units.
+//
+// Within a data mapper, StatementBuilder provides a squirrel statement
+// builder that formats placeholders for the driver of the work unit and
+// executes statements within its transaction:
+//
+//	func (m FooMapper) Insert(ctx context.Context, mCtx work.UnitMapperContext, foos ...interface{}) error {
+//		q := worksquirrel.StatementBuilder(mCtx).Insert("foos").Columns("id", "name")
+//		for _, f := range foos {
+//			q = q.Values(f.(Foo).ID, f.(Foo).Name)
+//		}
+//		_, err := q.ExecContext(ctx)
+//		return err
+//	}
+package worksquirrel
+
+import (
+	"context"
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/freerware/work/v4"
+)
+
+// runner executes statements within the transaction of the provided mapper
+// context, detecting usages of the transaction after the save attempt has
+// completed.
+type runner struct {
+	mCtx work.UnitMapperContext
+}
+
+func (r runner) Exec(query string, args ...interface{}) (sql.Result, error) {
+	return r.ExecContext(context.Background(), query, args...)
+}
+
+func (r runner) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	return r.QueryContext(context.Background(), query, args...)
+}
+
+func (r runner) QueryRow(query string, args ...interface{}) *sql.Row {
+	return r.QueryRowContext(context.Background(), query, args...)
+}
+
+func (r runner) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return r.mCtx.ExecContext(ctx, query, args...)
+}
+
+func (r runner) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return r.mCtx.QueryContext(ctx, query, args...)
+}
+
+func (r runner) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return r.mCtx.QueryRowContext(ctx, query, args...)
+}
+
+// RunnerFrom provides a squirrel runner that executes statements within the
+// transaction of the provided mapper context.
+func RunnerFrom(mCtx work.UnitMapperContext) sq.RunnerContext {
+	return sq.WrapStdSqlCtx(runner{mCtx: mCtx})
+}
+
+// PlaceholderFormatFrom provides the placeholder format used by the driver
+// of the provided mapper context, being dollar placeholders for PostgreSQL
+// drivers and question marks otherwise.
+func PlaceholderFormatFrom(mCtx work.UnitMapperContext) sq.PlaceholderFormat {
+	switch mCtx.DriverName() {
+	case "postgres", "pgx":
+		return sq.Dollar
+	default:
+		return sq.Question
+	}
+}
+
+// StatementBuilder provides a squirrel statement builder that formats
+// placeholders for the driver of the provided mapper context and executes
+// statements within its transaction.
+func StatementBuilder(mCtx work.UnitMapperContext) sq.StatementBuilderType {
+	return sq.StatementBuilder.
+		PlaceholderFormat(PlaceholderFormatFrom(mCtx)).
+		RunWith(RunnerFrom(mCtx))
+}
@@ -0,0 +1,82 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package worksquirrel_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/freerware/work/v4"
+	"github.com/freerware/work/v4/internal/test"
+	"github.com/freerware/work/v4/worksquirrel"
+	"github.com/stretchr/testify/suite"
+)
+
+type RunnerTestSuite struct {
+	suite.Suite
+}
+
+func TestRunnerTestSuite(t *testing.T) {
+	suite.Run(t, new(RunnerTestSuite))
+}
+
+func (s *RunnerTestSuite) TestStatementBuilder() {
+	tests := []struct {
+		name       string
+		driverName string
+		query      string
+	}{
+		{name: "Postgres", driverName: "postgres", query: `^INSERT INTO foos \(id\) VALUES \(\$1\)$`},
+		{name: "MySQL", driverName: "mysql", query: `^INSERT INTO foos \(id\) VALUES \(\?\)$`},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			// arrange.
+			ctx := context.Background()
+			db, mock, err := sqlmock.New()
+			s.Require().NoError(err)
+			defer db.Close()
+			noop := func(context.Context, work.UnitMapperContext, ...interface{}) error { return nil }
+			insert := func(ctx context.Context, mCtx work.UnitMapperContext, foos ...interface{}) error {
+				q := worksquirrel.StatementBuilder(mCtx).Insert("foos").Columns("id")
+				for _, f := range foos {
+					q = q.Values(f.(test.Foo).ID)
+				}
+				_, err := q.ExecContext(ctx)
+				return err
+			}
+			sut, err := work.NewUnit(
+				work.UnitDB(db),
+				work.UnitDriverName(tt.driverName),
+				work.UnitInsertFunc(work.TypeNameOf(test.Foo{}), insert),
+				work.UnitUpdateFunc(work.TypeNameOf(test.Foo{}), noop),
+				work.UnitDeleteFunc(work.TypeNameOf(test.Foo{}), noop),
+			)
+			s.Require().NoError(err)
+			s.Require().NoError(sut.Add(ctx, test.Foo{ID: 28}))
+			mock.ExpectBegin()
+			mock.ExpectExec(tt.query).WithArgs(28).WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectCommit()
+
+			// action.
+			err = sut.Save(ctx)
+
+			// assert.
+			s.Require().NoError(err)
+			s.NoError(mock.ExpectationsWereMet())
+		})
+	}
+}
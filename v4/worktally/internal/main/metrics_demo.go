@@ -21,10 +21,11 @@ import (
 	"math/rand"
 	"time"
 
-	"github.com/cactus/go-statsd-client/statsd"
+	"github.com/cactus/go-statsd-client/v5/statsd"
 	"github.com/freerware/work/v4"
 	"github.com/freerware/work/v4/internal/test"
 	"github.com/freerware/work/v4/unit"
+	"github.com/freerware/work/v4/worktally"
 	"github.com/uber-go/tally/v4"
 	tstatsd "github.com/uber-go/tally/v4/statsd"
 )
@@ -89,7 +90,7 @@ func setupDataMapper() map[work.TypeName]unit.DataMapper {
 
 func o() []work.UnitOption {
 	return []work.UnitOption{
-		work.UnitTallyMetricScope(setupScope()),
+		worktally.WithScope(setupScope()),
 		work.UnitDataMappers(setupDataMapper()),
 	}
 }
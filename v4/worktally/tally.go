@@ -0,0 +1,73 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package worktally provides a metrics collector for work units backed by
+// tally:
+//
+//	u, err := work.NewUnit(
+//		work.UnitDB(db),
+//		work.UnitDataMappers(m),
+//		worktally.WithScope(scope),
+//	)
+//
+// Metrics are emitted within the provided scope, tagged with the tags they
+// are emitted with, so that "unit.save.success" is emitted as the counter
+// "save.success" within the "unit" sub-scope.
+package worktally
+
+import (
+	"time"
+
+	"github.com/freerware/work/v4"
+	"github.com/uber-go/tally/v4"
+)
+
+// Collector collects the metrics emitted by work units within a tally
+// scope.
+type Collector struct {
+	s tally.Scope
+}
+
+// NewCollector creates a collector emitting metrics within the provided
+// scope.
+func NewCollector(scope tally.Scope) *Collector {
+	return &Collector{s: scope}
+}
+
+// WithScope provides the option to collect the metrics of work units within
+// the provided tally scope.
+func WithScope(scope tally.Scope) work.UnitOption {
+	return work.UnitWithMetricsCollector(NewCollector(scope))
+}
+
+// scope provides the scope tagged with the provided tags.
+func (c *Collector) scope(tags map[string]string) tally.Scope {
+	if len(tags) == 0 {
+		return c.s
+	}
+	return c.s.Tagged(tags)
+}
+
+// Counter increments the counter with the provided name and tags by the
+// provided value.
+func (c *Collector) Counter(name string, tags map[string]string, value int64) {
+	c.scope(tags).Counter(name).Inc(value)
+}
+
+// Timer records the provided duration for the timer with the provided name
+// and tags.
+func (c *Collector) Timer(name string, tags map[string]string, d time.Duration) {
+	c.scope(tags).Timer(name).Record(d)
+}
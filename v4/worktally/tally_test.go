@@ -0,0 +1,93 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worktally_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/freerware/work/v4"
+	"github.com/freerware/work/v4/internal/test"
+	"github.com/freerware/work/v4/worktally"
+	"github.com/stretchr/testify/suite"
+	"github.com/uber-go/tally/v4"
+)
+
+type TallyTestSuite struct {
+	suite.Suite
+
+	scope tally.TestScope
+	sut   *worktally.Collector
+}
+
+func TestTallyTestSuite(t *testing.T) {
+	suite.Run(t, new(TallyTestSuite))
+}
+
+func (s *TallyTestSuite) SetupTest() {
+	s.scope = tally.NewTestScope("app", map[string]string{})
+	s.sut = worktally.NewCollector(s.scope)
+}
+
+func (s *TallyTestSuite) TestCounter() {
+	// action.
+	s.sut.Counter("unit.save.success", map[string]string{"unit_type": "sql"}, 2)
+	s.sut.Counter("unit.save.success", map[string]string{"unit_type": "sql"}, 1)
+	s.sut.Counter("unit.insert", nil, 1)
+
+	// assert.
+	counters := s.scope.Snapshot().Counters()
+	s.Require().Contains(counters, "app.unit.save.success+unit_type=sql")
+	s.EqualValues(3, counters["app.unit.save.success+unit_type=sql"].Value())
+	s.Equal(map[string]string{"unit_type": "sql"}, counters["app.unit.save.success+unit_type=sql"].Tags())
+	s.Require().Contains(counters, "app.unit.insert+")
+	s.EqualValues(1, counters["app.unit.insert+"].Value())
+}
+
+func (s *TallyTestSuite) TestTimer() {
+	// action.
+	s.sut.Timer("unit.save", map[string]string{"unit_type": "sql"}, 250*time.Millisecond)
+
+	// assert.
+	timers := s.scope.Snapshot().Timers()
+	s.Require().Contains(timers, "app.unit.save+unit_type=sql")
+	s.Equal([]time.Duration{250 * time.Millisecond}, timers["app.unit.save+unit_type=sql"].Values())
+}
+
+func (s *TallyTestSuite) TestWithScope() {
+	// arrange.
+	foo := test.Foo{ID: 28}
+	fooType := work.TypeNameOf(foo)
+	noop := func(context.Context, work.UnitMapperContext, ...interface{}) error { return nil }
+	u, err := work.NewUnit(
+		work.UnitInsertFunc(fooType, noop),
+		work.UnitUpdateFunc(fooType, noop),
+		work.UnitDeleteFunc(fooType, noop),
+		worktally.WithScope(s.scope),
+	)
+	s.Require().NoError(err)
+	s.Require().NoError(u.Add(context.Background(), foo))
+
+	// action.
+	err = u.Save(context.Background())
+
+	// assert.
+	s.Require().NoError(err)
+	s.Contains(s.scope.Snapshot().Counters(), "app.unit.save.success+unit_type=best_effort")
+	s.Contains(s.scope.Snapshot().Counters(), "app.unit.insert+unit_type=best_effort")
+	s.Contains(s.scope.Snapshot().Timers(), "app.unit.save+unit_type=best_effort")
+}
@@ -0,0 +1,175 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package worktemporal provides helpers for saving work units within
+// Temporal activities, where the retry policy of the activity owns retrying
+// failed saves:
+//
+//	func (a *Activities) PlaceOrder(ctx context.Context, order Order) error {
+//		u, err := work.NewUnit(append(a.opts, worktemporal.Options()...)...)
+//		...
+//		return worktemporal.Save(ctx, u, "postgres", 10*time.Second)
+//	}
+package worktemporal
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/freerware/work/v4"
+	"go.temporal.io/sdk/activity"
+	"go.temporal.io/sdk/temporal"
+)
+
+// The types of the application errors provided for failed saves, which can
+// be listed as non-retryable error types within retry policies.
+const (
+	// ErrorTypeForbidden is the type of errors for forbidden operations.
+	ErrorTypeForbidden = "work.forbidden"
+	// ErrorTypeInvalid is the type of errors for invalid entities, entity
+	// types that are not allowed, and failed uniqueness checks.
+	ErrorTypeInvalid = "work.invalid"
+	// ErrorTypeConflict is the type of errors for unresolved conflicts.
+	ErrorTypeConflict = "work.conflict"
+	// ErrorTypeStale is the type of errors for stale entities.
+	ErrorTypeStale = "work.stale"
+	// ErrorTypeAmbiguous is the type of errors for commits with an unknown
+	// outcome and partially saved work units, which are not retried since
+	// retrying may apply changes more than once.
+	ErrorTypeAmbiguous = "work.ambiguous"
+	// ErrorTypeMisconfigured is the type of errors for work units that are
+	// missing data mappers, or that were already saved.
+	ErrorTypeMisconfigured = "work.misconfigured"
+)
+
+// nonRetryable provides the type of the provided error when it cannot
+// succeed when retried.
+func nonRetryable(err error) (string, bool) {
+	var (
+		forbidden  *work.UnitForbiddenError
+		uniqueness *work.UnitUniquenessError
+		partial    *work.UnitPartialSaveError
+	)
+	switch {
+	case errors.As(err, &forbidden):
+		return ErrorTypeForbidden, true
+	case errors.Is(err, work.ErrInvalidEntity),
+		errors.Is(err, work.ErrUntrackedEntity),
+		errors.Is(err, work.ErrTypeNotAllowed),
+		errors.As(err, &uniqueness):
+		return ErrorTypeInvalid, true
+	case errors.Is(err, work.ErrConflict):
+		return ErrorTypeConflict, true
+	case errors.Is(err, work.ErrCommitAmbiguous), errors.As(err, &partial):
+		return ErrorTypeAmbiguous, true
+	case errors.Is(err, work.ErrMissingDataMapper),
+		errors.Is(err, work.ErrNoDataMapper),
+		errors.Is(err, work.ErrUnitCompleted),
+		errors.Is(err, work.ErrUnitEvicted):
+		return ErrorTypeMisconfigured, true
+	}
+	return "", false
+}
+
+// Error converts the provided error returned when saving a work unit with
+// the driver registered with the provided name into a Temporal application
+// error. Failures that cannot succeed when retried, such as forbidden
+// operations, invalid entities, unresolved conflicts, ambiguous commits,
+// and constraint or permission failures as categorized by
+// work.ClassifyError, are non-retryable. Other failures are retryable, with
+// their error class as their type, such as "transient" or "timeout", while
+// stale entities are retryable with ErrorTypeStale so that the activity
+// reloads them. Nil is provided when the error is nil.
+func Error(driverName string, err error) error {
+	if err == nil {
+		return nil
+	}
+	if errType, ok := nonRetryable(err); ok {
+		return temporal.NewNonRetryableApplicationError(err.Error(), errType, err)
+	}
+	if errors.Is(err, work.ErrStaleEntity) {
+		return temporal.NewApplicationErrorWithCause(err.Error(), ErrorTypeStale, err)
+	}
+	class := work.ClassifyError(driverName, err)
+	if class == work.ErrorClassConstraint || class == work.ErrorClassPermission {
+		return temporal.NewNonRetryableApplicationError(err.Error(), class.String(), err)
+	}
+	return temporal.NewApplicationErrorWithCause(err.Error(), class.String(), err)
+}
+
+// heartbeat records a heartbeat for the activity with the provided context
+// with the provided details, if the context belongs to an activity.
+func heartbeat(ctx context.Context, details ...interface{}) {
+	if ctx != nil && activity.IsActivity(ctx) {
+		activity.RecordHeartbeat(ctx, details...)
+	}
+}
+
+// Heartbeats provides the options to record a heartbeat for the activity
+// saving the work unit as each phase of the save begins and completes, with
+// the name of the phase as the heartbeat details, such as "inserts".
+func Heartbeats() []work.UnitOption {
+	action := func(phase string) work.UnitAction {
+		return func(ctx work.UnitActionContext) {
+			heartbeat(ctx.Context, phase)
+		}
+	}
+	return []work.UnitOption{
+		work.UnitBeforeInsertsActions(action(string(work.UnitSavePhaseInserts))),
+		work.UnitAfterInsertsActions(action(string(work.UnitSavePhaseInserts))),
+		work.UnitBeforeUpsertsActions(action(string(work.UnitSavePhaseUpserts))),
+		work.UnitAfterUpsertsActions(action(string(work.UnitSavePhaseUpserts))),
+		work.UnitBeforeUpdatesActions(action(string(work.UnitSavePhaseUpdates))),
+		work.UnitAfterUpdatesActions(action(string(work.UnitSavePhaseUpdates))),
+		work.UnitBeforeDeletesActions(action(string(work.UnitSavePhaseDeletes))),
+		work.UnitAfterDeletesActions(action(string(work.UnitSavePhaseDeletes))),
+	}
+}
+
+// Options provides the options for work units saved within Temporal
+// activities. The retries of the work unit are disabled, since the retry
+// policy of the activity owns retrying, and heartbeats are recorded
+// throughout each save.
+func Options() []work.UnitOption {
+	return append([]work.UnitOption{work.UnitRetryAttempts(1)}, Heartbeats()...)
+}
+
+// Save saves the provided work unit within the activity with the provided
+// context, recording a heartbeat at the provided interval until the save
+// completes so that long saves do not exceed the heartbeat timeout of the
+// activity. The error returned is converted with Error for the driver
+// registered with the provided name.
+func Save(ctx context.Context, u work.Unit, driverName string, interval time.Duration) error {
+	if interval > 0 {
+		done := make(chan struct{})
+		defer close(done)
+		go func() {
+			ticker := time.NewTicker(interval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-done:
+					return
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					heartbeat(ctx, "saving")
+				}
+			}
+		}()
+	}
+	return Error(driverName, u.Save(ctx))
+}
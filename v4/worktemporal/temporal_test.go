@@ -0,0 +1,136 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worktemporal_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/freerware/work/v4"
+	"github.com/freerware/work/v4/internal/test"
+	"github.com/freerware/work/v4/worktemporal"
+	"github.com/stretchr/testify/suite"
+	"go.temporal.io/sdk/activity"
+	"go.temporal.io/sdk/converter"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/testsuite"
+	"go.temporal.io/sdk/workflow"
+)
+
+type TemporalTestSuite struct {
+	suite.Suite
+	testsuite.WorkflowTestSuite
+}
+
+func TestTemporalTestSuite(t *testing.T) {
+	suite.Run(t, new(TemporalTestSuite))
+}
+
+func (s *TemporalTestSuite) TestError() {
+	tests := []struct {
+		name         string
+		err          error
+		errType      string
+		nonRetryable bool
+	}{
+		{"Forbidden", &work.UnitForbiddenError{Err: errors.New("whoa")}, worktemporal.ErrorTypeForbidden, true},
+		{"Invalid", fmt.Errorf("wrapped: %w", work.ErrInvalidEntity), worktemporal.ErrorTypeInvalid, true},
+		{"Conflict", &work.UnitConflictError{}, worktemporal.ErrorTypeConflict, true},
+		{"Ambiguous", work.ErrCommitAmbiguous, worktemporal.ErrorTypeAmbiguous, true},
+		{"Completed", work.ErrUnitCompleted, worktemporal.ErrorTypeMisconfigured, true},
+		{"Stale", &work.UnitStaleEntityError{Entity: test.Foo{}}, worktemporal.ErrorTypeStale, false},
+		{"Constraint", &work.ClassifiedError{Class: work.ErrorClassConstraint, Err: errors.New("dup")}, "constraint", true},
+		{"Transient", &work.ClassifiedError{Class: work.ErrorClassTransient, Err: errors.New("conn")}, "transient", false},
+		{"Timeout", context.DeadlineExceeded, "timeout", false},
+		{"Unknown", errors.New("whoa"), "unknown", false},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			// action.
+			err := worktemporal.Error("", tt.err)
+
+			// assert.
+			var appErr *temporal.ApplicationError
+			s.Require().ErrorAs(err, &appErr)
+			s.Equal(tt.errType, appErr.Type())
+			s.Equal(tt.nonRetryable, appErr.NonRetryable())
+			s.ErrorIs(err, tt.err)
+		})
+	}
+}
+
+func (s *TemporalTestSuite) TestError_Nil() {
+	s.NoError(worktemporal.Error("", nil))
+}
+
+func (s *TemporalTestSuite) TestSave_Heartbeats() {
+	// arrange.
+	fooType := work.TypeNameOf(test.Foo{})
+	var attempts int
+	slow := func(context.Context, work.UnitMapperContext, ...interface{}) error {
+		attempts = attempts + 1
+		time.Sleep(50 * time.Millisecond)
+		return errors.New("whoa")
+	}
+	noop := func(context.Context, work.UnitMapperContext, ...interface{}) error { return nil }
+	save := func(ctx context.Context) error {
+		opts := append([]work.UnitOption{
+			work.UnitInsertFunc(fooType, slow),
+			work.UnitUpdateFunc(fooType, noop),
+			work.UnitDeleteFunc(fooType, noop),
+		}, worktemporal.Options()...)
+		u, err := work.NewUnit(opts...)
+		if err != nil {
+			return err
+		}
+		if err = u.Add(ctx, test.Foo{ID: 1}); err != nil {
+			return err
+		}
+		return worktemporal.Save(ctx, u, "", 10*time.Millisecond)
+	}
+	wf := func(ctx workflow.Context) error {
+		ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+			StartToCloseTimeout: time.Minute,
+			RetryPolicy:         &temporal.RetryPolicy{MaximumAttempts: 1},
+		})
+		return workflow.ExecuteActivity(ctx, save).Get(ctx, nil)
+	}
+	env := s.NewTestWorkflowEnvironment()
+	env.RegisterWorkflow(wf)
+	env.RegisterActivity(save)
+	var heartbeats []string
+	env.SetOnActivityHeartbeatListener(func(info *activity.Info, details converter.EncodedValues) {
+		var phase string
+		if details.HasValues() && details.Get(&phase) == nil {
+			heartbeats = append(heartbeats, phase)
+		}
+	})
+
+	// action.
+	env.ExecuteWorkflow(wf)
+
+	// assert.
+	s.True(env.IsWorkflowCompleted())
+	var appErr *temporal.ApplicationError
+	s.Require().ErrorAs(env.GetWorkflowError(), &appErr)
+	s.Equal("unknown", appErr.Type())
+	s.Equal(1, attempts)
+	s.Contains(heartbeats, string(work.UnitSavePhaseInserts))
+	s.Contains(heartbeats, "saving")
+}
@@ -0,0 +1,180 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worktest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/freerware/work/v4"
+)
+
+// concurrency is the number of goroutines that exercise clients
+// concurrently.
+const concurrency = 16
+
+// keys distinguishes the keys utilized by each run of a conformance suite,
+// so that suites can be run against shared caches.
+var keys int64
+
+// key provides a new key that is unique to the process.
+func key(name string) string {
+	return fmt.Sprintf("worktest-%s-%d", name, atomic.AddInt64(&keys, 1))
+}
+
+// RunCacheClientConformance verifies that the provided cache client behaves
+// consistently with the expectations of work units:
+//
+//   - retrieving an absent entry provides neither an entry nor an error;
+//   - retrieving an entry provides the entry most recently set;
+//   - deleting an entry, including an absent one, succeeds and removes it;
+//   - entries can be set, retrieved, and deleted concurrently;
+//   - errors due to a canceled context wrap context.Canceled.
+//
+// Clients implementing work.UnitCacheMultiClient are additionally verified
+// to set and delete many entries at once. Entries are strings, so that
+// clients serializing entries are supported.
+func RunCacheClientConformance(t *testing.T, client work.UnitCacheClient) {
+	ctx := context.Background()
+	get := func(t *testing.T, k string, expected interface{}) {
+		t.Helper()
+		entry, err := client.Get(ctx, k)
+		if err != nil {
+			t.Fatalf("Get(%q) returned error: %v", k, err)
+		}
+		if !reflect.DeepEqual(expected, entry) {
+			t.Fatalf("Get(%q) = %#v, expected %#v", k, entry, expected)
+		}
+	}
+	set := func(t *testing.T, k string, entry interface{}) {
+		t.Helper()
+		if err := client.Set(ctx, k, entry); err != nil {
+			t.Fatalf("Set(%q) returned error: %v", k, err)
+		}
+	}
+	del := func(t *testing.T, k string) {
+		t.Helper()
+		if err := client.Delete(ctx, k); err != nil {
+			t.Fatalf("Delete(%q) returned error: %v", k, err)
+		}
+	}
+
+	t.Run("GetAbsent", func(t *testing.T) {
+		get(t, key("absent"), nil)
+	})
+
+	t.Run("SetGet", func(t *testing.T) {
+		k := key("set")
+		set(t, k, "foo")
+		get(t, k, "foo")
+	})
+
+	t.Run("SetOverwrites", func(t *testing.T) {
+		k := key("overwrite")
+		set(t, k, "foo")
+		set(t, k, "bar")
+		get(t, k, "bar")
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		k := key("delete")
+		set(t, k, "foo")
+		del(t, k)
+		get(t, k, nil)
+	})
+
+	t.Run("DeleteAbsent", func(t *testing.T) {
+		del(t, key("delete-absent"))
+	})
+
+	t.Run("Concurrent", func(t *testing.T) {
+		var wg sync.WaitGroup
+		errs := make(chan error, concurrency)
+		ks := make([]string, concurrency)
+		for i := range ks {
+			ks[i] = key("concurrent")
+			wg.Add(1)
+			go func(k string, entry string) {
+				defer wg.Done()
+				if err := client.Set(ctx, k, entry); err != nil {
+					errs <- err
+					return
+				}
+				if _, err := client.Get(ctx, k); err != nil {
+					errs <- err
+					return
+				}
+				if err := client.Delete(ctx, k); err != nil {
+					errs <- err
+					return
+				}
+				if err := client.Set(ctx, k, entry); err != nil {
+					errs <- err
+				}
+			}(ks[i], fmt.Sprint(i))
+		}
+		wg.Wait()
+		close(errs)
+		for err := range errs {
+			t.Errorf("concurrent operation returned error: %v", err)
+		}
+		for i, k := range ks {
+			get(t, k, fmt.Sprint(i))
+		}
+	})
+
+	t.Run("CanceledContext", func(t *testing.T) {
+		canceled, cancel := context.WithCancel(ctx)
+		cancel()
+		k := key("canceled")
+		errs := map[string]error{"Set": client.Set(canceled, k, "foo")}
+		_, errs["Get"] = client.Get(canceled, k)
+		errs["Delete"] = client.Delete(canceled, k)
+		for op, err := range errs {
+			if err != nil && !errors.Is(err, context.Canceled) {
+				t.Errorf("%s with canceled context returned error not wrapping context.Canceled: %v", op, err)
+			}
+		}
+	})
+
+	multi, ok := client.(work.UnitCacheMultiClient)
+	if !ok {
+		return
+	}
+
+	t.Run("SetMultiDeleteMulti", func(t *testing.T) {
+		entries := map[string]interface{}{key("multi"): "foo", key("multi"): "bar"}
+		if err := multi.SetMulti(ctx, entries); err != nil {
+			t.Fatalf("SetMulti returned error: %v", err)
+		}
+		ks := make([]string, 0, len(entries))
+		for k, entry := range entries {
+			get(t, k, entry)
+			ks = append(ks, k)
+		}
+		if err := multi.DeleteMulti(ctx, append(ks, key("multi-absent"))); err != nil {
+			t.Fatalf("DeleteMulti returned error: %v", err)
+		}
+		for _, k := range ks {
+			get(t, k, nil)
+		}
+	})
+}
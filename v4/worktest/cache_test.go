@@ -0,0 +1,87 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worktest_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/freerware/work/v4/worktest"
+)
+
+// mapCacheClient represents a cache client storing entries within a map.
+type mapCacheClient struct {
+	mutex   sync.RWMutex
+	entries map[string]interface{}
+}
+
+func (c *mapCacheClient) Get(ctx context.Context, key string) (interface{}, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.entries[key], nil
+}
+
+func (c *mapCacheClient) Set(ctx context.Context, key string, entry interface{}) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.entries[key] = entry
+	return nil
+}
+
+func (c *mapCacheClient) Delete(ctx context.Context, key string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	delete(c.entries, key)
+	return nil
+}
+
+// mapCacheMultiClient represents a cache client storing entries within a
+// map, capable of setting and deleting many entries at once.
+type mapCacheMultiClient struct {
+	mapCacheClient
+}
+
+func (c *mapCacheMultiClient) SetMulti(ctx context.Context, entries map[string]interface{}) error {
+	for key, entry := range entries {
+		if err := c.Set(ctx, key, entry); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *mapCacheMultiClient) DeleteMulti(ctx context.Context, keys []string) error {
+	for _, key := range keys {
+		if err := c.Delete(ctx, key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestRunCacheClientConformance(t *testing.T) {
+	worktest.RunCacheClientConformance(t, &mapCacheClient{entries: map[string]interface{}{}})
+}
+
+func TestRunCacheClientConformance_MultiClient(t *testing.T) {
+	worktest.RunCacheClientConformance(t, &mapCacheMultiClient{
+		mapCacheClient{entries: map[string]interface{}{}},
+	})
+}
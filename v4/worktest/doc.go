@@ -0,0 +1,30 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package worktest provides conformance suites verifying that third-party
+// implementations of the extension points of work units, such as cache
+// clients and loggers, behave consistently with the expectations of work
+// units.
+//
+//	func TestRedisCacheClient(t *testing.T) {
+//		worktest.RunCacheClientConformance(t, newRedisCacheClient())
+//	}
+//
+//	func TestLogger(t *testing.T) {
+//		worktest.RunLoggerConformance(t, func(w io.Writer) work.UnitLogger {
+//			return newLoggerAdapter(w)
+//		})
+//	}
+package worktest
@@ -0,0 +1,216 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package integration
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"reflect"
+	"sync/atomic"
+	"testing"
+
+	"github.com/freerware/work/v4"
+)
+
+// errInjected represents the error injected to fail a save.
+var errInjected = errors.New("integration: injected failure")
+
+// Fixture describes the entities and data mappers certified by the
+// conformance suite.
+type Fixture struct {
+	// DB is the database the entities are persisted within.
+	DB *sql.DB
+	// Options are the unit options providing the data mappers for the
+	// entities, along with any other options necessary.
+	Options []work.UnitOption
+	// New creates a new entity that is distinct for each provided sequence.
+	New func(seq int) interface{}
+	// Modify provides the provided entity with one or more attributes
+	// modified.
+	Modify func(entity interface{}) interface{}
+	// Find retrieves the persisted state of the provided entity, indicating
+	// whether it is persisted.
+	Find func(ctx context.Context, db *sql.DB, entity interface{}) (interface{}, bool, error)
+}
+
+// failure represents the entity whose removal fails the save, after the
+// entities of the fixture have been inserted and updated.
+type failure struct{}
+
+// unit creates a new work unit for the fixture, whose removal of a failure
+// entity fails the provided number of times.
+func (f Fixture) unit(t *testing.T, failures int32) work.Unit {
+	t.Helper()
+	var attempts int32
+	fail := func(ctx context.Context, mCtx work.UnitMapperContext, entities ...interface{}) error {
+		if atomic.AddInt32(&attempts, 1) <= failures {
+			return errInjected
+		}
+		return nil
+	}
+	noop := func(ctx context.Context, mCtx work.UnitMapperContext, entities ...interface{}) error {
+		return nil
+	}
+	ft := work.TypeNameOf(failure{})
+	opts := append([]work.UnitOption{
+		work.UnitDB(f.DB),
+		work.UnitRetryAttempts(2),
+		work.UnitRetryDelay(0),
+		work.UnitInsertFunc(ft, noop),
+		work.UnitUpdateFunc(ft, noop),
+		work.UnitDeleteFunc(ft, fail),
+	}, f.Options...)
+	u, err := work.NewUnit(opts...)
+	if err != nil {
+		t.Fatalf("unable to create work unit: %v", err)
+	}
+	return u
+}
+
+// assertPersisted asserts the persisted state of the provided entity.
+func (f Fixture) assertPersisted(t *testing.T, entity, expected interface{}) {
+	t.Helper()
+	found, ok, err := f.Find(context.Background(), f.DB, entity)
+	if err != nil {
+		t.Fatalf("unable to find entity: %v", err)
+	}
+	switch {
+	case expected == nil && ok:
+		t.Errorf("expected entity %v to not be persisted, found %v", entity, found)
+	case expected != nil && !ok:
+		t.Errorf("expected entity %v to be persisted", expected)
+	case expected != nil && !reflect.DeepEqual(expected, found):
+		t.Errorf("expected entity %v to be persisted, found %v", expected, found)
+	}
+}
+
+// RunConformance certifies the data mappers described by the provided
+// fixture against the expectations of work units: entities are inserted,
+// updated, and deleted; the changes of failed saves are rolled back; and
+// saves retried after a failure do not duplicate changes.
+func RunConformance(t *testing.T, f Fixture) {
+	ctx := context.Background()
+	seq := 0
+	next := func() interface{} {
+		seq = seq + 1
+		return f.New(seq)
+	}
+
+	t.Run("Insert", func(t *testing.T) {
+		entity := next()
+		u := f.unit(t, 0)
+		if err := u.Add(ctx, entity); err != nil {
+			t.Fatalf("unable to add entity: %v", err)
+		}
+		if err := u.Save(ctx); err != nil {
+			t.Fatalf("unable to save work unit: %v", err)
+		}
+		f.assertPersisted(t, entity, entity)
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		entity := next()
+		u := f.unit(t, 0)
+		if err := u.Add(ctx, entity); err != nil {
+			t.Fatalf("unable to add entity: %v", err)
+		}
+		if err := u.Save(ctx); err != nil {
+			t.Fatalf("unable to save work unit: %v", err)
+		}
+		modified := f.Modify(entity)
+		u = f.unit(t, 0)
+		if err := u.Alter(ctx, modified); err != nil {
+			t.Fatalf("unable to alter entity: %v", err)
+		}
+		if err := u.Save(ctx); err != nil {
+			t.Fatalf("unable to save work unit: %v", err)
+		}
+		f.assertPersisted(t, entity, modified)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		entity := next()
+		u := f.unit(t, 0)
+		if err := u.Add(ctx, entity); err != nil {
+			t.Fatalf("unable to add entity: %v", err)
+		}
+		if err := u.Save(ctx); err != nil {
+			t.Fatalf("unable to save work unit: %v", err)
+		}
+		u = f.unit(t, 0)
+		if err := u.Remove(ctx, entity); err != nil {
+			t.Fatalf("unable to remove entity: %v", err)
+		}
+		if err := u.Save(ctx); err != nil {
+			t.Fatalf("unable to save work unit: %v", err)
+		}
+		f.assertPersisted(t, entity, nil)
+	})
+
+	t.Run("RollbackOnFailure", func(t *testing.T) {
+		existing, added := next(), next()
+		u := f.unit(t, 0)
+		if err := u.Add(ctx, existing); err != nil {
+			t.Fatalf("unable to add entity: %v", err)
+		}
+		if err := u.Save(ctx); err != nil {
+			t.Fatalf("unable to save work unit: %v", err)
+		}
+		u = f.unit(t, 2)
+		if err := u.Add(ctx, added); err != nil {
+			t.Fatalf("unable to add entity: %v", err)
+		}
+		if err := u.Alter(ctx, f.Modify(existing)); err != nil {
+			t.Fatalf("unable to alter entity: %v", err)
+		}
+		if err := u.Remove(ctx, failure{}); err != nil {
+			t.Fatalf("unable to remove entity: %v", err)
+		}
+		if err := u.Save(ctx); !errors.Is(err, errInjected) {
+			t.Fatalf("expected save to fail with injected failure, got %v", err)
+		}
+		f.assertPersisted(t, added, nil)
+		f.assertPersisted(t, existing, existing)
+	})
+
+	t.Run("IdempotentUnderRetry", func(t *testing.T) {
+		existing, added := next(), next()
+		u := f.unit(t, 0)
+		if err := u.Add(ctx, existing); err != nil {
+			t.Fatalf("unable to add entity: %v", err)
+		}
+		if err := u.Save(ctx); err != nil {
+			t.Fatalf("unable to save work unit: %v", err)
+		}
+		modified := f.Modify(existing)
+		u = f.unit(t, 1)
+		if err := u.Add(ctx, added); err != nil {
+			t.Fatalf("unable to add entity: %v", err)
+		}
+		if err := u.Alter(ctx, modified); err != nil {
+			t.Fatalf("unable to alter entity: %v", err)
+		}
+		if err := u.Remove(ctx, failure{}); err != nil {
+			t.Fatalf("unable to remove entity: %v", err)
+		}
+		if err := u.Save(ctx); err != nil {
+			t.Fatalf("unable to save work unit after retry: %v", err)
+		}
+		f.assertPersisted(t, added, added)
+		f.assertPersisted(t, existing, modified)
+	})
+}
@@ -0,0 +1,51 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package integration_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/freerware/work/v4"
+	"github.com/freerware/work/v4/worktest/integration"
+)
+
+func fixture(db *integration.Database) integration.Fixture {
+	m := integration.NewReferenceMapper(db.Dialect)
+	return integration.Fixture{
+		DB: db.DB,
+		Options: []work.UnitOption{work.UnitDataMappers(map[work.TypeName]work.UnitDataMapper{
+			work.TypeNameOf(integration.Entity{}): m,
+		})},
+		New: func(seq int) interface{} {
+			return integration.Entity{Key: fmt.Sprintf("entity-%d", seq), Name: "original"}
+		},
+		Modify: func(entity interface{}) interface{} {
+			e := entity.(integration.Entity)
+			e.Name = "modified"
+			return e
+		},
+		Find: m.Find,
+	}
+}
+
+func TestConformance_Postgres(t *testing.T) {
+	integration.RunConformance(t, fixture(integration.StartPostgres(t)))
+}
+
+func TestConformance_MySQL(t *testing.T) {
+	integration.RunConformance(t, fixture(integration.StartMySQL(t)))
+}
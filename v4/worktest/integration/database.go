@@ -0,0 +1,135 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package integration provides a harness certifying data mapper
+// implementations against the expectations of work units, such as
+// idempotency under retry and rollback correctness, leveraging databases
+// running within containers managed by testcontainers.
+//
+//	func TestMappers(t *testing.T) {
+//		db := integration.StartPostgres(t)
+//		integration.RunConformance(t, integration.Fixture{
+//			DB:      db.DB,
+//			Options: []work.UnitOption{work.UnitDataMappers(mappers)},
+//			New:     newOrder,
+//			Modify:  modifyOrder,
+//			Find:    findOrder,
+//		})
+//	}
+package integration
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	_ "github.com/go-sql-driver/mysql"
+	_ "github.com/lib/pq"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/mysql"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+// Dialect represents the SQL dialect of a database.
+type Dialect string
+
+const (
+	// Postgres represents the PostgreSQL dialect.
+	Postgres Dialect = "postgres"
+	// MySQL represents the MySQL dialect.
+	MySQL Dialect = "mysql"
+)
+
+// ReferenceSchema is the schema applied to each database started, which is
+// utilized by the reference data mapper.
+const ReferenceSchema = `CREATE TABLE work_entities (
+	id VARCHAR(64) PRIMARY KEY,
+	name VARCHAR(255) NOT NULL
+)`
+
+// Database represents a database running within a container.
+type Database struct {
+	// DB is the handle to the database.
+	DB *sql.DB
+	// Dialect is the SQL dialect of the database.
+	Dialect Dialect
+}
+
+// StartPostgres starts a PostgreSQL database within a container that is
+// terminated once the test completes, skipping the test when no container
+// runtime is available.
+func StartPostgres(t *testing.T) *Database {
+	t.Helper()
+	testcontainers.SkipIfProviderIsNotHealthy(t)
+	ctx := context.Background()
+	container, err := postgres.Run(ctx, "postgres:16-alpine",
+		postgres.WithDatabase("work"),
+		postgres.WithUsername("work"),
+		postgres.WithPassword("work"),
+		testcontainers.WithWaitStrategy(
+			wait.ForLog("database system is ready to accept connections").
+				WithOccurrence(2).
+				WithStartupTimeout(time.Minute)),
+	)
+	if err != nil {
+		t.Fatalf("unable to start postgres container: %v", err)
+	}
+	t.Cleanup(func() { container.Terminate(context.Background()) })
+	dsn, err := container.ConnectionString(ctx, "sslmode=disable")
+	if err != nil {
+		t.Fatalf("unable to determine postgres connection string: %v", err)
+	}
+	return open(t, "postgres", dsn, Postgres)
+}
+
+// StartMySQL starts a MySQL database within a container that is terminated
+// once the test completes, skipping the test when no container runtime is
+// available.
+func StartMySQL(t *testing.T) *Database {
+	t.Helper()
+	testcontainers.SkipIfProviderIsNotHealthy(t)
+	ctx := context.Background()
+	container, err := mysql.Run(ctx, "mysql:8.4",
+		mysql.WithDatabase("work"),
+		mysql.WithUsername("work"),
+		mysql.WithPassword("work"),
+	)
+	if err != nil {
+		t.Fatalf("unable to start mysql container: %v", err)
+	}
+	t.Cleanup(func() { container.Terminate(context.Background()) })
+	dsn, err := container.ConnectionString(ctx)
+	if err != nil {
+		t.Fatalf("unable to determine mysql connection string: %v", err)
+	}
+	return open(t, "mysql", dsn, MySQL)
+}
+
+// open opens the database with the provided driver and data source name,
+// and applies the reference schema.
+func open(t *testing.T, driver, dsn string, dialect Dialect) *Database {
+	t.Helper()
+	db, err := sql.Open(driver, dsn)
+	if err != nil {
+		t.Fatalf("unable to open %s database: %v", dialect, err)
+	}
+	t.Cleanup(func() { db.Close() })
+	if _, err = db.Exec(ReferenceSchema); err != nil {
+		t.Fatalf("unable to apply reference schema: %v", err)
+	}
+	return &Database{DB: db, Dialect: dialect}
+}
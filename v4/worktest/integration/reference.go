@@ -0,0 +1,111 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package integration
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/freerware/work/v4"
+)
+
+// Entity represents the entity persisted by the reference data mapper.
+type Entity struct {
+	Key  string
+	Name string
+}
+
+// ID retrieves the identity of the entity.
+func (e Entity) ID() interface{} {
+	return e.Key
+}
+
+// ReferenceMapper represents the reference data mapper, persisting entities
+// within the table created by the reference schema.
+type ReferenceMapper struct {
+	dialect Dialect
+}
+
+// NewReferenceMapper creates a new reference data mapper for the provided
+// dialect.
+func NewReferenceMapper(dialect Dialect) *ReferenceMapper {
+	return &ReferenceMapper{dialect: dialect}
+}
+
+// query rewrites the placeholders of the provided query for the dialect.
+func (m *ReferenceMapper) query(q string, placeholders int) string {
+	args := make([]interface{}, placeholders)
+	for i := range args {
+		if m.dialect == Postgres {
+			args[i] = fmt.Sprintf("$%d", i+1)
+		} else {
+			args[i] = "?"
+		}
+	}
+	return fmt.Sprintf(q, args...)
+}
+
+// Insert creates the provided entities.
+func (m *ReferenceMapper) Insert(ctx context.Context, mCtx work.UnitMapperContext, entities ...interface{}) error {
+	q := m.query("INSERT INTO work_entities (id, name) VALUES (%s, %s)", 2)
+	for _, entity := range entities {
+		e := entity.(Entity)
+		if _, err := mCtx.Tx.ExecContext(ctx, q, e.Key, e.Name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Update modifies the provided entities.
+func (m *ReferenceMapper) Update(ctx context.Context, mCtx work.UnitMapperContext, entities ...interface{}) error {
+	q := m.query("UPDATE work_entities SET name = %s WHERE id = %s", 2)
+	for _, entity := range entities {
+		e := entity.(Entity)
+		if _, err := mCtx.Tx.ExecContext(ctx, q, e.Name, e.Key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Delete removes the provided entities.
+func (m *ReferenceMapper) Delete(ctx context.Context, mCtx work.UnitMapperContext, entities ...interface{}) error {
+	q := m.query("DELETE FROM work_entities WHERE id = %s", 1)
+	for _, entity := range entities {
+		e := entity.(Entity)
+		if _, err := mCtx.Tx.ExecContext(ctx, q, e.Key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Find retrieves the persisted state of the provided entity, indicating
+// whether it is persisted.
+func (m *ReferenceMapper) Find(ctx context.Context, db *sql.DB, entity interface{}) (interface{}, bool, error) {
+	q := m.query("SELECT id, name FROM work_entities WHERE id = %s", 1)
+	var found Entity
+	err := db.QueryRowContext(ctx, q, entity.(Entity).Key).Scan(&found.Key, &found.Name)
+	if err == sql.ErrNoRows {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return found, true, nil
+}
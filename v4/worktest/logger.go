@@ -0,0 +1,158 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worktest
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/freerware/work/v4"
+)
+
+// syncBuffer represents a buffer that is safe for concurrent use.
+type syncBuffer struct {
+	mutex sync.Mutex
+	b     bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return b.b.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return b.b.String()
+}
+
+// RunLoggerConformance verifies that the loggers created by the provided
+// function behave consistently with the expectations of work units:
+//
+//   - messages are written at each level along with their key/value pairs,
+//     rather than the arguments being rendered as a slice;
+//   - nil values, keys lacking a value, and non-string keys do not panic;
+//   - messages can be written concurrently.
+//
+// The provided function must create a logger writing to the provided writer
+// at a level such that debug messages are written.
+func RunLoggerConformance(t *testing.T, newLogger func(w io.Writer) work.UnitLogger) {
+	type level struct {
+		name string
+		log  func(work.UnitLogger) func(string, ...any)
+	}
+	levels := []level{
+		{name: "Debug", log: func(l work.UnitLogger) func(string, ...any) { return l.Debug }},
+		{name: "Info", log: func(l work.UnitLogger) func(string, ...any) { return l.Info }},
+		{name: "Warn", log: func(l work.UnitLogger) func(string, ...any) { return l.Warn }},
+		{name: "Error", log: func(l work.UnitLogger) func(string, ...any) { return l.Error }},
+	}
+	logs := func(t *testing.T, f func(work.UnitLogger)) (out string) {
+		t.Helper()
+		b := &syncBuffer{}
+		defer func() {
+			if r := recover(); r != nil {
+				t.Fatalf("logger panicked: %v", r)
+			}
+			out = b.String()
+		}()
+		f(newLogger(b))
+		return
+	}
+
+	for _, lvl := range levels {
+		lvl := lvl
+		t.Run(lvl.name+"KeyValuePairs", func(t *testing.T) {
+			msg := "worktest " + strings.ToLower(lvl.name) + " message"
+			out := logs(t, func(l work.UnitLogger) {
+				lvl.log(l)(msg, "typeName", "worktest.Foo", "count", 28)
+			})
+			for _, expected := range []string{msg, "typeName", "worktest.Foo", "count", "28"} {
+				if !strings.Contains(out, expected) {
+					t.Errorf("expected output to contain %q, got %q", expected, out)
+				}
+			}
+			if strings.Contains(out, "["+msg) {
+				t.Errorf("expected arguments to not be rendered as a slice, got %q", out)
+			}
+			if strings.Contains(out, "%!") {
+				t.Errorf("expected arguments to be formatted, got %q", out)
+			}
+		})
+	}
+
+	t.Run("NilSafety", func(t *testing.T) {
+		var nilErr error
+		args := [][]any{
+			nil,
+			{"error", nil},
+			{"error", nilErr},
+			{"entity", (*struct{})(nil)},
+			{"dangling"},
+			{28, "value"},
+			{nil, nil},
+		}
+		for _, a := range args {
+			out := logs(t, func(l work.UnitLogger) {
+				for _, lvl := range levels {
+					lvl.log(l)("worktest nil message", a...)
+				}
+			})
+			if !strings.Contains(out, "worktest nil message") {
+				t.Errorf("expected message to be written for arguments %#v, got %q", a, out)
+			}
+		}
+	})
+
+	t.Run("ErrorValues", func(t *testing.T) {
+		out := logs(t, func(l work.UnitLogger) {
+			l.Error("worktest error message", "error", errors.New("worktest failure"))
+		})
+		if !strings.Contains(out, "worktest failure") {
+			t.Errorf("expected output to contain the error, got %q", out)
+		}
+	})
+
+	t.Run("Concurrent", func(t *testing.T) {
+		const messages = 10
+		out := logs(t, func(l work.UnitLogger) {
+			var wg sync.WaitGroup
+			for i := 0; i < concurrency; i++ {
+				wg.Add(1)
+				go func(i int) {
+					defer wg.Done()
+					for j := 0; j < messages; j++ {
+						l.Info(fmt.Sprintf("worktest concurrent message %d-%d", i, j), "goroutine", i)
+					}
+				}(i)
+			}
+			wg.Wait()
+		})
+		for i := 0; i < concurrency; i++ {
+			for j := 0; j < messages; j++ {
+				if msg := fmt.Sprintf("worktest concurrent message %d-%d", i, j); !strings.Contains(out, msg) {
+					t.Errorf("expected output to contain %q", msg)
+				}
+			}
+		}
+	})
+}
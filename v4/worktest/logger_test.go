@@ -0,0 +1,62 @@
+/* Copyright 2025 Freerware
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worktest_test
+
+import (
+	"io"
+	"log"
+	"log/slog"
+	"testing"
+
+	"github.com/freerware/work/v4"
+	"github.com/freerware/work/v4/internal/adapters"
+	"github.com/freerware/work/v4/worktest"
+	"github.com/sirupsen/logrus"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestRunLoggerConformance_Zap(t *testing.T) {
+	worktest.RunLoggerConformance(t, func(w io.Writer) work.UnitLogger {
+		core := zapcore.NewCore(
+			zapcore.NewJSONEncoder(zap.NewDevelopmentEncoderConfig()),
+			zapcore.AddSync(w),
+			zapcore.DebugLevel)
+		return adapters.NewZapLogger(zap.New(core, zap.Development()))
+	})
+}
+
+func TestRunLoggerConformance_Standard(t *testing.T) {
+	worktest.RunLoggerConformance(t, func(w io.Writer) work.UnitLogger {
+		return adapters.NewStandardLogger(log.New(w, "", log.LstdFlags))
+	})
+}
+
+func TestRunLoggerConformance_Structured(t *testing.T) {
+	worktest.RunLoggerConformance(t, func(w io.Writer) work.UnitLogger {
+		return adapters.NewStructuredLogger(slog.New(
+			slog.NewTextHandler(w, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	})
+}
+
+func TestRunLoggerConformance_Logrus(t *testing.T) {
+	worktest.RunLoggerConformance(t, func(w io.Writer) work.UnitLogger {
+		l := logrus.New()
+		l.SetOutput(w)
+		l.SetLevel(logrus.DebugLevel)
+		return adapters.NewLogrusLogger(l)
+	})
+}